@@ -26,7 +26,11 @@ type AdminServiceClient interface {
 	UpdateMarketHourConfig(ctx context.Context, in *UpdateMarketHourConfigRequest, opts ...grpc.CallOption) (*UpdateMarketHourConfigResponse, error)
 	GetTxRequestQueue(ctx context.Context, in *GetTxRequestQueueRequest, opts ...grpc.CallOption) (*GetTxRequestQueueResponse, error)
 	DeleteTxRequests(ctx context.Context, in *DeleteTxRequestsRequest, opts ...grpc.CallOption) (*DeleteTxRequestsResponse, error)
+	EstimateNextRound(ctx context.Context, in *EstimateNextRoundRequest, opts ...grpc.CallOption) (*EstimateNextRoundResponse, error)
 	Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error)
+	GetAccountingReport(ctx context.Context, in *GetAccountingReportRequest, opts ...grpc.CallOption) (*GetAccountingReportResponse, error)
+	GetLiquidityStatus(ctx context.Context, in *GetLiquidityStatusRequest, opts ...grpc.CallOption) (*GetLiquidityStatusResponse, error)
+	ListPendingSweeps(ctx context.Context, in *ListPendingSweepsRequest, opts ...grpc.CallOption) (*ListPendingSweepsResponse, error)
 }
 
 type adminServiceClient struct {
@@ -109,6 +113,15 @@ func (c *adminServiceClient) DeleteTxRequests(ctx context.Context, in *DeleteTxR
 	return out, nil
 }
 
+func (c *adminServiceClient) EstimateNextRound(ctx context.Context, in *EstimateNextRoundRequest, opts ...grpc.CallOption) (*EstimateNextRoundResponse, error) {
+	out := new(EstimateNextRoundResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/EstimateNextRound", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *adminServiceClient) Withdraw(ctx context.Context, in *WithdrawRequest, opts ...grpc.CallOption) (*WithdrawResponse, error) {
 	out := new(WithdrawResponse)
 	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/Withdraw", in, out, opts...)
@@ -118,6 +131,33 @@ func (c *adminServiceClient) Withdraw(ctx context.Context, in *WithdrawRequest,
 	return out, nil
 }
 
+func (c *adminServiceClient) GetAccountingReport(ctx context.Context, in *GetAccountingReportRequest, opts ...grpc.CallOption) (*GetAccountingReportResponse, error) {
+	out := new(GetAccountingReportResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetAccountingReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetLiquidityStatus(ctx context.Context, in *GetLiquidityStatusRequest, opts ...grpc.CallOption) (*GetLiquidityStatusResponse, error) {
+	out := new(GetLiquidityStatusResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/GetLiquidityStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListPendingSweeps(ctx context.Context, in *ListPendingSweepsRequest, opts ...grpc.CallOption) (*ListPendingSweepsResponse, error) {
+	out := new(ListPendingSweepsResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.AdminService/ListPendingSweeps", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AdminServiceServer is the server API for AdminService service.
 // All implementations should embed UnimplementedAdminServiceServer
 // for forward compatibility
@@ -130,7 +170,11 @@ type AdminServiceServer interface {
 	UpdateMarketHourConfig(context.Context, *UpdateMarketHourConfigRequest) (*UpdateMarketHourConfigResponse, error)
 	GetTxRequestQueue(context.Context, *GetTxRequestQueueRequest) (*GetTxRequestQueueResponse, error)
 	DeleteTxRequests(context.Context, *DeleteTxRequestsRequest) (*DeleteTxRequestsResponse, error)
+	EstimateNextRound(context.Context, *EstimateNextRoundRequest) (*EstimateNextRoundResponse, error)
 	Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error)
+	GetAccountingReport(context.Context, *GetAccountingReportRequest) (*GetAccountingReportResponse, error)
+	GetLiquidityStatus(context.Context, *GetLiquidityStatusRequest) (*GetLiquidityStatusResponse, error)
+	ListPendingSweeps(context.Context, *ListPendingSweepsRequest) (*ListPendingSweepsResponse, error)
 }
 
 // UnimplementedAdminServiceServer should be embedded to have forward compatible implementations.
@@ -161,9 +205,21 @@ func (UnimplementedAdminServiceServer) GetTxRequestQueue(context.Context, *GetTx
 func (UnimplementedAdminServiceServer) DeleteTxRequests(context.Context, *DeleteTxRequestsRequest) (*DeleteTxRequestsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteTxRequests not implemented")
 }
+func (UnimplementedAdminServiceServer) EstimateNextRound(context.Context, *EstimateNextRoundRequest) (*EstimateNextRoundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateNextRound not implemented")
+}
 func (UnimplementedAdminServiceServer) Withdraw(context.Context, *WithdrawRequest) (*WithdrawResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Withdraw not implemented")
 }
+func (UnimplementedAdminServiceServer) GetAccountingReport(context.Context, *GetAccountingReportRequest) (*GetAccountingReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAccountingReport not implemented")
+}
+func (UnimplementedAdminServiceServer) GetLiquidityStatus(context.Context, *GetLiquidityStatusRequest) (*GetLiquidityStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLiquidityStatus not implemented")
+}
+func (UnimplementedAdminServiceServer) ListPendingSweeps(context.Context, *ListPendingSweepsRequest) (*ListPendingSweepsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingSweeps not implemented")
+}
 
 // UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to AdminServiceServer will
@@ -320,6 +376,24 @@ func _AdminService_DeleteTxRequests_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_EstimateNextRound_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EstimateNextRoundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).EstimateNextRound(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/EstimateNextRound",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).EstimateNextRound(ctx, req.(*EstimateNextRoundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AdminService_Withdraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(WithdrawRequest)
 	if err := dec(in); err != nil {
@@ -338,6 +412,60 @@ func _AdminService_Withdraw_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AdminService_GetAccountingReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountingReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetAccountingReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetAccountingReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetAccountingReport(ctx, req.(*GetAccountingReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetLiquidityStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLiquidityStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetLiquidityStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/GetLiquidityStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetLiquidityStatus(ctx, req.(*GetLiquidityStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListPendingSweeps_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPendingSweepsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListPendingSweeps(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.AdminService/ListPendingSweeps",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListPendingSweeps(ctx, req.(*ListPendingSweepsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -377,10 +505,26 @@ var AdminService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteTxRequests",
 			Handler:    _AdminService_DeleteTxRequests_Handler,
 		},
+		{
+			MethodName: "EstimateNextRound",
+			Handler:    _AdminService_EstimateNextRound_Handler,
+		},
 		{
 			MethodName: "Withdraw",
 			Handler:    _AdminService_Withdraw_Handler,
 		},
+		{
+			MethodName: "GetAccountingReport",
+			Handler:    _AdminService_GetAccountingReport_Handler,
+		},
+		{
+			MethodName: "GetLiquidityStatus",
+			Handler:    _AdminService_GetLiquidityStatus_Handler,
+		},
+		{
+			MethodName: "ListPendingSweeps",
+			Handler:    _AdminService_ListPendingSweeps_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "ark/v1/admin.proto",
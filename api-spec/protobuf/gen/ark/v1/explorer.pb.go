@@ -27,6 +27,9 @@ type GetRoundRequest struct {
 	unknownFields protoimpl.UnknownFields
 
 	Txid string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	// If true, the response includes the raw signed round tx as broadcasted
+	// onchain, the round must be finalized.
+	IncludeRawTx bool `protobuf:"varint,2,opt,name=include_raw_tx,json=includeRawTx,proto3" json:"include_raw_tx,omitempty"`
 }
 
 func (x *GetRoundRequest) Reset() {
@@ -68,31 +71,603 @@ func (x *GetRoundRequest) GetTxid() string {
 	return ""
 }
 
+func (x *GetRoundRequest) GetIncludeRawTx() bool {
+	if x != nil {
+		return x.IncludeRawTx
+	}
+	return false
+}
+
 type GetRoundResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Round *Round `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	Round *Round `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	// Hex encoded raw round tx, set only if requested.
+	RawRoundTx string `protobuf:"bytes,2,opt,name=raw_round_tx,json=rawRoundTx,proto3" json:"raw_round_tx,omitempty"`
+}
+
+func (x *GetRoundResponse) Reset() {
+	*x = GetRoundResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoundResponse) ProtoMessage() {}
+
+func (x *GetRoundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoundResponse.ProtoReflect.Descriptor instead.
+func (*GetRoundResponse) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *GetRoundResponse) GetRound() *Round {
+	if x != nil {
+		return x.Round
+	}
+	return nil
+}
+
+func (x *GetRoundResponse) GetRawRoundTx() string {
+	if x != nil {
+		return x.RawRoundTx
+	}
+	return ""
+}
+
+type GetRoundByIdRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetRoundByIdRequest) Reset() {
+	*x = GetRoundByIdRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoundByIdRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoundByIdRequest) ProtoMessage() {}
+
+func (x *GetRoundByIdRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoundByIdRequest.ProtoReflect.Descriptor instead.
+func (*GetRoundByIdRequest) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetRoundByIdRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetRoundByIdResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Round *Round `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+}
+
+func (x *GetRoundByIdResponse) Reset() {
+	*x = GetRoundByIdResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoundByIdResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoundByIdResponse) ProtoMessage() {}
+
+func (x *GetRoundByIdResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoundByIdResponse.ProtoReflect.Descriptor instead.
+func (*GetRoundByIdResponse) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetRoundByIdResponse) GetRound() *Round {
+	if x != nil {
+		return x.Round
+	}
+	return nil
+}
+
+type GetRoundTreeBranchRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RoundTxid string    `protobuf:"bytes,1,opt,name=round_txid,json=roundTxid,proto3" json:"round_txid,omitempty"`
+	Vtxo      *Outpoint `protobuf:"bytes,2,opt,name=vtxo,proto3" json:"vtxo,omitempty"`
+}
+
+func (x *GetRoundTreeBranchRequest) Reset() {
+	*x = GetRoundTreeBranchRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoundTreeBranchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoundTreeBranchRequest) ProtoMessage() {}
+
+func (x *GetRoundTreeBranchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoundTreeBranchRequest.ProtoReflect.Descriptor instead.
+func (*GetRoundTreeBranchRequest) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *GetRoundTreeBranchRequest) GetRoundTxid() string {
+	if x != nil {
+		return x.RoundTxid
+	}
+	return ""
+}
+
+func (x *GetRoundTreeBranchRequest) GetVtxo() *Outpoint {
+	if x != nil {
+		return x.Vtxo
+	}
+	return nil
+}
+
+type GetRoundTreeBranchResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// The ancestors of the vtxo, one node per level from the root to the leaf.
+	Branch *Tree `protobuf:"bytes,1,opt,name=branch,proto3" json:"branch,omitempty"`
+}
+
+func (x *GetRoundTreeBranchResponse) Reset() {
+	*x = GetRoundTreeBranchResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetRoundTreeBranchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoundTreeBranchResponse) ProtoMessage() {}
+
+func (x *GetRoundTreeBranchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoundTreeBranchResponse.ProtoReflect.Descriptor instead.
+func (*GetRoundTreeBranchResponse) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetRoundTreeBranchResponse) GetBranch() *Tree {
+	if x != nil {
+		return x.Branch
+	}
+	return nil
+}
+
+type ListRoundsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Unix timestamp from which the rounds ended, 0 for all rounds.
+	After int64 `protobuf:"varint,1,opt,name=after,proto3" json:"after,omitempty"`
+	// Max number of rounds of the page, the server default is used if zero.
+	Limit uint32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Token of the page to fetch, empty for the first one.
+	PageToken string `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+}
+
+func (x *ListRoundsRequest) Reset() {
+	*x = ListRoundsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRoundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoundsRequest) ProtoMessage() {}
+
+func (x *ListRoundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoundsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoundsRequest) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListRoundsRequest) GetAfter() int64 {
+	if x != nil {
+		return x.After
+	}
+	return 0
+}
+
+func (x *ListRoundsRequest) GetLimit() uint32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListRoundsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListRoundsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Rounds []*RoundSummary `protobuf:"bytes,1,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	// Token of the next page, empty if this is the last one.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *ListRoundsResponse) Reset() {
+	*x = ListRoundsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListRoundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoundsResponse) ProtoMessage() {}
+
+func (x *ListRoundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoundsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoundsResponse) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListRoundsResponse) GetRounds() []*RoundSummary {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *ListRoundsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type RoundSummary struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Txid         string `protobuf:"bytes,1,opt,name=txid,proto3" json:"txid,omitempty"`
+	Start        int64  `protobuf:"varint,2,opt,name=start,proto3" json:"start,omitempty"`
+	End          int64  `protobuf:"varint,3,opt,name=end,proto3" json:"end,omitempty"`
+	InputsCount  uint32 `protobuf:"varint,4,opt,name=inputs_count,json=inputsCount,proto3" json:"inputs_count,omitempty"`
+	OutputsCount uint32 `protobuf:"varint,5,opt,name=outputs_count,json=outputsCount,proto3" json:"outputs_count,omitempty"`
+	// Amount in sats of the vtxos created by the round.
+	TotalAmount uint64 `protobuf:"varint,6,opt,name=total_amount,json=totalAmount,proto3" json:"total_amount,omitempty"`
+}
+
+func (x *RoundSummary) Reset() {
+	*x = RoundSummary{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RoundSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoundSummary) ProtoMessage() {}
+
+func (x *RoundSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoundSummary.ProtoReflect.Descriptor instead.
+func (*RoundSummary) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *RoundSummary) GetTxid() string {
+	if x != nil {
+		return x.Txid
+	}
+	return ""
+}
+
+func (x *RoundSummary) GetStart() int64 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *RoundSummary) GetEnd() int64 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *RoundSummary) GetInputsCount() uint32 {
+	if x != nil {
+		return x.InputsCount
+	}
+	return 0
+}
+
+func (x *RoundSummary) GetOutputsCount() uint32 {
+	if x != nil {
+		return x.OutputsCount
+	}
+	return 0
+}
+
+func (x *RoundSummary) GetTotalAmount() uint64 {
+	if x != nil {
+		return x.TotalAmount
+	}
+	return 0
+}
+
+type ListVtxosRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *ListVtxosRequest) Reset() {
+	*x = ListVtxosRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVtxosRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVtxosRequest) ProtoMessage() {}
+
+func (x *ListVtxosRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVtxosRequest.ProtoReflect.Descriptor instead.
+func (*ListVtxosRequest) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ListVtxosRequest) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ListVtxosResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SpendableVtxos []*Vtxo `protobuf:"bytes,1,rep,name=spendable_vtxos,json=spendableVtxos,proto3" json:"spendable_vtxos,omitempty"`
+	SpentVtxos     []*Vtxo `protobuf:"bytes,2,rep,name=spent_vtxos,json=spentVtxos,proto3" json:"spent_vtxos,omitempty"`
+}
+
+func (x *ListVtxosResponse) Reset() {
+	*x = ListVtxosResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ark_v1_explorer_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListVtxosResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListVtxosResponse) ProtoMessage() {}
+
+func (x *ListVtxosResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListVtxosResponse.ProtoReflect.Descriptor instead.
+func (*ListVtxosResponse) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListVtxosResponse) GetSpendableVtxos() []*Vtxo {
+	if x != nil {
+		return x.SpendableVtxos
+	}
+	return nil
+}
+
+func (x *ListVtxosResponse) GetSpentVtxos() []*Vtxo {
+	if x != nil {
+		return x.SpentVtxos
+	}
+	return nil
+}
+
+type ListVtxosForAddressesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 }
 
-func (x *GetRoundResponse) Reset() {
-	*x = GetRoundResponse{}
+func (x *ListVtxosForAddressesRequest) Reset() {
+	*x = ListVtxosForAddressesRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ark_v1_explorer_proto_msgTypes[1]
+		mi := &file_ark_v1_explorer_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRoundResponse) String() string {
+func (x *ListVtxosForAddressesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRoundResponse) ProtoMessage() {}
+func (*ListVtxosForAddressesRequest) ProtoMessage() {}
 
-func (x *GetRoundResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ark_v1_explorer_proto_msgTypes[1]
+func (x *ListVtxosForAddressesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -103,43 +678,44 @@ func (x *GetRoundResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRoundResponse.ProtoReflect.Descriptor instead.
-func (*GetRoundResponse) Descriptor() ([]byte, []int) {
-	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use ListVtxosForAddressesRequest.ProtoReflect.Descriptor instead.
+func (*ListVtxosForAddressesRequest) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *GetRoundResponse) GetRound() *Round {
+func (x *ListVtxosForAddressesRequest) GetAddresses() []string {
 	if x != nil {
-		return x.Round
+		return x.Addresses
 	}
 	return nil
 }
 
-type GetRoundByIdRequest struct {
+type ListVtxosForAddressesResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Vtxos grouped by address, in the same order of the request.
+	Vtxos []*AddressVtxos `protobuf:"bytes,1,rep,name=vtxos,proto3" json:"vtxos,omitempty"`
 }
 
-func (x *GetRoundByIdRequest) Reset() {
-	*x = GetRoundByIdRequest{}
+func (x *ListVtxosForAddressesResponse) Reset() {
+	*x = ListVtxosForAddressesResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ark_v1_explorer_proto_msgTypes[2]
+		mi := &file_ark_v1_explorer_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRoundByIdRequest) String() string {
+func (x *ListVtxosForAddressesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRoundByIdRequest) ProtoMessage() {}
+func (*ListVtxosForAddressesResponse) ProtoMessage() {}
 
-func (x *GetRoundByIdRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ark_v1_explorer_proto_msgTypes[2]
+func (x *ListVtxosForAddressesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -150,43 +726,45 @@ func (x *GetRoundByIdRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRoundByIdRequest.ProtoReflect.Descriptor instead.
-func (*GetRoundByIdRequest) Descriptor() ([]byte, []int) {
-	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use ListVtxosForAddressesResponse.ProtoReflect.Descriptor instead.
+func (*ListVtxosForAddressesResponse) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *GetRoundByIdRequest) GetId() string {
+func (x *ListVtxosForAddressesResponse) GetVtxos() []*AddressVtxos {
 	if x != nil {
-		return x.Id
+		return x.Vtxos
 	}
-	return ""
+	return nil
 }
 
-type GetRoundByIdResponse struct {
+type AddressVtxos struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Round *Round `protobuf:"bytes,1,opt,name=round,proto3" json:"round,omitempty"`
+	Address        string  `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	SpendableVtxos []*Vtxo `protobuf:"bytes,2,rep,name=spendable_vtxos,json=spendableVtxos,proto3" json:"spendable_vtxos,omitempty"`
+	SpentVtxos     []*Vtxo `protobuf:"bytes,3,rep,name=spent_vtxos,json=spentVtxos,proto3" json:"spent_vtxos,omitempty"`
 }
 
-func (x *GetRoundByIdResponse) Reset() {
-	*x = GetRoundByIdResponse{}
+func (x *AddressVtxos) Reset() {
+	*x = AddressVtxos{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ark_v1_explorer_proto_msgTypes[3]
+		mi := &file_ark_v1_explorer_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetRoundByIdResponse) String() string {
+func (x *AddressVtxos) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRoundByIdResponse) ProtoMessage() {}
+func (*AddressVtxos) ProtoMessage() {}
 
-func (x *GetRoundByIdResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ark_v1_explorer_proto_msgTypes[3]
+func (x *AddressVtxos) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -197,43 +775,66 @@ func (x *GetRoundByIdResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRoundByIdResponse.ProtoReflect.Descriptor instead.
-func (*GetRoundByIdResponse) Descriptor() ([]byte, []int) {
-	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use AddressVtxos.ProtoReflect.Descriptor instead.
+func (*AddressVtxos) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *GetRoundByIdResponse) GetRound() *Round {
+func (x *AddressVtxos) GetAddress() string {
 	if x != nil {
-		return x.Round
+		return x.Address
+	}
+	return ""
+}
+
+func (x *AddressVtxos) GetSpendableVtxos() []*Vtxo {
+	if x != nil {
+		return x.SpendableVtxos
 	}
 	return nil
 }
 
-type ListVtxosRequest struct {
+func (x *AddressVtxos) GetSpentVtxos() []*Vtxo {
+	if x != nil {
+		return x.SpentVtxos
+	}
+	return nil
+}
+
+type ListVtxosPagedRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Address string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Addresses []string `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
+	// If neither spendable nor spent is set, all vtxos are returned.
+	Spendable bool `protobuf:"varint,2,opt,name=spendable,proto3" json:"spendable,omitempty"`
+	Spent     bool `protobuf:"varint,3,opt,name=spent,proto3" json:"spent,omitempty"`
+	// Max number of vtxos of the page, the server default is used if zero.
+	PageSize uint32 `protobuf:"varint,4,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// Token of the page to fetch, empty for the first one.
+	PageToken string `protobuf:"bytes,5,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	MinAmount uint64 `protobuf:"varint,6,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	RoundTxid string `protobuf:"bytes,7,opt,name=round_txid,json=roundTxid,proto3" json:"round_txid,omitempty"`
 }
 
-func (x *ListVtxosRequest) Reset() {
-	*x = ListVtxosRequest{}
+func (x *ListVtxosPagedRequest) Reset() {
+	*x = ListVtxosPagedRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ark_v1_explorer_proto_msgTypes[4]
+		mi := &file_ark_v1_explorer_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListVtxosRequest) String() string {
+func (x *ListVtxosPagedRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVtxosRequest) ProtoMessage() {}
+func (*ListVtxosPagedRequest) ProtoMessage() {}
 
-func (x *ListVtxosRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ark_v1_explorer_proto_msgTypes[4]
+func (x *ListVtxosPagedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -244,44 +845,87 @@ func (x *ListVtxosRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVtxosRequest.ProtoReflect.Descriptor instead.
-func (*ListVtxosRequest) Descriptor() ([]byte, []int) {
-	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ListVtxosPagedRequest.ProtoReflect.Descriptor instead.
+func (*ListVtxosPagedRequest) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *ListVtxosRequest) GetAddress() string {
+func (x *ListVtxosPagedRequest) GetAddresses() []string {
 	if x != nil {
-		return x.Address
+		return x.Addresses
+	}
+	return nil
+}
+
+func (x *ListVtxosPagedRequest) GetSpendable() bool {
+	if x != nil {
+		return x.Spendable
+	}
+	return false
+}
+
+func (x *ListVtxosPagedRequest) GetSpent() bool {
+	if x != nil {
+		return x.Spent
+	}
+	return false
+}
+
+func (x *ListVtxosPagedRequest) GetPageSize() uint32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListVtxosPagedRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
 	}
 	return ""
 }
 
-type ListVtxosResponse struct {
+func (x *ListVtxosPagedRequest) GetMinAmount() uint64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *ListVtxosPagedRequest) GetRoundTxid() string {
+	if x != nil {
+		return x.RoundTxid
+	}
+	return ""
+}
+
+type ListVtxosPagedResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	SpendableVtxos []*Vtxo `protobuf:"bytes,1,rep,name=spendable_vtxos,json=spendableVtxos,proto3" json:"spendable_vtxos,omitempty"`
-	SpentVtxos     []*Vtxo `protobuf:"bytes,2,rep,name=spent_vtxos,json=spentVtxos,proto3" json:"spent_vtxos,omitempty"`
+	Vtxos []*Vtxo `protobuf:"bytes,1,rep,name=vtxos,proto3" json:"vtxos,omitempty"`
+	// Token of the next page, empty if this is the last one.
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
 }
 
-func (x *ListVtxosResponse) Reset() {
-	*x = ListVtxosResponse{}
+func (x *ListVtxosPagedResponse) Reset() {
+	*x = ListVtxosPagedResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ark_v1_explorer_proto_msgTypes[5]
+		mi := &file_ark_v1_explorer_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *ListVtxosResponse) String() string {
+func (x *ListVtxosPagedResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListVtxosResponse) ProtoMessage() {}
+func (*ListVtxosPagedResponse) ProtoMessage() {}
 
-func (x *ListVtxosResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ark_v1_explorer_proto_msgTypes[5]
+func (x *ListVtxosPagedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ark_v1_explorer_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -292,23 +936,23 @@ func (x *ListVtxosResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListVtxosResponse.ProtoReflect.Descriptor instead.
-func (*ListVtxosResponse) Descriptor() ([]byte, []int) {
-	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use ListVtxosPagedResponse.ProtoReflect.Descriptor instead.
+func (*ListVtxosPagedResponse) Descriptor() ([]byte, []int) {
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *ListVtxosResponse) GetSpendableVtxos() []*Vtxo {
+func (x *ListVtxosPagedResponse) GetVtxos() []*Vtxo {
 	if x != nil {
-		return x.SpendableVtxos
+		return x.Vtxos
 	}
 	return nil
 }
 
-func (x *ListVtxosResponse) GetSpentVtxos() []*Vtxo {
+func (x *ListVtxosPagedResponse) GetNextPageToken() string {
 	if x != nil {
-		return x.SpentVtxos
+		return x.NextPageToken
 	}
-	return nil
+	return ""
 }
 
 type SubscribeForAddressRequest struct {
@@ -322,7 +966,7 @@ type SubscribeForAddressRequest struct {
 func (x *SubscribeForAddressRequest) Reset() {
 	*x = SubscribeForAddressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ark_v1_explorer_proto_msgTypes[6]
+		mi := &file_ark_v1_explorer_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -335,7 +979,7 @@ func (x *SubscribeForAddressRequest) String() string {
 func (*SubscribeForAddressRequest) ProtoMessage() {}
 
 func (x *SubscribeForAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_ark_v1_explorer_proto_msgTypes[6]
+	mi := &file_ark_v1_explorer_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +992,7 @@ func (x *SubscribeForAddressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeForAddressRequest.ProtoReflect.Descriptor instead.
 func (*SubscribeForAddressRequest) Descriptor() ([]byte, []int) {
-	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{6}
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *SubscribeForAddressRequest) GetAddress() string {
@@ -370,7 +1014,7 @@ type SubscribeForAddressResponse struct {
 func (x *SubscribeForAddressResponse) Reset() {
 	*x = SubscribeForAddressResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_ark_v1_explorer_proto_msgTypes[7]
+		mi := &file_ark_v1_explorer_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -383,7 +1027,7 @@ func (x *SubscribeForAddressResponse) String() string {
 func (*SubscribeForAddressResponse) ProtoMessage() {}
 
 func (x *SubscribeForAddressResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_ark_v1_explorer_proto_msgTypes[7]
+	mi := &file_ark_v1_explorer_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,7 +1040,7 @@ func (x *SubscribeForAddressResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubscribeForAddressResponse.ProtoReflect.Descriptor instead.
 func (*SubscribeForAddressResponse) Descriptor() ([]byte, []int) {
-	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{7}
+	return file_ark_v1_explorer_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *SubscribeForAddressResponse) GetNewVtxos() []*Vtxo {
@@ -421,78 +1065,184 @@ var file_ark_v1_explorer_proto_rawDesc = []byte{
 	0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x61, 0x6e, 0x6e, 0x6f,
 	0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x12, 0x61,
 	0x72, 0x6b, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x22, 0x25, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71,
+	0x6f, 0x22, 0x4b, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71,
 	0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x52,
-	0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05,
-	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x72,
-	0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e,
-	0x64, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x3b, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52,
-	0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x23, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0d, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x05,
-	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x2c, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78,
-	0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
-	0x65, 0x73, 0x73, 0x22, 0x79, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0f, 0x73, 0x70, 0x65, 0x6e,
-	0x64, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
-	0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74, 0x78, 0x6f, 0x52,
-	0x0e, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x12,
-	0x2d, 0x0a, 0x0b, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74,
-	0x78, 0x6f, 0x52, 0x0a, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x22, 0x36,
-	0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x46, 0x6f, 0x72, 0x41, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x28, 0x09, 0x52, 0x04, 0x74, 0x78, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x0e, 0x69, 0x6e, 0x63, 0x6c,
+	0x75, 0x64, 0x65, 0x5f, 0x72, 0x61, 0x77, 0x5f, 0x74, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x0c, 0x69, 0x6e, 0x63, 0x6c, 0x75, 0x64, 0x65, 0x52, 0x61, 0x77, 0x54, 0x78, 0x22, 0x59,
+	0x0a, 0x10, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x20, 0x0a, 0x0c, 0x72, 0x61, 0x77, 0x5f, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72,
+	0x61, 0x77, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x78, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x3b, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x64,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x05, 0x72, 0x6f, 0x75, 0x6e,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x05, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x22, 0x60, 0x0a,
+	0x19, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x65, 0x65, 0x42, 0x72, 0x61,
+	0x6e, 0x63, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f,
+	0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x78, 0x69, 0x64, 0x12, 0x24, 0x0a, 0x04, 0x76, 0x74, 0x78,
+	0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x4f, 0x75, 0x74, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52, 0x04, 0x76, 0x74, 0x78, 0x6f, 0x22,
+	0x42, 0x0a, 0x1a, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x65, 0x65, 0x42,
+	0x72, 0x61, 0x6e, 0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a,
+	0x06, 0x62, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0c, 0x2e,
+	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x65, 0x65, 0x52, 0x06, 0x62, 0x72, 0x61,
+	0x6e, 0x63, 0x68, 0x22, 0x5e, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x66, 0x74, 0x65,
+	0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x61, 0x66, 0x74, 0x65, 0x72, 0x12, 0x14,
+	0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x6c,
+	0x69, 0x6d, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b,
+	0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54, 0x6f,
+	0x6b, 0x65, 0x6e, 0x22, 0x6a, 0x0a, 0x12, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64,
+	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x72, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79, 0x52,
+	0x06, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f,
+	0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22,
+	0xb5, 0x01, 0x0a, 0x0c, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x53, 0x75, 0x6d, 0x6d, 0x61, 0x72, 0x79,
+	0x12, 0x12, 0x0a, 0x04, 0x74, 0x78, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x74, 0x78, 0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x74, 0x61, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e,
+	0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x65, 0x6e, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x23, 0x0a, 0x0d, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x6f, 0x74, 0x61,
+	0x6c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x2c, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x74, 0x78, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x79, 0x0a, 0x11, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78,
+	0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x0f, 0x73, 0x70,
+	0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74, 0x78,
+	0x6f, 0x52, 0x0e, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x74, 0x78, 0x6f,
+	0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x56, 0x74, 0x78, 0x6f, 0x52, 0x0a, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73,
+	0x22, 0x3c, 0x0a, 0x1c, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x46, 0x6f, 0x72,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x4b,
+	0x0a, 0x1d, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x46, 0x6f, 0x72, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2a, 0x0a, 0x05, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14,
+	0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x56,
+	0x74, 0x78, 0x6f, 0x73, 0x52, 0x05, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x0c,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x12, 0x18, 0x0a, 0x07,
 	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x77, 0x0a, 0x1b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72,
-	0x69, 0x62, 0x65, 0x46, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x29, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x74, 0x78,
-	0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76,
-	0x31, 0x2e, 0x56, 0x74, 0x78, 0x6f, 0x52, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x74, 0x78, 0x6f, 0x73,
-	0x12, 0x2d, 0x0a, 0x0b, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18,
-	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56,
-	0x74, 0x78, 0x6f, 0x52, 0x0a, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x32,
-	0xb9, 0x03, 0x0a, 0x0f, 0x45, 0x78, 0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76,
-	0x69, 0x63, 0x65, 0x12, 0x57, 0x0a, 0x08, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12,
-	0x17, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e,
-	0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76,
-	0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x18, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f,
-	0x72, 0x6f, 0x75, 0x6e, 0x64, 0x2f, 0x7b, 0x74, 0x78, 0x69, 0x64, 0x7d, 0x12, 0x64, 0x0a, 0x0c,
-	0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x12, 0x1b, 0x2e, 0x61,
-	0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79,
-	0x49, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x72, 0x6b, 0x2e,
-	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x52,
-	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12,
-	0x11, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x2f, 0x69, 0x64, 0x2f, 0x7b, 0x69,
-	0x64, 0x7d, 0x12, 0x5d, 0x0a, 0x09, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x12,
-	0x18, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78,
-	0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x61, 0x72, 0x6b, 0x2e,
-	0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70,
-	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76,
-	0x31, 0x2f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x2f, 0x7b, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
-	0x7d, 0x12, 0x87, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x46,
-	0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x2e, 0x61, 0x72, 0x6b, 0x2e,
-	0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x46, 0x6f, 0x72, 0x41,
-	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e,
-	0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
-	0x46, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
-	0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f,
-	0x76, 0x74, 0x78, 0x6f, 0x73, 0x2f, 0x7b, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x7d, 0x2f,
-	0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x30, 0x01, 0x42, 0x93, 0x01, 0x0a, 0x0a,
-	0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x42, 0x0d, 0x45, 0x78, 0x70, 0x6c,
-	0x6f, 0x72, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69, 0x74,
-	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x72, 0x6b, 0x2d, 0x6e, 0x65, 0x74, 0x77,
-	0x6f, 0x72, 0x6b, 0x2f, 0x61, 0x72, 0x6b, 0x2f, 0x61, 0x70, 0x69, 0x2d, 0x73, 0x70, 0x65, 0x63,
-	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61, 0x72,
-	0x6b, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x72, 0x6b, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x58, 0x58,
-	0xaa, 0x02, 0x06, 0x41, 0x72, 0x6b, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x06, 0x41, 0x72, 0x6b, 0x5c,
-	0x56, 0x31, 0xe2, 0x02, 0x12, 0x41, 0x72, 0x6b, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42, 0x4d,
-	0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x07, 0x41, 0x72, 0x6b, 0x3a, 0x3a, 0x56,
-	0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x35, 0x0a, 0x0f, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x61,
+	0x62, 0x6c, 0x65, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74, 0x78, 0x6f, 0x52, 0x0e, 0x73,
+	0x70, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x12, 0x2d, 0x0a,
+	0x0b, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74, 0x78, 0x6f,
+	0x52, 0x0a, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x22, 0xe3, 0x01, 0x0a,
+	0x15, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x50, 0x61, 0x67, 0x65, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x61, 0x62, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x73, 0x70, 0x65, 0x6e, 0x64, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x05, 0x73, 0x70, 0x65, 0x6e, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x70, 0x61, 0x67,
+	0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61, 0x67, 0x65, 0x54,
+	0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x6d, 0x69, 0x6e, 0x41, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x69,
+	0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x78,
+	0x69, 0x64, 0x22, 0x64, 0x0a, 0x16, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x50,
+	0x61, 0x67, 0x65, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x22, 0x0a, 0x05,
+	0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72,
+	0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74, 0x78, 0x6f, 0x52, 0x05, 0x76, 0x74, 0x78, 0x6f, 0x73,
+	0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65, 0x5f, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65, 0x78, 0x74, 0x50,
+	0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x36, 0x0a, 0x1a, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x46, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x22, 0x77, 0x0a, 0x1b, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x46, 0x6f, 0x72,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x29, 0x0a, 0x09, 0x6e, 0x65, 0x77, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74, 0x78, 0x6f,
+	0x52, 0x08, 0x6e, 0x65, 0x77, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x12, 0x2d, 0x0a, 0x0b, 0x73, 0x70,
+	0x65, 0x6e, 0x74, 0x5f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x74, 0x78, 0x6f, 0x52, 0x0a, 0x73,
+	0x70, 0x65, 0x6e, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x32, 0x80, 0x07, 0x0a, 0x0f, 0x45, 0x78,
+	0x70, 0x6c, 0x6f, 0x72, 0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x57, 0x0a,
+	0x08, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x12, 0x17, 0x2e, 0x61, 0x72, 0x6b, 0x2e,
+	0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x18, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x52,
+	0x6f, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x18, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x12, 0x12, 0x10, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x2f,
+	0x7b, 0x74, 0x78, 0x69, 0x64, 0x7d, 0x12, 0x64, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x12, 0x1b, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x42, 0x79, 0x49, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x19, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x13, 0x12, 0x11, 0x2f, 0x76, 0x31, 0x2f, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x2f, 0x69, 0x64, 0x2f, 0x7b, 0x69, 0x64, 0x7d, 0x12, 0x82, 0x01, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x65, 0x65, 0x42, 0x72, 0x61,
+	0x6e, 0x63, 0x68, 0x12, 0x21, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74,
+	0x52, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x65, 0x65, 0x42, 0x72, 0x61, 0x6e, 0x63, 0x68, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x22, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x54, 0x72, 0x65, 0x65, 0x42, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93,
+	0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x2f, 0x7b, 0x72,
+	0x6f, 0x75, 0x6e, 0x64, 0x5f, 0x74, 0x78, 0x69, 0x64, 0x7d, 0x2f, 0x62, 0x72, 0x61, 0x6e, 0x63,
+	0x68, 0x12, 0x57, 0x0a, 0x0a, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12,
+	0x19, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75,
+	0x6e, 0x64, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1a, 0x2e, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x52, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x12, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0c, 0x12, 0x0a,
+	0x2f, 0x76, 0x31, 0x2f, 0x72, 0x6f, 0x75, 0x6e, 0x64, 0x73, 0x12, 0x5d, 0x0a, 0x09, 0x4c, 0x69,
+	0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x12, 0x18, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x19, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x74, 0x78, 0x6f, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1b, 0x82, 0xd3,
+	0xe4, 0x93, 0x02, 0x15, 0x12, 0x13, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x2f,
+	0x7b, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x7d, 0x12, 0x7a, 0x0a, 0x15, 0x4c, 0x69, 0x73,
+	0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x46, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x65, 0x73, 0x12, 0x24, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74,
+	0x56, 0x74, 0x78, 0x6f, 0x73, 0x46, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76,
+	0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x46, 0x6f, 0x72, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22,
+	0x14, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x0e, 0x3a, 0x01, 0x2a, 0x22, 0x09, 0x2f, 0x76, 0x31, 0x2f,
+	0x76, 0x74, 0x78, 0x6f, 0x73, 0x12, 0x6b, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78,
+	0x6f, 0x73, 0x50, 0x61, 0x67, 0x65, 0x64, 0x12, 0x1d, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31,
+	0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x50, 0x61, 0x67, 0x65, 0x64, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1e, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x56, 0x74, 0x78, 0x6f, 0x73, 0x50, 0x61, 0x67, 0x65, 0x64, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x1a, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x14, 0x3a, 0x01,
+	0x2a, 0x22, 0x0f, 0x2f, 0x76, 0x31, 0x2f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x2f, 0x70, 0x61, 0x67,
+	0x65, 0x64, 0x12, 0x87, 0x01, 0x0a, 0x13, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65,
+	0x46, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x22, 0x2e, 0x61, 0x72, 0x6b,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x46, 0x6f, 0x72,
+	0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23,
+	0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62,
+	0x65, 0x46, 0x6f, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x25, 0x82, 0xd3, 0xe4, 0x93, 0x02, 0x1f, 0x12, 0x1d, 0x2f, 0x76, 0x31,
+	0x2f, 0x76, 0x74, 0x78, 0x6f, 0x73, 0x2f, 0x7b, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x7d,
+	0x2f, 0x73, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x30, 0x01, 0x42, 0x93, 0x01, 0x0a,
+	0x0a, 0x63, 0x6f, 0x6d, 0x2e, 0x61, 0x72, 0x6b, 0x2e, 0x76, 0x31, 0x42, 0x0d, 0x45, 0x78, 0x70,
+	0x6c, 0x6f, 0x72, 0x65, 0x72, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x50, 0x01, 0x5a, 0x3d, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x72, 0x6b, 0x2d, 0x6e, 0x65, 0x74,
+	0x77, 0x6f, 0x72, 0x6b, 0x2f, 0x61, 0x72, 0x6b, 0x2f, 0x61, 0x70, 0x69, 0x2d, 0x73, 0x70, 0x65,
+	0x63, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x61,
+	0x72, 0x6b, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x72, 0x6b, 0x76, 0x31, 0xa2, 0x02, 0x03, 0x41, 0x58,
+	0x58, 0xaa, 0x02, 0x06, 0x41, 0x72, 0x6b, 0x2e, 0x56, 0x31, 0xca, 0x02, 0x06, 0x41, 0x72, 0x6b,
+	0x5c, 0x56, 0x31, 0xe2, 0x02, 0x12, 0x41, 0x72, 0x6b, 0x5c, 0x56, 0x31, 0x5c, 0x47, 0x50, 0x42,
+	0x4d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0xea, 0x02, 0x07, 0x41, 0x72, 0x6b, 0x3a, 0x3a,
+	0x56, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -507,39 +1257,66 @@ func file_ark_v1_explorer_proto_rawDescGZIP() []byte {
 	return file_ark_v1_explorer_proto_rawDescData
 }
 
-var file_ark_v1_explorer_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_ark_v1_explorer_proto_msgTypes = make([]protoimpl.MessageInfo, 18)
 var file_ark_v1_explorer_proto_goTypes = []interface{}{
-	(*GetRoundRequest)(nil),             // 0: ark.v1.GetRoundRequest
-	(*GetRoundResponse)(nil),            // 1: ark.v1.GetRoundResponse
-	(*GetRoundByIdRequest)(nil),         // 2: ark.v1.GetRoundByIdRequest
-	(*GetRoundByIdResponse)(nil),        // 3: ark.v1.GetRoundByIdResponse
-	(*ListVtxosRequest)(nil),            // 4: ark.v1.ListVtxosRequest
-	(*ListVtxosResponse)(nil),           // 5: ark.v1.ListVtxosResponse
-	(*SubscribeForAddressRequest)(nil),  // 6: ark.v1.SubscribeForAddressRequest
-	(*SubscribeForAddressResponse)(nil), // 7: ark.v1.SubscribeForAddressResponse
-	(*Round)(nil),                       // 8: ark.v1.Round
-	(*Vtxo)(nil),                        // 9: ark.v1.Vtxo
+	(*GetRoundRequest)(nil),               // 0: ark.v1.GetRoundRequest
+	(*GetRoundResponse)(nil),              // 1: ark.v1.GetRoundResponse
+	(*GetRoundByIdRequest)(nil),           // 2: ark.v1.GetRoundByIdRequest
+	(*GetRoundByIdResponse)(nil),          // 3: ark.v1.GetRoundByIdResponse
+	(*GetRoundTreeBranchRequest)(nil),     // 4: ark.v1.GetRoundTreeBranchRequest
+	(*GetRoundTreeBranchResponse)(nil),    // 5: ark.v1.GetRoundTreeBranchResponse
+	(*ListRoundsRequest)(nil),             // 6: ark.v1.ListRoundsRequest
+	(*ListRoundsResponse)(nil),            // 7: ark.v1.ListRoundsResponse
+	(*RoundSummary)(nil),                  // 8: ark.v1.RoundSummary
+	(*ListVtxosRequest)(nil),              // 9: ark.v1.ListVtxosRequest
+	(*ListVtxosResponse)(nil),             // 10: ark.v1.ListVtxosResponse
+	(*ListVtxosForAddressesRequest)(nil),  // 11: ark.v1.ListVtxosForAddressesRequest
+	(*ListVtxosForAddressesResponse)(nil), // 12: ark.v1.ListVtxosForAddressesResponse
+	(*AddressVtxos)(nil),                  // 13: ark.v1.AddressVtxos
+	(*ListVtxosPagedRequest)(nil),         // 14: ark.v1.ListVtxosPagedRequest
+	(*ListVtxosPagedResponse)(nil),        // 15: ark.v1.ListVtxosPagedResponse
+	(*SubscribeForAddressRequest)(nil),    // 16: ark.v1.SubscribeForAddressRequest
+	(*SubscribeForAddressResponse)(nil),   // 17: ark.v1.SubscribeForAddressResponse
+	(*Round)(nil),                         // 18: ark.v1.Round
+	(*Outpoint)(nil),                      // 19: ark.v1.Outpoint
+	(*Tree)(nil),                          // 20: ark.v1.Tree
+	(*Vtxo)(nil),                          // 21: ark.v1.Vtxo
 }
 var file_ark_v1_explorer_proto_depIdxs = []int32{
-	8,  // 0: ark.v1.GetRoundResponse.round:type_name -> ark.v1.Round
-	8,  // 1: ark.v1.GetRoundByIdResponse.round:type_name -> ark.v1.Round
-	9,  // 2: ark.v1.ListVtxosResponse.spendable_vtxos:type_name -> ark.v1.Vtxo
-	9,  // 3: ark.v1.ListVtxosResponse.spent_vtxos:type_name -> ark.v1.Vtxo
-	9,  // 4: ark.v1.SubscribeForAddressResponse.new_vtxos:type_name -> ark.v1.Vtxo
-	9,  // 5: ark.v1.SubscribeForAddressResponse.spent_vtxos:type_name -> ark.v1.Vtxo
-	0,  // 6: ark.v1.ExplorerService.GetRound:input_type -> ark.v1.GetRoundRequest
-	2,  // 7: ark.v1.ExplorerService.GetRoundById:input_type -> ark.v1.GetRoundByIdRequest
-	4,  // 8: ark.v1.ExplorerService.ListVtxos:input_type -> ark.v1.ListVtxosRequest
-	6,  // 9: ark.v1.ExplorerService.SubscribeForAddress:input_type -> ark.v1.SubscribeForAddressRequest
-	1,  // 10: ark.v1.ExplorerService.GetRound:output_type -> ark.v1.GetRoundResponse
-	3,  // 11: ark.v1.ExplorerService.GetRoundById:output_type -> ark.v1.GetRoundByIdResponse
-	5,  // 12: ark.v1.ExplorerService.ListVtxos:output_type -> ark.v1.ListVtxosResponse
-	7,  // 13: ark.v1.ExplorerService.SubscribeForAddress:output_type -> ark.v1.SubscribeForAddressResponse
-	10, // [10:14] is the sub-list for method output_type
-	6,  // [6:10] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	18, // 0: ark.v1.GetRoundResponse.round:type_name -> ark.v1.Round
+	18, // 1: ark.v1.GetRoundByIdResponse.round:type_name -> ark.v1.Round
+	19, // 2: ark.v1.GetRoundTreeBranchRequest.vtxo:type_name -> ark.v1.Outpoint
+	20, // 3: ark.v1.GetRoundTreeBranchResponse.branch:type_name -> ark.v1.Tree
+	8,  // 4: ark.v1.ListRoundsResponse.rounds:type_name -> ark.v1.RoundSummary
+	21, // 5: ark.v1.ListVtxosResponse.spendable_vtxos:type_name -> ark.v1.Vtxo
+	21, // 6: ark.v1.ListVtxosResponse.spent_vtxos:type_name -> ark.v1.Vtxo
+	13, // 7: ark.v1.ListVtxosForAddressesResponse.vtxos:type_name -> ark.v1.AddressVtxos
+	21, // 8: ark.v1.AddressVtxos.spendable_vtxos:type_name -> ark.v1.Vtxo
+	21, // 9: ark.v1.AddressVtxos.spent_vtxos:type_name -> ark.v1.Vtxo
+	21, // 10: ark.v1.ListVtxosPagedResponse.vtxos:type_name -> ark.v1.Vtxo
+	21, // 11: ark.v1.SubscribeForAddressResponse.new_vtxos:type_name -> ark.v1.Vtxo
+	21, // 12: ark.v1.SubscribeForAddressResponse.spent_vtxos:type_name -> ark.v1.Vtxo
+	0,  // 13: ark.v1.ExplorerService.GetRound:input_type -> ark.v1.GetRoundRequest
+	2,  // 14: ark.v1.ExplorerService.GetRoundById:input_type -> ark.v1.GetRoundByIdRequest
+	4,  // 15: ark.v1.ExplorerService.GetRoundTreeBranch:input_type -> ark.v1.GetRoundTreeBranchRequest
+	6,  // 16: ark.v1.ExplorerService.ListRounds:input_type -> ark.v1.ListRoundsRequest
+	9,  // 17: ark.v1.ExplorerService.ListVtxos:input_type -> ark.v1.ListVtxosRequest
+	11, // 18: ark.v1.ExplorerService.ListVtxosForAddresses:input_type -> ark.v1.ListVtxosForAddressesRequest
+	14, // 19: ark.v1.ExplorerService.ListVtxosPaged:input_type -> ark.v1.ListVtxosPagedRequest
+	16, // 20: ark.v1.ExplorerService.SubscribeForAddress:input_type -> ark.v1.SubscribeForAddressRequest
+	1,  // 21: ark.v1.ExplorerService.GetRound:output_type -> ark.v1.GetRoundResponse
+	3,  // 22: ark.v1.ExplorerService.GetRoundById:output_type -> ark.v1.GetRoundByIdResponse
+	5,  // 23: ark.v1.ExplorerService.GetRoundTreeBranch:output_type -> ark.v1.GetRoundTreeBranchResponse
+	7,  // 24: ark.v1.ExplorerService.ListRounds:output_type -> ark.v1.ListRoundsResponse
+	10, // 25: ark.v1.ExplorerService.ListVtxos:output_type -> ark.v1.ListVtxosResponse
+	12, // 26: ark.v1.ExplorerService.ListVtxosForAddresses:output_type -> ark.v1.ListVtxosForAddressesResponse
+	15, // 27: ark.v1.ExplorerService.ListVtxosPaged:output_type -> ark.v1.ListVtxosPagedResponse
+	17, // 28: ark.v1.ExplorerService.SubscribeForAddress:output_type -> ark.v1.SubscribeForAddressResponse
+	21, // [21:29] is the sub-list for method output_type
+	13, // [13:21] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_ark_v1_explorer_proto_init() }
@@ -598,7 +1375,7 @@ func file_ark_v1_explorer_proto_init() {
 			}
 		}
 		file_ark_v1_explorer_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListVtxosRequest); i {
+			switch v := v.(*GetRoundTreeBranchRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -610,7 +1387,7 @@ func file_ark_v1_explorer_proto_init() {
 			}
 		}
 		file_ark_v1_explorer_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ListVtxosResponse); i {
+			switch v := v.(*GetRoundTreeBranchResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -622,7 +1399,7 @@ func file_ark_v1_explorer_proto_init() {
 			}
 		}
 		file_ark_v1_explorer_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubscribeForAddressRequest); i {
+			switch v := v.(*ListRoundsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -634,6 +1411,126 @@ func file_ark_v1_explorer_proto_init() {
 			}
 		}
 		file_ark_v1_explorer_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListRoundsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RoundSummary); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVtxosRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVtxosResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVtxosForAddressesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVtxosForAddressesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AddressVtxos); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVtxosPagedRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ListVtxosPagedResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeForAddressRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ark_v1_explorer_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SubscribeForAddressResponse); i {
 			case 0:
 				return &v.state
@@ -652,7 +1549,7 @@ func file_ark_v1_explorer_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_ark_v1_explorer_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   8,
+			NumMessages:   18,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,7 +20,19 @@ const _ = grpc.SupportPackageIsVersion7
 type ExplorerServiceClient interface {
 	GetRound(ctx context.Context, in *GetRoundRequest, opts ...grpc.CallOption) (*GetRoundResponse, error)
 	GetRoundById(ctx context.Context, in *GetRoundByIdRequest, opts ...grpc.CallOption) (*GetRoundByIdResponse, error)
+	// GetRoundTreeBranch returns only the nodes of the vtxo tree of a round
+	// from the root to the leaf of the given vtxo, rather than the whole tree.
+	GetRoundTreeBranch(ctx context.Context, in *GetRoundTreeBranchRequest, opts ...grpc.CallOption) (*GetRoundTreeBranchResponse, error)
+	// ListRounds returns a page of the finalized rounds, sorted by ending time
+	// and txid.
+	ListRounds(ctx context.Context, in *ListRoundsRequest, opts ...grpc.CallOption) (*ListRoundsResponse, error)
 	ListVtxos(ctx context.Context, in *ListVtxosRequest, opts ...grpc.CallOption) (*ListVtxosResponse, error)
+	// ListVtxosForAddresses is the batched version of ListVtxos, the vtxos of all
+	// the given addresses are fetched with a single query.
+	ListVtxosForAddresses(ctx context.Context, in *ListVtxosForAddressesRequest, opts ...grpc.CallOption) (*ListVtxosForAddressesResponse, error)
+	// ListVtxosPaged returns a page of the vtxos of the given addresses, filtered
+	// by state, min amount and round. The vtxos are sorted by outpoint.
+	ListVtxosPaged(ctx context.Context, in *ListVtxosPagedRequest, opts ...grpc.CallOption) (*ListVtxosPagedResponse, error)
 	SubscribeForAddress(ctx context.Context, in *SubscribeForAddressRequest, opts ...grpc.CallOption) (ExplorerService_SubscribeForAddressClient, error)
 }
 
@@ -50,6 +62,24 @@ func (c *explorerServiceClient) GetRoundById(ctx context.Context, in *GetRoundBy
 	return out, nil
 }
 
+func (c *explorerServiceClient) GetRoundTreeBranch(ctx context.Context, in *GetRoundTreeBranchRequest, opts ...grpc.CallOption) (*GetRoundTreeBranchResponse, error) {
+	out := new(GetRoundTreeBranchResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ExplorerService/GetRoundTreeBranch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *explorerServiceClient) ListRounds(ctx context.Context, in *ListRoundsRequest, opts ...grpc.CallOption) (*ListRoundsResponse, error) {
+	out := new(ListRoundsResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ExplorerService/ListRounds", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *explorerServiceClient) ListVtxos(ctx context.Context, in *ListVtxosRequest, opts ...grpc.CallOption) (*ListVtxosResponse, error) {
 	out := new(ListVtxosResponse)
 	err := c.cc.Invoke(ctx, "/ark.v1.ExplorerService/ListVtxos", in, out, opts...)
@@ -59,6 +89,24 @@ func (c *explorerServiceClient) ListVtxos(ctx context.Context, in *ListVtxosRequ
 	return out, nil
 }
 
+func (c *explorerServiceClient) ListVtxosForAddresses(ctx context.Context, in *ListVtxosForAddressesRequest, opts ...grpc.CallOption) (*ListVtxosForAddressesResponse, error) {
+	out := new(ListVtxosForAddressesResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ExplorerService/ListVtxosForAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *explorerServiceClient) ListVtxosPaged(ctx context.Context, in *ListVtxosPagedRequest, opts ...grpc.CallOption) (*ListVtxosPagedResponse, error) {
+	out := new(ListVtxosPagedResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ExplorerService/ListVtxosPaged", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *explorerServiceClient) SubscribeForAddress(ctx context.Context, in *SubscribeForAddressRequest, opts ...grpc.CallOption) (ExplorerService_SubscribeForAddressClient, error) {
 	stream, err := c.cc.NewStream(ctx, &ExplorerService_ServiceDesc.Streams[0], "/ark.v1.ExplorerService/SubscribeForAddress", opts...)
 	if err != nil {
@@ -97,7 +145,19 @@ func (x *explorerServiceSubscribeForAddressClient) Recv() (*SubscribeForAddressR
 type ExplorerServiceServer interface {
 	GetRound(context.Context, *GetRoundRequest) (*GetRoundResponse, error)
 	GetRoundById(context.Context, *GetRoundByIdRequest) (*GetRoundByIdResponse, error)
+	// GetRoundTreeBranch returns only the nodes of the vtxo tree of a round
+	// from the root to the leaf of the given vtxo, rather than the whole tree.
+	GetRoundTreeBranch(context.Context, *GetRoundTreeBranchRequest) (*GetRoundTreeBranchResponse, error)
+	// ListRounds returns a page of the finalized rounds, sorted by ending time
+	// and txid.
+	ListRounds(context.Context, *ListRoundsRequest) (*ListRoundsResponse, error)
 	ListVtxos(context.Context, *ListVtxosRequest) (*ListVtxosResponse, error)
+	// ListVtxosForAddresses is the batched version of ListVtxos, the vtxos of all
+	// the given addresses are fetched with a single query.
+	ListVtxosForAddresses(context.Context, *ListVtxosForAddressesRequest) (*ListVtxosForAddressesResponse, error)
+	// ListVtxosPaged returns a page of the vtxos of the given addresses, filtered
+	// by state, min amount and round. The vtxos are sorted by outpoint.
+	ListVtxosPaged(context.Context, *ListVtxosPagedRequest) (*ListVtxosPagedResponse, error)
 	SubscribeForAddress(*SubscribeForAddressRequest, ExplorerService_SubscribeForAddressServer) error
 }
 
@@ -111,9 +171,21 @@ func (UnimplementedExplorerServiceServer) GetRound(context.Context, *GetRoundReq
 func (UnimplementedExplorerServiceServer) GetRoundById(context.Context, *GetRoundByIdRequest) (*GetRoundByIdResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRoundById not implemented")
 }
+func (UnimplementedExplorerServiceServer) GetRoundTreeBranch(context.Context, *GetRoundTreeBranchRequest) (*GetRoundTreeBranchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoundTreeBranch not implemented")
+}
+func (UnimplementedExplorerServiceServer) ListRounds(context.Context, *ListRoundsRequest) (*ListRoundsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRounds not implemented")
+}
 func (UnimplementedExplorerServiceServer) ListVtxos(context.Context, *ListVtxosRequest) (*ListVtxosResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListVtxos not implemented")
 }
+func (UnimplementedExplorerServiceServer) ListVtxosForAddresses(context.Context, *ListVtxosForAddressesRequest) (*ListVtxosForAddressesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVtxosForAddresses not implemented")
+}
+func (UnimplementedExplorerServiceServer) ListVtxosPaged(context.Context, *ListVtxosPagedRequest) (*ListVtxosPagedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVtxosPaged not implemented")
+}
 func (UnimplementedExplorerServiceServer) SubscribeForAddress(*SubscribeForAddressRequest, ExplorerService_SubscribeForAddressServer) error {
 	return status.Errorf(codes.Unimplemented, "method SubscribeForAddress not implemented")
 }
@@ -165,6 +237,42 @@ func _ExplorerService_GetRoundById_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExplorerService_GetRoundTreeBranch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoundTreeBranchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExplorerServiceServer).GetRoundTreeBranch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ExplorerService/GetRoundTreeBranch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExplorerServiceServer).GetRoundTreeBranch(ctx, req.(*GetRoundTreeBranchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExplorerService_ListRounds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExplorerServiceServer).ListRounds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ExplorerService/ListRounds",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExplorerServiceServer).ListRounds(ctx, req.(*ListRoundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ExplorerService_ListVtxos_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListVtxosRequest)
 	if err := dec(in); err != nil {
@@ -183,6 +291,42 @@ func _ExplorerService_ListVtxos_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ExplorerService_ListVtxosForAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVtxosForAddressesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExplorerServiceServer).ListVtxosForAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ExplorerService/ListVtxosForAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExplorerServiceServer).ListVtxosForAddresses(ctx, req.(*ListVtxosForAddressesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ExplorerService_ListVtxosPaged_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVtxosPagedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ExplorerServiceServer).ListVtxosPaged(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ExplorerService/ListVtxosPaged",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ExplorerServiceServer).ListVtxosPaged(ctx, req.(*ListVtxosPagedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ExplorerService_SubscribeForAddress_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(SubscribeForAddressRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -219,10 +363,26 @@ var ExplorerService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRoundById",
 			Handler:    _ExplorerService_GetRoundById_Handler,
 		},
+		{
+			MethodName: "GetRoundTreeBranch",
+			Handler:    _ExplorerService_GetRoundTreeBranch_Handler,
+		},
+		{
+			MethodName: "ListRounds",
+			Handler:    _ExplorerService_ListRounds_Handler,
+		},
 		{
 			MethodName: "ListVtxos",
 			Handler:    _ExplorerService_ListVtxos_Handler,
 		},
+		{
+			MethodName: "ListVtxosForAddresses",
+			Handler:    _ExplorerService_ListVtxosForAddresses_Handler,
+		},
+		{
+			MethodName: "ListVtxosPaged",
+			Handler:    _ExplorerService_ListVtxosPaged_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -23,12 +23,36 @@ type ArkServiceClient interface {
 	RegisterIntent(ctx context.Context, in *RegisterIntentRequest, opts ...grpc.CallOption) (*RegisterIntentResponse, error)
 	RegisterInputsForNextRound(ctx context.Context, in *RegisterInputsForNextRoundRequest, opts ...grpc.CallOption) (*RegisterInputsForNextRoundResponse, error)
 	RegisterOutputsForNextRound(ctx context.Context, in *RegisterOutputsForNextRoundRequest, opts ...grpc.CallOption) (*RegisterOutputsForNextRoundResponse, error)
+	// UpdateTxRequest replaces the outputs registered for a tx request with
+	// RegisterOutputsForNextRound, until the request is selected for a round.
+	UpdateTxRequest(ctx context.Context, in *UpdateTxRequestRequest, opts ...grpc.CallOption) (*UpdateTxRequestResponse, error)
+	// ValidateTxRequest runs the same checks of RegisterInputsForNextRound and
+	// RegisterOutputsForNextRound against the given inputs and outputs without
+	// registering or reserving anything.
+	ValidateTxRequest(ctx context.Context, in *ValidateTxRequestRequest, opts ...grpc.CallOption) (*ValidateTxRequestResponse, error)
 	SubmitTreeNonces(ctx context.Context, in *SubmitTreeNoncesRequest, opts ...grpc.CallOption) (*SubmitTreeNoncesResponse, error)
 	SubmitTreeSignatures(ctx context.Context, in *SubmitTreeSignaturesRequest, opts ...grpc.CallOption) (*SubmitTreeSignaturesResponse, error)
 	SubmitSignedForfeitTxs(ctx context.Context, in *SubmitSignedForfeitTxsRequest, opts ...grpc.CallOption) (*SubmitSignedForfeitTxsResponse, error)
 	GetEventStream(ctx context.Context, in *GetEventStreamRequest, opts ...grpc.CallOption) (ArkService_GetEventStreamClient, error)
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
 	SubmitRedeemTx(ctx context.Context, in *SubmitRedeemTxRequest, opts ...grpc.CallOption) (*SubmitRedeemTxResponse, error)
+	// ValidateRedeemTx runs the same checks of SubmitRedeemTx against the given
+	// redeem tx without signing, broadcasting or reserving anything.
+	ValidateRedeemTx(ctx context.Context, in *ValidateRedeemTxRequest, opts ...grpc.CallOption) (*ValidateRedeemTxResponse, error)
+	// SubmitRedeemTxs is the batched version of SubmitRedeemTx, the txs are
+	// processed concurrently and a result is returned for each of them.
+	SubmitRedeemTxs(ctx context.Context, in *SubmitRedeemTxsRequest, opts ...grpc.CallOption) (*SubmitRedeemTxsResponse, error)
+	// AuthorizeRefresh lets the server register the given intent on behalf of
+	// its owner when the spent vtxo gets close to expiry, so that it's not
+	// swept while the owner is offline. The intent must spend exactly one vtxo,
+	// must not expire and must not include any cosigner, the forfeit tx of the
+	// vtxo must be signed with SIGHASH_ALL|ANYONECANPAY.
+	AuthorizeRefresh(ctx context.Context, in *AuthorizeRefreshRequest, opts ...grpc.CallOption) (*AuthorizeRefreshResponse, error)
+	// RevokeRefreshAuthorization revokes the authorization to refresh a vtxo.
+	// The pre-signed forfeit tx stays valid as long as the vtxo is unspent, so
+	// the vtxo is refreshed right away into the outputs of the authorized intent
+	// and the authorization is dropped once the vtxo is spent.
+	RevokeRefreshAuthorization(ctx context.Context, in *RevokeRefreshAuthorizationRequest, opts ...grpc.CallOption) (*RevokeRefreshAuthorizationResponse, error)
 	GetTransactionsStream(ctx context.Context, in *GetTransactionsStreamRequest, opts ...grpc.CallOption) (ArkService_GetTransactionsStreamClient, error)
 }
 
@@ -85,6 +109,24 @@ func (c *arkServiceClient) RegisterOutputsForNextRound(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *arkServiceClient) UpdateTxRequest(ctx context.Context, in *UpdateTxRequestRequest, opts ...grpc.CallOption) (*UpdateTxRequestResponse, error) {
+	out := new(UpdateTxRequestResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/UpdateTxRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) ValidateTxRequest(ctx context.Context, in *ValidateTxRequestRequest, opts ...grpc.CallOption) (*ValidateTxRequestResponse, error) {
+	out := new(ValidateTxRequestResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/ValidateTxRequest", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arkServiceClient) SubmitTreeNonces(ctx context.Context, in *SubmitTreeNoncesRequest, opts ...grpc.CallOption) (*SubmitTreeNoncesResponse, error) {
 	out := new(SubmitTreeNoncesResponse)
 	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/SubmitTreeNonces", in, out, opts...)
@@ -162,6 +204,42 @@ func (c *arkServiceClient) SubmitRedeemTx(ctx context.Context, in *SubmitRedeemT
 	return out, nil
 }
 
+func (c *arkServiceClient) ValidateRedeemTx(ctx context.Context, in *ValidateRedeemTxRequest, opts ...grpc.CallOption) (*ValidateRedeemTxResponse, error) {
+	out := new(ValidateRedeemTxResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/ValidateRedeemTx", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) SubmitRedeemTxs(ctx context.Context, in *SubmitRedeemTxsRequest, opts ...grpc.CallOption) (*SubmitRedeemTxsResponse, error) {
+	out := new(SubmitRedeemTxsResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/SubmitRedeemTxs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) AuthorizeRefresh(ctx context.Context, in *AuthorizeRefreshRequest, opts ...grpc.CallOption) (*AuthorizeRefreshResponse, error) {
+	out := new(AuthorizeRefreshResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/AuthorizeRefresh", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *arkServiceClient) RevokeRefreshAuthorization(ctx context.Context, in *RevokeRefreshAuthorizationRequest, opts ...grpc.CallOption) (*RevokeRefreshAuthorizationResponse, error) {
+	out := new(RevokeRefreshAuthorizationResponse)
+	err := c.cc.Invoke(ctx, "/ark.v1.ArkService/RevokeRefreshAuthorization", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *arkServiceClient) GetTransactionsStream(ctx context.Context, in *GetTransactionsStreamRequest, opts ...grpc.CallOption) (ArkService_GetTransactionsStreamClient, error) {
 	stream, err := c.cc.NewStream(ctx, &ArkService_ServiceDesc.Streams[1], "/ark.v1.ArkService/GetTransactionsStream", opts...)
 	if err != nil {
@@ -203,12 +281,36 @@ type ArkServiceServer interface {
 	RegisterIntent(context.Context, *RegisterIntentRequest) (*RegisterIntentResponse, error)
 	RegisterInputsForNextRound(context.Context, *RegisterInputsForNextRoundRequest) (*RegisterInputsForNextRoundResponse, error)
 	RegisterOutputsForNextRound(context.Context, *RegisterOutputsForNextRoundRequest) (*RegisterOutputsForNextRoundResponse, error)
+	// UpdateTxRequest replaces the outputs registered for a tx request with
+	// RegisterOutputsForNextRound, until the request is selected for a round.
+	UpdateTxRequest(context.Context, *UpdateTxRequestRequest) (*UpdateTxRequestResponse, error)
+	// ValidateTxRequest runs the same checks of RegisterInputsForNextRound and
+	// RegisterOutputsForNextRound against the given inputs and outputs without
+	// registering or reserving anything.
+	ValidateTxRequest(context.Context, *ValidateTxRequestRequest) (*ValidateTxRequestResponse, error)
 	SubmitTreeNonces(context.Context, *SubmitTreeNoncesRequest) (*SubmitTreeNoncesResponse, error)
 	SubmitTreeSignatures(context.Context, *SubmitTreeSignaturesRequest) (*SubmitTreeSignaturesResponse, error)
 	SubmitSignedForfeitTxs(context.Context, *SubmitSignedForfeitTxsRequest) (*SubmitSignedForfeitTxsResponse, error)
 	GetEventStream(*GetEventStreamRequest, ArkService_GetEventStreamServer) error
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
 	SubmitRedeemTx(context.Context, *SubmitRedeemTxRequest) (*SubmitRedeemTxResponse, error)
+	// ValidateRedeemTx runs the same checks of SubmitRedeemTx against the given
+	// redeem tx without signing, broadcasting or reserving anything.
+	ValidateRedeemTx(context.Context, *ValidateRedeemTxRequest) (*ValidateRedeemTxResponse, error)
+	// SubmitRedeemTxs is the batched version of SubmitRedeemTx, the txs are
+	// processed concurrently and a result is returned for each of them.
+	SubmitRedeemTxs(context.Context, *SubmitRedeemTxsRequest) (*SubmitRedeemTxsResponse, error)
+	// AuthorizeRefresh lets the server register the given intent on behalf of
+	// its owner when the spent vtxo gets close to expiry, so that it's not
+	// swept while the owner is offline. The intent must spend exactly one vtxo,
+	// must not expire and must not include any cosigner, the forfeit tx of the
+	// vtxo must be signed with SIGHASH_ALL|ANYONECANPAY.
+	AuthorizeRefresh(context.Context, *AuthorizeRefreshRequest) (*AuthorizeRefreshResponse, error)
+	// RevokeRefreshAuthorization revokes the authorization to refresh a vtxo.
+	// The pre-signed forfeit tx stays valid as long as the vtxo is unspent, so
+	// the vtxo is refreshed right away into the outputs of the authorized intent
+	// and the authorization is dropped once the vtxo is spent.
+	RevokeRefreshAuthorization(context.Context, *RevokeRefreshAuthorizationRequest) (*RevokeRefreshAuthorizationResponse, error)
 	GetTransactionsStream(*GetTransactionsStreamRequest, ArkService_GetTransactionsStreamServer) error
 }
 
@@ -231,6 +333,12 @@ func (UnimplementedArkServiceServer) RegisterInputsForNextRound(context.Context,
 func (UnimplementedArkServiceServer) RegisterOutputsForNextRound(context.Context, *RegisterOutputsForNextRoundRequest) (*RegisterOutputsForNextRoundResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RegisterOutputsForNextRound not implemented")
 }
+func (UnimplementedArkServiceServer) UpdateTxRequest(context.Context, *UpdateTxRequestRequest) (*UpdateTxRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTxRequest not implemented")
+}
+func (UnimplementedArkServiceServer) ValidateTxRequest(context.Context, *ValidateTxRequestRequest) (*ValidateTxRequestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateTxRequest not implemented")
+}
 func (UnimplementedArkServiceServer) SubmitTreeNonces(context.Context, *SubmitTreeNoncesRequest) (*SubmitTreeNoncesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitTreeNonces not implemented")
 }
@@ -249,6 +357,18 @@ func (UnimplementedArkServiceServer) Ping(context.Context, *PingRequest) (*PingR
 func (UnimplementedArkServiceServer) SubmitRedeemTx(context.Context, *SubmitRedeemTxRequest) (*SubmitRedeemTxResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitRedeemTx not implemented")
 }
+func (UnimplementedArkServiceServer) ValidateRedeemTx(context.Context, *ValidateRedeemTxRequest) (*ValidateRedeemTxResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateRedeemTx not implemented")
+}
+func (UnimplementedArkServiceServer) SubmitRedeemTxs(context.Context, *SubmitRedeemTxsRequest) (*SubmitRedeemTxsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitRedeemTxs not implemented")
+}
+func (UnimplementedArkServiceServer) AuthorizeRefresh(context.Context, *AuthorizeRefreshRequest) (*AuthorizeRefreshResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeRefresh not implemented")
+}
+func (UnimplementedArkServiceServer) RevokeRefreshAuthorization(context.Context, *RevokeRefreshAuthorizationRequest) (*RevokeRefreshAuthorizationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeRefreshAuthorization not implemented")
+}
 func (UnimplementedArkServiceServer) GetTransactionsStream(*GetTransactionsStreamRequest, ArkService_GetTransactionsStreamServer) error {
 	return status.Errorf(codes.Unimplemented, "method GetTransactionsStream not implemented")
 }
@@ -354,6 +474,42 @@ func _ArkService_RegisterOutputsForNextRound_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArkService_UpdateTxRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTxRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).UpdateTxRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/UpdateTxRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).UpdateTxRequest(ctx, req.(*UpdateTxRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_ValidateTxRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateTxRequestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).ValidateTxRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/ValidateTxRequest",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).ValidateTxRequest(ctx, req.(*ValidateTxRequestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArkService_SubmitTreeNonces_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(SubmitTreeNoncesRequest)
 	if err := dec(in); err != nil {
@@ -465,6 +621,78 @@ func _ArkService_SubmitRedeemTx_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ArkService_ValidateRedeemTx_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ValidateRedeemTxRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).ValidateRedeemTx(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/ValidateRedeemTx",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).ValidateRedeemTx(ctx, req.(*ValidateRedeemTxRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_SubmitRedeemTxs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitRedeemTxsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).SubmitRedeemTxs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/SubmitRedeemTxs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).SubmitRedeemTxs(ctx, req.(*SubmitRedeemTxsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_AuthorizeRefresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeRefreshRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).AuthorizeRefresh(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/AuthorizeRefresh",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).AuthorizeRefresh(ctx, req.(*AuthorizeRefreshRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ArkService_RevokeRefreshAuthorization_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeRefreshAuthorizationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ArkServiceServer).RevokeRefreshAuthorization(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/ark.v1.ArkService/RevokeRefreshAuthorization",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ArkServiceServer).RevokeRefreshAuthorization(ctx, req.(*RevokeRefreshAuthorizationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ArkService_GetTransactionsStream_Handler(srv interface{}, stream grpc.ServerStream) error {
 	m := new(GetTransactionsStreamRequest)
 	if err := stream.RecvMsg(m); err != nil {
@@ -513,6 +741,14 @@ var ArkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RegisterOutputsForNextRound",
 			Handler:    _ArkService_RegisterOutputsForNextRound_Handler,
 		},
+		{
+			MethodName: "UpdateTxRequest",
+			Handler:    _ArkService_UpdateTxRequest_Handler,
+		},
+		{
+			MethodName: "ValidateTxRequest",
+			Handler:    _ArkService_ValidateTxRequest_Handler,
+		},
 		{
 			MethodName: "SubmitTreeNonces",
 			Handler:    _ArkService_SubmitTreeNonces_Handler,
@@ -533,6 +769,22 @@ var ArkService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitRedeemTx",
 			Handler:    _ArkService_SubmitRedeemTx_Handler,
 		},
+		{
+			MethodName: "ValidateRedeemTx",
+			Handler:    _ArkService_ValidateRedeemTx_Handler,
+		},
+		{
+			MethodName: "SubmitRedeemTxs",
+			Handler:    _ArkService_SubmitRedeemTxs_Handler,
+		},
+		{
+			MethodName: "AuthorizeRefresh",
+			Handler:    _ArkService_AuthorizeRefresh_Handler,
+		},
+		{
+			MethodName: "RevokeRefreshAuthorization",
+			Handler:    _ArkService_RevokeRefreshAuthorization_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -3,6 +3,7 @@ module github.com/ark-network/ark/client
 go 1.23.1
 
 require (
+	github.com/btcsuite/btcd/btcec/v2 v2.3.4
 	github.com/btcsuite/btcd/btcutil/psbt v1.1.9
 	github.com/urfave/cli/v2 v2.27.4
 	golang.org/x/term v0.29.0
@@ -10,7 +11,6 @@ require (
 
 require (
 	github.com/btcsuite/btcd v0.24.3-0.20240921052913-67b8efd3ba53 // indirect
-	github.com/btcsuite/btcd/btcec/v2 v2.3.4 // indirect
 	github.com/btcsuite/btcd/btcutil v1.1.5 // indirect
 	github.com/btcsuite/btcd/chaincfg/chainhash v1.1.0 // indirect
 	github.com/btcsuite/btclog v0.0.0-20170628155309-84c8d2346e9f // indirect
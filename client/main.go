@@ -9,11 +9,15 @@ import (
 	"os"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/note"
 	arksdk "github.com/ark-network/ark/pkg/client-sdk"
 	"github.com/ark-network/ark/pkg/client-sdk/store"
 	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/urfave/cli/v2"
 	"golang.org/x/term"
@@ -38,13 +42,19 @@ func main() {
 		&initCommand,
 		&configCommand,
 		&dumpCommand,
+		&exportSeedCommand,
+		&importSeedCommand,
 		&receiveCommand,
+		&addressesCommand,
 		&settleCmd,
 		&sendCommand,
 		&balanceCommand,
 		&redeemCommand,
 		&notesCommand,
 		&recoverCommand,
+		&decodeCommand,
+		&migrateServerKeyCommand,
+		&exitCostCommand,
 	)
 	app.Flags = []cli.Flag{
 		datadirFlag,
@@ -119,6 +129,10 @@ var (
 		Usage:   "UNSAFE: allow sending offchain transactions with zero fees, disable unilateral exit",
 		Value:   false,
 	}
+	feeRateFlag = &cli.Float64Flag{
+		Name:  "fee-rate",
+		Usage: "custom fee rate in sat/vbyte of the txs built by the client, must be at least the min relay fee rate",
+	}
 	enableExpiryCoinselectFlag = &cli.BoolFlag{
 		Name:  "enable-expiry-coinselect",
 		Usage: "select VTXOs about to expire first",
@@ -146,6 +160,39 @@ var (
 		Value:       false,
 		DefaultText: "false",
 	}
+	seedFileFlag = &cli.StringFlag{
+		Name:     "file",
+		Usage:    "path of the encrypted seed backup file",
+		Required: true,
+	}
+	scryptNFlag = &cli.IntFlag{
+		Name:  "scrypt-n",
+		Usage: "scrypt cost parameter N of the backup encryption key, must be a power of 2",
+		Value: wallet.DefaultScryptParams.N,
+	}
+	scryptRFlag = &cli.IntFlag{
+		Name:  "scrypt-r",
+		Usage: "scrypt block size parameter r of the backup encryption key",
+		Value: wallet.DefaultScryptParams.R,
+	}
+	scryptPFlag = &cli.IntFlag{
+		Name:  "scrypt-p",
+		Usage: "scrypt parallelization parameter p of the backup encryption key",
+		Value: wallet.DefaultScryptParams.P,
+	}
+	listRecoverableFlag = &cli.BoolFlag{
+		Name:  "list",
+		Usage: "only list the swept vtxos that can be recovered, without recovering them",
+	}
+	absorbDustChangeFlag = &cli.BoolFlag{
+		Name:  "absorb-dust-change",
+		Usage: "add to the onchain output the change that would be below dust instead of failing",
+	}
+	feeWindowFlag = &cli.DurationFlag{
+		Name:  "window",
+		Usage: "time window of the fee rates history used to estimate the fees",
+		Value: 24 * time.Hour,
+	}
 	completeFlag = &cli.BoolFlag{
 		Name:        "complete",
 		Usage:       "complete the unilateral exit after timelock expired",
@@ -178,6 +225,22 @@ var (
 		},
 		Flags: []cli.Flag{passwordFlag},
 	}
+	exportSeedCommand = cli.Command{
+		Name:  "export-seed",
+		Usage: "Exports the seed of the Ark wallet to a file encrypted with the wallet password",
+		Action: func(ctx *cli.Context) error {
+			return exportSeed(ctx)
+		},
+		Flags: []cli.Flag{passwordFlag, seedFileFlag, scryptNFlag, scryptRFlag, scryptPFlag},
+	}
+	importSeedCommand = cli.Command{
+		Name:  "import-seed",
+		Usage: "Initialize Ark wallet from an encrypted seed backup, connect to Ark server",
+		Action: func(ctx *cli.Context) error {
+			return importSeed(ctx)
+		},
+		Flags: []cli.Flag{networkFlag, passwordFlag, seedFileFlag, urlFlag, explorerFlag, restFlag},
+	}
 	receiveCommand = cli.Command{
 		Name:  "receive",
 		Usage: "Shows boarding and offchain addresses",
@@ -185,13 +248,20 @@ var (
 			return receive(ctx)
 		},
 	}
+	addressesCommand = cli.Command{
+		Name:  "addresses",
+		Usage: "Lists all addresses used by the wallet with their balance",
+		Action: func(ctx *cli.Context) error {
+			return addresses(ctx)
+		},
+	}
 	settleCmd = cli.Command{
 		Name:  "settle",
 		Usage: "Settle onboarding or pending funds",
 		Action: func(ctx *cli.Context) error {
 			return settle(ctx)
 		},
-		Flags: []cli.Flag{passwordFlag},
+		Flags: []cli.Flag{passwordFlag, feeRateFlag},
 	}
 	balanceCommand = cli.Command{
 		Name:  "balance",
@@ -207,12 +277,15 @@ var (
 		Action: func(ctx *cli.Context) error {
 			return send(ctx)
 		},
-		Flags: []cli.Flag{receiversFlag, toFlag, amountFlag, enableExpiryCoinselectFlag, passwordFlag, zeroFeesFlag},
+		Flags: []cli.Flag{receiversFlag, toFlag, amountFlag, enableExpiryCoinselectFlag, passwordFlag, zeroFeesFlag, feeRateFlag},
 	}
 	redeemCommand = cli.Command{
 		Name:  "redeem",
 		Usage: "Redeem offchain funds, collaboratively or unilaterally",
-		Flags: []cli.Flag{addressFlag, amountToRedeemFlag, forceFlag, passwordFlag, completeFlag},
+		Flags: []cli.Flag{
+			addressFlag, amountToRedeemFlag, forceFlag, passwordFlag, completeFlag, feeRateFlag,
+			absorbDustChangeFlag,
+		},
 		Action: func(ctx *cli.Context) error {
 			return redeem(ctx)
 		},
@@ -229,11 +302,35 @@ var (
 	recoverCommand = cli.Command{
 		Name:  "recover",
 		Usage: "Recover unspent and swept vtxos",
-		Flags: []cli.Flag{passwordFlag},
+		Flags: []cli.Flag{passwordFlag, listRecoverableFlag},
 		Action: func(ctx *cli.Context) error {
 			return recoverVtxos(ctx)
 		},
 	}
+	migrateServerKeyCommand = cli.Command{
+		Name:  "migrate-server-key",
+		Usage: "Migrate the wallet to the new pubkey of the Ark server, settling its funds into it",
+		Flags: []cli.Flag{passwordFlag},
+		Action: func(ctx *cli.Context) error {
+			return migrateServerKey(ctx)
+		},
+	}
+	exitCostCommand = cli.Command{
+		Name:  "exit-cost",
+		Usage: "Estimate the best, typical and worst case fees of a unilateral exit",
+		Flags: []cli.Flag{feeWindowFlag},
+		Action: func(ctx *cli.Context) error {
+			return exitCost(ctx)
+		},
+	}
+	decodeCommand = cli.Command{
+		Name:      "decode",
+		Usage:     "Decode an Ark address or note",
+		ArgsUsage: "<address|note>",
+		Action: func(ctx *cli.Context) error {
+			return decode(ctx)
+		},
+	}
 )
 
 func initArkSdk(ctx *cli.Context) error {
@@ -282,6 +379,8 @@ func config(ctx *cli.Context) error {
 		"utxo_max_amount":              cfgData.UtxoMaxAmount,
 		"vtxo_min_amount":              cfgData.VtxoMinAmount,
 		"vtxo_max_amount":              cfgData.VtxoMaxAmount,
+		"settle_min_amount":            cfgData.SettleMinAmount,
+		"settle_max_amount":            cfgData.SettleMaxAmount,
 	}
 
 	return printJSON(cfg)
@@ -306,6 +405,72 @@ func dumpPrivKey(ctx *cli.Context) error {
 	})
 }
 
+func exportSeed(ctx *cli.Context) error {
+	file := ctx.String(seedFileFlag.Name)
+	if _, err := os.Stat(file); err == nil {
+		return fmt.Errorf("file %s already exists", file)
+	}
+
+	password, err := readPassword(ctx)
+	if err != nil {
+		return err
+	}
+	if err := arkSdkClient.Unlock(ctx.Context, string(password)); err != nil {
+		return err
+	}
+
+	encryptedSeed, err := arkSdkClient.ExportEncryptedSeed(
+		ctx.Context, string(password), arksdk.WithScryptParams(
+			ctx.Int(scryptNFlag.Name), ctx.Int(scryptRFlag.Name), ctx.Int(scryptPFlag.Name),
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	// O_EXCL makes sure not to overwrite any file created in the meantime
+	f, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(encryptedSeed); err != nil {
+		return err
+	}
+
+	return printJSON(map[string]interface{}{
+		"file": file,
+	})
+}
+
+func importSeed(ctx *cli.Context) error {
+	encryptedSeed, err := os.ReadFile(ctx.String(seedFileFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	password, err := readPassword(ctx)
+	if err != nil {
+		return err
+	}
+
+	clientType := arksdk.GrpcClient
+	if ctx.Bool(restFlag.Name) {
+		clientType = arksdk.RestClient
+	}
+
+	return arkSdkClient.ImportEncryptedSeed(
+		ctx.Context, strings.TrimSpace(string(encryptedSeed)), arksdk.InitArgs{
+			ClientType:  clientType,
+			WalletType:  arksdk.SingleKeyWallet,
+			ServerUrl:   ctx.String(urlFlag.Name),
+			Password:    string(password),
+			ExplorerURL: ctx.String(explorerFlag.Name),
+		},
+	)
+}
+
 func receive(ctx *cli.Context) error {
 	offchainAddr, boardingAddr, err := arkSdkClient.Receive(ctx.Context)
 	if err != nil {
@@ -317,6 +482,14 @@ func receive(ctx *cli.Context) error {
 	})
 }
 
+func addresses(ctx *cli.Context) error {
+	list, err := arkSdkClient.ListAddresses(ctx.Context)
+	if err != nil {
+		return err
+	}
+	return printJSON(list)
+}
+
 func settle(ctx *cli.Context) error {
 	password, err := readPassword(ctx)
 	if err != nil {
@@ -326,7 +499,12 @@ func settle(ctx *cli.Context) error {
 		return err
 	}
 
-	txID, err := arkSdkClient.Settle(ctx.Context)
+	opts, err := feeRateOptions(ctx)
+	if err != nil {
+		return err
+	}
+
+	txID, err := arkSdkClient.Settle(ctx.Context, opts...)
 	if err != nil {
 		return err
 	}
@@ -375,6 +553,14 @@ func balance(ctx *cli.Context) error {
 	return printJSON(bal)
 }
 
+func exitCost(ctx *cli.Context) error {
+	cost, err := arkSdkClient.EstimateExitCost(ctx.Context, ctx.Duration(feeWindowFlag.Name))
+	if err != nil {
+		return err
+	}
+	return printJSON(cost)
+}
+
 func redeem(ctx *cli.Context) error {
 	password, err := readPassword(ctx)
 	if err != nil {
@@ -393,6 +579,15 @@ func redeem(ctx *cli.Context) error {
 	if force && complete {
 		return fmt.Errorf("cannot use --force and --complete at the same time")
 	}
+	// the txs of a unilateral exit are presigned, their fee can't be changed
+	if force && ctx.IsSet(feeRateFlag.Name) {
+		return fmt.Errorf("cannot use --force and --fee-rate at the same time")
+	}
+
+	opts, err := feeRateOptions(ctx)
+	if err != nil {
+		return err
+	}
 
 	if force {
 		return arkSdkClient.StartUnilateralExit(ctx.Context)
@@ -403,20 +598,33 @@ func redeem(ctx *cli.Context) error {
 	}
 
 	if complete {
-		txID, err := arkSdkClient.CompleteUnilateralExit(ctx.Context, address)
+		txID, locked, err := arkSdkClient.CompleteUnilateralExit(ctx.Context, address, opts...)
 		if err != nil {
 			return err
 		}
+		lockedOutputs := make([]map[string]interface{}, 0, len(locked))
+		for _, u := range locked {
+			lockedOutputs = append(lockedOutputs, map[string]interface{}{
+				"txid":         u.Txid,
+				"vout":         u.VOut,
+				"amount":       u.Amount,
+				"spendable_at": u.SpendableAt.Format(time.RFC3339),
+			})
+		}
 		return printJSON(map[string]interface{}{
-			"txid": txID,
+			"txid":   txID,
+			"locked": lockedOutputs,
 		})
 	}
 
 	if amount == 0 {
 		return fmt.Errorf("missing amount")
 	}
+	if ctx.Bool(absorbDustChangeFlag.Name) {
+		opts = append(opts, arksdk.WithDustChangeAbsorbed)
+	}
 	txID, err := arkSdkClient.CollaborativeExit(
-		ctx.Context, address, amount, computeExpiration,
+		ctx.Context, address, amount, computeExpiration, opts...,
 	)
 	if err != nil {
 		return err
@@ -427,6 +635,21 @@ func redeem(ctx *cli.Context) error {
 }
 
 func recoverVtxos(ctx *cli.Context) error {
+	if ctx.Bool(listRecoverableFlag.Name) {
+		recoverableVtxos, err := arkSdkClient.ListRecoverable(ctx.Context)
+		if err != nil {
+			return err
+		}
+		totalAmount := uint64(0)
+		for _, vtxo := range recoverableVtxos {
+			totalAmount += vtxo.Amount
+		}
+		return printJSON(map[string]interface{}{
+			"vtxos":        recoverableVtxos,
+			"total_amount": totalAmount,
+		})
+	}
+
 	password, err := readPassword(ctx)
 	if err != nil {
 		return err
@@ -464,6 +687,28 @@ func redeemNotes(ctx *cli.Context) error {
 	})
 }
 
+func migrateServerKey(ctx *cli.Context) error {
+	password, err := readPassword(ctx)
+	if err != nil {
+		return err
+	}
+	if err := arkSdkClient.Unlock(ctx.Context, string(password)); err != nil {
+		return err
+	}
+
+	if err := arkSdkClient.MigrateServerKey(ctx.Context); err != nil {
+		return err
+	}
+
+	cfgData, err := arkSdkClient.GetConfigData(ctx.Context)
+	if err != nil {
+		return err
+	}
+	return printJSON(map[string]interface{}{
+		"server_pubkey": hex.EncodeToString(cfgData.ServerPubKey.SerializeCompressed()),
+	})
+}
+
 func getArkSdkClient(ctx *cli.Context) (arksdk.ArkClient, error) {
 	dataDir := ctx.String(datadirFlag.Name)
 	sdkRepository, err := store.NewStore(store.Config{
@@ -529,10 +774,16 @@ func sendCovenantLess(ctx *cli.Context, receivers []arksdk.Receiver, withZeroFee
 		}
 	}
 
+	opts, err := feeRateOptions(ctx)
+	if err != nil {
+		return err
+	}
+
 	computeExpiration := ctx.Bool(enableExpiryCoinselectFlag.Name)
 	if len(onchainReceivers) > 0 {
 		txid, err := arkSdkClient.CollaborativeExit(
 			ctx.Context, onchainReceivers[0].To(), onchainReceivers[0].Amount(), computeExpiration,
+			opts...,
 		)
 		if err != nil {
 			return err
@@ -541,7 +792,7 @@ func sendCovenantLess(ctx *cli.Context, receivers []arksdk.Receiver, withZeroFee
 	}
 
 	redeemTx, err := arkSdkClient.SendOffChain(
-		ctx.Context, computeExpiration, offchainReceivers, withZeroFees,
+		ctx.Context, computeExpiration, offchainReceivers, withZeroFees, opts...,
 	)
 	if err != nil {
 		return err
@@ -554,6 +805,19 @@ func sendCovenantLess(ctx *cli.Context, receivers []arksdk.Receiver, withZeroFee
 	return printJSON(map[string]string{"txid": ptx.UnsignedTx.TxHash().String()})
 }
 
+func feeRateOptions(ctx *cli.Context) ([]arksdk.Option, error) {
+	if !ctx.IsSet(feeRateFlag.Name) {
+		return nil, nil
+	}
+
+	feeRate := arksdk.WithFeeRate(ctx.Float64(feeRateFlag.Name))
+	// validate the fee rate upfront rather than when building the txs
+	if err := feeRate(&arksdk.SendOptions{}); err != nil {
+		return nil, err
+	}
+	return []arksdk.Option{feeRate}, nil
+}
+
 func readPassword(ctx *cli.Context) ([]byte, error) {
 	password := []byte(ctx.String("password"))
 	if len(password) == 0 {
@@ -568,6 +832,55 @@ func readPassword(ctx *cli.Context) ([]byte, error) {
 	return password, nil
 }
 
+func decode(ctx *cli.Context) error {
+	if ctx.NArg() != 1 {
+		return fmt.Errorf("expected exactly one address or note to decode")
+	}
+	str := strings.TrimSpace(ctx.Args().First())
+
+	if note.IsNote(str) {
+		n, err := note.NewFromString(str)
+		if err != nil {
+			return fmt.Errorf("invalid note: %s", err)
+		}
+
+		resp := map[string]interface{}{
+			"type":      "note",
+			"id":        n.ID,
+			"value":     n.Value,
+			"signature": hex.EncodeToString(n.Signature),
+		}
+		if validFrom := n.ValidFrom(); !validFrom.IsZero() {
+			resp["not_before"] = validFrom.UTC().Format(time.RFC3339)
+		}
+		return printJSON(resp)
+	}
+
+	addr, err := common.DecodeAddress(str)
+	if err != nil {
+		return fmt.Errorf("invalid address: %s", err)
+	}
+
+	// the testnet hrp is shared by all the test networks
+	networks := make([]string, 0)
+	for _, network := range []common.Network{
+		common.Bitcoin, common.BitcoinTestNet, common.BitcoinTestNet4,
+		common.BitcoinSigNet, common.BitcoinMutinyNet, common.BitcoinRegTest,
+	} {
+		if network.Addr == addr.HRP {
+			networks = append(networks, network.Name)
+		}
+	}
+
+	return printJSON(map[string]interface{}{
+		"type":          "address",
+		"hrp":           addr.HRP,
+		"networks":      networks,
+		"server_pubkey": hex.EncodeToString(schnorr.SerializePubKey(addr.Server)),
+		"vtxo_tap_key":  hex.EncodeToString(schnorr.SerializePubKey(addr.VtxoTapKey)),
+	})
+}
+
 func printJSON(resp interface{}) error {
 	jsonBytes, err := json.MarshalIndent(resp, "", "\t")
 	if err != nil {
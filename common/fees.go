@@ -11,6 +11,8 @@ import (
 	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
+const witnessScaleFactor = 4
+
 var TreeTxSize = (&input.TxWeightEstimator{}).
 	AddTaprootKeySpendInput(txscript.SigHashDefault). // parent
 	AddP2TROutput().                                  // left child
@@ -25,6 +27,17 @@ var ConnectorTxSize = (&input.TxWeightEstimator{}).
 	AddP2TROutput().
 	VSize()
 
+// ConnectorTxVSize returns the vsize of a tx of the connectors tree with the
+// given number of outputs.
+func ConnectorTxVSize(numOutputs int) int {
+	estimator := (&input.TxWeightEstimator{}).
+		AddTaprootKeySpendInput(txscript.SigHashDefault)
+	for i := 0; i < numOutputs; i++ {
+		estimator.AddP2TROutput()
+	}
+	return estimator.VSize()
+}
+
 func ComputeForfeitTxFee(
 	feeRate chainfee.SatPerKVByte,
 	tapscript *waddrmgr.Tapscript,
@@ -57,6 +70,13 @@ func ComputeForfeitTxFee(
 	return uint64(feeRate.FeeForVSize(lntypes.VByte(txWeightEstimator.VSize())).ToUnit(btcutil.AmountSatoshi)), nil
 }
 
+// ComputeRedeemTxFee returns the fee of a redeem tx spending the given vtxos
+// into numOutputs P2TR outputs at the given fee rate.
+// The computation is deterministic so that anyone given the same inputs,
+// number of outputs and fee rate gets the same fee amount:
+//   - the weight is estimated from the exact witness size of every input;
+//   - the vsize is the weight divided by 4, rounded up;
+//   - the fee is vsize * feeRate / 1000, rounded up to the next satoshi.
 func ComputeRedeemTxFee(
 	feeRate chainfee.SatPerKVByte,
 	vtxos []VtxoInput,
@@ -65,23 +85,68 @@ func ComputeRedeemTxFee(
 	if len(vtxos) <= 0 {
 		return 0, fmt.Errorf("missing vtxos")
 	}
+	if numOutputs <= 0 {
+		return 0, fmt.Errorf("missing outputs")
+	}
 
-	redeemTxWeightEstimator := &input.TxWeightEstimator{}
+	redeemTxWeightEstimator, err := redeemTxInputsWeight(vtxos)
+	if err != nil {
+		return 0, err
+	}
+
+	// Estimate outputs
+	for i := 0; i < numOutputs; i++ {
+		redeemTxWeightEstimator.AddP2TROutput()
+	}
+
+	return computeFee(feeRate, redeemTxWeightEstimator.Weight()), nil
+}
+
+// ComputeRedeemTxFeeWithOutputs is like ComputeRedeemTxFee for outputs of any
+// type, the size of every output is estimated from its script.
+// For P2TR outputs, it returns the same fee as ComputeRedeemTxFee.
+func ComputeRedeemTxFeeWithOutputs(
+	feeRate chainfee.SatPerKVByte,
+	vtxos []VtxoInput,
+	outputScripts [][]byte,
+) (int64, error) {
+	if len(vtxos) <= 0 {
+		return 0, fmt.Errorf("missing vtxos")
+	}
+	if len(outputScripts) <= 0 {
+		return 0, fmt.Errorf("missing outputs")
+	}
+
+	redeemTxWeightEstimator, err := redeemTxInputsWeight(vtxos)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, script := range outputScripts {
+		redeemTxWeightEstimator.AddOutput(script)
+	}
 
-	// Estimate inputs
+	return computeFee(feeRate, redeemTxWeightEstimator.Weight()), nil
+}
+
+// redeemTxInputsWeight returns an estimator with the given vtxos spent with
+// their tapscript.
+func redeemTxInputsWeight(vtxos []VtxoInput) (*input.TxWeightEstimator, error) {
+	redeemTxWeightEstimator := &input.TxWeightEstimator{}
 	for _, vtxo := range vtxos {
 		if vtxo.Tapscript == nil {
 			txid := vtxo.Outpoint.Hash.String()
-			return 0, fmt.Errorf("missing tapscript for vtxo %s", txid)
+			return nil, fmt.Errorf("missing tapscript for vtxo %s", txid)
 		}
 
 		redeemTxWeightEstimator.AddTapscriptInput(lntypes.WeightUnit(vtxo.WitnessSize), vtxo.Tapscript)
 	}
+	return redeemTxWeightEstimator, nil
+}
 
-	// Estimate outputs
-	for i := 0; i < numOutputs; i++ {
-		redeemTxWeightEstimator.AddP2TROutput()
-	}
-
-	return int64(feeRate.FeeForVSize(lntypes.VByte(redeemTxWeightEstimator.VSize())).ToUnit(btcutil.AmountSatoshi)), nil
+// computeFee returns the fee for the given weight, rounding both the vsize and
+// the fee amount up.
+func computeFee(feeRate chainfee.SatPerKVByte, weight lntypes.WeightUnit) int64 {
+	vsize := (int64(weight) + witnessScaleFactor - 1) / witnessScaleFactor
+	return (vsize*int64(feeRate) + 999) / 1000
 }
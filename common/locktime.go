@@ -2,6 +2,8 @@ package common
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	"github.com/btcsuite/btcd/blockchain"
 	"github.com/btcsuite/btcd/txscript"
@@ -34,16 +36,112 @@ const (
 // it is used as argument to a CheckLocktimeVerify opcode
 type AbsoluteLocktime uint32
 
+// AbsoluteLocktimeFromHeight returns the locktime expiring at the given
+// block height.
+func AbsoluteLocktimeFromHeight(height uint32) (AbsoluteLocktime, error) {
+	if height >= nLocktimeMinSeconds {
+		return 0, fmt.Errorf("block height too large, max is %d", nLocktimeMinSeconds-1)
+	}
+	return AbsoluteLocktime(height), nil
+}
+
+// AbsoluteLocktimeFromTime returns the locktime expiring at the given time.
+func AbsoluteLocktimeFromTime(t time.Time) (AbsoluteLocktime, error) {
+	timestamp := t.Unix()
+	if timestamp < nLocktimeMinSeconds || timestamp > math.MaxUint32 {
+		return 0, fmt.Errorf(
+			"time out of range, must be between %d and %d", nLocktimeMinSeconds, uint32(math.MaxUint32),
+		)
+	}
+	return AbsoluteLocktime(timestamp), nil
+}
+
+// AbsoluteLocktimeAfterBlocks returns the locktime expiring the given number
+// of blocks after the current height.
+func AbsoluteLocktimeAfterBlocks(currentHeight, blocks uint32) (AbsoluteLocktime, error) {
+	height := uint64(currentHeight) + uint64(blocks)
+	if height >= nLocktimeMinSeconds {
+		return 0, fmt.Errorf("block height too large, max is %d", nLocktimeMinSeconds-1)
+	}
+	return AbsoluteLocktime(height), nil
+}
+
+// AbsoluteLocktimeAfter returns the locktime expiring the given duration
+// after the current time.
+func AbsoluteLocktimeAfter(now time.Time, duration time.Duration) (AbsoluteLocktime, error) {
+	return AbsoluteLocktimeFromTime(now.Add(duration))
+}
+
 func (l AbsoluteLocktime) IsSeconds() bool {
 	return l >= nLocktimeMinSeconds
 }
 
+// IsLocked returns whether the locktime is not expired yet at the given block
+// height and time. Only one of the two is relevant depending on the type of
+// the locktime.
+func (l AbsoluteLocktime) IsLocked(currentHeight uint32, currentTime int64) bool {
+	if l.IsSeconds() {
+		return int64(l) > currentTime
+	}
+	return uint32(l) > currentHeight
+}
+
+// ValidateInFuture returns an error if the locktime is already expired at the
+// given block height and time.
+func (l AbsoluteLocktime) ValidateInFuture(currentHeight uint32, currentTime int64) error {
+	if l.IsLocked(currentHeight, currentTime) {
+		return nil
+	}
+	if l.IsSeconds() {
+		return fmt.Errorf("locktime %d is in the past, current time is %d", l, currentTime)
+	}
+	return fmt.Errorf("locktime %d is in the past, current block height is %d", l, currentHeight)
+}
+
+// BlocksUntil returns the number of blocks to be mined after the current height
+// for a block height locktime to expire, 0 if already expired.
+func (l AbsoluteLocktime) BlocksUntil(currentHeight uint32) (uint32, error) {
+	if l.IsSeconds() {
+		return 0, fmt.Errorf("locktime %d is not a block height", l)
+	}
+	if uint32(l) <= currentHeight {
+		return 0, nil
+	}
+	return uint32(l) - currentHeight, nil
+}
+
+// TimeUntil returns the time left before a time based locktime expires, 0 if
+// already expired.
+func (l AbsoluteLocktime) TimeUntil(now time.Time) (time.Duration, error) {
+	if !l.IsSeconds() {
+		return 0, fmt.Errorf("locktime %d is not a timestamp", l)
+	}
+	left := time.Unix(int64(l), 0).Sub(now)
+	if left < 0 {
+		return 0, nil
+	}
+	return left, nil
+}
+
 // RelativeLocktime represents a BIP68 relative timelock value
 type RelativeLocktime struct {
 	Type  RelativeLocktimeType
 	Value uint32
 }
 
+// RelativeLocktimeFromDuration returns the time based relative locktime of
+// the given duration, rounded up to the BIP68 granularity of 512 seconds.
+func RelativeLocktimeFromDuration(duration time.Duration) (RelativeLocktime, error) {
+	if duration <= 0 {
+		return RelativeLocktime{}, fmt.Errorf("duration must be positive")
+	}
+	seconds := int64(math.Ceil(duration.Seconds()/SECONDS_MOD)) * SECONDS_MOD
+	if seconds > SECONDS_MAX {
+		return RelativeLocktime{}, fmt.Errorf("duration too large, max is %d seconds", SECONDS_MAX)
+	}
+	return RelativeLocktime{Type: LocktimeTypeSecond, Value: uint32(seconds)}, nil
+}
+
 func (l RelativeLocktime) Seconds() int64 {
 	if l.Type == LocktimeTypeBlock {
 		return int64(l.Value) * SECONDS_PER_BLOCK
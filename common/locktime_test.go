@@ -0,0 +1,91 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	common "github.com/ark-network/ark/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAbsoluteLocktime(t *testing.T) {
+	currentHeight := uint32(100)
+	now := time.Unix(1_700_000_000, 0)
+
+	t.Run("block height", func(t *testing.T) {
+		locktime, err := common.AbsoluteLocktimeAfterBlocks(currentHeight, 10)
+		require.NoError(t, err)
+		require.Equal(t, common.AbsoluteLocktime(110), locktime)
+		require.False(t, locktime.IsSeconds())
+
+		blocks, err := locktime.BlocksUntil(currentHeight)
+		require.NoError(t, err)
+		require.Equal(t, uint32(10), blocks)
+
+		require.True(t, locktime.IsLocked(currentHeight, now.Unix()))
+		require.NoError(t, locktime.ValidateInFuture(currentHeight, now.Unix()))
+
+		// the timestamp is irrelevant for a block height locktime
+		require.False(t, locktime.IsLocked(110, 0))
+		require.Error(t, locktime.ValidateInFuture(110, 0))
+
+		blocks, err = locktime.BlocksUntil(200)
+		require.NoError(t, err)
+		require.Zero(t, blocks)
+
+		_, err = locktime.TimeUntil(now)
+		require.Error(t, err)
+
+		_, err = common.AbsoluteLocktimeAfterBlocks(499_999_990, 10)
+		require.Error(t, err)
+		_, err = common.AbsoluteLocktimeFromHeight(500_000_000)
+		require.Error(t, err)
+	})
+
+	t.Run("time", func(t *testing.T) {
+		locktime, err := common.AbsoluteLocktimeAfter(now, time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, common.AbsoluteLocktime(now.Add(time.Hour).Unix()), locktime)
+		require.True(t, locktime.IsSeconds())
+
+		left, err := locktime.TimeUntil(now)
+		require.NoError(t, err)
+		require.Equal(t, time.Hour, left)
+
+		require.True(t, locktime.IsLocked(currentHeight, now.Unix()))
+		require.NoError(t, locktime.ValidateInFuture(currentHeight, now.Unix()))
+
+		later := now.Add(2 * time.Hour)
+		require.False(t, locktime.IsLocked(currentHeight, later.Unix()))
+		require.Error(t, locktime.ValidateInFuture(currentHeight, later.Unix()))
+
+		left, err = locktime.TimeUntil(later)
+		require.NoError(t, err)
+		require.Zero(t, left)
+
+		_, err = locktime.BlocksUntil(currentHeight)
+		require.Error(t, err)
+
+		_, err = common.AbsoluteLocktimeFromTime(time.Unix(1000, 0))
+		require.Error(t, err)
+	})
+}
+
+func TestRelativeLocktimeFromDuration(t *testing.T) {
+	locktime, err := common.RelativeLocktimeFromDuration(512 * time.Second)
+	require.NoError(t, err)
+	require.Equal(t, common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512}, locktime)
+
+	// rounded up to a multiple of 512 seconds
+	locktime, err = common.RelativeLocktimeFromDuration(time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, uint32(4096), locktime.Value)
+
+	_, err = common.BIP68Sequence(locktime)
+	require.NoError(t, err)
+
+	_, err = common.RelativeLocktimeFromDuration(0)
+	require.Error(t, err)
+	_, err = common.RelativeLocktimeFromDuration(time.Duration(common.SECONDS_MAX+1) * time.Second)
+	require.Error(t, err)
+}
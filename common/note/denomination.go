@@ -0,0 +1,71 @@
+package note
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Denomination is one of the fixed values notes can be issued with.
+type Denomination uint32
+
+// DenominationSet is the set of values notes can be issued with.
+type DenominationSet map[Denomination]struct{}
+
+// NewDenominationSet returns the set of the given denominations, which must be
+// greater than zero and unique.
+func NewDenominationSet(values ...uint32) (DenominationSet, error) {
+	set := make(DenominationSet, len(values))
+	for _, value := range values {
+		if value == 0 {
+			return nil, fmt.Errorf("invalid denomination, must be greater than 0")
+		}
+		if _, ok := set[Denomination(value)]; ok {
+			return nil, fmt.Errorf("duplicated denomination %d", value)
+		}
+		set[Denomination(value)] = struct{}{}
+	}
+	return set, nil
+}
+
+// Contains returns whether the given value is one of the denominations.
+func (s DenominationSet) Contains(value uint32) bool {
+	_, ok := s[Denomination(value)]
+	return ok
+}
+
+// Values returns the denominations in ascending order.
+func (s DenominationSet) Values() []uint32 {
+	values := make([]uint32, 0, len(s))
+	for denomination := range s {
+		values = append(values, uint32(denomination))
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+// ErrInvalidDenomination is returned when issuing a note with a value that
+// doesn't match any of the configured denominations.
+type ErrInvalidDenomination struct {
+	Value         uint32
+	Denominations []uint32
+}
+
+func (e ErrInvalidDenomination) Error() string {
+	return fmt.Sprintf(
+		"invalid note value %d, must be one of the denominations %v",
+		e.Value, e.Denominations,
+	)
+}
+
+// WithDenominations makes the issuance fail with ErrInvalidDenomination if the
+// value of the note is not in the given set. An empty set allows any value.
+func WithDenominations(denominations DenominationSet) Option {
+	return func(o *options) {
+		o.denominations = denominations
+	}
+}
+
+// Denomination returns the denomination of the note, its value.
+func (n *Data) Denomination() Denomination {
+	return Denomination(n.Value)
+}
@@ -6,11 +6,43 @@ import (
 	"encoding/binary"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/btcsuite/btcd/btcutil/base58"
 )
 
-const noteHRP = "arknote"
+const (
+	noteHRP = "arknote"
+	// timeLockedNoteHRP is the prefix of the notes with a not-before timestamp.
+	// It doesn't share the prefix of plain notes so the two can't be mistaken.
+	timeLockedNoteHRP = "arktlnote"
+	// expiringNoteHRP is the prefix of the notes with an expiry, and optionally
+	// a not-before timestamp.
+	expiringNoteHRP = "arkexpnote"
+
+	dataSize           = 12
+	timeLockedDataSize = 20
+	expiringDataSize   = 28
+)
+
+// ErrNoteNotYetValid is returned when redeeming a note before the time it
+// becomes valid.
+type ErrNoteNotYetValid struct {
+	ValidFrom time.Time
+}
+
+func (e ErrNoteNotYetValid) Error() string {
+	return fmt.Sprintf("note not valid until %s", e.ValidFrom.UTC().Format(time.RFC3339))
+}
+
+// ErrNoteExpired is returned when redeeming a note after its expiry.
+type ErrNoteExpired struct {
+	ExpiredAt time.Time
+}
+
+func (e ErrNoteExpired) Error() string {
+	return fmt.Sprintf("note expired at %s", e.ExpiredAt.UTC().Format(time.RFC3339))
+}
 
 // Note represents a note signed by the issuer
 type Note struct {
@@ -22,11 +54,50 @@ type Note struct {
 type Data struct {
 	ID    uint64
 	Value uint32
+	// NotBefore is the unix timestamp from which the note can be redeemed,
+	// 0 means the note is valid since its creation.
+	NotBefore int64
+	// ExpiresAt is the unix timestamp from which the note can't be redeemed
+	// anymore, 0 means the note never expires.
+	ExpiresAt int64
+}
+
+// Option customizes the issuance of a note.
+type Option func(*options)
+
+type options struct {
+	denominations DenominationSet
+	expiresAt     int64
+}
+
+// WithExpiry makes the note redeemable only before the given time.
+func WithExpiry(expiresAt time.Time) Option {
+	return func(o *options) {
+		o.expiresAt = expiresAt.Unix()
+	}
+}
+
+func (o options) validate(value uint32) error {
+	if len(o.denominations) > 0 && !o.denominations.Contains(value) {
+		return ErrInvalidDenomination{value, o.denominations.Values()}
+	}
+	if o.expiresAt < 0 {
+		return fmt.Errorf("invalid expiry %d", o.expiresAt)
+	}
+	return nil
 }
 
 // New generate a new note data struct with a random ID and the given value
 // it must be signed by the issuer and then converted to a Note using Data.ToNote(signature)
-func New(value uint32) (*Data, error) {
+func New(value uint32, opts ...Option) (*Data, error) {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if err := o.validate(value); err != nil {
+		return nil, err
+	}
+
 	randomBytes := make([]byte, 8)
 	_, err := rand.Read(randomBytes)
 	if err != nil {
@@ -36,25 +107,59 @@ func New(value uint32) (*Data, error) {
 	id := binary.BigEndian.Uint64(randomBytes)
 
 	return &Data{
-		ID:    id,
-		Value: value,
+		ID:        id,
+		Value:     value,
+		ExpiresAt: o.expiresAt,
 	}, nil
 }
 
+// NewTimeLocked generates a new note data struct like New, that can't be
+// redeemed before the given time.
+func NewTimeLocked(value uint32, notBefore time.Time, opts ...Option) (*Data, error) {
+	if notBefore.Unix() <= 0 {
+		return nil, fmt.Errorf("invalid not-before time %s", notBefore)
+	}
+
+	data, err := New(value, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if data.ExpiresAt > 0 && data.ExpiresAt <= notBefore.Unix() {
+		return nil, fmt.Errorf("invalid expiry, must be after the not-before time")
+	}
+
+	data.NotBefore = notBefore.Unix()
+	return data, nil
+}
+
+// IsNote returns whether the given string has the human-readable part of a
+// note, without validating the rest of it.
+func IsNote(s string) bool {
+	return strings.HasPrefix(s, noteHRP) || strings.HasPrefix(s, timeLockedNoteHRP) ||
+		strings.HasPrefix(s, expiringNoteHRP)
+}
+
 // NewFromString converts a base58 encoded string with HRP to a Note
 func NewFromString(s string) (*Note, error) {
-	if !strings.HasPrefix(s, noteHRP) {
+	hrp, size := noteHRP, dataSize
+	if strings.HasPrefix(s, timeLockedNoteHRP) {
+		hrp, size = timeLockedNoteHRP, timeLockedDataSize
+	}
+	if strings.HasPrefix(s, expiringNoteHRP) {
+		hrp, size = expiringNoteHRP, expiringDataSize
+	}
+	if !strings.HasPrefix(s, hrp) {
 		return nil, fmt.Errorf("invalid human-readable part: expected %s prefix (note '%s')", noteHRP, s)
 	}
 
-	encoded := strings.TrimPrefix(s, noteHRP)
+	encoded := strings.TrimPrefix(s, hrp)
 	decoded := base58.Decode(encoded)
 	if len(decoded) == 0 {
 		return nil, fmt.Errorf("failed to decode base58 string")
 	}
 
 	note := &Note{}
-	err := note.Deserialize(decoded)
+	err := note.deserialize(decoded, size)
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize note: %w", err)
 	}
@@ -62,22 +167,104 @@ func NewFromString(s string) (*Note, error) {
 	return note, nil
 }
 
-// Serialize converts Data to a byte slice
+// Serialize converts Data to a byte slice. The not-before and expiry
+// timestamps are appended only if set, so that notes without them keep the
+// original format.
 func (n *Data) Serialize() []byte {
-	buf := make([]byte, 12)
+	if n.ExpiresAt > 0 {
+		buf := make([]byte, expiringDataSize)
+		binary.BigEndian.PutUint64(buf[:8], n.ID)
+		binary.BigEndian.PutUint32(buf[8:12], n.Value)
+		binary.BigEndian.PutUint64(buf[12:20], uint64(n.NotBefore))
+		binary.BigEndian.PutUint64(buf[20:], uint64(n.ExpiresAt))
+		return buf
+	}
+
+	if n.NotBefore == 0 {
+		buf := make([]byte, dataSize)
+		binary.BigEndian.PutUint64(buf[:8], n.ID)
+		binary.BigEndian.PutUint32(buf[8:], n.Value)
+		return buf
+	}
+
+	buf := make([]byte, timeLockedDataSize)
 	binary.BigEndian.PutUint64(buf[:8], n.ID)
-	binary.BigEndian.PutUint32(buf[8:], n.Value)
+	binary.BigEndian.PutUint32(buf[8:12], n.Value)
+	binary.BigEndian.PutUint64(buf[12:], uint64(n.NotBefore))
 	return buf
 }
 
 // Deserialize converts a byte slice to Data
 func (n *Data) Deserialize(data []byte) error {
-	if len(data) != 12 {
-		return fmt.Errorf("invalid data length: expected 12 bytes, got %d", len(data))
+	switch len(data) {
+	case dataSize:
+		n.ID = binary.BigEndian.Uint64(data[:8])
+		n.Value = binary.BigEndian.Uint32(data[8:])
+		n.NotBefore = 0
+		n.ExpiresAt = 0
+		return nil
+	case timeLockedDataSize:
+		notBefore := int64(binary.BigEndian.Uint64(data[12:]))
+		// a note without not-before timestamp must use the shorter format
+		if notBefore <= 0 {
+			return fmt.Errorf("invalid not-before timestamp %d", notBefore)
+		}
+		n.ID = binary.BigEndian.Uint64(data[:8])
+		n.Value = binary.BigEndian.Uint32(data[8:12])
+		n.NotBefore = notBefore
+		n.ExpiresAt = 0
+		return nil
+	case expiringDataSize:
+		notBefore := int64(binary.BigEndian.Uint64(data[12:20]))
+		expiresAt := int64(binary.BigEndian.Uint64(data[20:]))
+		// a note without expiry must use one of the shorter formats
+		if expiresAt <= 0 {
+			return fmt.Errorf("invalid expiry timestamp %d", expiresAt)
+		}
+		if notBefore < 0 || notBefore >= expiresAt {
+			return fmt.Errorf("invalid not-before timestamp %d", notBefore)
+		}
+		n.ID = binary.BigEndian.Uint64(data[:8])
+		n.Value = binary.BigEndian.Uint32(data[8:12])
+		n.NotBefore = notBefore
+		n.ExpiresAt = expiresAt
+		return nil
+	default:
+		return fmt.Errorf(
+			"invalid data length: expected %d, %d or %d bytes, got %d",
+			dataSize, timeLockedDataSize, expiringDataSize, len(data),
+		)
 	}
+}
+
+// ValidFrom returns the time from which the note can be redeemed, the zero
+// time if the note has no not-before timestamp.
+func (n *Data) ValidFrom() time.Time {
+	if n.NotBefore <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(n.NotBefore, 0)
+}
+
+// ValidUntil returns the time from which the note can't be redeemed anymore,
+// the zero time if the note never expires.
+func (n *Data) ValidUntil() time.Time {
+	if n.ExpiresAt <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(n.ExpiresAt, 0)
+}
 
-	n.ID = binary.BigEndian.Uint64(data[:8])
-	n.Value = binary.BigEndian.Uint32(data[8:])
+// Verify checks that the note can be redeemed at the given time, otherwise it
+// returns ErrNoteNotYetValid reporting when the note becomes valid, or
+// ErrNoteExpired if it's past its expiry.
+func (n *Data) Verify(now time.Time) error {
+	if n.NotBefore > 0 && now.Unix() < n.NotBefore {
+		return ErrNoteNotYetValid{n.ValidFrom()}
+	}
+	if n.ExpiresAt > 0 && now.Unix() >= n.ExpiresAt {
+		return ErrNoteExpired{n.ValidUntil()}
+	}
 	return nil
 }
 
@@ -93,21 +280,28 @@ func (n *Note) Serialize() []byte {
 	return append(detailsBytes, n.Signature...)
 }
 
-// Deserialize converts a byte slice to a Note
+// Deserialize converts a byte slice to a Note. The byte slice doesn't record
+// whether the note has a not-before or expiry timestamp, therefore it is
+// parsed as a plain note; time-locked and expiring notes must be decoded with
+// NewFromString.
 func (n *Note) Deserialize(data []byte) error {
-	if len(data) < 12 {
-		return fmt.Errorf("invalid data length: expected at least 12 bytes, got %d", len(data))
+	return n.deserialize(data, dataSize)
+}
+
+func (n *Note) deserialize(data []byte, size int) error {
+	if len(data) < size {
+		return fmt.Errorf("invalid data length: expected at least %d bytes, got %d", size, len(data))
 	}
 
 	dataCopy := &Data{}
-	if err := dataCopy.Deserialize(data[:12]); err != nil {
+	if err := dataCopy.Deserialize(data[:size]); err != nil {
 		return err
 	}
 
 	n.Data = *dataCopy
 
-	if len(data) > 12 {
-		n.Signature = data[12:]
+	if len(data) > size {
+		n.Signature = data[size:]
 	}
 
 	return nil
@@ -115,7 +309,14 @@ func (n *Note) Deserialize(data []byte) error {
 
 // String converts the Note to a base58 encoded string with HRP
 func (n Note) String() string {
-	return noteHRP + base58.Encode(n.Serialize())
+	hrp := noteHRP
+	if n.NotBefore > 0 {
+		hrp = timeLockedNoteHRP
+	}
+	if n.ExpiresAt > 0 {
+		hrp = expiringNoteHRP
+	}
+	return hrp + base58.Encode(n.Serialize())
 }
 
 // ToNote creates a Note from Data with the given signature
@@ -2,8 +2,10 @@ package note_test
 
 import (
 	"encoding/binary"
+	"errors"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/ark-network/ark/common/note"
 	"github.com/stretchr/testify/require"
@@ -209,6 +211,8 @@ func TestNewFromString(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.str, func(t *testing.T) {
+			require.True(t, note.IsNote(tt.str))
+
 			note, err := note.NewFromString(tt.str)
 			require.NoError(t, err)
 			require.NotNil(t, note)
@@ -278,3 +282,138 @@ func TestDataHash(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeLockedNote(t *testing.T) {
+	notBefore := time.Unix(1_900_000_000, 0)
+
+	data, err := note.NewTimeLocked(1000, notBefore)
+	require.NoError(t, err)
+	require.Equal(t, notBefore.Unix(), data.NotBefore)
+	require.Len(t, data.Serialize(), 20)
+
+	// the not-before timestamp is committed in the hash
+	plain := note.Data{ID: data.ID, Value: data.Value}
+	require.NotEqual(t, plain.Hash(), data.Hash())
+	require.Len(t, plain.Serialize(), 12)
+
+	t.Run("string roundtrip", func(t *testing.T) {
+		n := data.ToNote(make([]byte, 64))
+		str := n.String()
+
+		parsed, err := note.NewFromString(str)
+		require.NoError(t, err)
+		require.Equal(t, *n, *parsed)
+
+		parsedPlain, err := note.NewFromString(plain.ToNote(make([]byte, 64)).String())
+		require.NoError(t, err)
+		require.Zero(t, parsedPlain.NotBefore)
+		require.Equal(t, plain, parsedPlain.Data)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		err := data.Verify(notBefore.Add(-time.Second))
+		require.Error(t, err)
+
+		var notYetValid note.ErrNoteNotYetValid
+		require.True(t, errors.As(err, &notYetValid))
+		require.True(t, notBefore.Equal(notYetValid.ValidFrom))
+
+		require.NoError(t, data.Verify(notBefore))
+		require.NoError(t, plain.Verify(time.Unix(0, 0)))
+		require.True(t, plain.ValidFrom().IsZero())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := note.NewTimeLocked(1000, time.Unix(0, 0))
+		require.Error(t, err)
+	})
+}
+
+func TestDenominations(t *testing.T) {
+	denominations, err := note.NewDenominationSet(10000, 1000, 5000)
+	require.NoError(t, err)
+	require.Equal(t, []uint32{1000, 5000, 10000}, denominations.Values())
+
+	t.Run("valid", func(t *testing.T) {
+		data, err := note.New(5000, note.WithDenominations(denominations))
+		require.NoError(t, err)
+		require.Equal(t, note.Denomination(5000), data.Denomination())
+
+		n := data.ToNote(make([]byte, 64))
+		require.Equal(t, note.Denomination(5000), n.Denomination())
+
+		notBefore := time.Now().Add(time.Hour)
+		_, err = note.NewTimeLocked(1000, notBefore, note.WithDenominations(denominations))
+		require.NoError(t, err)
+
+		// no denominations means any value is valid
+		_, err = note.New(1234, note.WithDenominations(nil))
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := note.New(1500, note.WithDenominations(denominations))
+		require.Error(t, err)
+
+		var invalidDenomination note.ErrInvalidDenomination
+		require.True(t, errors.As(err, &invalidDenomination))
+		require.Equal(t, uint32(1500), invalidDenomination.Value)
+		require.Equal(t, denominations.Values(), invalidDenomination.Denominations)
+
+		notBefore := time.Now().Add(time.Hour)
+		_, err = note.NewTimeLocked(1500, notBefore, note.WithDenominations(denominations))
+		require.ErrorAs(t, err, &invalidDenomination)
+
+		_, err = note.NewDenominationSet(1000, 0)
+		require.Error(t, err)
+		_, err = note.NewDenominationSet(1000, 1000)
+		require.Error(t, err)
+	})
+}
+
+func TestExpiringNote(t *testing.T) {
+	expiresAt := time.Unix(time.Now().Add(time.Hour).Unix(), 0)
+
+	data, err := note.New(1000, note.WithExpiry(expiresAt))
+	require.NoError(t, err)
+	require.Equal(t, expiresAt.Unix(), data.ExpiresAt)
+	require.True(t, expiresAt.Equal(data.ValidUntil()))
+
+	t.Run("roundtrip", func(t *testing.T) {
+		n := data.ToNote(make([]byte, 64))
+		str := n.String()
+		require.True(t, note.IsNote(str))
+
+		parsed, err := note.NewFromString(str)
+		require.NoError(t, err)
+		require.Equal(t, *n, *parsed)
+
+		notBefore := time.Unix(time.Now().Unix(), 0)
+		timeLocked, err := note.NewTimeLocked(1000, notBefore, note.WithExpiry(expiresAt))
+		require.NoError(t, err)
+		parsed, err = note.NewFromString(timeLocked.ToNote(make([]byte, 64)).String())
+		require.NoError(t, err)
+		require.Equal(t, notBefore.Unix(), parsed.NotBefore)
+		require.Equal(t, expiresAt.Unix(), parsed.ExpiresAt)
+	})
+
+	t.Run("verify", func(t *testing.T) {
+		require.NoError(t, data.Verify(expiresAt.Add(-time.Second)))
+
+		err := data.Verify(expiresAt)
+		var expired note.ErrNoteExpired
+		require.True(t, errors.As(err, &expired))
+		require.True(t, expiresAt.Equal(expired.ExpiredAt))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := note.NewTimeLocked(1000, expiresAt, note.WithExpiry(expiresAt))
+		require.Error(t, err)
+
+		buf := make([]byte, 28)
+		binary.BigEndian.PutUint64(buf[:8], 1)
+		binary.BigEndian.PutUint32(buf[8:12], 1000)
+		err = (&note.Data{}).Deserialize(buf)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,84 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/ark-network/ark/common/bip322"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// VtxoOwnershipProof is a BIP322 signature proving that the owner of a vtxo
+// holds the key to spend it, without revealing it. The proof is bound to the
+// vtxo outpoint, both through the signed message and the input spent by the
+// signature, so that it can't be replayed for a different vtxo.
+type VtxoOwnershipProof struct {
+	// Signature is the base64 encoded BIP322 signature.
+	Signature string
+	// PkScript and Amount are those of the vtxo. The verifier is expected to
+	// check them against the vtxo known by the indexer.
+	PkScript []byte
+	Amount   uint64
+}
+
+// VtxoOwnershipMessage returns the message signed by the proof of ownership
+// of the given vtxo.
+func VtxoOwnershipMessage(vtxo wire.OutPoint) string {
+	return fmt.Sprintf("ark vtxo ownership proof %s", vtxo)
+}
+
+// VerifyVtxoOwnershipProof returns an error if the given proof isn't a valid
+// BIP322 signature of the ownership message of the given vtxo, spending it.
+func VerifyVtxoOwnershipProof(proof VtxoOwnershipProof, vtxo wire.OutPoint) error {
+	return verifyVtxoSignature(
+		VtxoOwnershipMessage(vtxo), proof.Signature, proof.PkScript, proof.Amount, vtxo,
+	)
+}
+
+// RefreshRevocationMessage returns the message signed by the owner of the
+// given vtxo to revoke the authorization granted to the server to refresh it.
+func RefreshRevocationMessage(vtxo wire.OutPoint) string {
+	return fmt.Sprintf("ark revoke refresh authorization %s", vtxo)
+}
+
+// VerifyRefreshRevocation returns an error if the given signature isn't a
+// valid BIP322 signature of the refresh revocation message of the given vtxo,
+// locked by pkScript, spending it.
+func VerifyRefreshRevocation(
+	signature string, pkScript []byte, amount uint64, vtxo wire.OutPoint,
+) error {
+	return verifyVtxoSignature(
+		RefreshRevocationMessage(vtxo), signature, pkScript, amount, vtxo,
+	)
+}
+
+func verifyVtxoSignature(
+	message, signature string, pkScript []byte, amount uint64, vtxo wire.OutPoint,
+) error {
+	if len(pkScript) <= 0 {
+		return fmt.Errorf("missing vtxo script")
+	}
+
+	sig, err := bip322.DecodeSignature(signature)
+	if err != nil {
+		return fmt.Errorf("invalid signature: %s", err)
+	}
+
+	// the first input is the toSpend tx of the BIP322 message
+	if len(sig.TxIn) != 2 {
+		return fmt.Errorf(
+			"invalid signature, expected 2 inputs, got %d", len(sig.TxIn),
+		)
+	}
+	if sig.TxIn[1].PreviousOutPoint != vtxo {
+		return fmt.Errorf(
+			"proof bound to vtxo %s, expected %s", sig.TxIn[1].PreviousOutPoint, vtxo,
+		)
+	}
+
+	prevoutFetcher := txscript.NewCannedPrevOutputFetcher(pkScript, int64(amount))
+	if err := sig.Verify(message, prevoutFetcher); err != nil {
+		return fmt.Errorf("invalid signature: %s", err)
+	}
+	return nil
+}
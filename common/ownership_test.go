@@ -0,0 +1,140 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/bip322"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyVtxoOwnershipProof(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToTaprootScript(
+		txscript.ComputeTaprootKeyNoScript(key.PubKey()),
+	)
+	require.NoError(t, err)
+
+	vtxo := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+	otherVtxo := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 1}
+	proof := makeVtxoOwnershipProof(t, key, pkScript, vtxo, 1000)
+
+	t.Run("valid", func(t *testing.T) {
+		err := common.VerifyVtxoOwnershipProof(proof, vtxo)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		// replayed for another vtxo
+		err := common.VerifyVtxoOwnershipProof(proof, otherVtxo)
+		require.ErrorContains(t, err, "proof bound to vtxo")
+
+		// signed for another vtxo, but claiming the given one
+		replayed := makeVtxoOwnershipProof(t, key, pkScript, otherVtxo, 1000)
+		sig, err := bip322.DecodeSignature(replayed.Signature)
+		require.NoError(t, err)
+		sig.TxIn[1].PreviousOutPoint = vtxo
+		replayed.Signature, err = sig.Encode()
+		require.NoError(t, err)
+		err = common.VerifyVtxoOwnershipProof(replayed, vtxo)
+		require.ErrorContains(t, err, "invalid signature")
+
+		// wrong amount
+		wrongAmount := proof
+		wrongAmount.Amount = 2000
+		err = common.VerifyVtxoOwnershipProof(wrongAmount, vtxo)
+		require.ErrorContains(t, err, "invalid signature")
+
+		// signed by another key
+		otherKey, err := btcec.NewPrivateKey()
+		require.NoError(t, err)
+		wrongKey := makeVtxoOwnershipProof(t, otherKey, pkScript, vtxo, 1000)
+		err = common.VerifyVtxoOwnershipProof(wrongKey, vtxo)
+		require.ErrorContains(t, err, "invalid signature")
+
+		// missing script
+		err = common.VerifyVtxoOwnershipProof(
+			common.VtxoOwnershipProof{Signature: proof.Signature}, vtxo,
+		)
+		require.ErrorContains(t, err, "missing vtxo script")
+	})
+}
+
+func TestVerifyRefreshRevocation(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pkScript, err := txscript.PayToTaprootScript(
+		txscript.ComputeTaprootKeyNoScript(key.PubKey()),
+	)
+	require.NoError(t, err)
+
+	vtxo := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+	revocation := signVtxoMessage(
+		t, key, pkScript, vtxo, 1000, common.RefreshRevocationMessage(vtxo),
+	)
+
+	err = common.VerifyRefreshRevocation(revocation, pkScript, 1000, vtxo)
+	require.NoError(t, err)
+
+	// an ownership proof can't be used as revocation
+	proof := makeVtxoOwnershipProof(t, key, pkScript, vtxo, 1000)
+	err = common.VerifyRefreshRevocation(proof.Signature, pkScript, 1000, vtxo)
+	require.ErrorContains(t, err, "invalid signature")
+}
+
+// makeVtxoOwnershipProof signs the ownership proof of the given vtxo locked
+// by a taproot key-path script.
+func makeVtxoOwnershipProof(
+	t *testing.T, key *btcec.PrivateKey, pkScript []byte,
+	vtxo wire.OutPoint, amount uint64,
+) common.VtxoOwnershipProof {
+	return common.VtxoOwnershipProof{
+		Signature: signVtxoMessage(
+			t, key, pkScript, vtxo, amount, common.VtxoOwnershipMessage(vtxo),
+		),
+		PkScript: pkScript,
+		Amount:   amount,
+	}
+}
+
+// signVtxoMessage returns the BIP322 signature of the given message spending
+// the given vtxo locked by a taproot key-path script.
+func signVtxoMessage(
+	t *testing.T, key *btcec.PrivateKey, pkScript []byte,
+	vtxo wire.OutPoint, amount uint64, message string,
+) string {
+	prevout := &wire.TxOut{Value: int64(amount), PkScript: pkScript}
+	fullProof, err := bip322.New(
+		message, []bip322.Input{{OutPoint: &vtxo, WitnessUtxo: prevout}}, nil,
+	)
+	require.NoError(t, err)
+
+	ptx := psbt.Packet(*fullProof)
+	prevoutFetcher := txscript.NewMultiPrevOutFetcher(map[wire.OutPoint]*wire.TxOut{
+		ptx.UnsignedTx.TxIn[0].PreviousOutPoint: ptx.Inputs[0].WitnessUtxo,
+		vtxo:                                    prevout,
+	})
+	sigHashes := txscript.NewTxSigHashes(ptx.UnsignedTx, prevoutFetcher)
+
+	for i := range ptx.Inputs {
+		sig, err := txscript.RawTxInTaprootSignature(
+			ptx.UnsignedTx, sigHashes, i, ptx.Inputs[i].WitnessUtxo.Value,
+			pkScript, nil, txscript.SigHashAll, key,
+		)
+		require.NoError(t, err)
+		ptx.Inputs[i].TaprootKeySpendSig = sig
+	}
+
+	signedProof := bip322.FullProof(ptx)
+	sig, err := signedProof.Signature()
+	require.NoError(t, err)
+	encodedSig, err := sig.Encode()
+	require.NoError(t, err)
+	return encodedSig
+}
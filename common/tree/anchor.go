@@ -0,0 +1,90 @@
+package tree
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// AnchorPkScript is the pay-to-anchor (P2A) script, spendable by anyone with
+// an empty witness.
+var AnchorPkScript = []byte{txscript.OP_1, txscript.OP_DATA_2, 0x4e, 0x73}
+
+// anchorTxVersion is the version of the txs with an anchor output. TRUC (v3)
+// txs can pay zero fees and be relayed in a package with a child spending
+// their anchor to pay for both.
+const anchorTxVersion = 3
+
+// AnchorOutput returns the zero-value anchor output appended to the txs built
+// with WithAnchor.
+func AnchorOutput() *wire.TxOut {
+	return &wire.TxOut{Value: 0, PkScript: AnchorPkScript}
+}
+
+// IsAnchor returns whether the given output is a pay-to-anchor output.
+func IsAnchor(out *wire.TxOut) bool {
+	return bytes.Equal(out.PkScript, AnchorPkScript)
+}
+
+// ErrInvalidAnchor is returned when a tx has an anchor output with a value
+// other than zero.
+var ErrInvalidAnchor = errors.New("anchor output must have zero value")
+
+// ValidateAnchors makes sure all the anchor outputs of the given tx, if any,
+// have zero value.
+func ValidateAnchors(tx *wire.MsgTx) error {
+	for i, out := range tx.TxOut {
+		if IsAnchor(out) && out.Value != 0 {
+			return fmt.Errorf("%w: output %d has value %d", ErrInvalidAnchor, i, out.Value)
+		}
+	}
+	return nil
+}
+
+// hasAnchor returns whether the given tx has a valid, zero-value, anchor
+// output.
+func hasAnchor(tx *wire.MsgTx) bool {
+	for _, out := range tx.TxOut {
+		if IsAnchor(out) && out.Value == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildOption customizes the txs built by CraftSharedOutput, BuildVtxoTree and
+// BuildRedeemTx.
+type BuildOption func(*buildOptions)
+
+type buildOptions struct {
+	anchor bool
+}
+
+// WithAnchor appends a zero-value anchor output to every built tx, as last
+// output, and makes it a TRUC tx so that its fees can be paid by a child
+// spending the anchor. The txs of a vtxo tree built with this option pay no
+// fees, the fee per node is ignored.
+func WithAnchor() BuildOption {
+	return func(o *buildOptions) {
+		o.anchor = true
+	}
+}
+
+func newBuildOptions(opts []BuildOption) buildOptions {
+	o := buildOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// txVersion returns the version of the txs built with the options.
+func (o buildOptions) txVersion() int32 {
+	if o.anchor {
+		return anchorTxVersion
+	}
+	return 2
+}
@@ -13,18 +13,22 @@ import (
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
-const (
-	vtxoTreeRadix       = 2
-	connectorsTreeRadix = 4
-)
+const vtxoTreeRadix = 2
 
 // CraftSharedOutput returns the taproot script and the amount of the root shared output of a vtxo tree
 // radix is hardcoded to 2
+// The options must be the same given to BuildVtxoTree, with WithAnchor the amount doesn't include any
+// fee since the tree txs pay zero fees.
 func CraftSharedOutput(
 	receivers []Leaf,
 	feeSatsPerNode uint64,
 	sweepTapTreeRoot []byte,
+	opts ...BuildOption,
 ) ([]byte, int64, error) {
+	if newBuildOptions(opts).anchor {
+		feeSatsPerNode = 0
+	}
+
 	root, err := createTxTree(receivers, feeSatsPerNode, sweepTapTreeRoot, vtxoTreeRadix)
 	if err != nil {
 		return nil, 0, err
@@ -53,22 +57,33 @@ func BuildVtxoTree(
 	feeSatsPerNode uint64,
 	sweepTapTreeRoot []byte,
 	vtxoTreeExpiry common.RelativeLocktime,
+	opts ...BuildOption,
 ) (TxTree, error) {
+	o := newBuildOptions(opts)
+	if o.anchor {
+		feeSatsPerNode = 0
+	}
+
 	root, err := createTxTree(receivers, feeSatsPerNode, sweepTapTreeRoot, vtxoTreeRadix)
 	if err != nil {
 		return nil, err
 	}
 
-	return toTxTree(root, initialInput, &vtxoTreeExpiry)
+	return toTxTree(root, initialInput, &vtxoTreeExpiry, o)
 }
 
 // CraftConnectorsOutput returns the taproot script and the amount of the root shared output of a connectors tree
-// radix is hardcoded to 4
+// with the given radix
 func CraftConnectorsOutput(
 	receivers []Leaf,
 	feeSatsPerNode uint64,
+	radix int,
 ) ([]byte, int64, error) {
-	root, err := createTxTree(receivers, feeSatsPerNode, nil, connectorsTreeRadix)
+	if radix < 2 {
+		return nil, 0, fmt.Errorf("invalid connectors tree radix %d, must be at least 2", radix)
+	}
+
+	root, err := createTxTree(receivers, feeSatsPerNode, nil, radix)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -88,23 +103,30 @@ func CraftConnectorsOutput(
 	return scriptPubkey, amount, nil
 }
 
-// BuildConnectorsTree creates all the tree's transactions and returns the vtxo tree
-// radix is hardcoded to 4
+// BuildConnectorsTree creates all the tree's transactions and returns the connectors tree
+// with the given radix
 func BuildConnectorsTree(
 	initialInput *wire.OutPoint,
 	receivers []Leaf,
 	feeSatsPerNode uint64,
+	radix int,
 ) (TxTree, error) {
-	root, err := createTxTree(receivers, feeSatsPerNode, nil, connectorsTreeRadix)
+	if radix < 2 {
+		return nil, fmt.Errorf("invalid connectors tree radix %d, must be at least 2", radix)
+	}
+
+	root, err := createTxTree(receivers, feeSatsPerNode, nil, radix)
 	if err != nil {
 		return nil, err
 	}
 
-	return toTxTree(root, initialInput, nil)
+	return toTxTree(root, initialInput, nil, buildOptions{})
 }
 
 // toTxTree converts a node root to VtxoTree matrix
-func toTxTree(root node, initialInput *wire.OutPoint, expiry *common.RelativeLocktime) (TxTree, error) {
+func toTxTree(
+	root node, initialInput *wire.OutPoint, expiry *common.RelativeLocktime, opts buildOptions,
+) (TxTree, error) {
 	vtxoTree := make(TxTree, 0)
 
 	ins := []*wire.OutPoint{initialInput}
@@ -117,7 +139,7 @@ func toTxTree(root node, initialInput *wire.OutPoint, expiry *common.RelativeLoc
 		treeLevel := make([]Node, 0)
 
 		for i, node := range nodes {
-			treeNode, err := getTreeNode(node, ins[i], expiry)
+			treeNode, err := getTreeNode(node, ins[i], expiry, opts)
 			if err != nil {
 				return nil, err
 			}
@@ -226,8 +248,9 @@ func getTreeNode(
 	n node,
 	input *wire.OutPoint,
 	expiry *common.RelativeLocktime,
+	opts buildOptions,
 ) (Node, error) {
-	partialTx, err := getTx(n, input, expiry)
+	partialTx, err := getTx(n, input, expiry, opts)
 	if err != nil {
 		return Node{}, err
 	}
@@ -251,13 +274,21 @@ func getTx(
 	n node,
 	input *wire.OutPoint,
 	expiry *common.RelativeLocktime,
+	opts buildOptions,
 ) (*psbt.Packet, error) {
 	outputs, err := n.getOutputs()
 	if err != nil {
 		return nil, err
 	}
+	// the anchor goes last, the index of the output of every child matches its
+	// position in the node
+	if opts.anchor {
+		outputs = append(outputs, AnchorOutput())
+	}
 
-	tx, err := psbt.New([]*wire.OutPoint{input}, outputs, 2, 0, []uint32{wire.MaxTxInSequenceNum})
+	tx, err := psbt.New(
+		[]*wire.OutPoint{input}, outputs, opts.txVersion(), 0, []uint32{wire.MaxTxInSequenceNum},
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -434,6 +465,44 @@ func createUpperLevel(nodes []node, feeAmount int64, tapTreeRoot []byte, radix i
 	return groups, nil
 }
 
+// TxTreeBranches returns the number of outputs of every branch tx of a tree
+// with the given number of leaves and radix, as created by BuildVtxoTree and
+// BuildConnectorsTree. Each leaf is a tx with a single output.
+func TxTreeBranches(numOfLeaves, radix int) []int {
+	branches := make([]int, 0)
+	numOfNodes := numOfLeaves
+	for numOfNodes > 1 {
+		var levelBranches []int
+		levelBranches, numOfNodes = upperLevelBranches(numOfNodes, radix)
+		branches = append(branches, levelBranches...)
+	}
+	return branches
+}
+
+// upperLevelBranches mirrors createUpperLevel, it returns the number of
+// children of the branches of the upper level and its total number of nodes.
+func upperLevelBranches(numOfNodes, radix int) ([]int, int) {
+	if numOfNodes <= 1 {
+		return nil, numOfNodes
+	}
+
+	if numOfNodes < radix {
+		return upperLevelBranches(numOfNodes, numOfNodes)
+	}
+
+	remainder := numOfNodes % radix
+	if remainder != 0 {
+		branches, numOfUpperNodes := upperLevelBranches(numOfNodes-remainder, radix)
+		return branches, numOfUpperNodes + remainder
+	}
+
+	branches := make([]int, 0, numOfNodes/radix)
+	for i := 0; i < numOfNodes; i += radix {
+		branches = append(branches, radix)
+	}
+	return branches, len(branches)
+}
+
 // uniqueCosigners removes duplicate cosigner keys while preserving order
 func uniqueCosigners(cosigners []*secp256k1.PublicKey) []*secp256k1.PublicKey {
 	seen := make(map[string]struct{})
@@ -0,0 +1,51 @@
+package tree
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// AssignConnectors returns the connector outpoint assigned to each of the
+// given vtxos, indexed by vtxo outpoint (txid:vout).
+// The assignment is deterministic so that the server and the clients can
+// compute it independently: the vtxo outpoints are sorted lexicographically
+// and paired, in order, with the first output of the connector leaves, in the
+// order they appear in the connectors tree.
+func AssignConnectors(
+	vtxos []string, connectorLeaves []Node,
+) (map[string]wire.OutPoint, error) {
+	assignments := make(map[string]wire.OutPoint)
+	if len(vtxos) <= 0 {
+		return assignments, nil
+	}
+
+	if len(connectorLeaves) <= 0 {
+		return nil, fmt.Errorf("no connectors found")
+	}
+	if len(vtxos) > len(connectorLeaves) {
+		return nil, fmt.Errorf(
+			"more vtxos to sign than outpoints, %d > %d", len(vtxos), len(connectorLeaves),
+		)
+	}
+
+	connectorOutpoints := make([]wire.OutPoint, 0, len(connectorLeaves))
+	for _, leaf := range connectorLeaves {
+		hash, err := chainhash.NewHashFromStr(leaf.Txid)
+		if err != nil {
+			return nil, fmt.Errorf("invalid connector txid %s: %s", leaf.Txid, err)
+		}
+		connectorOutpoints = append(connectorOutpoints, wire.OutPoint{Hash: *hash, Index: 0})
+	}
+
+	sortedVtxos := make([]string, len(vtxos))
+	copy(sortedVtxos, vtxos)
+	sort.Strings(sortedVtxos)
+
+	for i, vtxo := range sortedVtxos {
+		assignments[vtxo] = connectorOutpoints[i]
+	}
+	return assignments, nil
+}
@@ -0,0 +1,67 @@
+package tree_test
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssignConnectors(t *testing.T) {
+	connectorLeaves := make([]tree.Node, 0, 3)
+	for i := 0; i < 3; i++ {
+		txid := chainhash.HashH([]byte(fmt.Sprintf("connector%d", i))).String()
+		connectorLeaves = append(connectorLeaves, tree.Node{Txid: txid, Leaf: true})
+	}
+
+	vtxo1 := chainhash.HashH([]byte("vtxo1")).String() + ":0"
+	vtxo2 := chainhash.HashH([]byte("vtxo2")).String() + ":1"
+	vtxo3 := chainhash.HashH([]byte("vtxo3")).String() + ":0"
+
+	t.Run("valid", func(t *testing.T) {
+		vtxos := []string{vtxo1, vtxo2, vtxo3}
+		assignments, err := tree.AssignConnectors(vtxos, connectorLeaves)
+		require.NoError(t, err)
+		require.Len(t, assignments, len(vtxos))
+
+		// the assignment doesn't depend on the order of the vtxos
+		reversed := []string{vtxo3, vtxo2, vtxo1}
+		otherAssignments, err := tree.AssignConnectors(reversed, connectorLeaves)
+		require.NoError(t, err)
+		require.Equal(t, assignments, otherAssignments)
+		require.Equal(t, []string{vtxo3, vtxo2, vtxo1}, reversed)
+
+		// vtxos are paired with connectors in lexicographic order
+		sorted := []string{vtxo1, vtxo2, vtxo3}
+		sort.Strings(sorted)
+		for i, vtxo := range sorted {
+			connector := assignments[vtxo]
+			require.Equal(t, connectorLeaves[i].Txid, connector.Hash.String())
+			require.Zero(t, connector.Index)
+		}
+
+		// fewer vtxos than connectors
+		assignments, err = tree.AssignConnectors(sorted[:1], connectorLeaves)
+		require.NoError(t, err)
+		require.Len(t, assignments, 1)
+		require.Equal(t, connectorLeaves[0].Txid, assignments[sorted[0]].Hash.String())
+
+		assignments, err = tree.AssignConnectors(nil, nil)
+		require.NoError(t, err)
+		require.Empty(t, assignments)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := tree.AssignConnectors([]string{vtxo1}, nil)
+		require.EqualError(t, err, "no connectors found")
+
+		_, err = tree.AssignConnectors([]string{vtxo1, vtxo2, vtxo3}, connectorLeaves[:2])
+		require.EqualError(t, err, "more vtxos to sign than outpoints, 3 > 2")
+
+		_, err = tree.AssignConnectors([]string{vtxo1}, []tree.Node{{Txid: "invalid"}})
+		require.Error(t, err)
+	})
+}
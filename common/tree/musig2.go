@@ -11,6 +11,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ark-network/ark/common"
 	"github.com/btcsuite/btcd/btcec/v2"
@@ -22,6 +23,9 @@ import (
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
+// partialSigSize is the length of an encoded musig2 partial signature
+const partialSigSize = 32
+
 var (
 	ErrMissingVtxoTree = errors.New("missing vtxo tree")
 )
@@ -62,6 +66,12 @@ func (n TreeNonces) Encode(w io.Writer) error {
 	return err
 }
 
+// EncodedSize returns the number of bytes written by Encode, without
+// encoding the nonces.
+func (n TreeNonces) EncodedSize() int {
+	return encodedMatrixSize(n, musig2.PubNonceSize)
+}
+
 func DecodeNonces(r io.Reader) (TreeNonces, error) {
 	return decodeMatrix(func() *Musig2Nonce { return new(Musig2Nonce) }, r)
 }
@@ -76,6 +86,12 @@ func (s TreePartialSigs) Encode(w io.Writer) error {
 	return err
 }
 
+// EncodedSize returns the number of bytes written by Encode, without
+// encoding the signatures.
+func (s TreePartialSigs) EncodedSize() int {
+	return encodedMatrixSize(s, partialSigSize)
+}
+
 func DecodeSignatures(r io.Reader) (TreePartialSigs, error) {
 	return decodeMatrix(func() *musig2.PartialSignature { return new(musig2.PartialSignature) }, r)
 }
@@ -88,10 +104,30 @@ type SignerSession interface {
 	Sign() (TreePartialSigs, error) // sign the tree
 }
 
+// CosignerNonces are the tree nonces submitted by a cosigner.
+type CosignerNonces struct {
+	PubKey *btcec.PublicKey
+	Nonces TreeNonces
+}
+
 type CoordinatorSession interface {
 	AddNonce(*btcec.PublicKey, TreeNonces)
+	// AddNonceStream adds the nonces received from the channel until all the
+	// cosigners of the tree submitted theirs, the channel is closed or the
+	// deadline is reached, and returns the cosigners that didn't submit them.
+	// It fails without waiting if the cosigners of the tree can't be parsed.
+	AddNonceStream(
+		noncesCh <-chan CosignerNonces, deadline time.Time,
+	) ([]*btcec.PublicKey, error)
 	AddSignatures(*btcec.PublicKey, TreePartialSigs)
 	AggregateNonces() (TreeNonces, error)
+	// AggregatePartial is like AggregateNonces but it doesn't fail for the txs
+	// missing the nonces of some of their cosigners: their aggregated nonces
+	// are nil and their txids are returned. With SignBranch, the txs of the
+	// branches of the other cosigners don't need the nonces of the missing
+	// ones, while their ancestors do. The skipped txs are left unsigned by
+	// SignTree.
+	AggregatePartial() (TreeNonces, []string, error)
 	// SignTree combines the signatures and add them to the tree's psbts
 	SignTree() (TxTree, error)
 }
@@ -140,6 +176,31 @@ func AggregateKeys(
 	return key, nil
 }
 
+// AggregateCosignerKey returns the untweaked musig2 key aggregating the given
+// hex encoded cosigner pubkeys. Like in the signer and coordinator sessions,
+// duplicates are ignored and the keys are sorted before being aggregated, so
+// the result doesn't depend on the order of the list.
+func AggregateCosignerKey(cosigners []string) (*btcec.PublicKey, error) {
+	pubkeys := make([]*btcec.PublicKey, 0, len(cosigners))
+	for _, cosigner := range cosigners {
+		buf, err := hex.DecodeString(cosigner)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode cosigner pubkey %s: %s", cosigner, err)
+		}
+		pubkey, err := btcec.ParsePubKey(buf)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse cosigner pubkey %s: %s", cosigner, err)
+		}
+		pubkeys = append(pubkeys, pubkey)
+	}
+
+	aggregateKey, err := AggregateKeys(uniqueCosigners(pubkeys), nil)
+	if err != nil {
+		return nil, err
+	}
+	return aggregateKey.FinalKey, nil
+}
+
 // ValidateTreeSigs iterates over the tree matrix and verify the TaprootKeySpendSig
 // the public key is rebuilt from the keys set in the unknown field of the psbt
 func ValidateTreeSigs(
@@ -153,65 +214,114 @@ func ValidateTreeSigs(
 	}
 
 	return workPoolMatrix(vtxoTree, func(_, _ int, node Node) error {
-		partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
-		if err != nil {
-			return fmt.Errorf("failed to parse tx: %w", err)
-		}
+		return validateNodeSig(scriptRoot, prevoutFetcherFactory, node)
+	})
+}
 
-		sig := partialTx.Inputs[0].TaprootKeySpendSig
-		if len(sig) == 0 {
-			return errors.New("unsigned tree input")
-		}
+// ValidateBranchSigs is like ValidateTreeSigs but verifies only the
+// signatures of the txs on the branch of the given leaf, those that a signer
+// with a branch session contributed to. The rest of the tree may be unsigned.
+func ValidateBranchSigs(
+	scriptRoot []byte,
+	roundSharedOutputAmount int64,
+	vtxoTree TxTree,
+	leafTxid string,
+) error {
+	branch, err := vtxoTree.Branch(leafTxid)
+	if err != nil {
+		return err
+	}
 
-		schnorrSig, err := schnorr.ParseSignature(sig)
-		if err != nil {
-			return fmt.Errorf("failed to parse signature: %w", err)
-		}
+	prevoutFetcherFactory, err := prevOutFetcherFactory(vtxoTree, roundSharedOutputAmount, scriptRoot)
+	if err != nil {
+		return err
+	}
 
-		prevoutFetcher, err := prevoutFetcherFactory(partialTx)
-		if err != nil {
-			return fmt.Errorf("failed to get prevout fetcher: %w", err)
+	for _, node := range branch {
+		if err := validateNodeSig(scriptRoot, prevoutFetcherFactory, node); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		message, err := txscript.CalcTaprootSignatureHash(
-			txscript.NewTxSigHashes(partialTx.UnsignedTx, prevoutFetcher),
-			txscript.SigHashDefault,
-			partialTx.UnsignedTx,
-			0,
-			prevoutFetcher,
-		)
-		if err != nil {
-			return fmt.Errorf("failed to calculate sighash: %w", err)
-		}
+func validateNodeSig(
+	scriptRoot []byte,
+	prevoutFetcherFactory func(*psbt.Packet) (txscript.PrevOutputFetcher, error),
+	node Node,
+) error {
+	partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+	if err != nil {
+		return fmt.Errorf("failed to parse tx: %w", err)
+	}
 
-		keys, err := GetCosignerKeys(partialTx.Inputs[0])
-		if err != nil {
-			return fmt.Errorf("failed to get cosigner keys: %w", err)
-		}
+	sig := partialTx.Inputs[0].TaprootKeySpendSig
+	if len(sig) == 0 {
+		return errors.New("unsigned tree input")
+	}
 
-		if len(keys) == 0 {
-			return fmt.Errorf("no keys for txid %s", partialTx.UnsignedTx.TxHash().String())
-		}
+	schnorrSig, err := schnorr.ParseSignature(sig)
+	if err != nil {
+		return fmt.Errorf("failed to parse signature: %w", err)
+	}
 
-		aggregateKey, err := AggregateKeys(keys, scriptRoot)
-		if err != nil {
-			return fmt.Errorf("failed to aggregate keys: %w", err)
-		}
+	prevoutFetcher, err := prevoutFetcherFactory(partialTx)
+	if err != nil {
+		return fmt.Errorf("failed to get prevout fetcher: %w", err)
+	}
 
-		if !schnorrSig.Verify(message, aggregateKey.FinalKey) {
-			return fmt.Errorf("invalid signature for txid %s", partialTx.UnsignedTx.TxHash().String())
-		}
+	message, err := txscript.CalcTaprootSignatureHash(
+		txscript.NewTxSigHashes(partialTx.UnsignedTx, prevoutFetcher),
+		txscript.SigHashDefault,
+		partialTx.UnsignedTx,
+		0,
+		prevoutFetcher,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to calculate sighash: %w", err)
+	}
 
-		return nil
-	})
+	keys, err := GetCosignerKeys(partialTx.Inputs[0])
+	if err != nil {
+		return fmt.Errorf("failed to get cosigner keys: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return fmt.Errorf("no keys for txid %s", partialTx.UnsignedTx.TxHash().String())
+	}
+
+	aggregateKey, err := AggregateKeys(keys, scriptRoot)
+	if err != nil {
+		return fmt.Errorf("failed to aggregate keys: %w", err)
+	}
+
+	if !schnorrSig.Verify(message, aggregateKey.FinalKey) {
+		return fmt.Errorf("invalid signature for txid %s", partialTx.UnsignedTx.TxHash().String())
+	}
+
+	return nil
 }
 
 func NewTreeSignerSession(signer *btcec.PrivateKey) SignerSession {
 	return &treeSignerSession{secretKey: signer}
 }
 
+// NewBranchSignerSession returns a signer session that generates nonces and
+// partial signatures only for the txs on the branch of the given leaf vtxo,
+// the rest of the tree provided by the coordinator is never parsed. The nonces
+// and signatures matrixes have the shape of the whole tree, with nil values
+// for the nodes off the branch, therefore the session must be used only for
+// a vtxo registered with the SignBranch signing type.
+func NewBranchSignerSession(
+	signer *btcec.PrivateKey, leafOutpoint wire.OutPoint,
+) SignerSession {
+	return &treeSignerSession{secretKey: signer, leafOutpoint: &leafOutpoint}
+}
+
 type treeSignerSession struct {
-	secretKey             *btcec.PrivateKey
+	secretKey *btcec.PrivateKey
+	// leafOutpoint, if set, restricts the session to the branch of the leaf
+	leafOutpoint          *wire.OutPoint
 	txs                   [][]*psbt.Packet
 	myNonces              [][]*musig2.Nonces
 	aggregateNonces       TreeNonces
@@ -225,7 +335,12 @@ func (t *treeSignerSession) Init(scriptRoot []byte, rootSharedOutputAmount int64
 		return err
 	}
 
-	txs, err := vtxoTreeToTx(vtxoTree)
+	var txs [][]*psbt.Packet
+	if t.leafOutpoint != nil {
+		txs, err = branchToTx(vtxoTree, *t.leafOutpoint)
+	} else {
+		txs, err = vtxoTreeToTx(vtxoTree)
+	}
 	if err != nil {
 		return err
 	}
@@ -293,6 +408,11 @@ func (t *treeSignerSession) Sign() (TreePartialSigs, error) {
 	signerPubKey := schnorr.SerializePubKey(t.secretKey.PubKey())
 
 	if err := workPoolMatrix(t.txs, func(i, j int, partialTx *psbt.Packet) error {
+		// skip the txs off the branch of a branch session
+		if partialTx == nil {
+			return nil
+		}
+
 		mustSign, keys, err := getCosignersPublicKeys(signerPubKey, partialTx)
 		if err != nil {
 			return err
@@ -304,6 +424,11 @@ func (t *treeSignerSession) Sign() (TreePartialSigs, error) {
 			return nil
 		}
 
+		// skip the txs left without aggregated nonces by the coordinator
+		if t.aggregateNonces[i][j] == nil {
+			return nil
+		}
+
 		// craft musig2 partial signature
 		sig, err := t.signPartial(partialTx, i, j, keys)
 		if err != nil {
@@ -334,6 +459,11 @@ func (t *treeSignerSession) generateNonces() error {
 	}
 
 	err := workPoolMatrix(t.txs, func(i, j int, partialTx *psbt.Packet) error {
+		// skip the txs off the branch of a branch session
+		if partialTx == nil {
+			return nil
+		}
+
 		mustGenerateNonce, _, err := getCosignersPublicKeys(serializedSignerPubKey, partialTx)
 		if err != nil {
 			return err
@@ -403,6 +533,9 @@ type treeCoordinatorSession struct {
 	prevoutFetcherFactory func(*psbt.Packet) (txscript.PrevOutputFetcher, error)
 	txs                   [][]*psbt.Packet
 	vtxoTree              TxTree
+	// skippedTxids are the txs left without aggregated nonces by
+	// AggregatePartial
+	skippedTxids map[string]struct{}
 }
 
 func NewTreeCoordinatorSession(
@@ -434,6 +567,62 @@ func (t *treeCoordinatorSession) AddNonce(pubkey *btcec.PublicKey, nonce TreeNon
 	t.nonces[hex.EncodeToString(schnorr.SerializePubKey(pubkey))] = nonce
 }
 
+func (t *treeCoordinatorSession) AddNonceStream(
+	noncesCh <-chan CosignerNonces, deadline time.Time,
+) ([]*btcec.PublicKey, error) {
+	cosigners, err := t.cosigners()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cosigners of the tree: %w", err)
+	}
+	if len(cosigners) <= 0 {
+		return nil, fmt.Errorf("no cosigners in the tree")
+	}
+
+	missing := func() []*btcec.PublicKey {
+		keys := make([]*btcec.PublicKey, 0)
+		for _, key := range cosigners {
+			if _, ok := t.nonces[hex.EncodeToString(schnorr.SerializePubKey(key))]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		return keys
+	}
+
+	timer := time.NewTimer(time.Until(deadline))
+	defer timer.Stop()
+
+	for len(missing()) > 0 {
+		select {
+		case <-timer.C:
+			return missing(), nil
+		case nonces, ok := <-noncesCh:
+			if !ok {
+				return missing(), nil
+			}
+			if nonces.PubKey == nil || nonces.Nonces == nil {
+				continue
+			}
+			t.AddNonce(nonces.PubKey, nonces.Nonces)
+		}
+	}
+	return nil, nil
+}
+
+// cosigners returns the unique cosigners of all the txs of the tree.
+func (t *treeCoordinatorSession) cosigners() ([]*btcec.PublicKey, error) {
+	cosigners := make([]*btcec.PublicKey, 0)
+	for _, level := range t.txs {
+		for _, partialTx := range level {
+			keys, err := GetCosignerKeys(partialTx.Inputs[0])
+			if err != nil {
+				return nil, err
+			}
+			cosigners = append(cosigners, keys...)
+		}
+	}
+	return uniqueCosigners(cosigners), nil
+}
+
 func (t *treeCoordinatorSession) AddSignatures(pubkey *btcec.PublicKey, sig TreePartialSigs) {
 	t.sigs[hex.EncodeToString(schnorr.SerializePubKey(pubkey))] = sig
 }
@@ -441,12 +630,27 @@ func (t *treeCoordinatorSession) AddSignatures(pubkey *btcec.PublicKey, sig Tree
 // AggregateNonces aggregates the musig2 nonces for each transaction in the tree
 // it returns an error if any of the nonces are not set
 func (t *treeCoordinatorSession) AggregateNonces() (TreeNonces, error) {
+	aggregatedNonces, _, err := t.aggregateNonces(false)
+	return aggregatedNonces, err
+}
+
+func (t *treeCoordinatorSession) AggregatePartial() (TreeNonces, []string, error) {
+	return t.aggregateNonces(true)
+}
+
+// aggregateNonces aggregates the musig2 nonces for each transaction in the
+// tree, if partial the txs missing some nonces are skipped rather than
+// making it fail.
+func (t *treeCoordinatorSession) aggregateNonces(partial bool) (TreeNonces, []string, error) {
 	for _, nonce := range t.nonces {
 		if nonce == nil {
-			return nil, errors.New("nonces not set")
+			return nil, nil, errors.New("nonces not set")
 		}
 	}
 
+	lock := &sync.Mutex{}
+	skippedTxids := make(map[string]struct{})
+
 	aggregatedNonces := make(TreeNonces, 0, len(t.txs))
 	for i := range t.txs {
 		aggregatedNonces = append(aggregatedNonces, make([]*Musig2Nonce, len(t.txs[i])))
@@ -467,14 +671,20 @@ func (t *treeCoordinatorSession) AggregateNonces() (TreeNonces, error) {
 		for _, key := range keys {
 			keyStr := hex.EncodeToString(schnorr.SerializePubKey(key))
 			nonceMatrix, ok := t.nonces[keyStr]
-			if !ok {
-				return fmt.Errorf("nonces not set for cosigner key %x", key.SerializeCompressed())
+			if !ok || nonceMatrix[i][j] == nil {
+				if partial {
+					lock.Lock()
+					skippedTxids[partialTx.UnsignedTx.TxHash().String()] = struct{}{}
+					lock.Unlock()
+					return nil
+				}
+				if !ok {
+					return fmt.Errorf("nonces not set for cosigner key %x", key.SerializeCompressed())
+				}
+				return fmt.Errorf("missing nonce for cosigner key %x", key.SerializeCompressed())
 			}
 
 			nonce := nonceMatrix[i][j]
-			if nonce == nil {
-				return fmt.Errorf("missing nonce for cosigner key %x", key.SerializeCompressed())
-			}
 
 			nonces = append(nonces, nonce.PubNonce)
 		}
@@ -497,10 +707,19 @@ func (t *treeCoordinatorSession) AggregateNonces() (TreeNonces, error) {
 	})
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return aggregatedNonces, nil
+	t.skippedTxids = skippedTxids
+	txids := make([]string, 0, len(skippedTxids))
+	for _, level := range t.vtxoTree {
+		for _, node := range level {
+			if _, ok := skippedTxids[node.Txid]; ok {
+				txids = append(txids, node.Txid)
+			}
+		}
+	}
+	return aggregatedNonces, txids, nil
 }
 
 // SignTree combines the signatures and add them to the tree's psbts
@@ -512,6 +731,12 @@ func (t *treeCoordinatorSession) SignTree() (TxTree, error) {
 	}
 
 	if err := workPoolMatrix(t.txs, func(i, j int, partialTx *psbt.Packet) error {
+		// leave unsigned the txs skipped by a partial aggregation of the nonces
+		if _, ok := t.skippedTxids[t.vtxoTree[i][j].Txid]; ok {
+			signedTree[i][j] = t.vtxoTree[i][j]
+			return nil
+		}
+
 		keys, err := GetCosignerKeys(partialTx.Inputs[0])
 		if err != nil {
 			return err
@@ -731,6 +956,23 @@ func encodeMatrix[T writable](matrix [][]T) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// encodedMatrixSize returns the length of the byte stream of encodeMatrix for
+// the given matrix, whose non-nil cells are encoded with cellSize bytes
+func encodedMatrixSize[T any](matrix [][]T, cellSize int) int {
+	// number of rows
+	size := 4
+	for _, row := range matrix {
+		// length of the row and <isNil> byte of every cell
+		size += 4 + len(row)
+		for _, cell := range row {
+			if !reflect.ValueOf(cell).IsNil() {
+				size += cellSize
+			}
+		}
+	}
+	return size
+}
+
 // decodeMatrix decode a byte stream into a matrix of serializable objects
 func decodeMatrix[T readable](factory func() T, data io.Reader) ([][]T, error) {
 	var rowCount uint32
@@ -794,6 +1036,44 @@ func vtxoTreeToTx(vtxoTree TxTree) ([][]*psbt.Packet, error) {
 	return txs, nil
 }
 
+// branchToTx is like vtxoTreeToTx but parses only the txs on the branch of
+// the given leaf, the other cells of the matrix are nil.
+func branchToTx(vtxoTree TxTree, leafOutpoint wire.OutPoint) ([][]*psbt.Packet, error) {
+	branch, err := vtxoTree.Branch(leafOutpoint.Hash.String())
+	if err != nil {
+		return nil, err
+	}
+
+	branchTxids := make(map[string]struct{}, len(branch))
+	for _, node := range branch {
+		branchTxids[node.Txid] = struct{}{}
+	}
+
+	txs := make([][]*psbt.Packet, 0, len(vtxoTree))
+	for _, level := range vtxoTree {
+		levelTxs := make([]*psbt.Packet, len(level))
+		for j, node := range level {
+			if _, ok := branchTxids[node.Txid]; !ok {
+				continue
+			}
+
+			ptx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+			if err != nil {
+				return nil, err
+			}
+			if node.Txid == leafOutpoint.Hash.String() &&
+				int(leafOutpoint.Index) >= len(ptx.UnsignedTx.TxOut) {
+				return nil, fmt.Errorf("leaf %s not found in vtxo tree", leafOutpoint)
+			}
+
+			levelTxs[j] = ptx
+		}
+		txs = append(txs, levelTxs)
+	}
+
+	return txs, nil
+}
+
 // workPool is a generic worker pool that processes items concurrently
 func workPool[T any](items []T, workers int, processItem func(item T) error) error {
 	errChan := make(chan error, 1)
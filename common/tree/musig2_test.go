@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/common/tree"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/stretchr/testify/require"
@@ -77,11 +82,379 @@ func TestBuildAndSignVtxoTree(t *testing.T) {
 	}
 }
 
+func TestBuildAndSignVtxoTreeWithAnchor(t *testing.T) {
+	t.Parallel()
+
+	receivers, privKeys, err := generateMockedReceivers(4)
+	require.NoError(t, err)
+	for i := range receivers {
+		receivers[i].Script = fmt.Sprintf("5120%064x", i+1)
+	}
+
+	_, sharedOutAmount, err := tree.CraftSharedOutput(
+		receivers, minRelayFee, sweepRoot[:], tree.WithAnchor(),
+	)
+	require.NoError(t, err)
+	// the tree txs pay zero fees
+	receiversAmount := int64(0)
+	for _, receiver := range receivers {
+		receiversAmount += int64(receiver.Amount)
+	}
+	require.Equal(t, receiversAmount, sharedOutAmount)
+
+	vtxoTree, err := tree.BuildVtxoTree(
+		rootInput, receivers, minRelayFee, sweepRoot[:], vtxoTreeExpiry, tree.WithAnchor(),
+	)
+	require.NoError(t, err)
+	require.NoError(t, vtxoTree.Validate(sweepRoot[:], sharedOutAmount))
+
+	for _, level := range vtxoTree {
+		for _, node := range level {
+			ptx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+			require.NoError(t, err)
+			require.Equal(t, int32(3), ptx.UnsignedTx.Version)
+
+			outs := ptx.UnsignedTx.TxOut
+			require.True(t, tree.IsAnchor(outs[len(outs)-1]))
+			require.Zero(t, outs[len(outs)-1].Value)
+			// the anchor is the only output not spent by a child
+			if node.Leaf {
+				require.Len(t, outs, 2)
+				continue
+			}
+			require.Len(t, vtxoTree.Children(node.Txid), len(outs)-1)
+		}
+	}
+
+	coordinator, err := tree.NewTreeCoordinatorSession(sharedOutAmount, vtxoTree, sweepRoot[:])
+	require.NoError(t, err)
+	signers, err := makeCosigners(privKeys, sharedOutAmount, vtxoTree)
+	require.NoError(t, err)
+	err = makeAggregatedNonces(signers, coordinator, checkNoncesRoundtrip(t))
+	require.NoError(t, err)
+	signedTree, err := makeAggregatedSignatures(signers, coordinator, checkSigsRoundtrip(t))
+	require.NoError(t, err)
+
+	err = tree.ValidateTreeSigs(sweepRoot[:], sharedOutAmount, signedTree)
+	require.NoError(t, err)
+}
+
+func TestBranchSignerSession(t *testing.T) {
+	t.Parallel()
+
+	receivers, privKeys, err := generateMockedReceivers(4)
+	require.NoError(t, err)
+	receivers = withSigningType(tree.SignBranch, receivers)
+
+	_, sharedOutAmount, err := tree.CraftSharedOutput(receivers, minRelayFee, sweepRoot[:])
+	require.NoError(t, err)
+	vtxoTree, err := tree.BuildVtxoTree(
+		rootInput, receivers, minRelayFee, sweepRoot[:], vtxoTreeExpiry,
+	)
+	require.NoError(t, err)
+
+	coordinator, err := tree.NewTreeCoordinatorSession(sharedOutAmount, vtxoTree, sweepRoot[:])
+	require.NoError(t, err)
+
+	// every receiver signs only its own branch, the server signs the whole tree
+	signers := make(map[string]tree.SignerSession)
+	leaves := make(map[string]string)
+	for _, prvkey := range privKeys {
+		leafTxid := findLeaf(t, vtxoTree, prvkey.PubKey())
+		leafHash, err := chainhash.NewHashFromStr(leafTxid)
+		require.NoError(t, err)
+
+		session := tree.NewBranchSignerSession(prvkey, wire.OutPoint{Hash: *leafHash})
+		require.NoError(t, session.Init(sweepRoot[:], sharedOutAmount, vtxoTree))
+
+		nonces, err := session.GetNonces()
+		require.NoError(t, err)
+		numOfNonces := 0
+		for _, level := range nonces {
+			for _, nonce := range level {
+				if nonce != nil {
+					numOfNonces++
+				}
+			}
+		}
+		require.Equal(t, len(vtxoTree), numOfNonces)
+
+		signers[keyToStr(prvkey)] = session
+		leaves[keyToStr(prvkey)] = leafTxid
+	}
+	serverSession := tree.NewTreeSignerSession(serverPrivKey)
+	require.NoError(t, serverSession.Init(sweepRoot[:], sharedOutAmount, vtxoTree))
+	signers[keyToStr(serverPrivKey)] = serverSession
+
+	err = makeAggregatedNonces(signers, coordinator, checkNoncesRoundtrip(t))
+	require.NoError(t, err)
+	signedTree, err := makeAggregatedSignatures(signers, coordinator, checkSigsRoundtrip(t))
+	require.NoError(t, err)
+
+	err = tree.ValidateTreeSigs(sweepRoot[:], sharedOutAmount, signedTree)
+	require.NoError(t, err)
+	for _, leafTxid := range leaves {
+		err := tree.ValidateBranchSigs(sweepRoot[:], sharedOutAmount, signedTree, leafTxid)
+		require.NoError(t, err)
+
+		// the signatures of the other branches are not required
+		err = tree.ValidateBranchSigs(sweepRoot[:], sharedOutAmount, vtxoTree, leafTxid)
+		require.Error(t, err)
+	}
+
+	t.Run("invalid leaf", func(t *testing.T) {
+		leafHash, err := chainhash.NewHashFromStr(vtxoTree[0][0].Txid)
+		require.NoError(t, err)
+		session := tree.NewBranchSignerSession(privKeys[0], wire.OutPoint{Hash: *leafHash})
+		err = session.Init(sweepRoot[:], sharedOutAmount, vtxoTree)
+		require.ErrorIs(t, err, tree.ErrLeafNotFound)
+
+		leafHash, err = chainhash.NewHashFromStr(leaves[keyToStr(privKeys[0])])
+		require.NoError(t, err)
+		session = tree.NewBranchSignerSession(privKeys[0], wire.OutPoint{Hash: *leafHash, Index: 10})
+		err = session.Init(sweepRoot[:], sharedOutAmount, vtxoTree)
+		require.Error(t, err)
+	})
+}
+
+func TestAggregatePartialNonces(t *testing.T) {
+	t.Parallel()
+
+	receivers, privKeys, err := generateMockedReceivers(4)
+	require.NoError(t, err)
+	receivers = withSigningType(tree.SignBranch, receivers)
+
+	_, sharedOutAmount, err := tree.CraftSharedOutput(receivers, minRelayFee, sweepRoot[:])
+	require.NoError(t, err)
+	vtxoTree, err := tree.BuildVtxoTree(
+		rootInput, receivers, minRelayFee, sweepRoot[:], vtxoTreeExpiry,
+	)
+	require.NoError(t, err)
+
+	coordinator, err := tree.NewTreeCoordinatorSession(sharedOutAmount, vtxoTree, sweepRoot[:])
+	require.NoError(t, err)
+
+	// the first receiver never submits its nonces
+	delinquentKey := privKeys[0]
+	delinquentLeaf := findLeaf(t, vtxoTree, delinquentKey.PubKey())
+
+	signers := make(map[string]tree.SignerSession)
+	for _, prvkey := range privKeys[1:] {
+		leafHash, err := chainhash.NewHashFromStr(findLeaf(t, vtxoTree, prvkey.PubKey()))
+		require.NoError(t, err)
+
+		session := tree.NewBranchSignerSession(prvkey, wire.OutPoint{Hash: *leafHash})
+		require.NoError(t, session.Init(sweepRoot[:], sharedOutAmount, vtxoTree))
+		signers[keyToStr(prvkey)] = session
+	}
+	serverSession := tree.NewTreeSignerSession(serverPrivKey)
+	require.NoError(t, serverSession.Init(sweepRoot[:], sharedOutAmount, vtxoTree))
+	signers[keyToStr(serverPrivKey)] = serverSession
+
+	noncesCh := make(chan tree.CosignerNonces, len(signers))
+	for pk, session := range signers {
+		buf, err := hex.DecodeString(pk)
+		require.NoError(t, err)
+		pubkey, err := btcec.ParsePubKey(buf)
+		require.NoError(t, err)
+		nonces, err := session.GetNonces()
+		require.NoError(t, err)
+		noncesCh <- tree.CosignerNonces{PubKey: pubkey, Nonces: nonces}
+	}
+
+	missing, err := coordinator.AddNonceStream(noncesCh, time.Now().Add(100*time.Millisecond))
+	require.NoError(t, err)
+	require.Len(t, missing, 1)
+	require.True(t, missing[0].IsEqual(delinquentKey.PubKey()))
+
+	_, err = coordinator.AggregateNonces()
+	require.Error(t, err)
+
+	aggregatedNonces, skippedTxids, err := coordinator.AggregatePartial()
+	require.NoError(t, err)
+
+	// only the branch of the delinquent signer is skipped
+	delinquentBranch, err := vtxoTree.Branch(delinquentLeaf)
+	require.NoError(t, err)
+	expectedSkippedTxids := make([]string, 0, len(delinquentBranch))
+	for _, node := range delinquentBranch {
+		expectedSkippedTxids = append(expectedSkippedTxids, node.Txid)
+	}
+	require.ElementsMatch(t, expectedSkippedTxids, skippedTxids)
+
+	for _, session := range signers {
+		session.SetAggregatedNonces(aggregatedNonces)
+	}
+	signedTree, err := makeAggregatedSignatures(signers, coordinator, checkSigsRoundtrip(t))
+	require.NoError(t, err)
+
+	// the skipped txs are unsigned, the others off the branch of the
+	// delinquent signer are signed
+	skipped := make(map[string]struct{})
+	for _, txid := range skippedTxids {
+		skipped[txid] = struct{}{}
+	}
+	numOfSignedTxs := 0
+	for _, level := range signedTree {
+		for _, node := range level {
+			ptx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+			require.NoError(t, err)
+
+			if _, ok := skipped[node.Txid]; ok {
+				require.Empty(t, ptx.Inputs[0].TaprootKeySpendSig)
+				continue
+			}
+			requireValidNodeSig(t, signedTree, ptx)
+			numOfSignedTxs++
+		}
+	}
+	require.Equal(t, signedTree.NumberOfNodes()-len(skippedTxids), numOfSignedTxs)
+}
+
+func TestAddNonceStreamInvalidTree(t *testing.T) {
+	t.Parallel()
+
+	receivers, _, err := generateMockedReceivers(2)
+	require.NoError(t, err)
+
+	_, sharedOutAmount, err := tree.CraftSharedOutput(receivers, minRelayFee, sweepRoot[:])
+	require.NoError(t, err)
+	vtxoTree, err := tree.BuildVtxoTree(
+		rootInput, receivers, minRelayFee, sweepRoot[:], vtxoTreeExpiry,
+	)
+	require.NoError(t, err)
+
+	// the cosigner keys of a leaf can't be parsed
+	leaf := vtxoTree.Leaves()[0]
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(leaf.Tx), true)
+	require.NoError(t, err)
+	require.NotEmpty(t, ptx.Inputs[0].Unknowns)
+	for _, unknown := range ptx.Inputs[0].Unknowns {
+		unknown.Value = []byte{0x01, 0x02}
+	}
+	corruptedTx, err := ptx.B64Encode()
+	require.NoError(t, err)
+	for i, level := range vtxoTree {
+		for j, node := range level {
+			if node.Txid == leaf.Txid {
+				vtxoTree[i][j].Tx = corruptedTx
+			}
+		}
+	}
+
+	coordinator, err := tree.NewTreeCoordinatorSession(sharedOutAmount, vtxoTree, sweepRoot[:])
+	require.NoError(t, err)
+
+	// the coordinator doesn't wait nor report that no cosigner is missing
+	noncesCh := make(chan tree.CosignerNonces)
+	start := time.Now()
+	missing, err := coordinator.AddNonceStream(noncesCh, time.Now().Add(time.Second))
+	require.ErrorContains(t, err, "failed to get cosigners of the tree")
+	require.Nil(t, missing)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+// requireValidNodeSig verifies the signature of the given non-root tx of the
+// tree against the aggregated key of its cosigners.
+func requireValidNodeSig(t *testing.T, vtxoTree tree.TxTree, ptx *psbt.Packet) {
+	prevout := ptx.UnsignedTx.TxIn[0].PreviousOutPoint
+
+	var parentTx *psbt.Packet
+	for _, level := range vtxoTree {
+		for _, node := range level {
+			if node.Txid == prevout.Hash.String() {
+				var err error
+				parentTx, err = psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+				require.NoError(t, err)
+			}
+		}
+	}
+	require.NotNil(t, parentTx)
+
+	parentOut := parentTx.UnsignedTx.TxOut[prevout.Index]
+	prevoutFetcher := txscript.NewCannedPrevOutputFetcher(parentOut.PkScript, parentOut.Value)
+	message, err := txscript.CalcTaprootSignatureHash(
+		txscript.NewTxSigHashes(ptx.UnsignedTx, prevoutFetcher),
+		txscript.SigHashDefault, ptx.UnsignedTx, 0, prevoutFetcher,
+	)
+	require.NoError(t, err)
+
+	keys, err := tree.GetCosignerKeys(ptx.Inputs[0])
+	require.NoError(t, err)
+	aggregatedKey, err := tree.AggregateKeys(keys, sweepRoot[:])
+	require.NoError(t, err)
+
+	sig, err := schnorr.ParseSignature(ptx.Inputs[0].TaprootKeySpendSig)
+	require.NoError(t, err)
+	require.True(t, sig.Verify(message, aggregatedKey.FinalKey))
+}
+
+// BenchmarkSignerSession measures the generation of the nonces of a SignBranch
+// receiver of a 128-leaf vtxo tree, with a session for the whole tree and one
+// for the receiver's branch only.
+func BenchmarkSignerSession(b *testing.B) {
+	receivers, privKeys, err := generateMockedReceivers(128)
+	require.NoError(b, err)
+	receivers = withSigningType(tree.SignBranch, receivers)
+
+	_, sharedOutAmount, err := tree.CraftSharedOutput(receivers, minRelayFee, sweepRoot[:])
+	require.NoError(b, err)
+	vtxoTree, err := tree.BuildVtxoTree(
+		rootInput, receivers, minRelayFee, sweepRoot[:], vtxoTreeExpiry,
+	)
+	require.NoError(b, err)
+
+	prvkey := privKeys[0]
+	leafHash, err := chainhash.NewHashFromStr(findLeaf(b, vtxoTree, prvkey.PubKey()))
+	require.NoError(b, err)
+
+	sessions := map[string]func() tree.SignerSession{
+		"tree": func() tree.SignerSession {
+			return tree.NewTreeSignerSession(prvkey)
+		},
+		"branch": func() tree.SignerSession {
+			return tree.NewBranchSignerSession(prvkey, wire.OutPoint{Hash: *leafHash})
+		},
+	}
+	for _, name := range []string{"tree", "branch"} {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				session := sessions[name]()
+				if err := session.Init(sweepRoot[:], sharedOutAmount, vtxoTree); err != nil {
+					b.Fatal(err)
+				}
+				if _, err := session.GetNonces(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// findLeaf returns the txid of the leaf of the vtxo tree cosigned by the
+// given key.
+func findLeaf(t testing.TB, vtxoTree tree.TxTree, pubkey *btcec.PublicKey) string {
+	for _, leaf := range vtxoTree.Leaves() {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(leaf.Tx), true)
+		require.NoError(t, err)
+		keys, err := tree.GetCosignerKeys(ptx.Inputs[0])
+		require.NoError(t, err)
+		for _, key := range keys {
+			if key.IsEqual(pubkey) {
+				return leaf.Txid
+			}
+		}
+	}
+	t.Fatalf("leaf not found for key %x", pubkey.SerializeCompressed())
+	return ""
+}
+
 func checkNoncesRoundtrip(t *testing.T) func(nonces tree.TreeNonces) {
 	return func(nonces tree.TreeNonces) {
 		var encodedNonces bytes.Buffer
 		err := nonces.Encode(&encodedNonces)
 		require.NoError(t, err)
+		require.Equal(t, encodedNonces.Len(), nonces.EncodedSize())
 
 		decodedNonces, err := tree.DecodeNonces(&encodedNonces)
 		require.NoError(t, err)
@@ -98,6 +471,7 @@ func checkSigsRoundtrip(t *testing.T) func(sigs tree.TreePartialSigs) {
 		var encodedSig bytes.Buffer
 		err := sigs.Encode(&encodedSig)
 		require.NoError(t, err)
+		require.Equal(t, encodedSig.Len(), sigs.EncodedSize())
 		decodedSig, err := tree.DecodeSignatures(&encodedSig)
 		require.NoError(t, err)
 		for i, sigRow := range sigs {
@@ -195,6 +569,40 @@ func makeAggregatedSignatures(
 	return coordinator.SignTree()
 }
 
+func TestAggregateCosignerKey(t *testing.T) {
+	receivers, _, err := generateMockedReceivers(2)
+	require.NoError(t, err)
+
+	cosigners := make([]string, 0)
+	for _, receiver := range receivers {
+		cosigners = append(cosigners, receiver.Musig2Data.CosignersPublicKeys...)
+	}
+
+	aggregateKey, err := tree.AggregateCosignerKey(cosigners)
+	require.NoError(t, err)
+
+	// the key is the same as the one of the shared output of the vtxo tree
+	sharedOutScript, _, err := tree.CraftSharedOutput(receivers, minRelayFee, sweepRoot[:])
+	require.NoError(t, err)
+	tapKey := txscript.ComputeTaprootOutputKey(aggregateKey, sweepRoot[:])
+	require.Equal(t, schnorr.SerializePubKey(tapKey), sharedOutScript[2:])
+
+	// the order of the cosigners doesn't matter
+	reversed := make([]string, 0, len(cosigners))
+	for i := len(cosigners) - 1; i >= 0; i-- {
+		reversed = append(reversed, cosigners[i])
+	}
+	reversedAggregateKey, err := tree.AggregateCosignerKey(reversed)
+	require.NoError(t, err)
+	require.True(t, aggregateKey.IsEqual(reversedAggregateKey))
+
+	_, err = tree.AggregateCosignerKey(nil)
+	require.Error(t, err)
+
+	_, err = tree.AggregateCosignerKey([]string{"invalid"})
+	require.Error(t, err)
+}
+
 type testCase struct {
 	name      string
 	receivers []tree.Leaf
@@ -1,12 +1,19 @@
 package tree
 
 import (
+	"bytes"
+	"encoding/hex"
 	"fmt"
 
 	"github.com/ark-network/ark/common"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/mempool"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
 const (
@@ -14,14 +21,123 @@ const (
 	cltvSequence = wire.MaxTxInSequenceNum - 1
 )
 
+// BuildRedeemTxWithFee builds a redeem tx like BuildRedeemTx, deducting the fee
+// from the last output, supposed to be the change.
+// The fee is computed with common.ComputeRedeemTxFee, therefore anyone given
+// the same vtxos, outputs and fee rate builds a byte-identical tx.
+func BuildRedeemTxWithFee(
+	vtxos []common.VtxoInput,
+	outputs []*wire.TxOut,
+	feeRate chainfee.SatPerKVByte,
+) (string, error) {
+	if len(outputs) <= 0 {
+		return "", fmt.Errorf("missing outputs")
+	}
+
+	fee, err := common.ComputeRedeemTxFee(feeRate, vtxos, len(outputs))
+	if err != nil {
+		return "", err
+	}
+
+	change := outputs[len(outputs)-1]
+	if fee >= change.Value {
+		return "", fmt.Errorf(
+			"redeem tx fee %d is higher than the amount of the change output %d",
+			fee, change.Value,
+		)
+	}
+
+	outs := make([]*wire.TxOut, 0, len(outputs))
+	outs = append(outs, outputs[:len(outputs)-1]...)
+	outs = append(outs, &wire.TxOut{
+		Value:    change.Value - fee,
+		PkScript: change.PkScript,
+	})
+
+	return BuildRedeemTx(vtxos, outs)
+}
+
+// BuildRedeemTxWithChange builds a redeem tx like BuildRedeemTx, paying the
+// given outputs and sending the rest of the amount of the vtxos, minus the fee,
+// to a change output with the given script, appended last.
+// The fee is computed with common.ComputeRedeemTxFeeWithOutputs, from the
+// witness size of every input and the scripts of all outputs, including the
+// change. It fails if the change would be dust.
+func BuildRedeemTxWithChange(
+	vtxos []common.VtxoInput,
+	outputs []*wire.TxOut,
+	changeScript []byte,
+	feeRate chainfee.SatPerKVByte,
+) (string, error) {
+	if len(changeScript) <= 0 {
+		return "", fmt.Errorf("missing change script")
+	}
+
+	inputAmount := int64(0)
+	for index, vtxo := range vtxos {
+		if vtxo.WitnessSize <= 0 {
+			return "", fmt.Errorf("missing witness size for input %d", index)
+		}
+		inputAmount += vtxo.Amount
+	}
+
+	outputAmount := int64(0)
+	outputScripts := make([][]byte, 0, len(outputs)+1)
+	for _, out := range outputs {
+		outputAmount += out.Value
+		outputScripts = append(outputScripts, out.PkScript)
+	}
+	outputScripts = append(outputScripts, changeScript)
+
+	fee, err := common.ComputeRedeemTxFeeWithOutputs(feeRate, vtxos, outputScripts)
+	if err != nil {
+		return "", err
+	}
+
+	change := &wire.TxOut{
+		Value:    inputAmount - outputAmount - fee,
+		PkScript: changeScript,
+	}
+	if change.Value <= 0 || mempool.IsDust(change, mempool.DefaultMinRelayTxFee) {
+		return "", fmt.Errorf(
+			"change amount %d is dust after paying fee %d",
+			change.Value, fee,
+		)
+	}
+
+	outs := make([]*wire.TxOut, 0, len(outputs)+1)
+	outs = append(outs, outputs...)
+	outs = append(outs, change)
+
+	return BuildRedeemTx(vtxos, outs)
+}
+
+// BuildRedeemTx builds the redeem tx spending the given vtxos with their
+// signing tapscript.
+// The tapscripts revealed for every input are added to the psbt: if empty,
+// only the spent closure is revealed, the rest of the taproot tree being
+// committed by the sibling hashes of the control block. The witness of the
+// final tx never includes other than the spent script, but the server needs
+// the full reveal to verify the vtxo script, therefore partial reveals are
+// meant for txs not submitted to it, like unilateral exits.
+// A full reveal must be the whole taproot tree of the spent output: the control
+// block of every input is verified against the taproot key of its revealed
+// tapscripts, and the error names the index of the offending input.
+// WithAnchor appends a zero-value anchor output after the given ones.
 func BuildRedeemTx(
 	vtxos []common.VtxoInput,
 	outputs []*wire.TxOut,
+	opts ...BuildOption,
 ) (string, error) {
 	if len(vtxos) <= 0 {
 		return "", fmt.Errorf("missing vtxos")
 	}
 
+	o := newBuildOptions(opts)
+	if o.anchor {
+		outputs = append(append([]*wire.TxOut{}, outputs...), AnchorOutput())
+	}
+
 	ins := make([]*wire.OutPoint, 0, len(vtxos))
 	sequences := make([]uint32, 0, len(vtxos))
 	witnessUtxos := make(map[int]*wire.TxOut)
@@ -31,14 +147,20 @@ func BuildRedeemTx(
 	txLocktime := common.AbsoluteLocktime(0)
 
 	for index, vtxo := range vtxos {
-		if len(vtxo.RevealedTapscripts) == 0 {
-			return "", fmt.Errorf("missing tapscripts for input %d", index)
+		if vtxo.Tapscript == nil {
+			return "", fmt.Errorf("missing tapscript for input %d", index)
 		}
 
-		tapscripts[index] = vtxo.RevealedTapscripts
+		revealedTapscripts, err := revealTapscripts(vtxo)
+		if err != nil {
+			return "", fmt.Errorf("invalid tapscripts for input %d: %s", index, err)
+		}
+		tapscripts[index] = revealedTapscripts
 
-		rootHash := vtxo.Tapscript.ControlBlock.RootHash(vtxo.Tapscript.RevealedScript)
-		taprootKey := txscript.ComputeTaprootOutputKey(UnspendableKey(), rootHash)
+		taprootKey, err := verifyTapscript(vtxo)
+		if err != nil {
+			return "", fmt.Errorf("invalid tapscript for input %d: %s", index, err)
+		}
 
 		vtxoOutputScript, err := common.P2TRScript(taprootKey)
 		if err != nil {
@@ -95,7 +217,7 @@ func BuildRedeemTx(
 	}
 
 	redeemPtx, err := psbt.New(
-		ins, outputs, 2, uint32(txLocktime), sequences,
+		ins, outputs, o.txVersion(), uint32(txLocktime), sequences,
 	)
 	if err != nil {
 		return "", err
@@ -116,3 +238,71 @@ func BuildRedeemTx(
 
 	return redeemTx, nil
 }
+
+// revealTapscripts returns the tapscripts to reveal for the given input, that
+// must include the spent one. If none is given, only the spent one is revealed.
+func revealTapscripts(vtxo common.VtxoInput) ([]string, error) {
+	spentScript := hex.EncodeToString(vtxo.Tapscript.RevealedScript)
+	if len(vtxo.RevealedTapscripts) <= 0 {
+		return []string{spentScript}, nil
+	}
+
+	for _, tapscript := range vtxo.RevealedTapscripts {
+		if tapscript == spentScript {
+			return vtxo.RevealedTapscripts, nil
+		}
+	}
+	return nil, fmt.Errorf("spent tapscript not revealed")
+}
+
+// verifyTapscript makes sure the control block of the given input proves the
+// spent script is a leaf of the taproot tree of the spent output, and returns
+// the taproot key of the output. If the tapscripts of the input are revealed,
+// the output key is the one of their tree, otherwise it's derived from the
+// control block itself.
+func verifyTapscript(vtxo common.VtxoInput) (*btcec.PublicKey, error) {
+	ctrlBlock := vtxo.Tapscript.ControlBlock
+	if ctrlBlock == nil {
+		return nil, fmt.Errorf("missing control block")
+	}
+	if ctrlBlock.InternalKey == nil || !bytes.Equal(
+		schnorr.SerializePubKey(ctrlBlock.InternalKey),
+		schnorr.SerializePubKey(UnspendableKey()),
+	) {
+		return nil, fmt.Errorf("control block internal key is not the unspendable key")
+	}
+	// the control block is given already parsed, its proof might be invalid
+	if len(ctrlBlock.InclusionProof)%chainhash.HashSize != 0 {
+		return nil, fmt.Errorf("invalid control block inclusion proof size")
+	}
+	if len(ctrlBlock.InclusionProof)/chainhash.HashSize > txscript.ControlBlockMaxNodeCount {
+		return nil, ErrTapTreeTooDeep
+	}
+
+	rootHash := ctrlBlock.RootHash(vtxo.Tapscript.RevealedScript)
+	taprootKey := txscript.ComputeTaprootOutputKey(UnspendableKey(), rootHash)
+
+	if len(vtxo.RevealedTapscripts) > 0 {
+		leaves := make([]txscript.TapLeaf, 0, len(vtxo.RevealedTapscripts))
+		for _, tapscript := range vtxo.RevealedTapscripts {
+			script, err := hex.DecodeString(tapscript)
+			if err != nil {
+				return nil, fmt.Errorf("invalid revealed tapscript %s: %s", tapscript, err)
+			}
+			leaves = append(leaves, txscript.NewBaseTapLeaf(script))
+		}
+		root := txscript.AssembleTaprootScriptTree(leaves...).RootNode.TapHash()
+		taprootKey = txscript.ComputeTaprootOutputKey(UnspendableKey(), root[:])
+	}
+
+	if err := txscript.VerifyTaprootLeafCommitment(
+		ctrlBlock, schnorr.SerializePubKey(taprootKey), vtxo.Tapscript.RevealedScript,
+	); err != nil {
+		return nil, fmt.Errorf(
+			"control block doesn't commit to the taproot key %x of the revealed "+
+				"tapscripts: %s", schnorr.SerializePubKey(taprootKey), err,
+		)
+	}
+
+	return taprootKey, nil
+}
@@ -0,0 +1,393 @@
+package tree_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRedeemTxWithFee(t *testing.T) {
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	receiverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512}
+	amounts := []int64{10_000, 25_000, 3_333}
+
+	// makeInputs rebuilds the inputs from scratch to simulate two parties
+	// independently constructing the same logical tx.
+	makeInputs := func() []common.VtxoInput {
+		ins := make([]common.VtxoInput, 0, len(amounts))
+		for i, amount := range amounts {
+			vtxoScript := tree.NewDefaultVtxoScript(
+				ownerKey.PubKey(), serverKey.PubKey(), exitDelay,
+			)
+			_, tapTree, err := vtxoScript.TapTree()
+			require.NoError(t, err)
+
+			forfeitClosure := vtxoScript.ForfeitClosures()[0]
+			forfeitScript, err := forfeitClosure.Script()
+			require.NoError(t, err)
+
+			leafProof, err := tapTree.GetTaprootMerkleProof(
+				txscript.NewBaseTapLeaf(forfeitScript).TapHash(),
+			)
+			require.NoError(t, err)
+
+			ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
+			require.NoError(t, err)
+
+			tapscripts, err := vtxoScript.Encode()
+			require.NoError(t, err)
+
+			ins = append(ins, common.VtxoInput{
+				Outpoint: &wire.OutPoint{
+					Hash:  chainhash.DoubleHashH([]byte{byte(i)}),
+					Index: uint32(i),
+				},
+				Amount: amount,
+				Tapscript: &waddrmgr.Tapscript{
+					RevealedScript: leafProof.Script,
+					ControlBlock:   ctrlBlock,
+				},
+				WitnessSize:        forfeitClosure.WitnessSize(),
+				RevealedTapscripts: tapscripts,
+			})
+		}
+		return ins
+	}
+
+	makeOutputs := func() []*wire.TxOut {
+		receiverScript, err := common.P2TRScript(receiverKey.PubKey())
+		require.NoError(t, err)
+		changeScript, err := common.P2TRScript(ownerKey.PubKey())
+		require.NoError(t, err)
+
+		return []*wire.TxOut{
+			{Value: 20_000, PkScript: receiverScript},
+			{Value: 18_333, PkScript: changeScript},
+		}
+	}
+
+	feeRates := []chainfee.SatPerKVByte{1000, 1001, 1499, 2500, 12_345}
+	for _, feeRate := range feeRates {
+		outputs := makeOutputs()
+
+		tx1, err := tree.BuildRedeemTxWithFee(makeInputs(), outputs, feeRate)
+		require.NoError(t, err)
+		tx2, err := tree.BuildRedeemTxWithFee(makeInputs(), makeOutputs(), feeRate)
+		require.NoError(t, err)
+		require.Equal(t, tx1, tx2)
+
+		// the outputs given by the caller must not be modified
+		require.Equal(t, makeOutputs(), outputs)
+
+		// building the tx by computing the fee separately must give the
+		// same serialization
+		ins := makeInputs()
+		fee, err := common.ComputeRedeemTxFee(feeRate, ins, len(outputs))
+		require.NoError(t, err)
+		outputs[len(outputs)-1].Value -= fee
+		tx3, err := tree.BuildRedeemTx(ins, outputs)
+		require.NoError(t, err)
+		require.Equal(t, tx1, tx3)
+
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx1), true)
+		require.NoError(t, err)
+		require.Equal(t, int64(18_333)-fee, ptx.UnsignedTx.TxOut[1].Value)
+	}
+
+	t.Run("fee rounding", func(t *testing.T) {
+		// at 1 sat/vbyte the fee equals the vsize of the tx
+		vsize, err := common.ComputeRedeemTxFee(1000, makeInputs(), 2)
+		require.NoError(t, err)
+		require.Less(t, vsize, int64(1000))
+
+		// any fraction of satoshi is rounded up
+		fee, err := common.ComputeRedeemTxFee(1001, makeInputs(), 2)
+		require.NoError(t, err)
+		require.Equal(t, vsize+1, fee)
+
+		fee, err = common.ComputeRedeemTxFee(1999, makeInputs(), 2)
+		require.NoError(t, err)
+		require.Equal(t, 2*vsize, fee)
+	})
+
+	t.Run("with change", func(t *testing.T) {
+		outputs := makeOutputs()
+		receiverOutputs := outputs[:1]
+		changeScript := outputs[1].PkScript
+
+		for _, feeRate := range feeRates {
+			redeemTx, err := tree.BuildRedeemTxWithChange(
+				makeInputs(), receiverOutputs, changeScript, feeRate,
+			)
+			require.NoError(t, err)
+
+			// with a P2TR change, the tx is the same as the one built with the
+			// change computed by the caller
+			expectedTx, err := tree.BuildRedeemTxWithFee(makeInputs(), outputs, feeRate)
+			require.NoError(t, err)
+			require.Equal(t, expectedTx, redeemTx)
+		}
+
+		// a smaller change script pays a lower fee
+		p2wpkhScript := append([]byte{txscript.OP_0, txscript.OP_DATA_20}, make([]byte, 20)...)
+		redeemTx, err := tree.BuildRedeemTxWithChange(
+			makeInputs(), receiverOutputs, p2wpkhScript, 2500,
+		)
+		require.NoError(t, err)
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+		require.NoError(t, err)
+		require.Len(t, ptx.UnsignedTx.TxOut, 2)
+		require.Equal(t, p2wpkhScript, ptx.UnsignedTx.TxOut[1].PkScript)
+
+		p2trFee, err := common.ComputeRedeemTxFee(2500, makeInputs(), 2)
+		require.NoError(t, err)
+		require.Greater(t, ptx.UnsignedTx.TxOut[1].Value, int64(18_333)-p2trFee)
+
+		// the whole amount can go to the change
+		_, err = tree.BuildRedeemTxWithChange(makeInputs(), nil, changeScript, 1000)
+		require.NoError(t, err)
+
+		// dust change
+		receiverOutputs[0].Value = 38_000
+		_, err = tree.BuildRedeemTxWithChange(
+			makeInputs(), receiverOutputs, changeScript, 1000,
+		)
+		require.Error(t, err)
+
+		_, err = tree.BuildRedeemTxWithChange(makeInputs(), nil, nil, 1000)
+		require.Error(t, err)
+
+		ins := makeInputs()
+		ins[0].WitnessSize = 0
+		_, err = tree.BuildRedeemTxWithChange(ins, nil, changeScript, 1000)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := tree.BuildRedeemTxWithFee(makeInputs(), nil, 1000)
+		require.Error(t, err)
+
+		outputs := makeOutputs()
+		outputs[len(outputs)-1].Value = 1
+		_, err = tree.BuildRedeemTxWithFee(makeInputs(), outputs, 1000)
+		require.Error(t, err)
+	})
+}
+
+func TestBuildRedeemTxPartialReveal(t *testing.T) {
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	watchtowerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	vtxoScript := &tree.TapscriptsVtxoScript{
+		Closures: []tree.Closure{
+			&tree.CSVMultisigClosure{
+				MultisigClosure: tree.MultisigClosure{
+					PubKeys: []*secp256k1.PublicKey{ownerKey.PubKey()},
+				},
+				Locktime: exitDelay,
+			},
+			&tree.MultisigClosure{
+				PubKeys: []*secp256k1.PublicKey{ownerKey.PubKey(), serverKey.PubKey()},
+			},
+			&tree.MultisigClosure{
+				PubKeys: []*secp256k1.PublicKey{ownerKey.PubKey(), watchtowerKey.PubKey()},
+			},
+		},
+	}
+	tapKey, tapTree, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+	vtxoPkScript, err := common.P2TRScript(tapKey)
+	require.NoError(t, err)
+
+	spentClosure := vtxoScript.Closures[1]
+	spentScript, err := spentClosure.Script()
+	require.NoError(t, err)
+	spentLeaf := txscript.NewBaseTapLeaf(spentScript)
+	leafProof, err := tapTree.GetTaprootMerkleProof(spentLeaf.TapHash())
+	require.NoError(t, err)
+	ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
+	require.NoError(t, err)
+
+	receiverScript, err := common.P2TRScript(ownerKey.PubKey())
+	require.NoError(t, err)
+
+	makeInput := func(revealedTapscripts []string) common.VtxoInput {
+		return common.VtxoInput{
+			Outpoint: &wire.OutPoint{Hash: chainhash.DoubleHashH([]byte("vtxo"))},
+			Amount:   10_000,
+			Tapscript: &waddrmgr.Tapscript{
+				RevealedScript: leafProof.Script,
+				ControlBlock:   ctrlBlock,
+			},
+			WitnessSize:        spentClosure.WitnessSize(),
+			RevealedTapscripts: revealedTapscripts,
+		}
+	}
+	outputs := []*wire.TxOut{{Value: 9_000, PkScript: receiverScript}}
+
+	t.Run("valid", func(t *testing.T) {
+		redeemTx, err := tree.BuildRedeemTx([]common.VtxoInput{makeInput(nil)}, outputs)
+		require.NoError(t, err)
+
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+		require.NoError(t, err)
+
+		// only the spent closure is revealed, the others are committed by the
+		// sibling hashes of the control block
+		revealed, err := tree.GetTaprootTree(ptx.Inputs[0])
+		require.NoError(t, err)
+		require.Equal(t, []string{hex.EncodeToString(spentScript)}, revealed)
+		require.Len(t, ctrlBlock.InclusionProof, 2*chainhash.HashSize)
+		require.Equal(t, vtxoPkScript, ptx.Inputs[0].WitnessUtxo.PkScript)
+
+		// the witness satisfies the script of the vtxo
+		prevoutFetcher := txscript.NewCannedPrevOutputFetcher(vtxoPkScript, 10_000)
+		sigHashes := txscript.NewTxSigHashes(ptx.UnsignedTx, prevoutFetcher)
+		sighash, err := txscript.CalcTapscriptSignaturehash(
+			sigHashes, txscript.SigHashDefault, ptx.UnsignedTx, 0, prevoutFetcher, spentLeaf,
+		)
+		require.NoError(t, err)
+
+		sigs := make(map[string][]byte)
+		for _, key := range []*secp256k1.PrivateKey{ownerKey, serverKey} {
+			sig, err := schnorr.Sign(key, sighash)
+			require.NoError(t, err)
+			sigs[hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))] = sig.Serialize()
+		}
+		witness, err := spentClosure.Witness(leafProof.ControlBlock, sigs)
+		require.NoError(t, err)
+		require.Len(t, witness, 4)
+
+		tx := ptx.UnsignedTx.Copy()
+		tx.TxIn[0].Witness = witness
+		engine, err := txscript.NewEngine(
+			vtxoPkScript, tx, 0, txscript.StandardVerifyFlags, nil,
+			txscript.NewTxSigHashes(tx, prevoutFetcher), 10_000, prevoutFetcher,
+		)
+		require.NoError(t, err)
+		require.NoError(t, engine.Execute())
+
+		// a full reveal results in the same tx, with a bigger psbt
+		tapscripts, err := vtxoScript.Encode()
+		require.NoError(t, err)
+		fullRedeemTx, err := tree.BuildRedeemTx([]common.VtxoInput{makeInput(tapscripts)}, outputs)
+		require.NoError(t, err)
+		fullPtx, err := psbt.NewFromRawBytes(strings.NewReader(fullRedeemTx), true)
+		require.NoError(t, err)
+		require.Equal(t, ptx.UnsignedTx.TxHash(), fullPtx.UnsignedTx.TxHash())
+		require.Less(t, len(redeemTx), len(fullRedeemTx))
+	})
+
+	t.Run("with anchor", func(t *testing.T) {
+		redeemTx, err := tree.BuildRedeemTx(
+			[]common.VtxoInput{makeInput(nil)}, outputs, tree.WithAnchor(),
+		)
+		require.NoError(t, err)
+
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+		require.NoError(t, err)
+		require.Equal(t, int32(3), ptx.UnsignedTx.Version)
+		require.Len(t, ptx.UnsignedTx.TxOut, len(outputs)+1)
+		require.Equal(t, outputs[0], ptx.UnsignedTx.TxOut[0])
+		require.True(t, tree.IsAnchor(ptx.UnsignedTx.TxOut[1]))
+		require.Zero(t, ptx.UnsignedTx.TxOut[1].Value)
+		// the given outputs are left untouched
+		require.Len(t, outputs, 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		// the spent closure must be revealed
+		otherScript, err := vtxoScript.Closures[2].Script()
+		require.NoError(t, err)
+		_, err = tree.BuildRedeemTx(
+			[]common.VtxoInput{makeInput([]string{hex.EncodeToString(otherScript)})}, outputs,
+		)
+		require.ErrorContains(t, err, "spent tapscript not revealed")
+
+		input := makeInput(nil)
+		input.Tapscript = nil
+		_, err = tree.BuildRedeemTx([]common.VtxoInput{input}, outputs)
+		require.ErrorContains(t, err, "missing tapscript")
+
+		// the revealed tapscripts must be the ones of the spent output
+		tapscripts, err := vtxoScript.Encode()
+		require.NoError(t, err)
+		_, err = tree.BuildRedeemTx(
+			[]common.VtxoInput{makeInput(tapscripts[:2])}, outputs,
+		)
+		require.ErrorContains(t, err, "invalid tapscript for input 0")
+		require.ErrorContains(t, err, "control block doesn't commit")
+
+		// the control block must prove the spent script
+		input = makeInput(tapscripts)
+		input.Tapscript = &waddrmgr.Tapscript{
+			RevealedScript: otherScript,
+			ControlBlock:   ctrlBlock,
+		}
+		_, err = tree.BuildRedeemTx(
+			[]common.VtxoInput{makeInput(tapscripts), input}, outputs,
+		)
+		require.ErrorContains(t, err, "invalid tapscript for input 1")
+
+		// the internal key must be the unspendable one
+		input = makeInput(tapscripts)
+		input.Tapscript = &waddrmgr.Tapscript{
+			RevealedScript: leafProof.Script,
+			ControlBlock: &txscript.ControlBlock{
+				InternalKey:     ownerKey.PubKey(),
+				OutputKeyYIsOdd: ctrlBlock.OutputKeyYIsOdd,
+				LeafVersion:     ctrlBlock.LeafVersion,
+				InclusionProof:  ctrlBlock.InclusionProof,
+			},
+		}
+		_, err = tree.BuildRedeemTx([]common.VtxoInput{input}, outputs)
+		require.ErrorContains(t, err, "internal key is not the unspendable key")
+
+		// the inclusion proof must fit in a valid control block
+		invalidProofs := map[string][]byte{
+			tree.ErrTapTreeTooDeep.Error(): bytes.Repeat(
+				ctrlBlock.InclusionProof[:chainhash.HashSize],
+				txscript.ControlBlockMaxNodeCount+1,
+			),
+			"invalid control block inclusion proof size": ctrlBlock.InclusionProof[1:],
+		}
+		for expectedErr, proof := range invalidProofs {
+			input = makeInput(nil)
+			input.Tapscript = &waddrmgr.Tapscript{
+				RevealedScript: leafProof.Script,
+				ControlBlock: &txscript.ControlBlock{
+					InternalKey:     ctrlBlock.InternalKey,
+					OutputKeyYIsOdd: ctrlBlock.OutputKeyYIsOdd,
+					LeafVersion:     ctrlBlock.LeafVersion,
+					InclusionProof:  proof,
+				},
+			}
+			_, err = tree.BuildRedeemTx([]common.VtxoInput{input}, outputs)
+			require.ErrorContains(t, err, expectedErr)
+		}
+	})
+}
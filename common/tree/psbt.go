@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"sort"
 
 	"github.com/ark-network/ark/common"
 	"github.com/btcsuite/btcd/btcutil/psbt"
@@ -48,7 +49,19 @@ func GetTaprootTree(in psbt.PInput) ([]string, error) {
 	return nil, fmt.Errorf("no taproot tree found")
 }
 
+// AddConditionWitness sets the condition witness of the given PSBT input,
+// replacing the one already set if any, so that it can be called once per
+// input of a tx spending several condition closures.
+//
+// The condition witness is stored in an unknown field of the input: it's not
+// committed by the sighash and doesn't need to be set before signing, but it
+// must be present when the input is finalized since the closure Witness
+// method reads it to build the final witness.
 func AddConditionWitness(inIndex int, ptx *psbt.Packet, witness wire.TxWitness) error {
+	if inIndex < 0 || inIndex >= len(ptx.Inputs) {
+		return fmt.Errorf("input index %d out of range [0, %d)", inIndex, len(ptx.Inputs))
+	}
+
 	var witnessBytes bytes.Buffer
 
 	err := psbt.WriteTxWitness(&witnessBytes, witness)
@@ -56,6 +69,13 @@ func AddConditionWitness(inIndex int, ptx *psbt.Packet, witness wire.TxWitness)
 		return err
 	}
 
+	for _, u := range ptx.Inputs[inIndex].Unknowns {
+		if bytes.Contains(u.Key, CONDITION_WITNESS_KEY_PREFIX) {
+			u.Value = witnessBytes.Bytes()
+			return nil
+		}
+	}
+
 	ptx.Inputs[inIndex].Unknowns = append(ptx.Inputs[inIndex].Unknowns, &psbt.Unknown{
 		Value: witnessBytes.Bytes(),
 		Key:   CONDITION_WITNESS_KEY_PREFIX,
@@ -63,6 +83,31 @@ func AddConditionWitness(inIndex int, ptx *psbt.Packet, witness wire.TxWitness)
 	return nil
 }
 
+// AddConditionWitnesses sets the condition witness of every given input of the
+// PSBT. Unlike AddConditionWitness, it fails if any input index is out of
+// range or already has a condition witness, and in that case the PSBT is left
+// untouched.
+func AddConditionWitnesses(ptx *psbt.Packet, witnesses map[int]wire.TxWitness) error {
+	indexes := make([]int, 0, len(witnesses))
+	for inIndex := range witnesses {
+		if inIndex < 0 || inIndex >= len(ptx.Inputs) {
+			return fmt.Errorf("input index %d out of range [0, %d)", inIndex, len(ptx.Inputs))
+		}
+		if hasConditionWitness(ptx.Inputs[inIndex]) {
+			return fmt.Errorf("input %d already has a condition witness", inIndex)
+		}
+		indexes = append(indexes, inIndex)
+	}
+	sort.Ints(indexes)
+
+	for _, inIndex := range indexes {
+		if err := AddConditionWitness(inIndex, ptx, witnesses[inIndex]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func GetConditionWitness(in psbt.PInput) (wire.TxWitness, error) {
 	for _, u := range in.Unknowns {
 		if bytes.Contains(u.Key, CONDITION_WITNESS_KEY_PREFIX) {
@@ -73,6 +118,15 @@ func GetConditionWitness(in psbt.PInput) (wire.TxWitness, error) {
 	return wire.TxWitness{}, nil
 }
 
+func hasConditionWitness(in psbt.PInput) bool {
+	for _, u := range in.Unknowns {
+		if bytes.Contains(u.Key, CONDITION_WITNESS_KEY_PREFIX) {
+			return true
+		}
+	}
+	return false
+}
+
 func AddVtxoTreeExpiry(inIndex int, ptx *psbt.Packet, vtxoTreeExpiry common.RelativeLocktime) error {
 	sequence, err := common.BIP68Sequence(vtxoTreeExpiry)
 	if err != nil {
@@ -44,6 +44,53 @@ func TestPsbtCustomUnknownFields(t *testing.T) {
 		}
 	})
 
+	t.Run("condition witnesses", func(t *testing.T) {
+		ptx, err := psbt.New(nil, nil, 2, 0, nil)
+		require.NoError(t, err)
+
+		ptx.UnsignedTx.TxIn = []*wire.TxIn{{}, {}, {}}
+		ptx.Inputs = []psbt.PInput{{}, {}, {}}
+
+		witnesses := map[int]wire.TxWitness{
+			0: {[]byte{0x01}},
+			2: {[]byte{0x02}, []byte{0x03}},
+		}
+		err = tree.AddConditionWitnesses(ptx, witnesses)
+		require.NoError(t, err)
+
+		for i, in := range ptx.Inputs {
+			retrievedWitness, err := tree.GetConditionWitness(in)
+			require.NoError(t, err)
+			if witness, ok := witnesses[i]; ok {
+				require.Equal(t, witness, retrievedWitness)
+				continue
+			}
+			require.Empty(t, retrievedWitness)
+		}
+
+		// an input can't be populated twice in a batch
+		err = tree.AddConditionWitnesses(ptx, map[int]wire.TxWitness{
+			1: {[]byte{0x04}},
+			2: {[]byte{0x05}},
+		})
+		require.Error(t, err)
+		require.Empty(t, ptx.Inputs[1].Unknowns)
+
+		err = tree.AddConditionWitnesses(ptx, map[int]wire.TxWitness{3: {[]byte{0x05}}})
+		require.Error(t, err)
+
+		// while AddConditionWitness replaces the existing one
+		err = tree.AddConditionWitness(2, ptx, wire.TxWitness{[]byte{0x05}})
+		require.NoError(t, err)
+		require.Len(t, ptx.Inputs[2].Unknowns, 1)
+		retrievedWitness, err := tree.GetConditionWitness(ptx.Inputs[2])
+		require.NoError(t, err)
+		require.Equal(t, wire.TxWitness{[]byte{0x05}}, retrievedWitness)
+
+		err = tree.AddConditionWitness(-1, ptx, wire.TxWitness{[]byte{0x05}})
+		require.Error(t, err)
+	})
+
 	t.Run("vtxo tree expiry", func(t *testing.T) {
 		// Create a new PSBT
 		ptx, err := psbt.New(nil, nil, 2, 0, nil)
@@ -2,6 +2,7 @@ package tree
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/binary"
 	"encoding/hex"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/ark-network/ark/common"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
@@ -31,6 +33,14 @@ const (
 
 const ConditionWitnessKey = "condition"
 
+// HashFunc is the hash function used by a HashLockMultisigClosure.
+type HashFunc int
+
+const (
+	HashFuncSHA256 HashFunc = iota
+	HashFuncHASH160
+)
+
 // forbiddenOpcodes are opcodes that are not allowed in a condition script
 var forbiddenOpcodes = []byte{
 	txscript.OP_CHECKMULTISIG,
@@ -92,6 +102,27 @@ type ConditionCSVMultisigClosure struct {
 	Condition []byte
 }
 
+// HashLockMultisigClosure is a closure that requires the preimage of the hash
+// along with the signatures of the multisig closure, like the claim path of an
+// HTLC. For HASH160 only the first 20 bytes of Hash are used. The preimage is
+// passed as condition witness and is expected to be 32 bytes long.
+type HashLockMultisigClosure struct {
+	MultisigClosure
+	Hash     [32]byte
+	HashType HashFunc
+}
+
+// RefundableCLTVClosure is a closure with two spending paths in the same
+// script: the receiver multisig can spend at any time, while the refund
+// multisig can spend only after the absolute locktime. The witness selects the
+// path with the OP_IF argument, therefore it is 1 byte bigger than the one of
+// the multisig of the chosen path.
+type RefundableCLTVClosure struct {
+	Locktime         common.AbsoluteLocktime
+	ReceiverMultisig MultisigClosure
+	RefundMultisig   MultisigClosure
+}
+
 func DecodeClosure(script []byte) (Closure, error) {
 	if len(script) == 0 {
 		return nil, fmt.Errorf("cannot decode empty script")
@@ -104,6 +135,8 @@ func DecodeClosure(script []byte) (Closure, error) {
 		{&CSVMultisigClosure{}, "CSV Multisig"},
 		{&CLTVMultisigClosure{}, "CLTV Multisig"},
 		{&MultisigClosure{}, "Multisig"},
+		{&HashLockMultisigClosure{}, "Hash Lock Multisig"},
+		{&RefundableCLTVClosure{}, "Refundable CLTV"},
 		{&ConditionMultisigClosure{}, "Condition Multisig"},
 		{&ConditionCSVMultisigClosure{}, "Condition CSV Multisig"},
 	}
@@ -810,3 +843,314 @@ func (f *ConditionCSVMultisigClosure) Witness(controlBlock []byte, args map[stri
 
 	return witness, nil
 }
+
+func (f *HashLockMultisigClosure) WitnessSize(preimageSizes ...int) int {
+	if len(preimageSizes) <= 0 {
+		return f.MultisigClosure.WitnessSize() + 32
+	}
+
+	var sum int
+	for _, size := range preimageSizes {
+		sum += size
+	}
+
+	return f.MultisigClosure.WitnessSize() + sum
+}
+
+func (f *HashLockMultisigClosure) Script() ([]byte, error) {
+	hashOp, hash, err := f.hashLock()
+	if err != nil {
+		return nil, err
+	}
+
+	scriptBuilder := txscript.NewScriptBuilder().
+		AddOp(hashOp).
+		AddData(hash).
+		AddOp(txscript.OP_EQUALVERIFY)
+
+	// Add the multisig script
+	multisigScript, err := f.MultisigClosure.Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate multisig script: %w", err)
+	}
+	scriptBuilder.AddOps(multisigScript)
+
+	return scriptBuilder.Script()
+}
+
+func (f *HashLockMultisigClosure) Decode(script []byte) (bool, error) {
+	if len(script) == 0 {
+		return false, fmt.Errorf("empty script")
+	}
+
+	tokenizer := txscript.MakeScriptTokenizer(0, script)
+
+	if !tokenizer.Next() {
+		return false, nil
+	}
+
+	var hashType HashFunc
+	var hashSize int
+	switch tokenizer.Opcode() {
+	case txscript.OP_SHA256:
+		hashType, hashSize = HashFuncSHA256, 32
+	case txscript.OP_HASH160:
+		hashType, hashSize = HashFuncHASH160, 20
+	default:
+		return false, nil
+	}
+
+	if !tokenizer.Next() || len(tokenizer.Data()) != hashSize {
+		return false, nil
+	}
+	var hash [32]byte
+	copy(hash[:], tokenizer.Data())
+
+	if !tokenizer.Next() || tokenizer.Opcode() != txscript.OP_EQUALVERIFY {
+		return false, nil
+	}
+
+	multisigClosure := &MultisigClosure{}
+	subScript := tokenizer.Script()[tokenizer.ByteIndex():]
+	valid, err := multisigClosure.Decode(subScript)
+	if err != nil || !valid {
+		return false, err
+	}
+
+	f.Hash = hash
+	f.HashType = hashType
+	f.MultisigClosure = *multisigClosure
+
+	return true, nil
+}
+
+func (f *HashLockMultisigClosure) Witness(controlBlock []byte, args map[string][]byte) (wire.TxWitness, error) {
+	script, err := f.Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate script: %w", err)
+	}
+
+	// Read the preimage from the condition witness
+	condWitness, err := ReadTxWitness(args[ConditionWitnessKey])
+	if err != nil {
+		return nil, fmt.Errorf("failed to read condition witness: %w", err)
+	}
+	if len(condWitness) != 1 {
+		return nil, fmt.Errorf(
+			"invalid condition witness, expected preimage only, got %d items", len(condWitness),
+		)
+	}
+	if err := f.VerifyPreimage(condWitness[0]); err != nil {
+		return nil, err
+	}
+
+	// Get multisig witness
+	multisigWitness, err := f.MultisigClosure.Witness(controlBlock, args)
+	if err != nil {
+		return nil, err
+	}
+
+	multisigWitness = multisigWitness[:len(multisigWitness)-2] // remove control block and script
+	witness := append(multisigWitness, condWitness[0])
+	witness = append(witness, script)
+	witness = append(witness, controlBlock)
+
+	return witness, nil
+}
+
+// VerifyPreimage returns an error if the given preimage doesn't match the hash
+// of the closure.
+func (f *HashLockMultisigClosure) VerifyPreimage(preimage []byte) error {
+	_, hash, err := f.hashLock()
+	if err != nil {
+		return err
+	}
+
+	var preimageHash []byte
+	switch f.HashType {
+	case HashFuncSHA256:
+		h := sha256.Sum256(preimage)
+		preimageHash = h[:]
+	case HashFuncHASH160:
+		preimageHash = btcutil.Hash160(preimage)
+	}
+
+	if !bytes.Equal(preimageHash, hash) {
+		return fmt.Errorf("preimage does not match hash %x", hash)
+	}
+	return nil
+}
+
+// ExtractPreimage returns the preimage revealed by the input at index vin of
+// the given tx, spending an output through the given hash lock closure. It
+// fails if the input doesn't spend the closure leaf or if the preimage doesn't
+// match its hash.
+func ExtractPreimage(
+	tx *wire.MsgTx, vin int, closure *HashLockMultisigClosure,
+) ([]byte, error) {
+	if vin < 0 || vin >= len(tx.TxIn) {
+		return nil, fmt.Errorf("input %d not found in tx", vin)
+	}
+
+	witness := tx.TxIn[vin].Witness
+	if len(witness) >= 2 {
+		// drop the annex, if any
+		last := witness[len(witness)-1]
+		if len(last) > 0 && last[0] == txscript.TaprootAnnexTag {
+			witness = witness[:len(witness)-1]
+		}
+	}
+	// the witness ends with the preimage, the script and the control block
+	if len(witness) < 3 {
+		return nil, fmt.Errorf("input %d does not spend a tapscript leaf", vin)
+	}
+
+	script, err := closure.Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate script: %w", err)
+	}
+	if !bytes.Equal(witness[len(witness)-2], script) {
+		return nil, fmt.Errorf("input %d does not spend the hash lock closure", vin)
+	}
+
+	preimage := witness[len(witness)-3]
+	if err := closure.VerifyPreimage(preimage); err != nil {
+		return nil, err
+	}
+	return preimage, nil
+}
+
+// hashLock returns the opcode and the hash, sized after the hash function,
+// pushed by the script.
+func (f *HashLockMultisigClosure) hashLock() (byte, []byte, error) {
+	switch f.HashType {
+	case HashFuncSHA256:
+		return txscript.OP_SHA256, f.Hash[:], nil
+	case HashFuncHASH160:
+		return txscript.OP_HASH160, f.Hash[:20], nil
+	default:
+		return 0, nil, fmt.Errorf("unknown hash function %d", f.HashType)
+	}
+}
+
+// WitnessSize returns the size of the bigger of the two spending paths, plus
+// the path selector.
+func (f *RefundableCLTVClosure) WitnessSize(_ ...int) int {
+	return max(f.ReceiverMultisig.WitnessSize(), f.RefundMultisig.WitnessSize()) + 1
+}
+
+func (f *RefundableCLTVClosure) Script() ([]byte, error) {
+	receiverScript, err := f.ReceiverMultisig.Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate receiver script: %w", err)
+	}
+
+	refundScript, err := f.refundClosure().Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refund script: %w", err)
+	}
+
+	scriptBuilder := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_IF).
+		AddOps(receiverScript).
+		AddOp(txscript.OP_ELSE).
+		AddOps(refundScript).
+		AddOp(txscript.OP_ENDIF)
+
+	return scriptBuilder.Script()
+}
+
+func (f *RefundableCLTVClosure) Decode(script []byte) (bool, error) {
+	if len(script) == 0 {
+		return false, fmt.Errorf("empty script")
+	}
+
+	tokenizer := txscript.MakeScriptTokenizer(0, script)
+	if !tokenizer.Next() || tokenizer.Opcode() != txscript.OP_IF {
+		return false, nil
+	}
+	receiverStart := int(tokenizer.ByteIndex())
+
+	// the multisig scripts don't contain any branch, the first OP_ELSE
+	// separates the receiver path from the refund one
+	receiverEnd := -1
+	for tokenizer.Next() {
+		if tokenizer.Opcode() == txscript.OP_ELSE {
+			receiverEnd = int(tokenizer.ByteIndex()) - 1
+			break
+		}
+	}
+	if tokenizer.Err() != nil || receiverEnd < 0 {
+		return false, nil
+	}
+	refundStart := int(tokenizer.ByteIndex())
+
+	if script[len(script)-1] != txscript.OP_ENDIF || refundStart >= len(script)-1 {
+		return false, nil
+	}
+	refundEnd := len(script) - 1
+
+	receiverMultisig := &MultisigClosure{}
+	valid, err := receiverMultisig.Decode(script[receiverStart:receiverEnd])
+	if err != nil || !valid {
+		return false, err
+	}
+
+	refundClosure := &CLTVMultisigClosure{}
+	valid, err = refundClosure.Decode(script[refundStart:refundEnd])
+	if err != nil || !valid {
+		return false, err
+	}
+
+	f.Locktime = refundClosure.Locktime
+	f.ReceiverMultisig = *receiverMultisig
+	f.RefundMultisig = refundClosure.MultisigClosure
+
+	// Verify the script matches what we would generate
+	rebuilt, err := f.Script()
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(rebuilt, script), nil
+}
+
+// Witness returns the witness to spend with the receiver path.
+func (f *RefundableCLTVClosure) Witness(controlBlock []byte, signatures map[string][]byte) (wire.TxWitness, error) {
+	return f.witness(&f.ReceiverMultisig, []byte{0x01}, controlBlock, signatures)
+}
+
+// RefundWitness returns the witness to spend with the refund path, the tx
+// locktime must be set to at least the one of the closure.
+func (f *RefundableCLTVClosure) RefundWitness(controlBlock []byte, signatures map[string][]byte) (wire.TxWitness, error) {
+	return f.witness(&f.RefundMultisig, []byte{}, controlBlock, signatures)
+}
+
+func (f *RefundableCLTVClosure) witness(
+	multisig *MultisigClosure, selector, controlBlock []byte,
+	signatures map[string][]byte,
+) (wire.TxWitness, error) {
+	script, err := f.Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate script: %w", err)
+	}
+
+	multisigWitness, err := multisig.Witness(controlBlock, signatures)
+	if err != nil {
+		return nil, err
+	}
+
+	multisigWitness = multisigWitness[:len(multisigWitness)-2] // remove control block and script
+	witness := append(multisigWitness, selector)
+	witness = append(witness, script)
+	witness = append(witness, controlBlock)
+
+	return witness, nil
+}
+
+func (f *RefundableCLTVClosure) refundClosure() *CLTVMultisigClosure {
+	return &CLTVMultisigClosure{
+		MultisigClosure: f.RefundMultisig,
+		Locktime:        f.Locktime,
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/common/tree"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
@@ -1180,3 +1181,387 @@ func TestConditionMultisigClosure(t *testing.T) {
 		require.Equal(t, 64, len(witness[1]))
 	})
 }
+
+func TestHashLockMultisigClosure(t *testing.T) {
+	privkey1, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubkey1 := privkey1.PubKey()
+
+	privkey2, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubkey2 := privkey2.PubKey()
+
+	preimage := make([]byte, 32)
+	_, err = rand.Read(preimage)
+	require.NoError(t, err)
+
+	sha256Hash := sha256.Sum256(preimage)
+	var hash160 [32]byte
+	copy(hash160[:], btcutil.Hash160(preimage))
+
+	testCases := []struct {
+		name           string
+		hash           [32]byte
+		hashType       tree.HashFunc
+		hashOp         byte
+		expectedHashed []byte
+	}{
+		{"sha256", sha256Hash, tree.HashFuncSHA256, txscript.OP_SHA256, sha256Hash[:]},
+		{"hash160", hash160, tree.HashFuncHASH160, txscript.OP_HASH160, hash160[:20]},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			closure := &tree.HashLockMultisigClosure{
+				MultisigClosure: tree.MultisigClosure{
+					PubKeys: []*secp256k1.PublicKey{pubkey1, pubkey2},
+					Type:    tree.MultisigTypeChecksig,
+				},
+				Hash:     tc.hash,
+				HashType: tc.hashType,
+			}
+
+			script, err := closure.Script()
+			require.NoError(t, err)
+			require.Equal(t, tc.hashOp, script[0])
+			require.Equal(t, tc.expectedHashed, script[2:2+len(tc.expectedHashed)])
+
+			decoded, err := tree.DecodeClosure(script)
+			require.NoError(t, err)
+			decodedClosure, ok := decoded.(*tree.HashLockMultisigClosure)
+			require.True(t, ok)
+			require.Equal(t, tc.hashType, decodedClosure.HashType)
+			require.Equal(t, tc.hash, decodedClosure.Hash)
+			require.Equal(t, 2, len(decodedClosure.PubKeys))
+
+			require.Equal(t, 64*2+32, closure.WitnessSize())
+
+			var preimageWitness bytes.Buffer
+			require.NoError(t, psbt.WriteTxWitness(&preimageWitness, wire.TxWitness{preimage}))
+
+			args := map[string][]byte{
+				hex.EncodeToString(schnorr.SerializePubKey(pubkey1)): bytes.Repeat([]byte{0x01}, 64),
+				hex.EncodeToString(schnorr.SerializePubKey(pubkey2)): bytes.Repeat([]byte{0x02}, 64),
+				tree.ConditionWitnessKey:                             preimageWitness.Bytes(),
+			}
+			controlBlock := bytes.Repeat([]byte{0x00}, 32)
+
+			witness, err := closure.Witness(controlBlock, args)
+			require.NoError(t, err)
+			require.Equal(t, 5, len(witness)) // 2 sigs + preimage + script + control block
+			require.Equal(t, preimage, witness[2])
+			require.Equal(t, script, witness[3])
+			require.Equal(t, controlBlock, witness[4])
+
+			var wrongWitness bytes.Buffer
+			require.NoError(t, psbt.WriteTxWitness(
+				&wrongWitness, wire.TxWitness{bytes.Repeat([]byte{0x00}, 32)},
+			))
+			args[tree.ConditionWitnessKey] = wrongWitness.Bytes()
+			_, err = closure.Witness(controlBlock, args)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("not a hash lock", func(t *testing.T) {
+		closure := &tree.HashLockMultisigClosure{}
+		valid, err := closure.Decode([]byte{txscript.OP_SHA256, txscript.OP_DATA_1, 0x01})
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+}
+
+func TestExtractPreimage(t *testing.T) {
+	aliceKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	bobKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	preimage := make([]byte, 32)
+	_, err = rand.Read(preimage)
+	require.NoError(t, err)
+
+	// bob can claim the funds with the preimage, alice gets a refund after the
+	// locktime
+	claimClosure := &tree.HashLockMultisigClosure{
+		MultisigClosure: tree.MultisigClosure{
+			PubKeys: []*secp256k1.PublicKey{bobKey.PubKey(), serverKey.PubKey()},
+		},
+		Hash: sha256.Sum256(preimage),
+	}
+	refundClosure := &tree.CLTVMultisigClosure{
+		MultisigClosure: tree.MultisigClosure{
+			PubKeys: []*secp256k1.PublicKey{aliceKey.PubKey(), serverKey.PubKey()},
+		},
+		Locktime: common.AbsoluteLocktime(1000),
+	}
+
+	vtxoScript := &tree.TapscriptsVtxoScript{
+		Closures: []tree.Closure{claimClosure, refundClosure},
+	}
+	tapKey, tapTree, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+	pkScript, err := common.P2TRScript(tapKey)
+	require.NoError(t, err)
+
+	const amount = 10_000
+	prevoutFetcher := txscript.NewCannedPrevOutputFetcher(pkScript, amount)
+
+	// spend signs a tx spending the given closure with the given keys, builds
+	// its witness with the given args and checks that it's valid
+	spend := func(
+		t *testing.T, closure tree.Closure, locktime uint32,
+		keys []*secp256k1.PrivateKey, args map[string][]byte,
+	) *wire.MsgTx {
+		script, err := closure.Script()
+		require.NoError(t, err)
+		leaf := txscript.NewBaseTapLeaf(script)
+		merkleProof, err := tapTree.GetTaprootMerkleProof(leaf.TapHash())
+		require.NoError(t, err)
+
+		tx := wire.NewMsgTx(2)
+		tx.LockTime = locktime
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{Index: 0},
+			Sequence:         wire.MaxTxInSequenceNum - 1,
+		})
+		tx.AddTxOut(&wire.TxOut{Value: amount - 500, PkScript: pkScript})
+
+		sigHashes := txscript.NewTxSigHashes(tx, prevoutFetcher)
+		sighash, err := txscript.CalcTapscriptSignaturehash(
+			sigHashes, txscript.SigHashDefault, tx, 0, prevoutFetcher, leaf,
+		)
+		require.NoError(t, err)
+
+		for _, key := range keys {
+			sig, err := schnorr.Sign(key, sighash)
+			require.NoError(t, err)
+			args[hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))] = sig.Serialize()
+		}
+		witness, err := closure.Witness(merkleProof.ControlBlock, args)
+		require.NoError(t, err)
+		tx.TxIn[0].Witness = witness
+
+		engine, err := txscript.NewEngine(
+			pkScript, tx, 0, txscript.StandardVerifyFlags, nil,
+			sigHashes, amount, prevoutFetcher,
+		)
+		require.NoError(t, err)
+		require.NoError(t, engine.Execute())
+		return tx
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		var preimageWitness bytes.Buffer
+		require.NoError(t, psbt.WriteTxWitness(&preimageWitness, wire.TxWitness{preimage}))
+
+		claimTx := spend(
+			t, claimClosure, 0, []*secp256k1.PrivateKey{bobKey, serverKey},
+			map[string][]byte{tree.ConditionWitnessKey: preimageWitness.Bytes()},
+		)
+
+		extracted, err := tree.ExtractPreimage(claimTx, 0, claimClosure)
+		require.NoError(t, err)
+		require.Equal(t, preimage, extracted)
+
+		// the annex, if any, is skipped
+		claimTx.TxIn[0].Witness = append(
+			claimTx.TxIn[0].Witness, []byte{txscript.TaprootAnnexTag, 0x01},
+		)
+		extracted, err = tree.ExtractPreimage(claimTx, 0, claimClosure)
+		require.NoError(t, err)
+		require.Equal(t, preimage, extracted)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		refundTx := spend(
+			t, refundClosure, 1000, []*secp256k1.PrivateKey{aliceKey, serverKey},
+			map[string][]byte{},
+		)
+
+		// the refund doesn't reveal any preimage
+		_, err := tree.ExtractPreimage(refundTx, 0, claimClosure)
+		require.ErrorContains(t, err, "does not spend the hash lock closure")
+
+		_, err = tree.ExtractPreimage(refundTx, 1, claimClosure)
+		require.ErrorContains(t, err, "not found")
+
+		// a hash lock closure with another hash doesn't match the spent leaf
+		otherClosure := *claimClosure
+		otherClosure.Hash = sha256.Sum256(otherClosure.Hash[:])
+		var preimageWitness bytes.Buffer
+		require.NoError(t, psbt.WriteTxWitness(&preimageWitness, wire.TxWitness{preimage}))
+		claimTx := spend(
+			t, claimClosure, 0, []*secp256k1.PrivateKey{bobKey, serverKey},
+			map[string][]byte{tree.ConditionWitnessKey: preimageWitness.Bytes()},
+		)
+		_, err = tree.ExtractPreimage(claimTx, 0, &otherClosure)
+		require.Error(t, err)
+
+		claimTx.TxIn[0].Witness = claimTx.TxIn[0].Witness[3:]
+		_, err = tree.ExtractPreimage(claimTx, 0, claimClosure)
+		require.ErrorContains(t, err, "does not spend a tapscript leaf")
+	})
+}
+
+func TestRefundableCLTVClosure(t *testing.T) {
+	aliceKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	bobKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	const cltvLocktime = 1000
+
+	// bob can claim the funds at any time, alice gets a refund after the
+	// locktime
+	closure := &tree.RefundableCLTVClosure{
+		Locktime: common.AbsoluteLocktime(cltvLocktime),
+		ReceiverMultisig: tree.MultisigClosure{
+			PubKeys: []*secp256k1.PublicKey{bobKey.PubKey(), serverKey.PubKey()},
+		},
+		RefundMultisig: tree.MultisigClosure{
+			PubKeys: []*secp256k1.PublicKey{aliceKey.PubKey(), serverKey.PubKey()},
+		},
+	}
+
+	t.Run("decode", func(t *testing.T) {
+		script, err := closure.Script()
+		require.NoError(t, err)
+
+		decoded, err := tree.DecodeClosure(script)
+		require.NoError(t, err)
+		decodedClosure, ok := decoded.(*tree.RefundableCLTVClosure)
+		require.True(t, ok)
+		require.Equal(t, closure.Locktime, decodedClosure.Locktime)
+		require.Len(t, decodedClosure.ReceiverMultisig.PubKeys, 2)
+		require.Len(t, decodedClosure.RefundMultisig.PubKeys, 2)
+		require.Equal(t, 2*64+1, decodedClosure.WitnessSize())
+
+		rebuilt, err := decodedClosure.Script()
+		require.NoError(t, err)
+		require.Equal(t, script, rebuilt)
+
+		checksigAddClosure := &tree.RefundableCLTVClosure{
+			Locktime:         closure.Locktime,
+			ReceiverMultisig: closure.ReceiverMultisig,
+			RefundMultisig: tree.MultisigClosure{
+				PubKeys: closure.RefundMultisig.PubKeys,
+				Type:    tree.MultisigTypeChecksigAdd,
+			},
+		}
+		script, err = checksigAddClosure.Script()
+		require.NoError(t, err)
+
+		decodedClosure = &tree.RefundableCLTVClosure{}
+		valid, err := decodedClosure.Decode(script)
+		require.NoError(t, err)
+		require.True(t, valid)
+		require.Equal(t, tree.MultisigTypeChecksigAdd, decodedClosure.RefundMultisig.Type)
+
+		// the single paths are not refundable closures
+		cltvScript, err := (&tree.CLTVMultisigClosure{
+			MultisigClosure: closure.RefundMultisig,
+			Locktime:        closure.Locktime,
+		}).Script()
+		require.NoError(t, err)
+		valid, err = (&tree.RefundableCLTVClosure{}).Decode(cltvScript)
+		require.NoError(t, err)
+		require.False(t, valid)
+
+		valid, err = (&tree.RefundableCLTVClosure{}).Decode(script[:len(script)-1])
+		require.NoError(t, err)
+		require.False(t, valid)
+	})
+
+	vtxoScript := &tree.TapscriptsVtxoScript{Closures: []tree.Closure{closure}}
+	tapKey, tapTree, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+	pkScript, err := common.P2TRScript(tapKey)
+	require.NoError(t, err)
+
+	script, err := closure.Script()
+	require.NoError(t, err)
+	leaf := txscript.NewBaseTapLeaf(script)
+	merkleProof, err := tapTree.GetTaprootMerkleProof(leaf.TapHash())
+	require.NoError(t, err)
+
+	const amount = 10_000
+	prevoutFetcher := txscript.NewCannedPrevOutputFetcher(pkScript, amount)
+
+	// spend signs a tx with the given locktime and keys, and executes the
+	// script with the witness built by the given func
+	spend := func(
+		t *testing.T, locktime uint32, keys []*secp256k1.PrivateKey,
+		witnessFn func([]byte, map[string][]byte) (wire.TxWitness, error),
+	) error {
+		tx := wire.NewMsgTx(2)
+		tx.LockTime = locktime
+		tx.AddTxIn(&wire.TxIn{
+			PreviousOutPoint: wire.OutPoint{Index: 0},
+			Sequence:         wire.MaxTxInSequenceNum - 1,
+		})
+		tx.AddTxOut(&wire.TxOut{Value: amount - 500, PkScript: pkScript})
+
+		sigHashes := txscript.NewTxSigHashes(tx, prevoutFetcher)
+		sighash, err := txscript.CalcTapscriptSignaturehash(
+			sigHashes, txscript.SigHashDefault, tx, 0, prevoutFetcher, leaf,
+		)
+		require.NoError(t, err)
+
+		sigs := make(map[string][]byte)
+		for _, key := range keys {
+			sig, err := schnorr.Sign(key, sighash)
+			require.NoError(t, err)
+			sigs[hex.EncodeToString(schnorr.SerializePubKey(key.PubKey()))] = sig.Serialize()
+		}
+		witness, err := witnessFn(merkleProof.ControlBlock, sigs)
+		require.NoError(t, err)
+		tx.TxIn[0].Witness = witness
+
+		engine, err := txscript.NewEngine(
+			pkScript, tx, 0, txscript.StandardVerifyFlags, nil,
+			sigHashes, amount, prevoutFetcher,
+		)
+		require.NoError(t, err)
+		return engine.Execute()
+	}
+
+	t.Run("claim", func(t *testing.T) {
+		// the receiver can spend before the locktime
+		err := spend(t, 0, []*secp256k1.PrivateKey{bobKey, serverKey}, closure.Witness)
+		require.NoError(t, err)
+
+		// the sender can't use the receiver path
+		_, err = closure.Witness(merkleProof.ControlBlock, map[string][]byte{
+			hex.EncodeToString(schnorr.SerializePubKey(aliceKey.PubKey())):  bytes.Repeat([]byte{0x01}, 64),
+			hex.EncodeToString(schnorr.SerializePubKey(serverKey.PubKey())): bytes.Repeat([]byte{0x01}, 64),
+		})
+		require.Error(t, err)
+	})
+
+	t.Run("refund", func(t *testing.T) {
+		// should fail because the tx is not yet valid
+		err := spend(
+			t, cltvLocktime-1, []*secp256k1.PrivateKey{aliceKey, serverKey},
+			closure.RefundWitness,
+		)
+		require.Error(t, err)
+
+		// the receiver can't use the refund path
+		_, err = closure.RefundWitness(merkleProof.ControlBlock, map[string][]byte{
+			hex.EncodeToString(schnorr.SerializePubKey(bobKey.PubKey())):    bytes.Repeat([]byte{0x01}, 64),
+			hex.EncodeToString(schnorr.SerializePubKey(serverKey.PubKey())): bytes.Repeat([]byte{0x01}, 64),
+		})
+		require.Error(t, err)
+
+		err = spend(
+			t, cltvLocktime, []*secp256k1.PrivateKey{aliceKey, serverKey},
+			closure.RefundWitness,
+		)
+		require.NoError(t, err)
+	})
+}
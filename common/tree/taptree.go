@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/btcsuite/btcd/txscript"
 )
@@ -61,10 +62,14 @@ func DecodeTapTree(data []byte) (TapTree, error) {
 	}
 
 	for i := uint64(0); i < count; i++ {
-		// depth : ignore
-		if _, err := buf.ReadByte(); err != nil {
+		// depth : only validated, the tree is always rebuilt balanced
+		depth, err := buf.ReadByte()
+		if err != nil {
 			return nil, err
 		}
+		if depth > txscript.ControlBlockMaxNodeCount {
+			return nil, fmt.Errorf("invalid leaf %d: %w", i, ErrTapTreeTooDeep)
+		}
 
 		// leaf version : ignore, we assume base tapscript
 		if _, err := buf.ReadByte(); err != nil {
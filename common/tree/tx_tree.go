@@ -1,8 +1,14 @@
 package tree
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
 )
 
 // Node is a struct embedding the transaction and the parent txid of a vtxo tree node
@@ -14,16 +20,20 @@ type Node struct {
 }
 
 var (
-	ErrParentNotFound = errors.New("parent not found")
-	ErrLeafNotFound   = errors.New("leaf not found in vtxo tree")
+	ErrParentNotFound      = errors.New("parent not found")
+	ErrLeafNotFound        = errors.New("leaf not found in vtxo tree")
+	ErrDuplicateNode       = errors.New("duplicate node in tree")
+	ErrOutputDoubleSpent   = errors.New("output spent by more than one node")
+	ErrDuplicateLeafScript = errors.New("duplicate leaf output script")
 )
 
 // TxTree is reprensented as a matrix of TreeNode struct
 // the first level of the matrix is the root of the tree
 type TxTree [][]Node
 
-// Validate checks if the tree is coherent
-func (c TxTree) Validate(getTxID func(string) string) error {
+// ValidateLinks checks that every node has the given txid and that its parent
+// is in the tree
+func (c TxTree) ValidateLinks(getTxID func(string) string) error {
 	for _, level := range c[1:] { // exclude the root level
 		for _, node := range level {
 			if getTxID(node.Tx) != node.Txid {
@@ -39,6 +49,114 @@ func (c TxTree) Validate(getTxID func(string) string) error {
 	return nil
 }
 
+// Validate checks the structural integrity of the vtxo tree, or of a branch of
+// it, before trusting it to redeem a vtxo:
+//   - every node tx has the node's txid and spends an output of its parent;
+//   - the root spends the shared output of the round tx, whose amount is
+//     sharedOutAmount;
+//   - no output is spent by more than one node;
+//   - the outputs of every node don't exceed the amount of its input;
+//   - the outputs of the branch nodes are locked by the aggregated key of the
+//     cosigners of the node, tweaked with sweepRoot;
+//   - the output scripts of the leaves are unique.
+func (c TxTree) Validate(sweepRoot []byte, sharedOutAmount int64) error {
+	if c.NumberOfNodes() == 0 {
+		return ErrEmptyTree
+	}
+	if len(c[0]) != 1 {
+		return ErrInvalidRootLevel
+	}
+
+	txs := make(map[string]*psbt.Packet, c.NumberOfNodes())
+	for _, level := range c {
+		for _, node := range level {
+			if _, ok := txs[node.Txid]; ok {
+				return fmt.Errorf("%w: %s", ErrDuplicateNode, node.Txid)
+			}
+
+			ptx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+			if err != nil {
+				return fmt.Errorf("invalid tx of node %s: %w", node.Txid, err)
+			}
+			if ptx.UnsignedTx.TxHash().String() != node.Txid {
+				return fmt.Errorf("%w: %s", ErrNodeTxidDifferent, node.Txid)
+			}
+			if len(ptx.UnsignedTx.TxIn) != 1 {
+				return fmt.Errorf("%w: %s", ErrNumberOfInputs, node.Txid)
+			}
+			if err := ValidateAnchors(ptx.UnsignedTx); err != nil {
+				return fmt.Errorf("%w: %s", err, node.Txid)
+			}
+			if ptx.UnsignedTx.TxIn[0].PreviousOutPoint.Hash.String() != node.ParentTxid {
+				return fmt.Errorf("%w: %s", ErrParentTxidInput, node.Txid)
+			}
+
+			txs[node.Txid] = ptx
+		}
+	}
+
+	rootTx := txs[c[0][0].Txid]
+	if rootTx.UnsignedTx.TxIn[0].PreviousOutPoint.Index != sharedOutputIndex {
+		return ErrWrongRoundTxid
+	}
+	if outputsAmount(rootTx) > sharedOutAmount {
+		return fmt.Errorf("%w: root", ErrInvalidAmount)
+	}
+
+	spentOutputs := make(map[wire.OutPoint]struct{})
+	leafScripts := make(map[string]struct{})
+	for i, level := range c {
+		for _, node := range level {
+			ptx := txs[node.Txid]
+
+			if i > 0 {
+				parentTx, ok := txs[node.ParentTxid]
+				if !ok {
+					return fmt.Errorf("%w: %s", ErrParentNotFound, node.Txid)
+				}
+
+				prevout := ptx.UnsignedTx.TxIn[0].PreviousOutPoint
+				if int(prevout.Index) >= len(parentTx.UnsignedTx.TxOut) {
+					return fmt.Errorf("%w: %s", ErrParentTxidInput, node.Txid)
+				}
+				if _, ok := spentOutputs[prevout]; ok {
+					return fmt.Errorf("%w: %s", ErrOutputDoubleSpent, prevout)
+				}
+				spentOutputs[prevout] = struct{}{}
+
+				if outputsAmount(ptx) > parentTx.UnsignedTx.TxOut[prevout.Index].Value {
+					return fmt.Errorf("%w: %s", ErrInvalidAmount, node.Txid)
+				}
+			}
+
+			isLeaf := node.Leaf || i == len(c)-1
+			if isLeaf {
+				if len(c.Children(node.Txid)) > 0 {
+					return fmt.Errorf("%w: %s", ErrLeafChildren, node.Txid)
+				}
+
+				for _, out := range ptx.UnsignedTx.TxOut {
+					if IsAnchor(out) {
+						continue
+					}
+					script := string(out.PkScript)
+					if _, ok := leafScripts[script]; ok {
+						return fmt.Errorf("%w: %x", ErrDuplicateLeafScript, out.PkScript)
+					}
+					leafScripts[script] = struct{}{}
+				}
+				continue
+			}
+
+			if err := validateBranchOutputs(ptx, sweepRoot); err != nil {
+				return fmt.Errorf("%w: %s", err, node.Txid)
+			}
+		}
+	}
+
+	return nil
+}
+
 // Root returns the root node of the vtxo tree
 func (c TxTree) Root() (Node, error) {
 	if len(c) <= 0 {
@@ -130,3 +248,39 @@ func (n Node) findParent(tree TxTree) (Node, error) {
 	}
 	return Node{}, ErrParentNotFound
 }
+
+// validateBranchOutputs checks that all the outputs of the given branch tx are
+// locked by the aggregated key of its cosigners, but the anchor
+func validateBranchOutputs(ptx *psbt.Packet, sweepRoot []byte) error {
+	cosigners, err := GetCosignerKeys(ptx.Inputs[0])
+	if err != nil {
+		return fmt.Errorf("unable to get cosigners keys: %w", err)
+	}
+	if len(cosigners) == 0 {
+		return ErrMissingCosignersPublicKeys
+	}
+
+	aggregatedKey, err := AggregateKeys(cosigners, sweepRoot)
+	if err != nil {
+		return fmt.Errorf("unable to aggregate keys: %w", err)
+	}
+	expectedKey := schnorr.SerializePubKey(aggregatedKey.FinalKey)
+
+	for _, out := range ptx.UnsignedTx.TxOut {
+		if IsAnchor(out) {
+			continue
+		}
+		if len(out.PkScript) != 34 || !bytes.Equal(out.PkScript[2:], expectedKey) {
+			return ErrInvalidTaprootScript
+		}
+	}
+	return nil
+}
+
+func outputsAmount(ptx *psbt.Packet) int64 {
+	amount := int64(0)
+	for _, out := range ptx.UnsignedTx.TxOut {
+		amount += out.Value
+	}
+	return amount
+}
@@ -0,0 +1,119 @@
+package tree_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxTreeValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid", func(t *testing.T) {
+		for _, count := range []int{1, 2, 5, 20} {
+			t.Run(fmt.Sprintf("%d receivers", count), func(t *testing.T) {
+				vtxoTree, sharedOutAmount := makeVtxoTree(t, count)
+				require.NoError(t, vtxoTree.Validate(sweepRoot[:], sharedOutAmount))
+
+				// a single branch of the tree is valid as well
+				leaves := vtxoTree.Leaves()
+				for _, leaf := range []tree.Node{leaves[0], leaves[len(leaves)-1]} {
+					nodes, err := vtxoTree.Branch(leaf.Txid)
+					require.NoError(t, err)
+
+					branch := make(tree.TxTree, 0, len(nodes))
+					for _, node := range nodes {
+						branch = append(branch, []tree.Node{node})
+					}
+					require.NoError(t, branch.Validate(sweepRoot[:], sharedOutAmount))
+				}
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		vtxoTree, sharedOutAmount := makeVtxoTree(t, 4)
+
+		err := vtxoTree.Validate(sweepRoot[:], sharedOutAmount-minRelayFee-1)
+		require.ErrorIs(t, err, tree.ErrInvalidAmount)
+
+		err = vtxoTree.Validate(make([]byte, 32), sharedOutAmount)
+		require.ErrorIs(t, err, tree.ErrInvalidTaprootScript)
+
+		err = tree.TxTree{}.Validate(sweepRoot[:], sharedOutAmount)
+		require.ErrorIs(t, err, tree.ErrEmptyTree)
+
+		wrongParent := cloneTxTree(vtxoTree)
+		wrongParent[1][0].ParentTxid = vtxoTree[1][1].Txid
+		err = wrongParent.Validate(sweepRoot[:], sharedOutAmount)
+		require.ErrorIs(t, err, tree.ErrParentTxidInput)
+
+		wrongTxid := cloneTxTree(vtxoTree)
+		wrongTxid[1][0].Tx = vtxoTree[1][1].Tx
+		err = wrongTxid.Validate(sweepRoot[:], sharedOutAmount)
+		require.ErrorIs(t, err, tree.ErrNodeTxidDifferent)
+
+		duplicateNode := cloneTxTree(vtxoTree)
+		duplicateNode[1] = append(duplicateNode[1], vtxoTree[1][0])
+		err = duplicateNode.Validate(sweepRoot[:], sharedOutAmount)
+		require.ErrorIs(t, err, tree.ErrDuplicateNode)
+
+		// the anchor outputs must have zero value
+		leafTx, err := psbt.NewFromRawBytes(strings.NewReader(vtxoTree[2][0].Tx), true)
+		require.NoError(t, err)
+		leafTx.UnsignedTx.TxOut[0].Value -= 100
+		leafTx.UnsignedTx.AddTxOut(&wire.TxOut{Value: 100, PkScript: tree.AnchorPkScript})
+		leafTx.Outputs = append(leafTx.Outputs, psbt.POutput{})
+		invalidAnchor := cloneTxTree(vtxoTree)
+		invalidAnchor[2][0].Tx, err = leafTx.B64Encode()
+		require.NoError(t, err)
+		invalidAnchor[2][0].Txid = leafTx.UnsignedTx.TxHash().String()
+		err = invalidAnchor.Validate(sweepRoot[:], sharedOutAmount)
+		require.ErrorIs(t, err, tree.ErrInvalidAnchor)
+
+		receivers, _, err := generateMockedReceivers(4)
+		require.NoError(t, err)
+		sameScriptTree, err := tree.BuildVtxoTree(
+			rootInput, receivers, minRelayFee, sweepRoot[:], vtxoTreeExpiry,
+		)
+		require.NoError(t, err)
+		err = sameScriptTree.Validate(sweepRoot[:], sharedOutAmount)
+		require.ErrorIs(t, err, tree.ErrDuplicateLeafScript)
+	})
+}
+
+func makeVtxoTree(t *testing.T, numOfReceivers int) (tree.TxTree, int64) {
+	receivers, _, err := generateMockedReceivers(numOfReceivers)
+	require.NoError(t, err)
+	for i := range receivers {
+		receivers[i].Script = fmt.Sprintf("5120%064x", i+1)
+	}
+
+	_, sharedOutAmount, err := tree.CraftSharedOutput(receivers, minRelayFee, sweepRoot[:])
+	require.NoError(t, err)
+
+	vtxoTree, err := tree.BuildVtxoTree(
+		rootInput, receivers, minRelayFee, sweepRoot[:], vtxoTreeExpiry,
+	)
+	require.NoError(t, err)
+
+	// sanity check, the root spends the given input
+	rootTx, err := psbt.NewFromRawBytes(strings.NewReader(vtxoTree[0][0].Tx), true)
+	require.NoError(t, err)
+	require.Equal(t, *rootInput, rootTx.UnsignedTx.TxIn[0].PreviousOutPoint)
+
+	return vtxoTree, sharedOutAmount
+}
+
+func cloneTxTree(vtxoTree tree.TxTree) tree.TxTree {
+	clone := make(tree.TxTree, 0, len(vtxoTree))
+	for _, level := range vtxoTree {
+		clone = append(clone, append([]tree.Node{}, level...))
+	}
+	return clone
+}
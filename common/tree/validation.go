@@ -10,6 +10,7 @@ import (
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
@@ -107,12 +108,16 @@ func ValidateVtxoTree(
 		return ErrWrongRoundTxid
 	}
 
+	if err := ValidateAnchors(rootPset.UnsignedTx); err != nil {
+		return err
+	}
+
 	sumRootValue := int64(0)
 	for _, output := range rootPset.UnsignedTx.TxOut {
 		sumRootValue += output.Value
 	}
 
-	if sumRootValue >= roundTxAmount {
+	if !validFees(rootPset.UnsignedTx, sumRootValue, roundTxAmount) {
 		return ErrInvalidAmount
 	}
 
@@ -174,6 +179,10 @@ func validateNodeTransaction(node Node, tree TxTree, tapTreeRoot []byte) error {
 		return ErrNumberOfInputs
 	}
 
+	if err := ValidateAnchors(decodedPsbt.UnsignedTx); err != nil {
+		return err
+	}
+
 	prevTxid := decodedPsbt.UnsignedTx.TxIn[0].PreviousOutPoint.Hash.String()
 	if prevTxid != node.ParentTxid {
 		return ErrParentTxidInput
@@ -220,10 +229,19 @@ func validateNodeTransaction(node Node, tree TxTree, tapTreeRoot []byte) error {
 			sumChildAmount += output.Value
 		}
 
-		if sumChildAmount >= parentOutput.Value {
+		if !validFees(childTx.UnsignedTx, sumChildAmount, parentOutput.Value) {
 			return ErrInvalidAmount
 		}
 	}
 
 	return nil
 }
+
+// validFees returns whether the given tree tx pays fees, unless it has an
+// anchor output, in which case it can pay zero fees and be bumped by a child.
+func validFees(tx *wire.MsgTx, outputsAmount, inputAmount int64) bool {
+	if hasAnchor(tx) {
+		return outputsAmount <= inputAmount
+	}
+	return outputsAmount < inputAmount
+}
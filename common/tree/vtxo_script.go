@@ -12,7 +12,15 @@ import (
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
-var ErrNoExitLeaf = fmt.Errorf("no exit leaf")
+var (
+	ErrNoExitLeaf = fmt.Errorf("no exit leaf")
+	// ErrTapTreeTooDeep is returned for decoded taproot trees and given control
+	// blocks with leaves deeper than the consensus limit, that would be invalid
+	// at spend time.
+	ErrTapTreeTooDeep = fmt.Errorf(
+		"taproot tree exceeds the max depth of %d", txscript.ControlBlockMaxNodeCount,
+	)
+)
 
 type VtxoScript common.VtxoScript[bitcoinTapTree, Closure]
 
@@ -77,32 +85,9 @@ func (v *TapscriptsVtxoScript) Decode(scripts []string) error {
 }
 
 func (v *TapscriptsVtxoScript) Validate(server *secp256k1.PublicKey, minLocktime common.RelativeLocktime) error {
-	serverXonly := schnorr.SerializePubKey(server)
 	for _, forfeit := range v.ForfeitClosures() {
-		keys := make([]*secp256k1.PublicKey, 0)
-		switch c := forfeit.(type) {
-		case *MultisigClosure:
-			keys = c.PubKeys
-		case *CLTVMultisigClosure:
-			keys = c.PubKeys
-		case *ConditionMultisigClosure:
-			keys = c.PubKeys
-		}
-
-		if len(keys) == 0 {
-			return fmt.Errorf("invalid forfeit closure, expected MultisigClosure, CLTVMultisigClosure or ConditionMultisigClosure")
-		}
-
-		// must contain server pubkey
-		found := false
-		for _, pubkey := range keys {
-			if bytes.Equal(schnorr.SerializePubKey(pubkey), serverXonly) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return fmt.Errorf("invalid forfeit closure, server pubkey not found")
+		if err := ValidateForfeitClosure(forfeit, server); err != nil {
+			return err
 		}
 	}
 
@@ -143,13 +128,49 @@ func (v *TapscriptsVtxoScript) ForfeitClosures() []Closure {
 	forfeits := make([]Closure, 0)
 	for _, closure := range v.Closures {
 		switch closure.(type) {
-		case *MultisigClosure, *CLTVMultisigClosure, *ConditionMultisigClosure:
+		case *MultisigClosure, *CLTVMultisigClosure, *ConditionMultisigClosure,
+			*HashLockMultisigClosure:
 			forfeits = append(forfeits, closure)
 		}
 	}
 	return forfeits
 }
 
+// ForfeitClosurePubKeys returns the keys that must sign to spend the given
+// forfeit closure, or nil if the closure can't be used as forfeit path.
+func ForfeitClosurePubKeys(closure Closure) []*secp256k1.PublicKey {
+	switch c := closure.(type) {
+	case *MultisigClosure:
+		return c.PubKeys
+	case *CLTVMultisigClosure:
+		return c.PubKeys
+	case *ConditionMultisigClosure:
+		return c.PubKeys
+	case *HashLockMultisigClosure:
+		return c.PubKeys
+	}
+	return nil
+}
+
+// ValidateForfeitClosure makes sure the given closure is a valid forfeit path,
+// ie. a multisig closure including the server key. Any other key (eg. of a
+// watchtower) is allowed and is expected to sign along with the owner.
+func ValidateForfeitClosure(closure Closure, server *secp256k1.PublicKey) error {
+	keys := ForfeitClosurePubKeys(closure)
+	if len(keys) == 0 {
+		return fmt.Errorf("invalid forfeit closure, expected MultisigClosure, CLTVMultisigClosure, ConditionMultisigClosure or HashLockMultisigClosure")
+	}
+
+	// must contain server pubkey
+	serverXonly := schnorr.SerializePubKey(server)
+	for _, pubkey := range keys {
+		if bytes.Equal(schnorr.SerializePubKey(pubkey), serverXonly) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid forfeit closure, server pubkey not found")
+}
+
 func (v *TapscriptsVtxoScript) ExitClosures() []Closure {
 	exits := make([]Closure, 0)
 	for _, closure := range v.Closures {
@@ -173,6 +194,25 @@ func NewDefaultVtxoScript(owner, server *secp256k1.PublicKey, exitDelay common.R
 	}
 }
 
+// NewVtxoScriptWithForfeitCosigners returns a vtxo script like the default
+// one, but whose forfeit closure requires also the signatures of the given
+// cosigners (eg. a watchtower).
+func NewVtxoScriptWithForfeitCosigners(
+	owner, server *secp256k1.PublicKey, exitDelay common.RelativeLocktime,
+	cosigners ...*secp256k1.PublicKey,
+) *TapscriptsVtxoScript {
+	forfeitKeys := append([]*secp256k1.PublicKey{owner, server}, cosigners...)
+	return &TapscriptsVtxoScript{
+		[]Closure{
+			&CSVMultisigClosure{
+				MultisigClosure: MultisigClosure{PubKeys: []*secp256k1.PublicKey{owner}},
+				Locktime:        exitDelay,
+			},
+			&MultisigClosure{PubKeys: forfeitKeys},
+		},
+	}
+}
+
 func (v *TapscriptsVtxoScript) TapTree() (*secp256k1.PublicKey, bitcoinTapTree, error) {
 	leaves := make([]txscript.TapLeaf, len(v.Closures))
 	for i, closure := range v.Closures {
@@ -0,0 +1,119 @@
+package tree_test
+
+import (
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVtxoScriptWithForfeitCosigners(t *testing.T) {
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	watchtowerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	owner := ownerKey.PubKey()
+	server := serverKey.PubKey()
+	watchtower := watchtowerKey.PubKey()
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+
+	t.Run("valid", func(t *testing.T) {
+		vtxoScript := tree.NewVtxoScriptWithForfeitCosigners(
+			owner, server, exitDelay, watchtower,
+		)
+		require.NoError(t, vtxoScript.Validate(server, exitDelay))
+
+		forfeitClosures := vtxoScript.ForfeitClosures()
+		require.Len(t, forfeitClosures, 1)
+		require.Equal(
+			t, []*secp256k1.PublicKey{owner, server, watchtower},
+			tree.ForfeitClosurePubKeys(forfeitClosures[0]),
+		)
+
+		// the custom forfeit closure survives the encoding of the vtxo script
+		tapscripts, err := vtxoScript.Encode()
+		require.NoError(t, err)
+
+		decoded, err := tree.ParseVtxoScript(tapscripts)
+		require.NoError(t, err)
+		require.NoError(t, decoded.Validate(server, exitDelay))
+
+		decodedForfeitClosures := decoded.ForfeitClosures()
+		require.Len(t, decodedForfeitClosures, 1)
+		require.Len(t, tree.ForfeitClosurePubKeys(decodedForfeitClosures[0]), 3)
+
+		// the watchtower changes the vtxo tap key
+		tapKey, _, err := vtxoScript.TapTree()
+		require.NoError(t, err)
+		defaultTapKey, _, err := tree.NewDefaultVtxoScript(owner, server, exitDelay).TapTree()
+		require.NoError(t, err)
+		require.False(t, tapKey.IsEqual(defaultTapKey))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		// the forfeit closure doesn't include the server key
+		vtxoScript := &tree.TapscriptsVtxoScript{
+			Closures: []tree.Closure{
+				&tree.CSVMultisigClosure{
+					MultisigClosure: tree.MultisigClosure{
+						PubKeys: []*secp256k1.PublicKey{owner},
+					},
+					Locktime: exitDelay,
+				},
+				&tree.MultisigClosure{
+					PubKeys: []*secp256k1.PublicKey{owner, watchtower},
+				},
+			},
+		}
+		err := vtxoScript.Validate(server, exitDelay)
+		require.EqualError(t, err, "invalid forfeit closure, server pubkey not found")
+
+		err = tree.ValidateForfeitClosure(vtxoScript.Closures[0], server)
+		require.Error(t, err)
+	})
+}
+
+func TestTapTreeDepth(t *testing.T) {
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	vtxoScript := tree.NewDefaultVtxoScript(ownerKey.PubKey(), serverKey.PubKey(), exitDelay)
+
+	tapscripts, err := vtxoScript.Encode()
+	require.NoError(t, err)
+	encoded, err := tree.TapTree(tapscripts).Encode()
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		decoded, err := tree.DecodeTapTree(encoded)
+		require.NoError(t, err)
+		require.Equal(t, tree.TapTree(tapscripts), decoded)
+
+		_, tapTree, err := vtxoScript.TapTree()
+		require.NoError(t, err)
+		for _, leaf := range tapTree.GetLeaves() {
+			proof, err := tapTree.GetTaprootMerkleProof(leaf)
+			require.NoError(t, err)
+			_, err = txscript.ParseControlBlock(proof.ControlBlock)
+			require.NoError(t, err)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		// the first leaf declares a depth beyond the consensus limit
+		tooDeep := append([]byte{}, encoded...)
+		tooDeep[1] = txscript.ControlBlockMaxNodeCount + 1
+
+		_, err := tree.DecodeTapTree(tooDeep)
+		require.ErrorIs(t, err, tree.ErrTapTreeTooDeep)
+	})
+}
@@ -2,9 +2,13 @@ package arksdk
 
 import (
 	"context"
+	"time"
 
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/pkg/client-sdk/client"
 	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/watchtower"
 )
 
 type Option func(options interface{}) error
@@ -20,25 +24,100 @@ type ArkClient interface {
 	Receive(ctx context.Context) (offchainAddr, boardingAddr string, err error)
 	SendOffChain(
 		ctx context.Context, withExpiryCoinselect bool, receivers []Receiver,
-		withZeroFees bool,
+		withZeroFees bool, opts ...Option,
 	) (string, error)
+	// ExportUnsignedPSBT is like SendOffChain but returns the unsigned redeem
+	// tx instead of signing and submitting it, for a cold signer to sign it
+	// offline. Every input of the PSBT carries its witness utxo.
+	ExportUnsignedPSBT(
+		ctx context.Context, withExpiryCoinselect bool, receivers []Receiver,
+		withZeroFees bool, opts ...Option,
+	) (string, error)
+	// SubmitSignedPSBT submits a redeem tx exported with ExportUnsignedPSBT
+	// and signed offline, and returns its txid.
+	SubmitSignedPSBT(ctx context.Context, signedTx string, opts ...Option) (string, error)
+	// SendOffChainBatch sends to many receivers at once and reports the
+	// outcome of each of them. With PartialAllowed, the receivers with an
+	// invalid address or amount are skipped rather than failing the send.
+	SendOffChainBatch(
+		ctx context.Context, receivers []Receiver, opts BatchOpts,
+	) (txid string, results []ReceiverResult, err error)
 	Settle(ctx context.Context, opts ...Option) (string, error)
+	// SettleWithProgress is like Settle but notifies the given callback of the
+	// stages of the settlement. The callback runs on the SDK's internal
+	// goroutine handling the round events and must not block.
+	SettleWithProgress(
+		ctx context.Context, progress func(SettleStage), opts ...Option,
+	) (string, error)
+	PreviewSettle(ctx context.Context, opts ...Option) (*SettlePreview, error)
+	ResumeSettle(ctx context.Context, roundID string, opts ...Option) (string, error)
+	AmendSettle(ctx context.Context, requestId string, receivers []Receiver) error
+	RecoverAll(
+		ctx context.Context, progressCh chan<- RecoveryProgress, opts ...Option,
+	) ([]string, error)
+	// ListRecoverable returns the swept vtxos that RecoverAll would recover.
+	ListRecoverable(ctx context.Context) ([]RecoverableVtxo, error)
 	CollaborativeExit(
 		ctx context.Context, addr string, amount uint64, withExpiryCoinselect bool,
 		opts ...Option,
 	) (string, error)
 	StartUnilateralExit(ctx context.Context) error
-	CompleteUnilateralExit(ctx context.Context, to string) (string, error)
+	// RedeemOutpoints unilaterally exits only the given spendable vtxos of the
+	// wallet. Unless forced, it fails if that would also unroll part of the
+	// tree of other vtxos of the wallet.
+	RedeemOutpoints(ctx context.Context, outpoints []client.Outpoint, force bool) error
+	CompleteUnilateralExit(
+		ctx context.Context, to string, opts ...Option,
+	) (txid string, locked []types.Utxo, err error)
+	EstimateExitCost(ctx context.Context, window time.Duration) (*ExitCost, error)
+	SubscribeExitMaturity(
+		ctx context.Context, outpoints []client.Outpoint,
+	) (<-chan ExitStatus, error)
 	OnboardAgainAllExpiredBoardings(ctx context.Context) (string, error)
 	WithdrawFromAllExpiredBoardings(ctx context.Context, to string) (string, error)
+	// ReplaceBoardingTx replaces an unconfirmed tx spending boarding utxos of
+	// the wallet with one paying the given higher fee rate, in sats/vbyte.
+	ReplaceBoardingTx(ctx context.Context, txid string, feeRate float64) (string, error)
+	// RefundHashlock broadcasts the given signed refund tx of a hash lock
+	// output, unless the output has been claimed already. In that case, it
+	// returns the revealed preimage along with ErrHashlockClaimed.
+	RefundHashlock(
+		ctx context.Context, outpoint client.Outpoint,
+		closure *tree.HashLockMultisigClosure, refundTx string,
+	) (txid string, preimage []byte, err error)
 	ListVtxos(ctx context.Context) (spendable, spent []client.Vtxo, err error)
+	// ListVtxosPaged returns a page of the vtxos of the wallet, filtered by the
+	// server, and the token of the next page, empty if it's the last one.
+	ListVtxosPaged(
+		ctx context.Context, req ListVtxosRequest,
+	) (vtxos []client.Vtxo, nextPageToken string, err error)
+	ListAddresses(ctx context.Context) ([]AddressInfo, error)
+	GetRoundTx(ctx context.Context, roundTxid string) (string, error)
+	StartWatchtower(ctx context.Context, autoRespond bool) (<-chan watchtower.Alert, error)
 	Dump(ctx context.Context) (seed string, err error)
+	ExportEncryptedSeed(ctx context.Context, password string, opts ...Option) (string, error)
+	ImportEncryptedSeed(ctx context.Context, encryptedSeed string, args InitArgs) error
+	ExportDescriptors(ctx context.Context) (*WalletDescriptors, error)
+	// ImportDescriptors initializes a watch-only wallet tracking the addresses
+	// of the given descriptors.
+	ImportDescriptors(ctx context.Context, descriptors WalletDescriptors, args InitArgs) error
 	GetTransactionHistory(ctx context.Context) ([]types.Transaction, error)
 	GetTransactionEventChannel(ctx context.Context) chan types.TransactionEvent
 	GetVtxoEventChannel(ctx context.Context) chan types.VtxoEvent
 	RedeemNotes(ctx context.Context, notes []string, opts ...Option) (string, error)
 	SignTransaction(ctx context.Context, tx string) (string, error)
+	ProveVtxoOwnership(
+		ctx context.Context, vtxoKey client.Outpoint,
+	) (*common.VtxoOwnershipProof, error)
 	NotifyIncomingFunds(ctx context.Context, address string) ([]types.Vtxo, error)
+	// NotifyIncomingFundsConfirmed is like NotifyIncomingFunds but, for an
+	// onchain address, it returns the new utxos only once their funding tx has
+	// at least minConf confirmations. minConf is ignored for offchain addresses.
+	NotifyIncomingFundsConfirmed(
+		ctx context.Context, address string, minConf uint32,
+	) ([]types.Vtxo, []types.Utxo, error)
+	MigrateServerKey(ctx context.Context) error
+	SetCoinSelector(selector CoinSelector)
 	Reset(ctx context.Context)
 	Stop()
 }
@@ -48,4 +127,7 @@ type Receiver interface {
 	Amount() uint64
 
 	IsOnchain() bool
+	// Validate checks the address and the amount of the receiver against the
+	// given network, without reaching the server.
+	Validate(net common.Network) error
 }
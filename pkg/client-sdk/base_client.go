@@ -1,12 +1,15 @@
 package arksdk
 
 import (
+	"bytes"
 	"context"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/pkg/client-sdk/client"
 	"github.com/ark-network/ark/pkg/client-sdk/explorer"
 	"github.com/ark-network/ark/pkg/client-sdk/indexer"
@@ -19,6 +22,8 @@ import (
 	walletstore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store"
 	filestore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store/file"
 	inmemorystore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store/inmemory"
+	"github.com/ark-network/ark/pkg/client-sdk/watchtower"
+	"github.com/btcsuite/btcd/wire"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/sirupsen/logrus"
 )
@@ -29,6 +34,7 @@ const (
 	RestClient = client.RestClient
 	// wallet
 	SingleKeyWallet = wallet.SingleKeyWallet
+	WatchOnlyWallet = wallet.WatchOnlyWallet
 	// store
 	FileStore     = types.FileStore
 	InMemoryStore = types.InMemoryStore
@@ -36,9 +42,44 @@ const (
 	BitcoinExplorer = explorer.BitcoinExplorer
 )
 
+// incomingFundsPollInterval is how often NotifyIncomingFundsConfirmed checks
+// the confirmations of the utxos of an onchain address.
+const incomingFundsPollInterval = 10 * time.Second
+
 var (
 	ErrAlreadyInitialized = fmt.Errorf("client already initialized")
 	ErrNotInitialized     = fmt.Errorf("client not initialized")
+	// ErrVtxoTreeSwept is returned when trying to spend offchain a vtxo whose
+	// tree has already been swept by the server. Such vtxo can only be
+	// recovered with Settle(ctx, WithRecoverableVtxos).
+	ErrVtxoTreeSwept = fmt.Errorf(
+		"vtxo tree swept, settle with recoverable vtxos to recover the funds",
+	)
+	// ErrAmountBelowMinimum and ErrAmountAboveMaximum are returned when the
+	// amount of an output, or the total amount settled in a round, is out of
+	// the bounds accepted by the server.
+	ErrAmountBelowMinimum = fmt.Errorf("amount below minimum accepted by the server")
+	ErrAmountAboveMaximum = fmt.Errorf("amount above maximum accepted by the server")
+	// ErrServerKeyChanged is returned when the server reports a pubkey different
+	// from the one the wallet was initialized with. The addresses derived with
+	// the old key might not be honored by the server anymore, therefore any
+	// operation creating new vtxos is refused until MigrateServerKey is called
+	// to settle the funds of the wallet into the new key.
+	ErrServerKeyChanged = fmt.Errorf("server pubkey changed, migrate to the new server key")
+	// ErrDustAmount and ErrInvalidReceiverAddress are returned by the
+	// validation of a receiver if its amount is below dust or its address is
+	// malformed or for another network.
+	ErrDustAmount             = fmt.Errorf("amount below dust")
+	ErrInvalidReceiverAddress = fmt.Errorf("invalid receiver address")
+	// ErrDustChange is returned by CollaborativeExit if the change of the exit
+	// would be a vtxo below dust (or the min vtxo amount of the server).
+	ErrDustChange = fmt.Errorf("change amount below dust")
+	// ErrAlreadyConfirmed is returned by ReplaceBoardingTx if the tx to replace
+	// is already confirmed.
+	ErrAlreadyConfirmed = fmt.Errorf("tx already confirmed")
+	// ErrWatchOnly is returned by any operation that requires to sign when the
+	// client is initialized with a watch-only wallet.
+	ErrWatchOnly = wallet.ErrWatchOnly
 )
 
 var (
@@ -61,6 +102,8 @@ type arkClient struct {
 	indexer  indexer.Indexer
 
 	txStreamCtxCancel context.CancelFunc
+	watchtower        *watchtower.Watchtower
+	coinSelector      CoinSelector
 }
 
 func (a *arkClient) GetConfigData(
@@ -101,15 +144,65 @@ func (a *arkClient) Dump(ctx context.Context) (string, error) {
 	return a.wallet.Dump(ctx)
 }
 
+// SeedBackupOptions allows to customize the encryption of the seed backup
+type SeedBackupOptions struct {
+	ScryptParams wallet.ScryptParams
+}
+
+// WithScryptParams sets the cost parameters of the kdf used to encrypt the
+// seed backup
+func WithScryptParams(n, r, p int) Option {
+	return func(o interface{}) error {
+		opts, ok := o.(*SeedBackupOptions)
+		if !ok {
+			return fmt.Errorf("invalid options type")
+		}
+
+		opts.ScryptParams = wallet.ScryptParams{N: n, R: r, P: p}
+		return nil
+	}
+}
+
+// ExportEncryptedSeed returns the seed of the wallet encrypted with the given
+// password, to be restored with ImportEncryptedSeed. The wallet must be
+// unlocked.
+func (a *arkClient) ExportEncryptedSeed(
+	ctx context.Context, password string, opts ...Option,
+) (string, error) {
+	if err := a.safeCheck(); err != nil {
+		return "", err
+	}
+
+	options := &SeedBackupOptions{ScryptParams: wallet.DefaultScryptParams}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return "", err
+		}
+	}
+
+	seed, err := a.wallet.Dump(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return wallet.EncryptSeed(seed, password, options.ScryptParams)
+}
+
 func (a *arkClient) Receive(ctx context.Context) (string, string, error) {
 	if a.wallet == nil {
 		return "", "", fmt.Errorf("wallet not initialized")
 	}
+	if err := a.ensureServerKey(ctx); err != nil {
+		return "", "", err
+	}
 
 	offchainAddr, boardingAddr, err := a.wallet.NewAddress(ctx, false)
 	if err != nil {
 		return "", "", err
 	}
+	if err := a.storeAddresses(ctx); err != nil {
+		return "", "", err
+	}
 
 	if a.UtxoMaxAmount == 0 {
 		boardingAddr.Address = ""
@@ -133,7 +226,7 @@ func (a *arkClient) GetVtxoEventChannel(_ context.Context) chan types.VtxoEvent
 }
 
 func (a *arkClient) SignTransaction(ctx context.Context, tx string) (string, error) {
-	if err := a.safeCheck(); err != nil {
+	if err := a.signerCheck(); err != nil {
 		return "", err
 	}
 	return a.wallet.SignTransaction(ctx, a.explorer, tx)
@@ -152,6 +245,9 @@ func (a *arkClient) Stop() {
 	if a.txStreamCtxCancel != nil {
 		a.txStreamCtxCancel()
 	}
+	if a.watchtower != nil {
+		a.watchtower.Stop()
+	}
 
 	a.store.Close()
 }
@@ -164,17 +260,233 @@ func (a *arkClient) ListVtxos(
 		return
 	}
 
+	if len(offchainAddrs) <= 0 {
+		return
+	}
+
+	addrs := make([]string, 0, len(offchainAddrs))
+	for _, addr := range offchainAddrs {
+		addrs = append(addrs, addr.Address)
+	}
+
+	vtxosByAddr, err := a.client.ListVtxosForAddresses(ctx, addrs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, v := range vtxosByAddr {
+		spendableVtxos = append(spendableVtxos, v.SpendableVtxos...)
+		spentVtxos = append(spentVtxos, v.SpentVtxos...)
+	}
+
+	return
+}
+
+func (a *arkClient) ListVtxosPaged(
+	ctx context.Context, req ListVtxosRequest,
+) ([]client.Vtxo, string, error) {
+	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(offchainAddrs) <= 0 {
+		return nil, "", nil
+	}
+
+	addrs := make([]string, 0, len(offchainAddrs))
 	for _, addr := range offchainAddrs {
-		spendable, spent, err := a.client.ListVtxos(ctx, addr.Address)
+		addrs = append(addrs, addr.Address)
+	}
+
+	return a.client.ListVtxosPaged(ctx, client.VtxosPageRequest{
+		Addresses: addrs,
+		Spendable: req.Spendable,
+		Spent:     req.Spent,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		MinAmount: req.MinAmount,
+		RoundTxid: req.RoundTxid,
+	})
+}
+
+// StartWatchtower starts monitoring the explorer in background for attempts
+// to unilaterally exit the inputs of the redeem txs of the spendable vtxos.
+// Alerts are published on the returned channel, if autoRespond is set the
+// redeem tx of a vtxo at risk is broadcasted right away.
+func (a *arkClient) StartWatchtower(
+	ctx context.Context, autoRespond bool,
+) (<-chan watchtower.Alert, error) {
+	if err := a.safeCheck(); err != nil {
+		return nil, err
+	}
+	if a.watchtower != nil {
+		return nil, fmt.Errorf("watchtower already started")
+	}
+
+	listVtxos := func(ctx context.Context) ([]client.Vtxo, error) {
+		spendable, _, err := a.ListVtxos(ctx)
+		return spendable, err
+	}
+	wt, err := watchtower.New(a.explorer, listVtxos, 0, autoRespond)
+	if err != nil {
+		return nil, err
+	}
+	wt.Start(ctx)
+	a.watchtower = wt
+
+	return wt.Alerts(), nil
+}
+
+// GetRoundTx returns the hex encoded raw round tx with the given txid, as
+// broadcasted onchain, making sure it matches the txid.
+func (a *arkClient) GetRoundTx(ctx context.Context, roundTxid string) (string, error) {
+	if err := a.safeCheck(); err != nil {
+		return "", err
+	}
+
+	txHex, err := a.client.GetRoundTx(ctx, roundTxid)
+	if err != nil {
+		return "", err
+	}
+
+	buf, err := hex.DecodeString(txHex)
+	if err != nil {
+		return "", fmt.Errorf("invalid round tx: %s", err)
+	}
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(buf)); err != nil {
+		return "", fmt.Errorf("invalid round tx: %s", err)
+	}
+	if txid := tx.TxHash().String(); txid != roundTxid {
+		return "", fmt.Errorf("round tx mismatch, expected txid %s, got %s", roundTxid, txid)
+	}
+	return txHex, nil
+}
+
+// ListAddresses returns all the addresses generated by the wallet, including
+// those not derived anymore (eg. after a server key migration), with their
+// balance.
+func (a *arkClient) ListAddresses(ctx context.Context) ([]AddressInfo, error) {
+	if err := a.safeCheck(); err != nil {
+		return nil, err
+	}
+	if err := a.storeAddresses(ctx); err != nil {
+		return nil, err
+	}
+
+	addresses, err := a.getAllAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	offchainAddrs := make([]string, 0)
+	for _, addr := range addresses {
+		if addr.Type == types.OffchainAddress {
+			offchainAddrs = append(offchainAddrs, addr.Address)
+		}
+	}
+
+	offchainBalances := make(map[string]uint64)
+	if len(offchainAddrs) > 0 {
+		vtxosByAddr, err := a.client.ListVtxosForAddresses(ctx, offchainAddrs)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
+		}
+		for _, v := range vtxosByAddr {
+			for _, vtxo := range v.SpendableVtxos {
+				offchainBalances[v.Address] += vtxo.Amount
+			}
+		}
+	}
+
+	list := make([]AddressInfo, 0, len(addresses))
+	for _, addr := range addresses {
+		balance := offchainBalances[addr.Address]
+		if addr.Type != types.OffchainAddress {
+			balance, err = a.explorer.GetBalance(addr.Address)
+			if err != nil {
+				return nil, err
+			}
 		}
+		list = append(list, AddressInfo{
+			Address: addr.Address,
+			Type:    addr.Type,
+			Balance: balance,
+		})
+	}
+	return list, nil
+}
 
-		spendableVtxos = append(spendableVtxos, spendable...)
-		spentVtxos = append(spentVtxos, spent...)
+// storeAddresses keeps track of the addresses currently derived by the wallet
+// so that they can be listed also once they're not derived anymore.
+func (a *arkClient) storeAddresses(ctx context.Context) error {
+	if a.store == nil || a.store.AddressStore() == nil {
+		return nil
 	}
 
-	return
+	addresses, err := a.getCurrentAddresses(ctx)
+	if err != nil {
+		return err
+	}
+	if _, err := a.store.AddressStore().AddAddresses(ctx, addresses); err != nil {
+		return fmt.Errorf("failed to store addresses: %s", err)
+	}
+	return nil
+}
+
+// getAllAddresses returns the addresses currently derived by the wallet
+// together with those in the store, if any.
+func (a *arkClient) getAllAddresses(ctx context.Context) ([]types.Address, error) {
+	addresses, err := a.getCurrentAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if a.store == nil || a.store.AddressStore() == nil {
+		return addresses, nil
+	}
+
+	storedAddresses, err := a.store.AddressStore().GetAllAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	for _, addr := range addresses {
+		seen[addr.Address] = struct{}{}
+	}
+	for _, addr := range storedAddresses {
+		if _, ok := seen[addr.Address]; ok {
+			continue
+		}
+		seen[addr.Address] = struct{}{}
+		addresses = append(addresses, addr)
+	}
+	return addresses, nil
+}
+
+func (a *arkClient) getCurrentAddresses(ctx context.Context) ([]types.Address, error) {
+	offchainAddrs, boardingAddrs, redemptionAddrs, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	addresses := make([]types.Address, 0)
+	addAll := func(addrType types.AddressType, addrs []wallet.TapscriptsAddress) {
+		for _, addr := range addrs {
+			addresses = append(addresses, types.Address{
+				Address:    addr.Address,
+				Type:       addrType,
+				Tapscripts: addr.Tapscripts,
+				CreatedAt:  now,
+			})
+		}
+	}
+	addAll(types.OffchainAddress, offchainAddrs)
+	addAll(types.BoardingAddress, boardingAddrs)
+	addAll(types.RedemptionAddress, redemptionAddrs)
+	return addresses, nil
 }
 
 func (a *arkClient) NotifyIncomingFunds(
@@ -204,6 +516,125 @@ func (a *arkClient) NotifyIncomingFunds(
 	return incomingVtxos, nil
 }
 
+func (a *arkClient) NotifyIncomingFundsConfirmed(
+	ctx context.Context, addr string, minConf uint32,
+) ([]types.Vtxo, []types.Utxo, error) {
+	if a.client == nil {
+		return nil, nil, fmt.Errorf("wallet not initialized")
+	}
+
+	// offchain funds are instant, the number of confirmations doesn't apply
+	if _, err := common.DecodeAddress(addr); err == nil {
+		incomingVtxos, err := a.NotifyIncomingFunds(ctx, addr)
+		return incomingVtxos, nil, err
+	}
+
+	delay, tapscripts, err := a.getBoardingScript(ctx, addr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the utxos already confirmed are not incoming funds, those in mempool or
+	// with not enough confirmations are instead notified once confirmed.
+	known, err := a.getConfirmedUtxos(addr, minConf, delay, tapscripts)
+	if err != nil {
+		return nil, nil, err
+	}
+	knownOutpoints := make(map[client.Outpoint]struct{}, len(known))
+	for _, utxo := range known {
+		knownOutpoints[client.Outpoint{Txid: utxo.Txid, VOut: utxo.VOut}] = struct{}{}
+	}
+
+	ticker := time.NewTicker(incomingFundsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		case <-ticker.C:
+		}
+
+		confirmed, err := a.getConfirmedUtxos(addr, minConf, delay, tapscripts)
+		if err != nil {
+			logrus.WithError(err).Warn("failed to get utxos of address")
+			continue
+		}
+
+		incomingUtxos := make([]types.Utxo, 0)
+		for _, utxo := range confirmed {
+			outpoint := client.Outpoint{Txid: utxo.Txid, VOut: utxo.VOut}
+			if _, ok := knownOutpoints[outpoint]; ok {
+				continue
+			}
+			incomingUtxos = append(incomingUtxos, utxo)
+		}
+		if len(incomingUtxos) > 0 {
+			return nil, incomingUtxos, nil
+		}
+	}
+}
+
+// getConfirmedUtxos returns the utxos of the given onchain address with at
+// least minConf confirmations.
+func (a *arkClient) getConfirmedUtxos(
+	addr string, minConf uint32,
+	delay common.RelativeLocktime, tapscripts []string,
+) ([]types.Utxo, error) {
+	utxos, err := a.explorer.GetUtxos(addr)
+	if err != nil {
+		return nil, err
+	}
+
+	var tipHeight int64
+	confirmed := make([]types.Utxo, 0, len(utxos))
+	for _, utxo := range utxos {
+		if minConf > 0 {
+			if !utxo.Status.Confirmed {
+				continue
+			}
+			if tipHeight == 0 {
+				if tipHeight, err = a.explorer.GetBlockHeight(); err != nil {
+					return nil, err
+				}
+			}
+			if tipHeight-utxo.Status.BlockHeight+1 < int64(minConf) {
+				continue
+			}
+		}
+		confirmed = append(confirmed, utxo.ToUtxo(delay, tapscripts))
+	}
+	return confirmed, nil
+}
+
+// getBoardingScript returns the exit delay and the tapscripts of the given
+// address if it's a boarding address of the wallet, or zero values otherwise.
+func (a *arkClient) getBoardingScript(
+	ctx context.Context, addr string,
+) (common.RelativeLocktime, []string, error) {
+	_, boardingAddrs, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return common.RelativeLocktime{}, nil, err
+	}
+
+	for _, boardingAddr := range boardingAddrs {
+		if boardingAddr.Address != addr {
+			continue
+		}
+
+		boardingScript, err := tree.ParseVtxoScript(boardingAddr.Tapscripts)
+		if err != nil {
+			return common.RelativeLocktime{}, nil, err
+		}
+		delay, err := boardingScript.SmallestExitDelay()
+		if err != nil {
+			return common.RelativeLocktime{}, nil, err
+		}
+		return *delay, boardingAddr.Tapscripts, nil
+	}
+	return common.RelativeLocktime{}, nil, nil
+}
+
 func (a *arkClient) initWithWallet(
 	ctx context.Context, args InitWithWalletArgs,
 ) error {
@@ -282,6 +713,8 @@ func (a *arkClient) initWithWallet(
 		UtxoMaxAmount:              info.UtxoMaxAmount,
 		VtxoMinAmount:              info.VtxoMinAmount,
 		VtxoMaxAmount:              info.VtxoMaxAmount,
+		SettleMinAmount:            info.SettleMinAmount,
+		SettleMaxAmount:            info.SettleMaxAmount,
 	}
 	if err := a.store.ConfigStore().AddData(ctx, storeData); err != nil {
 		return err
@@ -380,6 +813,8 @@ func (a *arkClient) init(
 		UtxoMaxAmount:              info.UtxoMaxAmount,
 		VtxoMinAmount:              info.VtxoMinAmount,
 		VtxoMaxAmount:              info.VtxoMaxAmount,
+		SettleMinAmount:            info.SettleMinAmount,
+		SettleMaxAmount:            info.SettleMaxAmount,
 	}
 	walletSvc, err := getWallet(a.store.ConfigStore(), &cfgData, supportedWallets)
 	if err != nil {
@@ -390,7 +825,11 @@ func (a *arkClient) init(
 		return err
 	}
 
-	if _, err := walletSvc.Create(ctx, args.Password, args.Seed); err != nil {
+	seed := args.Seed
+	if args.WalletType == wallet.WatchOnlyWallet {
+		seed = args.PubKey
+	}
+	if _, err := walletSvc.Create(ctx, args.Password, seed); err != nil {
 		//nolint:all
 		a.store.ConfigStore().CleanData(ctx)
 		return err
@@ -424,6 +863,41 @@ func (a *arkClient) ping(
 	return ticker.Stop
 }
 
+// ensureServerKey returns ErrServerKeyChanged if the pubkey reported by the
+// server differs from the one in the config.
+func (a *arkClient) ensureServerKey(ctx context.Context) error {
+	serverPubkey, _, err := a.getServerPubkey(ctx)
+	if err != nil {
+		return err
+	}
+	if !serverPubkey.IsEqual(a.ServerPubKey) {
+		return fmt.Errorf(
+			"%w: expected %x, got %x", ErrServerKeyChanged,
+			a.ServerPubKey.SerializeCompressed(), serverPubkey.SerializeCompressed(),
+		)
+	}
+	return nil
+}
+
+func (a *arkClient) getServerPubkey(
+	ctx context.Context,
+) (*secp256k1.PublicKey, *client.Info, error) {
+	info, err := a.client.GetInfo(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get server info: %s", err)
+	}
+
+	buf, err := hex.DecodeString(info.PubKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse server pubkey: %s", err)
+	}
+	serverPubkey, err := secp256k1.ParsePubKey(buf)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse server pubkey: %s", err)
+	}
+	return serverPubkey, info, nil
+}
+
 func (a *arkClient) safeCheck() error {
 	if a.wallet == nil {
 		return fmt.Errorf("wallet not initialized")
@@ -434,6 +908,18 @@ func (a *arkClient) safeCheck() error {
 	return nil
 }
 
+// signerCheck is the safeCheck of the operations that require the wallet to
+// sign, refused with ErrWatchOnly by a watch-only wallet.
+func (a *arkClient) signerCheck() error {
+	if err := a.safeCheck(); err != nil {
+		return err
+	}
+	if a.wallet.GetType() == wallet.WatchOnlyWallet {
+		return ErrWatchOnly
+	}
+	return nil
+}
+
 func getClient(
 	supportedClients utils.SupportedType[utils.ClientFactory], clientType, serverUrl string,
 ) (client.TransportClient, error) {
@@ -448,7 +934,12 @@ func getExplorer(explorerURL, network string) (explorer.Explorer, error) {
 			return nil, fmt.Errorf("invalid network")
 		}
 	}
-	return explorer.NewExplorer(explorerURL, utils.NetworkFromString(network)), nil
+	net := utils.NetworkFromString(network)
+	// A comma separated list of urls enables cross-checking among explorers.
+	if strings.Contains(explorerURL, ",") {
+		return explorer.NewQuorumExplorerFromUrls(explorerURL, net)
+	}
+	return explorer.NewExplorer(explorerURL, net), nil
 }
 
 func getIndexer(clientType, serverUrl string) (indexer.Indexer, error) {
@@ -467,6 +958,8 @@ func getWallet(
 	switch data.WalletType {
 	case wallet.SingleKeyWallet:
 		return getSingleKeyWallet(configStore)
+	case wallet.WatchOnlyWallet:
+		return getWatchOnlyWallet(configStore)
 	default:
 		return nil, fmt.Errorf(
 			"unsupported wallet type '%s', please select one of: %s",
@@ -484,6 +977,15 @@ func getSingleKeyWallet(configStore types.ConfigStore) (wallet.WalletService, er
 	return singlekeywallet.NewBitcoinWallet(configStore, walletStore)
 }
 
+func getWatchOnlyWallet(configStore types.ConfigStore) (wallet.WalletService, error) {
+	walletStore, err := getWalletStore(configStore.GetType(), configStore.GetDatadir())
+	if err != nil {
+		return nil, err
+	}
+
+	return singlekeywallet.NewWatchOnlyWallet(configStore, walletStore)
+}
+
 func getWalletStore(storeType, datadir string) (walletstore.WalletStore, error) {
 	switch storeType {
 	case types.InMemoryStore:
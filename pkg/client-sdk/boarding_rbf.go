@@ -0,0 +1,148 @@
+package arksdk
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/internal/utils"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ReplaceBoardingTx replaces the given unconfirmed tx spending boarding utxos
+// of the wallet, like the one of OnboardAgainAllExpiredBoardings, with one
+// paying the same output at the given higher fee rate in sats/vbyte. It
+// returns the txid of the replacement, or ErrAlreadyConfirmed if the tx is
+// already confirmed.
+func (a *covenantlessArkClient) ReplaceBoardingTx(
+	ctx context.Context, txid string, newFeeRate float64,
+) (string, error) {
+	if err := a.signerCheck(); err != nil {
+		return "", err
+	}
+	if newFeeRate <= 0 {
+		return "", fmt.Errorf("invalid fee rate %f", newFeeRate)
+	}
+
+	confirmed, _, err := a.explorer.GetTxBlockTime(txid)
+	if err != nil {
+		return "", err
+	}
+	if confirmed {
+		return "", ErrAlreadyConfirmed
+	}
+
+	tx, err := a.getTx(txid)
+	if err != nil {
+		return "", err
+	}
+	if !signalsRBF(tx) {
+		return "", fmt.Errorf("tx %s does not signal rbf", txid)
+	}
+	if len(tx.TxOut) != 1 {
+		return "", fmt.Errorf("tx %s is not a boarding tx of the wallet", txid)
+	}
+
+	utxos, err := a.getSpentBoardingUtxos(ctx, tx)
+	if err != nil {
+		return "", err
+	}
+
+	inputAmount := uint64(0)
+	for _, u := range utxos {
+		inputAmount += u.Amount
+	}
+	fees := inputAmount - uint64(tx.TxOut[0].Value)
+
+	return a.sendBoardingUtxos(ctx, tx.TxOut[0].PkScript, utxos, newFeeRate, fees)
+}
+
+// getSpentBoardingUtxos returns the boarding utxos of the wallet spent by the
+// given tx, it fails if any input doesn't spend one.
+func (a *covenantlessArkClient) getSpentBoardingUtxos(
+	ctx context.Context, tx *wire.MsgTx,
+) ([]types.Utxo, error) {
+	_, boardingAddrs, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	netParams := utils.ToBitcoinNetwork(a.Network)
+	addrsByScript := make(map[string]wallet.TapscriptsAddress, len(boardingAddrs))
+	for _, addr := range boardingAddrs {
+		decodedAddr, err := btcutil.DecodeAddress(addr.Address, &netParams)
+		if err != nil {
+			return nil, err
+		}
+		pkscript, err := txscript.PayToAddrScript(decodedAddr)
+		if err != nil {
+			return nil, err
+		}
+		addrsByScript[hex.EncodeToString(pkscript)] = addr
+	}
+
+	utxos := make([]types.Utxo, 0, len(tx.TxIn))
+	for _, in := range tx.TxIn {
+		prevout := in.PreviousOutPoint
+		prevTx, err := a.getTx(prevout.Hash.String())
+		if err != nil {
+			return nil, err
+		}
+		if int(prevout.Index) >= len(prevTx.TxOut) {
+			return nil, fmt.Errorf("prevout %s not found", prevout)
+		}
+		out := prevTx.TxOut[prevout.Index]
+
+		addr, ok := addrsByScript[hex.EncodeToString(out.PkScript)]
+		if !ok {
+			return nil, fmt.Errorf("input %s is not a boarding utxo of the wallet", prevout)
+		}
+
+		boardingScript, err := tree.ParseVtxoScript(addr.Tapscripts)
+		if err != nil {
+			return nil, err
+		}
+		boardingTimeout, err := boardingScript.SmallestExitDelay()
+		if err != nil {
+			return nil, err
+		}
+
+		utxos = append(utxos, types.Utxo{
+			Txid:       prevout.Hash.String(),
+			VOut:       prevout.Index,
+			Amount:     uint64(out.Value),
+			Delay:      *boardingTimeout,
+			Tapscripts: addr.Tapscripts,
+		})
+	}
+	return utxos, nil
+}
+
+func (a *covenantlessArkClient) getTx(txid string) (*wire.MsgTx, error) {
+	txHex, err := a.explorer.GetTxHex(txid)
+	if err != nil {
+		return nil, err
+	}
+	var tx wire.MsgTx
+	if err := tx.Deserialize(hex.NewDecoder(strings.NewReader(txHex))); err != nil {
+		return nil, err
+	}
+	return &tx, nil
+}
+
+// signalsRBF returns whether the given tx is replaceable as per BIP-125, ie.
+// if any of its inputs has a sequence lower than 0xfffffffe.
+func signalsRBF(tx *wire.MsgTx) bool {
+	for _, in := range tx.TxIn {
+		if in.Sequence < wire.MaxTxInSequenceNum-1 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package arksdk
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignalsRBF(t *testing.T) {
+	tests := []struct {
+		name      string
+		sequences []uint32
+		expected  bool
+	}{
+		{"final", []uint32{wire.MaxTxInSequenceNum}, false},
+		{"locktime enabled", []uint32{wire.MaxTxInSequenceNum - 1}, false},
+		{"opt-in", []uint32{wire.MaxTxInSequenceNum - 2}, true},
+		{"csv", []uint32{wire.MaxTxInSequenceNum, 144}, true},
+		{"no inputs", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx := wire.NewMsgTx(2)
+			for _, sequence := range tt.sequences {
+				tx.AddTxIn(&wire.TxIn{Sequence: sequence})
+			}
+			require.Equal(t, tt.expected, signalsRBF(tx))
+		})
+	}
+}
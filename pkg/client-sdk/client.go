@@ -46,10 +46,80 @@ type SettleOptions struct {
 	SigningType            *tree.SigningType
 	WalletSignerDisabled   bool
 	SelectRecoverableVtxos bool
+	// FeeRate overrides the server min relay fee rate for the forfeit txs
+	FeeRate chainfee.SatPerKVByte
+	// ForfeitCosigner signs the forfeit txs of vtxos with custom forfeit closures
+	ForfeitCosigner ForfeitCosigner
+	// AbsorbDustChange adds to the onchain output of a collaborative exit the
+	// change that would be below dust, instead of failing with ErrDustChange
+	AbsorbDustChange bool
+	// Denominations splits the offchain outputs into vtxos of standard amounts
+	Denominations []uint64
 
 	EventsCh chan<- client.RoundEvent
+	// Progress is notified of the stages of the settlement, see
+	// SettleWithProgress
+	Progress func(SettleStage)
 }
 
+// SendOptions allows to customize the txs built client-side when sending
+// funds offchain or completing a unilateral exit
+type SendOptions struct {
+	FeeRate chainfee.SatPerKVByte
+	// ForfeitCosigner signs the inputs of the redeem tx spending vtxos with
+	// custom forfeit closures
+	ForfeitCosigner ForfeitCosigner
+	// Denominations splits the receivers and the change into vtxos of standard
+	// amounts
+	Denominations []uint64
+	// IdempotencyKey makes the submission of the redeem tx safe to retry
+	IdempotencyKey string
+}
+
+// BatchOpts customizes a SendOffChainBatch
+type BatchOpts struct {
+	// PartialAllowed skips the invalid receivers instead of failing the whole
+	// send
+	PartialAllowed       bool
+	WithExpiryCoinselect bool
+	WithZeroFees         bool
+	// SendOpts are the options of the underlying SendOffChain
+	SendOpts []Option
+}
+
+// ReceiverResult is the outcome of a single receiver of a SendOffChainBatch
+type ReceiverResult struct {
+	// Index is the position of the receiver in the given list
+	Index int
+	// Err is the reason why the receiver was rejected, nil if valid
+	Err error
+	// Included is true if the receiver is an output of the redeem tx
+	Included bool
+}
+
+// ForfeitCosigner adds to the given tx, already signed by the wallet, the
+// signatures of the extra cosigners (eg. a watchtower) required by the custom
+// forfeit closures of the vtxos spent.
+type ForfeitCosigner func(ctx context.Context, tx string) (string, error)
+
+// maxVtxosPerRecoveryRound is the max number of vtxos recovered in a single
+// round by RecoverAll
+const maxVtxosPerRecoveryRound = 100
+
+// minRelayFeeRate is the network min relay fee rate (1 sat/vbyte), it's the
+// lower bound for the fee rate of the redeem txs and for any custom fee rate
+var minRelayFeeRate = chainfee.AbsoluteFeePerKwFloor.FeePerKVByte()
+
+const (
+	// redeemTxConfirmationTarget is the number of blocks used to estimate the
+	// fee rate of the redeem txs, they are broadcasted only in case of
+	// unilateral exit and can be bumped then.
+	redeemTxConfirmationTarget = 6
+	// onchainTxConfirmationTarget is the number of blocks used to estimate the
+	// fee rate of the txs broadcasted right away.
+	onchainTxConfirmationTarget = 1
+)
+
 func WithRecoverableVtxos(o interface{}) error {
 	opts, ok := o.(*SettleOptions)
 	if !ok {
@@ -60,6 +130,18 @@ func WithRecoverableVtxos(o interface{}) error {
 	return nil
 }
 
+// WithDustChangeAbsorbed makes a collaborative exit add to the onchain output
+// the change that would be below dust, instead of failing
+func WithDustChangeAbsorbed(o interface{}) error {
+	opts, ok := o.(*SettleOptions)
+	if !ok {
+		return fmt.Errorf("invalid options type")
+	}
+
+	opts.AbsorbDustChange = true
+	return nil
+}
+
 func WithEventsCh(ch chan<- client.RoundEvent) Option {
 	return func(o interface{}) error {
 		opts, ok := o.(*SettleOptions)
@@ -112,15 +194,147 @@ func WithExtraSigner(signerSessions ...tree.SignerSession) Option {
 	}
 }
 
+// WithFeeRate overrides the fee rate (in sats/vbyte) of the txs built
+// client-side. It applies to SendOptions and SettleOptions.
+func WithFeeRate(satsPerVByte float64) Option {
+	return func(o interface{}) error {
+		feeRate := chainfee.SatPerKVByte(math.Round(satsPerVByte * 1000))
+		if feeRate < minRelayFeeRate {
+			return fmt.Errorf(
+				"invalid fee rate %v sat/vbyte, must be at least the min relay fee rate %v sat/vbyte",
+				satsPerVByte, float64(minRelayFeeRate)/1000,
+			)
+		}
+
+		switch opts := o.(type) {
+		case *SettleOptions:
+			opts.FeeRate = feeRate
+		case *SendOptions:
+			opts.FeeRate = feeRate
+		default:
+			return fmt.Errorf("invalid options type")
+		}
+		return nil
+	}
+}
+
+// WithDenominations makes the offchain outputs of SendOffChain and Settle be
+// split into vtxos of the given standard amounts (eg. 100000, 10000, 1000 sats)
+// so that their amounts are less uniquely identifying. Every output is split
+// greedily, from the largest denomination to the smallest, and what's left is
+// kept as an extra vtxo, or added to the last one if below the min vtxo amount.
+// The sum of the outputs doesn't change.
+//
+// This is a tradeoff: every extra output makes the redeem tx bigger, hence more
+// expensive, and the wallet ends up with more vtxos, that cost more to spend or
+// to renew later. Few and large denominations keep both low.
+func WithDenominations(denominations ...uint64) Option {
+	return func(o interface{}) error {
+		if len(denominations) <= 0 {
+			return fmt.Errorf("missing denominations")
+		}
+
+		sorted := make([]uint64, 0, len(denominations))
+		for _, denomination := range denominations {
+			if denomination == 0 {
+				return fmt.Errorf("invalid denomination, must be greater than 0")
+			}
+			if !slices.Contains(sorted, denomination) {
+				sorted = append(sorted, denomination)
+			}
+		}
+		sort.Slice(sorted, func(i, j int) bool {
+			return sorted[i] > sorted[j]
+		})
+
+		switch opts := o.(type) {
+		case *SettleOptions:
+			opts.Denominations = sorted
+		case *SendOptions:
+			opts.Denominations = sorted
+		default:
+			return fmt.Errorf("invalid options type")
+		}
+		return nil
+	}
+}
+
+// WithIdempotencyKey submits the redeem tx of a send with the given key, so
+// that retrying the submission of the same tx, for example after a timeout,
+// returns its txid rather than a double spend error. It applies to
+// SendOptions only.
+func WithIdempotencyKey(key string) Option {
+	return func(o interface{}) error {
+		if len(key) <= 0 {
+			return fmt.Errorf("missing idempotency key")
+		}
+
+		opts, ok := o.(*SendOptions)
+		if !ok {
+			return fmt.Errorf("invalid options type")
+		}
+
+		opts.IdempotencyKey = key
+		return nil
+	}
+}
+
+// WithForfeitCosigner sets the signer of the vtxos whose forfeit closure
+// requires other keys than the owner and the server ones. It applies to
+// SendOptions and SettleOptions.
+func WithForfeitCosigner(cosigner ForfeitCosigner) Option {
+	return func(o interface{}) error {
+		if cosigner == nil {
+			return fmt.Errorf("missing forfeit cosigner")
+		}
+
+		switch opts := o.(type) {
+		case *SettleOptions:
+			opts.ForfeitCosigner = cosigner
+		case *SendOptions:
+			opts.ForfeitCosigner = cosigner
+		default:
+			return fmt.Errorf("invalid options type")
+		}
+		return nil
+	}
+}
+
+// dustLimit is the dust threshold of a taproot output at the default min relay
+// fee of 1 sat/vbyte, the same as the default dust of the server.
+const dustLimit = 330
+
+// DustLimit returns the min amount of an output, onchain or offchain, for the
+// given network. The server might require a greater amount, see the Dust field
+// of the client config.
+func DustLimit(_ common.Network) uint64 {
+	return dustLimit
+}
+
 type bitcoinReceiver struct {
 	to     string
 	amount uint64
 }
 
+// NewBitcoinReceiver returns a receiver of the given amount to the given
+// onchain or offchain address. Use Validate to check both up front.
 func NewBitcoinReceiver(to string, amount uint64) Receiver {
 	return bitcoinReceiver{to, amount}
 }
 
+// Validate returns ErrInvalidReceiverAddress if the address is not an onchain
+// or offchain address of the given network, or ErrDustAmount if the amount is
+// below DustLimit.
+func (r bitcoinReceiver) Validate(net common.Network) error {
+	if err := validateReceiverAddress(r.to, net); err != nil {
+		return err
+	}
+	if dust := DustLimit(net); r.amount < dust {
+		return fmt.Errorf("%w: amount %d, dust %d", ErrDustAmount, r.amount, dust)
+	}
+	return nil
+}
+
 func (r bitcoinReceiver) To() string {
 	return r.to
 }
@@ -134,6 +348,91 @@ func (r bitcoinReceiver) IsOnchain() bool {
 	return err == nil
 }
 
+type maxReceiver struct {
+	to string
+}
+
+// NewMaxReceiver returns a receiver of all the spendable offchain funds, minus
+// the amount of the other receivers and the fees. Only one max receiver is
+// allowed per SendOffChain.
+func NewMaxReceiver(to string) Receiver {
+	return maxReceiver{to}
+}
+
+func (r maxReceiver) To() string {
+	return r.to
+}
+
+// Amount is unknown until the inputs and the fees of the send are, therefore
+// it's always 0.
+func (r maxReceiver) Amount() uint64 {
+	return 0
+}
+
+func (r maxReceiver) IsOnchain() bool {
+	_, err := btcutil.DecodeAddress(r.to, nil)
+	return err == nil
+}
+
+// Validate returns ErrInvalidReceiverAddress if the address is not an onchain
+// or offchain address of the given network. The amount is validated once known.
+func (r maxReceiver) Validate(net common.Network) error {
+	return validateReceiverAddress(r.to, net)
+}
+
+func validateReceiverAddress(addr string, net common.Network) error {
+	netParams := utils.ToBitcoinNetwork(net)
+	if onchainAddr, err := btcutil.DecodeAddress(addr, &netParams); err == nil {
+		if !onchainAddr.IsForNet(&netParams) {
+			return fmt.Errorf(
+				"%w: %s is not a %s address", ErrInvalidReceiverAddress, addr, net.Name,
+			)
+		}
+		return nil
+	}
+
+	offchainAddr, err := common.DecodeAddress(addr)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidReceiverAddress, err)
+	}
+	if offchainAddr.HRP != net.Addr {
+		return fmt.Errorf(
+			"%w: %s is not a %s address", ErrInvalidReceiverAddress, addr, net.Name,
+		)
+	}
+	return nil
+}
+
+func isMaxReceiver(receiver Receiver) bool {
+	_, ok := receiver.(maxReceiver)
+	return ok
+}
+
+// checkBatchReceivers validates each of the given receivers and returns those
+// valid along with the outcome of every one. Only the first max receiver is
+// accepted since a send can have one at most.
+func checkBatchReceivers(
+	receivers []Receiver, validate func(Receiver) error,
+) ([]Receiver, []ReceiverResult) {
+	validReceivers := make([]Receiver, 0, len(receivers))
+	results := make([]ReceiverResult, 0, len(receivers))
+	hasMaxReceiver := false
+	for i, receiver := range receivers {
+		err := validate(receiver)
+		if err == nil && isMaxReceiver(receiver) {
+			if hasMaxReceiver {
+				err = fmt.Errorf("only one max receiver is allowed")
+			}
+			hasMaxReceiver = true
+		}
+		if err == nil {
+			validReceivers = append(validReceivers, receiver)
+		}
+		results = append(results, ReceiverResult{Index: i, Err: err})
+	}
+	return validReceivers, results
+}
+
 type covenantlessArkClient struct {
 	*arkClient
 }
@@ -286,6 +585,9 @@ func (a *covenantlessArkClient) Init(ctx context.Context, args InitArgs) error {
 	if err := a.init(ctx, args); err != nil {
 		return err
 	}
+	if args.CoinSelector != nil {
+		a.SetCoinSelector(args.CoinSelector)
+	}
 
 	if args.WithTransactionFeed {
 		txStreamCtx, txStreamCtxCancel := context.WithCancel(context.Background())
@@ -302,10 +604,32 @@ func (a *covenantlessArkClient) Init(ctx context.Context, args InitArgs) error {
 	return nil
 }
 
+// ImportEncryptedSeed initializes a fresh wallet with the seed of a backup made
+// with ExportEncryptedSeed. The password of the args is used both to decrypt
+// the backup and to encrypt the new wallet.
+func (a *covenantlessArkClient) ImportEncryptedSeed(
+	ctx context.Context, encryptedSeed string, args InitArgs,
+) error {
+	if len(args.Seed) > 0 {
+		return fmt.Errorf("seed must not be set when importing an encrypted seed")
+	}
+
+	seed, err := wallet.DecryptSeed(encryptedSeed, args.Password)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt seed: %s", err)
+	}
+	args.Seed = seed
+
+	return a.Init(ctx, args)
+}
+
 func (a *covenantlessArkClient) InitWithWallet(ctx context.Context, args InitWithWalletArgs) error {
 	if err := a.initWithWallet(ctx, args); err != nil {
 		return err
 	}
+	if args.CoinSelector != nil {
+		a.SetCoinSelector(args.CoinSelector)
+	}
 
 	if a.WithTransactionFeed {
 		txStreamCtx, txStreamCtxCancel := context.WithCancel(context.Background())
@@ -454,7 +778,7 @@ func (a *covenantlessArkClient) Balance(
 func (a *covenantlessArkClient) OnboardAgainAllExpiredBoardings(
 	ctx context.Context,
 ) (string, error) {
-	if err := a.safeCheck(); err != nil {
+	if err := a.signerCheck(); err != nil {
 		return "", err
 	}
 
@@ -473,7 +797,7 @@ func (a *covenantlessArkClient) OnboardAgainAllExpiredBoardings(
 func (a *covenantlessArkClient) WithdrawFromAllExpiredBoardings(
 	ctx context.Context, to string,
 ) (string, error) {
-	if err := a.safeCheck(); err != nil {
+	if err := a.signerCheck(); err != nil {
 		return "", err
 	}
 
@@ -487,60 +811,125 @@ func (a *covenantlessArkClient) WithdrawFromAllExpiredBoardings(
 func (a *covenantlessArkClient) SendOffChain(
 	ctx context.Context,
 	withExpiryCoinselect bool, receivers []Receiver,
-	withZeroFees bool,
+	withZeroFees bool, opts ...Option,
 ) (string, error) {
-	if err := a.safeCheck(); err != nil {
+	if err := a.signerCheck(); err != nil {
 		return "", err
 	}
-
-	if len(receivers) <= 0 {
-		return "", fmt.Errorf("missing receivers")
+	if err := a.ensureServerKey(ctx); err != nil {
+		return "", err
 	}
 
-	netParams := utils.ToBitcoinNetwork(a.Network)
-	for _, receiver := range receivers {
-		isOnchain, _, err := utils.ParseBitcoinAddress(receiver.To(), netParams)
-		if err != nil {
+	options := &SendOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
 			return "", err
 		}
-		if isOnchain {
-			return "", fmt.Errorf("all receiver addresses must be offchain addresses")
-		}
 	}
 
-	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
+	redeemTx, err := a.buildOffchainTx(
+		ctx, withExpiryCoinselect, receivers, withZeroFees, options,
+	)
 	if err != nil {
 		return "", err
 	}
 
-	expectedServerPubkey := schnorr.SerializePubKey(a.ServerPubKey)
+	signedRedeemTx, err := a.wallet.SignTransaction(ctx, a.explorer, redeemTx)
+	if err != nil {
+		return "", err
+	}
 
-	sumOfReceivers := uint64(0)
+	return a.submitOffchainTx(ctx, signedRedeemTx, options)
+}
 
-	for _, receiver := range receivers {
-		rcvAddr, err := common.DecodeAddress(receiver.To())
-		if err != nil {
-			return "", fmt.Errorf("invalid receiver address: %s", err)
+func (a *covenantlessArkClient) ExportUnsignedPSBT(
+	ctx context.Context,
+	withExpiryCoinselect bool, receivers []Receiver,
+	withZeroFees bool, opts ...Option,
+) (string, error) {
+	if err := a.safeCheck(); err != nil {
+		return "", err
+	}
+	if err := a.ensureServerKey(ctx); err != nil {
+		return "", err
+	}
+
+	options := &SendOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return "", err
 		}
+	}
 
-		rcvServerPubkey := schnorr.SerializePubKey(rcvAddr.Server)
+	return a.buildOffchainTx(
+		ctx, withExpiryCoinselect, receivers, withZeroFees, options,
+	)
+}
 
-		if !bytes.Equal(expectedServerPubkey, rcvServerPubkey) {
-			return "", fmt.Errorf("invalid receiver address '%s': expected server %s, got %s", receiver.To(), hex.EncodeToString(expectedServerPubkey), hex.EncodeToString(rcvServerPubkey))
-		}
+func (a *covenantlessArkClient) SubmitSignedPSBT(
+	ctx context.Context, signedTx string, opts ...Option,
+) (string, error) {
+	if err := a.safeCheck(); err != nil {
+		return "", err
+	}
+	if err := a.ensureServerKey(ctx); err != nil {
+		return "", err
+	}
 
-		if receiver.Amount() < a.Dust {
-			return "", fmt.Errorf("invalid amount (%d), must be greater than dust %d", receiver.Amount(), a.Dust)
+	options := &SendOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return "", err
 		}
+	}
+
+	if _, err := psbt.NewFromRawBytes(strings.NewReader(signedTx), true); err != nil {
+		return "", fmt.Errorf("invalid signed psbt: %s", err)
+	}
+
+	return a.submitOffchainTx(ctx, signedTx, options)
+}
 
+// buildOffchainTx selects the vtxos to spend and returns the unsigned redeem
+// tx paying the given receivers, with the witness utxo of every input set.
+func (a *covenantlessArkClient) buildOffchainTx(
+	ctx context.Context,
+	withExpiryCoinselect bool, receivers []Receiver,
+	withZeroFees bool, options *SendOptions,
+) (string, error) {
+	if len(receivers) <= 0 {
+		return "", fmt.Errorf("missing receivers")
+	}
+
+	maxReceiverIndex := -1
+	sumOfReceivers := uint64(0)
+	for i, receiver := range receivers {
+		if err := a.validateOffchainReceiver(receiver); err != nil {
+			return "", err
+		}
+		// the amount of the max receiver is known only once selected the coins
+		if isMaxReceiver(receiver) {
+			if maxReceiverIndex >= 0 {
+				return "", fmt.Errorf("only one max receiver is allowed")
+			}
+			maxReceiverIndex = i
+			continue
+		}
 		sumOfReceivers += receiver.Amount()
 	}
+	// don't modify the given list when appending the change or resolving the
+	// amount of the max receiver
+	receivers = append([]Receiver{}, receivers...)
+
+	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return "", err
+	}
 
 	vtxos := make([]client.TapscriptsVtxo, 0)
-	opts := &CoinSelectOptions{
+	spendableVtxos, err := a.getVtxos(ctx, &CoinSelectOptions{
 		WithExpirySorting: withExpiryCoinselect,
-	}
-	spendableVtxos, err := a.getVtxos(ctx, opts)
+	})
 	if err != nil {
 		return "", err
 	}
@@ -561,16 +950,38 @@ func (a *covenantlessArkClient) SendOffChain(
 		}
 	}
 
-	// do not include boarding utxos
-	_, selectedCoins, changeAmount, err := utils.CoinSelect(
-		nil, vtxos, sumOfReceivers, a.Dust, withExpiryCoinselect,
-	)
-	if err != nil {
+	var selectedCoins []client.TapscriptsVtxo
+	var changeAmount uint64
+	if maxReceiverIndex >= 0 {
+		// a send-all spends every vtxo and has no change
+		if len(vtxos) <= 0 {
+			return "", fmt.Errorf("no spendable vtxos")
+		}
+		selectedCoins = vtxos
+	} else {
+		// do not include boarding utxos
+		_, selectedCoins, changeAmount, err = a.selectCoins(
+			nil, vtxos, sumOfReceivers, withExpiryCoinselect,
+		)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// the local state might be stale, make sure the server didn't sweep the
+	// vtxos in the meantime
+	if err := a.ensureVtxosTreeNotSwept(ctx, selectedCoins); err != nil {
 		return "", err
 	}
 
 	if changeAmount > 0 {
-		receivers = append(receivers, NewBitcoinReceiver(offchainAddrs[0].Address, changeAmount))
+		if err := a.validateOutputAmount(changeAmount, false); err != nil {
+			return "", fmt.Errorf("invalid change: %w", err)
+		}
+		// the denominated change is added once known the fee to pay
+		if len(options.Denominations) <= 0 {
+			receivers = append(receivers, NewBitcoinReceiver(offchainAddrs[0].Address, changeAmount))
+		}
 	}
 
 	inputs := make([]redeemTxInput, 0, len(selectedCoins))
@@ -596,45 +1007,188 @@ func (a *covenantlessArkClient) SendOffChain(
 		})
 	}
 
-	feeRate := chainfee.FeePerKwFloor
-	redeemTx, err := buildRedeemTx(inputs, receivers, feeRate.FeePerVByte(), nil, withZeroFees)
-	if err != nil {
-		return "", err
-	}
-
-	signedRedeemTx, err := a.wallet.SignTransaction(ctx, a.explorer, redeemTx)
-	if err != nil {
-		return "", err
-	}
-
-	_, redeemTxid, err := a.client.SubmitRedeemTx(ctx, signedRedeemTx)
-	if err != nil {
-		return "", err
-	}
-
-	return redeemTxid, nil
-}
-
-func (a *covenantlessArkClient) RedeemNotes(ctx context.Context, notes []string, opts ...Option) (string, error) {
-	if err := a.safeCheck(); err != nil {
-		return "", err
+	feeRate := options.FeeRate
+	if feeRate <= 0 && !withZeroFees {
+		networkFeeRate, err := a.getNetworkFeeRate(redeemTxConfirmationTarget)
+		if err != nil {
+			return "", err
+		}
+		feeRate = networkFeeRate
 	}
 
-	amount := uint64(0)
+	if maxReceiverIndex >= 0 {
+		// The fee must be known before the amount of the max receiver, that is
+		// what's left of the inputs once paid the other receivers and the fee.
+		fee := int64(0)
+		if !withZeroFees {
+			ins, err := toVtxoInputs(inputs, nil)
+			if err != nil {
+				return "", err
+			}
+			fee, err = common.ComputeRedeemTxFee(feeRate, ins, len(receivers))
+			if err != nil {
+				return "", err
+			}
+		}
 
-	options := &SettleOptions{}
-	for _, opt := range opts {
-		if err := opt(options); err != nil {
-			return "", err
+		sumOfInputs := uint64(0)
+		for _, coin := range selectedCoins {
+			sumOfInputs += coin.Amount
 		}
-	}
 
-	for _, vStr := range notes {
-		v, err := note.NewFromString(vStr)
+		amount, err := sendAllAmount(sumOfInputs, sumOfReceivers, uint64(fee), a.Dust)
 		if err != nil {
 			return "", err
 		}
-		amount += uint64(v.Value)
+		if err := a.validateOutputAmount(amount, false); err != nil {
+			return "", err
+		}
+
+		receivers[maxReceiverIndex] = NewBitcoinReceiver(
+			receivers[maxReceiverIndex].To(), amount,
+		)
+		// the fee is already deducted from the amount of the max receiver
+		withZeroFees = true
+	}
+
+	if len(options.Denominations) > 0 {
+		minAmount := a.minChangeAmount()
+		denominatedReceivers := make([]Receiver, 0, len(receivers))
+		for i, receiver := range receivers {
+			// the fee paid by a send-all is computed for a single max receiver
+			if i == maxReceiverIndex {
+				denominatedReceivers = append(denominatedReceivers, receiver)
+				continue
+			}
+			for _, amount := range denominate(receiver.Amount(), options.Denominations, minAmount) {
+				if err := a.validateOutputAmount(amount, false); err != nil {
+					return "", err
+				}
+				denominatedReceivers = append(
+					denominatedReceivers, NewBitcoinReceiver(receiver.To(), amount),
+				)
+			}
+		}
+		receivers = denominatedReceivers
+
+		if changeAmount > 0 {
+			ins, err := toVtxoInputs(inputs, nil)
+			if err != nil {
+				return "", err
+			}
+			changeAmounts, err := denominateChange(
+				changeAmount, options.Denominations, minAmount,
+				func(numOfChangeOutputs int) (uint64, error) {
+					if withZeroFees {
+						return 0, nil
+					}
+					fee, err := common.ComputeRedeemTxFee(
+						feeRate, ins, len(receivers)+numOfChangeOutputs,
+					)
+					return uint64(fee), err
+				},
+			)
+			if err != nil {
+				return "", err
+			}
+			for _, amount := range changeAmounts {
+				if err := a.validateOutputAmount(amount, false); err != nil {
+					return "", fmt.Errorf("invalid change: %w", err)
+				}
+				receivers = append(
+					receivers, NewBitcoinReceiver(offchainAddrs[0].Address, amount),
+				)
+			}
+			// the fee is already deducted from the change
+			withZeroFees = true
+		}
+	}
+
+	return buildRedeemTx(inputs, receivers, feeRate, nil, withZeroFees)
+}
+
+// submitOffchainTx adds the missing signatures of the forfeit cosigners to the
+// given signed redeem tx and submits it to the server.
+func (a *covenantlessArkClient) submitOffchainTx(
+	ctx context.Context, signedRedeemTx string, options *SendOptions,
+) (string, error) {
+	signedRedeemTx, err := a.cosignForfeitTx(ctx, signedRedeemTx, options.ForfeitCosigner)
+	if err != nil {
+		return "", err
+	}
+
+	_, redeemTxid, err := a.client.SubmitRedeemTx(
+		ctx, signedRedeemTx, client.WithIdempotencyKey(options.IdempotencyKey),
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return redeemTxid, nil
+}
+
+func (a *covenantlessArkClient) SendOffChainBatch(
+	ctx context.Context, receivers []Receiver, opts BatchOpts,
+) (string, []ReceiverResult, error) {
+	if err := a.signerCheck(); err != nil {
+		return "", nil, err
+	}
+	if len(receivers) <= 0 {
+		return "", nil, fmt.Errorf("missing receivers")
+	}
+
+	validReceivers, results := checkBatchReceivers(receivers, a.validateOffchainReceiver)
+	if numOfInvalid := len(receivers) - len(validReceivers); numOfInvalid > 0 {
+		if !opts.PartialAllowed {
+			return "", results, fmt.Errorf(
+				"%d of %d receivers are invalid", numOfInvalid, len(receivers),
+			)
+		}
+		if len(validReceivers) <= 0 {
+			return "", results, fmt.Errorf("all receivers are invalid")
+		}
+	}
+
+	txid, err := a.SendOffChain(
+		ctx, opts.WithExpiryCoinselect, validReceivers, opts.WithZeroFees,
+		opts.SendOpts...,
+	)
+	if err != nil {
+		return "", results, err
+	}
+
+	for i := range results {
+		results[i].Included = results[i].Err == nil
+	}
+	return txid, results, nil
+}
+
+func (a *covenantlessArkClient) RedeemNotes(ctx context.Context, notes []string, opts ...Option) (string, error) {
+	if err := a.signerCheck(); err != nil {
+		return "", err
+	}
+	if err := a.ensureServerKey(ctx); err != nil {
+		return "", err
+	}
+
+	amount := uint64(0)
+
+	options := &SettleOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return "", err
+		}
+	}
+
+	for _, vStr := range notes {
+		v, err := note.NewFromString(vStr)
+		if err != nil {
+			return "", err
+		}
+		if err := v.Verify(time.Now()); err != nil {
+			return "", err
+		}
+		amount += uint64(v.Value)
 	}
 
 	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
@@ -654,7 +1208,7 @@ func (a *covenantlessArkClient) RedeemNotes(ctx context.Context, notes []string,
 }
 
 func (a *covenantlessArkClient) StartUnilateralExit(ctx context.Context) error {
-	if err := a.safeCheck(); err != nil {
+	if err := a.signerCheck(); err != nil {
 		return err
 	}
 
@@ -668,15 +1222,137 @@ func (a *covenantlessArkClient) StartUnilateralExit(ctx context.Context) error {
 		totalVtxosAmount += vtxo.Amount
 	}
 
-	// transactionsMap avoid duplicates
-	transactionsMap := make(map[string]struct{}, 0)
-	transactions := make([]string, 0)
+	redeemBranches, err := a.getRedeemBranches(ctx, vtxos)
+	if err != nil {
+		return err
+	}
+
+	return a.broadcastRedeemBranches(redeemBranches)
+}
+
+// RedeemOutpoints unilaterally exits only the given vtxos, by broadcasting the
+// txs of their branches of the vtxo tree. The other vtxos of the wallet stay in
+// the Ark, but those sharing the offchain part of the branches would be
+// partially unrolled as well, so unless forced it fails in that case.
+func (a *covenantlessArkClient) RedeemOutpoints(
+	ctx context.Context, outpoints []client.Outpoint, force bool,
+) error {
+	if err := a.signerCheck(); err != nil {
+		return err
+	}
+	if len(outpoints) <= 0 {
+		return fmt.Errorf("missing outpoints")
+	}
+
+	spendableVtxos, _, err := a.ListVtxos(ctx)
+	if err != nil {
+		return err
+	}
+
+	vtxos := make([]client.Vtxo, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		vtxo, ok := findVtxo(spendableVtxos, outpoint)
+		if !ok {
+			return fmt.Errorf("%s is not a spendable vtxo of the wallet", outpoint)
+		}
+		if vtxo.RedeemTx != "" {
+			return fmt.Errorf(
+				"vtxo %s is not settled in a round yet, it can't be unilaterally redeemed",
+				outpoint,
+			)
+		}
+		if _, ok := findVtxo(vtxos, outpoint); !ok {
+			vtxos = append(vtxos, vtxo)
+		}
+	}
 
 	redeemBranches, err := a.getRedeemBranches(ctx, vtxos)
 	if err != nil {
 		return err
 	}
 
+	if !force {
+		unrolled, err := a.getUnrolledVtxos(ctx, spendableVtxos, vtxos, redeemBranches)
+		if err != nil {
+			return err
+		}
+		if len(unrolled) > 0 {
+			return fmt.Errorf(
+				"redeeming the given vtxos would also unroll part of the tree of "+
+					"vtxos %s, use force to proceed", strings.Join(unrolled, ", "),
+			)
+		}
+	}
+
+	return a.broadcastRedeemBranches(redeemBranches)
+}
+
+// getUnrolledVtxos returns the other vtxos of the wallet whose branch shares
+// some offchain txs with the given redeem branches.
+func (a *covenantlessArkClient) getUnrolledVtxos(
+	ctx context.Context, spendableVtxos, redeemedVtxos []client.Vtxo,
+	redeemBranches map[string]*redemption.CovenantlessRedeemBranch,
+) ([]string, error) {
+	redeemedTxids := make(map[string]struct{})
+	rounds := make(map[string]struct{})
+	for _, vtxo := range redeemedVtxos {
+		rounds[vtxo.RoundTxid] = struct{}{}
+	}
+	for _, branch := range redeemBranches {
+		offchainPath, err := branch.OffchainPath()
+		if err != nil {
+			return nil, err
+		}
+		for _, ptx := range offchainPath {
+			redeemedTxids[ptx.UnsignedTx.TxHash().String()] = struct{}{}
+		}
+	}
+
+	// only the vtxos of the same rounds can share txs with the redeemed ones
+	otherVtxos := make([]client.Vtxo, 0)
+	for _, vtxo := range spendableVtxos {
+		if _, ok := rounds[vtxo.RoundTxid]; !ok || vtxo.RedeemTx != "" {
+			continue
+		}
+		if _, ok := findVtxo(redeemedVtxos, vtxo.Outpoint); ok {
+			continue
+		}
+		otherVtxos = append(otherVtxos, vtxo)
+	}
+
+	unrolled := make([]string, 0)
+	for _, vtxo := range otherVtxos {
+		branch, err := a.client.GetRoundTreeBranch(ctx, vtxo.RoundTxid, vtxo.Outpoint)
+		if err != nil {
+			return nil, err
+		}
+		if branchIncludesAny(branch, redeemedTxids) {
+			unrolled = append(unrolled, vtxo.Outpoint.String())
+		}
+	}
+	return unrolled, nil
+}
+
+func branchIncludesAny(branch tree.TxTree, txids map[string]struct{}) bool {
+	for _, level := range branch {
+		for _, node := range level {
+			if _, ok := txids[node.Txid]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// broadcastRedeemBranches broadcasts the offchain txs of the given branches,
+// parents first.
+func (a *covenantlessArkClient) broadcastRedeemBranches(
+	redeemBranches map[string]*redemption.CovenantlessRedeemBranch,
+) error {
+	// transactionsMap avoid duplicates
+	transactionsMap := make(map[string]struct{}, 0)
+	transactions := make([]string, 0)
+
 	for _, branch := range redeemBranches {
 		branchTxs, err := branch.RedeemPath()
 		if err != nil {
@@ -712,18 +1388,76 @@ func (a *covenantlessArkClient) StartUnilateralExit(ctx context.Context) error {
 	return nil
 }
 
+// CompleteUnilateralExit sweeps to the given onchain address the outputs of
+// the unilateral exit whose timelock has expired, and returns the ones still
+// locked that couldn't be spent yet.
 func (a *covenantlessArkClient) CompleteUnilateralExit(
-	ctx context.Context, to string,
-) (string, error) {
-	if err := a.safeCheck(); err != nil {
-		return "", err
+	ctx context.Context, to string, opts ...Option,
+) (string, []types.Utxo, error) {
+	if err := a.signerCheck(); err != nil {
+		return "", nil, err
+	}
+
+	options := &SendOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return "", nil, err
+		}
 	}
 
 	if _, err := btcutil.DecodeAddress(to, nil); err != nil {
-		return "", fmt.Errorf("invalid receiver address '%s': must be onchain", to)
+		return "", nil, fmt.Errorf("invalid receiver address '%s': must be onchain", to)
 	}
 
-	return a.completeUnilateralExit(ctx, to)
+	return a.completeUnilateralExit(ctx, to, options.FeeRate)
+}
+
+// EstimateExitCost estimates the network fees to pay to complete the
+// unilateral exit of all spendable vtxos, based on the fee rates of the given
+// time window.
+func (a *covenantlessArkClient) EstimateExitCost(
+	ctx context.Context, window time.Duration,
+) (*ExitCost, error) {
+	if err := a.safeCheck(); err != nil {
+		return nil, err
+	}
+
+	vtxos, err := a.getVtxos(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(vtxos) <= 0 {
+		return nil, fmt.Errorf("no vtxos to exit")
+	}
+
+	feeHistory, err := a.explorer.GetFeeHistory(window)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee history: %s", err)
+	}
+
+	// the tx spending the vtxos once unrolled, it has one input per vtxo and
+	// a single taproot output
+	tx := wire.NewMsgTx(2)
+	amount := uint64(0)
+	for _, vtxo := range vtxos {
+		amount += vtxo.Amount
+		tx.AddTxIn(&wire.TxIn{})
+	}
+	tx.AddTxOut(&wire.TxOut{PkScript: make([]byte, 34)})
+	size := float64(tx.SerializeSize())
+
+	feeAmount := func(feeRate float64) uint64 {
+		return uint64(math.Ceil(size*feeRate) + 50)
+	}
+
+	return &ExitCost{
+		NumOfVtxos: len(vtxos),
+		Amount:     amount,
+		BestFee:    feeAmount(feeHistory.Best),
+		TypicalFee: feeAmount(feeHistory.Typical),
+		WorstFee:   feeAmount(feeHistory.Worst),
+		Estimated:  feeHistory.Estimated,
+	}, nil
 }
 
 func (a *covenantlessArkClient) CollaborativeExit(
@@ -731,7 +1465,10 @@ func (a *covenantlessArkClient) CollaborativeExit(
 	addr string, amount uint64, withExpiryCoinselect bool,
 	opts ...Option,
 ) (string, error) {
-	if err := a.safeCheck(); err != nil {
+	if err := a.signerCheck(); err != nil {
+		return "", err
+	}
+	if err := a.ensureServerKey(ctx); err != nil {
 		return "", err
 	}
 
@@ -751,6 +1488,10 @@ func (a *covenantlessArkClient) CollaborativeExit(
 		return "", fmt.Errorf("invalid onchain address")
 	}
 
+	if err := a.validateOutputAmount(amount, true); err != nil {
+		return "", err
+	}
+
 	receivers := []client.Output{
 		{
 			Address: addr,
@@ -763,6 +1504,20 @@ func (a *covenantlessArkClient) CollaborativeExit(
 		return "", err
 	}
 
+	exitAmount, changeAmount, err := splitExitChange(
+		amount, changeAmount, a.minChangeAmount(), options.AbsorbDustChange,
+	)
+	if err != nil {
+		return "", err
+	}
+	if exitAmount != amount {
+		log.Infof(
+			"change of %d sats below dust absorbed into the onchain output, exiting %d sats",
+			exitAmount-amount, exitAmount,
+		)
+		receivers[0].Amount = exitAmount
+	}
+
 	if changeAmount > 0 {
 		offchainAddr, _, err := a.wallet.NewAddress(ctx, true)
 		if err != nil {
@@ -775,15 +1530,472 @@ func (a *covenantlessArkClient) CollaborativeExit(
 		})
 	}
 
+	if err := a.validateSettleOutputs(receivers); err != nil {
+		return "", err
+	}
+
 	return a.joinRoundWithRetry(ctx, nil, receivers, *options, vtxos, boardingUtxos)
 }
 
 func (a *covenantlessArkClient) Settle(ctx context.Context, opts ...Option) (string, error) {
+	if err := a.signerCheck(); err != nil {
+		return "", err
+	}
+	if err := a.ensureServerKey(ctx); err != nil {
+		return "", err
+	}
+
+	return a.sendOffchain(ctx, false, nil, opts...)
+}
+
+// SettleWithProgress is like Settle but notifies the given callback of every
+// stage of the settlement as soon as it's reached. The stages of the vtxo tree
+// signing are skipped if the settlement has no offchain output, and may be
+// reported again if the server restarts the signing session or if the round
+// fails and the settlement is retried. The callback runs on the goroutine
+// handling the round events, therefore it must not block.
+func (a *covenantlessArkClient) SettleWithProgress(
+	ctx context.Context, progress func(SettleStage), opts ...Option,
+) (string, error) {
+	if progress == nil {
+		return "", fmt.Errorf("missing progress callback")
+	}
+
+	opts = append(opts, func(o interface{}) error {
+		options, ok := o.(*SettleOptions)
+		if !ok {
+			return fmt.Errorf("invalid options type")
+		}
+		options.Progress = progress
+		return nil
+	})
+	return a.Settle(ctx, opts...)
+}
+
+// PreviewSettle returns the inputs and outputs that Settle would register with
+// the given options, without registering any tx request with the server.
+func (a *covenantlessArkClient) PreviewSettle(
+	ctx context.Context, opts ...Option,
+) (*SettlePreview, error) {
+	if err := a.safeCheck(); err != nil {
+		return nil, err
+	}
+	if err := a.ensureServerKey(ctx); err != nil {
+		return nil, err
+	}
+
+	options := &SettleOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	boardingUtxos, vtxos, outputs, err := a.prepareSettle(ctx, false, true, nil, *options)
+	if err != nil {
+		return nil, err
+	}
+
+	// the actual fee rate of the forfeit txs is known only once the round
+	// starts, the server min relay fee rate is at least the network one
+	feeRate := minRelayFeeRate
+	if options.FeeRate > 0 {
+		feeRate = options.FeeRate
+	}
+	forfeitFees, err := a.estimateForfeitTxsFee(vtxos, feeRate)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := uint64(0)
+	for _, utxo := range boardingUtxos {
+		amount += utxo.Amount
+	}
+	for _, vtxo := range vtxos {
+		amount += vtxo.Amount
+	}
+
+	return &SettlePreview{
+		Vtxos:         vtxos,
+		BoardingUtxos: boardingUtxos,
+		Outputs:       outputs,
+		Amount:        amount,
+		ForfeitFees:   forfeitFees,
+	}, nil
+}
+
+// AmendSettle replaces the receivers of the pending tx request with the given
+// id, before it's selected for a round. Their sum must match the one of the
+// inputs of the request, the cosigners registered with it are kept.
+func (a *covenantlessArkClient) AmendSettle(
+	ctx context.Context, requestId string, receivers []Receiver,
+) error {
 	if err := a.safeCheck(); err != nil {
+		return err
+	}
+	if len(receivers) <= 0 {
+		return fmt.Errorf("missing receivers")
+	}
+
+	outputs := make([]client.Output, 0, len(receivers))
+	for _, receiver := range receivers {
+		outputs = append(outputs, client.Output{
+			Address: receiver.To(),
+			Amount:  receiver.Amount(),
+		})
+	}
+	if err := a.validateSettleOutputs(outputs); err != nil {
+		return err
+	}
+
+	return a.client.UpdateTxRequest(ctx, requestId, outputs, nil)
+}
+
+// MigrateServerKey updates the wallet config with the pubkey currently
+// reported by the server, so that new addresses are derived with it, and
+// settles the spendable vtxos and boarding utxos locked to the old key into a
+// new address of the new one. If the settlement fails, the old key is restored
+// so that those funds are still tracked and the migration can be retried. The
+// migration is refused if the wallet owns expired boarding utxos locked to the
+// old key, they must be withdrawn first.
+func (a *covenantlessArkClient) MigrateServerKey(ctx context.Context) error {
+	if err := a.signerCheck(); err != nil {
+		return err
+	}
+
+	serverPubkey, info, err := a.getServerPubkey(ctx)
+	if err != nil {
+		return err
+	}
+	if serverPubkey.IsEqual(a.ServerPubKey) {
+		return nil
+	}
+	if info.Network != a.Network.Name {
+		return fmt.Errorf(
+			"server network changed from %s to %s, cannot migrate", a.Network.Name, info.Network,
+		)
+	}
+
+	// the funds locked to the old key must be selected before switching to the
+	// new one, the wallet doesn't derive the old addresses anymore after it
+	boardingUtxos, vtxos, _, err := a.selectFunds(ctx, false, false, 0)
+	if err != nil {
+		return err
+	}
+	allBoardingUtxos, _, err := a.getAllBoardingUtxos(ctx)
+	if err != nil {
+		return err
+	}
+	numOfExpiredBoardingUtxos := 0
+	for _, utxo := range allBoardingUtxos {
+		if !utxo.Spent {
+			numOfExpiredBoardingUtxos++
+		}
+	}
+	numOfExpiredBoardingUtxos -= len(boardingUtxos)
+	if numOfExpiredBoardingUtxos > 0 {
+		return fmt.Errorf(
+			"cannot migrate to the new server key, %d expired boarding utxos are "+
+				"locked to the old one and must be withdrawn first",
+			numOfExpiredBoardingUtxos,
+		)
+	}
+
+	// Keep track of the addresses derived with the old key before they're
+	// replaced by the new ones.
+	if err := a.storeAddresses(ctx); err != nil {
+		return err
+	}
+
+	oldCfgData := a.Config
+	cfgData := *a.Config
+	cfgData.ServerPubKey = serverPubkey
+	cfgData.ForfeitAddress = info.ForfeitAddress
+	cfgData.BoardingDescriptorTemplate = info.BoardingDescriptorTemplate
+	if err := a.store.ConfigStore().AddData(ctx, cfgData); err != nil {
+		return fmt.Errorf("failed to update config: %s", err)
+	}
+	a.Config = &cfgData
+
+	if len(boardingUtxos) > 0 || len(vtxos) > 0 {
+		roundTxid, err := a.settleIntoServerKey(ctx, boardingUtxos, vtxos)
+		if err != nil {
+			if err := a.store.ConfigStore().AddData(ctx, *oldCfgData); err != nil {
+				log.WithError(err).Warn("failed to restore the old server key")
+			}
+			a.Config = oldCfgData
+			return fmt.Errorf("failed to settle funds into the new server key: %s", err)
+		}
+		log.Infof(
+			"settled %d vtxos and %d boarding utxos into the new server key in round %s",
+			len(vtxos), len(boardingUtxos), roundTxid,
+		)
+	}
+
+	log.Infof(
+		"migrated to new server pubkey %x", serverPubkey.SerializeCompressed(),
+	)
+	return nil
+}
+
+// settleIntoServerKey self sends the given boarding utxos and vtxos to a new
+// offchain address, derived with the server key of the current config.
+func (a *covenantlessArkClient) settleIntoServerKey(
+	ctx context.Context, boardingUtxos []types.Utxo, vtxos []client.TapscriptsVtxo,
+) (string, error) {
+	offchainAddr, _, err := a.wallet.NewAddress(ctx, false)
+	if err != nil {
 		return "", err
 	}
 
-	return a.sendOffchain(ctx, false, nil, opts...)
+	amount := uint64(0)
+	for _, utxo := range boardingUtxos {
+		amount += utxo.Amount
+	}
+	for _, vtxo := range vtxos {
+		amount += vtxo.Amount
+	}
+	outputs := []client.Output{{Address: offchainAddr.Address, Amount: amount}}
+
+	return a.joinRoundWithRetry(ctx, nil, outputs, SettleOptions{}, vtxos, boardingUtxos)
+}
+
+// RecoverAll recovers all the swept vtxos of the wallet in batches of at most
+// maxVtxosPerRecoveryRound vtxos, one round per batch. Every recovered batch
+// is checkpointed in the app data store, if interrupted, calling RecoverAll
+// again skips the vtxos already recovered and continues with the others.
+// The aggregate progress is sent to progressCh, if any, after every batch.
+// It returns the txids of the rounds joined by this invocation.
+func (a *covenantlessArkClient) RecoverAll(
+	ctx context.Context, progressCh chan<- RecoveryProgress, opts ...Option,
+) ([]string, error) {
+	if err := a.signerCheck(); err != nil {
+		return nil, err
+	}
+	if err := a.ensureServerKey(ctx); err != nil {
+		return nil, err
+	}
+
+	recoveryStore := a.store.RecoveryStore()
+	if recoveryStore == nil {
+		return nil, fmt.Errorf("recovery requires an app data store")
+	}
+
+	options := &SettleOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return nil, err
+		}
+	}
+
+	checkpoint, err := recoveryStore.GetRecoveredVtxos(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recovery checkpoint: %s", err)
+	}
+
+	progress := RecoveryProgress{}
+	recovered := make(map[string]struct{})
+	for _, vtxo := range checkpoint {
+		recovered[vtxo.String()] = struct{}{}
+		progress.TotalVtxos++
+		progress.RecoveredVtxos++
+		progress.TotalAmount += vtxo.Amount
+		progress.RecoveredAmount += vtxo.Amount
+		progress.RoundTxid = vtxo.RoundTxid
+	}
+
+	recoverableVtxos, err := a.getRecoverableVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vtxos := make([]client.TapscriptsVtxo, 0, len(recoverableVtxos))
+	for _, vtxo := range recoverableVtxos {
+		key := types.VtxoKey{Txid: vtxo.Txid, VOut: vtxo.VOut}
+		if _, ok := recovered[key.String()]; ok {
+			continue
+		}
+		vtxos = append(vtxos, vtxo)
+		progress.TotalVtxos++
+		progress.TotalAmount += vtxo.Amount
+	}
+
+	if len(vtxos) <= 0 {
+		if err := recoveryStore.Clean(ctx); err != nil {
+			log.WithError(err).Warn("failed to clean recovery checkpoint")
+		}
+		return nil, nil
+	}
+
+	if len(checkpoint) > 0 {
+		log.Infof(
+			"resuming recovery, %d/%d vtxos already recovered",
+			progress.RecoveredVtxos, progress.TotalVtxos,
+		)
+	}
+	if err := sendRecoveryProgress(ctx, progressCh, progress); err != nil {
+		return nil, err
+	}
+
+	offchainAddr, _, err := a.wallet.NewAddress(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	roundTxids := make([]string, 0)
+	for start := 0; start < len(vtxos); start += maxVtxosPerRecoveryRound {
+		end := min(start+maxVtxosPerRecoveryRound, len(vtxos))
+		batch := vtxos[start:end]
+
+		amount := uint64(0)
+		for _, vtxo := range batch {
+			amount += vtxo.Amount
+		}
+		outputs := []client.Output{{Address: offchainAddr.Address, Amount: amount}}
+		if err := a.validateSettleOutputs(outputs); err != nil {
+			return roundTxids, err
+		}
+
+		roundTxid, err := a.joinRoundWithRetry(ctx, nil, outputs, *options, batch, nil)
+		if err != nil {
+			return roundTxids, fmt.Errorf(
+				"failed to recover vtxos (%d/%d done), retry to resume: %w",
+				progress.RecoveredVtxos, progress.TotalVtxos, err,
+			)
+		}
+		roundTxids = append(roundTxids, roundTxid)
+
+		now := time.Now()
+		recoveredVtxos := make([]types.RecoveredVtxo, 0, len(batch))
+		for _, vtxo := range batch {
+			recoveredVtxos = append(recoveredVtxos, types.RecoveredVtxo{
+				VtxoKey:   types.VtxoKey{Txid: vtxo.Txid, VOut: vtxo.VOut},
+				Amount:    vtxo.Amount,
+				RoundTxid: roundTxid,
+				CreatedAt: now,
+			})
+		}
+		if _, err := recoveryStore.AddRecoveredVtxos(ctx, recoveredVtxos); err != nil {
+			return roundTxids, fmt.Errorf("failed to checkpoint recovered vtxos: %s", err)
+		}
+
+		progress.RecoveredVtxos += len(batch)
+		progress.RecoveredAmount += amount
+		progress.RoundTxid = roundTxid
+		log.Infof(
+			"recovered %d/%d vtxos in round %s",
+			progress.RecoveredVtxos, progress.TotalVtxos, roundTxid,
+		)
+		if err := sendRecoveryProgress(ctx, progressCh, progress); err != nil {
+			return roundTxids, err
+		}
+	}
+
+	// the recovery is completed, the checkpoint is not needed anymore
+	if err := recoveryStore.Clean(ctx); err != nil {
+		log.WithError(err).Warn("failed to clean recovery checkpoint")
+	}
+
+	return roundTxids, nil
+}
+
+// ListRecoverable returns the swept vtxos of the wallet that can be recovered
+// by settling them as recovered inputs of a round, like RecoverAll does,
+// sorted by amount in descending order.
+func (a *covenantlessArkClient) ListRecoverable(
+	ctx context.Context,
+) ([]RecoverableVtxo, error) {
+	if err := a.safeCheck(); err != nil {
+		return nil, err
+	}
+
+	vtxos, err := a.getRecoverableVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recoverableVtxos := make([]RecoverableVtxo, 0, len(vtxos))
+	for _, vtxo := range vtxos {
+		recoverableVtxos = append(recoverableVtxos, RecoverableVtxo{
+			Outpoint:  vtxo.Outpoint,
+			Amount:    vtxo.Amount,
+			RoundTxid: vtxo.RoundTxid,
+			ExpiredAt: vtxo.ExpiresAt,
+		})
+	}
+	sort.SliceStable(recoverableVtxos, func(i, j int) bool {
+		return recoverableVtxos[i].Amount > recoverableVtxos[j].Amount
+	})
+	return recoverableVtxos, nil
+}
+
+// ProveVtxoOwnership returns a proof that the wallet holds the key to spend
+// the given vtxo, either spent or not, that anyone can check with
+// common.VerifyVtxoOwnershipProof without learning the key. The proof is a
+// BIP322 signature, bound to the vtxo, made with the exit path of its script.
+func (a *covenantlessArkClient) ProveVtxoOwnership(
+	ctx context.Context, vtxoKey client.Outpoint,
+) (*common.VtxoOwnershipProof, error) {
+	if err := a.signerCheck(); err != nil {
+		return nil, err
+	}
+
+	spendableVtxos, spentVtxos, err := a.ListVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+	vtxos := filterByOutpoints(
+		append(spendableVtxos, spentVtxos...), []client.Outpoint{vtxoKey},
+	)
+	if len(vtxos) <= 0 {
+		return nil, fmt.Errorf("vtxo %s not found", vtxoKey)
+	}
+	vtxo := vtxos[0]
+
+	vtxoAddr, err := vtxo.Address(a.ServerPubKey, a.Network)
+	if err != nil {
+		return nil, err
+	}
+
+	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tapscripts []string
+	for _, offchainAddr := range offchainAddrs {
+		if offchainAddr.Address == vtxoAddr {
+			tapscripts = offchainAddr.Tapscripts
+			break
+		}
+	}
+	if len(tapscripts) <= 0 {
+		return nil, fmt.Errorf("vtxo %s not owned by the wallet", vtxoKey)
+	}
+
+	inputs, exitLeaves, _, err := toBIP322Inputs(
+		nil, []client.TapscriptsVtxo{{Vtxo: vtxo, Tapscripts: tapscripts}},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	message := common.VtxoOwnershipMessage(*inputs[0].OutPoint)
+	proof, err := bip322.New(message, inputs, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sig, err := a.signBIP322Proof(ctx, proof, exitLeaves)
+	if err != nil {
+		return nil, err
+	}
+
+	return &common.VtxoOwnershipProof{
+		Signature: sig,
+		PkScript:  inputs[0].WitnessUtxo.PkScript,
+		Amount:    vtxo.Amount,
+	}, nil
 }
 
 func (a *covenantlessArkClient) GetTransactionHistory(
@@ -1177,16 +2389,32 @@ func (a *covenantlessArkClient) sendExpiredBoardingUtxos(
 	if err != nil {
 		return "", err
 	}
+	if len(utxos) <= 0 {
+		return "", fmt.Errorf("no expired boarding funds available")
+	}
+
+	feeRate, err := a.explorer.GetFeeRateForTarget(onchainTxConfirmationTarget)
+	if err != nil {
+		return "", err
+	}
 
+	return a.sendBoardingUtxos(ctx, pkscript, utxos, feeRate, 0)
+}
+
+// sendBoardingUtxos sends the given boarding utxos to the given script, at the
+// given fee rate in sats/vbyte, and broadcasts the tx. The fee must be greater
+// than minFeeAmount. The inputs spend the exit path of the boarding utxos,
+// their CSV sequence makes the tx signal RBF so that it can be replaced with
+// ReplaceBoardingTx.
+func (a *covenantlessArkClient) sendBoardingUtxos(
+	ctx context.Context, pkscript []byte, utxos []types.Utxo,
+	feeRate float64, minFeeAmount uint64,
+) (string, error) {
 	targetAmount := uint64(0)
 	for _, u := range utxos {
 		targetAmount += u.Amount
 	}
 
-	if targetAmount == 0 {
-		return "", fmt.Errorf("no expired boarding funds available")
-	}
-
 	ptx, err := psbt.New(nil, nil, 2, 0, nil)
 	if err != nil {
 		return "", err
@@ -1206,15 +2434,20 @@ func (a *covenantlessArkClient) sendExpiredBoardingUtxos(
 	if err := a.addInputs(ctx, updater, utxos); err != nil {
 		return "", err
 	}
+	if !signalsRBF(updater.Upsbt.UnsignedTx) {
+		return "", fmt.Errorf("boarding tx does not signal rbf")
+	}
 
 	size := updater.Upsbt.UnsignedTx.SerializeSize()
-	feeRate, err := a.explorer.GetFeeRate()
-	if err != nil {
-		return "", err
-	}
 	feeAmount := uint64(math.Ceil(float64(size)*feeRate) + 50)
+	if feeAmount <= minFeeAmount {
+		return "", fmt.Errorf(
+			"fee %d sats must be greater than %d sats, increase the fee rate",
+			feeAmount, minFeeAmount,
+		)
+	}
 
-	if targetAmount-feeAmount <= a.Dust {
+	if targetAmount <= feeAmount || targetAmount-feeAmount <= a.Dust {
 		return "", fmt.Errorf("not enough funds to cover network fees")
 	}
 
@@ -1238,26 +2471,35 @@ func (a *covenantlessArkClient) sendExpiredBoardingUtxos(
 		}
 	}
 
-	return ptx.B64Encode()
+	tx, err := psbt.Extract(ptx)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", err
+	}
+
+	return a.explorer.Broadcast(hex.EncodeToString(buf.Bytes()))
 }
 
 func (a *covenantlessArkClient) completeUnilateralExit(
-	ctx context.Context, to string,
-) (string, error) {
+	ctx context.Context, to string, customFeeRate chainfee.SatPerKVByte,
+) (string, []types.Utxo, error) {
 	netParams := utils.ToBitcoinNetwork(a.Network)
 	rcvAddr, err := btcutil.DecodeAddress(to, &netParams)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	pkscript, err := txscript.PayToAddrScript(rcvAddr)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
-	utxos, err := a.getMatureUtxos(ctx)
+	utxos, lockedUtxos, err := a.getExitUtxos(ctx)
 	if err != nil {
-		return "", err
+		return "", nil, err
 	}
 
 	targetAmount := uint64(0)
@@ -1266,17 +2508,17 @@ func (a *covenantlessArkClient) completeUnilateralExit(
 	}
 
 	if targetAmount == 0 {
-		return "", fmt.Errorf("no mature funds available")
+		return "", lockedUtxos, fmt.Errorf("no mature funds available")
 	}
 
 	ptx, err := psbt.New(nil, nil, 2, 0, nil)
 	if err != nil {
-		return "", err
+		return "", lockedUtxos, err
 	}
 
 	updater, err := psbt.NewUpdater(ptx)
 	if err != nil {
-		return "", err
+		return "", lockedUtxos, err
 	}
 
 	updater.Upsbt.UnsignedTx.AddTxOut(&wire.TxOut{
@@ -1286,19 +2528,22 @@ func (a *covenantlessArkClient) completeUnilateralExit(
 	updater.Upsbt.Outputs = append(updater.Upsbt.Outputs, psbt.POutput{})
 
 	if err := a.addInputs(ctx, updater, utxos); err != nil {
-		return "", err
+		return "", lockedUtxos, err
 	}
 
 	size := updater.Upsbt.UnsignedTx.SerializeSize()
-	feeRate, err := a.explorer.GetFeeRate()
-	if err != nil {
-		return "", err
+	feeRate := float64(customFeeRate) / 1000
+	if customFeeRate <= 0 {
+		feeRate, err = a.explorer.GetFeeRateForTarget(onchainTxConfirmationTarget)
+		if err != nil {
+			return "", lockedUtxos, err
+		}
 	}
 
 	feeAmount := uint64(math.Ceil(float64(size)*feeRate) + 50)
 
-	if targetAmount-feeAmount <= a.Dust {
-		return "", fmt.Errorf("not enough funds to cover network fees")
+	if targetAmount <= feeAmount || targetAmount-feeAmount <= a.Dust {
+		return "", lockedUtxos, fmt.Errorf("not enough funds to cover network fees")
 	}
 
 	updater.Upsbt.UnsignedTx.TxOut[0].Value -= int64(feeAmount)
@@ -1307,21 +2552,35 @@ func (a *covenantlessArkClient) completeUnilateralExit(
 
 	signedTx, err := a.wallet.SignTransaction(ctx, a.explorer, unsignedTx)
 	if err != nil {
-		return "", err
+		return "", lockedUtxos, err
 	}
 
 	ptx, err = psbt.NewFromRawBytes(strings.NewReader(signedTx), true)
 	if err != nil {
-		return "", err
+		return "", lockedUtxos, err
 	}
 
 	for i := range ptx.Inputs {
 		if err := psbt.Finalize(ptx, i); err != nil {
-			return "", err
+			return "", lockedUtxos, err
 		}
 	}
 
-	return ptx.B64Encode()
+	tx, err := psbt.Extract(ptx)
+	if err != nil {
+		return "", lockedUtxos, err
+	}
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", lockedUtxos, err
+	}
+
+	txid, err := a.explorer.Broadcast(hex.EncodeToString(buf.Bytes()))
+	if err != nil {
+		return "", lockedUtxos, err
+	}
+
+	return txid, lockedUtxos, nil
 }
 
 func (a *covenantlessArkClient) selectFunds(
@@ -1388,9 +2647,7 @@ func (a *covenantlessArkClient) selectFunds(
 		return selectedBoardingCoins, selectedCoins, 0, nil
 	}
 
-	return utils.CoinSelect(
-		boardingUtxos, vtxos, amount, a.Dust, withExpiryCoinselect,
-	)
+	return a.selectCoins(boardingUtxos, vtxos, amount, withExpiryCoinselect)
 }
 
 func (a *covenantlessArkClient) sendOffchain(
@@ -1410,6 +2667,27 @@ func (a *covenantlessArkClient) sendOffchain(
 		return "", fmt.Errorf("wallet is locked")
 	}
 
+	boardingUtxos, vtxos, outputs, err := a.prepareSettle(
+		ctx, withExpiryCoinselect, false, receivers, *options,
+	)
+	if err != nil {
+		return "", err
+	}
+
+	return a.joinRoundWithRetry(ctx, nil, outputs, *options, vtxos, boardingUtxos)
+}
+
+// prepareSettle selects the boarding utxos and vtxos to spend and builds the
+// outputs of the tx request to pay the given receivers, or to self send all
+// funds if there are none. In preview mode, the self send and change outputs
+// pay to an existing address of the wallet instead of a new one.
+func (a *covenantlessArkClient) prepareSettle(
+	ctx context.Context,
+	withExpiryCoinselect bool,
+	preview bool,
+	receivers []Receiver,
+	options SettleOptions,
+) ([]types.Utxo, []client.TapscriptsVtxo, []client.Output, error) {
 	expectedServerPubkey := schnorr.SerializePubKey(a.ServerPubKey)
 	outputs := make([]client.Output, 0)
 	sumOfReceivers := uint64(0)
@@ -1418,17 +2696,21 @@ func (a *covenantlessArkClient) sendOffchain(
 	for _, receiver := range receivers {
 		rcvAddr, err := common.DecodeAddress(receiver.To())
 		if err != nil {
-			return "", fmt.Errorf("invalid receiver address: %s", err)
+			return nil, nil, nil, fmt.Errorf("%w: %s", ErrInvalidReceiverAddress, err)
 		}
 
 		rcvServerPubkey := schnorr.SerializePubKey(rcvAddr.Server)
 
 		if !bytes.Equal(expectedServerPubkey, rcvServerPubkey) {
-			return "", fmt.Errorf("invalid receiver address '%s': expected server %s, got %s", receiver.To(), hex.EncodeToString(expectedServerPubkey), hex.EncodeToString(rcvServerPubkey))
+			return nil, nil, nil, fmt.Errorf("invalid receiver address '%s': expected server %s, got %s", receiver.To(), hex.EncodeToString(expectedServerPubkey), hex.EncodeToString(rcvServerPubkey))
 		}
 
 		if receiver.Amount() < a.Dust {
-			return "", fmt.Errorf("invalid amount (%d), must be greater than dust %d", receiver.Amount(), a.Dust)
+			return nil, nil, nil, fmt.Errorf("%w: invalid amount (%d), must be greater than dust %d", ErrDustAmount, receiver.Amount(), a.Dust)
+		}
+
+		if err := a.validateOutputAmount(receiver.Amount(), false); err != nil {
+			return nil, nil, nil, err
 		}
 
 		outputs = append(outputs, client.Output{
@@ -1441,12 +2723,12 @@ func (a *covenantlessArkClient) sendOffchain(
 	// coinselect boarding utxos and vtxos
 	boardingUtxos, vtxos, changeAmount, err := a.selectFunds(ctx, withExpiryCoinselect, options.SelectRecoverableVtxos, sumOfReceivers)
 	if err != nil {
-		return "", err
+		return nil, nil, nil, err
 	}
 
-	offchainAddr, _, err := a.wallet.NewAddress(ctx, false)
+	offchainAddr, err := a.settleAddress(ctx, preview)
 	if err != nil {
-		return "", err
+		return nil, nil, nil, err
 	}
 
 	// if no outputs, self send all selected coins
@@ -1473,7 +2755,46 @@ func (a *covenantlessArkClient) sendOffchain(
 		})
 	}
 
-	return a.joinRoundWithRetry(ctx, nil, outputs, *options, vtxos, boardingUtxos)
+	if len(options.Denominations) > 0 {
+		minAmount := a.minChangeAmount()
+		denominatedOutputs := make([]client.Output, 0, len(outputs))
+		for _, output := range outputs {
+			for _, amount := range denominate(output.Amount, options.Denominations, minAmount) {
+				denominatedOutputs = append(denominatedOutputs, client.Output{
+					Address: output.Address,
+					Amount:  amount,
+				})
+			}
+		}
+		outputs = denominatedOutputs
+	}
+
+	if err := a.validateSettleOutputs(outputs); err != nil {
+		return nil, nil, nil, err
+	}
+
+	return boardingUtxos, vtxos, outputs, nil
+}
+
+// settleAddress returns the offchain address receiving the self send and
+// change outputs of a settlement. A preview must not have side effects on the
+// wallet, so it returns an existing address instead of a new one.
+func (a *covenantlessArkClient) settleAddress(
+	ctx context.Context, preview bool,
+) (*wallet.TapscriptsAddress, error) {
+	if !preview {
+		offchainAddr, _, err := a.wallet.NewAddress(ctx, false)
+		return offchainAddr, err
+	}
+
+	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(offchainAddrs) <= 0 {
+		return nil, fmt.Errorf("no offchain address in wallet")
+	}
+	return &offchainAddrs[0], nil
 }
 
 func (a *covenantlessArkClient) makeBIP322Signature(
@@ -1533,6 +2854,19 @@ func (a *covenantlessArkClient) makeBIP322Signature(
 		return "", "", err
 	}
 
+	encodedSig, err := a.signBIP322Proof(context.Background(), proof, leafProofs)
+	if err != nil {
+		return "", "", err
+	}
+
+	return encodedSig, message, nil
+}
+
+// signBIP322Proof signs the given proof with the wallet, spending every input
+// with the given leaf, and returns the encoded BIP322 signature.
+func (a *covenantlessArkClient) signBIP322Proof(
+	ctx context.Context, proof *bip322.FullProof, leafProofs []*common.TaprootMerkleProof,
+) (string, error) {
 	for i, input := range proof.Inputs {
 		// BIP322 proof has an additional input using the first vtxo script
 		// so we need to use the previous leaf proof for the current input except for the first input
@@ -1557,32 +2891,27 @@ func (a *covenantlessArkClient) makeBIP322Signature(
 
 	unsignedProofTx, err := proofTx.B64Encode()
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
-	signedTx, err := a.wallet.SignTransaction(context.Background(), a.explorer, unsignedProofTx)
+	signedTx, err := a.wallet.SignTransaction(ctx, a.explorer, unsignedProofTx)
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
 	signedProofTx, err := psbt.NewFromRawBytes(strings.NewReader(signedTx), true)
 	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
 	proof = (*bip322.FullProof)(signedProofTx)
 
 	sig, err := proof.Signature()
 	if err != nil {
-		return "", "", err
-	}
-
-	encodedSig, err := sig.Encode()
-	if err != nil {
-		return "", "", err
+		return "", err
 	}
 
-	return encodedSig, message, nil
+	return sig.Encode()
 }
 
 func (a *covenantlessArkClient) addInputs(
@@ -1727,7 +3056,8 @@ func (a *covenantlessArkClient) joinRoundWithRetry(
 		log.Infof("registered inputs and outputs with request id: %s", requestID)
 
 		roundTxID, err := a.handleRoundStream(
-			ctx, requestID, selectedCoins, selectedBoardingCoins, outputs, signerSessions, options.EventsCh,
+			ctx, requestID, selectedCoins, selectedBoardingCoins, outputs, signerSessions,
+			options.FeeRate, options.ForfeitCosigner, options.EventsCh, options.Progress,
 		)
 		if err != nil {
 			log.WithError(err).Warn("round failed, retrying...")
@@ -1743,6 +3073,82 @@ func (a *covenantlessArkClient) joinRoundWithRetry(
 	return "", fmt.Errorf("reached max atttempt of retries, last round error: %s", roundErr)
 }
 
+// ResumeSettle rejoins the round of a settlement interrupted by a restart,
+// after its tx request was registered with the server. The session to resume
+// is the one of the given round, or the latest one if roundID is empty. The
+// options must include any extra signer session given to the interrupted
+// Settle.
+func (a *covenantlessArkClient) ResumeSettle(
+	ctx context.Context, roundID string, opts ...Option,
+) (string, error) {
+	if err := a.signerCheck(); err != nil {
+		return "", err
+	}
+
+	sessionStore := a.store.RoundSessionStore()
+	if sessionStore == nil {
+		return "", fmt.Errorf("resuming a settlement requires an app data store")
+	}
+
+	options := &SettleOptions{}
+	for _, opt := range opts {
+		if err := opt(options); err != nil {
+			return "", err
+		}
+	}
+
+	sessions, err := sessionStore.GetRoundSessions(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get round sessions: %s", err)
+	}
+	session := findRoundSession(sessions, roundID)
+	if session == nil {
+		return "", fmt.Errorf("no settlement to resume for round %s", roundID)
+	}
+
+	vtxos, boardingUtxos, outputs := roundSessionCoins(*session)
+	inputs, _, _, err := toBIP322Inputs(boardingUtxos, vtxos)
+	if err != nil {
+		return "", err
+	}
+	// the wallet signer is derived from the inputs, the same of the tree
+	// cosigner registered with the tx request
+	signerSessions, _, _, err := a.handleOptions(*options, inputs, nil)
+	if err != nil {
+		return "", err
+	}
+
+	log.Infof("resuming settlement with request id: %s", session.RequestId)
+	return a.handleRoundStream(
+		ctx, session.RequestId, vtxos, boardingUtxos, outputs, signerSessions,
+		options.FeeRate, options.ForfeitCosigner, options.EventsCh, options.Progress,
+	)
+}
+
+// addRoundSession persists the given session, if the client has an app data
+// store.
+func (a *covenantlessArkClient) addRoundSession(
+	ctx context.Context, session types.RoundSession,
+) {
+	sessionStore := a.store.RoundSessionStore()
+	if sessionStore == nil {
+		return
+	}
+	if err := sessionStore.AddRoundSession(ctx, session); err != nil {
+		log.WithError(err).Warn("failed to persist round session")
+	}
+}
+
+func (a *covenantlessArkClient) deleteRoundSession(ctx context.Context, requestID string) {
+	sessionStore := a.store.RoundSessionStore()
+	if sessionStore == nil {
+		return
+	}
+	if err := sessionStore.DeleteRoundSession(ctx, requestID); err != nil {
+		log.WithError(err).Warn("failed to delete round session")
+	}
+}
+
 func (a *covenantlessArkClient) handleRoundStream(
 	ctx context.Context,
 	requestID string,
@@ -1750,13 +3156,42 @@ func (a *covenantlessArkClient) handleRoundStream(
 	boardingUtxos []types.Utxo,
 	receivers []client.Output,
 	signerSessions []tree.SignerSession,
+	forfeitsFeeRate chainfee.SatPerKVByte,
+	forfeitCosigner ForfeitCosigner,
 	replayEventsCh chan<- client.RoundEvent,
+	progress func(SettleStage),
 ) (string, error) {
+	notifyProgress := func(stage SettleStage) {
+		if progress != nil {
+			progress(stage)
+		}
+	}
+
 	round, err := a.client.GetRound(ctx, "")
 	if err != nil {
 		return "", err
 	}
 
+	// persist the session to resume it with ResumeSettle if the process stops
+	// before the round completes. Notes can't be resumed, they aren't stored.
+	session := newRoundSession(requestID, round.ID, vtxos, boardingUtxos, receivers)
+	if len(vtxos)+len(boardingUtxos) > 0 {
+		a.addRoundSession(ctx, session)
+		defer func() {
+			// a session interrupted by the caller is kept to be resumed
+			if ctx.Err() == nil {
+				a.deleteRoundSession(context.Background(), requestID)
+			}
+		}()
+	}
+	setRoundID := func(id string) {
+		if id == session.RoundId || len(session.Vtxos)+len(session.BoardingUtxos) <= 0 {
+			return
+		}
+		session.RoundId = id
+		a.addRoundSession(ctx, session)
+	}
+
 	eventsCh, close, err := a.client.GetEventStream(ctx, requestID)
 	if err != nil {
 		if errors.Is(err, io.EOF) {
@@ -1776,6 +3211,8 @@ func (a *covenantlessArkClient) handleRoundStream(
 		close()
 	}()
 
+	notifyProgress(RequestRegistered)
+
 	vtxosToSign := make([]client.TapscriptsVtxo, 0)
 	for _, vtxo := range vtxos {
 		if !vtxo.IsRecoverable() {
@@ -1805,6 +3242,10 @@ func (a *covenantlessArkClient) handleRoundStream(
 		step = roundSigningNoncesGenerated
 	}
 
+	// the server may run several rounds concurrently, once the round including
+	// the request is known the events of the others are ignored
+	roundID := round.ID
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -1821,20 +3262,39 @@ func (a *covenantlessArkClient) handleRoundStream(
 			}
 			switch event := notify.Event; event.(type) {
 			case client.RoundFinalizedEvent:
-				if step != roundFinalization {
+				e := event.(client.RoundFinalizedEvent)
+				if step != roundFinalization || e.ID != roundID {
 					continue
 				}
-				log.Infof("round completed %s", event.(client.RoundFinalizedEvent).Txid)
-				return event.(client.RoundFinalizedEvent).Txid, nil
+				if e.FeeReport != nil {
+					log.Infof(
+						"round completed %s, received %d vtxos, paid %d sats of fees",
+						e.Txid, len(e.Vtxos), e.FeeReport.FeeAmount,
+					)
+				} else {
+					log.Infof("round completed %s", e.Txid)
+				}
+				notifyProgress(RoundFinalized)
+				return e.Txid, nil
 			case client.RoundFailedEvent:
-				if event.(client.RoundFailedEvent).ID == round.ID {
-					return "", fmt.Errorf("round failed: %s", event.(client.RoundFailedEvent).Reason)
+				e := event.(client.RoundFailedEvent)
+				if e.ID == roundID {
+					if e.Retryable {
+						return "", fmt.Errorf("%w: %s", client.ErrRoundAborted, e.Reason)
+					}
+					return "", fmt.Errorf("round failed: %s", e.Reason)
 				}
 				continue
 			case client.RoundSigningStartedEvent:
 				pingStop()
 				if step != start {
-					continue
+					// the server restarts the signing session with a rebuilt vtxo tree
+					// if some cosigners went offline before submitting their signatures
+					if !hasOffchainOutput || step == roundFinalization {
+						continue
+					}
+					log.Info("round signing restarted")
+					step = start
 				}
 				log.Info("a round signing started")
 				skipped, err := a.handleRoundSigningStarted(
@@ -1844,11 +3304,15 @@ func (a *covenantlessArkClient) handleRoundStream(
 					return "", err
 				}
 				if !skipped {
+					roundID = event.(client.RoundSigningStartedEvent).ID
+					setRoundID(roundID)
 					step++
+					notifyProgress(NoncesSubmitted)
 				}
 				continue
 			case client.RoundSigningNoncesGeneratedEvent:
-				if step != roundSigningStarted {
+				if step != roundSigningStarted ||
+					event.(client.RoundSigningNoncesGeneratedEvent).ID != roundID {
 					continue
 				}
 				pingStop()
@@ -1859,16 +3323,21 @@ func (a *covenantlessArkClient) handleRoundStream(
 					return "", err
 				}
 				step++
+				notifyProgress(TreeSigned)
 				continue
 			case client.RoundFinalizationEvent:
 				if step != roundSigningNoncesGenerated {
 					continue
 				}
+				if hasOffchainOutput && event.(client.RoundFinalizationEvent).ID != roundID {
+					continue
+				}
 				pingStop()
 				log.Info("a round finalization started")
 
 				signedForfeitTxs, signedRoundTx, err := a.handleRoundFinalization(
 					ctx, event.(client.RoundFinalizationEvent), vtxosToSign, boardingUtxos, receivers,
+					forfeitsFeeRate, forfeitCosigner,
 				)
 				if err != nil {
 					return "", err
@@ -1886,7 +3355,10 @@ func (a *covenantlessArkClient) handleRoundStream(
 
 				log.Info("done.")
 				log.Info("waiting for round finalization...")
+				roundID = event.(client.RoundFinalizationEvent).ID
+				setRoundID(roundID)
 				step++
+				notifyProgress(ForfeitsSubmitted)
 				continue
 			}
 		}
@@ -2032,6 +3504,8 @@ func (a *covenantlessArkClient) handleRoundFinalization(
 	vtxos []client.TapscriptsVtxo,
 	boardingUtxos []types.Utxo,
 	receivers []client.Output,
+	forfeitsFeeRate chainfee.SatPerKVByte,
+	forfeitCosigner ForfeitCosigner,
 ) ([]string, string, error) {
 	if err := a.validateVtxoTree(event, receivers, vtxos); err != nil {
 		return nil, "", fmt.Errorf("failed to verify vtxo tree: %s", err)
@@ -2040,10 +3514,21 @@ func (a *covenantlessArkClient) handleRoundFinalization(
 	var forfeits []string
 
 	if len(vtxos) > 0 {
+		feeRate := event.MinRelayFeeRate
+		if forfeitsFeeRate > 0 {
+			if forfeitsFeeRate < event.MinRelayFeeRate {
+				return nil, "", fmt.Errorf(
+					"invalid fee rate %s, must be at least the server min relay fee rate %s",
+					forfeitsFeeRate, event.MinRelayFeeRate,
+				)
+			}
+			feeRate = forfeitsFeeRate
+		}
+
 		signedForfeits, err := a.createAndSignForfeits(
 			ctx,
 			vtxos, event.Connectors.Leaves(),
-			event.ConnectorsIndex, event.MinRelayFeeRate,
+			event.ConnectorsIndex, feeRate, forfeitCosigner,
 		)
 		if err != nil {
 			return nil, "", err
@@ -2163,7 +3648,7 @@ func (a *covenantlessArkClient) validateVtxoTree(
 			return fmt.Errorf("root's parent txid is not the same as the round txid: %s != %s", root.ParentTxid, getTxID(roundTx))
 		}
 
-		if err := event.Connectors.Validate(getTxID); err != nil {
+		if err := event.Connectors.ValidateLinks(getTxID); err != nil {
 			return err
 		}
 
@@ -2176,6 +3661,24 @@ func (a *covenantlessArkClient) validateVtxoTree(
 				return fmt.Errorf("missing connector index for vtxo %s", vtxo.String())
 			}
 		}
+
+		// the server must assign the connectors the same way the client does
+		vtxoOutpoints := make([]string, 0, len(event.ConnectorsIndex))
+		for vtxo := range event.ConnectorsIndex {
+			vtxoOutpoints = append(vtxoOutpoints, vtxo)
+		}
+		assignments, err := tree.AssignConnectors(vtxoOutpoints, event.Connectors.Leaves())
+		if err != nil {
+			return err
+		}
+		for vtxo, connector := range assignments {
+			outpoint := event.ConnectorsIndex[vtxo]
+			if outpoint.Txid != connector.Hash.String() || outpoint.VOut != connector.Index {
+				return fmt.Errorf(
+					"invalid connector %s for vtxo %s, expected %s", outpoint, vtxo, connector,
+				)
+			}
+		}
 	}
 
 	return nil
@@ -2283,12 +3786,83 @@ func (a *covenantlessArkClient) validateOffChainReceiver(
 	return nil
 }
 
+// estimateForfeitTxsFee returns the sum of the fees of the forfeit txs of the
+// given vtxos at the given fee rate.
+func (a *covenantlessArkClient) estimateForfeitTxsFee(
+	vtxos []client.TapscriptsVtxo, feeRate chainfee.SatPerKVByte,
+) (uint64, error) {
+	if len(vtxos) <= 0 {
+		return 0, nil
+	}
+
+	parsedForfeitAddr, err := btcutil.DecodeAddress(a.ForfeitAddress, nil)
+	if err != nil {
+		return 0, err
+	}
+	forfeitPkScript, err := txscript.PayToAddrScript(parsedForfeitAddr)
+	if err != nil {
+		return 0, err
+	}
+	parsedScript, err := txscript.ParsePkScript(forfeitPkScript)
+	if err != nil {
+		return 0, err
+	}
+
+	fees := uint64(0)
+	for _, vtxo := range vtxos {
+		vtxoScript, err := tree.ParseVtxoScript(vtxo.Tapscripts)
+		if err != nil {
+			return 0, err
+		}
+		_, vtxoTapTree, err := vtxoScript.TapTree()
+		if err != nil {
+			return 0, err
+		}
+
+		forfeitClosures := vtxoScript.ForfeitClosures()
+		if len(forfeitClosures) <= 0 {
+			return 0, fmt.Errorf("no forfeit closures found")
+		}
+		forfeitClosure := forfeitClosures[0]
+
+		forfeitScript, err := forfeitClosure.Script()
+		if err != nil {
+			return 0, err
+		}
+		forfeitLeaf := txscript.NewBaseTapLeaf(forfeitScript)
+		leafProof, err := vtxoTapTree.GetTaprootMerkleProof(forfeitLeaf.TapHash())
+		if err != nil {
+			return 0, err
+		}
+		ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
+		if err != nil {
+			return 0, err
+		}
+
+		fee, err := common.ComputeForfeitTxFee(
+			feeRate,
+			&waddrmgr.Tapscript{
+				RevealedScript: leafProof.Script,
+				ControlBlock:   ctrlBlock,
+			},
+			forfeitClosure.WitnessSize(),
+			parsedScript.Class(),
+		)
+		if err != nil {
+			return 0, err
+		}
+		fees += fee
+	}
+	return fees, nil
+}
+
 func (a *covenantlessArkClient) createAndSignForfeits(
 	ctx context.Context,
 	vtxosToSign []client.TapscriptsVtxo,
 	connectorsTxs []tree.Node,
 	connectorsIndex map[string]client.Outpoint,
 	feeRate chainfee.SatPerKVByte,
+	forfeitCosigner ForfeitCosigner,
 ) ([]string, error) {
 	parsedForfeitAddr, err := btcutil.DecodeAddress(a.ForfeitAddress, nil)
 	if err != nil {
@@ -2436,45 +4010,93 @@ func (a *covenantlessArkClient) createAndSignForfeits(
 			return nil, err
 		}
 
+		signedForfeit, err = a.cosignForfeitTx(ctx, signedForfeit, forfeitCosigner)
+		if err != nil {
+			return nil, err
+		}
+
 		signedForfeits = append(signedForfeits, signedForfeit)
 	}
-
-	return signedForfeits, nil
+
+	return signedForfeits, nil
+}
+
+// cosignForfeitTx makes sure that the inputs of the given tx spending a vtxo
+// through its forfeit closure are signed by all keys but the server one. The
+// missing signatures, if any, are added by the given forfeit cosigner.
+func (a *covenantlessArkClient) cosignForfeitTx(
+	ctx context.Context, tx string, forfeitCosigner ForfeitCosigner,
+) (string, error) {
+	missingSigners, err := getMissingForfeitSigners(tx, a.ServerPubKey)
+	if err != nil {
+		return "", err
+	}
+	if len(missingSigners) <= 0 {
+		return tx, nil
+	}
+	if forfeitCosigner == nil {
+		return "", fmt.Errorf(
+			"missing signatures of forfeit cosigners %s, a forfeit cosigner is required",
+			strings.Join(missingSigners, ", "),
+		)
+	}
+
+	signedTx, err := forfeitCosigner(ctx, tx)
+	if err != nil {
+		return "", fmt.Errorf("failed to cosign forfeit tx: %s", err)
+	}
+
+	missingSigners, err = getMissingForfeitSigners(signedTx, a.ServerPubKey)
+	if err != nil {
+		return "", err
+	}
+	if len(missingSigners) > 0 {
+		return "", fmt.Errorf(
+			"missing signatures of forfeit cosigners %s", strings.Join(missingSigners, ", "),
+		)
+	}
+	return signedTx, nil
 }
 
-func (a *covenantlessArkClient) getMatureUtxos(
+// getExitUtxos returns the utxos of the redemption addresses of the wallet,
+// split between those that can be spent now and those still locked by the
+// exit timelock.
+func (a *covenantlessArkClient) getExitUtxos(
 	ctx context.Context,
-) ([]types.Utxo, error) {
+) (mature, locked []types.Utxo, err error) {
 	_, _, redemptionAddrs, err := a.wallet.GetAddresses(ctx)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	now := time.Now()
 
-	utxos := make([]types.Utxo, 0)
+	mature = make([]types.Utxo, 0)
+	locked = make([]types.Utxo, 0)
 	for _, addr := range redemptionAddrs {
 		fetchedUtxos, err := a.explorer.GetUtxos(addr.Address)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		for _, utxo := range fetchedUtxos {
 			u := utxo.ToUtxo(a.UnilateralExitDelay, addr.Tapscripts)
 			if u.SpendableAt.Before(now) {
-				utxos = append(utxos, u)
+				mature = append(mature, u)
+				continue
 			}
+			locked = append(locked, u)
 		}
 	}
 
-	return utxos, nil
+	return mature, locked, nil
 }
 
 func (a *covenantlessArkClient) getRedeemBranches(
 	ctx context.Context, vtxos []client.Vtxo,
 ) (map[string]*redemption.CovenantlessRedeemBranch, error) {
-	vtxoTrees := make(map[string]tree.TxTree, 0)
 	redeemBranches := make(map[string]*redemption.CovenantlessRedeemBranch, 0)
+	sharedOutAmounts := make(map[string]int64)
 
 	for i := range vtxos {
 		vtxo := vtxos[i]
@@ -2484,18 +4106,27 @@ func (a *covenantlessArkClient) getRedeemBranches(
 			continue
 		}
 
-		if _, ok := vtxoTrees[vtxo.RoundTxid]; !ok {
-			round, err := a.client.GetRound(ctx, vtxo.RoundTxid)
-			if err != nil {
+		// fetch only the txs from the root to the vtxo instead of the whole tree
+		branch, err := a.client.GetRoundTreeBranch(ctx, vtxo.RoundTxid, vtxo.Outpoint)
+		if err != nil {
+			return nil, err
+		}
+
+		sharedOutAmount, ok := sharedOutAmounts[vtxo.RoundTxid]
+		if !ok {
+			if sharedOutAmount, err = a.getSharedOutAmount(ctx, vtxo.RoundTxid); err != nil {
 				return nil, err
 			}
+			sharedOutAmounts[vtxo.RoundTxid] = sharedOutAmount
+		}
 
-			vtxoTrees[vtxo.RoundTxid] = round.Tree
+		// don't trust the server, the branch must be well formed before
+		// broadcasting any of its txs
+		if err := a.validateRoundTreeBranch(branch, vtxo, sharedOutAmount); err != nil {
+			return nil, fmt.Errorf("invalid tree branch for vtxo %s: %s", vtxo.Outpoint, err)
 		}
 
-		redeemBranch, err := redemption.NewRedeemBranch(
-			a.explorer, vtxoTrees[vtxo.RoundTxid], vtxo,
-		)
+		redeemBranch, err := redemption.NewRedeemBranch(a.explorer, branch, vtxo)
 		if err != nil {
 			return nil, err
 		}
@@ -2506,6 +4137,92 @@ func (a *covenantlessArkClient) getRedeemBranches(
 	return redeemBranches, nil
 }
 
+// getNetworkFeeRate returns the fee rate estimated by the explorer for a tx to
+// confirm within the given number of blocks, never less than the min relay fee
+// rate.
+func (a *covenantlessArkClient) getNetworkFeeRate(
+	target int,
+) (chainfee.SatPerKVByte, error) {
+	satsPerVByte, err := a.explorer.GetFeeRateForTarget(target)
+	if err != nil {
+		return 0, err
+	}
+	feeRate := chainfee.SatPerKVByte(math.Round(satsPerVByte * 1000))
+	if feeRate < minRelayFeeRate {
+		return minRelayFeeRate, nil
+	}
+	return feeRate, nil
+}
+
+// getSharedOutAmount returns the amount of the shared output of the given
+// round tx, ie. the input of the root of its vtxo tree.
+func (a *covenantlessArkClient) getSharedOutAmount(
+	ctx context.Context, roundTxid string,
+) (int64, error) {
+	txHex, err := a.client.GetRoundTx(ctx, roundTxid)
+	if err != nil {
+		return 0, err
+	}
+	buf, err := hex.DecodeString(txHex)
+	if err != nil {
+		return 0, fmt.Errorf("invalid round tx: %s", err)
+	}
+	roundTx := &wire.MsgTx{}
+	if err := roundTx.Deserialize(bytes.NewReader(buf)); err != nil {
+		return 0, fmt.Errorf("invalid round tx: %s", err)
+	}
+	if roundTx.TxHash().String() != roundTxid {
+		return 0, fmt.Errorf("round tx %s doesn't match txid %s", roundTx.TxHash(), roundTxid)
+	}
+	if len(roundTx.TxOut) <= 0 {
+		return 0, fmt.Errorf("round tx %s has no outputs", roundTxid)
+	}
+	return roundTx.TxOut[0].Value, nil
+}
+
+// validateRoundTreeBranch checks that the given branch belongs to the round of
+// the vtxo, leads to the vtxo and is well formed.
+func (a *covenantlessArkClient) validateRoundTreeBranch(
+	branch tree.TxTree, vtxo client.Vtxo, sharedOutAmount int64,
+) error {
+	root, err := branch.Root()
+	if err != nil {
+		return err
+	}
+	if root.ParentTxid != vtxo.RoundTxid {
+		return fmt.Errorf(
+			"root's parent txid is not the round txid: %s != %s",
+			root.ParentTxid, vtxo.RoundTxid,
+		)
+	}
+	if _, err := branch.Branch(vtxo.Txid); err != nil {
+		return err
+	}
+
+	ptxRoot, err := psbt.NewFromRawBytes(strings.NewReader(root.Tx), true)
+	if err != nil {
+		return err
+	}
+	vtxoTreeExpiry, err := tree.GetVtxoTreeExpiry(ptxRoot.Inputs[0])
+	if err != nil {
+		return err
+	}
+
+	sweepClosure := &tree.CSVMultisigClosure{
+		MultisigClosure: tree.MultisigClosure{
+			PubKeys: []*secp256k1.PublicKey{a.ServerPubKey},
+		},
+		Locktime: *vtxoTreeExpiry,
+	}
+	sweepScript, err := sweepClosure.Script()
+	if err != nil {
+		return err
+	}
+	sweepRoot := txscript.NewBaseTapLeaf(sweepScript).TapHash()
+
+	return branch.Validate(sweepRoot[:], sharedOutAmount)
+}
+
 func (a *covenantlessArkClient) getOffchainBalance(
 	ctx context.Context, computeVtxoExpiration bool,
 ) (uint64, map[int64]uint64, error) {
@@ -2743,6 +4460,216 @@ func (a *covenantlessArkClient) getVtxos(ctx context.Context, opts *CoinSelectOp
 	return allVtxos, nil
 }
 
+// ensureVtxosTreeNotSwept returns ErrVtxoTreeSwept if any of the given vtxos
+// belongs to a round whose vtxo tree has been swept.
+func (a *covenantlessArkClient) ensureVtxosTreeNotSwept(
+	ctx context.Context, vtxos []client.TapscriptsVtxo,
+) error {
+	sweptRounds := make(map[string]bool)
+	for _, vtxo := range vtxos {
+		if vtxo.Swept {
+			return fmt.Errorf("%w: vtxo %s", ErrVtxoTreeSwept, vtxo.Outpoint)
+		}
+
+		swept, ok := sweptRounds[vtxo.RoundTxid]
+		if !ok {
+			round, err := a.indexer.GetCommitmentTx(ctx, vtxo.RoundTxid)
+			if err != nil {
+				return fmt.Errorf("failed to get round %s: %s", vtxo.RoundTxid, err)
+			}
+			for _, batch := range round.Batches {
+				if batch.Swept {
+					swept = true
+					break
+				}
+			}
+			sweptRounds[vtxo.RoundTxid] = swept
+		}
+
+		if swept {
+			return fmt.Errorf("%w: vtxo %s", ErrVtxoTreeSwept, vtxo.Outpoint)
+		}
+	}
+	return nil
+}
+
+// validateOffchainReceiver checks that the given receiver has an offchain
+// address of this server and, unless it's a max receiver, an amount above dust
+// and within the bounds of the server.
+func (a *covenantlessArkClient) validateOffchainReceiver(receiver Receiver) error {
+	netParams := utils.ToBitcoinNetwork(a.Network)
+	isOnchain, _, err := utils.ParseBitcoinAddress(receiver.To(), netParams)
+	if err != nil {
+		return err
+	}
+	if isOnchain {
+		return fmt.Errorf("all receiver addresses must be offchain addresses")
+	}
+
+	rcvAddr, err := common.DecodeAddress(receiver.To())
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidReceiverAddress, err)
+	}
+
+	expectedServerPubkey := schnorr.SerializePubKey(a.ServerPubKey)
+	rcvServerPubkey := schnorr.SerializePubKey(rcvAddr.Server)
+	if !bytes.Equal(expectedServerPubkey, rcvServerPubkey) {
+		return fmt.Errorf("invalid receiver address '%s': expected server %s, got %s", receiver.To(), hex.EncodeToString(expectedServerPubkey), hex.EncodeToString(rcvServerPubkey))
+	}
+
+	// the amount of the max receiver is validated once known
+	if isMaxReceiver(receiver) {
+		return nil
+	}
+
+	if receiver.Amount() < a.Dust {
+		return fmt.Errorf("%w: invalid amount (%d), must be greater than dust %d", ErrDustAmount, receiver.Amount(), a.Dust)
+	}
+	return a.validateOutputAmount(receiver.Amount(), false)
+}
+
+// validateOutputAmount returns ErrAmountBelowMinimum or ErrAmountAboveMaximum
+// if the given amount is out of the bounds accepted by the server for an
+// onchain or offchain output. A negative bound means no limit.
+func (a *covenantlessArkClient) validateOutputAmount(amount uint64, isOnchain bool) error {
+	kind, minAmount, maxAmount := "vtxo", a.VtxoMinAmount, a.VtxoMaxAmount
+	if isOnchain {
+		kind, minAmount, maxAmount = "utxo", a.UtxoMinAmount, a.UtxoMaxAmount
+	}
+
+	if minAmount >= 0 && amount < uint64(minAmount) {
+		return fmt.Errorf(
+			"%w: output amount %d, min %s amount %d", ErrAmountBelowMinimum, amount, kind, minAmount,
+		)
+	}
+	if maxAmount >= 0 && amount > uint64(maxAmount) {
+		return fmt.Errorf(
+			"%w: output amount %d, max %s amount %d", ErrAmountAboveMaximum, amount, kind, maxAmount,
+		)
+	}
+	return nil
+}
+
+// minChangeAmount returns the min amount of the change vtxo of a collaborative
+// exit, the greatest between dust and the min vtxo amount of the server.
+func (a *covenantlessArkClient) minChangeAmount() uint64 {
+	minAmount := a.Dust
+	if a.VtxoMinAmount > 0 && uint64(a.VtxoMinAmount) > minAmount {
+		minAmount = uint64(a.VtxoMinAmount)
+	}
+	return minAmount
+}
+
+// splitExitChange returns the amounts of the onchain output and of the change
+// of a collaborative exit. A change below minChange can't be sent offchain: it
+// is either added to the onchain output, if absorb is true, or makes the exit
+// fail with ErrDustChange.
+func splitExitChange(
+	amount, change, minChange uint64, absorb bool,
+) (uint64, uint64, error) {
+	if change == 0 || change >= minChange {
+		return amount, change, nil
+	}
+	if absorb {
+		return amount + change, 0, nil
+	}
+
+	total := amount + change
+	if total <= minChange {
+		return 0, 0, fmt.Errorf(
+			"%w: change %d, min %d, exit all the %d sats selected",
+			ErrDustChange, change, minChange, total,
+		)
+	}
+	return 0, 0, fmt.Errorf(
+		"%w: change %d, min %d, exit all the %d sats selected or at most %d sats",
+		ErrDustChange, change, minChange, total, total-minChange,
+	)
+}
+
+// validateSettleOutputs makes sure the outputs registered for a round respect
+// the bounds of the server, both individually and in total, so that the
+// request is not rejected once the round has started.
+// Settle bounds <= 0 mean no limit, servers not exposing them report 0.
+func (a *covenantlessArkClient) validateSettleOutputs(outputs []client.Output) error {
+	netParams := utils.ToBitcoinNetwork(a.Network)
+	total := uint64(0)
+	for _, output := range outputs {
+		isOnchain, _, err := utils.ParseBitcoinAddress(output.Address, netParams)
+		if err != nil {
+			return err
+		}
+		if err := a.validateOutputAmount(output.Amount, isOnchain); err != nil {
+			return err
+		}
+		total += output.Amount
+	}
+
+	if a.SettleMinAmount > 0 && total < uint64(a.SettleMinAmount) {
+		return fmt.Errorf(
+			"%w: total amount %d, min settle amount %d", ErrAmountBelowMinimum, total, a.SettleMinAmount,
+		)
+	}
+	if a.SettleMaxAmount > 0 && total > uint64(a.SettleMaxAmount) {
+		return fmt.Errorf(
+			"%w: total amount %d, max settle amount %d", ErrAmountAboveMaximum, total, a.SettleMaxAmount,
+		)
+	}
+	return nil
+}
+
+// getRecoverableVtxos returns the swept but unspent vtxos of the wallet
+// along with their tapscripts.
+func (a *covenantlessArkClient) getRecoverableVtxos(
+	ctx context.Context,
+) ([]client.TapscriptsVtxo, error) {
+	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vtxos, err := a.getVtxos(ctx, &CoinSelectOptions{SelectRecoverableVtxos: true})
+	if err != nil {
+		return nil, err
+	}
+
+	recoverableVtxos := make([]client.TapscriptsVtxo, 0)
+	for _, offchainAddr := range offchainAddrs {
+		for _, v := range vtxos {
+			if !v.IsRecoverable() {
+				continue
+			}
+
+			vtxoAddr, err := v.Address(a.ServerPubKey, a.Network)
+			if err != nil {
+				return nil, err
+			}
+
+			if vtxoAddr == offchainAddr.Address {
+				recoverableVtxos = append(recoverableVtxos, client.TapscriptsVtxo{
+					Vtxo:       v,
+					Tapscripts: offchainAddr.Tapscripts,
+				})
+			}
+		}
+	}
+	return recoverableVtxos, nil
+}
+
+func sendRecoveryProgress(
+	ctx context.Context, progressCh chan<- RecoveryProgress, progress RecoveryProgress,
+) error {
+	if progressCh == nil {
+		return nil
+	}
+	select {
+	case progressCh <- progress:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("context done %s", ctx.Err())
+	}
+}
+
 func (a *covenantlessArkClient) getBoardingTxs(
 	ctx context.Context,
 ) ([]types.Transaction, map[string]struct{}, error) {
@@ -3104,6 +5031,15 @@ func (a *covenantlessArkClient) handleOptions(
 	return sessions, signerPubKeys, signingType, nil
 }
 
+func findVtxo(vtxos []client.Vtxo, outpoint client.Outpoint) (client.Vtxo, bool) {
+	for _, vtxo := range vtxos {
+		if vtxo.Equals(outpoint) {
+			return vtxo, true
+		}
+	}
+	return client.Vtxo{}, false
+}
+
 func findVtxosSpent(vtxos []client.Vtxo, id string) []client.Vtxo {
 	var result []client.Vtxo
 	leftVtxos := make([]client.Vtxo, 0)
@@ -3290,7 +5226,7 @@ type redeemTxInput struct {
 func buildRedeemTx(
 	vtxos []redeemTxInput,
 	receivers []Receiver,
-	feeRate chainfee.SatPerVByte,
+	feeRate chainfee.SatPerKVByte,
 	extraWitnessSizes map[client.Outpoint]int,
 	withZeroFees bool,
 ) (string, error) {
@@ -3298,16 +5234,56 @@ func buildRedeemTx(
 		return "", fmt.Errorf("missing vtxos")
 	}
 
+	ins, err := toVtxoInputs(vtxos, extraWitnessSizes)
+	if err != nil {
+		return "", err
+	}
+
+	outs := make([]*wire.TxOut, 0, len(receivers))
+	for i, receiver := range receivers {
+		if receiver.IsOnchain() {
+			return "", fmt.Errorf("receiver %d is onchain", i)
+		}
+
+		addr, err := common.DecodeAddress(receiver.To())
+		if err != nil {
+			return "", err
+		}
+
+		newVtxoScript, err := common.P2TRScript(addr.VtxoTapKey)
+		if err != nil {
+			return "", err
+		}
+
+		outs = append(outs, &wire.TxOut{
+			Value:    int64(receiver.Amount()),
+			PkScript: newVtxoScript,
+		})
+	}
+
+	if withZeroFees {
+		return tree.BuildRedeemTx(ins, outs)
+	}
+
+	// The fee is deducted from the very last receiver which is supposed to be
+	// the change in case it's not a send-all.
+	return tree.BuildRedeemTxWithFee(ins, outs, feeRate)
+}
+
+// toVtxoInputs returns the inputs of a redeem tx spending the given vtxos.
+func toVtxoInputs(
+	vtxos []redeemTxInput, extraWitnessSizes map[client.Outpoint]int,
+) ([]common.VtxoInput, error) {
 	ins := make([]common.VtxoInput, 0, len(vtxos))
 
 	for _, vtxo := range vtxos {
 		if len(vtxo.Tapscripts) <= 0 {
-			return "", fmt.Errorf("missing tapscripts for vtxo %s", vtxo.Txid)
+			return nil, fmt.Errorf("missing tapscripts for vtxo %s", vtxo.Txid)
 		}
 
 		vtxoTxID, err := chainhash.NewHashFromStr(vtxo.Txid)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		vtxoOutpoint := &wire.OutPoint{
@@ -3317,27 +5293,27 @@ func buildRedeemTx(
 
 		vtxoScript, err := tree.ParseVtxoScript(vtxo.Tapscripts)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		_, vtxoTree, err := vtxoScript.TapTree()
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		leafProof, err := vtxoTree.GetTaprootMerkleProof(vtxo.ForfeitLeafHash)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		closure, err := tree.DecodeClosure(leafProof.Script)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
 		tapscript := &waddrmgr.Tapscript{
@@ -3359,47 +5335,121 @@ func buildRedeemTx(
 		})
 	}
 
-	fees, err := common.ComputeRedeemTxFee(feeRate.FeePerKVByte(), ins, len(receivers))
-	if err != nil {
-		return "", err
+	return ins, nil
+}
+
+// sendAllAmount returns the amount of the max receiver of a send, ie. what's
+// left of the inputs once paid the other receivers and the fee.
+func sendAllAmount(sumOfInputs, sumOfReceivers, fee, dust uint64) (uint64, error) {
+	if sumOfInputs <= sumOfReceivers+fee {
+		return 0, fmt.Errorf(
+			"not enough funds to cover receivers and fee: available %d, required %d",
+			sumOfInputs, sumOfReceivers+fee,
+		)
+	}
+	amount := sumOfInputs - sumOfReceivers - fee
+	if amount < dust {
+		return 0, fmt.Errorf(
+			"invalid max amount (%d), must be greater than dust %d", amount, dust,
+		)
 	}
+	return amount, nil
+}
 
-	if fees >= int64(receivers[len(receivers)-1].Amount()) {
-		return "", fmt.Errorf("redeem tx fee is higher than the amount of the change receiver")
+// denominate splits the given amount into the given denominations, sorted
+// from the largest, greedily. What's left is returned as the last amount if not
+// below minAmount, otherwise it's added to the last one, so that the returned
+// amounts always sum to the given one. Denominations below minAmount are
+// ignored.
+func denominate(amount uint64, denominations []uint64, minAmount uint64) []uint64 {
+	amounts := make([]uint64, 0)
+	left := amount
+	for _, denomination := range denominations {
+		if denomination < minAmount {
+			continue
+		}
+		for left >= denomination {
+			amounts = append(amounts, denomination)
+			left -= denomination
+		}
 	}
 
-	outs := make([]*wire.TxOut, 0, len(receivers))
+	if left == 0 {
+		return amounts
+	}
+	if left >= minAmount || len(amounts) <= 0 {
+		return append(amounts, left)
+	}
+	amounts[len(amounts)-1] += left
+	return amounts
+}
 
-	for i, receiver := range receivers {
-		if receiver.IsOnchain() {
-			return "", fmt.Errorf("receiver %d is onchain", i)
+// denominateChange splits into the given denominations the change of a redeem
+// tx, once deducted the fee. The fee depends on the number of change outputs,
+// that depends in turn on the fee, therefore it's recomputed until the number
+// of outputs is stable. In case the split ends up with less outputs than those
+// accounted, the tx pays a slightly higher fee than required.
+func denominateChange(
+	change uint64, denominations []uint64, minAmount uint64,
+	computeFee func(numOfChangeOutputs int) (uint64, error),
+) ([]uint64, error) {
+	numOfOutputs := 1
+	for {
+		fee, err := computeFee(numOfOutputs)
+		if err != nil {
+			return nil, err
+		}
+		if change < fee+minAmount {
+			return nil, fmt.Errorf(
+				"not enough change to cover the fee: change %d, fee %d, min amount %d",
+				change, fee, minAmount,
+			)
 		}
 
-		addr, err := common.DecodeAddress(receiver.To())
-		if err != nil {
-			return "", err
+		amounts := denominate(change-fee, denominations, minAmount)
+		if len(amounts) <= numOfOutputs {
+			return amounts, nil
 		}
+		numOfOutputs = len(amounts)
+	}
+}
 
-		newVtxoScript, err := common.P2TRScript(addr.VtxoTapKey)
+// getMissingForfeitSigners returns the keys, other than the server one, that
+// didn't sign yet the inputs of the given tx spending a forfeit closure.
+func getMissingForfeitSigners(tx string, serverPubkey *secp256k1.PublicKey) ([]string, error) {
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+	if err != nil {
+		return nil, err
+	}
+
+	serverKey := hex.EncodeToString(schnorr.SerializePubKey(serverPubkey))
+	missingSigners := make([]string, 0)
+	for _, input := range ptx.Inputs {
+		if len(input.TaprootLeafScript) <= 0 {
+			continue
+		}
+
+		closure, err := tree.DecodeClosure(input.TaprootLeafScript[0].Script)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
 
-		value := receiver.Amount()
-		if !withZeroFees {
-			// Deduct the min relay fee from the very last receiver which is supposed
-			// to be the change in case it's not a send-all.
-			if i == len(receivers)-1 {
-				value -= uint64(fees)
+		signed := make(map[string]struct{})
+		for _, sig := range input.TaprootScriptSpendSig {
+			signed[hex.EncodeToString(sig.XOnlyPubKey)] = struct{}{}
+		}
+
+		for _, pubkey := range tree.ForfeitClosurePubKeys(closure) {
+			key := hex.EncodeToString(schnorr.SerializePubKey(pubkey))
+			if key == serverKey {
+				continue
+			}
+			if _, ok := signed[key]; !ok {
+				missingSigners = append(missingSigners, key)
 			}
 		}
-		outs = append(outs, &wire.TxOut{
-			Value:    int64(value),
-			PkScript: newVtxoScript,
-		})
 	}
-
-	return tree.BuildRedeemTx(ins, outs)
+	return missingSigners, nil
 }
 
 func inputsToDerivationPath(inputs []client.Outpoint, notesInputs []string) string {
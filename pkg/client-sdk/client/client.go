@@ -23,6 +23,10 @@ const (
 
 var (
 	ErrConnectionClosedByServer = fmt.Errorf("connection closed by server")
+	// ErrRoundAborted is returned when the round is aborted by the server, for
+	// example because it's shutting down, the same inputs can be registered
+	// again for a next round.
+	ErrRoundAborted = fmt.Errorf("round aborted, please retry")
 )
 
 type RoundEvent interface {
@@ -43,6 +47,9 @@ type TransportClient interface {
 	RegisterOutputsForNextRound(
 		ctx context.Context, requestID string, outputs []Output, musig2 *tree.Musig2,
 	) error
+	UpdateTxRequest(
+		ctx context.Context, requestID string, outputs []Output, musig2 *tree.Musig2,
+	) error
 	SubmitTreeNonces(
 		ctx context.Context, roundID, cosignerPubkey string, nonces tree.TreeNonces,
 	) error
@@ -56,12 +63,42 @@ type TransportClient interface {
 		ctx context.Context, requestID string,
 	) (<-chan RoundEventChannel, func(), error)
 	Ping(ctx context.Context, requestID string) error
+	// SubmitRedeemTx submits the given redeem tx. An idempotency key can be
+	// optionally given with WithIdempotencyKey: resubmitting the same tx with
+	// the same key returns the result of the first submission rather than a
+	// double spend error.
 	SubmitRedeemTx(
 		ctx context.Context, partialSignedRedeemTx string,
+		opts ...SubmitRedeemTxOption,
 	) (signedRedeemTx, redeemTxid string, err error)
+	// ValidateRedeemTx checks the given redeem tx like SubmitRedeemTx does but
+	// without submitting it, and returns its txid if valid.
+	ValidateRedeemTx(
+		ctx context.Context, partialSignedRedeemTx string,
+	) (redeemTxid string, err error)
+	// SubmitRedeemTxs submits a batch of redeem txs and returns the result of
+	// each of them in the same order, so that partial failures are visible.
+	SubmitRedeemTxs(
+		ctx context.Context, partialSignedRedeemTxs []string,
+	) ([]RedeemTxResult, error)
 	ListVtxos(ctx context.Context, addr string) ([]Vtxo, []Vtxo, error)
+	// ListVtxosForAddresses returns the vtxos of all the given addresses with a
+	// single round trip, grouped by address in the same order of the request.
+	ListVtxosForAddresses(ctx context.Context, addrs []string) ([]AddressVtxos, error)
+	// ListVtxosPaged returns a page of the vtxos of the given addresses,
+	// filtered server side, and the token of the next page.
+	ListVtxosPaged(ctx context.Context, req VtxosPageRequest) ([]Vtxo, string, error)
+	// ListRounds returns a page of the finalized rounds, sorted by ending time,
+	// and the token of the next page.
+	ListRounds(ctx context.Context, req ListRoundsRequest) ([]RoundSummary, string, error)
 	GetRound(ctx context.Context, txID string) (*Round, error)
 	GetRoundByID(ctx context.Context, roundID string) (*Round, error)
+	// GetRoundTx returns the hex encoded raw round tx of a finalized round, as
+	// broadcasted onchain.
+	GetRoundTx(ctx context.Context, txID string) (string, error)
+	// GetRoundTreeBranch returns only the branch of the vtxo tree of the given
+	// round leading to the given vtxo, as a tree with one node per level.
+	GetRoundTreeBranch(ctx context.Context, roundTxid string, vtxo Outpoint) (tree.TxTree, error)
 	Close()
 	GetTransactionsStream(ctx context.Context) (<-chan TransactionEvent, func(), error)
 	SubscribeForAddress(ctx context.Context, address string) (<-chan AddressEvent, func(), error)
@@ -86,6 +123,11 @@ type Info struct {
 	UtxoMaxAmount              int64
 	VtxoMinAmount              int64
 	VtxoMaxAmount              int64
+	SettleMinAmount            int64
+	SettleMaxAmount            int64
+	// MaxReceiversPerRequest is the max number of outputs of a tx request,
+	// 0 means no limit.
+	MaxReceiversPerRequest int64
 }
 
 type RoundEventChannel struct {
@@ -149,6 +191,69 @@ func (v Vtxo) Address(server *secp256k1.PublicKey, net common.Network) (string,
 	return a.Encode()
 }
 
+// SubmitRedeemTxOptions are the optional params of SubmitRedeemTx.
+type SubmitRedeemTxOptions struct {
+	IdempotencyKey string
+}
+
+// SubmitRedeemTxOption sets one of the optional params of SubmitRedeemTx.
+type SubmitRedeemTxOption func(*SubmitRedeemTxOptions)
+
+// WithIdempotencyKey makes resubmissions of the same redeem tx with the same
+// key return the result of the first submission.
+func WithIdempotencyKey(key string) SubmitRedeemTxOption {
+	return func(o *SubmitRedeemTxOptions) {
+		o.IdempotencyKey = key
+	}
+}
+
+// NewSubmitRedeemTxOptions applies the given options to the defaults.
+func NewSubmitRedeemTxOptions(opts ...SubmitRedeemTxOption) SubmitRedeemTxOptions {
+	o := SubmitRedeemTxOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// RedeemTxResult is the outcome of the submission of a redeem tx in a batch,
+// Err is set if the tx has been rejected by the server.
+type RedeemTxResult struct {
+	SignedRedeemTx string
+	Txid           string
+	Err            error
+}
+
+type AddressVtxos struct {
+	Address        string
+	SpendableVtxos []Vtxo
+	SpentVtxos     []Vtxo
+}
+
+// VtxosPageRequest selects a page of the vtxos of a set of addresses. If
+// neither Spendable nor Spent is set, all vtxos are selected.
+type VtxosPageRequest struct {
+	Addresses []string
+	Spendable bool
+	Spent     bool
+	// PageSize is the max number of vtxos of the page, the server default is
+	// used if zero.
+	PageSize  uint32
+	PageToken string
+	MinAmount uint64
+	RoundTxid string
+}
+
+// ListRoundsRequest selects a page of the finalized rounds. If After is zero,
+// all rounds are listed.
+type ListRoundsRequest struct {
+	After time.Time
+	// Limit is the max number of rounds of the page, the server default is
+	// used if zero.
+	Limit     int
+	PageToken string
+}
+
 type TapscriptsVtxo struct {
 	Vtxo
 	Tapscripts []string
@@ -228,6 +333,21 @@ type Round struct {
 	ForfeitTxs []string
 	Connectors tree.TxTree
 	Stage      RoundStage
+	// NumOfParticipants is the size of the anonymity set of the round.
+	NumOfParticipants int
+	NumOfOutputs      int
+	TotalOutputAmount uint64
+}
+
+// RoundSummary is the lightweight view of a finalized round returned by
+// ListRounds.
+type RoundSummary struct {
+	Txid         string
+	StartedAt    time.Time
+	EndedAt      time.Time
+	InputsCount  int
+	OutputsCount int
+	TotalAmount  uint64
 }
 
 type RoundFinalizationEvent struct {
@@ -244,6 +364,16 @@ func (e RoundFinalizationEvent) isRoundEvent() {}
 type RoundFinalizedEvent struct {
 	ID   string
 	Txid string
+	// Vtxos and FeeReport are set only if the event stream is subscribed for a
+	// tx request taking part to the round.
+	Vtxos     []Vtxo
+	FeeReport *RoundFeeReport
+}
+
+type RoundFeeReport struct {
+	InputAmount  uint64
+	OutputAmount uint64
+	FeeAmount    uint64
 }
 
 func (e RoundFinalizedEvent) isRoundEvent() {}
@@ -251,6 +381,9 @@ func (e RoundFinalizedEvent) isRoundEvent() {}
 type RoundFailedEvent struct {
 	ID     string
 	Reason string
+	// Retryable is set if the round was aborted by the server, the same inputs
+	// can be registered again for a next round.
+	Retryable bool
 }
 
 func (e RoundFailedEvent) isRoundEvent() {}
@@ -84,6 +84,9 @@ func (a *grpcClient) GetInfo(ctx context.Context) (*client.Info, error) {
 		UtxoMaxAmount:              resp.GetUtxoMaxAmount(),
 		VtxoMinAmount:              resp.GetVtxoMinAmount(),
 		VtxoMaxAmount:              resp.GetVtxoMaxAmount(),
+		SettleMinAmount:            resp.GetSettleMinAmount(),
+		SettleMaxAmount:            resp.GetSettleMaxAmount(),
+		MaxReceiversPerRequest:     resp.GetMaxReceiversPerRequest(),
 	}, nil
 }
 
@@ -162,6 +165,23 @@ func (a *grpcClient) RegisterOutputsForNextRound(
 	return err
 }
 
+func (a *grpcClient) UpdateTxRequest(
+	ctx context.Context, requestID string, outputs []client.Output, musig2 *tree.Musig2,
+) error {
+	req := &arkv1.UpdateTxRequestRequest{
+		RequestId: requestID,
+		Outputs:   outs(outputs).toProto(),
+	}
+	if musig2 != nil {
+		req.Musig2 = &arkv1.Musig2{
+			CosignersPublicKeys: musig2.CosignersPublicKeys,
+			SigningAll:          musig2.SigningType == tree.SignAll,
+		}
+	}
+	_, err := a.svc.UpdateTxRequest(ctx, req)
+	return err
+}
+
 func (a *grpcClient) SubmitTreeNonces(
 	ctx context.Context, roundID, cosignerPubkey string, nonces tree.TreeNonces,
 ) error {
@@ -230,7 +250,9 @@ func (a *grpcClient) GetEventStream(
 ) (<-chan client.RoundEventChannel, func(), error) {
 	ctx, cancel := context.WithCancel(ctx)
 
-	stream, err := a.svc.GetEventStream(ctx, &arkv1.GetEventStreamRequest{})
+	stream, err := a.svc.GetEventStream(ctx, &arkv1.GetEventStreamRequest{
+		RequestId: requestID,
+	})
 	if err != nil {
 		cancel()
 		return nil, nil, err
@@ -286,10 +308,12 @@ func (a *grpcClient) Ping(
 }
 
 func (a *grpcClient) SubmitRedeemTx(
-	ctx context.Context, redeemTx string,
+	ctx context.Context, redeemTx string, opts ...client.SubmitRedeemTxOption,
 ) (string, string, error) {
+	o := client.NewSubmitRedeemTxOptions(opts...)
 	req := &arkv1.SubmitRedeemTxRequest{
-		RedeemTx: redeemTx,
+		RedeemTx:       redeemTx,
+		IdempotencyKey: o.IdempotencyKey,
 	}
 
 	resp, err := a.svc.SubmitRedeemTx(ctx, req)
@@ -300,6 +324,50 @@ func (a *grpcClient) SubmitRedeemTx(
 	return resp.GetSignedRedeemTx(), resp.GetTxid(), nil
 }
 
+func (a *grpcClient) ValidateRedeemTx(
+	ctx context.Context, redeemTx string,
+) (string, error) {
+	req := &arkv1.ValidateRedeemTxRequest{
+		RedeemTx: redeemTx,
+	}
+
+	resp, err := a.svc.ValidateRedeemTx(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	if !resp.GetValid() {
+		return "", fmt.Errorf("invalid redeem tx: %s", resp.GetError())
+	}
+
+	return resp.GetTxid(), nil
+}
+
+func (a *grpcClient) SubmitRedeemTxs(
+	ctx context.Context, redeemTxs []string,
+) ([]client.RedeemTxResult, error) {
+	req := &arkv1.SubmitRedeemTxsRequest{
+		RedeemTxs: redeemTxs,
+	}
+
+	resp, err := a.svc.SubmitRedeemTxs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]client.RedeemTxResult, 0, len(resp.GetResults()))
+	for _, r := range resp.GetResults() {
+		result := client.RedeemTxResult{
+			SignedRedeemTx: r.GetSignedRedeemTx(),
+			Txid:           r.GetTxid(),
+		}
+		if r.GetError() != "" {
+			result.Err = fmt.Errorf("%s", r.GetError())
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 func (a *grpcClient) GetRound(
 	ctx context.Context, txID string,
 ) (*client.Round, error) {
@@ -324,9 +392,39 @@ func (a *grpcClient) GetRound(
 		ForfeitTxs: round.GetForfeitTxs(),
 		Connectors: treeFromProto{round.GetConnectors()}.parse(),
 		Stage:      client.RoundStage(int(round.GetStage())),
+
+		NumOfParticipants: int(round.GetNumOfParticipants()),
+		NumOfOutputs:      int(round.GetNumOfOutputs()),
+		TotalOutputAmount: round.GetTotalOutputAmount(),
 	}, nil
 }
 
+func (a *grpcClient) GetRoundTx(ctx context.Context, txID string) (string, error) {
+	req := &arkv1.GetRoundRequest{Txid: txID, IncludeRawTx: true}
+	resp, err := a.svc.GetRound(ctx, req)
+	if err != nil {
+		return "", err
+	}
+	return resp.GetRawRoundTx(), nil
+}
+
+func (a *grpcClient) GetRoundTreeBranch(
+	ctx context.Context, roundTxid string, vtxo client.Outpoint,
+) (tree.TxTree, error) {
+	req := &arkv1.GetRoundTreeBranchRequest{
+		RoundTxid: roundTxid,
+		Vtxo: &arkv1.Outpoint{
+			Txid: vtxo.Txid,
+			Vout: vtxo.VOut,
+		},
+	}
+	resp, err := a.svc.GetRoundTreeBranch(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return treeFromProto{resp.GetBranch()}.parse(), nil
+}
+
 func (a *grpcClient) GetRoundByID(
 	ctx context.Context, roundID string,
 ) (*client.Round, error) {
@@ -352,6 +450,10 @@ func (a *grpcClient) GetRoundByID(
 		ForfeitTxs: round.GetForfeitTxs(),
 		Connectors: treeFromProto{round.GetConnectors()}.parse(),
 		Stage:      client.RoundStage(int(round.GetStage())),
+
+		NumOfParticipants: int(round.GetNumOfParticipants()),
+		NumOfOutputs:      int(round.GetNumOfOutputs()),
+		TotalOutputAmount: round.GetTotalOutputAmount(),
 	}, nil
 }
 
@@ -365,6 +467,75 @@ func (a *grpcClient) ListVtxos(
 	return vtxos(resp.GetSpendableVtxos()).toVtxos(), vtxos(resp.GetSpentVtxos()).toVtxos(), nil
 }
 
+func (a *grpcClient) ListVtxosForAddresses(
+	ctx context.Context, addrs []string,
+) ([]client.AddressVtxos, error) {
+	resp, err := a.svc.ListVtxosForAddresses(
+		ctx, &arkv1.ListVtxosForAddressesRequest{Addresses: addrs},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]client.AddressVtxos, 0, len(resp.GetVtxos()))
+	for _, v := range resp.GetVtxos() {
+		list = append(list, client.AddressVtxos{
+			Address:        v.GetAddress(),
+			SpendableVtxos: vtxos(v.GetSpendableVtxos()).toVtxos(),
+			SpentVtxos:     vtxos(v.GetSpentVtxos()).toVtxos(),
+		})
+	}
+	return list, nil
+}
+
+func (a *grpcClient) ListVtxosPaged(
+	ctx context.Context, req client.VtxosPageRequest,
+) ([]client.Vtxo, string, error) {
+	resp, err := a.svc.ListVtxosPaged(ctx, &arkv1.ListVtxosPagedRequest{
+		Addresses: req.Addresses,
+		Spendable: req.Spendable,
+		Spent:     req.Spent,
+		PageSize:  req.PageSize,
+		PageToken: req.PageToken,
+		MinAmount: req.MinAmount,
+		RoundTxid: req.RoundTxid,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return vtxos(resp.GetVtxos()).toVtxos(), resp.GetNextPageToken(), nil
+}
+
+func (a *grpcClient) ListRounds(
+	ctx context.Context, req client.ListRoundsRequest,
+) ([]client.RoundSummary, string, error) {
+	var after int64
+	if !req.After.IsZero() {
+		after = req.After.Unix()
+	}
+	resp, err := a.svc.ListRounds(ctx, &arkv1.ListRoundsRequest{
+		After:     after,
+		Limit:     uint32(req.Limit),
+		PageToken: req.PageToken,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	rounds := make([]client.RoundSummary, 0, len(resp.GetRounds()))
+	for _, r := range resp.GetRounds() {
+		rounds = append(rounds, client.RoundSummary{
+			Txid:         r.GetTxid(),
+			StartedAt:    time.Unix(r.GetStart(), 0),
+			EndedAt:      time.Unix(r.GetEnd(), 0),
+			InputsCount:  int(r.GetInputsCount()),
+			OutputsCount: int(r.GetOutputsCount()),
+			TotalAmount:  r.GetTotalAmount(),
+		})
+	}
+	return rounds, resp.GetNextPageToken(), nil
+}
+
 func (c *grpcClient) Close() {
 	//nolint:all
 	c.conn.Close()
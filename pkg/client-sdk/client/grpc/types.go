@@ -47,8 +47,9 @@ type event struct {
 func (e event) toRoundEvent() (client.RoundEvent, error) {
 	if ee := e.GetRoundFailed(); ee != nil {
 		return client.RoundFailedEvent{
-			ID:     ee.GetId(),
-			Reason: ee.GetReason(),
+			ID:        ee.GetId(),
+			Reason:    ee.GetReason(),
+			Retryable: ee.GetRetryable(),
 		}, nil
 	}
 	if ee := e.GetRoundFinalization(); ee != nil {
@@ -67,9 +68,19 @@ func (e event) toRoundEvent() (client.RoundEvent, error) {
 	}
 
 	if ee := e.GetRoundFinalized(); ee != nil {
+		var feeReport *client.RoundFeeReport
+		if report := ee.GetFeeReport(); report != nil {
+			feeReport = &client.RoundFeeReport{
+				InputAmount:  report.GetInputAmount(),
+				OutputAmount: report.GetOutputAmount(),
+				FeeAmount:    report.GetFeeAmount(),
+			}
+		}
 		return client.RoundFinalizedEvent{
-			ID:   ee.GetId(),
-			Txid: ee.GetRoundTxid(),
+			ID:        ee.GetId(),
+			Txid:      ee.GetRoundTxid(),
+			Vtxos:     vtxos(ee.GetVtxos()).toVtxos(),
+			FeeReport: feeReport,
 		}, nil
 	}
 
@@ -116,6 +116,26 @@ func (a *restClient) GetInfo(
 	if err != nil {
 		return nil, err
 	}
+	// settle bounds are not returned by older servers
+	settleMinAmount, settleMaxAmount := -1, -1
+	if resp.Payload.SettleMinAmount != "" {
+		if settleMinAmount, err = strconv.Atoi(resp.Payload.SettleMinAmount); err != nil {
+			return nil, err
+		}
+	}
+	if resp.Payload.SettleMaxAmount != "" {
+		if settleMaxAmount, err = strconv.Atoi(resp.Payload.SettleMaxAmount); err != nil {
+			return nil, err
+		}
+	}
+	var maxReceiversPerRequest int
+	if resp.Payload.MaxReceiversPerRequest != "" {
+		if maxReceiversPerRequest, err = strconv.Atoi(
+			resp.Payload.MaxReceiversPerRequest,
+		); err != nil {
+			return nil, err
+		}
+	}
 
 	return &client.Info{
 		PubKey:                     resp.Payload.Pubkey,
@@ -136,6 +156,9 @@ func (a *restClient) GetInfo(
 		UtxoMaxAmount:              int64(utxoMaxAmount),
 		VtxoMinAmount:              int64(vtxoMinAmount),
 		VtxoMaxAmount:              int64(vtxoMaxAmount),
+		SettleMinAmount:            int64(settleMinAmount),
+		SettleMaxAmount:            int64(settleMaxAmount),
+		MaxReceiversPerRequest:     int64(maxReceiversPerRequest),
 	}, nil
 }
 
@@ -245,6 +268,32 @@ func (a *restClient) RegisterOutputsForNextRound(
 	return err
 }
 
+func (a *restClient) UpdateTxRequest(
+	ctx context.Context, requestID string, outputs []client.Output, musig2 *tree.Musig2,
+) error {
+	outs := make([]*models.V1Output, 0, len(outputs))
+	for _, o := range outputs {
+		outs = append(outs, &models.V1Output{
+			Address: o.Address,
+			Amount:  strconv.Itoa(int(o.Amount)),
+		})
+	}
+	body := models.V1UpdateTxRequestRequest{
+		RequestID: requestID,
+		Outputs:   outs,
+	}
+	if musig2 != nil {
+		body.Musig2 = &models.V1Musig2{
+			CosignersPublicKeys: musig2.CosignersPublicKeys,
+			SigningAll:          musig2.SigningType == tree.SignAll,
+		}
+	}
+	_, err := a.svc.ArkServiceUpdateTxRequest(
+		ark_service.NewArkServiceUpdateTxRequestParams().WithBody(&body),
+	)
+	return err
+}
+
 func (a *restClient) SubmitTreeNonces(
 	ctx context.Context, roundID, cosignerPubkey string,
 	nonces tree.TreeNonces,
@@ -319,6 +368,9 @@ func (c *restClient) GetEventStream(
 	eventsCh := make(chan client.RoundEventChannel)
 	chunkCh := make(chan chunk)
 	url := fmt.Sprintf("%s/v1/events", c.serverURL)
+	if len(requestID) > 0 {
+		url = fmt.Sprintf("%s?requestId=%s", url, requestID)
+	}
 
 	go listenToStream(url, chunkCh)
 
@@ -366,8 +418,9 @@ func (c *restClient) GetEventStream(
 				case resp.Result.RoundFailed != nil:
 					e := resp.Result.RoundFailed
 					event = client.RoundFailedEvent{
-						ID:     e.ID,
-						Reason: e.Reason,
+						ID:        e.ID,
+						Reason:    e.Reason,
+						Retryable: e.Retryable,
 					}
 				case resp.Result.RoundFinalization != nil:
 					e := resp.Result.RoundFinalization
@@ -390,9 +443,18 @@ func (c *restClient) GetEventStream(
 					}
 				case resp.Result.RoundFinalized != nil:
 					e := resp.Result.RoundFinalized
+					var feeReport *client.RoundFeeReport
+					if e.FeeReport != nil {
+						feeReport, _err = feeReportFromRest(e.FeeReport)
+						if _err != nil {
+							break
+						}
+					}
 					event = client.RoundFinalizedEvent{
-						ID:   e.ID,
-						Txid: e.RoundTxid,
+						ID:        e.ID,
+						Txid:      e.RoundTxid,
+						Vtxos:     vtxosFromRest(e.Vtxos),
+						FeeReport: feeReport,
 					}
 				case resp.Result.RoundSigning != nil:
 					e := resp.Result.RoundSigning
@@ -437,10 +499,12 @@ func (a *restClient) Ping(
 }
 
 func (a *restClient) SubmitRedeemTx(
-	ctx context.Context, redeemTx string,
+	ctx context.Context, redeemTx string, opts ...client.SubmitRedeemTxOption,
 ) (string, string, error) {
+	o := client.NewSubmitRedeemTxOptions(opts...)
 	req := &models.V1SubmitRedeemTxRequest{
-		RedeemTx: redeemTx,
+		RedeemTx:       redeemTx,
+		IdempotencyKey: o.IdempotencyKey,
 	}
 	resp, err := a.svc.ArkServiceSubmitRedeemTx(
 		ark_service.NewArkServiceSubmitRedeemTxParams().WithBody(req),
@@ -451,6 +515,51 @@ func (a *restClient) SubmitRedeemTx(
 	return resp.Payload.SignedRedeemTx, resp.Payload.Txid, nil
 }
 
+func (a *restClient) ValidateRedeemTx(
+	ctx context.Context, redeemTx string,
+) (string, error) {
+	req := &models.V1ValidateRedeemTxRequest{
+		RedeemTx: redeemTx,
+	}
+	resp, err := a.svc.ArkServiceValidateRedeemTx(
+		ark_service.NewArkServiceValidateRedeemTxParams().WithBody(req),
+	)
+	if err != nil {
+		return "", err
+	}
+	if !resp.Payload.Valid {
+		return "", fmt.Errorf("invalid redeem tx: %s", resp.Payload.Error)
+	}
+	return resp.Payload.Txid, nil
+}
+
+func (a *restClient) SubmitRedeemTxs(
+	ctx context.Context, redeemTxs []string,
+) ([]client.RedeemTxResult, error) {
+	req := &models.V1SubmitRedeemTxsRequest{
+		RedeemTxs: redeemTxs,
+	}
+	resp, err := a.svc.ArkServiceSubmitRedeemTxs(
+		ark_service.NewArkServiceSubmitRedeemTxsParams().WithBody(req),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]client.RedeemTxResult, 0, len(resp.Payload.Results))
+	for _, r := range resp.Payload.Results {
+		result := client.RedeemTxResult{
+			SignedRedeemTx: r.SignedRedeemTx,
+			Txid:           r.Txid,
+		}
+		if r.Error != "" {
+			result.Err = fmt.Errorf("%s", r.Error)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
 func (a *restClient) GetRound(
 	ctx context.Context, txID string,
 ) (*client.Round, error) {
@@ -478,6 +587,11 @@ func (a *restClient) GetRound(
 		endedAt = &t
 	}
 
+	totalOutputAmount, err := parseRoundAmount(resp.Payload.Round.TotalOutputAmount)
+	if err != nil {
+		return nil, err
+	}
+
 	return &client.Round{
 		ID:         resp.Payload.Round.ID,
 		StartedAt:  &startedAt,
@@ -487,9 +601,41 @@ func (a *restClient) GetRound(
 		ForfeitTxs: resp.Payload.Round.ForfeitTxs,
 		Connectors: treeFromProto{resp.Payload.Round.Connectors}.parse(),
 		Stage:      toRoundStage(*resp.Payload.Round.Stage),
+
+		NumOfParticipants: int(resp.Payload.Round.NumOfParticipants),
+		NumOfOutputs:      int(resp.Payload.Round.NumOfOutputs),
+		TotalOutputAmount: totalOutputAmount,
 	}, nil
 }
 
+func (a *restClient) GetRoundTx(ctx context.Context, txID string) (string, error) {
+	includeRawTx := true
+	resp, err := a.explorerSvc.ExplorerServiceGetRound(
+		explorer_service.NewExplorerServiceGetRoundParams().
+			WithTxid(txID).WithIncludeRawTx(&includeRawTx),
+	)
+	if err != nil {
+		return "", err
+	}
+	return resp.Payload.RawRoundTx, nil
+}
+
+func (a *restClient) GetRoundTreeBranch(
+	ctx context.Context, roundTxid string, vtxo client.Outpoint,
+) (tree.TxTree, error) {
+	vout := int64(vtxo.VOut)
+	resp, err := a.explorerSvc.ExplorerServiceGetRoundTreeBranch(
+		explorer_service.NewExplorerServiceGetRoundTreeBranchParams().
+			WithRoundTxid(roundTxid).
+			WithVtxoTxid(&vtxo.Txid).
+			WithVtxoVout(&vout),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return treeFromProto{resp.Payload.Branch}.parse(), nil
+}
+
 func (a *restClient) GetRoundByID(
 	ctx context.Context, roundID string,
 ) (*client.Round, error) {
@@ -517,6 +663,11 @@ func (a *restClient) GetRoundByID(
 		endedAt = &t
 	}
 
+	totalOutputAmount, err := parseRoundAmount(resp.Payload.Round.TotalOutputAmount)
+	if err != nil {
+		return nil, err
+	}
+
 	return &client.Round{
 		ID:         resp.Payload.Round.ID,
 		StartedAt:  &startedAt,
@@ -526,6 +677,10 @@ func (a *restClient) GetRoundByID(
 		ForfeitTxs: resp.Payload.Round.ForfeitTxs,
 		Connectors: treeFromProto{resp.Payload.Round.Connectors}.parse(),
 		Stage:      toRoundStage(*resp.Payload.Round.Stage),
+
+		NumOfParticipants: int(resp.Payload.Round.NumOfParticipants),
+		NumOfOutputs:      int(resp.Payload.Round.NumOfOutputs),
+		TotalOutputAmount: totalOutputAmount,
 	}, nil
 }
 
@@ -545,6 +700,101 @@ func (a *restClient) ListVtxos(
 	return spendableVtxos, spentVtxos, nil
 }
 
+func (a *restClient) ListVtxosForAddresses(
+	ctx context.Context, addrs []string,
+) ([]client.AddressVtxos, error) {
+	body := &models.V1ListVtxosForAddressesRequest{Addresses: addrs}
+	resp, err := a.explorerSvc.ExplorerServiceListVtxosForAddresses(
+		explorer_service.NewExplorerServiceListVtxosForAddressesParams().WithBody(body),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]client.AddressVtxos, 0, len(resp.Payload.Vtxos))
+	for _, v := range resp.Payload.Vtxos {
+		list = append(list, client.AddressVtxos{
+			Address:        v.Address,
+			SpendableVtxos: vtxosFromRest(v.SpendableVtxos),
+			SpentVtxos:     vtxosFromRest(v.SpentVtxos),
+		})
+	}
+	return list, nil
+}
+
+func (a *restClient) ListVtxosPaged(
+	ctx context.Context, req client.VtxosPageRequest,
+) ([]client.Vtxo, string, error) {
+	body := &models.V1ListVtxosPagedRequest{
+		Addresses: req.Addresses,
+		Spendable: req.Spendable,
+		Spent:     req.Spent,
+		PageSize:  int64(req.PageSize),
+		PageToken: req.PageToken,
+		MinAmount: strconv.FormatUint(req.MinAmount, 10),
+		RoundTxid: req.RoundTxid,
+	}
+	resp, err := a.explorerSvc.ExplorerServiceListVtxosPaged(
+		explorer_service.NewExplorerServiceListVtxosPagedParams().WithBody(body),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return vtxosFromRest(resp.Payload.Vtxos), resp.Payload.NextPageToken, nil
+}
+
+func (a *restClient) ListRounds(
+	ctx context.Context, req client.ListRoundsRequest,
+) ([]client.RoundSummary, string, error) {
+	params := explorer_service.NewExplorerServiceListRoundsParams()
+	if !req.After.IsZero() {
+		after := req.After.Unix()
+		params.SetAfter(&after)
+	}
+	if req.Limit > 0 {
+		limit := int64(req.Limit)
+		params.SetLimit(&limit)
+	}
+	if req.PageToken != "" {
+		params.SetPageToken(&req.PageToken)
+	}
+
+	resp, err := a.explorerSvc.ExplorerServiceListRounds(params)
+	if err != nil {
+		return nil, "", err
+	}
+
+	rounds := make([]client.RoundSummary, 0, len(resp.Payload.Rounds))
+	for _, r := range resp.Payload.Rounds {
+		start, err := strconv.ParseInt(r.Start, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		end, err := strconv.ParseInt(r.End, 10, 64)
+		if err != nil {
+			return nil, "", err
+		}
+		var amount uint64
+		if r.TotalAmount != "" {
+			amount, err = strconv.ParseUint(r.TotalAmount, 10, 64)
+			if err != nil {
+				return nil, "", err
+			}
+		}
+		rounds = append(rounds, client.RoundSummary{
+			Txid:         r.Txid,
+			StartedAt:    time.Unix(start, 0),
+			EndedAt:      time.Unix(end, 0),
+			InputsCount:  int(r.InputsCount),
+			OutputsCount: int(r.OutputsCount),
+			TotalAmount:  amount,
+		})
+	}
+
+	return rounds, resp.Payload.NextPageToken, nil
+}
+
 func (c *restClient) GetTransactionsStream(ctx context.Context) (<-chan client.TransactionEvent, func(), error) {
 	ctx, cancel := context.WithCancel(ctx)
 	eventsCh := make(chan client.TransactionEvent)
@@ -811,6 +1061,39 @@ func outpointsFromRest(restOutpoints []*models.V1Outpoint) []client.Outpoint {
 	return outpoints
 }
 
+func feeReportFromRest(report *models.V1RoundFeeReport) (*client.RoundFeeReport, error) {
+	amounts := make([]uint64, 0, 3)
+	for _, amount := range []string{
+		report.InputAmount, report.OutputAmount, report.FeeAmount,
+	} {
+		if len(amount) <= 0 {
+			amounts = append(amounts, 0)
+			continue
+		}
+		value, err := strconv.ParseUint(amount, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee report amount %s: %s", amount, err)
+		}
+		amounts = append(amounts, value)
+	}
+	return &client.RoundFeeReport{
+		InputAmount:  amounts[0],
+		OutputAmount: amounts[1],
+		FeeAmount:    amounts[2],
+	}, nil
+}
+
+func parseRoundAmount(amount string) (uint64, error) {
+	if len(amount) <= 0 {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(amount, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid round amount %s: %s", amount, err)
+	}
+	return value, nil
+}
+
 func vtxosFromRest(restVtxos []*models.V1Vtxo) []client.Vtxo {
 	vtxos := make([]client.Vtxo, len(restVtxos))
 	for i, v := range restVtxos {
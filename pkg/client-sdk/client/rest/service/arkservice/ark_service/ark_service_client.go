@@ -72,12 +72,18 @@ type ClientService interface {
 
 	ArkServiceSubmitRedeemTx(params *ArkServiceSubmitRedeemTxParams, opts ...ClientOption) (*ArkServiceSubmitRedeemTxOK, error)
 
+	ArkServiceSubmitRedeemTxs(params *ArkServiceSubmitRedeemTxsParams, opts ...ClientOption) (*ArkServiceSubmitRedeemTxsOK, error)
+
 	ArkServiceSubmitSignedForfeitTxs(params *ArkServiceSubmitSignedForfeitTxsParams, opts ...ClientOption) (*ArkServiceSubmitSignedForfeitTxsOK, error)
 
 	ArkServiceSubmitTreeNonces(params *ArkServiceSubmitTreeNoncesParams, opts ...ClientOption) (*ArkServiceSubmitTreeNoncesOK, error)
 
 	ArkServiceSubmitTreeSignatures(params *ArkServiceSubmitTreeSignaturesParams, opts ...ClientOption) (*ArkServiceSubmitTreeSignaturesOK, error)
 
+	ArkServiceUpdateTxRequest(params *ArkServiceUpdateTxRequestParams, opts ...ClientOption) (*ArkServiceUpdateTxRequestOK, error)
+
+	ArkServiceValidateRedeemTx(params *ArkServiceValidateRedeemTxParams, opts ...ClientOption) (*ArkServiceValidateRedeemTxOK, error)
+
 	SetTransport(transport runtime.ClientTransport)
 }
 
@@ -414,6 +420,45 @@ func (a *Client) ArkServiceSubmitRedeemTx(params *ArkServiceSubmitRedeemTxParams
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+ArkServiceSubmitRedeemTxs submit redeem txs is the batched version of submit redeem tx, the txs are
+
+processed concurrently and a result is returned for each of them.
+*/
+func (a *Client) ArkServiceSubmitRedeemTxs(params *ArkServiceSubmitRedeemTxsParams, opts ...ClientOption) (*ArkServiceSubmitRedeemTxsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewArkServiceSubmitRedeemTxsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ArkService_SubmitRedeemTxs",
+		Method:             "POST",
+		PathPattern:        "/v1/redeem-txs",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ArkServiceSubmitRedeemTxsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ArkServiceSubmitRedeemTxsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ArkServiceSubmitRedeemTxsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 ArkServiceSubmitSignedForfeitTxs ark service submit signed forfeit txs API
 */
@@ -525,6 +570,82 @@ func (a *Client) ArkServiceSubmitTreeSignatures(params *ArkServiceSubmitTreeSign
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+ArkServiceUpdateTxRequest ark service update tx request API
+*/
+func (a *Client) ArkServiceUpdateTxRequest(params *ArkServiceUpdateTxRequestParams, opts ...ClientOption) (*ArkServiceUpdateTxRequestOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewArkServiceUpdateTxRequestParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ArkService_UpdateTxRequest",
+		Method:             "POST",
+		PathPattern:        "/v1/round/updateTxRequest",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ArkServiceUpdateTxRequestReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ArkServiceUpdateTxRequestOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ArkServiceUpdateTxRequestDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+ArkServiceValidateRedeemTx validate redeem tx runs the same checks of submit redeem tx against the given
+
+redeem tx without signing, broadcasting or reserving anything.
+*/
+func (a *Client) ArkServiceValidateRedeemTx(params *ArkServiceValidateRedeemTxParams, opts ...ClientOption) (*ArkServiceValidateRedeemTxOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewArkServiceValidateRedeemTxParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ArkService_ValidateRedeemTx",
+		Method:             "POST",
+		PathPattern:        "/v1/redeem-tx/validate",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ArkServiceValidateRedeemTxReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ArkServiceValidateRedeemTxOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ArkServiceValidateRedeemTxDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 // SetTransport changes the transport on the client
 func (a *Client) SetTransport(transport runtime.ClientTransport) {
 	a.transport = transport
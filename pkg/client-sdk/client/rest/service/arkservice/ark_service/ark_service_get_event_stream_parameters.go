@@ -60,6 +60,14 @@ ArkServiceGetEventStreamParams contains all the parameters to send to the API en
 	Typically these are written to a http.Request.
 */
 type ArkServiceGetEventStreamParams struct {
+
+	/* RequestID.
+
+	     Optional, the id used to register inputs and outputs. If set, the round
+	finalized event includes the outcome of the round for the request.
+	*/
+	RequestID *string
+
 	timeout    time.Duration
 	Context    context.Context
 	HTTPClient *http.Client
@@ -113,6 +121,17 @@ func (o *ArkServiceGetEventStreamParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithRequestID adds the requestID to the ark service get event stream params
+func (o *ArkServiceGetEventStreamParams) WithRequestID(requestID *string) *ArkServiceGetEventStreamParams {
+	o.SetRequestID(requestID)
+	return o
+}
+
+// SetRequestID adds the requestId to the ark service get event stream params
+func (o *ArkServiceGetEventStreamParams) SetRequestID(requestID *string) {
+	o.RequestID = requestID
+}
+
 // WriteToRequest writes these params to a swagger request
 func (o *ArkServiceGetEventStreamParams) WriteToRequest(r runtime.ClientRequest, reg strfmt.Registry) error {
 
@@ -121,6 +140,23 @@ func (o *ArkServiceGetEventStreamParams) WriteToRequest(r runtime.ClientRequest,
 	}
 	var res []error
 
+	if o.RequestID != nil {
+
+		// query param requestId
+		var qrRequestID string
+
+		if o.RequestID != nil {
+			qrRequestID = *o.RequestID
+		}
+		qRequestID := qrRequestID
+		if qRequestID != "" {
+
+			if err := r.SetQueryParam("requestId", qRequestID); err != nil {
+				return err
+			}
+		}
+	}
+
 	if len(res) > 0 {
 		return errors.CompositeValidationError(res...)
 	}
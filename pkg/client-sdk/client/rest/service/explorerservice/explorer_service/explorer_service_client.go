@@ -58,8 +58,16 @@ type ClientService interface {
 
 	ExplorerServiceGetRoundByID(params *ExplorerServiceGetRoundByIDParams, opts ...ClientOption) (*ExplorerServiceGetRoundByIDOK, error)
 
+	ExplorerServiceGetRoundTreeBranch(params *ExplorerServiceGetRoundTreeBranchParams, opts ...ClientOption) (*ExplorerServiceGetRoundTreeBranchOK, error)
+
+	ExplorerServiceListRounds(params *ExplorerServiceListRoundsParams, opts ...ClientOption) (*ExplorerServiceListRoundsOK, error)
+
 	ExplorerServiceListVtxos(params *ExplorerServiceListVtxosParams, opts ...ClientOption) (*ExplorerServiceListVtxosOK, error)
 
+	ExplorerServiceListVtxosForAddresses(params *ExplorerServiceListVtxosForAddressesParams, opts ...ClientOption) (*ExplorerServiceListVtxosForAddressesOK, error)
+
+	ExplorerServiceListVtxosPaged(params *ExplorerServiceListVtxosPagedParams, opts ...ClientOption) (*ExplorerServiceListVtxosPagedOK, error)
+
 	ExplorerServiceSubscribeForAddress(params *ExplorerServiceSubscribeForAddressParams, opts ...ClientOption) (*ExplorerServiceSubscribeForAddressOK, error)
 
 	SetTransport(transport runtime.ClientTransport)
@@ -139,6 +147,84 @@ func (a *Client) ExplorerServiceGetRoundByID(params *ExplorerServiceGetRoundByID
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+ExplorerServiceGetRoundTreeBranch gets round tree branch returns only the nodes of the vtxo tree of a round
+
+from the root to the leaf of the given vtxo, rather than the whole tree.
+*/
+func (a *Client) ExplorerServiceGetRoundTreeBranch(params *ExplorerServiceGetRoundTreeBranchParams, opts ...ClientOption) (*ExplorerServiceGetRoundTreeBranchOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExplorerServiceGetRoundTreeBranchParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ExplorerService_GetRoundTreeBranch",
+		Method:             "GET",
+		PathPattern:        "/v1/round/{roundTxid}/branch",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ExplorerServiceGetRoundTreeBranchReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExplorerServiceGetRoundTreeBranchOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ExplorerServiceGetRoundTreeBranchDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+ExplorerServiceListRounds lists rounds returns a page of the finalized rounds, sorted by ending time
+
+and txid.
+*/
+func (a *Client) ExplorerServiceListRounds(params *ExplorerServiceListRoundsParams, opts ...ClientOption) (*ExplorerServiceListRoundsOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExplorerServiceListRoundsParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ExplorerService_ListRounds",
+		Method:             "GET",
+		PathPattern:        "/v1/rounds",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ExplorerServiceListRoundsReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExplorerServiceListRoundsOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ExplorerServiceListRoundsDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 ExplorerServiceListVtxos explorer service list vtxos API
 */
@@ -176,6 +262,84 @@ func (a *Client) ExplorerServiceListVtxos(params *ExplorerServiceListVtxosParams
 	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
 }
 
+/*
+ExplorerServiceListVtxosForAddresses lists vtxos for addresses is the batched version of list vtxos, the vtxos of all
+
+the given addresses are fetched with a single query.
+*/
+func (a *Client) ExplorerServiceListVtxosForAddresses(params *ExplorerServiceListVtxosForAddressesParams, opts ...ClientOption) (*ExplorerServiceListVtxosForAddressesOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExplorerServiceListVtxosForAddressesParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ExplorerService_ListVtxosForAddresses",
+		Method:             "POST",
+		PathPattern:        "/v1/vtxos",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ExplorerServiceListVtxosForAddressesReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExplorerServiceListVtxosForAddressesOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ExplorerServiceListVtxosForAddressesDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
+/*
+ExplorerServiceListVtxosPaged lists vtxos paged returns a page of the vtxos of the given addresses, filtered
+
+by state, min amount and round. The vtxos are sorted by outpoint.
+*/
+func (a *Client) ExplorerServiceListVtxosPaged(params *ExplorerServiceListVtxosPagedParams, opts ...ClientOption) (*ExplorerServiceListVtxosPagedOK, error) {
+	// TODO: Validate the params before sending
+	if params == nil {
+		params = NewExplorerServiceListVtxosPagedParams()
+	}
+	op := &runtime.ClientOperation{
+		ID:                 "ExplorerService_ListVtxosPaged",
+		Method:             "POST",
+		PathPattern:        "/v1/vtxos/paged",
+		ProducesMediaTypes: []string{"application/json"},
+		ConsumesMediaTypes: []string{"application/json"},
+		Schemes:            []string{"http"},
+		Params:             params,
+		Reader:             &ExplorerServiceListVtxosPagedReader{formats: a.formats},
+		Context:            params.Context,
+		Client:             params.HTTPClient,
+	}
+	for _, opt := range opts {
+		opt(op)
+	}
+
+	result, err := a.transport.Submit(op)
+	if err != nil {
+		return nil, err
+	}
+	success, ok := result.(*ExplorerServiceListVtxosPagedOK)
+	if ok {
+		return success, nil
+	}
+	// unexpected success response
+	unexpectedSuccess := result.(*ExplorerServiceListVtxosPagedDefault)
+	return nil, runtime.NewAPIError("unexpected success response: content available as default response in error", unexpectedSuccess, unexpectedSuccess.Code())
+}
+
 /*
 ExplorerServiceSubscribeForAddress explorer service subscribe for address API
 */
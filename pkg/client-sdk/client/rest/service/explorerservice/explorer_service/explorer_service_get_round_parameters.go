@@ -14,6 +14,7 @@ import (
 	"github.com/go-openapi/runtime"
 	cr "github.com/go-openapi/runtime/client"
 	"github.com/go-openapi/strfmt"
+	"github.com/go-openapi/swag"
 )
 
 // NewExplorerServiceGetRoundParams creates a new ExplorerServiceGetRoundParams object,
@@ -61,6 +62,13 @@ ExplorerServiceGetRoundParams contains all the parameters to send to the API end
 */
 type ExplorerServiceGetRoundParams struct {
 
+	/* IncludeRawTx.
+
+	     If true, the response includes the raw signed round tx as broadcasted
+	onchain, the round must be finalized.
+	*/
+	IncludeRawTx *bool
+
 	// Txid.
 	Txid string
 
@@ -117,6 +125,17 @@ func (o *ExplorerServiceGetRoundParams) SetHTTPClient(client *http.Client) {
 	o.HTTPClient = client
 }
 
+// WithIncludeRawTx adds the includeRawTx to the explorer service get round params
+func (o *ExplorerServiceGetRoundParams) WithIncludeRawTx(includeRawTx *bool) *ExplorerServiceGetRoundParams {
+	o.SetIncludeRawTx(includeRawTx)
+	return o
+}
+
+// SetIncludeRawTx adds the includeRawTx to the explorer service get round params
+func (o *ExplorerServiceGetRoundParams) SetIncludeRawTx(includeRawTx *bool) {
+	o.IncludeRawTx = includeRawTx
+}
+
 // WithTxid adds the txid to the explorer service get round params
 func (o *ExplorerServiceGetRoundParams) WithTxid(txid string) *ExplorerServiceGetRoundParams {
 	o.SetTxid(txid)
@@ -136,6 +155,23 @@ func (o *ExplorerServiceGetRoundParams) WriteToRequest(r runtime.ClientRequest,
 	}
 	var res []error
 
+	if o.IncludeRawTx != nil {
+
+		// query param includeRawTx
+		var qrIncludeRawTx bool
+
+		if o.IncludeRawTx != nil {
+			qrIncludeRawTx = *o.IncludeRawTx
+		}
+		qIncludeRawTx := swag.FormatBool(qrIncludeRawTx)
+		if qIncludeRawTx != "" {
+
+			if err := r.SetQueryParam("includeRawTx", qIncludeRawTx); err != nil {
+				return err
+			}
+		}
+	}
+
 	// path param txid
 	if err := r.SetPathParam("txid", o.Txid); err != nil {
 		return err
@@ -33,6 +33,9 @@ type V1GetInfoResponse struct {
 	// market hour
 	MarketHour *V1MarketHour `json:"marketHour,omitempty"`
 
+	// max number of outputs of a tx request, 0 means no limit (default)
+	MaxReceiversPerRequest string `json:"maxReceiversPerRequest,omitempty"`
+
 	// network
 	Network string `json:"network,omitempty"`
 
@@ -42,6 +45,12 @@ type V1GetInfoResponse struct {
 	// round interval
 	RoundInterval string `json:"roundInterval,omitempty"`
 
+	// total amount of a tx request, <= 0 means no limit (default -1)
+	SettleMaxAmount string `json:"settleMaxAmount,omitempty"`
+
+	// total amount of a tx request, <= 0 means no limit (default -1)
+	SettleMinAmount string `json:"settleMinAmount,omitempty"`
+
 	// unilateral exit delay
 	UnilateralExitDelay string `json:"unilateralExitDelay,omitempty"`
 
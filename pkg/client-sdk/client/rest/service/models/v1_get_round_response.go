@@ -18,6 +18,9 @@ import (
 // swagger:model v1GetRoundResponse
 type V1GetRoundResponse struct {
 
+	// Hex encoded raw round tx, set only if requested.
+	RawRoundTx string `json:"rawRoundTx,omitempty"`
+
 	// round
 	Round *V1Round `json:"round,omitempty"`
 }
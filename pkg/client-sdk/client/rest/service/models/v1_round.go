@@ -30,6 +30,13 @@ type V1Round struct {
 	// id
 	ID string `json:"id,omitempty"`
 
+	// Number of vtxos and onchain outputs created by the round.
+	NumOfOutputs int32 `json:"numOfOutputs,omitempty"`
+
+	// Number of tx requests registered for the round, ie. the size of the
+	// anonymity set of its participants.
+	NumOfParticipants int32 `json:"numOfParticipants,omitempty"`
+
 	// round tx
 	RoundTx string `json:"roundTx,omitempty"`
 
@@ -39,6 +46,9 @@ type V1Round struct {
 	// start
 	Start string `json:"start,omitempty"`
 
+	// Sum of the outputs created by the round.
+	TotalOutputAmount string `json:"totalOutputAmount,omitempty"`
+
 	// vtxo tree
 	VtxoTree *V1Tree `json:"vtxoTree,omitempty"`
 }
@@ -22,6 +22,10 @@ type V1RoundFailed struct {
 
 	// reason
 	Reason string `json:"reason,omitempty"`
+
+	// Whether the round was aborted by the server, in which case the same
+	// inputs can be registered again for a next round.
+	Retryable bool `json:"retryable,omitempty"`
 }
 
 // Validate validates this v1 round failed
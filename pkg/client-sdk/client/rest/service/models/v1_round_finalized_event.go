@@ -7,7 +7,9 @@ package models
 
 import (
 	"context"
+	"strconv"
 
+	"github.com/go-openapi/errors"
 	"github.com/go-openapi/strfmt"
 	"github.com/go-openapi/swag"
 )
@@ -17,20 +19,144 @@ import (
 // swagger:model v1RoundFinalizedEvent
 type V1RoundFinalizedEvent struct {
 
+	// fee report
+	FeeReport *V1RoundFeeReport `json:"feeReport,omitempty"`
+
 	// id
 	ID string `json:"id,omitempty"`
 
 	// round txid
 	RoundTxid string `json:"roundTxid,omitempty"`
+
+	// The vtxos created for the request the stream is subscribed to, empty if
+	// not subscribed or if the request didn't take part to the round.
+	Vtxos []*V1Vtxo `json:"vtxos"`
 }
 
 // Validate validates this v1 round finalized event
 func (m *V1RoundFinalizedEvent) Validate(formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.validateFeeReport(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.validateVtxos(formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *V1RoundFinalizedEvent) validateFeeReport(formats strfmt.Registry) error {
+	if swag.IsZero(m.FeeReport) { // not required
+		return nil
+	}
+
+	if m.FeeReport != nil {
+		if err := m.FeeReport.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("feeReport")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("feeReport")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *V1RoundFinalizedEvent) validateVtxos(formats strfmt.Registry) error {
+	if swag.IsZero(m.Vtxos) { // not required
+		return nil
+	}
+
+	for i := 0; i < len(m.Vtxos); i++ {
+		if swag.IsZero(m.Vtxos[i]) { // not required
+			continue
+		}
+
+		if m.Vtxos[i] != nil {
+			if err := m.Vtxos[i].Validate(formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("vtxos" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("vtxos" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
-// ContextValidate validates this v1 round finalized event based on context it is used
+// ContextValidate validate this v1 round finalized event based on the context it is used
 func (m *V1RoundFinalizedEvent) ContextValidate(ctx context.Context, formats strfmt.Registry) error {
+	var res []error
+
+	if err := m.contextValidateFeeReport(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if err := m.contextValidateVtxos(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
+	if len(res) > 0 {
+		return errors.CompositeValidationError(res...)
+	}
+	return nil
+}
+
+func (m *V1RoundFinalizedEvent) contextValidateFeeReport(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.FeeReport != nil {
+
+		if swag.IsZero(m.FeeReport) { // not required
+			return nil
+		}
+
+		if err := m.FeeReport.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("feeReport")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("feeReport")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *V1RoundFinalizedEvent) contextValidateVtxos(ctx context.Context, formats strfmt.Registry) error {
+
+	for i := 0; i < len(m.Vtxos); i++ {
+
+		if m.Vtxos[i] != nil {
+
+			if swag.IsZero(m.Vtxos[i]) { // not required
+				return nil
+			}
+
+			if err := m.Vtxos[i].ContextValidate(ctx, formats); err != nil {
+				if ve, ok := err.(*errors.Validation); ok {
+					return ve.ValidateName("vtxos" + "." + strconv.Itoa(i))
+				} else if ce, ok := err.(*errors.CompositeError); ok {
+					return ce.ValidateName("vtxos" + "." + strconv.Itoa(i))
+				}
+				return err
+			}
+		}
+
+	}
+
 	return nil
 }
 
@@ -17,6 +17,10 @@ import (
 // swagger:model v1SubmitRedeemTxRequest
 type V1SubmitRedeemTxRequest struct {
 
+	// Optional, resubmitting the same redeem tx with the same key returns the
+	// result of the first submission instead of a double spend error.
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+
 	// redeem tx
 	RedeemTx string `json:"redeemTx,omitempty"`
 }
@@ -0,0 +1,765 @@
+package arksdk
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/bip322"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/ark-network/ark/pkg/client-sdk/indexer"
+	"github.com/ark-network/ark/pkg/client-sdk/store"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	singlekeywallet "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey"
+	inmemorywalletstore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store/inmemory"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitExitChange(t *testing.T) {
+	const minChange = 330
+
+	t.Run("valid", func(t *testing.T) {
+		fixtures := []struct {
+			name               string
+			amount             uint64
+			change             uint64
+			absorb             bool
+			expectedExitAmount uint64
+			expectedChange     uint64
+		}{
+			{
+				name:               "no change",
+				amount:             10000,
+				change:             0,
+				expectedExitAmount: 10000,
+				expectedChange:     0,
+			},
+			{
+				name:               "change above dust",
+				amount:             10000,
+				change:             1000,
+				expectedExitAmount: 10000,
+				expectedChange:     1000,
+			},
+			{
+				name:               "change equal to dust",
+				amount:             10000,
+				change:             minChange,
+				expectedExitAmount: 10000,
+				expectedChange:     minChange,
+			},
+			{
+				name:               "dust change absorbed",
+				amount:             10000,
+				change:             100,
+				absorb:             true,
+				expectedExitAmount: 10100,
+				expectedChange:     0,
+			},
+		}
+
+		for _, f := range fixtures {
+			t.Run(f.name, func(t *testing.T) {
+				exitAmount, change, err := splitExitChange(
+					f.amount, f.change, minChange, f.absorb,
+				)
+				require.NoError(t, err)
+				require.Equal(t, f.expectedExitAmount, exitAmount)
+				require.Equal(t, f.expectedChange, change)
+			})
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := splitExitChange(10000, 100, minChange, false)
+		require.ErrorIs(t, err, ErrDustChange)
+		require.ErrorContains(t, err, "at most 9770 sats")
+
+		// only the whole amount can be exited
+		_, _, err = splitExitChange(200, 100, minChange, false)
+		require.ErrorIs(t, err, ErrDustChange)
+		require.NotContains(t, err.Error(), "at most")
+	})
+}
+
+func TestSendAllAmount(t *testing.T) {
+	const dust = 330
+
+	t.Run("valid", func(t *testing.T) {
+		amount, err := sendAllAmount(10000, 3000, 200, dust)
+		require.NoError(t, err)
+		require.Equal(t, uint64(6800), amount)
+
+		// zero fees
+		amount, err = sendAllAmount(10000, 0, 0, dust)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10000), amount)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := sendAllAmount(10000, 9800, 200, dust)
+		require.ErrorContains(t, err, "not enough funds")
+
+		_, err = sendAllAmount(10000, 9500, 200, dust)
+		require.ErrorContains(t, err, "must be greater than dust")
+	})
+
+	t.Run("max receiver", func(t *testing.T) {
+		receiver := NewMaxReceiver("tark1address")
+		require.True(t, isMaxReceiver(receiver))
+		require.Zero(t, receiver.Amount())
+		require.False(t, isMaxReceiver(NewBitcoinReceiver("tark1address", 1000)))
+	})
+}
+
+func TestDenominate(t *testing.T) {
+	const minAmount = 330
+	denominations := []uint64{100000, 10000, 1000, 100}
+
+	testCases := []struct {
+		name     string
+		amount   uint64
+		expected []uint64
+	}{
+		{
+			name:     "exact",
+			amount:   121000,
+			expected: []uint64{100000, 10000, 10000, 1000},
+		},
+		{
+			name:     "remainder above min amount",
+			amount:   11500,
+			expected: []uint64{10000, 1000, 500},
+		},
+		{
+			name:     "remainder below min amount",
+			amount:   11200,
+			expected: []uint64{10000, 1200},
+		},
+		{
+			name:     "below smallest denomination",
+			amount:   800,
+			expected: []uint64{800},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			amounts := denominate(tc.amount, denominations, minAmount)
+			require.Equal(t, tc.expected, amounts)
+
+			sum := uint64(0)
+			for _, amount := range amounts {
+				sum += amount
+			}
+			require.Equal(t, tc.amount, sum)
+		})
+	}
+}
+
+func TestDenominateChange(t *testing.T) {
+	const minAmount = 330
+	denominations := []uint64{10000, 1000}
+	feePerOutput := func(numOfChangeOutputs int) (uint64, error) {
+		return 100 + 50*uint64(numOfChangeOutputs), nil
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		// the fee of a single output leaves a split in 4 outputs, whose fee in
+		// turn leaves a remainder below min amount, added to the last output
+		amounts, err := denominateChange(21500, denominations, minAmount, feePerOutput)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{10000, 10000, 1200}, amounts)
+
+		// zero fees
+		amounts, err = denominateChange(
+			21000, denominations, minAmount,
+			func(int) (uint64, error) { return 0, nil },
+		)
+		require.NoError(t, err)
+		require.Equal(t, []uint64{10000, 10000, 1000}, amounts)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := denominateChange(400, denominations, minAmount, feePerOutput)
+		require.ErrorContains(t, err, "not enough change")
+	})
+}
+
+func TestCheckBatchReceivers(t *testing.T) {
+	validate := func(receiver Receiver) error {
+		if receiver.To() == "invalid" {
+			return fmt.Errorf("invalid receiver address")
+		}
+		if !isMaxReceiver(receiver) && receiver.Amount() < 330 {
+			return fmt.Errorf("invalid amount")
+		}
+		return nil
+	}
+
+	receivers := []Receiver{
+		NewBitcoinReceiver("tark1alice", 1000),
+		NewBitcoinReceiver("invalid", 1000),
+		NewBitcoinReceiver("tark1bob", 100),
+		NewMaxReceiver("tark1carol"),
+		NewMaxReceiver("tark1dave"),
+	}
+
+	valid, results := checkBatchReceivers(receivers, validate)
+	require.Equal(t, []Receiver{receivers[0], receivers[3]}, valid)
+	require.Len(t, results, len(receivers))
+	for i, result := range results {
+		require.Equal(t, i, result.Index)
+		require.False(t, result.Included)
+	}
+	require.NoError(t, results[0].Err)
+	require.EqualError(t, results[1].Err, "invalid receiver address")
+	require.EqualError(t, results[2].Err, "invalid amount")
+	require.NoError(t, results[3].Err)
+	require.EqualError(t, results[4].Err, "only one max receiver is allowed")
+}
+
+func TestReceiverValidate(t *testing.T) {
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	offchainAddr, err := (&common.Address{
+		HRP:        common.BitcoinRegTest.Addr,
+		Server:     key.PubKey(),
+		VtxoTapKey: key.PubKey(),
+	}).Encode()
+	require.NoError(t, err)
+	mainnetOffchainAddr, err := (&common.Address{
+		HRP:        common.Bitcoin.Addr,
+		Server:     key.PubKey(),
+		VtxoTapKey: key.PubKey(),
+	}).Encode()
+	require.NoError(t, err)
+
+	onchainAddr, err := btcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(key.PubKey()), &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+	mainnetOnchainAddr, err := btcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(key.PubKey()), &chaincfg.MainNetParams,
+	)
+	require.NoError(t, err)
+
+	net := common.BitcoinRegTest
+	dust := DustLimit(net)
+
+	tests := []struct {
+		name        string
+		receiver    Receiver
+		expectedErr error
+	}{
+		{"offchain", NewBitcoinReceiver(offchainAddr, dust), nil},
+		{"onchain", NewBitcoinReceiver(onchainAddr.EncodeAddress(), dust), nil},
+		{"max", NewMaxReceiver(offchainAddr), nil},
+		{"dust", NewBitcoinReceiver(offchainAddr, dust-1), ErrDustAmount},
+		{"malformed address", NewBitcoinReceiver("tark1invalid", dust), ErrInvalidReceiverAddress},
+		{"empty address", NewMaxReceiver(""), ErrInvalidReceiverAddress},
+		{"offchain wrong network", NewBitcoinReceiver(mainnetOffchainAddr, dust), ErrInvalidReceiverAddress},
+		{
+			"onchain wrong network",
+			NewBitcoinReceiver(mainnetOnchainAddr.EncodeAddress(), dust),
+			ErrInvalidReceiverAddress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.receiver.Validate(net)
+			if tt.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestMigrateServerKey(t *testing.T) {
+	ctx := context.Background()
+	oldServerKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	newServerKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	userKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512}
+
+	// the explorer reports no boarding utxos
+	explorerSvc := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			// nolint:all
+			w.Write([]byte("[]"))
+		},
+	))
+	defer explorerSvc.Close()
+
+	newClient := func(t *testing.T, transport *mockedTransportClient) *covenantlessArkClient {
+		return newTestArkClient(t, oldServerKey, userKey, explorerSvc.URL, transport)
+	}
+	newInfo := func(serverKey *btcec.PrivateKey) *client.Info {
+		return &client.Info{
+			PubKey:  hex.EncodeToString(serverKey.PubKey().SerializeCompressed()),
+			Network: common.BitcoinRegTest.Name,
+		}
+	}
+
+	t.Run("without funds", func(t *testing.T) {
+		transport := &mockedTransportClient{info: newInfo(newServerKey)}
+		arkClient := newClient(t, transport)
+
+		_, err := arkClient.Settle(ctx)
+		require.ErrorIs(t, err, ErrServerKeyChanged)
+
+		require.NoError(t, arkClient.MigrateServerKey(ctx))
+		require.True(t, arkClient.ServerPubKey.IsEqual(newServerKey.PubKey()))
+		require.NoError(t, arkClient.ensureServerKey(ctx))
+		require.Empty(t, transport.intents)
+
+		cfg, err := arkClient.store.ConfigStore().GetData(ctx)
+		require.NoError(t, err)
+		require.True(t, cfg.ServerPubKey.IsEqual(newServerKey.PubKey()))
+	})
+
+	t.Run("with funds", func(t *testing.T) {
+		transport := &mockedTransportClient{
+			info:      newInfo(newServerKey),
+			intentErr: fmt.Errorf("intent refused"),
+		}
+		arkClient := newClient(t, transport)
+
+		// the wallet owns a vtxo locked to the old key
+		offchainAddrs, _, _, err := arkClient.wallet.GetAddresses(ctx)
+		require.NoError(t, err)
+		oldAddr, err := common.DecodeAddress(offchainAddrs[0].Address)
+		require.NoError(t, err)
+		vtxo := client.Vtxo{
+			Outpoint: client.Outpoint{Txid: chainhash.HashH([]byte("vtxo")).String()},
+			PubKey:   hex.EncodeToString(schnorr.SerializePubKey(oldAddr.VtxoTapKey)),
+			Amount:   10000,
+		}
+		transport.vtxos = []client.Vtxo{vtxo}
+
+		// the migration settles the vtxo into an address of the new key but the
+		// round fails, the old key is restored
+		err = arkClient.MigrateServerKey(ctx)
+		require.ErrorContains(t, err, "intent refused")
+		require.True(t, arkClient.ServerPubKey.IsEqual(oldServerKey.PubKey()))
+		require.ErrorIs(t, arkClient.ensureServerKey(ctx), ErrServerKeyChanged)
+		cfg, err := arkClient.store.ConfigStore().GetData(ctx)
+		require.NoError(t, err)
+		require.True(t, cfg.ServerPubKey.IsEqual(oldServerKey.PubKey()))
+
+		require.Len(t, transport.intents, 1)
+		intent, err := bip322.DecodeSignature(transport.intents[0])
+		require.NoError(t, err)
+		outpoints := intent.GetOutpoints()
+		require.Len(t, outpoints, 1)
+		require.Equal(t, vtxo.Txid, outpoints[0].Hash.String())
+
+		newVtxoScript := tree.NewDefaultVtxoScript(
+			userKey.PubKey(), newServerKey.PubKey(), exitDelay,
+		)
+		newTapKey, _, err := newVtxoScript.TapTree()
+		require.NoError(t, err)
+		newPkScript, err := common.P2TRScript(newTapKey)
+		require.NoError(t, err)
+		require.Len(t, intent.TxOut, 1)
+		require.Equal(t, newPkScript, intent.TxOut[0].PkScript)
+		require.Equal(t, int64(vtxo.Amount), intent.TxOut[0].Value)
+	})
+
+	t.Run("network changed", func(t *testing.T) {
+		info := newInfo(newServerKey)
+		info.Network = common.Bitcoin.Name
+		arkClient := newClient(t, &mockedTransportClient{info: info})
+
+		err := arkClient.MigrateServerKey(ctx)
+		require.ErrorContains(t, err, "server network changed")
+		require.True(t, arkClient.ServerPubKey.IsEqual(oldServerKey.PubKey()))
+	})
+}
+
+func TestPreviewSettle(t *testing.T) {
+	ctx := context.Background()
+	serverKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	userKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	explorerSvc := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			// nolint:all
+			w.Write([]byte("[]"))
+		},
+	))
+	defer explorerSvc.Close()
+
+	transport := &mockedTransportClient{info: &client.Info{
+		PubKey:  hex.EncodeToString(serverKey.PubKey().SerializeCompressed()),
+		Network: common.BitcoinRegTest.Name,
+	}}
+	arkClient := newTestArkClient(t, serverKey, userKey, explorerSvc.URL, transport)
+	walletSvc := &mockedAddressWallet{WalletService: arkClient.wallet}
+	arkClient.wallet = walletSvc
+
+	offchainAddrs, _, _, err := walletSvc.GetAddresses(ctx)
+	require.NoError(t, err)
+	addr, err := common.DecodeAddress(offchainAddrs[0].Address)
+	require.NoError(t, err)
+	transport.vtxos = []client.Vtxo{
+		{
+			Outpoint: client.Outpoint{Txid: chainhash.HashH([]byte("vtxo1")).String()},
+			PubKey:   hex.EncodeToString(schnorr.SerializePubKey(addr.VtxoTapKey)),
+			Amount:   10000,
+		},
+		{
+			Outpoint: client.Outpoint{Txid: chainhash.HashH([]byte("vtxo2")).String()},
+			PubKey:   hex.EncodeToString(schnorr.SerializePubKey(addr.VtxoTapKey)),
+			Amount:   5000,
+		},
+	}
+
+	preview, err := arkClient.PreviewSettle(ctx)
+	require.NoError(t, err)
+	require.Len(t, preview.Vtxos, 2)
+	require.Empty(t, preview.BoardingUtxos)
+	require.Equal(t, uint64(15000), preview.Amount)
+	require.NotZero(t, preview.ForfeitFees)
+
+	// the funds are self sent to an existing address of the wallet
+	require.Equal(t, []client.Output{
+		{Address: offchainAddrs[0].Address, Amount: 15000},
+	}, preview.Outputs)
+
+	// the preview has no side effects, neither on the wallet nor on the server
+	require.Zero(t, walletSvc.newAddresses)
+	require.Empty(t, transport.intents)
+}
+
+func TestRecoverAll(t *testing.T) {
+	ctx := context.Background()
+	serverKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	userKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	explorerSvc := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, _ *http.Request) {
+			// nolint:all
+			w.Write([]byte("[]"))
+		},
+	))
+	defer explorerSvc.Close()
+
+	newClient := func(t *testing.T) (*covenantlessArkClient, *mockedTransportClient) {
+		transport := &mockedTransportClient{
+			info: &client.Info{
+				PubKey:  hex.EncodeToString(serverKey.PubKey().SerializeCompressed()),
+				Network: common.BitcoinRegTest.Name,
+			},
+			intentErr: fmt.Errorf("intent refused"),
+		}
+		arkClient := newTestArkClient(t, serverKey, userKey, explorerSvc.URL, transport)
+
+		// the wallet owns 3 swept vtxos
+		offchainAddrs, _, _, err := arkClient.wallet.GetAddresses(ctx)
+		require.NoError(t, err)
+		addr, err := common.DecodeAddress(offchainAddrs[0].Address)
+		require.NoError(t, err)
+		for i := 0; i < 3; i++ {
+			transport.spentVtxos = append(transport.spentVtxos, client.Vtxo{
+				Outpoint: client.Outpoint{
+					Txid: chainhash.HashH([]byte(fmt.Sprintf("vtxo%d", i))).String(),
+				},
+				PubKey: hex.EncodeToString(schnorr.SerializePubKey(addr.VtxoTapKey)),
+				Amount: uint64(1000 * (i + 1)),
+				Swept:  true,
+			})
+		}
+		return arkClient, transport
+	}
+	checkpoint := func(roundTxid string, vtxos ...client.Vtxo) []types.RecoveredVtxo {
+		recovered := make([]types.RecoveredVtxo, 0, len(vtxos))
+		for _, vtxo := range vtxos {
+			recovered = append(recovered, types.RecoveredVtxo{
+				VtxoKey:   types.VtxoKey{Txid: vtxo.Txid, VOut: vtxo.VOut},
+				Amount:    vtxo.Amount,
+				RoundTxid: roundTxid,
+			})
+		}
+		return recovered
+	}
+
+	t.Run("resume", func(t *testing.T) {
+		arkClient, transport := newClient(t)
+		recoveryStore := arkClient.store.RecoveryStore()
+
+		// a previous invocation recovered the first vtxo before being interrupted
+		recovered := checkpoint("roundtxid", transport.spentVtxos[0])
+		_, err := recoveryStore.AddRecoveredVtxos(ctx, recovered)
+		require.NoError(t, err)
+
+		progressCh := make(chan RecoveryProgress, 1)
+		roundTxids, err := arkClient.RecoverAll(ctx, progressCh)
+		require.ErrorContains(t, err, "failed to recover vtxos (1/3 done), retry to resume")
+		require.ErrorContains(t, err, "intent refused")
+		require.Empty(t, roundTxids)
+
+		// the progress accounts for the checkpointed vtxo
+		require.Equal(t, RecoveryProgress{
+			TotalVtxos:      3,
+			RecoveredVtxos:  1,
+			TotalAmount:     6000,
+			RecoveredAmount: 1000,
+			RoundTxid:       "roundtxid",
+		}, <-progressCh)
+
+		// only the vtxos not recovered yet are registered
+		require.Len(t, transport.intents, 1)
+		intent, err := bip322.DecodeSignature(transport.intents[0])
+		require.NoError(t, err)
+		outpoints := make([]string, 0)
+		for _, outpoint := range intent.GetOutpoints() {
+			outpoints = append(outpoints, outpoint.Hash.String())
+		}
+		require.ElementsMatch(t, []string{
+			transport.spentVtxos[1].Txid, transport.spentVtxos[2].Txid,
+		}, outpoints)
+		require.Len(t, intent.TxOut, 1)
+		require.Equal(t, int64(5000), intent.TxOut[0].Value)
+
+		// the checkpoint is kept to resume again
+		stored, err := recoveryStore.GetRecoveredVtxos(ctx)
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, recovered[0].VtxoKey, stored[0].VtxoKey)
+	})
+
+	t.Run("already recovered", func(t *testing.T) {
+		arkClient, transport := newClient(t)
+		recoveryStore := arkClient.store.RecoveryStore()
+
+		// a previous invocation recovered all the vtxos but stopped before
+		// cleaning the checkpoint
+		_, err := recoveryStore.AddRecoveredVtxos(
+			ctx, checkpoint("roundtxid", transport.spentVtxos...),
+		)
+		require.NoError(t, err)
+
+		roundTxids, err := arkClient.RecoverAll(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, roundTxids)
+		require.Empty(t, transport.intents)
+
+		// the completed recovery cleans the checkpoint
+		stored, err := recoveryStore.GetRecoveredVtxos(ctx)
+		require.NoError(t, err)
+		require.Empty(t, stored)
+	})
+}
+
+// newTestArkClient returns a client with an unlocked singlekey wallet of the
+// given user key and in-memory stores configured for the given server key.
+func TestEnsureVtxosTreeNotSwept(t *testing.T) {
+	ctx := context.Background()
+	serverKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	userKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	newVtxo := func(txid, roundTxid string) client.TapscriptsVtxo {
+		return client.TapscriptsVtxo{Vtxo: client.Vtxo{
+			Outpoint:  client.Outpoint{Txid: chainhash.HashH([]byte(txid)).String()},
+			RoundTxid: chainhash.HashH([]byte(roundTxid)).String(),
+			Amount:    1000,
+		}}
+	}
+	vtxos := []client.TapscriptsVtxo{
+		newVtxo("vtxo1", "round1"), newVtxo("vtxo2", "round1"), newVtxo("vtxo3", "round2"),
+	}
+	notSwept := &indexer.CommitmentTx{Batches: map[uint32]*indexer.Batch{
+		0: {Swept: false}, 1: {Swept: false},
+	}}
+	swept := &indexer.CommitmentTx{Batches: map[uint32]*indexer.Batch{
+		0: {Swept: false}, 1: {Swept: true},
+	}}
+
+	t.Run("not swept", func(t *testing.T) {
+		indexerSvc := &mockedIndexer{commitmentTxs: map[string]*indexer.CommitmentTx{
+			vtxos[0].RoundTxid: notSwept,
+			vtxos[2].RoundTxid: notSwept,
+		}}
+		arkClient := newTestArkClient(t, serverKey, userKey, "", nil)
+		arkClient.indexer = indexerSvc
+
+		require.NoError(t, arkClient.ensureVtxosTreeNotSwept(ctx, vtxos))
+		// every round is fetched only once
+		require.Equal(t, []string{vtxos[0].RoundTxid, vtxos[2].RoundTxid}, indexerSvc.calls)
+	})
+
+	t.Run("swept", func(t *testing.T) {
+		indexerSvc := &mockedIndexer{commitmentTxs: map[string]*indexer.CommitmentTx{
+			vtxos[0].RoundTxid: notSwept,
+			vtxos[2].RoundTxid: swept,
+		}}
+		arkClient := newTestArkClient(t, serverKey, userKey, "", nil)
+		arkClient.indexer = indexerSvc
+
+		err := arkClient.ensureVtxosTreeNotSwept(ctx, vtxos)
+		require.ErrorIs(t, err, ErrVtxoTreeSwept)
+		require.ErrorContains(t, err, vtxos[2].Outpoint.String())
+
+		// a vtxo marked as swept is rejected without querying the indexer
+		indexerSvc.calls = nil
+		sweptVtxo := newVtxo("vtxo4", "round1")
+		sweptVtxo.Swept = true
+		err = arkClient.ensureVtxosTreeNotSwept(ctx, []client.TapscriptsVtxo{sweptVtxo})
+		require.ErrorIs(t, err, ErrVtxoTreeSwept)
+		require.Empty(t, indexerSvc.calls)
+	})
+
+	t.Run("indexer error", func(t *testing.T) {
+		indexerSvc := &mockedIndexer{err: fmt.Errorf("indexer unavailable")}
+		arkClient := newTestArkClient(t, serverKey, userKey, "", nil)
+		arkClient.indexer = indexerSvc
+
+		err := arkClient.ensureVtxosTreeNotSwept(ctx, vtxos)
+		require.ErrorContains(t, err, "indexer unavailable")
+		require.NotErrorIs(t, err, ErrVtxoTreeSwept)
+	})
+}
+
+func newTestArkClient(
+	t *testing.T, serverKey, userKey *btcec.PrivateKey, explorerUrl string,
+	transport *mockedTransportClient,
+) *covenantlessArkClient {
+	ctx := context.Background()
+
+	forfeitAddr, err := btcutil.NewAddressWitnessPubKeyHash(
+		make([]byte, 20), &chaincfg.RegressionNetParams,
+	)
+	require.NoError(t, err)
+
+	sdkStore, err := store.NewStore(store.Config{
+		ConfigStoreType:  types.InMemoryStore,
+		AppDataStoreType: types.KVStore,
+	})
+	require.NoError(t, err)
+	t.Cleanup(sdkStore.Close)
+	cfg := types.Config{
+		ServerUrl:           "localhost:7070",
+		ServerPubKey:        serverKey.PubKey(),
+		WalletType:          wallet.SingleKeyWallet,
+		ClientType:          client.GrpcClient,
+		Network:             common.BitcoinRegTest,
+		VtxoTreeExpiry:      common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 1024},
+		RoundInterval:       10,
+		UnilateralExitDelay: common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		Dust:                1000,
+		BoardingExitDelay:   common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 1024},
+		ForfeitAddress:      forfeitAddr.EncodeAddress(),
+		UtxoMinAmount:       -1,
+		UtxoMaxAmount:       -1,
+		VtxoMinAmount:       -1,
+		VtxoMaxAmount:       -1,
+	}
+	require.NoError(t, sdkStore.ConfigStore().AddData(ctx, cfg))
+
+	walletStore, err := inmemorywalletstore.NewWalletStore()
+	require.NoError(t, err)
+	walletSvc, err := singlekeywallet.NewBitcoinWallet(sdkStore.ConfigStore(), walletStore)
+	require.NoError(t, err)
+	_, err = walletSvc.Create(ctx, "password", hex.EncodeToString(userKey.Serialize()))
+	require.NoError(t, err)
+	_, err = walletSvc.Unlock(ctx, "password")
+	require.NoError(t, err)
+
+	return &covenantlessArkClient{&arkClient{
+		Config:   &cfg,
+		wallet:   walletSvc,
+		store:    sdkStore,
+		explorer: explorer.NewExplorer(explorerUrl, common.BitcoinRegTest),
+		client:   transport,
+	}}
+}
+
+// mockedAddressWallet counts the new addresses derived by the wallet.
+type mockedAddressWallet struct {
+	wallet.WalletService
+	newAddresses int
+}
+
+func (w *mockedAddressWallet) NewAddress(
+	ctx context.Context, change bool,
+) (*wallet.TapscriptsAddress, *wallet.TapscriptsAddress, error) {
+	w.newAddresses++
+	return w.WalletService.NewAddress(ctx, change)
+}
+
+type mockedTransportClient struct {
+	client.TransportClient
+	info       *client.Info
+	vtxos      []client.Vtxo
+	spentVtxos []client.Vtxo
+	intents    []string
+	intentErr  error
+}
+
+func (m *mockedTransportClient) GetInfo(context.Context) (*client.Info, error) {
+	return m.info, nil
+}
+
+func (m *mockedTransportClient) ListVtxosForAddresses(
+	_ context.Context, addrs []string,
+) ([]client.AddressVtxos, error) {
+	return []client.AddressVtxos{
+		{Address: addrs[0], SpendableVtxos: m.vtxos, SpentVtxos: m.spentVtxos},
+	}, nil
+}
+
+func (m *mockedTransportClient) RegisterIntent(
+	_ context.Context, signature, _ string,
+) (string, error) {
+	m.intents = append(m.intents, signature)
+	return "", m.intentErr
+}
+
+// mockedIndexer serves the given commitment txs and records the requested
+// txids. If err is set, every request fails with it.
+type mockedIndexer struct {
+	indexer.Indexer
+	commitmentTxs map[string]*indexer.CommitmentTx
+	err           error
+	calls         []string
+}
+
+func (m *mockedIndexer) GetCommitmentTx(
+	_ context.Context, txid string,
+) (*indexer.CommitmentTx, error) {
+	m.calls = append(m.calls, txid)
+	if m.err != nil {
+		return nil, m.err
+	}
+	commitmentTx, ok := m.commitmentTxs[txid]
+	if !ok {
+		return nil, fmt.Errorf("commitment tx %s not found", txid)
+	}
+	return commitmentTx, nil
+}
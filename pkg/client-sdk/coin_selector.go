@@ -0,0 +1,169 @@
+package arksdk
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+)
+
+// CoinSelector selects among the given vtxos those to spend to cover the
+// target amount and returns them along with the change.
+type CoinSelector interface {
+	Select(vtxos []client.Vtxo, target uint64) (selected []client.Vtxo, change uint64, err error)
+}
+
+// LargestFirst selects the vtxos with the greatest amount first, to spend as
+// few inputs as possible and keep the forfeit txs light.
+type LargestFirst struct{}
+
+func (LargestFirst) Select(vtxos []client.Vtxo, target uint64) ([]client.Vtxo, uint64, error) {
+	return selectSorted(vtxos, target, func(a, b client.Vtxo) bool {
+		return a.Amount > b.Amount
+	})
+}
+
+// OldestFirst selects the vtxos created earlier first. It's the default coin
+// selector of the client.
+type OldestFirst struct{}
+
+func (OldestFirst) Select(vtxos []client.Vtxo, target uint64) ([]client.Vtxo, uint64, error) {
+	return selectSorted(vtxos, target, func(a, b client.Vtxo) bool {
+		return a.CreatedAt.Before(b.CreatedAt)
+	})
+}
+
+// ExpiringFirst selects the vtxos closest to expiry first.
+type ExpiringFirst struct{}
+
+func (ExpiringFirst) Select(vtxos []client.Vtxo, target uint64) ([]client.Vtxo, uint64, error) {
+	return selectSorted(vtxos, target, func(a, b client.Vtxo) bool {
+		return a.ExpiresAt.Before(b.ExpiresAt)
+	})
+}
+
+// selectSorted selects the vtxos in the order given by less until the target
+// amount is covered, without modifying the given list.
+func selectSorted(
+	vtxos []client.Vtxo, target uint64, less func(a, b client.Vtxo) bool,
+) ([]client.Vtxo, uint64, error) {
+	sorted := append([]client.Vtxo{}, vtxos...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i], sorted[j])
+	})
+
+	selected := make([]client.Vtxo, 0)
+	selectedAmount := uint64(0)
+	for _, vtxo := range sorted {
+		if selectedAmount >= target {
+			break
+		}
+		selected = append(selected, vtxo)
+		selectedAmount += vtxo.Amount
+	}
+
+	if selectedAmount < target {
+		return nil, 0, fmt.Errorf("not enough funds to cover amount %d", target)
+	}
+	return selected, selectedAmount - target, nil
+}
+
+// coinSelect selects the boarding utxos first, in the given order, and then
+// the vtxos with the given selector to cover the amount. If the change would
+// be below dust, more coins are selected so that it's a valid output, if the
+// funds allow.
+func coinSelect(
+	selector CoinSelector,
+	boardingUtxos []types.Utxo,
+	vtxos []client.TapscriptsVtxo,
+	amount, dust uint64,
+) ([]types.Utxo, []client.TapscriptsVtxo, uint64, error) {
+	selectedBoarding := make([]types.Utxo, 0)
+	selectedAmount := uint64(0)
+	for _, utxo := range boardingUtxos {
+		if selectedAmount >= amount {
+			break
+		}
+		selectedBoarding = append(selectedBoarding, utxo)
+		selectedAmount += utxo.Amount
+	}
+
+	selectedVtxos := make([]client.TapscriptsVtxo, 0)
+	change := uint64(0)
+	if selectedAmount >= amount {
+		change = selectedAmount - amount
+	} else {
+		plainVtxos := make([]client.Vtxo, 0, len(vtxos))
+		for _, vtxo := range vtxos {
+			plainVtxos = append(plainVtxos, vtxo.Vtxo)
+		}
+
+		target := amount - selectedAmount
+		selected, vtxosChange, err := selector.Select(plainVtxos, target)
+		if err != nil {
+			return nil, nil, 0, fmt.Errorf("not enough funds to cover amount %d", amount)
+		}
+		if vtxosChange > 0 && vtxosChange < dust {
+			if moreSelected, moreChange, err := selector.Select(
+				plainVtxos, target+dust,
+			); err == nil {
+				selected, vtxosChange = moreSelected, moreChange+dust
+			}
+		}
+
+		tapscriptsByOutpoint := make(map[client.Outpoint][]string, len(vtxos))
+		for _, vtxo := range vtxos {
+			tapscriptsByOutpoint[vtxo.Outpoint] = vtxo.Tapscripts
+		}
+		for _, vtxo := range selected {
+			selectedVtxos = append(selectedVtxos, client.TapscriptsVtxo{
+				Vtxo:       vtxo,
+				Tapscripts: tapscriptsByOutpoint[vtxo.Outpoint],
+			})
+		}
+		change = vtxosChange
+	}
+
+	// the boarding utxos alone may leave a change below dust
+	if change > 0 && change < dust &&
+		len(selectedBoarding) < len(boardingUtxos) && len(selectedVtxos) <= 0 {
+		next := boardingUtxos[len(selectedBoarding)]
+		selectedBoarding = append(selectedBoarding, next)
+		change += next.Amount
+	}
+
+	return selectedBoarding, selectedVtxos, change, nil
+}
+
+// selectCoins covers the given amount with the coin selector of the client, or
+// by spending the coins closest to expiry first if withExpiryCoinselect is set.
+func (a *arkClient) selectCoins(
+	boardingUtxos []types.Utxo,
+	vtxos []client.TapscriptsVtxo,
+	amount uint64,
+	withExpiryCoinselect bool,
+) ([]types.Utxo, []client.TapscriptsVtxo, uint64, error) {
+	selector := a.getCoinSelector()
+	if withExpiryCoinselect {
+		selector = ExpiringFirst{}
+		boardingUtxos = append([]types.Utxo{}, boardingUtxos...)
+		sort.SliceStable(boardingUtxos, func(i, j int) bool {
+			return boardingUtxos[i].SpendableAt.Before(boardingUtxos[j].SpendableAt)
+		})
+	}
+	return coinSelect(selector, boardingUtxos, vtxos, amount, a.Dust)
+}
+
+// SetCoinSelector sets the strategy to select the vtxos spent by SendOffChain,
+// Settle and CollaborativeExit.
+func (a *arkClient) SetCoinSelector(selector CoinSelector) {
+	a.coinSelector = selector
+}
+
+func (a *arkClient) getCoinSelector() CoinSelector {
+	if a.coinSelector == nil {
+		return OldestFirst{}
+	}
+	return a.coinSelector
+}
@@ -0,0 +1,94 @@
+package arksdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoinSelectors(t *testing.T) {
+	now := time.Now()
+	newVtxo := func(txid string, amount uint64, createdAt, expiresAt time.Time) client.Vtxo {
+		return client.Vtxo{
+			Outpoint:  client.Outpoint{Txid: txid},
+			Amount:    amount,
+			CreatedAt: createdAt,
+			ExpiresAt: expiresAt,
+		}
+	}
+	// a: oldest and smallest, b: largest, c: closest to expiry
+	a := newVtxo("a", 1000, now.Add(-3*time.Hour), now.Add(3*time.Hour))
+	b := newVtxo("b", 5000, now.Add(-2*time.Hour), now.Add(2*time.Hour))
+	c := newVtxo("c", 2000, now.Add(-time.Hour), now.Add(time.Hour))
+	vtxos := []client.Vtxo{b, c, a}
+
+	testCases := []struct {
+		name             string
+		selector         CoinSelector
+		target           uint64
+		expectedSelected []client.Vtxo
+		expectedChange   uint64
+	}{
+		{"largest first", LargestFirst{}, 4000, []client.Vtxo{b}, 1000},
+		{"oldest first", OldestFirst{}, 2500, []client.Vtxo{a, b}, 3500},
+		{"expiring first", ExpiringFirst{}, 2500, []client.Vtxo{c, b}, 4500},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			selected, change, err := tc.selector.Select(vtxos, tc.target)
+			require.NoError(t, err)
+			require.Equal(t, tc.expectedSelected, selected)
+			require.Equal(t, tc.expectedChange, change)
+
+			_, _, err = tc.selector.Select(vtxos, 9000)
+			require.ErrorContains(t, err, "not enough funds")
+		})
+	}
+
+	// the given list is not sorted in place
+	require.Equal(t, []client.Vtxo{b, c, a}, vtxos)
+}
+
+func TestCoinSelect(t *testing.T) {
+	const dust = 330
+
+	tapscripts := []string{"tapscript"}
+	vtxos := []client.TapscriptsVtxo{
+		{Vtxo: client.Vtxo{Outpoint: client.Outpoint{Txid: "a"}, Amount: 1000}, Tapscripts: tapscripts},
+		{Vtxo: client.Vtxo{Outpoint: client.Outpoint{Txid: "b"}, Amount: 5000}, Tapscripts: tapscripts},
+	}
+	boardingUtxos := []types.Utxo{{Txid: "boarding", Amount: 2000}}
+
+	t.Run("boarding first", func(t *testing.T) {
+		boarding, selected, change, err := coinSelect(
+			LargestFirst{}, boardingUtxos, vtxos, 1500, dust,
+		)
+		require.NoError(t, err)
+		require.Equal(t, boardingUtxos, boarding)
+		require.Empty(t, selected)
+		require.Equal(t, uint64(500), change)
+
+		boarding, selected, change, err = coinSelect(
+			LargestFirst{}, boardingUtxos, vtxos, 6000, dust,
+		)
+		require.NoError(t, err)
+		require.Equal(t, boardingUtxos, boarding)
+		require.Equal(t, vtxos[1:], selected)
+		require.Equal(t, uint64(1000), change)
+	})
+
+	t.Run("change below dust", func(t *testing.T) {
+		_, selected, change, err := coinSelect(LargestFirst{}, nil, vtxos, 4900, dust)
+		require.NoError(t, err)
+		require.Equal(t, []client.TapscriptsVtxo{vtxos[1], vtxos[0]}, selected)
+		require.Equal(t, uint64(1100), change)
+	})
+
+	t.Run("not enough funds", func(t *testing.T) {
+		_, _, _, err := coinSelect(LargestFirst{}, boardingUtxos, vtxos, 9000, dust)
+		require.EqualError(t, err, "not enough funds to cover amount 9000")
+	})
+}
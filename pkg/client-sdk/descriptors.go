@@ -0,0 +1,147 @@
+package arksdk
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/descriptor"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// ExportDescriptors returns the descriptors of the boarding and offchain
+// addresses of the wallet. It doesn't require the wallet to be unlocked.
+func (a *arkClient) ExportDescriptors(ctx context.Context) (*WalletDescriptors, error) {
+	if a.wallet == nil {
+		return nil, fmt.Errorf("wallet not initialized")
+	}
+
+	offchainAddrs, _, _, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(offchainAddrs) <= 0 {
+		return nil, fmt.Errorf("no address found")
+	}
+
+	userPubkey, err := ownerPubkey(offchainAddrs[0].Tapscripts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WalletDescriptors{
+		Network:      a.Network.Name,
+		ServerPubKey: hex.EncodeToString(schnorr.SerializePubKey(a.ServerPubKey)),
+		Boarding:     vtxoDescriptor(userPubkey, a.ServerPubKey, a.BoardingExitDelay),
+		Offchain:     vtxoDescriptor(userPubkey, a.ServerPubKey, a.UnilateralExitDelay),
+	}, nil
+}
+
+// ImportDescriptors initializes a watch-only wallet with the key of the given
+// descriptors, exported with ExportDescriptors, and the other args. The
+// descriptors must match those of the new wallet, ie. the server and its
+// timelocks must be the same of the exporting wallet, otherwise the wallet is
+// reset and an error is returned.
+func (a *covenantlessArkClient) ImportDescriptors(
+	ctx context.Context, descriptors WalletDescriptors, args InitArgs,
+) error {
+	if len(args.Seed) > 0 || len(args.PubKey) > 0 {
+		return fmt.Errorf("seed and pubkey must not be set when importing descriptors")
+	}
+
+	userPubkey, err := parseWalletDescriptors(descriptors)
+	if err != nil {
+		return err
+	}
+
+	args.WalletType = WatchOnlyWallet
+	args.PubKey = hex.EncodeToString(schnorr.SerializePubKey(userPubkey))
+	if err := a.Init(ctx, args); err != nil {
+		return err
+	}
+
+	imported, err := a.ExportDescriptors(ctx)
+	if err != nil {
+		a.Reset(ctx)
+		return err
+	}
+	if *imported != descriptors {
+		a.Reset(ctx)
+		return fmt.Errorf(
+			"descriptors don't match the wallet, got %+v, expected %+v",
+			descriptors, *imported,
+		)
+	}
+	return nil
+}
+
+// vtxoDescriptor returns the descriptor of the default vtxo script of the
+// given keys and exit delay.
+func vtxoDescriptor(
+	user, server *secp256k1.PublicKey, exitDelay common.RelativeLocktime,
+) string {
+	userKey := hex.EncodeToString(schnorr.SerializePubKey(user))
+	serverKey := hex.EncodeToString(schnorr.SerializePubKey(server))
+	return fmt.Sprintf(
+		descriptor.DefaultVtxoDescriptorTemplate,
+		descriptor.UnspendableKey, userKey, serverKey, exitDelay.Value, userKey,
+	)
+}
+
+// parseWalletDescriptors checks that the given descriptors are those of the
+// same user and server and returns the user key.
+func parseWalletDescriptors(descriptors WalletDescriptors) (*secp256k1.PublicKey, error) {
+	buf, err := hex.DecodeString(descriptors.ServerPubKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server pubkey: %s", err)
+	}
+	serverPubkey, err := schnorr.ParsePubKey(buf)
+	if err != nil {
+		return nil, fmt.Errorf("invalid server pubkey: %s", err)
+	}
+
+	boardingUser, boardingServer, _, err := descriptor.ParseDefaultVtxoDescriptor(
+		descriptors.Boarding,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid boarding descriptor: %s", err)
+	}
+	offchainUser, offchainServer, _, err := descriptor.ParseDefaultVtxoDescriptor(
+		descriptors.Offchain,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid offchain descriptor: %s", err)
+	}
+
+	if !isSameXOnlyKey(boardingUser, offchainUser) {
+		return nil, fmt.Errorf("boarding and offchain descriptors have different user keys")
+	}
+	if !isSameXOnlyKey(boardingServer, serverPubkey) ||
+		!isSameXOnlyKey(offchainServer, serverPubkey) {
+		return nil, fmt.Errorf("descriptors don't match the server pubkey")
+	}
+	return offchainUser, nil
+}
+
+// ownerPubkey returns the key of the owner of the default vtxo script made of
+// the given tapscripts, the only one of its exit closure.
+func ownerPubkey(tapscripts []string) (*secp256k1.PublicKey, error) {
+	vtxoScript, err := tree.ParseVtxoScript(tapscripts)
+	if err != nil {
+		return nil, err
+	}
+	for _, closure := range vtxoScript.ExitClosures() {
+		if c, ok := closure.(*tree.CSVMultisigClosure); ok && len(c.PubKeys) == 1 {
+			return c.PubKeys[0], nil
+		}
+	}
+	return nil, fmt.Errorf("not a default vtxo script")
+}
+
+func isSameXOnlyKey(a, b *secp256k1.PublicKey) bool {
+	return bytes.Equal(schnorr.SerializePubKey(a), schnorr.SerializePubKey(b))
+}
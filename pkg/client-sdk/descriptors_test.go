@@ -0,0 +1,62 @@
+package arksdk
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWalletDescriptors(t *testing.T) {
+	userKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 512}
+	boardingDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 1024}
+
+	tapscripts, err := tree.NewDefaultVtxoScript(
+		userKey.PubKey(), serverKey.PubKey(), exitDelay,
+	).Encode()
+	require.NoError(t, err)
+	owner, err := ownerPubkey(tapscripts)
+	require.NoError(t, err)
+	require.True(t, isSameXOnlyKey(userKey.PubKey(), owner))
+
+	descriptors := WalletDescriptors{
+		Network:      common.Bitcoin.Name,
+		ServerPubKey: hex.EncodeToString(schnorr.SerializePubKey(serverKey.PubKey())),
+		Boarding:     vtxoDescriptor(userKey.PubKey(), serverKey.PubKey(), boardingDelay),
+		Offchain:     vtxoDescriptor(userKey.PubKey(), serverKey.PubKey(), exitDelay),
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		user, err := parseWalletDescriptors(descriptors)
+		require.NoError(t, err)
+		require.True(t, isSameXOnlyKey(userKey.PubKey(), user))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := descriptors
+		invalid.ServerPubKey = hex.EncodeToString(schnorr.SerializePubKey(otherKey.PubKey()))
+		_, err := parseWalletDescriptors(invalid)
+		require.ErrorContains(t, err, "server pubkey")
+
+		invalid = descriptors
+		invalid.Boarding = vtxoDescriptor(otherKey.PubKey(), serverKey.PubKey(), boardingDelay)
+		_, err = parseWalletDescriptors(invalid)
+		require.ErrorContains(t, err, "different user keys")
+
+		invalid = descriptors
+		invalid.Offchain = "tr(invalid)"
+		_, err = parseWalletDescriptors(invalid)
+		require.ErrorContains(t, err, "invalid offchain descriptor")
+	})
+}
@@ -0,0 +1,194 @@
+package arksdk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	log "github.com/sirupsen/logrus"
+)
+
+const exitMaturityInterval = 30 * time.Second
+
+// SubscribeExitMaturity watches the given onchain outputs of a unilateral
+// exit, ie. the vtxos unrolled with StartUnilateralExit, and emits the status
+// of each of them every time it changes, until all of them are spendable or
+// the context is done. The channel is closed then.
+func (a *covenantlessArkClient) SubscribeExitMaturity(
+	ctx context.Context, outpoints []client.Outpoint,
+) (<-chan ExitStatus, error) {
+	if err := a.safeCheck(); err != nil {
+		return nil, err
+	}
+	if len(outpoints) <= 0 {
+		return nil, fmt.Errorf("missing outpoints")
+	}
+
+	statusCh := make(chan ExitStatus, len(outpoints))
+
+	go func() {
+		defer close(statusCh)
+
+		ticker := time.NewTicker(exitMaturityInterval)
+		defer ticker.Stop()
+
+		lastStatus := make(map[client.Outpoint]ExitStatus, len(outpoints))
+		for {
+			statuses, err := a.getExitStatuses(ctx, outpoints)
+			if err != nil {
+				log.WithError(err).Warn("failed to get status of exit outputs")
+			}
+
+			for _, status := range statuses {
+				if last, ok := lastStatus[status.Outpoint]; ok && last == status {
+					continue
+				}
+				lastStatus[status.Outpoint] = status
+
+				select {
+				case statusCh <- status:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			if allSpendable(lastStatus, outpoints) {
+				return
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return statusCh, nil
+}
+
+// getExitStatuses returns the status of the given exit outputs, looked up
+// among the utxos of the redemption addresses of the wallet.
+func (a *covenantlessArkClient) getExitStatuses(
+	ctx context.Context, outpoints []client.Outpoint,
+) ([]ExitStatus, error) {
+	_, _, redemptionAddrs, err := a.wallet.GetAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tipHeight, err := a.explorer.GetBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+
+	watched := make(map[client.Outpoint]struct{}, len(outpoints))
+	for _, outpoint := range outpoints {
+		watched[outpoint] = struct{}{}
+	}
+
+	now := time.Now()
+	found := make(map[client.Outpoint]ExitStatus, len(outpoints))
+	for _, addr := range redemptionAddrs {
+		utxos, err := a.explorer.GetUtxos(addr.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		var delay *common.RelativeLocktime
+		for _, utxo := range utxos {
+			outpoint := client.Outpoint{Txid: utxo.Txid, VOut: utxo.Vout}
+			if _, ok := watched[outpoint]; !ok {
+				continue
+			}
+
+			if delay == nil {
+				vtxoScript, err := tree.ParseVtxoScript(addr.Tapscripts)
+				if err != nil {
+					return nil, err
+				}
+				if delay, err = vtxoScript.SmallestExitDelay(); err != nil {
+					return nil, err
+				}
+			}
+
+			status := ExitStatus{
+				Outpoint:  outpoint,
+				Amount:    utxo.Amount,
+				Delay:     *delay,
+				Confirmed: utxo.Status.Confirmed,
+			}
+			if status.Confirmed {
+				status.ConfirmationHeight = utxo.Status.BlockHeight
+			}
+			found[outpoint] = exitMaturity(
+				status, time.Unix(utxo.Status.Blocktime, 0), tipHeight, now,
+			)
+		}
+	}
+
+	statuses := make([]ExitStatus, 0, len(outpoints))
+	for _, outpoint := range outpoints {
+		status, ok := found[outpoint]
+		if !ok {
+			// the exit tx is not broadcasted yet
+			status = exitMaturity(ExitStatus{
+				Outpoint: outpoint,
+				Delay:    a.UnilateralExitDelay,
+			}, time.Time{}, tipHeight, now)
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses, nil
+}
+
+// exitMaturity sets the blocks left and the time when the given exit output,
+// confirmed at the given block time, becomes spendable.
+func exitMaturity(
+	status ExitStatus, confirmedAt time.Time, tipHeight int64, now time.Time,
+) ExitStatus {
+	delayInBlocks := int64(status.Delay.Value)
+	if status.Delay.Type == common.LocktimeTypeSecond {
+		delayInBlocks = int64(math.Ceil(float64(status.Delay.Value) / common.SECONDS_PER_BLOCK))
+	}
+
+	status.SpendableAt = time.Time{}
+	if !status.Confirmed {
+		status.BlocksLeft = delayInBlocks
+		status.Spendable = false
+		return status
+	}
+
+	blockDuration := time.Duration(common.SECONDS_PER_BLOCK) * time.Second
+	if status.Delay.Type == common.LocktimeTypeBlock {
+		// the output can be spent in the block at confirmation height + delay
+		blocksLeft := status.ConfirmationHeight + delayInBlocks - (tipHeight + 1)
+		status.BlocksLeft = max(blocksLeft, 0)
+		status.SpendableAt = confirmedAt.Add(time.Duration(delayInBlocks) * blockDuration)
+		status.Spendable = status.BlocksLeft == 0
+		return status
+	}
+
+	status.SpendableAt = confirmedAt.Add(time.Duration(status.Delay.Value) * time.Second)
+	status.Spendable = !now.Before(status.SpendableAt)
+	status.BlocksLeft = 0
+	if !status.Spendable {
+		status.BlocksLeft = int64(math.Ceil(float64(status.SpendableAt.Sub(now)) / float64(blockDuration)))
+	}
+	return status
+}
+
+func allSpendable(
+	statuses map[client.Outpoint]ExitStatus, outpoints []client.Outpoint,
+) bool {
+	for _, outpoint := range outpoints {
+		if status, ok := statuses[outpoint]; !ok || !status.Spendable {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,111 @@
+package arksdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitMaturity(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	outpoint := client.Outpoint{Txid: "txid", VOut: 0}
+	blockDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	secondsDelay := common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512 * 10}
+
+	testCases := []struct {
+		name               string
+		delay              common.RelativeLocktime
+		confirmed          bool
+		confirmationHeight int64
+		confirmedAt        time.Time
+		tipHeight          int64
+		expectedBlocksLeft int64
+		expectedSpendable  bool
+	}{
+		{
+			name:               "unconfirmed",
+			delay:              blockDelay,
+			tipHeight:          1000,
+			expectedBlocksLeft: 144,
+		},
+		{
+			name:               "blocks, just confirmed",
+			delay:              blockDelay,
+			confirmed:          true,
+			confirmationHeight: 1000,
+			confirmedAt:        now,
+			tipHeight:          1000,
+			expectedBlocksLeft: 143,
+		},
+		{
+			name:               "blocks, spendable in the next block",
+			delay:              blockDelay,
+			confirmed:          true,
+			confirmationHeight: 1000,
+			confirmedAt:        now,
+			tipHeight:          1143,
+			expectedBlocksLeft: 0,
+			expectedSpendable:  true,
+		},
+		{
+			name:               "blocks, matured",
+			delay:              blockDelay,
+			confirmed:          true,
+			confirmationHeight: 1000,
+			confirmedAt:        now,
+			tipHeight:          2000,
+			expectedBlocksLeft: 0,
+			expectedSpendable:  true,
+		},
+		{
+			name:               "unconfirmed, seconds",
+			delay:              secondsDelay,
+			tipHeight:          1000,
+			expectedBlocksLeft: 9,
+		},
+		{
+			name:               "seconds, just confirmed",
+			delay:              secondsDelay,
+			confirmed:          true,
+			confirmationHeight: 1000,
+			confirmedAt:        now,
+			tipHeight:          1000,
+			expectedBlocksLeft: 9,
+		},
+		{
+			name:               "seconds, matured",
+			delay:              secondsDelay,
+			confirmed:          true,
+			confirmationHeight: 1000,
+			confirmedAt:        now.Add(-2 * time.Hour),
+			tipHeight:          1012,
+			expectedBlocksLeft: 0,
+			expectedSpendable:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			status := exitMaturity(ExitStatus{
+				Outpoint:           outpoint,
+				Delay:              tc.delay,
+				Confirmed:          tc.confirmed,
+				ConfirmationHeight: tc.confirmationHeight,
+			}, tc.confirmedAt, tc.tipHeight, now)
+
+			require.Equal(t, tc.expectedBlocksLeft, status.BlocksLeft)
+			require.Equal(t, tc.expectedSpendable, status.Spendable)
+			if !tc.confirmed {
+				require.True(t, status.SpendableAt.IsZero())
+				return
+			}
+			require.Equal(t, tc.confirmedAt.Add(time.Duration(tc.delay.Seconds())*time.Second), status.SpendableAt)
+
+			// the status doesn't change until a new block is found
+			require.Equal(t, status, exitMaturity(status, tc.confirmedAt, tc.tipHeight, now))
+		})
+	}
+}
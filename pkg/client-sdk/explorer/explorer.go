@@ -8,6 +8,7 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,10 +17,14 @@ import (
 	"github.com/ark-network/ark/pkg/client-sdk/types"
 	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/wire"
+	log "github.com/sirupsen/logrus"
 )
 
 const (
 	BitcoinExplorer = "bitcoin"
+	// DefaultFeeRateFloor is the default min fee rate, in sats/vbyte, returned
+	// by GetFeeRateForTarget. It's the network min relay fee rate.
+	DefaultFeeRateFloor = 1.0
 )
 
 type Explorer interface {
@@ -28,6 +33,7 @@ type Explorer interface {
 	GetTxs(addr string) ([]tx, error)
 	IsRBFTx(txid, txHex string) (bool, string, int64, error)
 	GetTxOutspends(tx string) ([]spentStatus, error)
+	GetOutspend(txid string, vout uint32) (*Outspend, error)
 	GetUtxos(addr string) ([]utxo, error)
 	GetBalance(addr string) (uint64, error)
 	GetRedeemedVtxosBalance(
@@ -36,22 +42,50 @@ type Explorer interface {
 	GetTxBlockTime(
 		txid string,
 	) (confirmed bool, blocktime int64, err error)
+	GetBlockHeight() (int64, error)
 	BaseUrl() string
 	GetFeeRate() (float64, error)
+	// GetFeeRateForTarget returns the fee rate, in sats/vbyte, for a tx to
+	// confirm within the given number of blocks. It never returns less than the
+	// fee rate floor of the explorer, that is also returned if the fee
+	// estimates are not available.
+	GetFeeRateForTarget(target int) (float64, error)
+	GetFeeHistory(window time.Duration) (*FeeHistory, error)
 }
 
+// ErrUnavailable is returned when an explorer fails to serve a request
+// because of a server side error.
+var ErrUnavailable = fmt.Errorf("explorer unavailable")
+
 type explorerSvc struct {
-	cache   *utils.Cache[string]
-	baseUrl string
-	net     common.Network
+	cache        *utils.Cache[string]
+	baseUrl      string
+	net          common.Network
+	feeRateFloor float64
+}
+
+// Option customizes the explorers created with NewExplorer.
+type Option func(*explorerSvc)
+
+// WithFeeRateFloor sets the min fee rate, in sats/vbyte, returned by
+// GetFeeRateForTarget, DefaultFeeRateFloor is used otherwise.
+func WithFeeRateFloor(satsPerVByte float64) Option {
+	return func(e *explorerSvc) {
+		e.feeRateFloor = satsPerVByte
+	}
 }
 
-func NewExplorer(baseUrl string, net common.Network) Explorer {
-	return &explorerSvc{
-		cache:   utils.NewCache[string](),
-		baseUrl: baseUrl,
-		net:     net,
+func NewExplorer(baseUrl string, net common.Network, opts ...Option) Explorer {
+	e := &explorerSvc{
+		cache:        utils.NewCache[string](),
+		baseUrl:      baseUrl,
+		net:          net,
+		feeRateFloor: DefaultFeeRateFloor,
+	}
+	for _, opt := range opts {
+		opt(e)
 	}
+	return e
 }
 
 func (e *explorerSvc) BaseUrl() string {
@@ -82,7 +116,7 @@ func (e *explorerSvc) GetFeeRate() (float64, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return 0, fmt.Errorf("error getting fee rate: %s", resp.Status)
+		return 0, responseError(resp.StatusCode, "error getting fee rate: %s", resp.Status)
 	}
 
 	if len(response) == 0 {
@@ -92,6 +126,78 @@ func (e *explorerSvc) GetFeeRate() (float64, error) {
 	return response["1"], nil
 }
 
+func (e *explorerSvc) GetFeeRateForTarget(target int) (float64, error) {
+	if target <= 0 {
+		return 0, fmt.Errorf("invalid confirmation target %d, must be greater than 0", target)
+	}
+
+	estimates, err := e.getFeeEstimates()
+	if err != nil {
+		log.WithError(err).Warnf(
+			"failed to get fee estimates, falling back to %v sat/vbyte", e.feeRateFloor,
+		)
+		return e.feeRateFloor, nil
+	}
+
+	feeRate, ok := feeRateForTarget(estimates, target)
+	if !ok || feeRate < e.feeRateFloor {
+		return e.feeRateFloor, nil
+	}
+	return feeRate, nil
+}
+
+// GetFeeHistory returns the fee rates of the blocks mined in the given time
+// window. The history is available only for mempool.space explorers, for
+// Esplora ones the current fee estimates are returned instead.
+func (e *explorerSvc) GetFeeHistory(window time.Duration) (*FeeHistory, error) {
+	if window <= 0 {
+		return nil, fmt.Errorf("invalid time window, must be greater than 0")
+	}
+
+	period := mempoolFeeRatesPeriods[len(mempoolFeeRatesPeriods)-1].name
+	for _, p := range mempoolFeeRatesPeriods {
+		if window <= p.duration {
+			period = p.name
+			break
+		}
+	}
+
+	resp, err := http.Get(fmt.Sprintf("%s/v1/mining/blocks/fee-rates/%s", e.baseUrl, period))
+	if err != nil {
+		return nil, err
+	}
+	// nolint:all
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusNotFound {
+		return e.getEstimatedFeeHistory()
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp.StatusCode, "error getting fee history: %s", string(body))
+	}
+
+	blocks := make([]blockFeeRates, 0)
+	if err := json.Unmarshal(body, &blocks); err != nil {
+		return nil, err
+	}
+
+	since := time.Now().Add(-window).Unix()
+	feeRates := make([]float64, 0, len(blocks))
+	for _, b := range blocks {
+		if b.Timestamp >= since {
+			feeRates = append(feeRates, b.MedianFeeRate)
+		}
+	}
+	if len(feeRates) <= 0 {
+		return e.getEstimatedFeeHistory()
+	}
+
+	return newFeeHistory(feeRates, false), nil
+}
+
 func (e *explorerSvc) GetTxHex(txid string) (string, error) {
 	if hex, ok := e.cache.Get(txid); ok {
 		return hex, nil
@@ -142,7 +248,7 @@ func (e *explorerSvc) GetTxs(addr string) ([]tx, error) {
 		return nil, err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get txs: %s", string(body))
+		return nil, responseError(resp.StatusCode, "failed to get txs: %s", string(body))
 	}
 	payload := []tx{}
 	if err := json.Unmarshal(body, &payload); err != nil {
@@ -168,7 +274,7 @@ func (e explorerSvc) IsRBFTx(txid, txHex string) (bool, string, int64, error) {
 		return e.esploraIsRBFTx(txid, txHex)
 	}
 	if resp.StatusCode != http.StatusOK {
-		return false, "", -1, fmt.Errorf("%s", string(body))
+		return false, "", -1, responseError(resp.StatusCode, "%s", string(body))
 	}
 
 	isRbf, replacedBy, timestamp, err := e.mempoolIsRBFTx(
@@ -197,7 +303,7 @@ func (e *explorerSvc) GetTxOutspends(txid string) ([]spentStatus, error) {
 		return nil, err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get txs: %s", string(body))
+		return nil, responseError(resp.StatusCode, "failed to get txs: %s", string(body))
 	}
 
 	spentStatuses := make([]spentStatus, 0)
@@ -207,6 +313,29 @@ func (e *explorerSvc) GetTxOutspends(txid string) ([]spentStatus, error) {
 	return spentStatuses, nil
 }
 
+func (e *explorerSvc) GetOutspend(txid string, vout uint32) (*Outspend, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/tx/%s/outspend/%d", e.baseUrl, txid, vout))
+	if err != nil {
+		return nil, err
+	}
+
+	// nolint:all
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp.StatusCode, "failed to get outspend: %s", string(body))
+	}
+
+	outspend := &Outspend{}
+	if err := json.Unmarshal(body, outspend); err != nil {
+		return nil, err
+	}
+	return outspend, nil
+}
+
 func (e *explorerSvc) GetUtxos(addr string) ([]utxo, error) {
 	resp, err := http.Get(fmt.Sprintf("%s/address/%s/utxo", e.baseUrl, addr))
 	if err != nil {
@@ -220,7 +349,7 @@ func (e *explorerSvc) GetUtxos(addr string) ([]utxo, error) {
 		return nil, err
 	}
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to get utxos: %s", string(body))
+		return nil, responseError(resp.StatusCode, "failed to get utxos: %s", string(body))
 	}
 	payload := []utxo{}
 	if err := json.Unmarshal(body, &payload); err != nil {
@@ -251,27 +380,9 @@ func (e *explorerSvc) GetRedeemedVtxosBalance(
 		return
 	}
 
-	lockedBalance = make(map[int64]uint64, 0)
-	now := time.Now()
-	for _, utxo := range utxos {
-		blocktime := now
-		if utxo.Status.Confirmed {
-			blocktime = time.Unix(utxo.Status.Blocktime, 0)
-		}
-
-		delay := time.Duration(unilateralExitDelay.Seconds()) * time.Second
-		availableAt := blocktime.Add(delay)
-		if availableAt.After(now) {
-			if _, ok := lockedBalance[availableAt.Unix()]; !ok {
-				lockedBalance[availableAt.Unix()] = 0
-			}
-
-			lockedBalance[availableAt.Unix()] += utxo.Amount
-		} else {
-			spendableBalance += utxo.Amount
-		}
-	}
-
+	spendableBalance, lockedBalance = redeemedVtxosBalance(
+		utxos, unilateralExitDelay, time.Now(),
+	)
 	return
 }
 
@@ -290,7 +401,7 @@ func (e *explorerSvc) GetTxBlockTime(
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return false, 0, fmt.Errorf("failed to get block time: %s", string(body))
+		return false, 0, responseError(resp.StatusCode, "failed to get block time: %s", string(body))
 	}
 
 	var tx struct {
@@ -311,6 +422,56 @@ func (e *explorerSvc) GetTxBlockTime(
 
 }
 
+// GetBlockHeight returns the height of the tip of the chain.
+func (e *explorerSvc) GetBlockHeight() (int64, error) {
+	resp, err := http.Get(fmt.Sprintf("%s/blocks/tip/height", e.baseUrl))
+	if err != nil {
+		return 0, err
+	}
+	// nolint:all
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, responseError(resp.StatusCode, "failed to get block height: %s", string(body))
+	}
+
+	height, err := strconv.ParseInt(strings.TrimSpace(string(body)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid block height: %s", err)
+	}
+	return height, nil
+}
+
+func redeemedVtxosBalance(
+	utxos []utxo, unilateralExitDelay common.RelativeLocktime, now time.Time,
+) (spendableBalance uint64, lockedBalance map[int64]uint64) {
+	lockedBalance = make(map[int64]uint64, 0)
+	for _, utxo := range utxos {
+		blocktime := now
+		if utxo.Status.Confirmed {
+			blocktime = time.Unix(utxo.Status.Blocktime, 0)
+		}
+
+		delay := time.Duration(unilateralExitDelay.Seconds()) * time.Second
+		availableAt := blocktime.Add(delay)
+		if availableAt.After(now) {
+			if _, ok := lockedBalance[availableAt.Unix()]; !ok {
+				lockedBalance[availableAt.Unix()] = 0
+			}
+
+			lockedBalance[availableAt.Unix()] += utxo.Amount
+		} else {
+			spendableBalance += utxo.Amount
+		}
+	}
+
+	return
+}
+
 func (e *explorerSvc) getTxHex(txid string) (string, error) {
 	resp, err := http.Get(fmt.Sprintf("%s/tx/%s/hex", e.baseUrl, txid))
 	if err != nil {
@@ -324,7 +485,7 @@ func (e *explorerSvc) getTxHex(txid string) (string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get tx hex: %s", string(body))
+		return "", responseError(resp.StatusCode, "failed to get tx hex: %s", string(body))
 	}
 
 	hex := string(body)
@@ -347,12 +508,85 @@ func (e *explorerSvc) broadcast(txHex string) (string, error) {
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to broadcast: %s", string(bodyResponse))
+		return "", responseError(resp.StatusCode, "failed to broadcast: %s", string(bodyResponse))
 	}
 
 	return string(bodyResponse), nil
 }
 
+// getEstimatedFeeHistory returns the current fee estimates for the different
+// confirmation targets as fee history.
+// getFeeEstimates returns the fee rates, in sats/vbyte, by confirmation
+// target in number of blocks.
+func (e *explorerSvc) getFeeEstimates() (map[int]float64, error) {
+	endpoint, err := url.JoinPath(e.baseUrl, "fee-estimates")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:all
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp.StatusCode, "error getting fee estimates: %s", string(body))
+	}
+
+	response := make(map[string]float64)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, err
+	}
+
+	estimates := make(map[int]float64, len(response))
+	for key, feeRate := range response {
+		target, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee estimate target %s", key)
+		}
+		estimates[target] = feeRate
+	}
+	return estimates, nil
+}
+
+func (e *explorerSvc) getEstimatedFeeHistory() (*FeeHistory, error) {
+	endpoint, err := url.JoinPath(e.baseUrl, "fee-estimates")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.Get(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:all
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp.StatusCode, "error getting fee estimates: %s", resp.Status)
+	}
+
+	var response map[string]float64
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+
+	feeRates := make([]float64, 0, len(response))
+	for _, feeRate := range response {
+		feeRates = append(feeRates, feeRate)
+	}
+	if len(feeRates) <= 0 {
+		feeRates = append(feeRates, 1)
+	}
+
+	return newFeeHistory(feeRates, true), nil
+}
+
 func (e *explorerSvc) mempoolIsRBFTx(url, txid string) (bool, string, int64, error) {
 	resp, err := http.Get(url)
 	if err != nil {
@@ -367,7 +601,7 @@ func (e *explorerSvc) mempoolIsRBFTx(url, txid string) (bool, string, int64, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return false, "", -1, fmt.Errorf("%s", string(body))
+		return false, "", -1, responseError(resp.StatusCode, "%s", string(body))
 	}
 
 	replacements := make([]replacement, 0)
@@ -466,3 +700,36 @@ func newUtxo(explorerUtxo utxo, delay common.RelativeLocktime, tapscripts []stri
 		Tapscripts:  tapscripts,
 	}
 }
+
+// responseError returns the error for a response with the given unexpected
+// status code, wrapping ErrUnavailable in case of server side error.
+func responseError(statusCode int, format string, args ...any) error {
+	err := fmt.Errorf(format, args...)
+	if statusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%w: %s", ErrUnavailable, err)
+	}
+	return err
+}
+
+// feeRateForTarget returns the estimate of the greatest target not above the
+// given one, or that of the smallest target if they are all above.
+func feeRateForTarget(estimates map[int]float64, target int) (float64, bool) {
+	if len(estimates) <= 0 {
+		return 0, false
+	}
+
+	bestTarget := -1
+	for t := range estimates {
+		if t <= target && t > bestTarget {
+			bestTarget = t
+		}
+	}
+	if bestTarget < 0 {
+		for t := range estimates {
+			if bestTarget < 0 || t < bestTarget {
+				bestTarget = t
+			}
+		}
+	}
+	return estimates[bestTarget], true
+}
@@ -0,0 +1,153 @@
+package explorer
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeeHistory(t *testing.T) {
+	feeEstimates := map[string]float64{"1": 20, "6": 10, "144": 2}
+
+	t.Run("mempool", func(t *testing.T) {
+		now := time.Now().Unix()
+		blocks := []map[string]interface{}{
+			{"avgHeight": 1, "timestamp": now - 3*24*3600, "avgFee_50": 100},
+			{"avgHeight": 2, "timestamp": now - 3600, "avgFee_50": 5},
+			{"avgHeight": 3, "timestamp": now - 1800, "avgFee_50": 15},
+			{"avgHeight": 4, "timestamp": now - 60, "avgFee_50": 8},
+		}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v1/mining/blocks/fee-rates/24h":
+				// nolint:all
+				json.NewEncoder(w).Encode(blocks)
+			case "/fee-estimates":
+				// nolint:all
+				json.NewEncoder(w).Encode(feeEstimates)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		svc := NewExplorer(server.URL, common.BitcoinRegTest)
+
+		history, err := svc.GetFeeHistory(2 * time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, &FeeHistory{Best: 5, Typical: 8, Worst: 15}, history)
+
+		// no blocks mined in the window, fallback to the current estimates
+		history, err = svc.GetFeeHistory(time.Second)
+		require.NoError(t, err)
+		require.Equal(t, &FeeHistory{Best: 2, Typical: 10, Worst: 20, Estimated: true}, history)
+
+		_, err = svc.GetFeeHistory(0)
+		require.Error(t, err)
+	})
+
+	t.Run("esplora", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/fee-estimates" {
+				// nolint:all
+				json.NewEncoder(w).Encode(feeEstimates)
+				return
+			}
+			http.NotFound(w, r)
+		}))
+		defer server.Close()
+
+		svc := NewExplorer(server.URL, common.BitcoinRegTest)
+
+		history, err := svc.GetFeeHistory(24 * time.Hour)
+		require.NoError(t, err)
+		require.Equal(t, &FeeHistory{Best: 2, Typical: 10, Worst: 20, Estimated: true}, history)
+	})
+}
+
+func TestGetFeeRateForTarget(t *testing.T) {
+	feeEstimates := map[string]float64{"2": 20, "6": 10, "144": 0.5}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fee-estimates" {
+			// nolint:all
+			json.NewEncoder(w).Encode(feeEstimates)
+			return
+		}
+		http.NotFound(w, r)
+	}))
+	defer server.Close()
+
+	svc := NewExplorer(server.URL, common.BitcoinRegTest)
+
+	testCases := []struct {
+		target   int
+		expected float64
+	}{
+		{1, 20},
+		{2, 20},
+		{5, 20},
+		{6, 10},
+		{100, 10},
+		// below the default floor
+		{144, DefaultFeeRateFloor},
+		{1008, DefaultFeeRateFloor},
+	}
+	for _, tc := range testCases {
+		feeRate, err := svc.GetFeeRateForTarget(tc.target)
+		require.NoError(t, err)
+		require.Equal(t, tc.expected, feeRate, "target %d", tc.target)
+	}
+
+	_, err := svc.GetFeeRateForTarget(0)
+	require.Error(t, err)
+
+	// the floor is returned if the estimates are not available
+	unavailable := NewExplorer("http://localhost:1", common.BitcoinRegTest, WithFeeRateFloor(3))
+	feeRate, err := unavailable.GetFeeRateForTarget(1)
+	require.NoError(t, err)
+	require.Equal(t, 3.0, feeRate)
+
+	svc = NewExplorer(server.URL, common.BitcoinRegTest, WithFeeRateFloor(15))
+	feeRate, err = svc.GetFeeRateForTarget(6)
+	require.NoError(t, err)
+	require.Equal(t, 15.0, feeRate)
+}
+
+func TestGetOutspend(t *testing.T) {
+	txid := "f3e1a2b4c5d6e7f8091a2b3c4d5e6f708192a3b4c5d6e7f8091a2b3c4d5e6f70"
+	spendingTxid := "0a1b2c3d4e5f60718293a4b5c6d7e8f90a1b2c3d4e5f60718293a4b5c6d7e8f9"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tx/" + txid + "/outspend/0":
+			// nolint:all
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"spent": true, "txid": spendingTxid, "vin": 1,
+			})
+		case "/tx/" + txid + "/outspend/1":
+			// nolint:all
+			json.NewEncoder(w).Encode(map[string]interface{}{"spent": false})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	svc := NewExplorer(server.URL, common.BitcoinRegTest)
+
+	outspend, err := svc.GetOutspend(txid, 0)
+	require.NoError(t, err)
+	require.Equal(t, &Outspend{Spent: true, Txid: spendingTxid, Vin: 1}, outspend)
+
+	outspend, err = svc.GetOutspend(txid, 1)
+	require.NoError(t, err)
+	require.Equal(t, &Outspend{}, outspend)
+
+	_, err = svc.GetOutspend(txid, 2)
+	require.Error(t, err)
+}
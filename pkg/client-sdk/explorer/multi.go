@@ -0,0 +1,298 @@
+package explorer
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	log "github.com/sirupsen/logrus"
+)
+
+// FailoverPolicy defines the order in which a MultiExplorer tries its
+// endpoints.
+type FailoverPolicy int
+
+const (
+	// FailoverInOrder always tries the endpoints in the given order, the
+	// following ones are used only if the previous ones are unavailable.
+	FailoverInOrder FailoverPolicy = iota
+	// FailoverRoundRobin starts every request from the endpoint following the
+	// one that started the previous request, to spread the load.
+	FailoverRoundRobin
+)
+
+// EndpointHealth is the state of an endpoint of a MultiExplorer.
+type EndpointHealth struct {
+	Url string
+	// Healthy is false if the last request to the endpoint failed because of
+	// a connection or server side error.
+	Healthy bool
+	// LastError is the error of the last failed request to the endpoint.
+	LastError error
+	// Failures is the number of consecutive failed requests.
+	Failures int
+	// Served is the number of requests served by the endpoint.
+	Served int
+	// LastServedAt is the last time the endpoint served a request.
+	LastServedAt time.Time
+}
+
+// MultiExplorer is an Explorer that forwards every request to one of the
+// underlying explorers and falls over the next one in case of connection or
+// server side errors. Any other error, like a tx rejected by Broadcast, is
+// returned as is.
+type MultiExplorer struct {
+	explorers []Explorer
+	policy    FailoverPolicy
+
+	lock   *sync.Mutex
+	next   int
+	health []EndpointHealth
+}
+
+// NewMultiExplorer returns a MultiExplorer for the given explorer urls, tried
+// according to the given policy.
+func NewMultiExplorer(
+	urls []string, net common.Network, policy FailoverPolicy, opts ...Option,
+) (*MultiExplorer, error) {
+	explorers := make([]Explorer, 0, len(urls))
+	for _, url := range urls {
+		explorers = append(explorers, NewExplorer(url, net, opts...))
+	}
+	return newMultiExplorer(explorers, policy)
+}
+
+func newMultiExplorer(explorers []Explorer, policy FailoverPolicy) (*MultiExplorer, error) {
+	if len(explorers) <= 0 {
+		return nil, fmt.Errorf("missing explorers")
+	}
+	if policy != FailoverInOrder && policy != FailoverRoundRobin {
+		return nil, fmt.Errorf("unknown failover policy %d", policy)
+	}
+
+	health := make([]EndpointHealth, 0, len(explorers))
+	for _, e := range explorers {
+		health = append(health, EndpointHealth{Url: e.BaseUrl(), Healthy: true})
+	}
+	return &MultiExplorer{
+		explorers: explorers,
+		policy:    policy,
+		lock:      &sync.Mutex{},
+		health:    health,
+	}, nil
+}
+
+// Health returns the state of every endpoint, in the given order.
+func (m *MultiExplorer) Health() []EndpointHealth {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return append([]EndpointHealth{}, m.health...)
+}
+
+// BaseUrl returns the url of the endpoint the next request would be sent to.
+func (m *MultiExplorer) BaseUrl() string {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	return m.explorers[m.next].BaseUrl()
+}
+
+func (m *MultiExplorer) GetTxHex(txid string) (string, error) {
+	return withFailover(m, "get tx hex", func(e Explorer) (string, error) {
+		return e.GetTxHex(txid)
+	})
+}
+
+func (m *MultiExplorer) Broadcast(txHex string) (string, error) {
+	return withFailover(m, "broadcast tx", func(e Explorer) (string, error) {
+		return e.Broadcast(txHex)
+	})
+}
+
+func (m *MultiExplorer) GetTxs(addr string) ([]tx, error) {
+	return withFailover(m, "get txs", func(e Explorer) ([]tx, error) {
+		return e.GetTxs(addr)
+	})
+}
+
+func (m *MultiExplorer) IsRBFTx(txid, txHex string) (bool, string, int64, error) {
+	type rbfResult struct {
+		isRbf      bool
+		replacedBy string
+		timestamp  int64
+	}
+
+	res, err := withFailover(m, "check rbf tx", func(e Explorer) (rbfResult, error) {
+		isRbf, replacedBy, timestamp, err := e.IsRBFTx(txid, txHex)
+		return rbfResult{isRbf, replacedBy, timestamp}, err
+	})
+	if err != nil {
+		return false, "", -1, err
+	}
+	return res.isRbf, res.replacedBy, res.timestamp, nil
+}
+
+func (m *MultiExplorer) GetTxOutspends(txid string) ([]spentStatus, error) {
+	return withFailover(m, "get tx outspends", func(e Explorer) ([]spentStatus, error) {
+		return e.GetTxOutspends(txid)
+	})
+}
+
+func (m *MultiExplorer) GetOutspend(txid string, vout uint32) (*Outspend, error) {
+	return withFailover(m, "get outspend", func(e Explorer) (*Outspend, error) {
+		return e.GetOutspend(txid, vout)
+	})
+}
+
+func (m *MultiExplorer) GetUtxos(addr string) ([]utxo, error) {
+	return withFailover(m, "get utxos", func(e Explorer) ([]utxo, error) {
+		return e.GetUtxos(addr)
+	})
+}
+
+func (m *MultiExplorer) GetBalance(addr string) (uint64, error) {
+	return withFailover(m, "get balance", func(e Explorer) (uint64, error) {
+		return e.GetBalance(addr)
+	})
+}
+
+func (m *MultiExplorer) GetRedeemedVtxosBalance(
+	addr string, unilateralExitDelay common.RelativeLocktime,
+) (uint64, map[int64]uint64, error) {
+	type balanceResult struct {
+		spendable uint64
+		locked    map[int64]uint64
+	}
+
+	res, err := withFailover(m, "get redeemed vtxos balance", func(e Explorer) (balanceResult, error) {
+		spendable, locked, err := e.GetRedeemedVtxosBalance(addr, unilateralExitDelay)
+		return balanceResult{spendable, locked}, err
+	})
+	if err != nil {
+		return 0, nil, err
+	}
+	return res.spendable, res.locked, nil
+}
+
+func (m *MultiExplorer) GetTxBlockTime(
+	txid string,
+) (confirmed bool, blocktime int64, err error) {
+	type blockTimeResult struct {
+		confirmed bool
+		blocktime int64
+	}
+
+	res, err := withFailover(m, "get tx block time", func(e Explorer) (blockTimeResult, error) {
+		confirmed, blocktime, err := e.GetTxBlockTime(txid)
+		return blockTimeResult{confirmed, blocktime}, err
+	})
+	if err != nil {
+		return false, 0, err
+	}
+	return res.confirmed, res.blocktime, nil
+}
+
+func (m *MultiExplorer) GetBlockHeight() (int64, error) {
+	return withFailover(m, "get block height", func(e Explorer) (int64, error) {
+		return e.GetBlockHeight()
+	})
+}
+
+func (m *MultiExplorer) GetFeeRate() (float64, error) {
+	return withFailover(m, "get fee rate", func(e Explorer) (float64, error) {
+		return e.GetFeeRate()
+	})
+}
+
+func (m *MultiExplorer) GetFeeRateForTarget(target int) (float64, error) {
+	return withFailover(m, "get fee rate for target", func(e Explorer) (float64, error) {
+		return e.GetFeeRateForTarget(target)
+	})
+}
+
+func (m *MultiExplorer) GetFeeHistory(window time.Duration) (*FeeHistory, error) {
+	return withFailover(m, "get fee history", func(e Explorer) (*FeeHistory, error) {
+		return e.GetFeeHistory(window)
+	})
+}
+
+// order returns the indexes of the endpoints in the order to try them for the
+// next request.
+func (m *MultiExplorer) order() []int {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	start := m.next
+	if m.policy == FailoverRoundRobin {
+		m.next = (m.next + 1) % len(m.explorers)
+	}
+
+	indexes := make([]int, 0, len(m.explorers))
+	for i := range m.explorers {
+		indexes = append(indexes, (start+i)%len(m.explorers))
+	}
+	return indexes
+}
+
+func (m *MultiExplorer) markServed(i int) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.health[i].Healthy = true
+	m.health[i].Failures = 0
+	m.health[i].Served++
+	m.health[i].LastServedAt = time.Now()
+}
+
+func (m *MultiExplorer) markFailed(i int, err error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.health[i].Healthy = false
+	m.health[i].LastError = err
+	m.health[i].Failures++
+}
+
+// withFailover runs the given query against the endpoints in the order given
+// by the policy until one of them serves it. The error of the last endpoint is
+// returned if all of them are unavailable.
+func withFailover[T any](
+	m *MultiExplorer, op string, query func(Explorer) (T, error),
+) (T, error) {
+	var zero T
+	var lastErr error
+	for _, i := range m.order() {
+		e := m.explorers[i]
+		value, err := query(e)
+		if err != nil && isUnavailable(err) {
+			m.markFailed(i, err)
+			log.WithError(err).Warnf("explorer %s: failed to %s", e.BaseUrl(), op)
+			lastErr = err
+			continue
+		}
+
+		// any other error comes from an endpoint that served the request
+		m.markServed(i)
+		log.Debugf("explorer %s: served %s", e.BaseUrl(), op)
+		if err != nil {
+			return zero, err
+		}
+		return value, nil
+	}
+
+	return zero, fmt.Errorf("all explorers failed to %s: %w", op, lastErr)
+}
+
+// isUnavailable returns whether the given error is due to a connection or
+// server side error, rather than to the request.
+func isUnavailable(err error) bool {
+	if errors.Is(err, ErrUnavailable) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
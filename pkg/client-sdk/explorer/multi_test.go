@@ -0,0 +1,106 @@
+package explorer
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultiExplorer(t *testing.T) {
+	unavailable := fmt.Errorf("%w: failed to get tx hex", ErrUnavailable)
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newMultiExplorer(nil, FailoverInOrder)
+		require.Error(t, err)
+
+		_, err = newMultiExplorer([]Explorer{&mockExplorer{}}, FailoverPolicy(-1))
+		require.Error(t, err)
+	})
+
+	t.Run("in order", func(t *testing.T) {
+		explorers := []*mockExplorer{
+			{url: "a", err: unavailable},
+			{url: "b", confirmed: true, blocktime: 100},
+			{url: "c", confirmed: true, blocktime: 200},
+		}
+		svc, err := newMultiExplorer(
+			[]Explorer{explorers[0], explorers[1], explorers[2]}, FailoverInOrder,
+		)
+		require.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			confirmed, blocktime, err := svc.GetTxBlockTime("txid")
+			require.NoError(t, err)
+			require.True(t, confirmed)
+			require.Equal(t, int64(100), blocktime)
+		}
+		require.Equal(t, "a", svc.BaseUrl())
+
+		health := svc.Health()
+		require.False(t, health[0].Healthy)
+		require.Equal(t, 2, health[0].Failures)
+		require.ErrorIs(t, health[0].LastError, ErrUnavailable)
+		require.True(t, health[1].Healthy)
+		require.Equal(t, 2, health[1].Served)
+		require.Zero(t, health[2].Served)
+	})
+
+	t.Run("round robin", func(t *testing.T) {
+		explorers := []*mockExplorer{{url: "a"}, {url: "b"}}
+		svc, err := newMultiExplorer(
+			[]Explorer{explorers[0], explorers[1]}, FailoverRoundRobin,
+		)
+		require.NoError(t, err)
+
+		for i := 0; i < 4; i++ {
+			_, err := svc.Broadcast("txhex")
+			require.NoError(t, err)
+		}
+		require.Equal(t, 2, explorers[0].broadcasts)
+		require.Equal(t, 2, explorers[1].broadcasts)
+	})
+
+	t.Run("request errors are not retried", func(t *testing.T) {
+		explorers := []*mockExplorer{
+			{url: "a", err: fmt.Errorf("rejected")}, {url: "b"},
+		}
+		svc, err := newMultiExplorer(
+			[]Explorer{explorers[0], explorers[1]}, FailoverInOrder,
+		)
+		require.NoError(t, err)
+
+		_, err = svc.Broadcast("txhex")
+		require.EqualError(t, err, "rejected")
+		require.Zero(t, explorers[1].broadcasts)
+		require.True(t, svc.Health()[0].Healthy)
+	})
+
+	t.Run("all unavailable", func(t *testing.T) {
+		failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer failing.Close()
+		down := httptest.NewServer(http.NotFoundHandler())
+		down.Close()
+
+		svc, err := NewMultiExplorer(
+			[]string{failing.URL, down.URL}, common.BitcoinRegTest, FailoverInOrder,
+		)
+		require.NoError(t, err)
+
+		_, err = svc.GetTxHex("txid")
+		require.Error(t, err)
+
+		// the error of the last endpoint is the connection error
+		require.False(t, errors.Is(err, ErrUnavailable))
+		for _, health := range svc.Health() {
+			require.False(t, health.Healthy)
+		}
+		require.ErrorIs(t, svc.Health()[0].LastError, ErrUnavailable)
+	})
+}
@@ -0,0 +1,386 @@
+package explorer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	log "github.com/sirupsen/logrus"
+)
+
+// ErrNoQuorum is returned when not enough explorers agree on the result of a
+// query.
+var ErrNoQuorum = fmt.Errorf("explorers did not reach quorum")
+
+// quorumExplorer is a composite Explorer that queries all the underlying
+// explorers and returns a result only if at least quorum of them agree on it.
+// Broadcasts are submitted to every explorer.
+type quorumExplorer struct {
+	explorers []Explorer
+	quorum    int
+}
+
+// NewQuorumExplorer returns an Explorer that cross-checks the data returned by
+// the given explorers and requires at least quorum of them to agree.
+func NewQuorumExplorer(explorers []Explorer, quorum int) (Explorer, error) {
+	if len(explorers) <= 0 {
+		return nil, fmt.Errorf("missing explorers")
+	}
+	if quorum <= 0 || quorum > len(explorers) {
+		return nil, fmt.Errorf(
+			"invalid quorum %d, must be in range [1, %d]", quorum, len(explorers),
+		)
+	}
+	return &quorumExplorer{explorers, quorum}, nil
+}
+
+// NewQuorumExplorerFromUrls returns a quorum explorer for the given comma
+// separated list of explorer urls, requiring the majority of them to agree.
+func NewQuorumExplorerFromUrls(
+	baseUrls string, net common.Network, opts ...Option,
+) (Explorer, error) {
+	urls := strings.Split(baseUrls, ",")
+	explorers := make([]Explorer, 0, len(urls))
+	for _, url := range urls {
+		url = strings.TrimSpace(url)
+		if len(url) <= 0 {
+			continue
+		}
+		explorers = append(explorers, NewExplorer(url, net, opts...))
+	}
+	return NewQuorumExplorer(explorers, len(explorers)/2+1)
+}
+
+func (q *quorumExplorer) BaseUrl() string {
+	urls := make([]string, 0, len(q.explorers))
+	for _, e := range q.explorers {
+		urls = append(urls, e.BaseUrl())
+	}
+	return strings.Join(urls, ",")
+}
+
+// GetFeeRate returns the median of the fee rates returned by the explorers.
+func (q *quorumExplorer) GetFeeRate() (float64, error) {
+	results := queryAll(q.explorers, func(e Explorer) (float64, error) {
+		return e.GetFeeRate()
+	})
+
+	feeRates := make([]float64, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).Warnf("explorer %s: failed to get fee rate", r.url)
+			continue
+		}
+		feeRates = append(feeRates, r.value)
+	}
+	if len(feeRates) < q.quorum {
+		return 0, fmt.Errorf("%w: got %d fee rates, need %d", ErrNoQuorum, len(feeRates), q.quorum)
+	}
+
+	sort.Float64s(feeRates)
+	return feeRates[len(feeRates)/2], nil
+}
+
+// GetFeeRateForTarget returns the median of the fee rates for the given target
+// returned by the explorers.
+func (q *quorumExplorer) GetFeeRateForTarget(target int) (float64, error) {
+	results := queryAll(q.explorers, func(e Explorer) (float64, error) {
+		return e.GetFeeRateForTarget(target)
+	})
+
+	feeRates := make([]float64, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).Warnf("explorer %s: failed to get fee rate for target", r.url)
+			continue
+		}
+		feeRates = append(feeRates, r.value)
+	}
+	if len(feeRates) < q.quorum {
+		return 0, fmt.Errorf("%w: got %d fee rates, need %d", ErrNoQuorum, len(feeRates), q.quorum)
+	}
+
+	sort.Float64s(feeRates)
+	return feeRates[len(feeRates)/2], nil
+}
+
+// GetFeeHistory returns the median of every fee rate of the histories
+// returned by the explorers. The result is flagged as estimated if any of them
+// doesn't expose the history.
+func (q *quorumExplorer) GetFeeHistory(window time.Duration) (*FeeHistory, error) {
+	results := queryAll(q.explorers, func(e Explorer) (*FeeHistory, error) {
+		return e.GetFeeHistory(window)
+	})
+
+	best := make([]float64, 0, len(results))
+	typical := make([]float64, 0, len(results))
+	worst := make([]float64, 0, len(results))
+	estimated := false
+	for _, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).Warnf("explorer %s: failed to get fee history", r.url)
+			continue
+		}
+		best = append(best, r.value.Best)
+		typical = append(typical, r.value.Typical)
+		worst = append(worst, r.value.Worst)
+		estimated = estimated || r.value.Estimated
+	}
+	if len(best) < q.quorum {
+		return nil, fmt.Errorf(
+			"%w: got %d fee histories, need %d", ErrNoQuorum, len(best), q.quorum,
+		)
+	}
+
+	median := func(values []float64) float64 {
+		sort.Float64s(values)
+		return values[len(values)/2]
+	}
+	return &FeeHistory{
+		Best:      median(best),
+		Typical:   median(typical),
+		Worst:     median(worst),
+		Estimated: estimated,
+	}, nil
+}
+
+func (q *quorumExplorer) GetTxHex(txid string) (string, error) {
+	return withQuorum(q, "get tx hex", func(e Explorer) (string, error) {
+		return e.GetTxHex(txid)
+	}, func(txHex string) string {
+		return txHex
+	})
+}
+
+// Broadcast submits the tx to all the explorers and succeeds if at least one of
+// them accepted it.
+func (q *quorumExplorer) Broadcast(txHex string) (string, error) {
+	results := queryAll(q.explorers, func(e Explorer) (string, error) {
+		return e.Broadcast(txHex)
+	})
+
+	txid := ""
+	errs := make([]string, 0)
+	for _, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).Warnf("explorer %s: failed to broadcast tx", r.url)
+			errs = append(errs, fmt.Sprintf("%s: %s", r.url, r.err))
+			continue
+		}
+		txid = r.value
+	}
+	if len(txid) <= 0 {
+		return "", fmt.Errorf("failed to broadcast tx: %s", strings.Join(errs, ", "))
+	}
+	return txid, nil
+}
+
+func (q *quorumExplorer) GetTxs(addr string) ([]tx, error) {
+	return withQuorum(q, "get txs", func(e Explorer) ([]tx, error) {
+		txs, err := e.GetTxs(addr)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(txs, func(i, j int) bool {
+			return txs[i].Txid < txs[j].Txid
+		})
+		return txs, nil
+	}, jsonKey[[]tx])
+}
+
+func (q *quorumExplorer) IsRBFTx(txid, txHex string) (bool, string, int64, error) {
+	type rbfResult struct {
+		IsRbf      bool
+		ReplacedBy string
+		Timestamp  int64
+	}
+
+	res, err := withQuorum(q, "check rbf tx", func(e Explorer) (rbfResult, error) {
+		isRbf, replacedBy, timestamp, err := e.IsRBFTx(txid, txHex)
+		return rbfResult{isRbf, replacedBy, timestamp}, err
+	}, jsonKey[rbfResult])
+	if err != nil {
+		return false, "", -1, err
+	}
+	return res.IsRbf, res.ReplacedBy, res.Timestamp, nil
+}
+
+func (q *quorumExplorer) GetTxOutspends(txid string) ([]spentStatus, error) {
+	return withQuorum(q, "get tx outspends", func(e Explorer) ([]spentStatus, error) {
+		return e.GetTxOutspends(txid)
+	}, jsonKey[[]spentStatus])
+}
+
+func (q *quorumExplorer) GetOutspend(txid string, vout uint32) (*Outspend, error) {
+	return withQuorum(q, "get outspend", func(e Explorer) (*Outspend, error) {
+		return e.GetOutspend(txid, vout)
+	}, jsonKey[*Outspend])
+}
+
+func (q *quorumExplorer) GetUtxos(addr string) ([]utxo, error) {
+	return withQuorum(q, "get utxos", func(e Explorer) ([]utxo, error) {
+		utxos, err := e.GetUtxos(addr)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(utxos, func(i, j int) bool {
+			if utxos[i].Txid == utxos[j].Txid {
+				return utxos[i].Vout < utxos[j].Vout
+			}
+			return utxos[i].Txid < utxos[j].Txid
+		})
+		return utxos, nil
+	}, jsonKey[[]utxo])
+}
+
+func (q *quorumExplorer) GetBalance(addr string) (uint64, error) {
+	utxos, err := q.GetUtxos(addr)
+	if err != nil {
+		return 0, err
+	}
+
+	balance := uint64(0)
+	for _, u := range utxos {
+		balance += u.Amount
+	}
+	return balance, nil
+}
+
+func (q *quorumExplorer) GetRedeemedVtxosBalance(
+	addr string, unilateralExitDelay common.RelativeLocktime,
+) (uint64, map[int64]uint64, error) {
+	utxos, err := q.GetUtxos(addr)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	spendableBalance, lockedBalance := redeemedVtxosBalance(
+		utxos, unilateralExitDelay, time.Now(),
+	)
+	return spendableBalance, lockedBalance, nil
+}
+
+func (q *quorumExplorer) GetTxBlockTime(
+	txid string,
+) (confirmed bool, blocktime int64, err error) {
+	type blockTimeResult struct {
+		Confirmed bool
+		Blocktime int64
+	}
+
+	res, err := withQuorum(q, "get tx block time", func(e Explorer) (blockTimeResult, error) {
+		confirmed, blocktime, err := e.GetTxBlockTime(txid)
+		return blockTimeResult{confirmed, blocktime}, err
+	}, jsonKey[blockTimeResult])
+	if err != nil {
+		return false, 0, err
+	}
+	return res.Confirmed, res.Blocktime, nil
+}
+
+// GetBlockHeight returns the median of the tip heights returned by the
+// explorers, since they may be a block apart from each other.
+func (q *quorumExplorer) GetBlockHeight() (int64, error) {
+	results := queryAll(q.explorers, func(e Explorer) (int64, error) {
+		return e.GetBlockHeight()
+	})
+
+	heights := make([]int64, 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).Warnf("explorer %s: failed to get block height", r.url)
+			continue
+		}
+		heights = append(heights, r.value)
+	}
+	if len(heights) < q.quorum {
+		return 0, fmt.Errorf("%w: got %d block heights, need %d", ErrNoQuorum, len(heights), q.quorum)
+	}
+
+	sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+	return heights[len(heights)/2], nil
+}
+
+type queryResult[T any] struct {
+	url   string
+	value T
+	err   error
+}
+
+// queryAll runs the given query against all explorers concurrently and returns
+// the results in the same order of the explorers.
+func queryAll[T any](
+	explorers []Explorer, query func(Explorer) (T, error),
+) []queryResult[T] {
+	results := make([]queryResult[T], len(explorers))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(explorers))
+	for i, e := range explorers {
+		go func(i int, e Explorer) {
+			defer wg.Done()
+			value, err := query(e)
+			results[i] = queryResult[T]{e.BaseUrl(), value, err}
+		}(i, e)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// withQuorum queries all the explorers and returns the result that at least
+// quorum of them agree on. Results are compared by the given key function.
+// Any disagreement among explorers is logged.
+func withQuorum[T any](
+	q *quorumExplorer, op string,
+	query func(Explorer) (T, error), key func(T) string,
+) (T, error) {
+	results := queryAll(q.explorers, query)
+
+	votes := make(map[string][]queryResult[T])
+	for _, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).Warnf("explorer %s: failed to %s", r.url, op)
+			continue
+		}
+		k := key(r.value)
+		votes[k] = append(votes[k], r)
+	}
+
+	if len(votes) > 1 {
+		groups := make([]string, 0, len(votes))
+		for _, rr := range votes {
+			urls := make([]string, 0, len(rr))
+			for _, r := range rr {
+				urls = append(urls, r.url)
+			}
+			groups = append(groups, fmt.Sprintf("[%s]", strings.Join(urls, ", ")))
+		}
+		sort.Strings(groups)
+		log.Warnf(
+			"explorers disagree on %s, results grouped by agreement: %s",
+			op, strings.Join(groups, " "),
+		)
+	}
+
+	for _, rr := range votes {
+		if len(rr) >= q.quorum {
+			return rr[0].value, nil
+		}
+	}
+
+	var zero T
+	return zero, fmt.Errorf(
+		"%w: failed to %s, %d explorers must agree", ErrNoQuorum, op, q.quorum,
+	)
+}
+
+func jsonKey[T any](v T) string {
+	// nolint:all
+	buf, _ := json.Marshal(v)
+	return string(buf)
+}
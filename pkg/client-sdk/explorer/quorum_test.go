@@ -0,0 +1,156 @@
+package explorer
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuorumExplorer(t *testing.T) {
+	t.Run("invalid", func(t *testing.T) {
+		_, err := NewQuorumExplorer(nil, 1)
+		require.Error(t, err)
+
+		_, err = NewQuorumExplorer([]Explorer{&mockExplorer{}}, 2)
+		require.Error(t, err)
+	})
+
+	t.Run("get tx block time", func(t *testing.T) {
+		fixtures := []struct {
+			name          string
+			explorers     []Explorer
+			quorum        int
+			expectedErr   error
+			expectedBlock int64
+		}{
+			{
+				name: "all agree",
+				explorers: []Explorer{
+					&mockExplorer{url: "a", confirmed: true, blocktime: 100},
+					&mockExplorer{url: "b", confirmed: true, blocktime: 100},
+					&mockExplorer{url: "c", confirmed: true, blocktime: 100},
+				},
+				quorum:        2,
+				expectedBlock: 100,
+			},
+			{
+				name: "2 of 3 agree",
+				explorers: []Explorer{
+					&mockExplorer{url: "a", confirmed: true, blocktime: 100},
+					&mockExplorer{url: "b", confirmed: false, blocktime: -1},
+					&mockExplorer{url: "c", confirmed: true, blocktime: 100},
+				},
+				quorum:        2,
+				expectedBlock: 100,
+			},
+			{
+				name: "no quorum",
+				explorers: []Explorer{
+					&mockExplorer{url: "a", confirmed: true, blocktime: 100},
+					&mockExplorer{url: "b", confirmed: false, blocktime: -1},
+					&mockExplorer{url: "c", err: fmt.Errorf("unreachable")},
+				},
+				quorum:      2,
+				expectedErr: ErrNoQuorum,
+			},
+		}
+
+		for _, f := range fixtures {
+			t.Run(f.name, func(t *testing.T) {
+				svc, err := NewQuorumExplorer(f.explorers, f.quorum)
+				require.NoError(t, err)
+
+				confirmed, blocktime, err := svc.GetTxBlockTime("txid")
+				if f.expectedErr != nil {
+					require.True(t, errors.Is(err, f.expectedErr))
+					return
+				}
+				require.NoError(t, err)
+				require.True(t, confirmed)
+				require.Equal(t, f.expectedBlock, blocktime)
+			})
+		}
+	})
+
+	t.Run("get utxos ignores ordering", func(t *testing.T) {
+		u1 := utxo{Txid: "aa", Vout: 0, Amount: 1000}
+		u2 := utxo{Txid: "bb", Vout: 1, Amount: 2000}
+		svc, err := NewQuorumExplorer([]Explorer{
+			&mockExplorer{url: "a", utxos: []utxo{u1, u2}},
+			&mockExplorer{url: "b", utxos: []utxo{u2, u1}},
+		}, 2)
+		require.NoError(t, err)
+
+		balance, err := svc.GetBalance("addr")
+		require.NoError(t, err)
+		require.Equal(t, uint64(3000), balance)
+	})
+
+	t.Run("broadcast to all", func(t *testing.T) {
+		explorers := []*mockExplorer{
+			{url: "a"}, {url: "b", err: fmt.Errorf("rejected")}, {url: "c"},
+		}
+		svc, err := NewQuorumExplorer(
+			[]Explorer{explorers[0], explorers[1], explorers[2]}, 2,
+		)
+		require.NoError(t, err)
+
+		txid, err := svc.Broadcast("txhex")
+		require.NoError(t, err)
+		require.Equal(t, "txid", txid)
+		for _, e := range explorers {
+			require.Equal(t, 1, e.broadcasts)
+		}
+		require.Equal(t, "a,b,c", svc.BaseUrl())
+	})
+}
+
+type mockExplorer struct {
+	url        string
+	err        error
+	confirmed  bool
+	blocktime  int64
+	utxos      []utxo
+	broadcasts int
+}
+
+func (m *mockExplorer) GetTxHex(string) (string, error) { return "", m.err }
+func (m *mockExplorer) Broadcast(string) (string, error) {
+	m.broadcasts++
+	if m.err != nil {
+		return "", m.err
+	}
+	return "txid", nil
+}
+func (m *mockExplorer) GetTxs(string) ([]tx, error) { return nil, m.err }
+func (m *mockExplorer) IsRBFTx(string, string) (bool, string, int64, error) {
+	return false, "", -1, m.err
+}
+func (m *mockExplorer) GetTxOutspends(string) ([]spentStatus, error)  { return nil, m.err }
+func (m *mockExplorer) GetOutspend(string, uint32) (*Outspend, error) { return nil, m.err }
+func (m *mockExplorer) GetUtxos(string) ([]utxo, error) {
+	// return a copy since callers may reorder the slice
+	return append([]utxo{}, m.utxos...), m.err
+}
+func (m *mockExplorer) GetBalance(string) (uint64, error) { return 0, m.err }
+func (m *mockExplorer) GetRedeemedVtxosBalance(
+	string, common.RelativeLocktime,
+) (uint64, map[int64]uint64, error) {
+	return 0, nil, m.err
+}
+func (m *mockExplorer) GetTxBlockTime(string) (bool, int64, error) {
+	return m.confirmed, m.blocktime, m.err
+}
+func (m *mockExplorer) GetBlockHeight() (int64, error) { return 0, m.err }
+func (m *mockExplorer) BaseUrl() string                { return m.url }
+func (m *mockExplorer) GetFeeRate() (float64, error)   { return 1, m.err }
+func (m *mockExplorer) GetFeeRateForTarget(int) (float64, error) {
+	return 1, m.err
+}
+func (m *mockExplorer) GetFeeHistory(time.Duration) (*FeeHistory, error) {
+	return &FeeHistory{Best: 1, Typical: 1, Worst: 1}, m.err
+}
@@ -1,6 +1,9 @@
 package explorer
 
 import (
+	"sort"
+	"time"
+
 	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/pkg/client-sdk/types"
 )
@@ -10,6 +13,14 @@ type spentStatus struct {
 	SpentBy string `json:"txid,omitempty"`
 }
 
+// Outspend is the spending status of a tx output. If spent, Txid and Vin are
+// the spending tx and the index of its input spending the output.
+type Outspend struct {
+	Spent bool   `json:"spent"`
+	Txid  string `json:"txid,omitempty"`
+	Vin   uint32 `json:"vin,omitempty"`
+}
+
 type tx struct {
 	Txid string `json:"txid"`
 	Vout []struct {
@@ -36,14 +47,57 @@ type replacement struct {
 	Replaces  []replacement `json:"replaces"`
 }
 
+// FeeHistory summarizes the fee rates, in sat/vbyte, over a time window.
+type FeeHistory struct {
+	Best    float64 `json:"best"`
+	Typical float64 `json:"typical"`
+	Worst   float64 `json:"worst"`
+	// Estimated is true if the explorer doesn't expose the history of fee
+	// rates and the current fee estimates are returned instead.
+	Estimated bool `json:"estimated"`
+}
+
+func newFeeHistory(feeRates []float64, estimated bool) *FeeHistory {
+	sorted := append([]float64{}, feeRates...)
+	sort.Float64s(sorted)
+	return &FeeHistory{
+		Best:      sorted[0],
+		Typical:   sorted[len(sorted)/2],
+		Worst:     sorted[len(sorted)-1],
+		Estimated: estimated,
+	}
+}
+
+// mempoolFeeRatesPeriods are the time periods supported by the mempool.space
+// api to get the history of the fee rates of the mined blocks.
+var mempoolFeeRatesPeriods = []struct {
+	name     string
+	duration time.Duration
+}{
+	{"24h", 24 * time.Hour},
+	{"3d", 3 * 24 * time.Hour},
+	{"1w", 7 * 24 * time.Hour},
+	{"1m", 30 * 24 * time.Hour},
+	{"3m", 90 * 24 * time.Hour},
+	{"6m", 180 * 24 * time.Hour},
+	{"1y", 365 * 24 * time.Hour},
+}
+
+type blockFeeRates struct {
+	AvgHeight     int64   `json:"avgHeight"`
+	Timestamp     int64   `json:"timestamp"`
+	MedianFeeRate float64 `json:"avgFee_50"`
+}
+
 type utxo struct {
 	Txid   string `json:"txid"`
 	Vout   uint32 `json:"vout"`
 	Amount uint64 `json:"value"`
 	Asset  string `json:"asset,omitempty"`
 	Status struct {
-		Confirmed bool  `json:"confirmed"`
-		Blocktime int64 `json:"block_time"`
+		Confirmed   bool  `json:"confirmed"`
+		BlockHeight int64 `json:"block_height"`
+		Blocktime   int64 `json:"block_time"`
 	} `json:"status"`
 }
 
@@ -0,0 +1,104 @@
+package arksdk
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/btcsuite/btcd/wire"
+)
+
+// ErrHashlockClaimed is returned by RefundHashlock if the hash lock output
+// has already been claimed by revealing the preimage.
+var ErrHashlockClaimed = fmt.Errorf("hash lock output already claimed")
+
+// RefundHashlock broadcasts the given signed tx refunding the hash lock output
+// at the given outpoint. Before broadcasting, and again if the broadcast
+// fails, it checks whether the output has been claimed through the given hash
+// lock closure: in that case the refund is aborted and the preimage revealed
+// by the claim tx is returned along with ErrHashlockClaimed, so that it can be
+// used in chained swaps.
+func (a *covenantlessArkClient) RefundHashlock(
+	ctx context.Context, outpoint client.Outpoint,
+	closure *tree.HashLockMultisigClosure, refundTx string,
+) (string, []byte, error) {
+	if err := a.safeCheck(); err != nil {
+		return "", nil, err
+	}
+
+	var tx wire.MsgTx
+	if err := tx.Deserialize(hex.NewDecoder(strings.NewReader(refundTx))); err != nil {
+		return "", nil, fmt.Errorf("invalid refund tx: %s", err)
+	}
+	if !spendsOutpoint(&tx, outpoint) {
+		return "", nil, fmt.Errorf("refund tx does not spend %s", outpoint)
+	}
+	txid := tx.TxHash().String()
+
+	spent, preimage, err := a.getHashlockPreimage(outpoint, closure, txid)
+	if err != nil {
+		return "", nil, err
+	}
+	if preimage != nil {
+		return "", preimage, ErrHashlockClaimed
+	}
+	// the refund has already been broadcasted
+	if spent {
+		return txid, nil, nil
+	}
+
+	if _, err := a.explorer.Broadcast(refundTx); err != nil {
+		// the claim tx might have been broadcasted in the meanwhile
+		_, preimage, checkErr := a.getHashlockPreimage(outpoint, closure, txid)
+		if checkErr == nil && preimage != nil {
+			return "", preimage, ErrHashlockClaimed
+		}
+		return "", nil, err
+	}
+
+	return txid, nil, nil
+}
+
+// getHashlockPreimage returns whether the given hash lock output is spent and,
+// if spent by a claim tx, the preimage revealed by it. It fails if the output
+// is spent by a tx other than the claim or the given refund one.
+func (a *covenantlessArkClient) getHashlockPreimage(
+	outpoint client.Outpoint, closure *tree.HashLockMultisigClosure,
+	refundTxid string,
+) (bool, []byte, error) {
+	outspend, err := a.explorer.GetOutspend(outpoint.Txid, outpoint.VOut)
+	if err != nil {
+		return false, nil, err
+	}
+	if !outspend.Spent {
+		return false, nil, nil
+	}
+	if outspend.Txid == refundTxid {
+		return true, nil, nil
+	}
+
+	spendingTx, err := a.getTx(outspend.Txid)
+	if err != nil {
+		return true, nil, err
+	}
+	preimage, err := tree.ExtractPreimage(spendingTx, int(outspend.Vin), closure)
+	if err != nil {
+		return true, nil, fmt.Errorf(
+			"hash lock output already spent by tx %s: %s", outspend.Txid, err,
+		)
+	}
+	return true, preimage, nil
+}
+
+func spendsOutpoint(tx *wire.MsgTx, outpoint client.Outpoint) bool {
+	for _, in := range tx.TxIn {
+		if in.PreviousOutPoint.Hash.String() == outpoint.Txid &&
+			in.PreviousOutPoint.Index == outpoint.VOut {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,208 @@
+package arksdk
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRefundHashlock(t *testing.T) {
+	ctx := context.Background()
+	serverKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	userKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	preimage := bytes.Repeat([]byte{0x01}, 32)
+	closure := &tree.HashLockMultisigClosure{
+		MultisigClosure: tree.MultisigClosure{
+			PubKeys: []*btcec.PublicKey{userKey.PubKey(), serverKey.PubKey()},
+		},
+		Hash: sha256.Sum256(preimage),
+	}
+	outpoint := client.Outpoint{Txid: chainhash.Hash{0x01}.String(), VOut: 1}
+
+	// the claim tx reveals the preimage in the witness of its second input
+	var preimageWitness bytes.Buffer
+	require.NoError(t, psbt.WriteTxWitness(&preimageWitness, wire.TxWitness{preimage}))
+	claimWitness, err := closure.Witness(bytes.Repeat([]byte{0x00}, 33), map[string][]byte{
+		hex.EncodeToString(schnorr.SerializePubKey(userKey.PubKey())):   bytes.Repeat([]byte{0x01}, 64),
+		hex.EncodeToString(schnorr.SerializePubKey(serverKey.PubKey())): bytes.Repeat([]byte{0x02}, 64),
+		tree.ConditionWitnessKey: preimageWitness.Bytes(),
+	})
+	require.NoError(t, err)
+	claimTx := makeTestTx(wire.OutPoint{Hash: chainhash.Hash{0x02}}, nil)
+	claimTx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: chainhash.Hash{0x01}, Index: 1},
+		Witness:          claimWitness,
+	})
+
+	// the other tx spends the output without revealing any preimage
+	otherTx := makeTestTx(
+		wire.OutPoint{Hash: chainhash.Hash{0x01}, Index: 1},
+		wire.TxWitness{bytes.Repeat([]byte{0x01}, 64)},
+	)
+	otherTx.TxOut[0].Value = 500
+
+	refundTx := makeTestTx(wire.OutPoint{Hash: chainhash.Hash{0x01}, Index: 1}, nil)
+	refundTxHex := encodeTestTx(t, refundTx)
+	refundTxid := refundTx.TxHash().String()
+
+	claimOutspend := &explorer.Outspend{Spent: true, Txid: claimTx.TxHash().String(), Vin: 1}
+
+	t.Run("not spent", func(t *testing.T) {
+		explorerSvc := newMockedHashlockExplorer(t, claimTx, otherTx)
+		arkClient := newTestArkClient(t, serverKey, userKey, explorerSvc.url, nil)
+
+		txid, revealed, err := arkClient.RefundHashlock(ctx, outpoint, closure, refundTxHex)
+		require.NoError(t, err)
+		require.Equal(t, refundTxid, txid)
+		require.Nil(t, revealed)
+		require.Equal(t, []string{refundTxHex}, explorerSvc.broadcasted)
+	})
+
+	t.Run("already claimed", func(t *testing.T) {
+		explorerSvc := newMockedHashlockExplorer(t, claimTx, otherTx)
+		explorerSvc.outspend = claimOutspend
+		arkClient := newTestArkClient(t, serverKey, userKey, explorerSvc.url, nil)
+
+		txid, revealed, err := arkClient.RefundHashlock(ctx, outpoint, closure, refundTxHex)
+		require.ErrorIs(t, err, ErrHashlockClaimed)
+		require.Empty(t, txid)
+		require.Equal(t, preimage, revealed)
+		require.Empty(t, explorerSvc.broadcasted)
+	})
+
+	t.Run("claimed while refunding", func(t *testing.T) {
+		explorerSvc := newMockedHashlockExplorer(t, claimTx, otherTx)
+		explorerSvc.onBroadcast = claimOutspend
+		arkClient := newTestArkClient(t, serverKey, userKey, explorerSvc.url, nil)
+
+		txid, revealed, err := arkClient.RefundHashlock(ctx, outpoint, closure, refundTxHex)
+		require.ErrorIs(t, err, ErrHashlockClaimed)
+		require.Empty(t, txid)
+		require.Equal(t, preimage, revealed)
+	})
+
+	t.Run("already refunded", func(t *testing.T) {
+		explorerSvc := newMockedHashlockExplorer(t, claimTx, otherTx)
+		explorerSvc.outspend = &explorer.Outspend{Spent: true, Txid: refundTxid}
+		arkClient := newTestArkClient(t, serverKey, userKey, explorerSvc.url, nil)
+
+		txid, revealed, err := arkClient.RefundHashlock(ctx, outpoint, closure, refundTxHex)
+		require.NoError(t, err)
+		require.Equal(t, refundTxid, txid)
+		require.Nil(t, revealed)
+		require.Empty(t, explorerSvc.broadcasted)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		explorerSvc := newMockedHashlockExplorer(t, claimTx, otherTx)
+		explorerSvc.outspend = &explorer.Outspend{Spent: true, Txid: otherTx.TxHash().String()}
+		arkClient := newTestArkClient(t, serverKey, userKey, explorerSvc.url, nil)
+
+		_, _, err := arkClient.RefundHashlock(ctx, outpoint, closure, refundTxHex)
+		require.ErrorContains(t, err, "already spent by tx")
+
+		_, _, err = arkClient.RefundHashlock(
+			ctx, client.Outpoint{Txid: outpoint.Txid}, closure, refundTxHex,
+		)
+		require.ErrorContains(t, err, "does not spend")
+
+		_, _, err = arkClient.RefundHashlock(ctx, outpoint, closure, "not a tx")
+		require.ErrorContains(t, err, "invalid refund tx")
+
+		require.Empty(t, explorerSvc.broadcasted)
+	})
+}
+
+// mockedHashlockExplorer serves the outspend of the hash lock output and the
+// given txs. If onBroadcast is set, the broadcast fails and the output is
+// reported as spent as per onBroadcast from then on.
+type mockedHashlockExplorer struct {
+	url         string
+	lock        sync.Mutex
+	txs         map[string]string
+	outspend    *explorer.Outspend
+	onBroadcast *explorer.Outspend
+	broadcasted []string
+}
+
+func newMockedHashlockExplorer(t *testing.T, txs ...*wire.MsgTx) *mockedHashlockExplorer {
+	e := &mockedHashlockExplorer{
+		txs:      make(map[string]string),
+		outspend: &explorer.Outspend{},
+	}
+	for _, tx := range txs {
+		e.txs[tx.TxHash().String()] = encodeTestTx(t, tx)
+	}
+
+	svc := httptest.NewServer(http.HandlerFunc(e.serveHTTP))
+	t.Cleanup(svc.Close)
+	e.url = svc.URL
+	return e
+}
+
+func (e *mockedHashlockExplorer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case r.Method == http.MethodPost && r.URL.Path == "/tx":
+		body, _ := io.ReadAll(r.Body)
+		if e.onBroadcast != nil {
+			e.outspend = e.onBroadcast
+			http.Error(w, "bad-txns-inputs-missingorspent", http.StatusBadRequest)
+			return
+		}
+		e.broadcasted = append(e.broadcasted, string(body))
+		// nolint:all
+		w.Write([]byte("ok"))
+	case len(path) == 4 && path[2] == "outspend":
+		// nolint:all
+		json.NewEncoder(w).Encode(e.outspend)
+	case len(path) == 3 && path[2] == "hex":
+		txHex, ok := e.txs[path[1]]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		// nolint:all
+		w.Write([]byte(txHex))
+	default:
+		http.Error(w, fmt.Sprintf("unexpected request %s", r.URL.Path), http.StatusBadRequest)
+	}
+}
+
+func makeTestTx(input wire.OutPoint, witness wire.TxWitness) *wire.MsgTx {
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{PreviousOutPoint: input, Witness: witness})
+	tx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: []byte{0x51, 0x20}})
+	return tx
+}
+
+func encodeTestTx(t *testing.T, tx *wire.MsgTx) string {
+	var buf bytes.Buffer
+	require.NoError(t, tx.Serialize(&buf))
+	return hex.EncodeToString(buf.Bytes())
+}
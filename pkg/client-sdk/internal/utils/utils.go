@@ -6,12 +6,10 @@ import (
 	"crypto/rand"
 	"crypto/sha256"
 	"fmt"
-	"sort"
 	"sync"
 
 	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/pkg/client-sdk/client"
-	"github.com/ark-network/ark/pkg/client-sdk/types"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -20,67 +18,6 @@ import (
 	"golang.org/x/crypto/pbkdf2"
 )
 
-func CoinSelect(
-	boardingUtxos []types.Utxo,
-	vtxos []client.TapscriptsVtxo,
-	amount,
-	dust uint64,
-	sortByExpirationTime bool,
-) ([]types.Utxo, []client.TapscriptsVtxo, uint64, error) {
-	selected, notSelected := make([]client.TapscriptsVtxo, 0), make([]client.TapscriptsVtxo, 0)
-	selectedBoarding, notSelectedBoarding := make([]types.Utxo, 0), make([]types.Utxo, 0)
-	selectedAmount := uint64(0)
-
-	if sortByExpirationTime {
-		// sort vtxos by expiration (older first)
-		sort.SliceStable(vtxos, func(i, j int) bool {
-			return vtxos[i].ExpiresAt.Before(vtxos[j].ExpiresAt)
-		})
-
-		sort.SliceStable(boardingUtxos, func(i, j int) bool {
-			return boardingUtxos[i].SpendableAt.Before(boardingUtxos[j].SpendableAt)
-		})
-	}
-
-	for _, boardingUtxo := range boardingUtxos {
-		if selectedAmount >= amount {
-			notSelectedBoarding = append(notSelectedBoarding, boardingUtxo)
-			break
-		}
-
-		selectedBoarding = append(selectedBoarding, boardingUtxo)
-		selectedAmount += boardingUtxo.Amount
-	}
-
-	for _, vtxo := range vtxos {
-		if selectedAmount >= amount {
-			notSelected = append(notSelected, vtxo)
-			break
-		}
-
-		selected = append(selected, vtxo)
-		selectedAmount += vtxo.Amount
-	}
-
-	if selectedAmount < amount {
-		return nil, nil, 0, fmt.Errorf("not enough funds to cover amount %d", amount)
-	}
-
-	change := selectedAmount - amount
-
-	if change < dust {
-		if len(notSelected) > 0 {
-			selected = append(selected, notSelected[0])
-			change += notSelected[0].Amount
-		} else if len(notSelectedBoarding) > 0 {
-			selectedBoarding = append(selectedBoarding, notSelectedBoarding[0])
-			change += notSelectedBoarding[0].Amount
-		}
-	}
-
-	return selectedBoarding, selected, change, nil
-}
-
 func ParseBitcoinAddress(addr string, net chaincfg.Params) (
 	bool, []byte, error,
 ) {
@@ -0,0 +1,289 @@
+package redemption
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/mempool"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/input"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+)
+
+// ErrNoAnchor is returned when the last tx of the redeem path has no anchor
+// output that a child tx can spend to bump its fee.
+var ErrNoAnchor = errors.New("redeem path has no spendable anchor output")
+
+// BumpFee returns a signed CPFP child, in hex format, of the last tx of the
+// redeem path, so that the unconfirmed txs of the path and the child pay
+// together the given fee rate. The child spends the anchor output of the
+// last tx and the smallest of the given mature utxos of the wallet that can
+// pay the extra fee, the change goes to a new onchain address of the wallet.
+// The given getTx fetches the prevouts of the path and of the utxos.
+func (r *CovenantlessRedeemBranch) BumpFee(
+	ctx context.Context, w wallet.WalletService, utxos []types.Utxo,
+	getTx func(txid string) (*wire.MsgTx, error), feeRate chainfee.SatPerKVByte,
+) (string, error) {
+	offchainPath, err := r.OffchainPath()
+	if err != nil {
+		return "", err
+	}
+	if len(offchainPath) <= 0 {
+		return "", fmt.Errorf("redeem path is already onchain")
+	}
+
+	parent := offchainPath[len(offchainPath)-1].UnsignedTx
+	anchorIndex := -1
+	for i, out := range parent.TxOut {
+		if tree.IsAnchor(out) {
+			anchorIndex = i
+			break
+		}
+	}
+	if anchorIndex < 0 {
+		return "", ErrNoAnchor
+	}
+	anchor := parent.TxOut[anchorIndex]
+
+	pathFees, pathVSize, err := pathFees(offchainPath, getTx)
+	if err != nil {
+		return "", err
+	}
+
+	_, onchainAddr, err := w.NewAddress(ctx, true)
+	if err != nil {
+		return "", err
+	}
+	changeOut, _, err := client.Output{Address: onchainAddr.Address}.ToTxOut()
+	if err != nil {
+		return "", err
+	}
+
+	// spend the smallest utxo covering the fee with a change above dust
+	utxos = append([]types.Utxo{}, utxos...)
+	sort.SliceStable(utxos, func(i, j int) bool {
+		return utxos[i].Amount < utxos[j].Amount
+	})
+	for _, utxo := range utxos {
+		leafProof, err := exitLeafProof(utxo.Tapscripts)
+		if err != nil {
+			return "", err
+		}
+
+		childVSize := estimateChildVSize(leafProof)
+		fee := int64(feeRate.FeeForVSize(lntypes.VByte(pathVSize+childVSize)).
+			ToUnit(btcutil.AmountSatoshi)) - pathFees
+		if fee < 0 {
+			fee = 0
+		}
+		change := anchor.Value + int64(utxo.Amount) - fee
+		changeOut.Value = change
+		if change <= 0 || mempool.IsDust(changeOut, mempool.DefaultMinRelayTxFee) {
+			continue
+		}
+
+		return r.signChild(
+			ctx, w, getTx, parent.TxHash(), uint32(anchorIndex), anchor, utxo, leafProof, changeOut,
+		)
+	}
+
+	return "", fmt.Errorf("no mature wallet utxo to cover the fee of the redeem path")
+}
+
+// BumpFeeForTarget is like BumpFee, at the fee rate estimated by the explorer
+// for the redeem path to confirm within the given number of blocks.
+func (r *CovenantlessRedeemBranch) BumpFeeForTarget(
+	ctx context.Context, w wallet.WalletService, utxos []types.Utxo,
+	getTx func(txid string) (*wire.MsgTx, error), target int,
+) (string, error) {
+	satsPerVByte, err := r.explorer.GetFeeRateForTarget(target)
+	if err != nil {
+		return "", err
+	}
+	feeRate := chainfee.SatPerKVByte(math.Round(satsPerVByte * 1000))
+	return r.BumpFee(ctx, w, utxos, getTx, feeRate)
+}
+
+// pathFees returns the fees paid by the given txs and their total vsize once
+// signed.
+func pathFees(
+	path []*psbt.Packet, getTx func(txid string) (*wire.MsgTx, error),
+) (int64, int64, error) {
+	fees := int64(0)
+	vsize := int64(0)
+	for i, ptx := range path {
+		prevout := ptx.UnsignedTx.TxIn[0].PreviousOutPoint
+		var inputAmount int64
+		switch {
+		case ptx.Inputs[0].WitnessUtxo != nil:
+			inputAmount = ptx.Inputs[0].WitnessUtxo.Value
+		case i > 0:
+			inputAmount = path[i-1].UnsignedTx.TxOut[prevout.Index].Value
+		default:
+			prevoutTx, err := getTx(prevout.Hash.String())
+			if err != nil {
+				return 0, 0, err
+			}
+			if int(prevout.Index) >= len(prevoutTx.TxOut) {
+				return 0, 0, fmt.Errorf("prevout %s not found", prevout)
+			}
+			inputAmount = prevoutTx.TxOut[prevout.Index].Value
+		}
+
+		outputAmount := int64(0)
+		for _, out := range ptx.UnsignedTx.TxOut {
+			outputAmount += out.Value
+		}
+		fees += inputAmount - outputAmount
+
+		// every tx of the tree spends its parent with a key path signature
+		weight := (&input.TxWeightEstimator{}).
+			AddTaprootKeySpendInput(txscript.SigHashDefault)
+		for _, out := range ptx.UnsignedTx.TxOut {
+			weight.AddOutput(out.PkScript)
+		}
+		vsize += int64(weight.VSize())
+	}
+	return fees, vsize, nil
+}
+
+func (r *CovenantlessRedeemBranch) signChild(
+	ctx context.Context,
+	w wallet.WalletService,
+	getTx func(txid string) (*wire.MsgTx, error),
+	parentTxid chainhash.Hash,
+	anchorIndex uint32,
+	anchor *wire.TxOut,
+	utxo types.Utxo,
+	leafProof *psbt.TaprootTapLeafScript,
+	changeOut *wire.TxOut,
+) (string, error) {
+	utxoHash, err := chainhash.NewHashFromStr(utxo.Txid)
+	if err != nil {
+		return "", err
+	}
+	sequence, err := utxo.Sequence()
+	if err != nil {
+		return "", err
+	}
+	utxoTx, err := getTx(utxo.Txid)
+	if err != nil {
+		return "", err
+	}
+	if int(utxo.VOut) >= len(utxoTx.TxOut) {
+		return "", fmt.Errorf("utxo %s:%d not found", utxo.Txid, utxo.VOut)
+	}
+
+	// the child of a TRUC tx must be TRUC too
+	ptx, err := psbt.New(
+		[]*wire.OutPoint{
+			wire.NewOutPoint(&parentTxid, anchorIndex),
+			wire.NewOutPoint(utxoHash, utxo.VOut),
+		},
+		[]*wire.TxOut{changeOut},
+		3, 0, []uint32{wire.MaxTxInSequenceNum, sequence},
+	)
+	if err != nil {
+		return "", err
+	}
+	ptx.Inputs[0].WitnessUtxo = anchor
+	ptx.Inputs[1].WitnessUtxo = utxoTx.TxOut[utxo.VOut]
+	ptx.Inputs[1].TaprootLeafScript = []*psbt.TaprootTapLeafScript{leafProof}
+
+	unsignedTx, err := ptx.B64Encode()
+	if err != nil {
+		return "", err
+	}
+	signedTx, err := w.SignTransaction(ctx, r.explorer, unsignedTx)
+	if err != nil {
+		return "", err
+	}
+	ptx, err = psbt.NewFromRawBytes(strings.NewReader(signedTx), true)
+	if err != nil {
+		return "", err
+	}
+
+	// the anchor is spent with an empty witness
+	var emptyWitness bytes.Buffer
+	if err := psbt.WriteTxWitness(&emptyWitness, wire.TxWitness{}); err != nil {
+		return "", err
+	}
+	ptx.Inputs[0].FinalScriptWitness = emptyWitness.Bytes()
+	if err := psbt.Finalize(ptx, 1); err != nil {
+		return "", err
+	}
+
+	tx, err := psbt.Extract(ptx)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
+
+// exitLeafProof returns the leaf to spend an utxo of the wallet with the exit
+// path of the given tapscripts.
+func exitLeafProof(tapscripts []string) (*psbt.TaprootTapLeafScript, error) {
+	vtxoScript, err := tree.ParseVtxoScript(tapscripts)
+	if err != nil {
+		return nil, err
+	}
+	exitClosures := vtxoScript.ExitClosures()
+	if len(exitClosures) <= 0 {
+		return nil, fmt.Errorf("no exit closures found")
+	}
+	exitScript, err := exitClosures[0].Script()
+	if err != nil {
+		return nil, err
+	}
+	_, taprootTree, err := vtxoScript.TapTree()
+	if err != nil {
+		return nil, err
+	}
+
+	exitLeaf := txscript.NewBaseTapLeaf(exitScript)
+	leafProof, err := taprootTree.GetTaprootMerkleProof(exitLeaf.TapHash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get taproot merkle proof: %s", err)
+	}
+	return &psbt.TaprootTapLeafScript{
+		ControlBlock: leafProof.ControlBlock,
+		Script:       leafProof.Script,
+		LeafVersion:  txscript.BaseLeafVersion,
+	}, nil
+}
+
+// estimateChildVSize returns the vsize of the child tx spending the anchor,
+// with an empty witness, and a wallet utxo with the given leaf into a P2TR
+// output.
+func estimateChildVSize(leafProof *psbt.TaprootTapLeafScript) int64 {
+	// witness items count + schnorr signature + script + control block
+	walletWitnessSize := 1 +
+		1 + 64 +
+		wire.VarIntSerializeSize(uint64(len(leafProof.Script))) + len(leafProof.Script) +
+		wire.VarIntSerializeSize(uint64(len(leafProof.ControlBlock))) + len(leafProof.ControlBlock)
+
+	return int64((&input.TxWeightEstimator{}).
+		AddWitnessInput(1).
+		AddWitnessInput(lntypes.WeightUnit(walletWitnessSize)).
+		AddP2TROutput().
+		VSize())
+}
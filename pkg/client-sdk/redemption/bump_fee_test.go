@@ -0,0 +1,251 @@
+package redemption
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lntypes"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBumpFee(t *testing.T) {
+	ctx := context.Background()
+	feeRate := chainfee.SatPerKVByte(2000)
+
+	key, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	pkScript, err := common.P2TRScript(key.PubKey())
+	require.NoError(t, err)
+
+	// the round tx funds the root of the branch without any fee
+	roundTx := wire.NewMsgTx(2)
+	roundTx.AddTxOut(&wire.TxOut{Value: 1000, PkScript: pkScript})
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	tapscripts, err := tree.NewDefaultVtxoScript(key.PubKey(), key.PubKey(), exitDelay).Encode()
+	require.NoError(t, err)
+	walletUtxos, fundingTx := makeWalletUtxos(t, tapscripts, exitDelay, 300, 100_000, 5000)
+
+	txs := map[string]*wire.MsgTx{
+		roundTx.TxHash().String():   roundTx,
+		fundingTx.TxHash().String(): fundingTx,
+	}
+	getTx := func(txid string) (*wire.MsgTx, error) {
+		tx, ok := txs[txid]
+		if !ok {
+			return nil, fmt.Errorf("tx %s not found", txid)
+		}
+		return tx, nil
+	}
+	w := &mockedWallet{key: key}
+
+	t.Run("valid", func(t *testing.T) {
+		parent := makeBranchTx(t, roundTx, pkScript, true)
+		branch := &CovenantlessRedeemBranch{
+			branch:   []*psbt.Packet{parent},
+			explorer: &mockedExplorer{},
+		}
+
+		childHex, err := branch.BumpFee(ctx, w, walletUtxos, getTx, feeRate)
+		require.NoError(t, err)
+
+		var child wire.MsgTx
+		require.NoError(t, child.Deserialize(hex.NewDecoder(strings.NewReader(childHex))))
+		require.Equal(t, int32(3), child.Version)
+		require.Len(t, child.TxIn, 2)
+		require.Len(t, child.TxOut, 1)
+
+		// the child spends the anchor with an empty witness
+		anchorIndex := uint32(len(parent.UnsignedTx.TxOut) - 1)
+		require.Equal(t, parent.UnsignedTx.TxHash(), child.TxIn[0].PreviousOutPoint.Hash)
+		require.Equal(t, anchorIndex, child.TxIn[0].PreviousOutPoint.Index)
+		require.Empty(t, child.TxIn[0].Witness)
+
+		// the smallest wallet utxo covering the fee is spent with the exit path
+		utxo := walletUtxos[2]
+		require.Equal(t, utxo.Txid, child.TxIn[1].PreviousOutPoint.Hash.String())
+		require.Equal(t, utxo.VOut, child.TxIn[1].PreviousOutPoint.Index)
+		sequence, err := utxo.Sequence()
+		require.NoError(t, err)
+		require.Equal(t, sequence, child.TxIn[1].Sequence)
+		require.Len(t, child.TxIn[1].Witness, 3)
+
+		// the change pays the fee of the whole package
+		_, pathVSize, err := pathFees([]*psbt.Packet{parent}, getTx)
+		require.NoError(t, err)
+		leafProof, err := exitLeafProof(tapscripts)
+		require.NoError(t, err)
+		fee := int64(feeRate.FeeForVSize(
+			lntypes.VByte(pathVSize + estimateChildVSize(leafProof)),
+		).ToUnit(btcutil.AmountSatoshi))
+		require.Equal(t, int64(utxo.Amount)-fee, child.TxOut[0].Value)
+		require.Equal(t, w.changeScript(t), child.TxOut[0].PkScript)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Run("no anchor", func(t *testing.T) {
+			branch := &CovenantlessRedeemBranch{
+				branch:   []*psbt.Packet{makeBranchTx(t, roundTx, pkScript, false)},
+				explorer: &mockedExplorer{},
+			}
+
+			_, err := branch.BumpFee(ctx, w, walletUtxos, getTx, feeRate)
+			require.ErrorIs(t, err, ErrNoAnchor)
+		})
+
+		t.Run("no utxo covering the fee", func(t *testing.T) {
+			branch := &CovenantlessRedeemBranch{
+				branch:   []*psbt.Packet{makeBranchTx(t, roundTx, pkScript, true)},
+				explorer: &mockedExplorer{},
+			}
+
+			_, err := branch.BumpFee(ctx, w, walletUtxos[:1], getTx, feeRate)
+			require.EqualError(
+				t, err, "no mature wallet utxo to cover the fee of the redeem path",
+			)
+		})
+
+		t.Run("already onchain", func(t *testing.T) {
+			parent := makeBranchTx(t, roundTx, pkScript, true)
+			branch := &CovenantlessRedeemBranch{
+				branch: []*psbt.Packet{parent},
+				explorer: &mockedExplorer{
+					onchain: map[string]struct{}{parent.UnsignedTx.TxHash().String(): {}},
+				},
+			}
+
+			_, err := branch.BumpFee(ctx, w, walletUtxos, getTx, feeRate)
+			require.EqualError(t, err, "redeem path is already onchain")
+		})
+	})
+}
+
+// makeBranchTx returns a tx of the vtxo tree spending the first output of the
+// given round tx, with or without an anchor output.
+func makeBranchTx(
+	t *testing.T, roundTx *wire.MsgTx, pkScript []byte, withAnchor bool,
+) *psbt.Packet {
+	roundTxid := roundTx.TxHash()
+	outputs := []*wire.TxOut{{Value: roundTx.TxOut[0].Value, PkScript: pkScript}}
+	if withAnchor {
+		outputs = append(outputs, tree.AnchorOutput())
+	}
+	ptx, err := psbt.New(
+		[]*wire.OutPoint{wire.NewOutPoint(&roundTxid, 0)}, outputs,
+		3, 0, []uint32{wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+	return ptx
+}
+
+// makeWalletUtxos returns mature wallet utxos with the given amounts, all
+// outputs of the returned funding tx.
+func makeWalletUtxos(
+	t *testing.T, tapscripts []string, exitDelay common.RelativeLocktime,
+	amounts ...uint64,
+) ([]types.Utxo, *wire.MsgTx) {
+	vtxoScript, err := tree.ParseVtxoScript(tapscripts)
+	require.NoError(t, err)
+	taprootKey, _, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+	pkScript, err := common.P2TRScript(taprootKey)
+	require.NoError(t, err)
+
+	fundingTx := wire.NewMsgTx(2)
+	for _, amount := range amounts {
+		fundingTx.AddTxOut(&wire.TxOut{Value: int64(amount), PkScript: pkScript})
+	}
+
+	utxos := make([]types.Utxo, 0, len(amounts))
+	for i, amount := range amounts {
+		utxos = append(utxos, types.Utxo{
+			Txid:       fundingTx.TxHash().String(),
+			VOut:       uint32(i),
+			Amount:     amount,
+			Delay:      exitDelay,
+			Tapscripts: tapscripts,
+		})
+	}
+	return utxos, fundingTx
+}
+
+type mockedExplorer struct {
+	explorer.Explorer
+	onchain map[string]struct{}
+}
+
+func (e *mockedExplorer) GetTxHex(txid string) (string, error) {
+	if _, ok := e.onchain[txid]; ok {
+		return "", nil
+	}
+	return "", fmt.Errorf("tx %s not found", txid)
+}
+
+type mockedWallet struct {
+	wallet.WalletService
+	key *btcec.PrivateKey
+}
+
+func (w *mockedWallet) NewAddress(
+	_ context.Context, _ bool,
+) (*wallet.TapscriptsAddress, *wallet.TapscriptsAddress, error) {
+	addr, err := btcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(w.key.PubKey()), &chaincfg.MainNetParams,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return nil, &wallet.TapscriptsAddress{Address: addr.EncodeAddress()}, nil
+}
+
+// SignTransaction adds a dummy signature for the leaf of every input spent
+// with a script path.
+func (w *mockedWallet) SignTransaction(
+	_ context.Context, _ explorer.Explorer, tx string,
+) (string, error) {
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+	if err != nil {
+		return "", err
+	}
+	for i, in := range ptx.Inputs {
+		if len(in.TaprootLeafScript) <= 0 {
+			continue
+		}
+		leaf := txscript.NewBaseTapLeaf(in.TaprootLeafScript[0].Script)
+		leafHash := leaf.TapHash()
+		ptx.Inputs[i].TaprootScriptSpendSig = []*psbt.TaprootScriptSpendSig{{
+			XOnlyPubKey: schnorr.SerializePubKey(w.key.PubKey()),
+			LeafHash:    leafHash[:],
+			Signature:   bytes.Repeat([]byte{1}, schnorr.SignatureSize),
+			SigHash:     txscript.SigHashDefault,
+		}}
+	}
+	return ptx.B64Encode()
+}
+
+func (w *mockedWallet) changeScript(t *testing.T) []byte {
+	_, addr, err := w.NewAddress(context.Background(), true)
+	require.NoError(t, err)
+	decoded, err := btcutil.DecodeAddress(addr.Address, nil)
+	require.NoError(t, err)
+	script, err := txscript.PayToAddrScript(decoded)
+	require.NoError(t, err)
+	return script
+}
@@ -0,0 +1,94 @@
+package arksdk
+
+import (
+	"time"
+
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+)
+
+// newRoundSession returns the data required to rejoin the round of the given
+// tx request.
+func newRoundSession(
+	requestID, roundID string,
+	vtxos []client.TapscriptsVtxo,
+	boardingUtxos []types.Utxo,
+	receivers []client.Output,
+) types.RoundSession {
+	sessionVtxos := make([]types.RoundSessionVtxo, 0, len(vtxos))
+	for _, vtxo := range vtxos {
+		sessionVtxos = append(sessionVtxos, types.RoundSessionVtxo{
+			VtxoKey: types.VtxoKey{
+				Txid: vtxo.Txid,
+				VOut: vtxo.VOut,
+			},
+			Amount:      vtxo.Amount,
+			Tapscripts:  vtxo.Tapscripts,
+			Recoverable: vtxo.Swept,
+		})
+	}
+	outputs := make([]types.RoundSessionOutput, 0, len(receivers))
+	for _, receiver := range receivers {
+		outputs = append(outputs, types.RoundSessionOutput{
+			Address: receiver.Address,
+			Amount:  receiver.Amount,
+		})
+	}
+	return types.RoundSession{
+		RequestId:     requestID,
+		RoundId:       roundID,
+		Vtxos:         sessionVtxos,
+		BoardingUtxos: append([]types.Utxo{}, boardingUtxos...),
+		Outputs:       outputs,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// findRoundSession returns the session of the given round, or the latest one
+// if roundID is empty.
+func findRoundSession(
+	sessions []types.RoundSession, roundID string,
+) *types.RoundSession {
+	var found *types.RoundSession
+	for i, session := range sessions {
+		if roundID != "" {
+			if session.RoundId == roundID {
+				return &sessions[i]
+			}
+			continue
+		}
+		if found == nil || session.CreatedAt.After(found.CreatedAt) {
+			found = &sessions[i]
+		}
+	}
+	return found
+}
+
+// roundSessionCoins returns the inputs and outputs of the tx request of the
+// given session.
+func roundSessionCoins(
+	session types.RoundSession,
+) ([]client.TapscriptsVtxo, []types.Utxo, []client.Output) {
+	vtxos := make([]client.TapscriptsVtxo, 0, len(session.Vtxos))
+	for _, vtxo := range session.Vtxos {
+		vtxos = append(vtxos, client.TapscriptsVtxo{
+			Vtxo: client.Vtxo{
+				Outpoint: client.Outpoint{
+					Txid: vtxo.Txid,
+					VOut: vtxo.VOut,
+				},
+				Amount: vtxo.Amount,
+				Swept:  vtxo.Recoverable,
+			},
+			Tapscripts: vtxo.Tapscripts,
+		})
+	}
+	outputs := make([]client.Output, 0, len(session.Outputs))
+	for _, output := range session.Outputs {
+		outputs = append(outputs, client.Output{
+			Address: output.Address,
+			Amount:  output.Amount,
+		})
+	}
+	return vtxos, session.BoardingUtxos, outputs
+}
@@ -0,0 +1,47 @@
+package arksdk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundSession(t *testing.T) {
+	vtxos := []client.TapscriptsVtxo{
+		{
+			Vtxo: client.Vtxo{
+				Outpoint: client.Outpoint{Txid: "vtxo", VOut: 1},
+				Amount:   1000,
+				Swept:    true,
+			},
+			Tapscripts: []string{"tapscript"},
+		},
+	}
+	boardingUtxos := []types.Utxo{{Txid: "boarding", Amount: 2000}}
+	receivers := []client.Output{{Address: "address", Amount: 3000}}
+
+	session := newRoundSession("request", "round", vtxos, boardingUtxos, receivers)
+	gotVtxos, gotBoardingUtxos, gotReceivers := roundSessionCoins(session)
+	require.Equal(t, vtxos, gotVtxos)
+	require.Equal(t, boardingUtxos, gotBoardingUtxos)
+	require.Equal(t, receivers, gotReceivers)
+
+	older := types.RoundSession{
+		RequestId: "older", RoundId: "older round", CreatedAt: time.Now().Add(-time.Hour),
+	}
+	sessions := []types.RoundSession{older, session}
+
+	found := findRoundSession(sessions, "")
+	require.NotNil(t, found)
+	require.Equal(t, "request", found.RequestId)
+
+	found = findRoundSession(sessions, "older round")
+	require.NotNil(t, found)
+	require.Equal(t, "older", found.RequestId)
+
+	require.Nil(t, findRoundSession(sessions, "unknown"))
+	require.Nil(t, findRoundSession(nil, ""))
+}
@@ -74,6 +74,8 @@ func (s *configStore) AddData(ctx context.Context, data types.Config) error {
 		UtxoMaxAmount:              fmt.Sprintf("%d", data.UtxoMaxAmount),
 		VtxoMinAmount:              fmt.Sprintf("%d", data.VtxoMinAmount),
 		VtxoMaxAmount:              fmt.Sprintf("%d", data.VtxoMaxAmount),
+		SettleMinAmount:            fmt.Sprintf("%d", data.SettleMinAmount),
+		SettleMaxAmount:            fmt.Sprintf("%d", data.SettleMaxAmount),
 	}
 
 	if err := s.write(sd); err != nil {
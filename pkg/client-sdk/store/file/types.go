@@ -33,6 +33,8 @@ type storeData struct {
 	UtxoMaxAmount              string `json:"utxo_max_amount"`
 	VtxoMinAmount              string `json:"vtxo_min_amount"`
 	VtxoMaxAmount              string `json:"vtxo_max_amount"`
+	SettleMinAmount            string `json:"settle_min_amount"`
+	SettleMaxAmount            string `json:"settle_max_amount"`
 }
 
 func (d storeData) isEmpty() bool {
@@ -63,6 +65,8 @@ func (d storeData) decode() types.Config {
 	utxoMaxAmount, _ := strconv.Atoi(d.UtxoMaxAmount)
 	vtxoMinAmount, _ := strconv.Atoi(d.VtxoMinAmount)
 	vtxoMaxAmount, _ := strconv.Atoi(d.VtxoMaxAmount)
+	settleMinAmount, _ := strconv.Atoi(d.SettleMinAmount)
+	settleMaxAmount, _ := strconv.Atoi(d.SettleMaxAmount)
 
 	vtxoTreeExpiryType := common.LocktimeTypeBlock
 	if vtxoTreeExpiry >= 512 {
@@ -102,6 +106,8 @@ func (d storeData) decode() types.Config {
 		UtxoMaxAmount:              int64(utxoMaxAmount),
 		VtxoMinAmount:              int64(vtxoMinAmount),
 		VtxoMaxAmount:              int64(vtxoMaxAmount),
+		SettleMinAmount:            int64(settleMinAmount),
+		SettleMaxAmount:            int64(settleMaxAmount),
 	}
 }
 
@@ -129,5 +135,7 @@ func (d storeData) asMap() map[string]string {
 		"utxo_max_amount":              d.UtxoMaxAmount,
 		"vtxo_min_amount":              d.VtxoMinAmount,
 		"vtxo_max_amount":              d.VtxoMaxAmount,
+		"settle_min_amount":            d.SettleMinAmount,
+		"settle_max_amount":            d.SettleMaxAmount,
 	}
 }
@@ -0,0 +1,71 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/dgraph-io/badger/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const (
+	addressStoreDir = "addresses"
+)
+
+type addressStore struct {
+	db *badgerhold.Store
+}
+
+func NewAddressStore(dir string, logger badger.Logger) (types.AddressStore, error) {
+	if dir != "" {
+		dir = filepath.Join(dir, addressStoreDir)
+	}
+	badgerDb, err := createDB(dir, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open address store: %s", err)
+	}
+	return &addressStore{badgerDb}, nil
+}
+
+func (s *addressStore) AddAddresses(
+	_ context.Context, addresses []types.Address,
+) (int, error) {
+	count := 0
+	for _, addr := range addresses {
+		if err := s.db.Insert(addr.Address, &addr); err != nil {
+			if errors.Is(err, badgerhold.ErrKeyExists) {
+				continue
+			}
+			return -1, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (s *addressStore) GetAllAddresses(
+	_ context.Context,
+) ([]types.Address, error) {
+	var addresses []types.Address
+	if err := s.db.Find(&addresses, nil); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+func (s *addressStore) Clean(_ context.Context) error {
+	if err := s.db.Badger().DropAll(); err != nil {
+		return fmt.Errorf("failed to clean the address db: %s", err)
+	}
+	return nil
+}
+
+func (s *addressStore) Close() {
+	if err := s.db.Close(); err != nil {
+		log.Debugf("error on closing db: %s", err)
+	}
+}
@@ -0,0 +1,71 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/dgraph-io/badger/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const (
+	recoveryStoreDir = "recovery"
+)
+
+type recoveryStore struct {
+	db *badgerhold.Store
+}
+
+func NewRecoveryStore(dir string, logger badger.Logger) (types.RecoveryStore, error) {
+	if dir != "" {
+		dir = filepath.Join(dir, recoveryStoreDir)
+	}
+	badgerDb, err := createDB(dir, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open recovery store: %s", err)
+	}
+	return &recoveryStore{badgerDb}, nil
+}
+
+func (s *recoveryStore) AddRecoveredVtxos(
+	_ context.Context, vtxos []types.RecoveredVtxo,
+) (int, error) {
+	count := 0
+	for _, vtxo := range vtxos {
+		if err := s.db.Insert(vtxo.String(), &vtxo); err != nil {
+			if errors.Is(err, badgerhold.ErrKeyExists) {
+				continue
+			}
+			return -1, err
+		}
+		count++
+	}
+	return count, nil
+}
+
+func (s *recoveryStore) GetRecoveredVtxos(
+	_ context.Context,
+) ([]types.RecoveredVtxo, error) {
+	var vtxos []types.RecoveredVtxo
+	if err := s.db.Find(&vtxos, nil); err != nil {
+		return nil, err
+	}
+	return vtxos, nil
+}
+
+func (s *recoveryStore) Clean(_ context.Context) error {
+	if err := s.db.Badger().DropAll(); err != nil {
+		return fmt.Errorf("failed to clean the recovery db: %s", err)
+	}
+	return nil
+}
+
+func (s *recoveryStore) Close() {
+	if err := s.db.Close(); err != nil {
+		log.Debugf("error on closing db: %s", err)
+	}
+}
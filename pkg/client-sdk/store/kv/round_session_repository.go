@@ -0,0 +1,74 @@
+package kvstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/dgraph-io/badger/v4"
+	log "github.com/sirupsen/logrus"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const (
+	roundSessionStoreDir = "round_sessions"
+)
+
+type roundSessionStore struct {
+	db *badgerhold.Store
+}
+
+func NewRoundSessionStore(
+	dir string, logger badger.Logger,
+) (types.RoundSessionStore, error) {
+	if dir != "" {
+		dir = filepath.Join(dir, roundSessionStoreDir)
+	}
+	badgerDb, err := createDB(dir, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open round session store: %s", err)
+	}
+	return &roundSessionStore{badgerDb}, nil
+}
+
+func (s *roundSessionStore) AddRoundSession(
+	_ context.Context, session types.RoundSession,
+) error {
+	return s.db.Upsert(session.RequestId, &session)
+}
+
+func (s *roundSessionStore) GetRoundSessions(
+	_ context.Context,
+) ([]types.RoundSession, error) {
+	var sessions []types.RoundSession
+	if err := s.db.Find(&sessions, nil); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+func (s *roundSessionStore) DeleteRoundSession(
+	_ context.Context, requestId string,
+) error {
+	if err := s.db.Delete(
+		requestId, types.RoundSession{},
+	); err != nil && !errors.Is(err, badgerhold.ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (s *roundSessionStore) Clean(_ context.Context) error {
+	if err := s.db.Badger().DropAll(); err != nil {
+		return fmt.Errorf("failed to clean the round session db: %s", err)
+	}
+	return nil
+}
+
+func (s *roundSessionStore) Close() {
+	if err := s.db.Close(); err != nil {
+		log.Debugf("error on closing db: %s", err)
+	}
+}
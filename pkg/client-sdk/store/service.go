@@ -26,9 +26,12 @@ const (
 )
 
 type service struct {
-	configStore types.ConfigStore
-	vtxoStore   types.VtxoStore
-	txStore     types.TransactionStore
+	configStore   types.ConfigStore
+	vtxoStore     types.VtxoStore
+	txStore       types.TransactionStore
+	recoveryStore types.RecoveryStore
+	addressStore  types.AddressStore
+	sessionStore  types.RoundSessionStore
 }
 
 type Config struct {
@@ -40,10 +43,13 @@ type Config struct {
 
 func NewStore(storeConfig Config) (types.Store, error) {
 	var (
-		configStore types.ConfigStore
-		vtxoStore   types.VtxoStore
-		txStore     types.TransactionStore
-		err         error
+		configStore   types.ConfigStore
+		vtxoStore     types.VtxoStore
+		txStore       types.TransactionStore
+		recoveryStore types.RecoveryStore
+		addressStore  types.AddressStore
+		sessionStore  types.RoundSessionStore
+		err           error
 
 		dir = storeConfig.BaseDir
 	)
@@ -68,6 +74,18 @@ func NewStore(storeConfig Config) (types.Store, error) {
 				return nil, err
 			}
 			txStore, err = kvstore.NewTransactionStore(dir, nil)
+			if err != nil {
+				return nil, err
+			}
+			recoveryStore, err = kvstore.NewRecoveryStore(dir, nil)
+			if err != nil {
+				return nil, err
+			}
+			addressStore, err = kvstore.NewAddressStore(dir, nil)
+			if err != nil {
+				return nil, err
+			}
+			sessionStore, err = kvstore.NewRoundSessionStore(dir, nil)
 		case types.SQLStore:
 			dbFile := filepath.Join(dir, sqliteDbFile)
 			db, err := sqlstore.OpenDb(dbFile)
@@ -94,6 +112,9 @@ func NewStore(storeConfig Config) (types.Store, error) {
 			}
 			vtxoStore = sqlstore.NewVtxoStore(db)
 			txStore = sqlstore.NewTransactionStore(db)
+			recoveryStore = sqlstore.NewRecoveryStore(db)
+			addressStore = sqlstore.NewAddressStore(db)
+			sessionStore = sqlstore.NewRoundSessionStore(db)
 		default:
 			err = fmt.Errorf("unknown appdata store type")
 		}
@@ -102,7 +123,9 @@ func NewStore(storeConfig Config) (types.Store, error) {
 		}
 	}
 
-	return &service{configStore, vtxoStore, txStore}, nil
+	return &service{
+		configStore, vtxoStore, txStore, recoveryStore, addressStore, sessionStore,
+	}, nil
 }
 
 func (s *service) ConfigStore() types.ConfigStore {
@@ -117,6 +140,18 @@ func (s *service) TransactionStore() types.TransactionStore {
 	return s.txStore
 }
 
+func (s *service) RecoveryStore() types.RecoveryStore {
+	return s.recoveryStore
+}
+
+func (s *service) AddressStore() types.AddressStore {
+	return s.addressStore
+}
+
+func (s *service) RoundSessionStore() types.RoundSessionStore {
+	return s.sessionStore
+}
+
 func (s *service) Clean(ctx context.Context) {
 	//nolint:all
 	s.configStore.CleanData(ctx)
@@ -128,10 +163,25 @@ func (s *service) Clean(ctx context.Context) {
 		//nolint:all
 		s.vtxoStore.Clean(ctx)
 	}
+	if s.recoveryStore != nil {
+		//nolint:all
+		s.recoveryStore.Clean(ctx)
+	}
+	if s.addressStore != nil {
+		//nolint:all
+		s.addressStore.Clean(ctx)
+	}
+	if s.sessionStore != nil {
+		//nolint:all
+		s.sessionStore.Clean(ctx)
+	}
 }
 
 func (s *service) Close() {
 	s.configStore.Close()
 	s.vtxoStore.Close()
 	s.txStore.Close()
+	s.recoveryStore.Close()
+	s.addressStore.Close()
+	s.sessionStore.Close()
 }
@@ -75,6 +75,9 @@ func TestService(t *testing.T) {
 				require.NoError(t, err)
 				testVtxoStore(t, svc.VtxoStore(), tt.config.AppDataStoreType)
 				testTxStore(t, svc.TransactionStore(), tt.config.AppDataStoreType)
+				testRecoveryStore(t, svc.RecoveryStore())
+				testAddressStore(t, svc.AddressStore())
+				testRoundSessionStore(t, svc.RoundSessionStore())
 				svc.Close()
 			})
 		}
@@ -381,3 +384,171 @@ func testTxStore(t *testing.T, storeSvc types.TransactionStore, storeType string
 		require.True(t, txs[0].Settled)
 	})
 }
+
+func testRecoveryStore(t *testing.T, storeSvc types.RecoveryStore) {
+	ctx := context.Background()
+	now := time.Unix(time.Now().Unix(), 0)
+	recoveredVtxos := []types.RecoveredVtxo{
+		{
+			VtxoKey:   testVtxoKeys[0],
+			Amount:    1000,
+			RoundTxid: "cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc",
+			CreatedAt: now,
+		},
+		{
+			VtxoKey:   testVtxoKeys[1],
+			Amount:    2000,
+			RoundTxid: "cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc",
+			CreatedAt: now,
+		},
+	}
+
+	vtxos, err := storeSvc.GetRecoveredVtxos(ctx)
+	require.NoError(t, err)
+	require.Empty(t, vtxos)
+
+	count, err := storeSvc.AddRecoveredVtxos(ctx, recoveredVtxos)
+	require.NoError(t, err)
+	require.Equal(t, len(recoveredVtxos), count)
+
+	count, err = storeSvc.AddRecoveredVtxos(ctx, recoveredVtxos)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	vtxos, err = storeSvc.GetRecoveredVtxos(ctx)
+	require.NoError(t, err)
+	require.Len(t, vtxos, len(recoveredVtxos))
+	for _, vtxo := range vtxos {
+		require.Contains(t, testVtxoKeys, vtxo.VtxoKey)
+		require.Equal(t, recoveredVtxos[0].RoundTxid, vtxo.RoundTxid)
+		require.Equal(t, now.Unix(), vtxo.CreatedAt.Unix())
+	}
+
+	err = storeSvc.Clean(ctx)
+	require.NoError(t, err)
+
+	vtxos, err = storeSvc.GetRecoveredVtxos(ctx)
+	require.NoError(t, err)
+	require.Empty(t, vtxos)
+}
+
+func testAddressStore(t *testing.T, storeSvc types.AddressStore) {
+	ctx := context.Background()
+	now := time.Unix(time.Now().Unix(), 0)
+	addresses := []types.Address{
+		{
+			Address:    "tark1qqellv77udfmr20tun8dvju5vgudpf9vxe8jwhthrkn26fz96pawqfdy8nk05rsmrf8h94j26905e7n6sng8y059z8ykn2j5xcuw4xt846qj6x",
+			Type:       types.OffchainAddress,
+			Tapscripts: []string{"aa", "bb"},
+			CreatedAt:  now,
+		},
+		{
+			Address:    "bcrt1pjzc2rgaa3mxdu7wxnzlhs6elspjn0fjnnxuewzeskqzxgqu3qa4q7gkfvz",
+			Type:       types.BoardingAddress,
+			Tapscripts: []string{"cc", "dd"},
+			CreatedAt:  now,
+		},
+	}
+
+	stored, err := storeSvc.GetAllAddresses(ctx)
+	require.NoError(t, err)
+	require.Empty(t, stored)
+
+	count, err := storeSvc.AddAddresses(ctx, addresses)
+	require.NoError(t, err)
+	require.Equal(t, len(addresses), count)
+
+	// Check adding the same addresses is a no-op.
+	count, err = storeSvc.AddAddresses(ctx, addresses)
+	require.NoError(t, err)
+	require.Zero(t, count)
+
+	stored, err = storeSvc.GetAllAddresses(ctx)
+	require.NoError(t, err)
+	require.ElementsMatch(t, addresses, stored)
+
+	err = storeSvc.Clean(ctx)
+	require.NoError(t, err)
+
+	stored, err = storeSvc.GetAllAddresses(ctx)
+	require.NoError(t, err)
+	require.Empty(t, stored)
+}
+
+func testRoundSessionStore(t *testing.T, storeSvc types.RoundSessionStore) {
+	ctx := context.Background()
+	now := time.Unix(time.Now().Unix(), 0)
+	session := types.RoundSession{
+		RequestId: "request",
+		RoundId:   "round",
+		Vtxos: []types.RoundSessionVtxo{
+			{
+				VtxoKey:    testVtxoKeys[0],
+				Amount:     1000,
+				Tapscripts: []string{"aa", "bb"},
+			},
+			{
+				VtxoKey:     testVtxoKeys[1],
+				Amount:      2000,
+				Tapscripts:  []string{"aa", "bb"},
+				Recoverable: true,
+			},
+		},
+		BoardingUtxos: []types.Utxo{
+			{
+				Txid:        "dddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddddd",
+				Amount:      3000,
+				Delay:       common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144},
+				SpendableAt: now,
+				CreatedAt:   now,
+				Tapscripts:  []string{"cc", "dd"},
+			},
+		},
+		Outputs: []types.RoundSessionOutput{
+			{
+				Address: "tark1qqellv77udfmr20tun8dvju5vgudpf9vxe8jwhthrkn26fz96pawqfdy8nk05rsmrf8h94j26905e7n6sng8y059z8ykn2j5xcuw4xt846qj6x",
+				Amount:  6000,
+			},
+		},
+		CreatedAt: now,
+	}
+
+	sessions, err := storeSvc.GetRoundSessions(ctx)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	err = storeSvc.AddRoundSession(ctx, session)
+	require.NoError(t, err)
+
+	// Check adding a session with the same request id replaces the stored one.
+	session.RoundId = "another round"
+	err = storeSvc.AddRoundSession(ctx, session)
+	require.NoError(t, err)
+
+	sessions, err = storeSvc.GetRoundSessions(ctx)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, session.RoundId, sessions[0].RoundId)
+	require.Equal(t, session.Vtxos, sessions[0].Vtxos)
+	require.Equal(t, session.Outputs, sessions[0].Outputs)
+	require.Len(t, sessions[0].BoardingUtxos, 1)
+	require.Equal(t, session.BoardingUtxos[0].Delay, sessions[0].BoardingUtxos[0].Delay)
+	require.Equal(t, now.Unix(), sessions[0].CreatedAt.Unix())
+
+	err = storeSvc.DeleteRoundSession(ctx, session.RequestId)
+	require.NoError(t, err)
+
+	sessions, err = storeSvc.GetRoundSessions(ctx)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+
+	err = storeSvc.AddRoundSession(ctx, session)
+	require.NoError(t, err)
+
+	err = storeSvc.Clean(ctx)
+	require.NoError(t, err)
+
+	sessions, err = storeSvc.GetRoundSessions(ctx)
+	require.NoError(t, err)
+	require.Empty(t, sessions)
+}
@@ -0,0 +1,93 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/pkg/client-sdk/store/sql/sqlc/queries"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+)
+
+type addressRepository struct {
+	db      *sql.DB
+	querier *queries.Queries
+}
+
+func NewAddressStore(db *sql.DB) types.AddressStore {
+	return &addressRepository{
+		db:      db,
+		querier: queries.New(db),
+	}
+}
+
+func (r *addressRepository) AddAddresses(
+	ctx context.Context, addresses []types.Address,
+) (int, error) {
+	count := 0
+	txBody := func(querierWithTx *queries.Queries) error {
+		for _, addr := range addresses {
+			var createdAt int64
+			if !addr.CreatedAt.IsZero() {
+				createdAt = addr.CreatedAt.Unix()
+			}
+			if err := querierWithTx.InsertAddress(
+				ctx, queries.InsertAddressParams{
+					Address:    addr.Address,
+					Type:       string(addr.Type),
+					Tapscripts: strings.Join(addr.Tapscripts, ","),
+					CreatedAt:  createdAt,
+				},
+			); err != nil {
+				if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+					continue
+				}
+				return err
+			}
+			count++
+		}
+		return nil
+	}
+	if err := execTx(ctx, r.db, txBody); err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+func (r *addressRepository) GetAllAddresses(
+	ctx context.Context,
+) ([]types.Address, error) {
+	rows, err := r.querier.SelectAllAddresses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses := make([]types.Address, 0, len(rows))
+	for _, row := range rows {
+		var createdAt time.Time
+		if row.CreatedAt != 0 {
+			createdAt = time.Unix(row.CreatedAt, 0)
+		}
+		var tapscripts []string
+		if len(row.Tapscripts) > 0 {
+			tapscripts = strings.Split(row.Tapscripts, ",")
+		}
+		addresses = append(addresses, types.Address{
+			Address:    row.Address,
+			Type:       types.AddressType(row.Type),
+			Tapscripts: tapscripts,
+			CreatedAt:  createdAt,
+		})
+	}
+	return addresses, nil
+}
+
+func (r *addressRepository) Clean(ctx context.Context) error {
+	return r.querier.CleanAddresses(ctx)
+}
+
+func (r *addressRepository) Close() {
+	// nolint:all
+	r.db.Close()
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS recovered_vtxo;
\ No newline at end of file
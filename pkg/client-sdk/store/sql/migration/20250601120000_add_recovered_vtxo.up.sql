@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS recovered_vtxo (
+    txid TEXT NOT NULL,
+    vout INTEGER NOT NULL,
+    amount INTEGER NOT NULL,
+    round_txid TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (txid, vout)
+);
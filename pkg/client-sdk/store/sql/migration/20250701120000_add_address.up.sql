@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS address (
+    address TEXT NOT NULL PRIMARY KEY,
+    type TEXT NOT NULL,
+    tapscripts TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
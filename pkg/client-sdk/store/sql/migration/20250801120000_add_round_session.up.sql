@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS round_session (
+    request_id TEXT NOT NULL PRIMARY KEY,
+    round_id TEXT NOT NULL,
+    vtxos TEXT NOT NULL,
+    boarding_utxos TEXT NOT NULL,
+    outputs TEXT NOT NULL,
+    created_at INTEGER NOT NULL
+);
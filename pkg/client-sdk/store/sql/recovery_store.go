@@ -0,0 +1,93 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/pkg/client-sdk/store/sql/sqlc/queries"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+)
+
+type recoveryRepository struct {
+	db      *sql.DB
+	querier *queries.Queries
+}
+
+func NewRecoveryStore(db *sql.DB) types.RecoveryStore {
+	return &recoveryRepository{
+		db:      db,
+		querier: queries.New(db),
+	}
+}
+
+func (r *recoveryRepository) AddRecoveredVtxos(
+	ctx context.Context, vtxos []types.RecoveredVtxo,
+) (int, error) {
+	count := 0
+	txBody := func(querierWithTx *queries.Queries) error {
+		for _, vtxo := range vtxos {
+			var createdAt int64
+			if !vtxo.CreatedAt.IsZero() {
+				createdAt = vtxo.CreatedAt.Unix()
+			}
+			if err := querierWithTx.InsertRecoveredVtxo(
+				ctx, queries.InsertRecoveredVtxoParams{
+					Txid:      vtxo.Txid,
+					Vout:      int64(vtxo.VOut),
+					Amount:    int64(vtxo.Amount),
+					RoundTxid: vtxo.RoundTxid,
+					CreatedAt: createdAt,
+				},
+			); err != nil {
+				if strings.Contains(err.Error(), "UNIQUE constraint failed") {
+					continue
+				}
+				return err
+			}
+			count++
+		}
+		return nil
+	}
+	if err := execTx(ctx, r.db, txBody); err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+func (r *recoveryRepository) GetRecoveredVtxos(
+	ctx context.Context,
+) ([]types.RecoveredVtxo, error) {
+	rows, err := r.querier.SelectAllRecoveredVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	vtxos := make([]types.RecoveredVtxo, 0, len(rows))
+	for _, row := range rows {
+		var createdAt time.Time
+		if row.CreatedAt != 0 {
+			createdAt = time.Unix(row.CreatedAt, 0)
+		}
+		vtxos = append(vtxos, types.RecoveredVtxo{
+			VtxoKey: types.VtxoKey{
+				Txid: row.Txid,
+				VOut: uint32(row.Vout),
+			},
+			Amount:    uint64(row.Amount),
+			RoundTxid: row.RoundTxid,
+			CreatedAt: createdAt,
+		})
+	}
+	return vtxos, nil
+}
+
+func (r *recoveryRepository) Clean(ctx context.Context) error {
+	return r.querier.CleanRecoveredVtxos(ctx)
+}
+
+func (r *recoveryRepository) Close() {
+	// nolint:all
+	r.db.Close()
+}
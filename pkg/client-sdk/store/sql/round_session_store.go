@@ -0,0 +1,107 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ark-network/ark/pkg/client-sdk/store/sql/sqlc/queries"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+)
+
+type roundSessionRepository struct {
+	db      *sql.DB
+	querier *queries.Queries
+}
+
+func NewRoundSessionStore(db *sql.DB) types.RoundSessionStore {
+	return &roundSessionRepository{
+		db:      db,
+		querier: queries.New(db),
+	}
+}
+
+func (r *roundSessionRepository) AddRoundSession(
+	ctx context.Context, session types.RoundSession,
+) error {
+	vtxos, err := json.Marshal(session.Vtxos)
+	if err != nil {
+		return err
+	}
+	boardingUtxos, err := json.Marshal(session.BoardingUtxos)
+	if err != nil {
+		return err
+	}
+	outputs, err := json.Marshal(session.Outputs)
+	if err != nil {
+		return err
+	}
+
+	var createdAt int64
+	if !session.CreatedAt.IsZero() {
+		createdAt = session.CreatedAt.Unix()
+	}
+	return r.querier.UpsertRoundSession(ctx, queries.UpsertRoundSessionParams{
+		RequestID:     session.RequestId,
+		RoundID:       session.RoundId,
+		Vtxos:         string(vtxos),
+		BoardingUtxos: string(boardingUtxos),
+		Outputs:       string(outputs),
+		CreatedAt:     createdAt,
+	})
+}
+
+func (r *roundSessionRepository) GetRoundSessions(
+	ctx context.Context,
+) ([]types.RoundSession, error) {
+	rows, err := r.querier.SelectAllRoundSessions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]types.RoundSession, 0, len(rows))
+	for _, row := range rows {
+		var vtxos []types.RoundSessionVtxo
+		if err := json.Unmarshal([]byte(row.Vtxos), &vtxos); err != nil {
+			return nil, err
+		}
+		var boardingUtxos []types.Utxo
+		if err := json.Unmarshal([]byte(row.BoardingUtxos), &boardingUtxos); err != nil {
+			return nil, err
+		}
+		var outputs []types.RoundSessionOutput
+		if err := json.Unmarshal([]byte(row.Outputs), &outputs); err != nil {
+			return nil, err
+		}
+
+		var createdAt time.Time
+		if row.CreatedAt != 0 {
+			createdAt = time.Unix(row.CreatedAt, 0)
+		}
+		sessions = append(sessions, types.RoundSession{
+			RequestId:     row.RequestID,
+			RoundId:       row.RoundID,
+			Vtxos:         vtxos,
+			BoardingUtxos: boardingUtxos,
+			Outputs:       outputs,
+			CreatedAt:     createdAt,
+		})
+	}
+	return sessions, nil
+}
+
+func (r *roundSessionRepository) DeleteRoundSession(
+	ctx context.Context, requestId string,
+) error {
+	return r.querier.DeleteRoundSession(ctx, requestId)
+}
+
+func (r *roundSessionRepository) Clean(ctx context.Context) error {
+	return r.querier.CleanRoundSessions(ctx)
+}
+
+func (r *roundSessionRepository) Close() {
+	// nolint:all
+	r.db.Close()
+}
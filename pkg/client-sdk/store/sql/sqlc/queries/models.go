@@ -8,6 +8,30 @@ import (
 	"database/sql"
 )
 
+type Address struct {
+	Address    string
+	Type       string
+	Tapscripts string
+	CreatedAt  int64
+}
+
+type RecoveredVtxo struct {
+	Txid      string
+	Vout      int64
+	Amount    int64
+	RoundTxid string
+	CreatedAt int64
+}
+
+type RoundSession struct {
+	RequestID     string
+	RoundID       string
+	Vtxos         string
+	BoardingUtxos string
+	Outputs       string
+	CreatedAt     int64
+}
+
 type Tx struct {
 	Txid      string
 	TxidType  string
@@ -11,6 +11,33 @@ import (
 	"strings"
 )
 
+const cleanAddresses = `-- name: CleanAddresses :exec
+DELETE FROM address
+`
+
+func (q *Queries) CleanAddresses(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, cleanAddresses)
+	return err
+}
+
+const cleanRecoveredVtxos = `-- name: CleanRecoveredVtxos :exec
+DELETE FROM recovered_vtxo
+`
+
+func (q *Queries) CleanRecoveredVtxos(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, cleanRecoveredVtxos)
+	return err
+}
+
+const cleanRoundSessions = `-- name: CleanRoundSessions :exec
+DELETE FROM round_session
+`
+
+func (q *Queries) CleanRoundSessions(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, cleanRoundSessions)
+	return err
+}
+
 const cleanTxs = `-- name: CleanTxs :exec
 DELETE FROM tx
 `
@@ -29,6 +56,63 @@ func (q *Queries) CleanVtxos(ctx context.Context) error {
 	return err
 }
 
+const deleteRoundSession = `-- name: DeleteRoundSession :exec
+DELETE FROM round_session WHERE request_id = ?
+`
+
+func (q *Queries) DeleteRoundSession(ctx context.Context, requestID string) error {
+	_, err := q.db.ExecContext(ctx, deleteRoundSession, requestID)
+	return err
+}
+
+const insertAddress = `-- name: InsertAddress :exec
+INSERT INTO address (
+    address, type, tapscripts, created_at
+) VALUES (?, ?, ?, ?)
+`
+
+type InsertAddressParams struct {
+	Address    string
+	Type       string
+	Tapscripts string
+	CreatedAt  int64
+}
+
+func (q *Queries) InsertAddress(ctx context.Context, arg InsertAddressParams) error {
+	_, err := q.db.ExecContext(ctx, insertAddress,
+		arg.Address,
+		arg.Type,
+		arg.Tapscripts,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const insertRecoveredVtxo = `-- name: InsertRecoveredVtxo :exec
+INSERT INTO recovered_vtxo (
+    txid, vout, amount, round_txid, created_at
+) VALUES (?, ?, ?, ?, ?)
+`
+
+type InsertRecoveredVtxoParams struct {
+	Txid      string
+	Vout      int64
+	Amount    int64
+	RoundTxid string
+	CreatedAt int64
+}
+
+func (q *Queries) InsertRecoveredVtxo(ctx context.Context, arg InsertRecoveredVtxoParams) error {
+	_, err := q.db.ExecContext(ctx, insertRecoveredVtxo,
+		arg.Txid,
+		arg.Vout,
+		arg.Amount,
+		arg.RoundTxid,
+		arg.CreatedAt,
+	)
+	return err
+}
+
 const insertTx = `-- name: InsertTx :exec
 INSERT INTO tx (
     txid, txid_type, amount, type, settled, created_at, hex
@@ -132,6 +216,105 @@ func (q *Queries) ReplaceTx(ctx context.Context, arg ReplaceTxParams) error {
 	return err
 }
 
+const selectAllAddresses = `-- name: SelectAllAddresses :many
+SELECT address, type, tapscripts, created_at FROM address
+`
+
+func (q *Queries) SelectAllAddresses(ctx context.Context) ([]Address, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllAddresses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Address
+	for rows.Next() {
+		var i Address
+		if err := rows.Scan(
+			&i.Address,
+			&i.Type,
+			&i.Tapscripts,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllRecoveredVtxos = `-- name: SelectAllRecoveredVtxos :many
+SELECT txid, vout, amount, round_txid, created_at FROM recovered_vtxo
+`
+
+func (q *Queries) SelectAllRecoveredVtxos(ctx context.Context) ([]RecoveredVtxo, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllRecoveredVtxos)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecoveredVtxo
+	for rows.Next() {
+		var i RecoveredVtxo
+		if err := rows.Scan(
+			&i.Txid,
+			&i.Vout,
+			&i.Amount,
+			&i.RoundTxid,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectAllRoundSessions = `-- name: SelectAllRoundSessions :many
+SELECT request_id, round_id, vtxos, boarding_utxos, outputs, created_at FROM round_session
+`
+
+func (q *Queries) SelectAllRoundSessions(ctx context.Context) ([]RoundSession, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllRoundSessions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RoundSession
+	for rows.Next() {
+		var i RoundSession
+		if err := rows.Scan(
+			&i.RequestID,
+			&i.RoundID,
+			&i.Vtxos,
+			&i.BoardingUtxos,
+			&i.Outputs,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const selectAllTxs = `-- name: SelectAllTxs :many
 SELECT txid, txid_type, amount, type, settled, created_at, hex FROM tx
 `
@@ -319,3 +502,35 @@ func (q *Queries) UpdateVtxo(ctx context.Context, arg UpdateVtxoParams) error {
 	_, err := q.db.ExecContext(ctx, updateVtxo, arg.SpentBy, arg.Txid, arg.Vout)
 	return err
 }
+
+const upsertRoundSession = `-- name: UpsertRoundSession :exec
+INSERT INTO round_session (
+    request_id, round_id, vtxos, boarding_utxos, outputs, created_at
+) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(request_id) DO UPDATE SET
+    round_id = excluded.round_id,
+    vtxos = excluded.vtxos,
+    boarding_utxos = excluded.boarding_utxos,
+    outputs = excluded.outputs
+`
+
+type UpsertRoundSessionParams struct {
+	RequestID     string
+	RoundID       string
+	Vtxos         string
+	BoardingUtxos string
+	Outputs       string
+	CreatedAt     int64
+}
+
+func (q *Queries) UpsertRoundSession(ctx context.Context, arg UpsertRoundSessionParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRoundSession,
+		arg.RequestID,
+		arg.RoundID,
+		arg.Vtxos,
+		arg.BoardingUtxos,
+		arg.Outputs,
+		arg.CreatedAt,
+	)
+	return err
+}
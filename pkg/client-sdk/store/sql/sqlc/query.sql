@@ -21,6 +21,47 @@ WHERE txid = :txid AND vout = :vout;
 -- name: CleanVtxos :exec
 DELETE FROM vtxo;
 
+-- name: InsertRecoveredVtxo :exec
+INSERT INTO recovered_vtxo (
+    txid, vout, amount, round_txid, created_at
+) VALUES (?, ?, ?, ?, ?);
+
+-- name: SelectAllRecoveredVtxos :many
+SELECT * FROM recovered_vtxo;
+
+-- name: CleanRecoveredVtxos :exec
+DELETE FROM recovered_vtxo;
+
+-- name: InsertAddress :exec
+INSERT INTO address (
+    address, type, tapscripts, created_at
+) VALUES (?, ?, ?, ?);
+
+-- name: SelectAllAddresses :many
+SELECT * FROM address;
+
+-- name: CleanAddresses :exec
+DELETE FROM address;
+
+-- name: UpsertRoundSession :exec
+INSERT INTO round_session (
+    request_id, round_id, vtxos, boarding_utxos, outputs, created_at
+) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT(request_id) DO UPDATE SET
+    round_id = excluded.round_id,
+    vtxos = excluded.vtxos,
+    boarding_utxos = excluded.boarding_utxos,
+    outputs = excluded.outputs;
+
+-- name: SelectAllRoundSessions :many
+SELECT * FROM round_session;
+
+-- name: DeleteRoundSession :exec
+DELETE FROM round_session WHERE request_id = ?;
+
+-- name: CleanRoundSessions :exec
+DELETE FROM round_session;
+
 -- name: InsertTx :exec
 INSERT INTO tx (
     txid, txid_type, amount, type, settled, created_at, hex
@@ -2,17 +2,21 @@ package arksdk
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/pkg/client-sdk/client"
 	grpcclient "github.com/ark-network/ark/pkg/client-sdk/client/grpc"
 	restclient "github.com/ark-network/ark/pkg/client-sdk/client/rest"
 	"github.com/ark-network/ark/pkg/client-sdk/internal/utils"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
 	"github.com/ark-network/ark/pkg/client-sdk/wallet"
 )
 
 var (
 	supportedWallets = utils.SupportedType[struct{}]{
 		SingleKeyWallet: struct{}{},
+		WatchOnlyWallet: struct{}{},
 	}
 	supportedClients = utils.SupportedType[utils.ClientFactory]{
 		GrpcClient: grpcclient.NewClient,
@@ -21,13 +25,19 @@ var (
 )
 
 type InitArgs struct {
-	ClientType          string
-	WalletType          string
-	ServerUrl           string
-	Seed                string
-	Password            string
+	ClientType string
+	WalletType string
+	ServerUrl  string
+	Seed       string
+	Password   string
+	// PubKey is the public key, hex encoded or as xpub, watched by a
+	// WatchOnlyWallet. Seed and Password are not used by such wallet.
+	PubKey              string
 	ExplorerURL         string
 	WithTransactionFeed bool
+	// CoinSelector selects the vtxos to spend, OldestFirst if not set. It's not
+	// persisted, use SetCoinSelector to set it again when loading the client.
+	CoinSelector CoinSelector
 }
 
 func (a InitArgs) validate() error {
@@ -54,6 +64,12 @@ func (a InitArgs) validate() error {
 	if len(a.ServerUrl) <= 0 {
 		return fmt.Errorf("missing server url")
 	}
+	if a.WalletType == WatchOnlyWallet {
+		if len(a.PubKey) <= 0 {
+			return fmt.Errorf("missing public key")
+		}
+		return nil
+	}
 	if len(a.Password) <= 0 {
 		return fmt.Errorf("missing password")
 	}
@@ -68,6 +84,8 @@ type InitWithWalletArgs struct {
 	Password            string
 	ExplorerURL         string
 	WithTransactionFeed bool
+	// CoinSelector selects the vtxos to spend, OldestFirst if not set.
+	CoinSelector CoinSelector
 }
 
 func (a InitWithWalletArgs) validate() error {
@@ -117,6 +135,118 @@ type VtxoDetails struct {
 	Amount     uint64 `json:"amount"`
 }
 
+// AddressInfo is an address generated by the wallet with the balance of the
+// vtxos (offchain) or utxos (boarding and redemption) locked to it.
+type AddressInfo struct {
+	Address string            `json:"address"`
+	Type    types.AddressType `json:"type"`
+	Balance uint64            `json:"balance"`
+}
+
+// WalletDescriptors are the output descriptors of the addresses of a wallet,
+// to track them with other software or with a watch-only wallet of another
+// instance. The keys are x-only hex encoded.
+type WalletDescriptors struct {
+	Network      string `json:"network"`
+	ServerPubKey string `json:"server_pubkey"`
+	Boarding     string `json:"boarding_descriptor"`
+	Offchain     string `json:"offchain_descriptor"`
+}
+
+// SettlePreview is what a Settle would register for the next round: the
+// inputs selected, the outputs and the total amount going into the round.
+// ForfeitFees is the estimated sum of the fees of the forfeit txs of the
+// vtxos, at the server min relay fee rate unless a custom one is given.
+type SettlePreview struct {
+	Vtxos         []client.TapscriptsVtxo `json:"vtxos"`
+	BoardingUtxos []types.Utxo            `json:"boarding_utxos"`
+	Outputs       []client.Output         `json:"outputs"`
+	Amount        uint64                  `json:"amount"`
+	ForfeitFees   uint64                  `json:"forfeit_fees"`
+}
+
+// SettleStage is a stage of a settlement reported by SettleWithProgress.
+type SettleStage int
+
+const (
+	// RequestRegistered is reported once the tx request is registered and the
+	// client is listening to the round events.
+	RequestRegistered SettleStage = iota
+	// NoncesSubmitted is reported once the nonces of the vtxo tree are sent.
+	NoncesSubmitted
+	// TreeSigned is reported once the partial signatures of the vtxo tree are
+	// sent.
+	TreeSigned
+	// ForfeitsSubmitted is reported once the signed forfeit txs, and the round
+	// tx if spending boarding utxos, are sent.
+	ForfeitsSubmitted
+	// RoundFinalized is reported once the round is finalized.
+	RoundFinalized
+)
+
+func (s SettleStage) String() string {
+	switch s {
+	case RequestRegistered:
+		return "request_registered"
+	case NoncesSubmitted:
+		return "nonces_submitted"
+	case TreeSigned:
+		return "tree_signed"
+	case ForfeitsSubmitted:
+		return "forfeits_submitted"
+	case RoundFinalized:
+		return "round_finalized"
+	default:
+		return "unknown"
+	}
+}
+
+// ExitCost is the estimated network fees to complete the unilateral exit of
+// all spendable vtxos in the best, typical and worst case.
+type ExitCost struct {
+	NumOfVtxos int    `json:"num_of_vtxos"`
+	Amount     uint64 `json:"amount"`
+	BestFee    uint64 `json:"best_fee"`
+	TypicalFee uint64 `json:"typical_fee"`
+	WorstFee   uint64 `json:"worst_fee"`
+	// Estimated is true if the fees are based on the current fee estimates
+	// because the explorer doesn't expose the history of fee rates.
+	Estimated bool `json:"estimated"`
+}
+
+// ListVtxosRequest selects a page of the vtxos of the wallet returned by
+// ListVtxosPaged. If neither Spendable nor Spent is set, all vtxos are listed.
+type ListVtxosRequest struct {
+	Spendable bool
+	Spent     bool
+	// PageSize is the max number of vtxos of the page, the server default is
+	// used if zero.
+	PageSize uint32
+	// PageToken is the token of the page to fetch, empty for the first one.
+	PageToken string
+	MinAmount uint64
+	RoundTxid string
+}
+
+// ExitStatus is the state of an onchain output of a unilateral exit. The
+// output becomes spendable once its relative timelock, counted from its
+// confirmation, has expired.
+type ExitStatus struct {
+	Outpoint           client.Outpoint         `json:"outpoint"`
+	Amount             uint64                  `json:"amount"`
+	Delay              common.RelativeLocktime `json:"delay"`
+	Confirmed          bool                    `json:"confirmed"`
+	ConfirmationHeight int64                   `json:"confirmation_height,omitempty"`
+	// BlocksLeft is the number of blocks to wait before the output can be
+	// spent. It's estimated for time based timelocks and it's the whole delay
+	// if the output is not confirmed yet.
+	BlocksLeft int64 `json:"blocks_left"`
+	// SpendableAt is the time the output can be spent from. It's estimated for
+	// block based timelocks and not set if the output is not confirmed yet.
+	SpendableAt time.Time `json:"spendable_at,omitempty"`
+	Spendable   bool      `json:"spendable"`
+}
+
 type balanceRes struct {
 	offchainBalance             uint64
 	onchainSpendableBalance     uint64
@@ -125,6 +255,31 @@ type balanceRes struct {
 	err                         error
 }
 
+// RecoveryProgress is the aggregate progress of a RecoverAll, including the
+// vtxos recovered by previous interrupted invocations.
+type RecoveryProgress struct {
+	TotalVtxos      int    `json:"total_vtxos"`
+	RecoveredVtxos  int    `json:"recovered_vtxos"`
+	TotalAmount     uint64 `json:"total_amount"`
+	RecoveredAmount uint64 `json:"recovered_amount"`
+	// RoundTxid is the round in which the last batch of vtxos was recovered.
+	RoundTxid string `json:"round_txid,omitempty"`
+}
+
+func (p RecoveryProgress) IsCompleted() bool {
+	return p.RecoveredVtxos >= p.TotalVtxos
+}
+
+// RecoverableVtxo is a swept vtxo of the wallet that can be recovered.
+type RecoverableVtxo struct {
+	Outpoint client.Outpoint `json:"outpoint"`
+	Amount   uint64          `json:"amount"`
+	// RoundTxid is the round that created the vtxo and whose tree has been
+	// swept by the server.
+	RoundTxid string    `json:"round_txid"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
+
 type CoinSelectOptions struct {
 	// If true, coin selector will select coins closest to expiry first.
 	WithExpirySorting bool
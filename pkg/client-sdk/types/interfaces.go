@@ -9,6 +9,9 @@ type Store interface {
 	ConfigStore() ConfigStore
 	TransactionStore() TransactionStore
 	VtxoStore() VtxoStore
+	RecoveryStore() RecoveryStore
+	AddressStore() AddressStore
+	RoundSessionStore() RoundSessionStore
 	Clean(ctx context.Context)
 	Close()
 }
@@ -45,3 +48,32 @@ type VtxoStore interface {
 	GetEventChannel() chan VtxoEvent
 	Close()
 }
+
+// RecoveryStore keeps track of the vtxos already recovered by a RecoverAll so
+// that an interrupted recovery can be resumed.
+type RecoveryStore interface {
+	AddRecoveredVtxos(ctx context.Context, vtxos []RecoveredVtxo) (int, error)
+	GetRecoveredVtxos(ctx context.Context) ([]RecoveredVtxo, error)
+	Clean(ctx context.Context) error
+	Close()
+}
+
+// AddressStore keeps track of all the addresses generated by the wallet.
+type AddressStore interface {
+	AddAddresses(ctx context.Context, addresses []Address) (int, error)
+	GetAllAddresses(ctx context.Context) ([]Address, error)
+	Clean(ctx context.Context) error
+	Close()
+}
+
+// RoundSessionStore keeps track of the settlements in progress so that they
+// can be resumed after a restart.
+type RoundSessionStore interface {
+	// AddRoundSession stores the given session, replacing any other one with
+	// the same request id.
+	AddRoundSession(ctx context.Context, session RoundSession) error
+	GetRoundSessions(ctx context.Context) ([]RoundSession, error)
+	DeleteRoundSession(ctx context.Context, requestId string) error
+	Clean(ctx context.Context) error
+	Close()
+}
@@ -40,6 +40,8 @@ type Config struct {
 	UtxoMaxAmount              int64
 	VtxoMinAmount              int64
 	VtxoMaxAmount              int64
+	SettleMinAmount            int64
+	SettleMaxAmount            int64
 }
 
 type VtxoKey struct {
@@ -51,6 +53,59 @@ func (v VtxoKey) String() string {
 	return fmt.Sprintf("%s:%s", v.Txid, strconv.Itoa(int(v.VOut)))
 }
 
+const (
+	OffchainAddress   AddressType = "OFFCHAIN"
+	BoardingAddress   AddressType = "BOARDING"
+	RedemptionAddress AddressType = "REDEMPTION"
+)
+
+type AddressType string
+
+// Address is an address generated by the wallet, it's kept in the store also
+// once the wallet doesn't derive it anymore (eg. after a server key change).
+type Address struct {
+	Address    string
+	Type       AddressType
+	Tapscripts []string
+	CreatedAt  time.Time
+}
+
+// RecoveredVtxo is the checkpoint of a swept vtxo recovered in a round.
+type RecoveredVtxo struct {
+	VtxoKey
+	Amount    uint64
+	RoundTxid string
+	CreatedAt time.Time
+}
+
+// RoundSession is the state of a settlement whose tx request is registered
+// with the server, persisted to resume the settlement after a restart. The
+// musig2 nonces of the vtxo tree signing are never persisted since they must
+// not be reused: a resumed session joins the signing if the server restarts it.
+type RoundSession struct {
+	RequestId     string
+	RoundId       string
+	Vtxos         []RoundSessionVtxo
+	BoardingUtxos []Utxo
+	Outputs       []RoundSessionOutput
+	CreatedAt     time.Time
+}
+
+// RoundSessionVtxo is a vtxo spent in a round session, with the tapscripts
+// required to sign its forfeit tx.
+type RoundSessionVtxo struct {
+	VtxoKey
+	Amount     uint64
+	Tapscripts []string
+	// Recoverable vtxos are swept and don't require a forfeit tx.
+	Recoverable bool
+}
+
+type RoundSessionOutput struct {
+	Address string
+	Amount  uint64
+}
+
 type Vtxo struct {
 	VtxoKey
 	PubKey    string
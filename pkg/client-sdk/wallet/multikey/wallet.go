@@ -0,0 +1,376 @@
+package multikeywallet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/ark-network/ark/pkg/client-sdk/internal/utils"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	singlekeywallet "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey"
+	walletstore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	log "github.com/sirupsen/logrus"
+)
+
+// Signer adds its partial signatures to the tapscript inputs of a tx, like the
+// co-signer service of a multi-key wallet. Any wallet.WalletService is a
+// Signer.
+type Signer interface {
+	SignTransaction(
+		ctx context.Context, explorerSvc explorer.Explorer, tx string,
+	) (signedTx string, err error)
+}
+
+// multikeyWallet is a threshold wallet: its vtxos and boarding utxos can be
+// spent with the signatures of any threshold of keys among the local one and
+// those of the cosigners. The local key is managed by a single-key wallet,
+// the other partial signatures are requested to the signers.
+type multikeyWallet struct {
+	wallet.WalletService
+	configStore  types.ConfigStore
+	walletStore  walletstore.WalletStore
+	cosignerKeys []*secp256k1.PublicKey
+	signers      []Signer
+	threshold    int
+}
+
+// NewBitcoinWallet returns a threshold wallet for the local key and the given
+// cosigner keys. The key of the co-signer service should come first, the
+// forfeit path used for offchain txs is the one of the local key and the first
+// threshold-1 cosigner keys. SignTransaction queries the signers in order
+// until threshold partial signatures, the local one included, are collected.
+// The wallet must be loaded with LoadArkClientWithWallet, its type can't be
+// restored from the config store only.
+func NewBitcoinWallet(
+	configStore types.ConfigStore, walletStore walletstore.WalletStore,
+	threshold int, cosignerKeys []*secp256k1.PublicKey, signers ...Signer,
+) (wallet.WalletService, error) {
+	if len(cosignerKeys) <= 0 {
+		return nil, fmt.Errorf("missing cosigner keys")
+	}
+	if threshold <= 0 || threshold > len(cosignerKeys)+1 {
+		return nil, fmt.Errorf(
+			"invalid threshold %d, must be in range [1, %d]", threshold, len(cosignerKeys)+1,
+		)
+	}
+	seen := make(map[string]struct{}, len(cosignerKeys))
+	for _, key := range cosignerKeys {
+		xonly := string(schnorr.SerializePubKey(key))
+		if _, ok := seen[xonly]; ok {
+			return nil, fmt.Errorf("duplicate cosigner key %x", schnorr.SerializePubKey(key))
+		}
+		seen[xonly] = struct{}{}
+	}
+
+	singlekeyWallet, err := singlekeywallet.NewBitcoinWallet(configStore, walletStore)
+	if err != nil {
+		return nil, err
+	}
+
+	return &multikeyWallet{
+		WalletService: singlekeyWallet,
+		configStore:   configStore,
+		walletStore:   walletStore,
+		cosignerKeys:  cosignerKeys,
+		signers:       signers,
+		threshold:     threshold,
+	}, nil
+}
+
+func (w *multikeyWallet) GetType() string {
+	return wallet.MultiKeyWallet
+}
+
+func (w *multikeyWallet) GetAddresses(
+	ctx context.Context,
+) ([]wallet.TapscriptsAddress, []wallet.TapscriptsAddress, []wallet.TapscriptsAddress, error) {
+	offchainAddr, boardingAddr, redemptionAddr, err := w.getAddress(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return []wallet.TapscriptsAddress{*offchainAddr},
+		[]wallet.TapscriptsAddress{*boardingAddr},
+		[]wallet.TapscriptsAddress{*redemptionAddr},
+		nil
+}
+
+func (w *multikeyWallet) NewAddress(
+	ctx context.Context, _ bool,
+) (*wallet.TapscriptsAddress, *wallet.TapscriptsAddress, error) {
+	offchainAddr, boardingAddr, _, err := w.getAddress(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	return offchainAddr, boardingAddr, nil
+}
+
+func (w *multikeyWallet) NewAddresses(
+	ctx context.Context, _ bool, num int,
+) ([]wallet.TapscriptsAddress, []wallet.TapscriptsAddress, error) {
+	offchainAddr, boardingAddr, _, err := w.getAddress(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	offchainAddrs := make([]wallet.TapscriptsAddress, 0, num)
+	boardingAddrs := make([]wallet.TapscriptsAddress, 0, num)
+	for i := 0; i < num; i++ {
+		offchainAddrs = append(offchainAddrs, *offchainAddr)
+		boardingAddrs = append(boardingAddrs, *boardingAddr)
+	}
+	return offchainAddrs, boardingAddrs, nil
+}
+
+// SignTransaction signs the given tx with the local key and combines the
+// result with the partial signatures of the signers.
+func (w *multikeyWallet) SignTransaction(
+	ctx context.Context, explorerSvc explorer.Explorer, tx string,
+) (string, error) {
+	if w.IsLocked() {
+		return "", fmt.Errorf("wallet is locked")
+	}
+
+	signedTx, err := w.WalletService.SignTransaction(ctx, explorerSvc, tx)
+	if err != nil {
+		return "", err
+	}
+
+	partialTxs := []string{signedTx}
+	for i, signer := range w.signers {
+		if len(partialTxs) >= w.threshold {
+			break
+		}
+
+		signedTx, err := signer.SignTransaction(ctx, explorerSvc, tx)
+		if err != nil {
+			log.WithError(err).Warnf("signer %d failed to sign tx", i)
+			continue
+		}
+		partialTxs = append(partialTxs, signedTx)
+	}
+
+	if len(partialTxs) < w.threshold {
+		return "", fmt.Errorf(
+			"not enough partial signatures, got %d out of %d", len(partialTxs), w.threshold,
+		)
+	}
+
+	return CombineSignedTxs(partialTxs...)
+}
+
+// SignTransactionOffline adds only the signature of the local key to the given
+// tx, the signers are never reached. The partially signed tx can be later
+// combined with those of the cosigners with CombineSignedTxs.
+func (w *multikeyWallet) SignTransactionOffline(
+	ctx context.Context, tx string, prevouts []wallet.PrevoutInfo,
+) (string, error) {
+	if w.IsLocked() {
+		return "", fmt.Errorf("wallet is locked")
+	}
+	return w.WalletService.SignTransactionOffline(ctx, tx, prevouts)
+}
+
+// CombineSignedTxs merges the tapscript signatures of the given partially
+// signed versions of the same tx into the first one.
+func CombineSignedTxs(txs ...string) (string, error) {
+	if len(txs) <= 0 {
+		return "", fmt.Errorf("missing txs")
+	}
+
+	combined, err := psbt.NewFromRawBytes(strings.NewReader(txs[0]), true)
+	if err != nil {
+		return "", err
+	}
+	txid := combined.UnsignedTx.TxHash()
+
+	for _, tx := range txs[1:] {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+		if err != nil {
+			return "", err
+		}
+		if ptx.UnsignedTx.TxHash() != txid {
+			return "", fmt.Errorf(
+				"partial tx %s doesn't match tx %s", ptx.UnsignedTx.TxHash(), txid,
+			)
+		}
+
+		for i, input := range ptx.Inputs {
+			if combined.Inputs[i].WitnessUtxo == nil {
+				combined.Inputs[i].WitnessUtxo = input.WitnessUtxo
+			}
+
+			for _, sig := range input.TaprootScriptSpendSig {
+				if hasTapscriptSig(combined.Inputs[i], sig) {
+					continue
+				}
+				combined.Inputs[i].TaprootScriptSpendSig = append(
+					combined.Inputs[i].TaprootScriptSpendSig, sig,
+				)
+			}
+		}
+	}
+
+	return combined.B64Encode()
+}
+
+func (w *multikeyWallet) getAddress(
+	ctx context.Context,
+) (*wallet.TapscriptsAddress, *wallet.TapscriptsAddress, *wallet.TapscriptsAddress, error) {
+	walletData, err := w.walletStore.GetWallet()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if walletData == nil {
+		return nil, nil, nil, fmt.Errorf("wallet not initialized")
+	}
+
+	data, err := w.configStore.GetData(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	netParams := utils.ToBitcoinNetwork(data.Network)
+	keys := append([]*secp256k1.PublicKey{walletData.PubKey}, w.cosignerKeys...)
+
+	vtxoScript := NewThresholdVtxoScript(
+		keys, w.threshold, data.ServerPubKey, data.UnilateralExitDelay,
+	)
+	vtxoTapKey, _, err := vtxoScript.TapTree()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	tapscripts, err := vtxoScript.Encode()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	offchainAddr, err := (&common.Address{
+		HRP:        data.Network.Addr,
+		Server:     data.ServerPubKey,
+		VtxoTapKey: vtxoTapKey,
+	}).Encode()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	redemptionAddr, err := btcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(vtxoTapKey), &netParams,
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	boardingVtxoScript := NewThresholdVtxoScript(
+		keys, w.threshold, data.ServerPubKey, data.BoardingExitDelay,
+	)
+	boardingTapKey, _, err := boardingVtxoScript.TapTree()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	boardingTapscripts, err := boardingVtxoScript.Encode()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	boardingAddr, err := btcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(boardingTapKey), &netParams,
+	)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return &wallet.TapscriptsAddress{
+			Tapscripts: tapscripts,
+			Address:    offchainAddr,
+		},
+		&wallet.TapscriptsAddress{
+			Tapscripts: boardingTapscripts,
+			Address:    boardingAddr.EncodeAddress(),
+		},
+		&wallet.TapscriptsAddress{
+			Tapscripts: tapscripts,
+			Address:    redemptionAddr.EncodeAddress(),
+		},
+		nil
+}
+
+// NewThresholdVtxoScript returns a vtxo script that can be spent with the
+// signatures of any threshold of the given keys: for every combination of
+// threshold keys there's an exit closure and a forfeit closure along with the
+// server. The closures follow the order of the keys, the first forfeit closure
+// is the one of the first threshold keys.
+func NewThresholdVtxoScript(
+	keys []*secp256k1.PublicKey, threshold int,
+	server *secp256k1.PublicKey, exitDelay common.RelativeLocktime,
+) *tree.TapscriptsVtxoScript {
+	combinations := keyCombinations(keys, threshold)
+
+	closures := make([]tree.Closure, 0, 2*len(combinations))
+	for _, combination := range combinations {
+		closures = append(closures, &tree.CSVMultisigClosure{
+			MultisigClosure: tree.MultisigClosure{PubKeys: combination},
+			Locktime:        exitDelay,
+		})
+	}
+	for _, combination := range combinations {
+		forfeitKeys := append(append([]*secp256k1.PublicKey{}, combination...), server)
+		closures = append(closures, &tree.MultisigClosure{PubKeys: forfeitKeys})
+	}
+
+	return &tree.TapscriptsVtxoScript{Closures: closures}
+}
+
+// keyCombinations returns all the combinations of size k of the given keys,
+// in lexicographic order of their indexes.
+func keyCombinations(keys []*secp256k1.PublicKey, k int) [][]*secp256k1.PublicKey {
+	if k <= 0 || k > len(keys) {
+		return nil
+	}
+
+	combinations := make([][]*secp256k1.PublicKey, 0)
+	indexes := make([]int, k)
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	for {
+		combination := make([]*secp256k1.PublicKey, 0, k)
+		for _, i := range indexes {
+			combination = append(combination, keys[i])
+		}
+		combinations = append(combinations, combination)
+
+		// find the rightmost index that can be incremented
+		i := k - 1
+		for i >= 0 && indexes[i] == len(keys)-k+i {
+			i--
+		}
+		if i < 0 {
+			return combinations
+		}
+		indexes[i]++
+		for j := i + 1; j < k; j++ {
+			indexes[j] = indexes[j-1] + 1
+		}
+	}
+}
+
+func hasTapscriptSig(input psbt.PInput, sig *psbt.TaprootScriptSpendSig) bool {
+	for _, s := range input.TaprootScriptSpendSig {
+		if bytes.Equal(s.XOnlyPubKey, sig.XOnlyPubKey) &&
+			bytes.Equal(s.LeafHash, sig.LeafHash) {
+			return true
+		}
+	}
+	return false
+}
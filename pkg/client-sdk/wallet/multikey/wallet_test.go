@@ -0,0 +1,241 @@
+package multikeywallet_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	inmemorystore "github.com/ark-network/ark/pkg/client-sdk/store/inmemory"
+	sdktypes "github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	multikeywallet "github.com/ark-network/ark/pkg/client-sdk/wallet/multikey"
+	singlekeywallet "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey"
+	inmemorywalletstore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store/inmemory"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/stretchr/testify/require"
+)
+
+const password = "password"
+
+var exitDelay = common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512}
+
+func TestMultiKeyWallet(t *testing.T) {
+	ctx := context.Background()
+
+	serverKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	serviceKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+	backupKey, err := btcec.NewPrivateKey()
+	require.NoError(t, err)
+
+	configStore := newConfigStore(t, serverKey.PubKey())
+	serviceWallet := newSingleKeyWallet(t, configStore, serviceKey)
+	serverWallet := newSingleKeyWallet(t, configStore, serverKey)
+
+	walletStore, err := inmemorywalletstore.NewWalletStore()
+	require.NoError(t, err)
+	walletSvc, err := multikeywallet.NewBitcoinWallet(
+		configStore, walletStore, 2,
+		[]*btcec.PublicKey{serviceKey.PubKey(), backupKey.PubKey()}, serviceWallet,
+	)
+	require.NoError(t, err)
+	require.Equal(t, wallet.MultiKeyWallet, walletSvc.GetType())
+
+	_, err = walletSvc.Create(ctx, password, "")
+	require.NoError(t, err)
+	_, err = walletSvc.Unlock(ctx, password)
+	require.NoError(t, err)
+
+	offchainAddrs, boardingAddrs, redemptionAddrs, err := walletSvc.GetAddresses(ctx)
+	require.NoError(t, err)
+	require.Len(t, offchainAddrs, 1)
+	require.Len(t, boardingAddrs, 1)
+	require.Len(t, redemptionAddrs, 1)
+
+	// 2-of-3: 3 exit closures and 3 forfeit closures
+	vtxoScript, err := tree.ParseVtxoScript(offchainAddrs[0].Tapscripts)
+	require.NoError(t, err)
+	require.Len(t, vtxoScript.ExitClosures(), 3)
+	require.Len(t, vtxoScript.ForfeitClosures(), 3)
+	require.NoError(t, vtxoScript.Validate(serverKey.PubKey(), exitDelay))
+
+	vtxoTapKey, _, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+	addr, err := common.DecodeAddress(offchainAddrs[0].Address)
+	require.NoError(t, err)
+	require.Equal(
+		t, schnorr.SerializePubKey(vtxoTapKey), schnorr.SerializePubKey(addr.VtxoTapKey),
+	)
+
+	t.Run("sign redeem tx", func(t *testing.T) {
+		redeemTx := makeRedeemTx(t, vtxoScript, offchainAddrs[0].Tapscripts)
+
+		signedTx, err := walletSvc.SignTransaction(ctx, nil, redeemTx)
+		require.NoError(t, err)
+
+		ptx, err := psbt.NewFromRawBytes(bytes.NewBufferString(signedTx), true)
+		require.NoError(t, err)
+		require.Len(t, ptx.Inputs[0].TaprootScriptSpendSig, 2)
+
+		// the server adds its signature to the forfeit leaf
+		signedTx, err = serverWallet.SignTransaction(ctx, nil, signedTx)
+		require.NoError(t, err)
+		requireValidTx(t, signedTx)
+	})
+
+	t.Run("missing co-signer", func(t *testing.T) {
+		walletStore, err := inmemorywalletstore.NewWalletStore()
+		require.NoError(t, err)
+		walletSvc, err := multikeywallet.NewBitcoinWallet(
+			configStore, walletStore, 2,
+			[]*btcec.PublicKey{serviceKey.PubKey(), backupKey.PubKey()},
+		)
+		require.NoError(t, err)
+		_, err = walletSvc.Create(ctx, password, "")
+		require.NoError(t, err)
+		_, err = walletSvc.Unlock(ctx, password)
+		require.NoError(t, err)
+
+		offchainAddrs, _, _, err := walletSvc.GetAddresses(ctx)
+		require.NoError(t, err)
+		vtxoScript, err := tree.ParseVtxoScript(offchainAddrs[0].Tapscripts)
+		require.NoError(t, err)
+
+		redeemTx := makeRedeemTx(t, vtxoScript, offchainAddrs[0].Tapscripts)
+		_, err = walletSvc.SignTransaction(ctx, nil, redeemTx)
+		require.ErrorContains(t, err, "not enough partial signatures")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		walletStore, err := inmemorywalletstore.NewWalletStore()
+		require.NoError(t, err)
+
+		_, err = multikeywallet.NewBitcoinWallet(configStore, walletStore, 2, nil)
+		require.Error(t, err)
+
+		_, err = multikeywallet.NewBitcoinWallet(
+			configStore, walletStore, 3, []*btcec.PublicKey{serviceKey.PubKey()},
+		)
+		require.Error(t, err)
+
+		_, err = multikeywallet.NewBitcoinWallet(
+			configStore, walletStore, 2,
+			[]*btcec.PublicKey{serviceKey.PubKey(), serviceKey.PubKey()},
+		)
+		require.Error(t, err)
+	})
+}
+
+func newConfigStore(t *testing.T, serverPubkey *btcec.PublicKey) sdktypes.ConfigStore {
+	store, err := inmemorystore.NewConfigStore()
+	require.NoError(t, err)
+
+	err = store.AddData(context.Background(), sdktypes.Config{
+		ServerUrl:           "localhost:7070",
+		ServerPubKey:        serverPubkey,
+		WalletType:          wallet.MultiKeyWallet,
+		ClientType:          client.GrpcClient,
+		Network:             common.BitcoinRegTest,
+		VtxoTreeExpiry:      common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		RoundInterval:       10,
+		UnilateralExitDelay: exitDelay,
+		Dust:                1000,
+		BoardingExitDelay:   common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 1024},
+		ForfeitAddress:      "bcrt1qzvqj",
+	})
+	require.NoError(t, err)
+	return store
+}
+
+func newSingleKeyWallet(
+	t *testing.T, configStore sdktypes.ConfigStore, key *btcec.PrivateKey,
+) wallet.WalletService {
+	ctx := context.Background()
+	walletStore, err := inmemorywalletstore.NewWalletStore()
+	require.NoError(t, err)
+	walletSvc, err := singlekeywallet.NewBitcoinWallet(configStore, walletStore)
+	require.NoError(t, err)
+	_, err = walletSvc.Create(ctx, password, hex.EncodeToString(key.Serialize()))
+	require.NoError(t, err)
+	_, err = walletSvc.Unlock(ctx, password)
+	require.NoError(t, err)
+	return walletSvc
+}
+
+// makeRedeemTx returns a redeem tx spending a vtxo with the given script with
+// its first forfeit closure.
+func makeRedeemTx(t *testing.T, vtxoScript tree.VtxoScript, tapscripts []string) string {
+	_, vtxoTapTree, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+
+	forfeitScript, err := vtxoScript.ForfeitClosures()[0].Script()
+	require.NoError(t, err)
+	forfeitLeaf := txscript.NewBaseTapLeaf(forfeitScript)
+	leafProof, err := vtxoTapTree.GetTaprootMerkleProof(forfeitLeaf.TapHash())
+	require.NoError(t, err)
+	ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
+	require.NoError(t, err)
+
+	prevout := wire.OutPoint{Hash: chainhash.HashH([]byte("input")), Index: 0}
+	receiverScript, err := common.P2TRScript(ctrlBlock.InternalKey)
+	require.NoError(t, err)
+
+	redeemTx, err := tree.BuildRedeemTx(
+		[]common.VtxoInput{{
+			Outpoint: &prevout,
+			Amount:   10000,
+			Tapscript: &waddrmgr.Tapscript{
+				RevealedScript: leafProof.Script,
+				ControlBlock:   ctrlBlock,
+			},
+			RevealedTapscripts: tapscripts,
+		}},
+		[]*wire.TxOut{{Value: 9000, PkScript: receiverScript}},
+	)
+	require.NoError(t, err)
+	return redeemTx
+}
+
+// requireValidTx finalizes the given signed redeem tx and executes the script
+// of its input.
+func requireValidTx(t *testing.T, signedTx string) {
+	ptx, err := psbt.NewFromRawBytes(bytes.NewBufferString(signedTx), true)
+	require.NoError(t, err)
+
+	in := ptx.Inputs[0]
+	leaf := in.TaprootLeafScript[0]
+	closure, err := tree.DecodeClosure(leaf.Script)
+	require.NoError(t, err)
+
+	sigs := make(map[string][]byte)
+	for _, sig := range in.TaprootScriptSpendSig {
+		sigs[hex.EncodeToString(sig.XOnlyPubKey)] = sig.Signature
+	}
+	witness, err := closure.Witness(leaf.ControlBlock, sigs)
+	require.NoError(t, err)
+
+	tx := ptx.UnsignedTx.Copy()
+	tx.TxIn[0].Witness = witness
+
+	prevoutFetcher := txscript.NewCannedPrevOutputFetcher(
+		in.WitnessUtxo.PkScript, in.WitnessUtxo.Value,
+	)
+	engine, err := txscript.NewEngine(
+		in.WitnessUtxo.PkScript, tx, 0, txscript.StandardVerifyFlags,
+		nil, txscript.NewTxSigHashes(tx, prevoutFetcher), in.WitnessUtxo.Value,
+		prevoutFetcher,
+	)
+	require.NoError(t, err)
+	require.NoError(t, engine.Execute())
+}
@@ -0,0 +1,191 @@
+package wallet
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	seedBackupVersion = 1
+	seedBackupKdf     = "scrypt"
+	seedBackupSaltLen = 32
+	seedBackupKeyLen  = 32
+
+	// minScryptN and maxScryptN bound the cost parameter of a backup.
+	minScryptN = 1 << 14
+	maxScryptN = 1 << 20
+	// maxScryptMemory bounds the memory used by scrypt, 128*N*r bytes, so that
+	// a crafted backup can't exhaust the memory (1GiB).
+	maxScryptMemory = 1 << 30
+)
+
+// DefaultScryptParams are the scrypt parameters used by default to derive the
+// encryption key of a seed backup (128MiB of memory).
+var DefaultScryptParams = ScryptParams{N: 1 << 17, R: 8, P: 1}
+
+// ScryptParams are the cost parameters of the scrypt key derivation function.
+type ScryptParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+func (p ScryptParams) validate() error {
+	if p.N < minScryptN || p.N > maxScryptN || p.N&(p.N-1) != 0 {
+		return fmt.Errorf(
+			"invalid scrypt N %d, must be a power of 2 in range [%d, %d]",
+			p.N, minScryptN, maxScryptN,
+		)
+	}
+	if p.R <= 0 || p.R > 32 {
+		return fmt.Errorf("invalid scrypt r %d, must be in range [1, 32]", p.R)
+	}
+	if p.P <= 0 || p.P > 16 {
+		return fmt.Errorf("invalid scrypt p %d, must be in range [1, 16]", p.P)
+	}
+	if 128*p.N*p.R > maxScryptMemory {
+		return fmt.Errorf(
+			"invalid scrypt params N %d and r %d, they require more than %d bytes of memory",
+			p.N, p.R, maxScryptMemory,
+		)
+	}
+	return nil
+}
+
+// seedBackup is the serialized form of an encrypted seed. The header (version,
+// kdf and its params) is authenticated as additional data of the AES-GCM
+// ciphertext, so any tampering makes the decryption fail.
+type seedBackup struct {
+	Version    int          `json:"version"`
+	Kdf        string       `json:"kdf"`
+	Params     ScryptParams `json:"params"`
+	Salt       string       `json:"salt"`
+	Nonce      string       `json:"nonce"`
+	Ciphertext string       `json:"ciphertext"`
+}
+
+func (b seedBackup) additionalData() []byte {
+	return []byte(fmt.Sprintf(
+		"%d:%s:%d:%d:%d", b.Version, b.Kdf, b.Params.N, b.Params.R, b.Params.P,
+	))
+}
+
+// EncryptSeed encrypts the given seed with a key derived from the password
+// with scrypt and returns the backup as a JSON string. The plaintext seed is
+// never persisted.
+func EncryptSeed(seed, password string, params ScryptParams) (string, error) {
+	if len(seed) <= 0 {
+		return "", fmt.Errorf("missing seed")
+	}
+	if len(password) <= 0 {
+		return "", fmt.Errorf("missing encryption password")
+	}
+	if err := params.validate(); err != nil {
+		return "", err
+	}
+
+	salt := make([]byte, seedBackupSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	gcm, err := newSeedBackupCipher(password, salt, params)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	backup := seedBackup{
+		Version: seedBackupVersion,
+		Kdf:     seedBackupKdf,
+		Params:  params,
+		Salt:    hex.EncodeToString(salt),
+		Nonce:   hex.EncodeToString(nonce),
+	}
+	ciphertext := gcm.Seal(nil, nonce, []byte(seed), backup.additionalData())
+	backup.Ciphertext = hex.EncodeToString(ciphertext)
+
+	buf, err := json.Marshal(backup)
+	if err != nil {
+		return "", err
+	}
+	return string(buf), nil
+}
+
+// DecryptSeed returns the seed of a backup made with EncryptSeed.
+func DecryptSeed(encryptedSeed, password string) (string, error) {
+	if len(encryptedSeed) <= 0 {
+		return "", fmt.Errorf("missing encrypted seed")
+	}
+	if len(password) <= 0 {
+		return "", fmt.Errorf("missing decryption password")
+	}
+
+	var backup seedBackup
+	if err := json.Unmarshal([]byte(encryptedSeed), &backup); err != nil {
+		return "", fmt.Errorf("invalid encrypted seed format: %s", err)
+	}
+	if backup.Version != seedBackupVersion {
+		return "", fmt.Errorf("unsupported encrypted seed version %d", backup.Version)
+	}
+	if backup.Kdf != seedBackupKdf {
+		return "", fmt.Errorf("unsupported encrypted seed kdf %s", backup.Kdf)
+	}
+	if err := backup.Params.validate(); err != nil {
+		return "", err
+	}
+
+	salt, err := hex.DecodeString(backup.Salt)
+	if err != nil || len(salt) != seedBackupSaltLen {
+		return "", fmt.Errorf("invalid encrypted seed salt")
+	}
+	nonce, err := hex.DecodeString(backup.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted seed nonce")
+	}
+	ciphertext, err := hex.DecodeString(backup.Ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("invalid encrypted seed ciphertext")
+	}
+
+	gcm, err := newSeedBackupCipher(password, salt, backup.Params)
+	if err != nil {
+		return "", err
+	}
+	if len(nonce) != gcm.NonceSize() {
+		return "", fmt.Errorf("invalid encrypted seed nonce")
+	}
+
+	seed, err := gcm.Open(nil, nonce, ciphertext, backup.additionalData())
+	if err != nil {
+		return "", fmt.Errorf("invalid password")
+	}
+	return string(seed), nil
+}
+
+func newSeedBackupCipher(
+	password string, salt []byte, params ScryptParams,
+) (cipher.AEAD, error) {
+	key, err := scrypt.Key(
+		[]byte(password), salt, params.N, params.R, params.P, seedBackupKeyLen,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	blockCipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(blockCipher)
+}
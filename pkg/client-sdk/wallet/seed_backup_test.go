@@ -0,0 +1,70 @@
+package wallet_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeedBackup(t *testing.T) {
+	seed := "e8c5e8b4ba2b8a6e1e1c6ec9e6ad67b1f0bbac2cfc4c6b48fe8a16dd4fa4f78d"
+	password := "password"
+	params := wallet.ScryptParams{N: 1 << 14, R: 8, P: 1}
+
+	t.Run("valid", func(t *testing.T) {
+		encryptedSeed, err := wallet.EncryptSeed(seed, password, params)
+		require.NoError(t, err)
+		require.NotContains(t, encryptedSeed, seed)
+
+		// the salt and nonce are random
+		otherEncryptedSeed, err := wallet.EncryptSeed(seed, password, params)
+		require.NoError(t, err)
+		require.NotEqual(t, encryptedSeed, otherEncryptedSeed)
+
+		decryptedSeed, err := wallet.DecryptSeed(encryptedSeed, password)
+		require.NoError(t, err)
+		require.Equal(t, seed, decryptedSeed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalidParams := []wallet.ScryptParams{
+			{N: 1 << 10, R: 8, P: 1},
+			{N: 1<<14 + 1, R: 8, P: 1},
+			{N: 1 << 21, R: 8, P: 1},
+			{N: 1 << 14, R: 0, P: 1},
+			{N: 1 << 14, R: 8, P: 0},
+			// within the single bounds, but above the memory limit
+			{N: 1 << 20, R: 32, P: 1},
+		}
+		for _, p := range invalidParams {
+			_, err := wallet.EncryptSeed(seed, password, p)
+			require.Error(t, err)
+		}
+
+		_, err := wallet.EncryptSeed("", password, params)
+		require.Error(t, err)
+		_, err = wallet.EncryptSeed(seed, "", params)
+		require.Error(t, err)
+
+		encryptedSeed, err := wallet.EncryptSeed(seed, password, params)
+		require.NoError(t, err)
+
+		_, err = wallet.DecryptSeed(encryptedSeed, "wrong password")
+		require.EqualError(t, err, "invalid password")
+
+		_, err = wallet.DecryptSeed(strings.TrimSuffix(encryptedSeed, "}"), password)
+		require.Error(t, err)
+
+		// tampering the kdf params must be detected
+		var backup map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(encryptedSeed), &backup))
+		backup["params"].(map[string]interface{})["r"] = 9
+		tampered, err := json.Marshal(backup)
+		require.NoError(t, err)
+		_, err = wallet.DecryptSeed(string(tampered), password)
+		require.EqualError(t, err, "invalid password")
+	})
+}
@@ -182,6 +182,68 @@ func (s *bitcoinWallet) SignTransaction(
 		}
 	}
 
+	return s.signInputs(updater)
+}
+
+// SignTransactionOffline signs the given tx without reaching the network: the
+// prevouts of the inputs missing the witness utxo must be given. A prevout not
+// matching the witness utxo of its input makes the signing fail.
+func (s *bitcoinWallet) SignTransactionOffline(
+	_ context.Context, tx string, prevouts []wallet.PrevoutInfo,
+) (string, error) {
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+	if err != nil {
+		return "", err
+	}
+
+	updater, err := psbt.NewUpdater(ptx)
+	if err != nil {
+		return "", err
+	}
+
+	prevoutsByOutpoint := make(map[wire.OutPoint]wallet.PrevoutInfo)
+	for _, prevout := range prevouts {
+		prevoutsByOutpoint[prevout.Outpoint] = prevout
+	}
+
+	for i, input := range updater.Upsbt.UnsignedTx.TxIn {
+		witnessUtxo := updater.Upsbt.Inputs[i].WitnessUtxo
+		prevout, ok := prevoutsByOutpoint[input.PreviousOutPoint]
+
+		if witnessUtxo != nil {
+			if ok && (witnessUtxo.Value != prevout.Amount ||
+				!bytes.Equal(witnessUtxo.PkScript, prevout.PkScript)) {
+				return "", fmt.Errorf(
+					"prevout of input %d doesn't match its witness utxo", i,
+				)
+			}
+			continue
+		}
+
+		if !ok {
+			return "", fmt.Errorf(
+				"missing prevout of input %d (%s)", i, input.PreviousOutPoint,
+			)
+		}
+
+		if err := updater.AddInWitnessUtxo(
+			wire.NewTxOut(prevout.Amount, prevout.PkScript), i,
+		); err != nil {
+			return "", err
+		}
+
+		if err := updater.AddInSighashType(txscript.SigHashDefault, i); err != nil {
+			return "", err
+		}
+	}
+
+	return s.signInputs(updater)
+}
+
+// signInputs adds the signatures of the wallet key to the inputs of the given
+// tx, the witness utxo of every input must be set.
+func (s *bitcoinWallet) signInputs(updater *psbt.Updater) (string, error) {
+	ptx := updater.Upsbt
 	prevouts := make(map[wire.OutPoint]*wire.TxOut)
 
 	for i, input := range updater.Upsbt.Inputs {
@@ -235,6 +297,13 @@ func (s *bitcoinWallet) SignTransaction(
 							break
 						}
 					}
+				case *tree.HashLockMultisigClosure:
+					for _, key := range c.PubKeys {
+						if bytes.Equal(schnorr.SerializePubKey(key), myPubkey) {
+							sign = true
+							break
+						}
+					}
 				}
 
 				if sign {
@@ -0,0 +1,131 @@
+package singlekeywallet
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/ark-network/ark/pkg/client-sdk/types"
+	"github.com/ark-network/ark/pkg/client-sdk/wallet"
+	walletstore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// watchOnlyWallet derives the same addresses of a single-key wallet from its
+// public key only, therefore it can track the vtxos and boarding utxos of the
+// wallet but can't sign any tx or message.
+type watchOnlyWallet struct {
+	*bitcoinWallet
+}
+
+func NewWatchOnlyWallet(
+	configStore types.ConfigStore, walletStore walletstore.WalletStore,
+) (wallet.WalletService, error) {
+	walletData, err := walletStore.GetWallet()
+	if err != nil {
+		return nil, err
+	}
+	return &watchOnlyWallet{
+		&bitcoinWallet{
+			&singlekeyWallet{
+				configStore: configStore,
+				walletStore: walletStore,
+				walletData:  walletData,
+			},
+		},
+	}, nil
+}
+
+func (w *watchOnlyWallet) GetType() string {
+	return wallet.WatchOnlyWallet
+}
+
+// Create stores the given public key, either hex encoded (compressed or
+// x-only) or as an extended public key. There's no seed to return.
+func (w *watchOnlyWallet) Create(
+	_ context.Context, _, pubkey string,
+) (string, error) {
+	key, err := parseWatchOnlyPubKey(pubkey)
+	if err != nil {
+		return "", err
+	}
+
+	walletData := walletstore.WalletData{PubKey: key}
+	if err := w.walletStore.AddWallet(walletData); err != nil {
+		return "", err
+	}
+
+	w.walletData = &walletData
+	return "", nil
+}
+
+func (w *watchOnlyWallet) Lock(context.Context) error {
+	if w.walletData == nil {
+		return fmt.Errorf("wallet not initialized")
+	}
+	return nil
+}
+
+// Unlock is a no-op, there's no private key to decrypt.
+func (w *watchOnlyWallet) Unlock(context.Context, string) (bool, error) {
+	if w.walletData == nil {
+		return false, fmt.Errorf("wallet not initialized")
+	}
+	return true, nil
+}
+
+func (w *watchOnlyWallet) IsLocked() bool {
+	return false
+}
+
+func (w *watchOnlyWallet) Dump(context.Context) (string, error) {
+	return "", wallet.ErrWatchOnly
+}
+
+func (w *watchOnlyWallet) SignTransaction(
+	context.Context, explorer.Explorer, string,
+) (string, error) {
+	return "", wallet.ErrWatchOnly
+}
+
+func (w *watchOnlyWallet) SignTransactionOffline(
+	context.Context, string, []wallet.PrevoutInfo,
+) (string, error) {
+	return "", wallet.ErrWatchOnly
+}
+
+func (w *watchOnlyWallet) SignMessage(context.Context, []byte) (string, error) {
+	return "", wallet.ErrWatchOnly
+}
+
+func (w *watchOnlyWallet) NewVtxoTreeSigner(
+	context.Context, string,
+) (tree.SignerSession, error) {
+	return nil, wallet.ErrWatchOnly
+}
+
+func parseWatchOnlyPubKey(pubkey string) (*secp256k1.PublicKey, error) {
+	if len(pubkey) <= 0 {
+		return nil, fmt.Errorf("missing public key")
+	}
+
+	if buf, err := hex.DecodeString(pubkey); err == nil {
+		if len(buf) == schnorr.PubKeyBytesLen {
+			return schnorr.ParsePubKey(buf)
+		}
+		return secp256k1.ParsePubKey(buf)
+	}
+
+	extendedKey, err := hdkeychain.NewKeyFromString(pubkey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid public key, must be hex or xpub: %s", err)
+	}
+	if extendedKey.IsPrivate() {
+		return nil, fmt.Errorf("invalid public key, got an extended private key")
+	}
+	return extendedKey.ECPubKey()
+}
@@ -2,20 +2,35 @@ package wallet
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/btcsuite/btcd/wire"
 )
 
 const (
 	SingleKeyWallet = "singlekey"
+	WatchOnlyWallet = "watchonly"
+	MultiKeyWallet  = "multikey"
 )
 
+// ErrWatchOnly is returned by any operation that requires the private key of
+// a watch-only wallet.
+var ErrWatchOnly = fmt.Errorf("operation not supported by watch-only wallet")
+
 type TapscriptsAddress struct {
 	Tapscripts []string
 	Address    string
 }
 
+// PrevoutInfo is the output spent by an input of a tx to sign offline.
+type PrevoutInfo struct {
+	Outpoint wire.OutPoint
+	Amount   int64
+	PkScript []byte
+}
+
 type WalletService interface {
 	GetType() string
 	Create(
@@ -36,6 +51,11 @@ type WalletService interface {
 	SignTransaction(
 		ctx context.Context, explorerSvc explorer.Explorer, tx string,
 	) (signedTx string, err error)
+	// SignTransactionOffline is like SignTransaction but never reaches the
+	// network: the prevouts of the inputs missing the witness utxo are given.
+	SignTransactionOffline(
+		ctx context.Context, tx string, prevouts []PrevoutInfo,
+	) (signedTx string, err error)
 	SignMessage(
 		ctx context.Context, message []byte,
 	) (signature string, err error)
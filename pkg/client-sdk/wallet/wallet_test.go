@@ -1,11 +1,14 @@
 package wallet_test
 
 import (
+	"bytes"
 	"context"
+	"encoding/hex"
 	"strings"
 	"testing"
 
 	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/pkg/client-sdk/client"
 	inmemorystore "github.com/ark-network/ark/pkg/client-sdk/store/inmemory"
 	sdktypes "github.com/ark-network/ark/pkg/client-sdk/types"
@@ -13,6 +16,13 @@ import (
 	singlekeywallet "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey"
 	inmemorywalletstore "github.com/ark-network/ark/pkg/client-sdk/wallet/singlekey/store/inmemory"
 	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/stretchr/testify/require"
 )
 
@@ -136,3 +146,238 @@ func TestWallet(t *testing.T) {
 		})
 	}
 }
+
+func TestWatchOnlyWallet(t *testing.T) {
+	ctx := context.Background()
+	serverKey, _ := btcec.NewPrivateKey()
+	testStoreData := sdktypes.Config{
+		ServerUrl:           "localhost:7070",
+		ServerPubKey:        serverKey.PubKey(),
+		WalletType:          wallet.WatchOnlyWallet,
+		ClientType:          client.GrpcClient,
+		Network:             common.BitcoinRegTest,
+		VtxoTreeExpiry:      common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		RoundInterval:       10,
+		UnilateralExitDelay: common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		Dust:                1000,
+		BoardingExitDelay:   common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		ForfeitAddress:      "bcrt1qzvqj",
+	}
+
+	newWallet := func(t *testing.T, watchOnly bool) wallet.WalletService {
+		store, err := inmemorystore.NewConfigStore()
+		require.NoError(t, err)
+		err = store.AddData(ctx, testStoreData)
+		require.NoError(t, err)
+		walletStore, err := inmemorywalletstore.NewWalletStore()
+		require.NoError(t, err)
+
+		if watchOnly {
+			walletSvc, err := singlekeywallet.NewWatchOnlyWallet(store, walletStore)
+			require.NoError(t, err)
+			return walletSvc
+		}
+		walletSvc, err := singlekeywallet.NewBitcoinWallet(store, walletStore)
+		require.NoError(t, err)
+		return walletSvc
+	}
+
+	signingWallet := newWallet(t, false)
+	prvkey, err := signingWallet.Create(ctx, "password", "")
+	require.NoError(t, err)
+	expectedOffchainAddrs, expectedBoardingAddrs, _, err := signingWallet.GetAddresses(ctx)
+	require.NoError(t, err)
+
+	buf, err := hex.DecodeString(prvkey)
+	require.NoError(t, err)
+	_, pubkey := btcec.PrivKeyFromBytes(buf)
+
+	t.Run("pubkey", func(t *testing.T) {
+		walletSvc := newWallet(t, true)
+		require.Equal(t, wallet.WatchOnlyWallet, walletSvc.GetType())
+
+		seed, err := walletSvc.Create(ctx, "", hex.EncodeToString(pubkey.SerializeCompressed()))
+		require.NoError(t, err)
+		require.Empty(t, seed)
+		require.False(t, walletSvc.IsLocked())
+
+		offchainAddrs, boardingAddrs, _, err := walletSvc.GetAddresses(ctx)
+		require.NoError(t, err)
+		require.Equal(t, expectedOffchainAddrs, offchainAddrs)
+		require.Equal(t, expectedBoardingAddrs, boardingAddrs)
+
+		_, err = walletSvc.SignTransaction(ctx, nil, "")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = walletSvc.SignTransactionOffline(ctx, "", nil)
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = walletSvc.SignMessage(ctx, []byte("message"))
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = walletSvc.Dump(ctx)
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+		_, err = walletSvc.NewVtxoTreeSigner(ctx, "m/0")
+		require.ErrorIs(t, err, wallet.ErrWatchOnly)
+	})
+
+	t.Run("xpub", func(t *testing.T) {
+		seed := make([]byte, hdkeychain.RecommendedSeedLen)
+		masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.RegressionNetParams)
+		require.NoError(t, err)
+		xpub, err := masterKey.Neuter()
+		require.NoError(t, err)
+
+		walletSvc := newWallet(t, true)
+		_, err = walletSvc.Create(ctx, "", xpub.String())
+		require.NoError(t, err)
+
+		offchainAddr, boardingAddr, err := walletSvc.NewAddress(ctx, false)
+		require.NoError(t, err)
+		require.NotEmpty(t, offchainAddr.Address)
+		require.NotEmpty(t, boardingAddr.Address)
+
+		_, err = newWallet(t, true).Create(ctx, "", masterKey.String())
+		require.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newWallet(t, true).Create(ctx, "", "")
+		require.Error(t, err)
+		_, err = newWallet(t, true).Create(ctx, "", "not a key")
+		require.Error(t, err)
+	})
+}
+
+func TestSignTransactionOffline(t *testing.T) {
+	ctx := context.Background()
+	serverKey, _ := btcec.NewPrivateKey()
+	testStoreData := sdktypes.Config{
+		ServerUrl:           "localhost:7070",
+		ServerPubKey:        serverKey.PubKey(),
+		WalletType:          wallet.SingleKeyWallet,
+		ClientType:          client.GrpcClient,
+		Network:             common.BitcoinRegTest,
+		VtxoTreeExpiry:      common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		RoundInterval:       10,
+		UnilateralExitDelay: common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		Dust:                1000,
+		BoardingExitDelay:   common.RelativeLocktime{Type: common.LocktimeTypeSecond, Value: 512},
+		ForfeitAddress:      "bcrt1qzvqj",
+	}
+
+	store, err := inmemorystore.NewConfigStore()
+	require.NoError(t, err)
+	err = store.AddData(ctx, testStoreData)
+	require.NoError(t, err)
+	walletStore, err := inmemorywalletstore.NewWalletStore()
+	require.NoError(t, err)
+	walletSvc, err := singlekeywallet.NewBitcoinWallet(store, walletStore)
+	require.NoError(t, err)
+	_, err = walletSvc.Create(ctx, "password", "")
+	require.NoError(t, err)
+	_, err = walletSvc.Unlock(ctx, "password")
+	require.NoError(t, err)
+
+	offchainAddrs, _, _, err := walletSvc.GetAddresses(ctx)
+	require.NoError(t, err)
+	vtxoScript, err := tree.ParseVtxoScript(offchainAddrs[0].Tapscripts)
+	require.NoError(t, err)
+	_, vtxoTapTree, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+
+	forfeitScript, err := vtxoScript.ForfeitClosures()[0].Script()
+	require.NoError(t, err)
+	leafProof, err := vtxoTapTree.GetTaprootMerkleProof(
+		txscript.NewBaseTapLeaf(forfeitScript).TapHash(),
+	)
+	require.NoError(t, err)
+	ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
+	require.NoError(t, err)
+	receiverScript, err := common.P2TRScript(ctrlBlock.InternalKey)
+	require.NoError(t, err)
+
+	prevout := wire.OutPoint{Hash: chainhash.HashH([]byte("input")), Index: 0}
+	redeemTx, err := tree.BuildRedeemTx(
+		[]common.VtxoInput{{
+			Outpoint: &prevout,
+			Amount:   10000,
+			Tapscript: &waddrmgr.Tapscript{
+				RevealedScript: leafProof.Script,
+				ControlBlock:   ctrlBlock,
+			},
+			RevealedTapscripts: offchainAddrs[0].Tapscripts,
+		}},
+		[]*wire.TxOut{{Value: 9000, PkScript: receiverScript}},
+	)
+	require.NoError(t, err)
+
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+	require.NoError(t, err)
+	prevoutInfo := wallet.PrevoutInfo{
+		Outpoint: prevout,
+		Amount:   ptx.Inputs[0].WitnessUtxo.Value,
+		PkScript: ptx.Inputs[0].WitnessUtxo.PkScript,
+	}
+
+	// the explorer is never used if the witness utxos are set
+	expectedTx, err := walletSvc.SignTransaction(ctx, nil, redeemTx)
+	require.NoError(t, err)
+	expectedPtx, err := psbt.NewFromRawBytes(strings.NewReader(expectedTx), true)
+	require.NoError(t, err)
+	require.Len(t, expectedPtx.Inputs[0].TaprootScriptSpendSig, 1)
+
+	t.Run("with witness utxos", func(t *testing.T) {
+		signedTx, err := walletSvc.SignTransactionOffline(ctx, redeemTx, nil)
+		require.NoError(t, err)
+		requireSameSigs(t, expectedPtx, signedTx)
+
+		signedTx, err = walletSvc.SignTransactionOffline(
+			ctx, redeemTx, []wallet.PrevoutInfo{prevoutInfo},
+		)
+		require.NoError(t, err)
+		requireSameSigs(t, expectedPtx, signedTx)
+	})
+
+	t.Run("with prevouts", func(t *testing.T) {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+		require.NoError(t, err)
+		ptx.Inputs[0].WitnessUtxo = nil
+		unsignedTx, err := ptx.B64Encode()
+		require.NoError(t, err)
+
+		signedTx, err := walletSvc.SignTransactionOffline(
+			ctx, unsignedTx, []wallet.PrevoutInfo{prevoutInfo},
+		)
+		require.NoError(t, err)
+		requireSameSigs(t, expectedPtx, signedTx)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+		require.NoError(t, err)
+		ptx.Inputs[0].WitnessUtxo = nil
+		unsignedTx, err := ptx.B64Encode()
+		require.NoError(t, err)
+
+		_, err = walletSvc.SignTransactionOffline(ctx, unsignedTx, nil)
+		require.ErrorContains(t, err, "missing prevout")
+
+		wrongPrevout := prevoutInfo
+		wrongPrevout.Amount++
+		_, err = walletSvc.SignTransactionOffline(
+			ctx, redeemTx, []wallet.PrevoutInfo{wrongPrevout},
+		)
+		require.ErrorContains(t, err, "doesn't match")
+	})
+}
+
+func requireSameSigs(t *testing.T, expectedPtx *psbt.Packet, signedTx string) {
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(signedTx), true)
+	require.NoError(t, err)
+
+	for i, in := range ptx.Inputs {
+		expectedSigs := expectedPtx.Inputs[i].TaprootScriptSpendSig
+		require.Len(t, in.TaprootScriptSpendSig, len(expectedSigs))
+		for j, sig := range in.TaprootScriptSpendSig {
+			require.True(t, bytes.Equal(expectedSigs[j].Signature, sig.Signature))
+		}
+	}
+}
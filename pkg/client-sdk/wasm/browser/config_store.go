@@ -44,6 +44,8 @@ type storeData struct {
 	UtxoMaxAmount              string `json:"utxo_max_amount"`
 	VtxoMinAmount              string `json:"vtxo_min_amount"`
 	VtxoMaxAmount              string `json:"vtxo_max_amount"`
+	SettleMinAmount            string `json:"settle_min_amount"`
+	SettleMaxAmount            string `json:"settle_max_amount"`
 }
 
 type configStore struct {
@@ -85,6 +87,8 @@ func (s *configStore) AddData(ctx context.Context, data types.Config) error {
 		UtxoMaxAmount:              fmt.Sprintf("%d", data.UtxoMaxAmount),
 		VtxoMinAmount:              fmt.Sprintf("%d", data.VtxoMinAmount),
 		VtxoMaxAmount:              fmt.Sprintf("%d", data.VtxoMaxAmount),
+		SettleMinAmount:            fmt.Sprintf("%d", data.SettleMinAmount),
+		SettleMaxAmount:            fmt.Sprintf("%d", data.SettleMaxAmount),
 	}
 	return s.writeData(sd)
 }
@@ -121,6 +125,8 @@ func (s *configStore) GetData(ctx context.Context) (*types.Config, error) {
 	utxoMaxAmount, _ := strconv.Atoi(s.store.Call("getItem", "utxo_max_amount").String())
 	vtxoMinAmount, _ := strconv.Atoi(s.store.Call("getItem", "vtxo_min_amount").String())
 	vtxoMaxAmount, _ := strconv.Atoi(s.store.Call("getItem", "vtxo_max_amount").String())
+	settleMinAmount, _ := strconv.Atoi(s.store.Call("getItem", "settle_min_amount").String())
+	settleMaxAmount, _ := strconv.Atoi(s.store.Call("getItem", "settle_max_amount").String())
 
 	vtxoTreeExpiryType := common.LocktimeTypeBlock
 	if vtxoTreeExpiry >= 512 {
@@ -160,6 +166,8 @@ func (s *configStore) GetData(ctx context.Context) (*types.Config, error) {
 		UtxoMaxAmount:              int64(utxoMaxAmount),
 		VtxoMinAmount:              int64(vtxoMinAmount),
 		VtxoMaxAmount:              int64(vtxoMaxAmount),
+		SettleMinAmount:            int64(settleMinAmount),
+		SettleMaxAmount:            int64(settleMaxAmount),
 	}, nil
 }
 
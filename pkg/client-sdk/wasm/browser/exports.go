@@ -43,6 +43,8 @@ func init() {
 	js.Global().Set("listVtxos", ListVtxosWrapper())
 	js.Global().Set("signTransaction", SignTransactionWrapper())
 	js.Global().Set("notifyIncomingFunds", NotifyIncomingFundsWrapper())
+	js.Global().Set("notifyIncomingFundsConfirmed", NotifyIncomingFundsConfirmedWrapper())
+	js.Global().Set("migrateServerKey", MigrateServerKeyWrapper())
 	js.Global().Set("reset", ResetWrapper())
 
 	js.Global().Set("getServerUrl", GetServerUrlWrapper())
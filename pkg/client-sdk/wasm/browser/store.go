@@ -32,6 +32,18 @@ func (s *localStorageStore) TransactionStore() types.TransactionStore {
 	return nil
 }
 
+func (s *localStorageStore) RecoveryStore() types.RecoveryStore {
+	return nil
+}
+
+func (s *localStorageStore) AddressStore() types.AddressStore {
+	return nil
+}
+
+func (s *localStorageStore) RoundSessionStore() types.RoundSessionStore {
+	return nil
+}
+
 func (s *localStorageStore) Clean(ctx context.Context) {
 	//nolint:all
 	s.configStore.CleanData(ctx)
@@ -288,6 +288,12 @@ func StartUnilateralExitWrapper() js.Func {
 	})
 }
 
+func MigrateServerKeyWrapper() js.Func {
+	return JSPromise(func(args []js.Value) (interface{}, error) {
+		return nil, arkSdkClient.MigrateServerKey(context.Background())
+	})
+}
+
 func CompleteUnilateralExitWrapper() js.Func {
 	return JSPromise(func(args []js.Value) (interface{}, error) {
 		if len(args) != 1 {
@@ -299,7 +305,7 @@ func CompleteUnilateralExitWrapper() js.Func {
 			return nil, err
 		}
 
-		txID, err := arkSdkClient.CompleteUnilateralExit(
+		txID, _, err := arkSdkClient.CompleteUnilateralExit(
 			context.Background(), to,
 		)
 		if err != nil {
@@ -569,6 +575,35 @@ func NotifyIncomingFundsWrapper() js.Func {
 	})
 }
 
+func NotifyIncomingFundsConfirmedWrapper() js.Func {
+	return JSPromise(func(args []js.Value) (interface{}, error) {
+		if len(args) != 2 {
+			return nil, fmt.Errorf("invalid number of args")
+		}
+
+		address := args[0].String()
+		minConf := uint32(args[1].Int())
+
+		incomingVtxos, incomingUtxos, err := arkSdkClient.NotifyIncomingFundsConfirmed(
+			context.Background(), address, minConf,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		rawList := map[string]interface{}{
+			"incomingVtxos": incomingVtxos,
+			"incomingUtxos": incomingUtxos,
+		}
+		result, err := json.Marshal(rawList)
+		if err != nil {
+			return nil, err
+		}
+
+		return js.ValueOf(string(result)), nil
+	})
+}
+
 func ResetWrapper() js.Func {
 	return js.FuncOf(func(this js.Value, p []js.Value) interface{} {
 		arkSdkClient.Reset(context.Background())
@@ -696,9 +731,10 @@ func transformEventForJS(event client.RoundEvent) map[string]interface{} {
 		}
 	case client.RoundFailedEvent:
 		return map[string]interface{}{
-			"type":   "failed",
-			"id":     e.ID,
-			"reason": e.Reason,
+			"type":      "failed",
+			"id":        e.ID,
+			"reason":    e.Reason,
+			"retryable": e.Retryable,
 		}
 	case client.RoundSigningStartedEvent:
 		return map[string]interface{}{
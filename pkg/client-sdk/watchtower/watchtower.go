@@ -0,0 +1,312 @@
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultInterval = 10 * time.Second
+
+// VtxoSource returns the vtxos the watchtower must protect, usually the
+// spendable vtxos of the wallet.
+type VtxoSource func(ctx context.Context) ([]client.Vtxo, error)
+
+// Alert is emitted when the input of the redeem tx of a watched vtxo is found
+// onchain and not spent by the redeem tx, meaning that its previous owner is
+// trying to unilaterally exit a vtxo already spent offchain.
+type Alert struct {
+	// Vtxo is the watched vtxo whose funds are at risk.
+	Vtxo client.Vtxo
+	// Input is the outpoint, spent by the redeem tx of the vtxo, found onchain.
+	Input client.Outpoint
+	// ResponseTxid is the txid of the redeem tx broadcasted in response, set
+	// only if auto-respond is enabled and the broadcast succeeded.
+	ResponseTxid string
+	// Err is set if the response failed or if the input has already been spent
+	// by a tx other than the redeem one.
+	Err error
+}
+
+// Watchtower monitors the explorer for fraudulent exits of the inputs of the
+// redeem txs of the watched vtxos. Such vtxos are secured only by their redeem
+// tx being broadcasted before the exit delay of the inputs expires: if
+// auto-respond is enabled, the watchtower finalizes and broadcasts the redeem
+// tx as soon as any of its inputs shows up onchain, otherwise it only alerts.
+type Watchtower struct {
+	explorer    explorer.Explorer
+	listVtxos   VtxoSource
+	interval    time.Duration
+	autoRespond bool
+
+	alerts chan Alert
+	// alerted keeps track of the inputs already reported to not flood the
+	// alerts channel at every check.
+	alerted map[string]struct{}
+	lock    *sync.Mutex
+	cancel  context.CancelFunc
+}
+
+// New returns a watchtower checking the vtxos returned by listVtxos every
+// interval. A non-positive interval defaults to 10 seconds.
+func New(
+	explorerSvc explorer.Explorer, listVtxos VtxoSource,
+	interval time.Duration, autoRespond bool,
+) (*Watchtower, error) {
+	if explorerSvc == nil {
+		return nil, fmt.Errorf("missing explorer")
+	}
+	if listVtxos == nil {
+		return nil, fmt.Errorf("missing vtxo source")
+	}
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	return &Watchtower{
+		explorer:    explorerSvc,
+		listVtxos:   listVtxos,
+		interval:    interval,
+		autoRespond: autoRespond,
+		alerts:      make(chan Alert, 64),
+		alerted:     make(map[string]struct{}),
+		lock:        &sync.Mutex{},
+	}, nil
+}
+
+// Alerts returns the channel where the watchtower publishes its alerts.
+func (w *Watchtower) Alerts() <-chan Alert {
+	return w.alerts
+}
+
+// Start runs the checks in background until Stop is called or the given
+// context is done.
+func (w *Watchtower) Start(ctx context.Context) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.cancel != nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	w.cancel = cancel
+
+	go func() {
+		ticker := time.NewTicker(w.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				alerts, err := w.Check(ctx)
+				if err != nil {
+					log.WithError(err).Warn("watchtower: failed to check vtxos")
+					continue
+				}
+				for _, alert := range alerts {
+					select {
+					case w.alerts <- alert:
+					default:
+						log.Warnf(
+							"watchtower: alerts channel full, dropped alert for vtxo %s",
+							alert.Vtxo.String(),
+						)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the background checks.
+func (w *Watchtower) Stop() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.cancel != nil {
+		w.cancel()
+		w.cancel = nil
+	}
+}
+
+// Check runs a single check of the watched vtxos and returns the new alerts.
+func (w *Watchtower) Check(ctx context.Context) ([]Alert, error) {
+	vtxos, err := w.listVtxos(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := make([]Alert, 0)
+	for _, vtxo := range vtxos {
+		// only vtxos created by a redeem tx not yet onchain can be stolen by
+		// the previous owners of the inputs
+		if len(vtxo.RedeemTx) <= 0 || vtxo.Spent {
+			continue
+		}
+
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(vtxo.RedeemTx), true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse redeem tx of vtxo %s: %s", vtxo.String(), err)
+		}
+		redeemTxid := ptx.UnsignedTx.TxHash().String()
+		if _, err := w.explorer.GetTxHex(redeemTxid); err == nil {
+			// the redeem tx is already onchain, the vtxo is safe
+			continue
+		}
+
+		for _, in := range ptx.UnsignedTx.TxIn {
+			input := client.Outpoint{
+				Txid: in.PreviousOutPoint.Hash.String(),
+				VOut: in.PreviousOutPoint.Index,
+			}
+			if w.isAlerted(vtxo, input) {
+				continue
+			}
+
+			outspend, err := w.getOnchainOutspend(input)
+			if err != nil {
+				log.WithError(err).Debugf("watchtower: failed to check input %s", input.String())
+				continue
+			}
+			if outspend == nil || outspend.Txid == redeemTxid {
+				continue
+			}
+
+			alert := Alert{Vtxo: vtxo, Input: input}
+			if outspend.Spent {
+				// too late to react, the input has been spent by another tx
+				alert.Err = fmt.Errorf("input %s already spent by tx %s", input.String(), outspend.Txid)
+			} else if w.autoRespond {
+				alert.ResponseTxid, alert.Err = w.respond(vtxo.RedeemTx)
+			}
+			// a failed response is retried at the next check
+			if alert.Err == nil || outspend.Spent {
+				w.setAlerted(vtxo, input)
+			}
+			alerts = append(alerts, alert)
+
+			// the redeem tx spends all the inputs at once
+			break
+		}
+	}
+
+	return alerts, nil
+}
+
+// getOnchainOutspend returns the spending status of the given outpoint, or
+// nil if its tx is not onchain.
+func (w *Watchtower) getOnchainOutspend(outpoint client.Outpoint) (*explorer.Outspend, error) {
+	if _, err := w.explorer.GetTxHex(outpoint.Txid); err != nil {
+		// the tx is not onchain (or can't be fetched), nothing to react to yet
+		return nil, nil
+	}
+	return w.explorer.GetOutspend(outpoint.Txid, outpoint.VOut)
+}
+
+func (w *Watchtower) respond(redeemTx string) (string, error) {
+	txHex, err := finalizeRedeemTx(redeemTx)
+	if err != nil {
+		return "", fmt.Errorf("failed to finalize redeem tx: %s", err)
+	}
+
+	txid, err := w.explorer.Broadcast(txHex)
+	if err != nil {
+		return "", fmt.Errorf("failed to broadcast redeem tx: %s", err)
+	}
+	log.Infof("watchtower: broadcasted redeem tx %s", txid)
+	return txid, nil
+}
+
+func (w *Watchtower) isAlerted(vtxo client.Vtxo, input client.Outpoint) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	_, ok := w.alerted[alertKey(vtxo, input)]
+	return ok
+}
+
+func (w *Watchtower) setAlerted(vtxo client.Vtxo, input client.Outpoint) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.alerted[alertKey(vtxo, input)] = struct{}{}
+}
+
+func alertKey(vtxo client.Vtxo, input client.Outpoint) string {
+	return fmt.Sprintf("%s-%s", vtxo.String(), input.String())
+}
+
+// finalizeRedeemTx turns the given fully signed redeem tx into a raw tx ready
+// to be broadcasted, by building the witness of every input from the signed
+// tapscript leaf.
+func finalizeRedeemTx(redeemTx string) (string, error) {
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+	if err != nil {
+		return "", err
+	}
+
+	for i, in := range ptx.Inputs {
+		if in.WitnessUtxo == nil || !txscript.IsPayToTaproot(in.WitnessUtxo.PkScript) ||
+			len(in.TaprootLeafScript) <= 0 {
+			return "", fmt.Errorf("input %d is not a signed tapscript spend", i)
+		}
+
+		leaf := in.TaprootLeafScript[0]
+		closure, err := tree.DecodeClosure(leaf.Script)
+		if err != nil {
+			return "", err
+		}
+
+		args := make(map[string][]byte)
+		conditionWitness, err := tree.GetConditionWitness(in)
+		if err != nil {
+			return "", err
+		}
+		if len(conditionWitness) > 0 {
+			var buf bytes.Buffer
+			if err := psbt.WriteTxWitness(&buf, conditionWitness); err != nil {
+				return "", err
+			}
+			args[tree.ConditionWitnessKey] = buf.Bytes()
+		}
+		for _, sig := range in.TaprootScriptSpendSig {
+			args[hex.EncodeToString(sig.XOnlyPubKey)] = sig.Signature
+		}
+
+		witness, err := closure.Witness(leaf.ControlBlock, args)
+		if err != nil {
+			return "", err
+		}
+
+		var witnessBuf bytes.Buffer
+		if err := psbt.WriteTxWitness(&witnessBuf, witness); err != nil {
+			return "", err
+		}
+		ptx.Inputs[i].FinalScriptWitness = witnessBuf.Bytes()
+	}
+
+	tx, err := psbt.Extract(ptx)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tx.Serialize(&buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf.Bytes()), nil
+}
@@ -0,0 +1,261 @@
+package watchtower
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/pkg/client-sdk/client"
+	"github.com/ark-network/ark/pkg/client-sdk/explorer"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchtower(t *testing.T) {
+	ctx := context.Background()
+	redeemTx, prevout := makeRedeemTx(t)
+	input := client.Outpoint{Txid: prevout.Hash.String(), VOut: prevout.Index}
+	vtxo := client.Vtxo{
+		Outpoint:  client.Outpoint{Txid: chainhash.HashH([]byte("vtxo")).String()},
+		Amount:    9000,
+		RedeemTx:  redeemTx,
+		IsPending: true,
+	}
+	listVtxos := func(context.Context) ([]client.Vtxo, error) {
+		return []client.Vtxo{vtxo}, nil
+	}
+
+	t.Run("no fraud", func(t *testing.T) {
+		explorerSvc := newMockedExplorer()
+		wt, err := New(explorerSvc, listVtxos, 0, true)
+		require.NoError(t, err)
+
+		alerts, err := wt.Check(ctx)
+		require.NoError(t, err)
+		require.Empty(t, alerts)
+		require.Empty(t, explorerSvc.broadcasted)
+	})
+
+	t.Run("alert", func(t *testing.T) {
+		explorerSvc := newMockedExplorer()
+		explorerSvc.txs[input.Txid] = "00"
+		wt, err := New(explorerSvc, listVtxos, 0, false)
+		require.NoError(t, err)
+
+		alerts, err := wt.Check(ctx)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		require.Equal(t, vtxo, alerts[0].Vtxo)
+		require.Equal(t, input, alerts[0].Input)
+		require.Empty(t, alerts[0].ResponseTxid)
+		require.NoError(t, alerts[0].Err)
+		require.Empty(t, explorerSvc.broadcasted)
+
+		// the same fraud is reported only once
+		alerts, err = wt.Check(ctx)
+		require.NoError(t, err)
+		require.Empty(t, alerts)
+	})
+
+	t.Run("auto respond", func(t *testing.T) {
+		explorerSvc := newMockedExplorer()
+		explorerSvc.txs[input.Txid] = "00"
+		wt, err := New(explorerSvc, listVtxos, 0, true)
+		require.NoError(t, err)
+
+		alerts, err := wt.Check(ctx)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		require.NoError(t, alerts[0].Err)
+		require.Len(t, explorerSvc.broadcasted, 1)
+
+		ptx, err := psbt.NewFromRawBytes(bytes.NewBufferString(redeemTx), true)
+		require.NoError(t, err)
+		require.Equal(t, ptx.UnsignedTx.TxHash().String(), alerts[0].ResponseTxid)
+
+		// once the redeem tx is onchain the vtxo is safe
+		alerts, err = wt.Check(ctx)
+		require.NoError(t, err)
+		require.Empty(t, alerts)
+	})
+
+	t.Run("too late", func(t *testing.T) {
+		explorerSvc := newMockedExplorer()
+		explorerSvc.txs[input.Txid] = "00"
+		explorerSvc.outspends[input.String()] = &explorer.Outspend{
+			Spent: true, Txid: chainhash.HashH([]byte("exit")).String(),
+		}
+		wt, err := New(explorerSvc, listVtxos, 0, true)
+		require.NoError(t, err)
+
+		alerts, err := wt.Check(ctx)
+		require.NoError(t, err)
+		require.Len(t, alerts, 1)
+		require.ErrorContains(t, alerts[0].Err, "already spent")
+		require.Empty(t, explorerSvc.broadcasted)
+	})
+}
+
+func TestFinalizeRedeemTx(t *testing.T) {
+	redeemTx, prevout := makeRedeemTx(t)
+
+	txHex, err := finalizeRedeemTx(redeemTx)
+	require.NoError(t, err)
+
+	buf, err := hex.DecodeString(txHex)
+	require.NoError(t, err)
+	var tx wire.MsgTx
+	require.NoError(t, tx.Deserialize(bytes.NewReader(buf)))
+
+	// the finalized tx satisfies the script of the spent vtxo
+	ptx, err := psbt.NewFromRawBytes(bytes.NewBufferString(redeemTx), true)
+	require.NoError(t, err)
+	prevoutFetcher := txscript.NewCannedPrevOutputFetcher(
+		ptx.Inputs[0].WitnessUtxo.PkScript, ptx.Inputs[0].WitnessUtxo.Value,
+	)
+	require.Equal(t, prevout, tx.TxIn[0].PreviousOutPoint)
+
+	engine, err := txscript.NewEngine(
+		ptx.Inputs[0].WitnessUtxo.PkScript, &tx, 0, txscript.StandardVerifyFlags,
+		nil, txscript.NewTxSigHashes(&tx, prevoutFetcher), ptx.Inputs[0].WitnessUtxo.Value,
+		prevoutFetcher,
+	)
+	require.NoError(t, err)
+	require.NoError(t, engine.Execute())
+
+	// a redeem tx missing a signature can't be finalized
+	ptx.Inputs[0].TaprootScriptSpendSig = ptx.Inputs[0].TaprootScriptSpendSig[:1]
+	b64, err := ptx.B64Encode()
+	require.NoError(t, err)
+	_, err = finalizeRedeemTx(b64)
+	require.Error(t, err)
+}
+
+// makeRedeemTx returns a redeem tx spending a vtxo with the collaborative
+// path, signed by both the owner and the server, along with the spent vtxo.
+func makeRedeemTx(t *testing.T) (string, wire.OutPoint) {
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	vtxoScript := tree.NewDefaultVtxoScript(ownerKey.PubKey(), serverKey.PubKey(), exitDelay)
+	tapscripts, err := vtxoScript.Encode()
+	require.NoError(t, err)
+	_, vtxoTapTree, err := vtxoScript.TapTree()
+	require.NoError(t, err)
+
+	forfeitClosure := vtxoScript.ForfeitClosures()[0]
+	forfeitScript, err := forfeitClosure.Script()
+	require.NoError(t, err)
+	forfeitLeaf := txscript.NewBaseTapLeaf(forfeitScript)
+	leafProof, err := vtxoTapTree.GetTaprootMerkleProof(forfeitLeaf.TapHash())
+	require.NoError(t, err)
+	ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
+	require.NoError(t, err)
+
+	prevout := wire.OutPoint{Hash: chainhash.HashH([]byte("input")), Index: 1}
+	receiverScript, err := common.P2TRScript(ownerKey.PubKey())
+	require.NoError(t, err)
+
+	redeemTx, err := tree.BuildRedeemTx(
+		[]common.VtxoInput{{
+			Outpoint: &prevout,
+			Amount:   10000,
+			Tapscript: &waddrmgr.Tapscript{
+				RevealedScript: leafProof.Script,
+				ControlBlock:   ctrlBlock,
+			},
+			RevealedTapscripts: tapscripts,
+		}},
+		[]*wire.TxOut{{Value: 9000, PkScript: receiverScript}},
+	)
+	require.NoError(t, err)
+
+	ptx, err := psbt.NewFromRawBytes(bytes.NewBufferString(redeemTx), true)
+	require.NoError(t, err)
+
+	prevoutFetcher := txscript.NewCannedPrevOutputFetcher(
+		ptx.Inputs[0].WitnessUtxo.PkScript, ptx.Inputs[0].WitnessUtxo.Value,
+	)
+	sighash, err := txscript.CalcTapscriptSignaturehash(
+		txscript.NewTxSigHashes(ptx.UnsignedTx, prevoutFetcher),
+		txscript.SigHashDefault, ptx.UnsignedTx, 0, prevoutFetcher, forfeitLeaf,
+	)
+	require.NoError(t, err)
+
+	leafHash := forfeitLeaf.TapHash()
+	for _, signer := range []*secp256k1.PrivateKey{ownerKey, serverKey} {
+		sig, err := schnorr.Sign(signer, sighash)
+		require.NoError(t, err)
+		ptx.Inputs[0].TaprootScriptSpendSig = append(
+			ptx.Inputs[0].TaprootScriptSpendSig, &psbt.TaprootScriptSpendSig{
+				XOnlyPubKey: schnorr.SerializePubKey(signer.PubKey()),
+				LeafHash:    leafHash[:],
+				Signature:   sig.Serialize(),
+				SigHash:     txscript.SigHashDefault,
+			},
+		)
+	}
+
+	b64, err := ptx.B64Encode()
+	require.NoError(t, err)
+	return b64, prevout
+}
+
+type mockedExplorer struct {
+	explorer.Explorer
+	txs         map[string]string
+	outspends   map[string]*explorer.Outspend
+	broadcasted []string
+}
+
+func newMockedExplorer() *mockedExplorer {
+	return &mockedExplorer{
+		txs:       make(map[string]string),
+		outspends: make(map[string]*explorer.Outspend),
+	}
+}
+
+func (e *mockedExplorer) GetTxHex(txid string) (string, error) {
+	txHex, ok := e.txs[txid]
+	if !ok {
+		return "", fmt.Errorf("tx %s not found", txid)
+	}
+	return txHex, nil
+}
+
+func (e *mockedExplorer) GetOutspend(txid string, vout uint32) (*explorer.Outspend, error) {
+	outpoint := client.Outpoint{Txid: txid, VOut: vout}
+	if outspend, ok := e.outspends[outpoint.String()]; ok {
+		return outspend, nil
+	}
+	return &explorer.Outspend{}, nil
+}
+
+func (e *mockedExplorer) Broadcast(txHex string) (string, error) {
+	buf, err := hex.DecodeString(txHex)
+	if err != nil {
+		return "", err
+	}
+	var tx wire.MsgTx
+	if err := tx.Deserialize(bytes.NewReader(buf)); err != nil {
+		return "", err
+	}
+
+	txid := tx.TxHash().String()
+	e.txs[txid] = txHex
+	e.broadcasted = append(e.broadcasted, txid)
+	return txid, nil
+}
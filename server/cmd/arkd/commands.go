@@ -3,6 +3,7 @@ package main
 import (
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -10,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -43,6 +45,10 @@ var (
 		Usage: "quantity of notes to create",
 		Value: 1,
 	}
+	notBeforeFlag = &cli.Int64Flag{
+		Name:  flagNotBefore,
+		Usage: "unix timestamp before which the notes can't be redeemed",
+	}
 	requestIdsFlag = func(required bool) *cli.StringSliceFlag {
 		return &cli.StringSliceFlag{
 			Name:     flagRequestIds,
@@ -60,6 +66,23 @@ var (
 		Usage:    "address to withdraw to",
 		Required: true,
 	}
+	afterFlag = &cli.Int64Flag{
+		Name:  flagAfter,
+		Usage: "unix timestamp of the start of the period",
+	}
+	beforeFlag = &cli.Int64Flag{
+		Name:  flagBefore,
+		Usage: "unix timestamp of the end of the period, defaults to now",
+	}
+	formatFlag = &cli.StringFlag{
+		Name:  flagFormat,
+		Usage: "format of the report, json or csv",
+		Value: "json",
+	}
+	outputFlag = &cli.StringFlag{
+		Name:  flagOutput,
+		Usage: "path of the file where to write the report, defaults to stdout",
+	}
 )
 
 // commands
@@ -74,6 +97,7 @@ var (
 			walletUnlockCmd,
 			walletAddressCmd,
 			walletBalanceCmd,
+			walletLiquidityCmd,
 			createNoteCmd,
 			walletWithdrawCmd,
 		),
@@ -105,11 +129,16 @@ var (
 		Usage:  "Get the wallet balance",
 		Action: walletBalanceAction,
 	}
+	walletLiquidityCmd = &cli.Command{
+		Name:   "liquidity",
+		Usage:  "Get the wallet balance not committed to any round in flight",
+		Action: walletLiquidityAction,
+	}
 	createNoteCmd = &cli.Command{
 		Name:   "note",
 		Usage:  "Create a credit note",
 		Action: createNoteAction,
-		Flags:  []cli.Flag{amountFlag, quantityFlag},
+		Flags:  []cli.Flag{amountFlag, quantityFlag, notBeforeFlag},
 	}
 	queueCmd = &cli.Command{
 		Name:  "queue",
@@ -144,6 +173,12 @@ var (
 		Action: walletWithdrawAction,
 		Flags:  []cli.Flag{withdrawAmountFlag, withdrawAddressFlag},
 	}
+	accountingCmd = &cli.Command{
+		Name:   "accounting",
+		Usage:  "Export the accounting report of the rounds and vtxos of a period",
+		Action: accountingAction,
+		Flags:  []cli.Flag{afterFlag, beforeFlag, formatFlag, outputFlag},
+	}
 )
 
 var timeout = time.Minute
@@ -261,17 +296,37 @@ func walletBalanceAction(ctx *cli.Context) error {
 	return nil
 }
 
+func walletLiquidityAction(ctx *cli.Context) error {
+	baseURL := ctx.String(flagURL)
+	macaroon, tlsCertPath, err := getCredentialPaths(ctx)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/v1/admin/liquidity", baseURL)
+	liquidity, err := getLiquidityStatus(url, macaroon, tlsCertPath)
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(liquidity)
+	return nil
+}
+
 func createNoteAction(ctx *cli.Context) error {
 	baseURL := ctx.String(flagURL)
 	amount := ctx.Uint(flagAmount)
 	quantity := ctx.Uint(flagQuantity)
+	notBefore := ctx.Int64(flagNotBefore)
 	macaroon, tlsCertPath, err := getCredentialPaths(ctx)
 	if err != nil {
 		return err
 	}
 
 	url := fmt.Sprintf("%s/v1/admin/note", baseURL)
-	body := fmt.Sprintf(`{"amount": %d, "quantity": %d}`, amount, quantity)
+	body := fmt.Sprintf(
+		`{"amount": %d, "quantity": %d, "notBefore": %d}`, amount, quantity, notBefore,
+	)
 
 	notes, err := post[[]string](url, body, "notes", macaroon, tlsCertPath)
 	if err != nil {
@@ -481,6 +536,70 @@ func getBalance(url, macaroon, tlsCert string) (*balance, error) {
 	return result, nil
 }
 
+// zero values are omitted in the response
+type liquidityStatus struct {
+	AvailableBalance  string `json:"availableBalance"`
+	ReservedLiquidity string `json:"reservedLiquidity"`
+	FreeLiquidity     string `json:"freeLiquidity"`
+	Threshold         string `json:"threshold"`
+	Low               bool   `json:"low"`
+}
+
+func (l liquidityStatus) String() string {
+	orZero := func(amount string) string {
+		if len(amount) <= 0 {
+			return "0"
+		}
+		return amount
+	}
+	return fmt.Sprintf(
+		"available balance: %s\nreserved liquidity: %s\nfree liquidity: %s\nthreshold: %s\nlow: %t",
+		orZero(l.AvailableBalance), orZero(l.ReservedLiquidity),
+		orZero(l.FreeLiquidity), orZero(l.Threshold), l.Low,
+	)
+}
+
+func getLiquidityStatus(url, macaroon, tlsCert string) (*liquidityStatus, error) {
+	tlsConfig, err := getTLSConfig(tlsCert)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	if len(macaroon) > 0 {
+		req.Header.Add("X-Macaroon", macaroon)
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:all
+	defer resp.Body.Close()
+
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get liquidity status: %s", string(buf))
+	}
+
+	result := &liquidityStatus{}
+	if err := json.Unmarshal(buf, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 type status struct {
 	Initialized bool `json:"initialized"`
 	Unlocked    bool `json:"unlocked"`
@@ -638,3 +757,164 @@ func clearTxRequestQueueAction(ctx *cli.Context) error {
 	fmt.Println("Successfully cleared tx request queue")
 	return nil
 }
+
+func accountingAction(ctx *cli.Context) error {
+	baseURL := ctx.String(flagURL)
+	after := ctx.Int64(flagAfter)
+	before := ctx.Int64(flagBefore)
+	format := ctx.String(flagFormat)
+	if format != "json" && format != "csv" {
+		return fmt.Errorf("invalid format %s, must be json or csv", format)
+	}
+	macaroon, tlsCertPath, err := getCredentialPaths(ctx)
+	if err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("%s/v1/admin/accounting?after=%d&before=%d", baseURL, after, before)
+	report, err := getAccountingReport(url, macaroon, tlsCertPath)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if path := ctx.String(flagOutput); path != "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %s", err)
+		}
+		// nolint:all
+		defer file.Close()
+		out = file
+	}
+
+	if format == "csv" {
+		return report.writeCSV(out)
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+type roundAccounting struct {
+	RoundId                 string `json:"roundId"`
+	Txid                    string `json:"txid"`
+	Timestamp               int64  `json:"timestamp,string"`
+	BoardingAmount          uint64 `json:"boardingAmount,string"`
+	NotesAmount             uint64 `json:"notesAmount,string"`
+	ForfeitedAmount         uint64 `json:"forfeitedAmount,string"`
+	VtxosAmount             uint64 `json:"vtxosAmount,string"`
+	CollaborativeExitAmount uint64 `json:"collaborativeExitAmount,string"`
+	FeesAmount              uint64 `json:"feesAmount,string"`
+}
+
+type accountingReport struct {
+	After                   int64             `json:"after,string"`
+	Before                  int64             `json:"before,string"`
+	OpeningBalance          uint64            `json:"openingBalance,string"`
+	ClosingBalance          uint64            `json:"closingBalance,string"`
+	CreatedAmount           uint64            `json:"createdAmount,string"`
+	SpentAmount             uint64            `json:"spentAmount,string"`
+	SweptAmount             uint64            `json:"sweptAmount,string"`
+	UnilateralExitAmount    uint64            `json:"unilateralExitAmount,string"`
+	BoardingAmount          uint64            `json:"boardingAmount,string"`
+	NotesAmount             uint64            `json:"notesAmount,string"`
+	CollaborativeExitAmount uint64            `json:"collaborativeExitAmount,string"`
+	FeesAmount              uint64            `json:"feesAmount,string"`
+	Rounds                  []roundAccounting `json:"rounds"`
+}
+
+// writeCSV writes the summary of the period as key/value records, followed
+// by an empty line and the table of the rounds. Amounts are in sats.
+func (r accountingReport) writeCSV(w io.Writer) error {
+	formatTime := func(timestamp int64) string {
+		return time.Unix(timestamp, 0).UTC().Format(time.RFC3339)
+	}
+	formatAmount := func(amount uint64) string {
+		return strconv.FormatUint(amount, 10)
+	}
+
+	writer := csv.NewWriter(w)
+	records := [][]string{
+		{"entry", "value"},
+		{"period_start", formatTime(r.After)},
+		{"period_end", formatTime(r.Before)},
+		{"opening_balance", formatAmount(r.OpeningBalance)},
+		{"created_amount", formatAmount(r.CreatedAmount)},
+		{"spent_amount", formatAmount(r.SpentAmount)},
+		{"swept_amount", formatAmount(r.SweptAmount)},
+		{"unilateral_exit_amount", formatAmount(r.UnilateralExitAmount)},
+		{"closing_balance", formatAmount(r.ClosingBalance)},
+		{"boarding_amount", formatAmount(r.BoardingAmount)},
+		{"notes_amount", formatAmount(r.NotesAmount)},
+		{"collaborative_exit_amount", formatAmount(r.CollaborativeExitAmount)},
+		{"fees_amount", formatAmount(r.FeesAmount)},
+	}
+	if err := writer.WriteAll(records); err != nil {
+		return err
+	}
+	// csv.Writer can't write empty records
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return err
+	}
+
+	records = [][]string{{
+		"round_id", "txid", "time", "boarding_amount", "notes_amount",
+		"forfeited_amount", "vtxos_amount", "collaborative_exit_amount", "fees_amount",
+	}}
+	for _, round := range r.Rounds {
+		records = append(records, []string{
+			round.RoundId,
+			round.Txid,
+			formatTime(round.Timestamp),
+			formatAmount(round.BoardingAmount),
+			formatAmount(round.NotesAmount),
+			formatAmount(round.ForfeitedAmount),
+			formatAmount(round.VtxosAmount),
+			formatAmount(round.CollaborativeExitAmount),
+			formatAmount(round.FeesAmount),
+		})
+	}
+	return writer.WriteAll(records)
+}
+
+func getAccountingReport(url, macaroon, tlsCert string) (*accountingReport, error) {
+	tlsConfig, err := getTLSConfig(tlsCert)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", "application/json")
+	if len(macaroon) > 0 {
+		req.Header.Add("X-Macaroon", macaroon)
+	}
+	client := &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			TLSClientConfig: tlsConfig,
+		},
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	// nolint:all
+	defer resp.Body.Close()
+
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get accounting report: %s", string(buf))
+	}
+
+	report := &accountingReport{}
+	if err := json.Unmarshal(buf, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
@@ -29,9 +29,14 @@ const (
 	flagGapLimit        = "addr-gap-limit"
 	flagAmount          = "amount"
 	flagQuantity        = "quantity"
+	flagNotBefore       = "not-before"
 	flagWithdrawAmount  = "amount"
 	flagWithdrawAddress = "address"
 	flagRequestIds      = "ids"
+	flagAfter           = "after"
+	flagBefore          = "before"
+	flagFormat          = "format"
+	flagOutput          = "output"
 )
 
 // flags
@@ -98,7 +103,7 @@ func main() {
 	app.Version = Version
 	app.Name = "Arkd CLI"
 	app.Usage = "arkd command line interface"
-	app.Commands = append(app.Commands, walletCmd, queueCmd)
+	app.Commands = append(app.Commands, walletCmd, queueCmd, accountingCmd)
 	app.Action = mainAction
 	app.Flags = append(app.Flags, urlFlag, datadirFlag)
 
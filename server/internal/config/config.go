@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -32,6 +33,9 @@ var (
 		"badger": {},
 		"sqlite": {},
 	}
+	supportedNoteDbs = supportedType{
+		"postgres": {},
+	}
 	supportedSchedulers = supportedType{
 		"gocron": {},
 		"block":  {},
@@ -64,6 +68,8 @@ type Config struct {
 
 	DbType              string
 	EventDbType         string
+	NoteDbType          string
+	NoteDbUrl           string
 	DbDir               string
 	EventDbDir          string
 	RoundInterval       int64
@@ -102,6 +108,53 @@ type Config struct {
 	UtxoMinAmount             int64
 	VtxoMaxAmount             int64
 	VtxoMinAmount             int64
+	SettleMaxAmount           int64
+	SettleMinAmount           int64
+
+	MaxSubscriptionsPerClient int64
+	MaxSubscriptions          int64
+	MaxInputsPerSweepTx       int64
+
+	// MaxConcurrentConfirmationChecks is the max number of txs of a vtxo tree
+	// whose onchain state is checked at the same time when looking for the
+	// outputs to sweep
+	MaxConcurrentConfirmationChecks int64
+
+	StuckRoundThresholds map[application.RoundPhase]time.Duration
+	StuckRoundWebhookUrl string
+
+	OfflineCosignerPolicy application.OfflineCosignerPolicy
+
+	MaxConcurrentRounds int64
+
+	RequireSameBoardingOwner bool
+
+	TxRequestPingGap   time.Duration
+	TxRequestDeleteGap time.Duration
+
+	RoundTimeout time.Duration
+
+	MempoolAncestorLimit int64
+
+	SinglePartyFastMode bool
+
+	LowLiquidityThreshold uint64
+
+	AutoRefreshMargin time.Duration
+
+	MaxReceiversPerRequest int64
+
+	CollaborativeExitScriptTypes []application.ExitScriptType
+	CollaborativeExitAddresses   []string
+
+	NoteDenominations []uint32
+	NoteExpiry        time.Duration
+
+	ShutdownTimeout time.Duration
+
+	// EventPublisher is notified of the round events, it's not loaded from the
+	// environment and must be set by the program embedding the server.
+	EventPublisher application.EventPublisher
 
 	repo      ports.RepoManager
 	svc       application.Service
@@ -155,6 +208,72 @@ var (
 	VtxoMaxAmount             = "VTXO_MAX_AMOUNT"
 	UtxoMinAmount             = "UTXO_MIN_AMOUNT"
 	VtxoMinAmount             = "VTXO_MIN_AMOUNT"
+	SettleMaxAmount           = "SETTLE_MAX_AMOUNT"
+	SettleMinAmount           = "SETTLE_MIN_AMOUNT"
+	MaxSubscriptionsPerClient = "MAX_SUBSCRIPTIONS_PER_CLIENT"
+	MaxSubscriptions          = "MAX_SUBSCRIPTIONS"
+	MaxInputsPerSweepTx       = "MAX_INPUTS_PER_SWEEP_TX"
+	// max number of txs of a vtxo tree whose onchain state is checked at the
+	// same time when looking for the outputs to sweep
+	MaxConcurrentConfirmationChecks = "MAX_CONCURRENT_CONFIRMATION_CHECKS"
+	// comma separated list of <phase>=<duration>, eg. tree_signing=30s
+	StuckRoundThresholds = "STUCK_ROUND_THRESHOLDS"
+	StuckRoundWebhookUrl = "STUCK_ROUND_WEBHOOK_URL"
+	// either "fail" or "drop" the tx requests of cosigners going offline between
+	// the submission of tree nonces and signatures
+	OfflineCosignerPolicy = "OFFLINE_COSIGNER_POLICY"
+	// max number of rounds in flight at the same time, the registration of a
+	// new round starts while the previous ones are being finalized
+	MaxConcurrentRounds = "MAX_CONCURRENT_ROUNDS"
+	// if true, the outputs of a tx request spending boarding inputs must be
+	// owned by the owner of those inputs
+	RequireSameBoardingOwner = "REQUIRE_SAME_BOARDING_OWNER"
+	// tx requests without a ping for longer than the ping gap are not selected
+	// for the next round, and they're deleted after the delete gap unless
+	// they're being signed in a round
+	TxRequestPingGap   = "TX_REQUEST_PING_GAP"
+	TxRequestDeleteGap = "TX_REQUEST_DELETE_GAP"
+	// max duration of a round, from the start of its registration, after which
+	// it's aborted whatever phase it's stuck in, 0 means twice the round interval
+	RoundTimeout = "ROUND_TIMEOUT"
+	// max number of unconfirmed ancestors of a redeem tx, including the tx
+	// itself, for it to be broadcastable in case of unilateral exit, 0 means
+	// no check (bitcoin core default limit is 25)
+	MempoolAncestorLimit = "MEMPOOL_ANCESTOR_LIMIT"
+	// if true, the registration stage of a round ends as soon as a tx request
+	// is ready instead of lasting a sixth of the round interval, meant for
+	// servers used by a single participant. Only the registration stage is
+	// shortened, the other stages of the round are unchanged
+	SinglePartyFastMode = "SINGLE_PARTY_FAST_MODE"
+	// amount in sats of the wallet balance not committed to any round in flight
+	// under which the server warns it's low on liquidity, 0 means no warning
+	LowLiquidityThreshold = "LOW_LIQUIDITY_THRESHOLD"
+	// how long before their expiry the vtxos whose owners authorized the server
+	// to refresh them are registered for a round on their behalf, 0 means the
+	// server doesn't accept refresh authorizations
+	AutoRefreshMargin = "AUTO_REFRESH_MARGIN"
+	// max number of outputs of a single tx request, to bound the width of the
+	// vtxo tree, 0 means no limit
+	MaxReceiversPerRequest = "MAX_RECEIVERS_PER_REQUEST"
+	// space separated lists of the script types (p2pkh, p2sh, p2wpkh, p2wsh,
+	// p2tr) and of the addresses collaborative exits can send funds to, empty
+	// means no restriction
+	CollaborativeExitScriptTypes = "COLLABORATIVE_EXIT_SCRIPT_TYPES"
+	CollaborativeExitAddresses   = "COLLABORATIVE_EXIT_ADDRESSES"
+	// space separated list of the amounts in sats notes can be created with,
+	// empty means any amount
+	NoteDenominations = "NOTE_DENOMINATIONS"
+	// how long the created notes can be redeemed for, 0 means forever
+	NoteExpiry = "NOTE_EXPIRY"
+	// how long the rounds in flight can take to end at shutdown before being
+	// aborted
+	ShutdownTimeout = "SHUTDOWN_TIMEOUT"
+	// db storing the redeemed notes, instead of the one of DB_TYPE, so that
+	// several servers behind a load balancer can share them. Empty means the
+	// notes are stored with the rest of the data
+	NoteDbType = "NOTE_DB_TYPE"
+	// connection string of the notes db, required if NOTE_DB_TYPE is set
+	NoteDbUrl = "NOTE_DB_URL"
 
 	defaultDatadir             = common.AppDataDir("arkd", false)
 	defaultRoundInterval       = 30
@@ -179,9 +298,28 @@ var (
 	defaultUtxoMinAmount       = -1 // -1 means native dust limit (default)
 	defaultVtxoMinAmount       = -1 // -1 means native dust limit (default)
 	defaultVtxoMaxAmount       = -1 // -1 means no limit (default)
+	defaultSettleMaxAmount     = -1 // <= 0 means no limit (default)
+	defaultSettleMinAmount     = -1 // <= 0 means no limit (default)
 
 	defaultAllowZeroFees             = false
 	defaultRoundMaxParticipantsCount = 128
+	defaultMaxSubscriptionsPerClient = 100   // 0 means no limit
+	defaultMaxSubscriptions          = 10000 // 0 means no limit
+	defaultMaxInputsPerSweepTx       = 100   // 0 means no limit
+	defaultOfflineCosignerPolicy     = string(application.OfflineCosignerPolicyFail)
+	defaultMaxConcurrentRounds       = 1
+	defaultRequireSameBoardingOwner  = false
+	defaultTxRequestPingGap          = time.Minute
+	defaultTxRequestDeleteGap        = 5 * time.Minute
+	defaultRoundTimeout              = 0
+	defaultMempoolAncestorLimit      = 0
+	defaultSinglePartyFastMode       = false
+	defaultLowLiquidityThreshold     = 0
+	defaultAutoRefreshMargin         = 0
+	defaultMaxReceiversPerRequest    = 0
+	defaultShutdownTimeout           = time.Minute
+
+	defaultMaxConcurrentConfirmationChecks = 16
 )
 
 func LoadConfig() (*Config, error) {
@@ -213,12 +351,44 @@ func LoadConfig() (*Config, error) {
 	viper.SetDefault(UtxoMinAmount, defaultUtxoMinAmount)
 	viper.SetDefault(VtxoMaxAmount, defaultVtxoMaxAmount)
 	viper.SetDefault(VtxoMinAmount, defaultVtxoMinAmount)
+	viper.SetDefault(SettleMaxAmount, defaultSettleMaxAmount)
+	viper.SetDefault(SettleMinAmount, defaultSettleMinAmount)
+	viper.SetDefault(MaxSubscriptionsPerClient, defaultMaxSubscriptionsPerClient)
+	viper.SetDefault(MaxSubscriptions, defaultMaxSubscriptions)
+	viper.SetDefault(MaxInputsPerSweepTx, defaultMaxInputsPerSweepTx)
+	viper.SetDefault(MaxConcurrentConfirmationChecks, defaultMaxConcurrentConfirmationChecks)
+	viper.SetDefault(OfflineCosignerPolicy, defaultOfflineCosignerPolicy)
+	viper.SetDefault(MaxConcurrentRounds, defaultMaxConcurrentRounds)
+	viper.SetDefault(RequireSameBoardingOwner, defaultRequireSameBoardingOwner)
+	viper.SetDefault(TxRequestPingGap, defaultTxRequestPingGap)
+	viper.SetDefault(TxRequestDeleteGap, defaultTxRequestDeleteGap)
+	viper.SetDefault(RoundTimeout, defaultRoundTimeout)
+	viper.SetDefault(MempoolAncestorLimit, defaultMempoolAncestorLimit)
+	viper.SetDefault(SinglePartyFastMode, defaultSinglePartyFastMode)
+	viper.SetDefault(LowLiquidityThreshold, defaultLowLiquidityThreshold)
+	viper.SetDefault(AutoRefreshMargin, defaultAutoRefreshMargin)
+	viper.SetDefault(MaxReceiversPerRequest, defaultMaxReceiversPerRequest)
+	viper.SetDefault(ShutdownTimeout, defaultShutdownTimeout)
 
 	net, err := getNetwork()
 	if err != nil {
 		return nil, fmt.Errorf("error while getting network: %s", err)
 	}
 
+	stuckRoundThresholds, err := parseStuckRoundThresholds(
+		viper.GetString(StuckRoundThresholds),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid stuck round thresholds: %s", err)
+	}
+
+	noteDenominations, err := parseNoteDenominations(
+		viper.GetStringSlice(NoteDenominations),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid note denominations: %s", err)
+	}
+
 	if err := initDatadir(); err != nil {
 		return nil, fmt.Errorf("error while creating datadir: %s", err)
 	}
@@ -232,6 +402,8 @@ func LoadConfig() (*Config, error) {
 		Port:                      viper.GetUint32(Port),
 		EventDbType:               viper.GetString(EventDbType),
 		DbType:                    viper.GetString(DbType),
+		NoteDbType:                viper.GetString(NoteDbType),
+		NoteDbUrl:                 viper.GetString(NoteDbUrl),
 		SchedulerType:             viper.GetString(SchedulerType),
 		TxBuilderType:             viper.GetString(TxBuilderType),
 		NoTLS:                     viper.GetBool(NoTLS),
@@ -267,9 +439,88 @@ func LoadConfig() (*Config, error) {
 		UtxoMinAmount:             viper.GetInt64(UtxoMinAmount),
 		VtxoMaxAmount:             viper.GetInt64(VtxoMaxAmount),
 		VtxoMinAmount:             viper.GetInt64(VtxoMinAmount),
+		SettleMaxAmount:           viper.GetInt64(SettleMaxAmount),
+		SettleMinAmount:           viper.GetInt64(SettleMinAmount),
+		MaxSubscriptionsPerClient: viper.GetInt64(MaxSubscriptionsPerClient),
+		MaxSubscriptions:          viper.GetInt64(MaxSubscriptions),
+		MaxInputsPerSweepTx:       viper.GetInt64(MaxInputsPerSweepTx),
+		StuckRoundThresholds:      stuckRoundThresholds,
+		StuckRoundWebhookUrl:      viper.GetString(StuckRoundWebhookUrl),
+		OfflineCosignerPolicy:     application.OfflineCosignerPolicy(viper.GetString(OfflineCosignerPolicy)),
+		MaxConcurrentRounds:       viper.GetInt64(MaxConcurrentRounds),
+		RequireSameBoardingOwner:  viper.GetBool(RequireSameBoardingOwner),
+		TxRequestPingGap:          viper.GetDuration(TxRequestPingGap),
+		TxRequestDeleteGap:        viper.GetDuration(TxRequestDeleteGap),
+		RoundTimeout:              viper.GetDuration(RoundTimeout),
+		MempoolAncestorLimit:      viper.GetInt64(MempoolAncestorLimit),
+		SinglePartyFastMode:       viper.GetBool(SinglePartyFastMode),
+		LowLiquidityThreshold:     viper.GetUint64(LowLiquidityThreshold),
+		AutoRefreshMargin:         viper.GetDuration(AutoRefreshMargin),
+		MaxReceiversPerRequest:    viper.GetInt64(MaxReceiversPerRequest),
+		CollaborativeExitScriptTypes: parseExitScriptTypes(
+			viper.GetStringSlice(CollaborativeExitScriptTypes),
+		),
+		CollaborativeExitAddresses: viper.GetStringSlice(CollaborativeExitAddresses),
+		NoteDenominations:          noteDenominations,
+		NoteExpiry:                 viper.GetDuration(NoteExpiry),
+		ShutdownTimeout:            viper.GetDuration(ShutdownTimeout),
+		MaxConcurrentConfirmationChecks: viper.GetInt64(
+			MaxConcurrentConfirmationChecks,
+		),
 	}, nil
 }
 
+func parseNoteDenominations(list []string) ([]uint32, error) {
+	denominations := make([]uint32, 0, len(list))
+	for _, item := range list {
+		denomination, err := strconv.ParseUint(item, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid amount %s", item)
+		}
+		denominations = append(denominations, uint32(denomination))
+	}
+	return denominations, nil
+}
+
+func parseExitScriptTypes(list []string) []application.ExitScriptType {
+	scriptTypes := make([]application.ExitScriptType, 0, len(list))
+	for _, scriptType := range list {
+		scriptTypes = append(
+			scriptTypes, application.ExitScriptType(strings.ToLower(scriptType)),
+		)
+	}
+	return scriptTypes
+}
+
+// parseStuckRoundThresholds parses a list like "registration=1m,forfeits=30s"
+// into the per-phase thresholds of the stuck round alert.
+func parseStuckRoundThresholds(s string) (map[application.RoundPhase]time.Duration, error) {
+	thresholds := make(map[application.RoundPhase]time.Duration)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) <= 0 {
+			continue
+		}
+
+		kv := strings.SplitN(item, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid format %s, must be <phase>=<duration>", item)
+		}
+
+		phase := application.RoundPhase(strings.TrimSpace(kv[0]))
+		if !phase.IsValid() {
+			return nil, fmt.Errorf("unknown round phase %s, must be one of %v", phase, application.RoundPhases)
+		}
+
+		threshold, err := time.ParseDuration(strings.TrimSpace(kv[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration for phase %s: %s", phase, err)
+		}
+		thresholds[phase] = threshold
+	}
+	return thresholds, nil
+}
+
 func initDatadir() error {
 	datadir := viper.GetString(Datadir)
 	return makeDirectoryIfNotExists(datadir)
@@ -316,6 +567,14 @@ func (c *Config) Validate() error {
 	if !supportedDbs.supports(c.DbType) {
 		return fmt.Errorf("db type not supported, please select one of: %s", supportedDbs)
 	}
+	if len(c.NoteDbType) > 0 {
+		if !supportedNoteDbs.supports(c.NoteDbType) {
+			return fmt.Errorf("note db type not supported, please select one of: %s", supportedNoteDbs)
+		}
+		if len(c.NoteDbUrl) <= 0 {
+			return fmt.Errorf("missing note db url")
+		}
+	}
 	if !supportedSchedulers.supports(c.SchedulerType) {
 		return fmt.Errorf("scheduler type not supported, please select one of: %s", supportedSchedulers)
 	}
@@ -328,6 +587,61 @@ func (c *Config) Validate() error {
 	if c.RoundInterval < 2 {
 		return fmt.Errorf("invalid round interval, must be at least 2 seconds")
 	}
+	if c.RoundMaxParticipantsCount < 1 {
+		return fmt.Errorf("invalid round max participants count, must be >= 1")
+	}
+	if c.MaxSubscriptionsPerClient < 0 || c.MaxSubscriptions < 0 {
+		return fmt.Errorf("invalid max subscriptions, must be >= 0")
+	}
+	if c.MaxInputsPerSweepTx < 0 {
+		return fmt.Errorf("invalid max inputs per sweep tx, must be >= 0")
+	}
+	if c.MaxConcurrentConfirmationChecks < 1 {
+		return fmt.Errorf("invalid max concurrent confirmation checks, must be >= 1")
+	}
+	if c.SettleMaxAmount > 0 && c.SettleMinAmount > c.SettleMaxAmount {
+		return fmt.Errorf("invalid settle amount bounds, min must be <= max")
+	}
+	if !c.OfflineCosignerPolicy.IsValid() {
+		return fmt.Errorf(
+			"invalid offline cosigner policy, must be one of: %v", application.OfflineCosignerPolicies,
+		)
+	}
+	if c.MaxConcurrentRounds < 1 {
+		return fmt.Errorf("invalid max concurrent rounds, must be >= 1")
+	}
+	if c.TxRequestPingGap <= 0 {
+		return fmt.Errorf("invalid tx request ping gap, must be > 0")
+	}
+	if c.TxRequestDeleteGap <= c.TxRequestPingGap {
+		return fmt.Errorf("invalid tx request delete gap, must be > ping gap")
+	}
+	if c.RoundTimeout < 0 {
+		return fmt.Errorf("invalid round timeout, must be >= 0")
+	}
+	if c.ShutdownTimeout < 0 {
+		return fmt.Errorf("invalid shutdown timeout, must be >= 0")
+	}
+	if c.RoundTimeout > 0 && c.RoundTimeout <= time.Duration(c.RoundInterval)*time.Second {
+		return fmt.Errorf("invalid round timeout, must be greater than round interval")
+	}
+	if c.MempoolAncestorLimit < 0 {
+		return fmt.Errorf("invalid mempool ancestor limit, must be >= 0")
+	}
+	if c.AutoRefreshMargin < 0 {
+		return fmt.Errorf("invalid auto refresh margin, must be >= 0")
+	}
+	if c.MaxReceiversPerRequest < 0 {
+		return fmt.Errorf("invalid max receivers per request, must be >= 0")
+	}
+	for _, scriptType := range c.CollaborativeExitScriptTypes {
+		if !scriptType.IsValid() {
+			return fmt.Errorf(
+				"invalid collaborative exit script type %s, must be one of: %v",
+				scriptType, application.ExitScriptTypes,
+			)
+		}
+	}
 	if !supportedNetworks.supports(c.Network.Name) {
 		return fmt.Errorf("invalid network, must be one of: %s", supportedNetworks)
 	}
@@ -455,11 +769,18 @@ func (c *Config) repoManager() error {
 		return fmt.Errorf("unknown db type")
 	}
 
+	var noteStoreConfig []interface{}
+	if len(c.NoteDbType) > 0 {
+		noteStoreConfig = []interface{}{c.NoteDbUrl}
+	}
+
 	svc, err = db.NewService(db.ServiceConfig{
 		EventStoreType:   c.EventDbType,
 		DataStoreType:    c.DbType,
+		NoteStoreType:    c.NoteDbType,
 		EventStoreConfig: eventStoreConfig,
 		DataStoreConfig:  dataStoreConfig,
+		NoteStoreConfig:  noteStoreConfig,
 	})
 	if err != nil {
 		return err
@@ -557,6 +878,14 @@ func (c *Config) appService() error {
 		c.wallet, c.repo, c.txBuilder, c.scanner, c.scheduler, c.NoteUriPrefix,
 		c.MarketHourStartTime, c.MarketHourEndTime, c.MarketHourPeriod, c.MarketHourRoundInterval,
 		c.AllowZeroFees, c.RoundMaxParticipantsCount, c.UtxoMaxAmount, c.UtxoMinAmount, c.VtxoMaxAmount, c.VtxoMinAmount,
+		c.SettleMaxAmount, c.SettleMinAmount,
+		c.MaxInputsPerSweepTx, c.MaxConcurrentConfirmationChecks,
+		c.StuckRoundThresholds, c.StuckRoundWebhookUrl,
+		c.OfflineCosignerPolicy, c.MaxConcurrentRounds, c.RequireSameBoardingOwner,
+		c.TxRequestPingGap, c.TxRequestDeleteGap,
+		c.CollaborativeExitScriptTypes, c.CollaborativeExitAddresses, c.RoundTimeout,
+		c.MempoolAncestorLimit, c.SinglePartyFastMode, c.LowLiquidityThreshold,
+		c.AutoRefreshMargin, c.MaxReceiversPerRequest, c.EventPublisher,
 	)
 	if err != nil {
 		return err
@@ -572,7 +901,15 @@ func (c *Config) adminService() error {
 		unit = ports.BlockHeight
 	}
 
-	c.adminSvc = application.NewAdminService(c.wallet, c.repo, c.txBuilder, unit)
+	svc, err := application.NewAdminService(
+		c.wallet, c.repo, c.txBuilder, unit, c.NoteDenominations, c.NoteExpiry,
+		c.MaxConcurrentConfirmationChecks,
+	)
+	if err != nil {
+		return err
+	}
+
+	c.adminSvc = svc
 	return nil
 }
 
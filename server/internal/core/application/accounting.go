@@ -0,0 +1,253 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// RoundAccounting is the value moved by a finalized round.
+type RoundAccounting struct {
+	RoundId   string
+	Txid      string
+	Timestamp int64
+	// BoardingAmount and NotesAmount are the value entered with the boarding
+	// inputs and the notes redeemed in the round.
+	BoardingAmount uint64
+	NotesAmount    uint64
+	// ForfeitedAmount is the value of the vtxos spent in the round.
+	ForfeitedAmount uint64
+	// VtxosAmount is the value of the vtxos created by the round.
+	VtxosAmount uint64
+	// CollaborativeExitAmount is the value sent to the onchain outputs of the
+	// round.
+	CollaborativeExitAmount uint64
+	// FeesAmount is the value of the inputs not sent to any output.
+	FeesAmount uint64
+}
+
+// AccountingReport is the operator's books over the period [After, Before).
+// The balances are the value of the vtxos outstanding at the start and at the
+// end of the period, ie. the liabilities of the operator, and they reconcile:
+// ClosingBalance = OpeningBalance + CreatedAmount - SpentAmount - SweptAmount
+// - UnilateralExitAmount.
+type AccountingReport struct {
+	After  int64
+	Before int64
+
+	OpeningBalance uint64
+	ClosingBalance uint64
+
+	// CreatedAmount is the value of the vtxos created during the period, by
+	// rounds or redeem txs.
+	CreatedAmount uint64
+	// SpentAmount is the value of the vtxos spent during the period, in rounds
+	// or by redeem txs.
+	SpentAmount uint64
+	// SweptAmount is the value of the vtxos swept by the operator during the
+	// period. They can still be recovered by their owners.
+	SweptAmount uint64
+	// UnilateralExitAmount is the value of the vtxos unilaterally exited
+	// during the period.
+	UnilateralExitAmount uint64
+
+	// Totals of the rounds started during the period.
+	BoardingAmount          uint64
+	NotesAmount             uint64
+	CollaborativeExitAmount uint64
+	FeesAmount              uint64
+	Rounds                  []RoundAccounting
+}
+
+// GetAccountingReport returns the accounting report of the given period, a
+// before of 0 means now.
+// The time of sweeps and unilateral exits isn't persisted: they're accounted
+// at the expiration of the vtxo, the deadline by which they happen.
+func (a *adminService) GetAccountingReport(
+	ctx context.Context, after, before int64,
+) (*AccountingReport, error) {
+	if before <= 0 {
+		before = time.Now().Unix()
+	}
+	if after < 0 || after >= before {
+		return nil, fmt.Errorf("invalid period, after must be lower than before")
+	}
+
+	report := &AccountingReport{
+		After:  after,
+		Before: before,
+		Rounds: make([]RoundAccounting, 0),
+	}
+
+	if err := a.addRoundsActivity(ctx, report); err != nil {
+		return nil, err
+	}
+	if err := a.addVtxosBalances(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+func (a *adminService) addRoundsActivity(
+	ctx context.Context, report *AccountingReport,
+) error {
+	roundIds, err := a.repoManager.Rounds().GetRoundsIds(ctx, report.After, report.Before)
+	if err != nil {
+		return fmt.Errorf("failed to get rounds: %s", err)
+	}
+
+	for _, roundId := range roundIds {
+		round, err := a.repoManager.Rounds().GetRoundWithId(ctx, roundId)
+		if err != nil {
+			return fmt.Errorf("failed to get round %s: %s", roundId, err)
+		}
+		if !round.IsEnded() {
+			continue
+		}
+
+		entry, err := a.getRoundAccounting(ctx, round)
+		if err != nil {
+			return err
+		}
+
+		report.BoardingAmount += entry.BoardingAmount
+		report.NotesAmount += entry.NotesAmount
+		report.CollaborativeExitAmount += entry.CollaborativeExitAmount
+		report.FeesAmount += entry.FeesAmount
+		report.Rounds = append(report.Rounds, *entry)
+	}
+	return nil
+}
+
+func (a *adminService) getRoundAccounting(
+	ctx context.Context, round *domain.Round,
+) (*RoundAccounting, error) {
+	entry := &RoundAccounting{
+		RoundId:   round.Id,
+		Txid:      round.Txid,
+		Timestamp: round.EndingTimestamp,
+	}
+
+	for _, request := range round.TxRequests {
+		entry.ForfeitedAmount += request.TotalInputAmount()
+		for _, receiver := range request.Receivers {
+			if receiver.IsOnchain() {
+				entry.CollaborativeExitAmount += receiver.Amount
+				continue
+			}
+			entry.VtxosAmount += receiver.Amount
+		}
+	}
+
+	roundTx, err := psbt.NewFromRawBytes(strings.NewReader(round.UnsignedTx), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse tx of round %s: %s", round.Id, err)
+	}
+	for _, in := range roundTx.Inputs {
+		// the server doesn't use taproot addresses, taproot inputs are the
+		// boarding ones
+		if in.WitnessUtxo != nil &&
+			txscript.GetScriptClass(in.WitnessUtxo.PkScript) == txscript.WitnessV1TaprootTy {
+			entry.BoardingAmount += uint64(in.WitnessUtxo.Value)
+		}
+	}
+
+	entry.NotesAmount, err = a.repoManager.Notes().GetAmountForRound(ctx, round.Txid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notes of round %s: %s", round.Id, err)
+	}
+
+	inputs := entry.ForfeitedAmount + entry.BoardingAmount + entry.NotesAmount
+	outputs := entry.VtxosAmount + entry.CollaborativeExitAmount
+	// the value of the notes redeemed before it was persisted is unknown
+	if inputs > outputs {
+		entry.FeesAmount = inputs - outputs
+	}
+	return entry, nil
+}
+
+func (a *adminService) addVtxosBalances(
+	ctx context.Context, report *AccountingReport,
+) error {
+	vtxos, err := a.repoManager.Vtxos().GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get vtxos: %s", err)
+	}
+
+	// the vtxos spent by a redeem tx are spent when its outputs are created
+	redeemTxTimestamps := make(map[string]int64)
+	for _, vtxo := range vtxos {
+		if vtxo.IsPending() {
+			redeemTxTimestamps[vtxo.Txid] = vtxo.CreatedAt
+		}
+	}
+	// the vtxos spent in a round are spent when the round ends
+	roundTimestamps := make(map[string]int64)
+	spentAt := func(vtxo domain.Vtxo) (int64, error) {
+		if timestamp, ok := redeemTxTimestamps[vtxo.SpentBy]; ok {
+			return timestamp, nil
+		}
+		if timestamp, ok := roundTimestamps[vtxo.SpentBy]; ok {
+			return timestamp, nil
+		}
+		round, err := a.repoManager.Rounds().GetRoundWithTxid(ctx, vtxo.SpentBy)
+		if err != nil {
+			return 0, fmt.Errorf(
+				"failed to get round %s spending vtxo %s: %s", vtxo.SpentBy, vtxo.VtxoKey, err,
+			)
+		}
+		roundTimestamps[vtxo.SpentBy] = round.EndingTimestamp
+		return round.EndingTimestamp, nil
+	}
+
+	inPeriod := func(timestamp int64) bool {
+		return timestamp >= report.After && timestamp < report.Before
+	}
+	isOutstanding := func(createdAt, endedAt int64, at int64) bool {
+		return createdAt < at && (endedAt < 0 || endedAt >= at)
+	}
+
+	for _, vtxo := range vtxos {
+		// -1 means the vtxo is still outstanding
+		endedAt := int64(-1)
+		switch {
+		case vtxo.Spent:
+			endedAt, err = spentAt(vtxo)
+			if err != nil {
+				return err
+			}
+		case vtxo.Redeemed, vtxo.Swept:
+			endedAt = vtxo.ExpireAt
+		}
+		if endedAt >= 0 && endedAt < vtxo.CreatedAt {
+			endedAt = vtxo.CreatedAt
+		}
+
+		if isOutstanding(vtxo.CreatedAt, endedAt, report.After) {
+			report.OpeningBalance += vtxo.Amount
+		}
+		if isOutstanding(vtxo.CreatedAt, endedAt, report.Before) {
+			report.ClosingBalance += vtxo.Amount
+		}
+		if inPeriod(vtxo.CreatedAt) {
+			report.CreatedAmount += vtxo.Amount
+		}
+		if endedAt < 0 || !inPeriod(endedAt) {
+			continue
+		}
+		switch {
+		case vtxo.Spent:
+			report.SpentAmount += vtxo.Amount
+		case vtxo.Redeemed:
+			report.UnilateralExitAmount += vtxo.Amount
+		default:
+			report.SweptAmount += vtxo.Amount
+		}
+	}
+	return nil
+}
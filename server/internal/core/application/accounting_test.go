@@ -0,0 +1,161 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAccountingReport(t *testing.T) {
+	ctx := context.Background()
+
+	// vtxos outstanding before the period
+	forfeited := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: "forfeited"}, Amount: 1000, CreatedAt: 50,
+		Spent: true, SpentBy: "roundtx",
+	}
+	swept := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: "swept"}, Amount: 300, CreatedAt: 60,
+		Swept: true, ExpireAt: 180,
+	}
+	redeemed := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: "redeemed"}, Amount: 200, CreatedAt: 70,
+		Redeemed: true, ExpireAt: 250,
+	}
+	spent := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: chainhash.Hash{1}.String()}, Amount: 700, CreatedAt: 80,
+	}
+	// vtxos created during the period
+	pending := makePendingVtxo(t, spent.VtxoKey, "roundtx")
+	pending.Amount = 700
+	pending.CreatedAt = 170
+	spent.Spent = true
+	spent.SpentBy = pending.Txid
+	roundVtxo := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: "roundvtxo"}, Amount: 1500, CreatedAt: 160,
+		RoundTxid: "roundtx",
+	}
+
+	round := &domain.Round{
+		Id:                "round",
+		Txid:              "roundtx",
+		StartingTimestamp: 150,
+		EndingTimestamp:   160,
+		Stage:             domain.Stage{Code: domain.FinalizationStage, Ended: true},
+		TxRequests: map[string]domain.TxRequest{
+			"request": {
+				Id:     "request",
+				Inputs: []domain.Vtxo{forfeited},
+				Receivers: []domain.Receiver{
+					{PubKey: "pubkey", Amount: 1500},
+					{OnchainAddress: "address", Amount: 400},
+				},
+			},
+		},
+		UnsignedTx: makeRoundTx(t, 1000, 5000),
+	}
+	failedRound := &domain.Round{
+		Id:                "failed",
+		Txid:              "failedtx",
+		StartingTimestamp: 120,
+		Stage:             domain.Stage{Code: domain.FinalizationStage, Failed: true},
+	}
+
+	svc := &adminService{
+		repoManager: &mockedRepoManager{
+			vtxos: &mockedVtxoRepo{vtxos: map[string]domain.Vtxo{
+				forfeited.String(): forfeited,
+				swept.String():     swept,
+				redeemed.String():  redeemed,
+				spent.String():     spent,
+				pending.String():   pending,
+				roundVtxo.String(): roundVtxo,
+			}},
+			rounds: &mockedRoundRepo{rounds: map[string]*domain.Round{
+				round.Txid:       round,
+				failedRound.Txid: failedRound,
+			}},
+			notes: &mockedNoteRepo{amounts: map[string]uint64{round.Txid: 50}},
+		},
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		report, err := svc.GetAccountingReport(ctx, 100, 200)
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(2200), report.OpeningBalance)
+		require.Equal(t, uint64(2200), report.CreatedAmount)
+		require.Equal(t, uint64(1700), report.SpentAmount)
+		require.Equal(t, uint64(300), report.SweptAmount)
+		require.Zero(t, report.UnilateralExitAmount)
+		require.Equal(t, uint64(2400), report.ClosingBalance)
+		require.Equal(
+			t, report.ClosingBalance,
+			report.OpeningBalance+report.CreatedAmount-report.SpentAmount-
+				report.SweptAmount-report.UnilateralExitAmount,
+		)
+
+		require.Len(t, report.Rounds, 1)
+		require.Equal(t, RoundAccounting{
+			RoundId:                 round.Id,
+			Txid:                    round.Txid,
+			Timestamp:               round.EndingTimestamp,
+			BoardingAmount:          1000,
+			NotesAmount:             50,
+			ForfeitedAmount:         1000,
+			VtxosAmount:             1500,
+			CollaborativeExitAmount: 400,
+			FeesAmount:              150,
+		}, report.Rounds[0])
+		require.Equal(t, uint64(1000), report.BoardingAmount)
+		require.Equal(t, uint64(50), report.NotesAmount)
+		require.Equal(t, uint64(400), report.CollaborativeExitAmount)
+		require.Equal(t, uint64(150), report.FeesAmount)
+	})
+
+	t.Run("unilateral exit", func(t *testing.T) {
+		report, err := svc.GetAccountingReport(ctx, 200, 300)
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(2400), report.OpeningBalance)
+		require.Equal(t, uint64(200), report.UnilateralExitAmount)
+		require.Equal(t, uint64(2200), report.ClosingBalance)
+		require.Empty(t, report.Rounds)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := svc.GetAccountingReport(ctx, 200, 100)
+		require.Error(t, err)
+
+		_, err = svc.GetAccountingReport(ctx, -1, 100)
+		require.Error(t, err)
+	})
+}
+
+// makeRoundTx returns a round tx spending a taproot boarding input and a
+// segwit v0 input of the server wallet.
+func makeRoundTx(t *testing.T, boardingAmount, walletAmount int64) string {
+	ptx, err := psbt.New(
+		[]*wire.OutPoint{{Hash: chainhash.Hash{2}}, {Hash: chainhash.Hash{3}}},
+		[]*wire.TxOut{{Value: 1000, PkScript: []byte{0x51}}},
+		2, 0, []uint32{wire.MaxTxInSequenceNum, wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+
+	ptx.Inputs[0].WitnessUtxo = &wire.TxOut{
+		Value: boardingAmount, PkScript: append([]byte{0x51, 0x20}, bytes.Repeat([]byte{1}, 32)...),
+	}
+	ptx.Inputs[1].WitnessUtxo = &wire.TxOut{
+		Value: walletAmount, PkScript: append([]byte{0x00, 0x14}, bytes.Repeat([]byte{1}, 20)...),
+	}
+
+	b64, err := ptx.B64Encode()
+	require.NoError(t, err)
+	return b64
+}
@@ -2,8 +2,12 @@ package application
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"time"
 
 	"github.com/ark-network/ark/common/note"
+	"github.com/ark-network/ark/server/internal/core/domain"
 	"github.com/ark-network/ark/server/internal/core/ports"
 )
 
@@ -29,6 +33,19 @@ type ScheduledSweep struct {
 	SweepableOutputs []SweepableOutput
 }
 
+// PendingSweep is an output of a vtxo tree not swept yet.
+// Only one of ExpirationTime and ExpirationHeight is set, depending on the time
+// unit of the sweeper.
+type PendingSweep struct {
+	RoundId          string
+	RoundTxid        string
+	Txid             string
+	Vout             uint32
+	Amount           uint64
+	ExpirationTime   int64
+	ExpirationHeight int64
+}
+
 type RoundDetails struct {
 	RoundId          string
 	TxId             string
@@ -44,11 +61,15 @@ type RoundDetails struct {
 type AdminService interface {
 	Wallet() ports.WalletService
 	GetScheduledSweeps(ctx context.Context) ([]ScheduledSweep, error)
+	// ListPendingSweeps returns the outputs of the vtxo trees scheduled to be
+	// swept, sorted by expiration.
+	ListPendingSweeps(ctx context.Context) ([]PendingSweep, error)
 	GetRoundDetails(ctx context.Context, roundId string) (*RoundDetails, error)
 	GetRounds(ctx context.Context, after int64, before int64) ([]string, error)
 	GetWalletAddress(ctx context.Context) (string, error)
 	GetWalletStatus(ctx context.Context) (*WalletStatus, error)
-	CreateNotes(ctx context.Context, amount uint32, quantity int) ([]string, error)
+	CreateNotes(ctx context.Context, amount uint32, quantity int, notBefore time.Time) ([]string, error)
+	GetAccountingReport(ctx context.Context, after, before int64) (*AccountingReport, error)
 }
 
 type adminService struct {
@@ -56,15 +77,35 @@ type adminService struct {
 	repoManager     ports.RepoManager
 	txBuilder       ports.TxBuilder
 	sweeperTimeUnit ports.TimeUnit
+	// noteDenominations are the values notes can be created with, any if empty
+	noteDenominations note.DenominationSet
+	// noteExpiry is how long the created notes are valid for, 0 means forever
+	noteExpiry time.Duration
+	// maxConcurrentConfirmationChecks is the max number of txs of a vtxo tree
+	// whose onchain state is checked at the same time
+	maxConcurrentConfirmationChecks int64
 }
 
-func NewAdminService(walletSvc ports.WalletService, repoManager ports.RepoManager, txBuilder ports.TxBuilder, timeUnit ports.TimeUnit) AdminService {
-	return &adminService{
-		walletSvc:       walletSvc,
-		repoManager:     repoManager,
-		txBuilder:       txBuilder,
-		sweeperTimeUnit: timeUnit,
+func NewAdminService(
+	walletSvc ports.WalletService, repoManager ports.RepoManager, txBuilder ports.TxBuilder,
+	timeUnit ports.TimeUnit, noteDenominations []uint32, noteExpiry time.Duration,
+	maxConcurrentConfirmationChecks int64,
+) (AdminService, error) {
+	denominations, err := note.NewDenominationSet(noteDenominations...)
+	if err != nil {
+		return nil, fmt.Errorf("invalid note denominations: %s", err)
 	}
+
+	return &adminService{
+		walletSvc:         walletSvc,
+		repoManager:       repoManager,
+		txBuilder:         txBuilder,
+		sweeperTimeUnit:   timeUnit,
+		noteDenominations: denominations,
+		noteExpiry:        noteExpiry,
+
+		maxConcurrentConfirmationChecks: maxConcurrentConfirmationChecks,
+	}, nil
 }
 
 func (a *adminService) Wallet() ports.WalletService {
@@ -125,26 +166,10 @@ func (a *adminService) GetRounds(ctx context.Context, after int64, before int64)
 }
 
 func (a *adminService) GetScheduledSweeps(ctx context.Context) ([]ScheduledSweep, error) {
-	sweepableRounds, err := a.repoManager.Rounds().GetExpiredRoundsTxid(ctx)
-	if err != nil {
-		return nil, err
-	}
-
-	scheduledSweeps := make([]ScheduledSweep, 0, len(sweepableRounds))
-
-	for _, txid := range sweepableRounds {
-		round, err := a.repoManager.Rounds().GetRoundWithTxid(ctx, txid)
-		if err != nil {
-			return nil, err
-		}
-
-		sweepable, err := findSweepableOutputs(
-			ctx, a.walletSvc, a.txBuilder, a.sweeperTimeUnit, round.VtxoTree,
-		)
-		if err != nil {
-			return nil, err
-		}
-
+	scheduledSweeps := make([]ScheduledSweep, 0)
+	err := a.forEachSweepableRound(ctx, func(
+		round *domain.Round, sweepable map[int64][]ports.SweepInput,
+	) {
 		sweepableOutputs := make([]SweepableOutput, 0)
 		for expirationTime, inputs := range sweepable {
 			for _, input := range inputs {
@@ -161,11 +186,87 @@ func (a *adminService) GetScheduledSweeps(ctx context.Context) ([]ScheduledSweep
 			RoundId:          round.Id,
 			SweepableOutputs: sweepableOutputs,
 		})
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return scheduledSweeps, nil
 }
 
+func (a *adminService) ListPendingSweeps(ctx context.Context) ([]PendingSweep, error) {
+	pendingSweeps := make([]PendingSweep, 0)
+	err := a.forEachSweepableRound(ctx, func(
+		round *domain.Round, sweepable map[int64][]ports.SweepInput,
+	) {
+		for expiration, inputs := range sweepable {
+			for _, input := range inputs {
+				pendingSweep := PendingSweep{
+					RoundId:   round.Id,
+					RoundTxid: round.Txid,
+					Txid:      input.GetHash().String(),
+					Vout:      input.GetIndex(),
+					Amount:    input.GetAmount(),
+				}
+				if a.sweeperTimeUnit == ports.BlockHeight {
+					pendingSweep.ExpirationHeight = expiration
+				} else {
+					pendingSweep.ExpirationTime = expiration
+				}
+				pendingSweeps = append(pendingSweeps, pendingSweep)
+			}
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// only one of expiration time and height is set
+	sort.SliceStable(pendingSweeps, func(i, j int) bool {
+		ei := pendingSweeps[i].ExpirationTime + pendingSweeps[i].ExpirationHeight
+		ej := pendingSweeps[j].ExpirationTime + pendingSweeps[j].ExpirationHeight
+		if ei != ej {
+			return ei < ej
+		}
+		if pendingSweeps[i].Txid != pendingSweeps[j].Txid {
+			return pendingSweeps[i].Txid < pendingSweeps[j].Txid
+		}
+		return pendingSweeps[i].Vout < pendingSweeps[j].Vout
+	})
+
+	return pendingSweeps, nil
+}
+
+// forEachSweepableRound calls fn with the outputs of the vtxo tree of every
+// round not swept yet, mapped by their expiration like done by the sweeper.
+func (a *adminService) forEachSweepableRound(
+	ctx context.Context,
+	fn func(round *domain.Round, sweepable map[int64][]ports.SweepInput),
+) error {
+	sweepableRounds, err := a.repoManager.Rounds().GetExpiredRoundsTxid(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, txid := range sweepableRounds {
+		round, err := a.repoManager.Rounds().GetRoundWithTxid(ctx, txid)
+		if err != nil {
+			return err
+		}
+
+		sweepable, err := findSweepableOutputs(
+			ctx, a.walletSvc, a.txBuilder, a.sweeperTimeUnit, round.VtxoTree,
+			a.maxConcurrentConfirmationChecks,
+		)
+		if err != nil {
+			return err
+		}
+
+		fn(round, sweepable)
+	}
+	return nil
+}
+
 func (a *adminService) GetWalletAddress(ctx context.Context) (string, error) {
 	addresses, err := a.walletSvc.DeriveAddresses(ctx, 1)
 	if err != nil {
@@ -187,10 +288,33 @@ func (a *adminService) GetWalletStatus(ctx context.Context) (*WalletStatus, erro
 	}, nil
 }
 
-func (a *adminService) CreateNotes(ctx context.Context, value uint32, quantity int) ([]string, error) {
+// CreateNotes mints the given quantity of notes. If notBefore is not the zero
+// time, the notes can't be redeemed before then. If note denominations are
+// configured, the value must be one of them otherwise note.ErrInvalidDenomination
+// is returned.
+func (a *adminService) CreateNotes(
+	ctx context.Context, value uint32, quantity int, notBefore time.Time,
+) ([]string, error) {
+	opts := []note.Option{note.WithDenominations(a.noteDenominations)}
+	if a.noteExpiry > 0 {
+		// a time-locked note expires after the configured duration from the
+		// moment it can be redeemed
+		validFrom := time.Now()
+		if notBefore.After(validFrom) {
+			validFrom = notBefore
+		}
+		opts = append(opts, note.WithExpiry(validFrom.Add(a.noteExpiry)))
+	}
+
 	notes := make([]string, 0, quantity)
 	for i := 0; i < quantity; i++ {
-		data, err := note.New(value)
+		var data *note.Data
+		var err error
+		if notBefore.IsZero() {
+			data, err = note.New(value, opts...)
+		} else {
+			data, err = note.NewTimeLocked(value, notBefore, opts...)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,69 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPendingSweeps(t *testing.T) {
+	ctx := context.Background()
+	vtxoTree := makeTestVtxoTree(4)
+	root := vtxoTree[0][0]
+	left, right := vtxoTree[1][0], vtxoTree[1][1]
+	vtxoTreeExpiry := int64(testVtxoTreeExpiry.Value)
+
+	round := &domain.Round{Id: "round", Txid: root.ParentTxid, VtxoTree: vtxoTree}
+	sweptRound := &domain.Round{Id: "swept", Txid: "sweptroundtx", Swept: true}
+	wallet := &mockedWallet{confirmed: map[string]int64{
+		root.ParentTxid: 100,
+		root.Txid:       101,
+		left.Txid:       102,
+	}}
+
+	newAdminService := func(timeUnit ports.TimeUnit) *adminService {
+		return &adminService{
+			walletSvc: wallet,
+			repoManager: &mockedRepoManager{
+				rounds: &mockedRoundRepo{rounds: map[string]*domain.Round{
+					round.Txid:      round,
+					sweptRound.Txid: sweptRound,
+				}},
+			},
+			txBuilder:       &mockedTxBuilder{},
+			sweeperTimeUnit: timeUnit,
+		}
+	}
+
+	t.Run("block height", func(t *testing.T) {
+		pendingSweeps, err := newAdminService(ports.BlockHeight).ListPendingSweeps(ctx)
+		require.NoError(t, err)
+
+		// the outputs of the unrolled part of the tree expire later
+		expected := []PendingSweep{
+			{Txid: right.Txid, ExpirationHeight: 101 + vtxoTreeExpiry},
+			{Txid: vtxoTree[2][0].Txid, ExpirationHeight: 102 + vtxoTreeExpiry},
+			{Txid: vtxoTree[2][1].Txid, ExpirationHeight: 102 + vtxoTreeExpiry},
+		}
+		require.Len(t, pendingSweeps, len(expected))
+		for i, pendingSweep := range pendingSweeps {
+			require.Equal(t, round.Id, pendingSweep.RoundId)
+			require.Equal(t, round.Txid, pendingSweep.RoundTxid)
+			require.Equal(t, expected[i].Txid, pendingSweep.Txid)
+			require.Equal(t, expected[i].ExpirationHeight, pendingSweep.ExpirationHeight)
+			require.Zero(t, pendingSweep.ExpirationTime)
+			require.Equal(t, uint64(1000), pendingSweep.Amount)
+		}
+	})
+
+	t.Run("unix time", func(t *testing.T) {
+		pendingSweeps, err := newAdminService(ports.UnixTime).ListPendingSweeps(ctx)
+		require.NoError(t, err)
+		require.Len(t, pendingSweeps, 3)
+		require.Equal(t, 101*600+vtxoTreeExpiry, pendingSweeps[0].ExpirationTime)
+		require.Zero(t, pendingSweeps[0].ExpirationHeight)
+	})
+}
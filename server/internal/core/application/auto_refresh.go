@@ -0,0 +1,507 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/bip322"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	log "github.com/sirupsen/logrus"
+)
+
+// average time between blocks, used to express the refresh margin in blocks
+// when the expiry of the vtxos is a block height
+const blockInterval = 10 * time.Minute
+
+// autoRefresher periodically registers for the next round the vtxos close to
+// expiry whose owners authorized the server to refresh them on their behalf,
+// so that they're not swept while the owners are offline.
+type autoRefresher struct {
+	lock        *sync.Mutex
+	repoManager ports.RepoManager
+	scheduler   ports.SchedulerService
+	txRequests  *txRequestsQueue
+	roundInputs *outpointMap
+	// registerIntent registers the pre-signed intent of an authorization
+	registerIntent func(
+		ctx context.Context, sig bip322.Signature, message tree.IntentMessage,
+	) (string, error)
+	// margin is how long before expiry a vtxo gets refreshed, in the unit of
+	// the scheduler, 0 means disabled
+	margin   int64
+	interval time.Duration
+
+	// ids of the tx requests registered on behalf of the owners, by vtxo
+	requests map[domain.VtxoKey]string
+	quit     chan struct{}
+}
+
+func newAutoRefresher(
+	repoManager ports.RepoManager, scheduler ports.SchedulerService,
+	txRequests *txRequestsQueue, roundInputs *outpointMap,
+	registerIntent func(context.Context, bip322.Signature, tree.IntentMessage) (string, error),
+	margin, interval time.Duration,
+) *autoRefresher {
+	unitMargin := int64(margin.Seconds())
+	if scheduler != nil && scheduler.Unit() == ports.BlockHeight {
+		unitMargin = int64(margin / blockInterval)
+		// round up to not refresh later than the configured margin
+		if margin%blockInterval != 0 {
+			unitMargin++
+		}
+	}
+
+	return &autoRefresher{
+		lock:           &sync.Mutex{},
+		repoManager:    repoManager,
+		scheduler:      scheduler,
+		txRequests:     txRequests,
+		roundInputs:    roundInputs,
+		registerIntent: registerIntent,
+		margin:         unitMargin,
+		interval:       interval,
+		requests:       make(map[domain.VtxoKey]string),
+	}
+}
+
+func (r *autoRefresher) enabled() bool {
+	return r.margin > 0
+}
+
+// start runs the periodic check in background until stop is called.
+func (r *autoRefresher) start() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.quit != nil || !r.enabled() || r.interval <= 0 {
+		return
+	}
+	r.quit = make(chan struct{})
+
+	go func(quit chan struct{}) {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := r.check(context.Background()); err != nil {
+				log.WithError(err).Warn("failed to refresh vtxos")
+			}
+
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.quit)
+}
+
+func (r *autoRefresher) stop() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.quit != nil {
+		close(r.quit)
+		r.quit = nil
+	}
+}
+
+// check registers the intents of the authorized vtxos entering the refresh
+// margin, or whose authorization has been revoked, keeps alive the tx requests
+// already registered for them and drops the authorizations of the vtxos that
+// can't be refreshed anymore.
+func (r *autoRefresher) check(ctx context.Context) error {
+	authorizations, err := r.repoManager.RefreshAuthorizations().GetAll(ctx)
+	if err != nil {
+		return err
+	}
+
+	stale := make([]domain.VtxoKey, 0)
+	for _, authorization := range authorizations {
+		vtxos, err := r.repoManager.Vtxos().GetVtxos(
+			ctx, []domain.VtxoKey{authorization.VtxoKey},
+		)
+		if err != nil {
+			log.WithError(err).Warnf("failed to get vtxo %s", authorization.VtxoKey)
+			continue
+		}
+		if len(vtxos) <= 0 {
+			stale = append(stale, authorization.VtxoKey)
+			continue
+		}
+
+		vtxo := vtxos[0]
+		if vtxo.Spent || vtxo.Swept || vtxo.Redeemed {
+			stale = append(stale, vtxo.VtxoKey)
+			continue
+		}
+
+		// a revoked authorization is revoked for good only once the vtxo is
+		// refreshed, so it doesn't wait for the refresh margin
+		if authorization.RevokedAt <= 0 && r.scheduler.AfterNow(vtxo.ExpireAt-r.margin) {
+			continue
+		}
+
+		r.lock.Lock()
+		registeredId, registered := r.requests[vtxo.VtxoKey]
+		r.lock.Unlock()
+
+		if requestId, ok := r.txRequests.findInput(vtxo.VtxoKey); ok {
+			// the requests registered by the owners are pinged by them
+			if registered && requestId == registeredId {
+				// nolint:all
+				r.txRequests.updatePingTimestamp(requestId)
+			}
+			continue
+		}
+		if r.roundInputs.includes(vtxo.VtxoKey) {
+			continue
+		}
+
+		sig, err := bip322.DecodeSignature(authorization.Intent)
+		if err != nil {
+			log.WithError(err).Warnf("invalid refresh intent for vtxo %s", vtxo.VtxoKey)
+			stale = append(stale, vtxo.VtxoKey)
+			continue
+		}
+		var message tree.IntentMessage
+		if err := message.Decode(authorization.Message); err != nil {
+			log.WithError(err).Warnf("invalid refresh intent for vtxo %s", vtxo.VtxoKey)
+			stale = append(stale, vtxo.VtxoKey)
+			continue
+		}
+
+		requestId, err := r.registerIntent(ctx, *sig, message)
+		if err != nil {
+			log.WithError(err).Warnf("failed to register refresh of vtxo %s", vtxo.VtxoKey)
+			continue
+		}
+
+		r.lock.Lock()
+		r.requests[vtxo.VtxoKey] = requestId
+		r.lock.Unlock()
+
+		log.Infof(
+			"registered tx request %s to refresh vtxo %s on behalf of its owner",
+			requestId, vtxo.VtxoKey,
+		)
+	}
+
+	if len(stale) <= 0 {
+		return nil
+	}
+
+	r.lock.Lock()
+	for _, vtxo := range stale {
+		delete(r.requests, vtxo)
+	}
+	r.lock.Unlock()
+
+	return r.repoManager.RefreshAuthorizations().Delete(ctx, stale)
+}
+
+// isRefreshRequest returns whether the given tx request has been registered
+// on behalf of the owner of the vtxo it spends.
+func (r *autoRefresher) isRefreshRequest(requestId string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, id := range r.requests {
+		if id == requestId {
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorizeRefresh stores the authorization granted by the owner of a vtxo to
+// register the given intent on its behalf once the vtxo enters the refresh
+// margin, along with the forfeit tx of the vtxo signed with
+// SIGHASH_ALL|ANYONECANPAY, so that its connector input can be set once the
+// round is built.
+//
+// The owner isn't online to cosign the vtxo tree of the refresh round, so the
+// intent must not include any cosigner and the tree is signed by the server
+// only: the owner trusts the server not to double spend the tree until the
+// new vtxos are spent or refreshed again by the owner.
+//
+// The forfeit tx isn't bound to any round, so it stays valid as long as the
+// vtxo is unspent. That's why the authorization can't be simply deleted, see
+// RevokeRefreshAuthorization.
+func (s *covenantlessService) AuthorizeRefresh(
+	ctx context.Context, bip322signature bip322.Signature,
+	message tree.IntentMessage, forfeitTx string,
+) error {
+	if !s.autoRefresher.enabled() {
+		return fmt.Errorf("auto refresh is not enabled")
+	}
+
+	outpoints := bip322signature.GetOutpoints()
+	if len(outpoints) != 1 || len(message.InputTapTrees) != 1 {
+		return fmt.Errorf("refresh intent must spend exactly one vtxo")
+	}
+	outpoint := outpoints[0]
+	vtxoKey := domain.VtxoKey{Txid: outpoint.Hash.String(), VOut: outpoint.Index}
+
+	vtxos, err := s.repoManager.Vtxos().GetVtxos(ctx, []domain.VtxoKey{vtxoKey})
+	if err != nil || len(vtxos) <= 0 {
+		return fmt.Errorf("vtxo %s not found", vtxoKey)
+	}
+	vtxo := vtxos[0]
+	if vtxo.Spent || vtxo.Swept || vtxo.Redeemed {
+		return fmt.Errorf("vtxo %s can't be refreshed, already spent or swept", vtxoKey)
+	}
+
+	// the intent is registered whenever the vtxo gets close to expiry
+	if message.ExpireAt > 0 {
+		return fmt.Errorf("refresh intent must not expire")
+	}
+
+	tapTreeBytes, err := hex.DecodeString(message.InputTapTrees[0])
+	if err != nil {
+		return fmt.Errorf("failed to decode taptree: %s", err)
+	}
+	tapscripts, err := tree.DecodeTapTree(tapTreeBytes)
+	if err != nil {
+		return fmt.Errorf("failed to decode taptree: %s", err)
+	}
+	vtxoScript, err := tree.ParseVtxoScript(tapscripts)
+	if err != nil {
+		return fmt.Errorf("failed to parse vtxo script: %s", err)
+	}
+	if err := vtxoScript.Validate(s.pubkey, s.unilateralExitDelay); err != nil {
+		return fmt.Errorf("invalid vtxo script: %s", err)
+	}
+	tapKey, _, err := vtxoScript.TapTree()
+	if err != nil {
+		return fmt.Errorf("failed to get taproot key: %s", err)
+	}
+	vtxoTapKey, err := vtxo.TapKey()
+	if err != nil {
+		return fmt.Errorf("failed to get taproot key: %s", err)
+	}
+	if !bytes.Equal(schnorr.SerializePubKey(tapKey), schnorr.SerializePubKey(vtxoTapKey)) {
+		return fmt.Errorf("descriptor does not match vtxo pubkey")
+	}
+
+	pkScript, err := common.P2TRScript(vtxoTapKey)
+	if err != nil {
+		return fmt.Errorf("failed to create p2tr script: %s", err)
+	}
+	prevout := &wire.TxOut{Value: int64(vtxo.Amount), PkScript: pkScript}
+
+	encodedMessage, err := message.Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode message: %s", err)
+	}
+	if err := bip322signature.Verify(
+		encodedMessage, txscript.NewCannedPrevOutputFetcher(prevout.PkScript, prevout.Value),
+	); err != nil {
+		return fmt.Errorf("invalid BIP0322 proof of funds: %s", err)
+	}
+
+	// a refresh moves the funds to new vtxos of the owner's choice
+	if !bip322signature.ContainsOutputs() || len(message.OnchainOutputIndexes) > 0 {
+		return fmt.Errorf("refresh intent must have offchain outputs only")
+	}
+	if message.Musig2Data == nil {
+		return fmt.Errorf("musig2 data is required for offchain receivers")
+	}
+	if len(message.Musig2Data.CosignersPublicKeys) > 0 {
+		return fmt.Errorf("refresh intent must not include cosigners")
+	}
+
+	if err := s.validateRefreshForfeitTx(forfeitTx, outpoint, prevout); err != nil {
+		return err
+	}
+
+	encodedSig, err := bip322signature.Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode signature: %s", err)
+	}
+
+	return s.repoManager.RefreshAuthorizations().Add(ctx, domain.RefreshAuthorization{
+		VtxoKey:   vtxoKey,
+		Intent:    encodedSig,
+		Message:   encodedMessage,
+		ForfeitTx: forfeitTx,
+		CreatedAt: time.Now().Unix(),
+	})
+}
+
+// RevokeRefreshAuthorization revokes the authorization to refresh the given
+// vtxo. The signature is the BIP322 signature of the refresh revocation
+// message of the vtxo made by its owner.
+//
+// Deleting the authorization wouldn't be enough, the forfeit tx signed with
+// SIGHASH_ALL|ANYONECANPAY could still be used to forfeit the vtxo in any
+// round. The revocation rotates the vtxo instead: it's refreshed right away
+// into the outputs of the authorized intent, regardless of its expiry, and
+// the authorization is dropped once the vtxo is spent. The owner can do the
+// same by settling the vtxo on its own.
+func (s *covenantlessService) RevokeRefreshAuthorization(
+	ctx context.Context, vtxoKey domain.VtxoKey, signature string,
+) error {
+	authorization, err := s.repoManager.RefreshAuthorizations().Get(ctx, vtxoKey)
+	if err != nil {
+		return err
+	}
+
+	vtxos, err := s.repoManager.Vtxos().GetVtxos(ctx, []domain.VtxoKey{vtxoKey})
+	if err != nil || len(vtxos) <= 0 {
+		return fmt.Errorf("vtxo %s not found", vtxoKey)
+	}
+	vtxo := vtxos[0]
+
+	vtxoTapKey, err := vtxo.TapKey()
+	if err != nil {
+		return fmt.Errorf("failed to get taproot key: %s", err)
+	}
+	pkScript, err := common.P2TRScript(vtxoTapKey)
+	if err != nil {
+		return fmt.Errorf("failed to create p2tr script: %s", err)
+	}
+	hash, err := chainhash.NewHashFromStr(vtxoKey.Txid)
+	if err != nil {
+		return fmt.Errorf("invalid vtxo txid: %s", err)
+	}
+
+	if err := common.VerifyRefreshRevocation(
+		signature, pkScript, vtxo.Amount, wire.OutPoint{Hash: *hash, Index: vtxoKey.VOut},
+	); err != nil {
+		return err
+	}
+
+	// a vtxo already spent or swept can't be refreshed anymore
+	if vtxo.Spent || vtxo.Swept || vtxo.Redeemed {
+		return s.repoManager.RefreshAuthorizations().Delete(
+			ctx, []domain.VtxoKey{vtxoKey},
+		)
+	}
+	if authorization.RevokedAt > 0 {
+		return nil
+	}
+
+	authorization.RevokedAt = time.Now().Unix()
+	return s.repoManager.RefreshAuthorizations().Add(ctx, *authorization)
+}
+
+// validateRefreshForfeitTx makes sure that the given forfeit tx spends the
+// vtxo and is signed by its owner with SIGHASH_ALL|ANYONECANPAY. The rest of
+// the tx is verified like any other forfeit once its connector is set.
+func (s *covenantlessService) validateRefreshForfeitTx(
+	forfeitTx string, vtxo wire.OutPoint, prevout *wire.TxOut,
+) error {
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(forfeitTx), true)
+	if err != nil {
+		return fmt.Errorf("failed to parse forfeit tx: %s", err)
+	}
+	if len(ptx.Inputs) != 2 || ptx.UnsignedTx.TxIn[1].PreviousOutPoint != vtxo {
+		return fmt.Errorf("forfeit tx must spend the vtxo as second input")
+	}
+
+	sigs := ptx.Inputs[1].TaprootScriptSpendSig
+	if len(sigs) <= 0 {
+		return fmt.Errorf("missing vtxo signature in forfeit tx")
+	}
+	for _, sig := range sigs {
+		if sig.SigHash != txscript.SigHashAll|txscript.SigHashAnyOneCanPay {
+			return fmt.Errorf("forfeit tx must be signed with SIGHASH_ALL|ANYONECANPAY")
+		}
+	}
+
+	ptx.Inputs[1].WitnessUtxo = prevout
+	// the connector is unknown yet and isn't committed by the signatures
+	if ptx.Inputs[0].WitnessUtxo == nil {
+		ptx.Inputs[0].WitnessUtxo = &wire.TxOut{}
+	}
+	tx, err := ptx.B64Encode()
+	if err != nil {
+		return fmt.Errorf("failed to encode forfeit tx: %s", err)
+	}
+
+	valid, _, err := s.builder.VerifyTapscriptPartialSigs(tx)
+	if err != nil {
+		return fmt.Errorf("invalid forfeit tx: %s", err)
+	}
+	if !valid {
+		return fmt.Errorf("invalid forfeit tx signature")
+	}
+	return nil
+}
+
+// signRefreshForfeitTxs submits the forfeit txs pre-signed by the owners of
+// the vtxos refreshed on their behalf, once the connectors of the round are
+// assigned. An authorization whose forfeit tx turns out to be invalid is
+// dropped so that it doesn't make other rounds fail.
+func (s *covenantlessService) signRefreshForfeitTxs(
+	instance *roundInstance, requests []domain.TxRequest,
+) {
+	ctx := context.Background()
+	signed := 0
+	for _, request := range requests {
+		if !s.autoRefresher.isRefreshRequest(request.Id) {
+			continue
+		}
+
+		for _, vtxo := range request.Inputs {
+			if err := s.signRefreshForfeitTx(ctx, instance, vtxo.VtxoKey); err != nil {
+				log.WithError(err).Warnf(
+					"failed to submit forfeit tx of refreshed vtxo %s, dropping its authorization",
+					vtxo.VtxoKey,
+				)
+				if err := s.repoManager.RefreshAuthorizations().Delete(
+					ctx, []domain.VtxoKey{vtxo.VtxoKey},
+				); err != nil {
+					log.WithError(err).Warn("failed to delete refresh authorization")
+				}
+				continue
+			}
+			signed++
+		}
+	}
+
+	if signed > 0 {
+		go s.checkForfeitsAndBoardingSigsSent(instance)
+	}
+}
+
+func (s *covenantlessService) signRefreshForfeitTx(
+	ctx context.Context, instance *roundInstance, vtxo domain.VtxoKey,
+) error {
+	authorization, err := s.repoManager.RefreshAuthorizations().Get(ctx, vtxo)
+	if err != nil {
+		return err
+	}
+
+	connector, prevout, err := instance.forfeitTxs.connectorOf(vtxo)
+	if err != nil {
+		return err
+	}
+
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(authorization.ForfeitTx), true)
+	if err != nil {
+		return err
+	}
+	ptx.UnsignedTx.TxIn[0].PreviousOutPoint = *connector
+	ptx.Inputs[0].WitnessUtxo = prevout
+	forfeitTx, err := ptx.B64Encode()
+	if err != nil {
+		return err
+	}
+
+	return instance.forfeitTxs.sign([]string{forfeitTx})
+}
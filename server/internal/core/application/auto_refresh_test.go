@@ -0,0 +1,201 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/common/bip322"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoRefresher(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	margin := time.Hour
+
+	newVtxo := func(name string, expireAt int64) domain.Vtxo {
+		return domain.Vtxo{
+			VtxoKey:  domain.VtxoKey{Txid: chainhash.HashH([]byte(name)).String()},
+			Amount:   1000,
+			ExpireAt: expireAt,
+		}
+	}
+	expiring := newVtxo("expiring", now+int64(margin.Seconds())/2)
+	notExpiring := newVtxo("not expiring", now+2*int64(margin.Seconds()))
+	spent := newVtxo("spent", now)
+	spent.Spent = true
+
+	vtxoRepo := &mockedVtxoRepo{vtxos: map[string]domain.Vtxo{}}
+	authRepo := &mockedRefreshAuthorizationRepo{
+		authorizations: map[domain.VtxoKey]domain.RefreshAuthorization{},
+	}
+	for _, vtxo := range []domain.Vtxo{expiring, notExpiring, spent} {
+		vtxoRepo.vtxos[vtxo.String()] = vtxo
+		authRepo.authorizations[vtxo.VtxoKey] = newTestRefreshAuthorization(t, vtxo)
+	}
+	repoManager := &mockedRepoManager{vtxos: vtxoRepo, refreshAuthorizations: authRepo}
+
+	txRequests := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+	roundInputs := newOutpointMap()
+	registered := make([]domain.VtxoKey, 0)
+	registerIntent := func(
+		_ context.Context, sig bip322.Signature, _ tree.IntentMessage,
+	) (string, error) {
+		prevout := sig.TxIn[1].PreviousOutPoint
+		vtxo := vtxoRepo.vtxos[domain.VtxoKey{
+			Txid: prevout.Hash.String(), VOut: prevout.Index,
+		}.String()]
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		if err != nil {
+			return "", err
+		}
+		if err := txRequests.push(*request, nil, nil, nil); err != nil {
+			return "", err
+		}
+		registered = append(registered, vtxo.VtxoKey)
+		return request.Id, nil
+	}
+
+	refresher := newAutoRefresher(
+		repoManager, mockedScheduler{}, txRequests, roundInputs, registerIntent,
+		margin, time.Minute,
+	)
+	require.True(t, refresher.enabled())
+
+	// only the vtxo entering the refresh margin is registered, and the
+	// authorization of the spent one is dropped
+	err := refresher.check(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []domain.VtxoKey{expiring.VtxoKey}, registered)
+	require.Len(t, authRepo.authorizations, 2)
+	require.NotContains(t, authRepo.authorizations, spent.VtxoKey)
+
+	requestId, ok := txRequests.findInput(expiring.VtxoKey)
+	require.True(t, ok)
+	require.True(t, refresher.isRefreshRequest(requestId))
+
+	// the registered request is kept alive rather than registered again
+	err = refresher.check(ctx)
+	require.NoError(t, err)
+	require.Len(t, registered, 1)
+
+	// the authorization is dropped once the vtxo is refreshed
+	expiring.Spent = true
+	vtxoRepo.vtxos[expiring.String()] = expiring
+	err = refresher.check(ctx)
+	require.NoError(t, err)
+	require.NotContains(t, authRepo.authorizations, expiring.VtxoKey)
+	require.False(t, refresher.isRefreshRequest(requestId))
+
+	// a revoked authorization is used to refresh the vtxo right away, even if
+	// not close to expiry, and is dropped only once the vtxo is spent
+	authorization := authRepo.authorizations[notExpiring.VtxoKey]
+	authorization.RevokedAt = now
+	authRepo.authorizations[notExpiring.VtxoKey] = authorization
+	err = refresher.check(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []domain.VtxoKey{expiring.VtxoKey, notExpiring.VtxoKey}, registered)
+	require.Contains(t, authRepo.authorizations, notExpiring.VtxoKey)
+
+	notExpiring.Spent = true
+	vtxoRepo.vtxos[notExpiring.String()] = notExpiring
+	err = refresher.check(ctx)
+	require.NoError(t, err)
+	require.Empty(t, authRepo.authorizations)
+
+	t.Run("disabled", func(t *testing.T) {
+		refresher := newAutoRefresher(
+			repoManager, mockedScheduler{}, txRequests, roundInputs, registerIntent,
+			0, time.Minute,
+		)
+		require.False(t, refresher.enabled())
+	})
+}
+
+func newTestRefreshAuthorization(
+	t *testing.T, vtxo domain.Vtxo,
+) domain.RefreshAuthorization {
+	hash, err := chainhash.NewHashFromStr(vtxo.Txid)
+	require.NoError(t, err)
+
+	tx := wire.NewMsgTx(2)
+	tx.AddTxIn(&wire.TxIn{})
+	tx.AddTxIn(&wire.TxIn{
+		PreviousOutPoint: wire.OutPoint{Hash: *hash, Index: vtxo.VOut},
+	})
+	tx.AddTxOut(&wire.TxOut{Value: int64(vtxo.Amount)})
+	intent, err := bip322.Signature(*tx).Encode()
+	require.NoError(t, err)
+
+	message, err := tree.IntentMessage{
+		InputTapTrees: []string{""},
+		Musig2Data:    &tree.Musig2{},
+	}.Encode()
+	require.NoError(t, err)
+
+	return domain.RefreshAuthorization{
+		VtxoKey: vtxo.VtxoKey,
+		Intent:  intent,
+		Message: message,
+	}
+}
+
+type mockedScheduler struct {
+	ports.SchedulerService
+}
+
+func (mockedScheduler) Unit() ports.TimeUnit {
+	return ports.UnixTime
+}
+
+func (mockedScheduler) AfterNow(expiry int64) bool {
+	return expiry > time.Now().Unix()
+}
+
+type mockedRefreshAuthorizationRepo struct {
+	domain.RefreshAuthorizationRepository
+	authorizations map[domain.VtxoKey]domain.RefreshAuthorization
+}
+
+func (m *mockedRefreshAuthorizationRepo) Add(
+	_ context.Context, authorization domain.RefreshAuthorization,
+) error {
+	m.authorizations[authorization.VtxoKey] = authorization
+	return nil
+}
+
+func (m *mockedRefreshAuthorizationRepo) Get(
+	_ context.Context, vtxo domain.VtxoKey,
+) (*domain.RefreshAuthorization, error) {
+	authorization, ok := m.authorizations[vtxo]
+	if !ok {
+		return nil, fmt.Errorf("refresh authorization for vtxo %s not found", vtxo)
+	}
+	return &authorization, nil
+}
+
+func (m *mockedRefreshAuthorizationRepo) GetAll(
+	context.Context,
+) ([]domain.RefreshAuthorization, error) {
+	authorizations := make([]domain.RefreshAuthorization, 0, len(m.authorizations))
+	for _, authorization := range m.authorizations {
+		authorizations = append(authorizations, authorization)
+	}
+	return authorizations, nil
+}
+
+func (m *mockedRefreshAuthorizationRepo) Delete(
+	_ context.Context, vtxos []domain.VtxoKey,
+) error {
+	for _, vtxo := range vtxos {
+		delete(m.authorizations, vtxo)
+	}
+	return nil
+}
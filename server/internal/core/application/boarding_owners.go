@@ -0,0 +1,115 @@
+package application
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// getBoardingOwners returns the keys of the owners of the given boarding
+// inputs, ie. the keys other than the server one in their forfeit closures.
+func getBoardingOwners(
+	boardingInputs []ports.BoardingInput, server *secp256k1.PublicKey,
+) ([]*secp256k1.PublicKey, error) {
+	serverKey := schnorr.SerializePubKey(server)
+	owners := make([]*secp256k1.PublicKey, 0)
+	seen := make(map[string]struct{})
+	for _, input := range boardingInputs {
+		vtxoScript, err := tree.ParseVtxoScript(input.Tapscripts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse boarding input %s script: %s", input.String(), err)
+		}
+
+		for _, closure := range vtxoScript.ForfeitClosures() {
+			for _, key := range tree.ForfeitClosurePubKeys(closure) {
+				xonlyKey := schnorr.SerializePubKey(key)
+				if bytes.Equal(xonlyKey, serverKey) {
+					continue
+				}
+				if _, ok := seen[hex.EncodeToString(xonlyKey)]; ok {
+					continue
+				}
+				seen[hex.EncodeToString(xonlyKey)] = struct{}{}
+				owners = append(owners, key)
+			}
+		}
+	}
+	return owners, nil
+}
+
+// validateBoardingOwners makes sure that every receiver belongs to one of the
+// owners of the boarding inputs. Offchain receivers must be default vtxo
+// scripts of an owner, onchain ones must be taproot addresses of an owner key.
+func validateBoardingOwners(
+	owners []*secp256k1.PublicKey, receivers []domain.Receiver,
+	server *secp256k1.PublicKey, exitDelay common.RelativeLocktime,
+	chainParams *chaincfg.Params,
+) error {
+	if len(owners) <= 0 {
+		return fmt.Errorf("failed to derive the owner of the boarding inputs")
+	}
+
+	ownedVtxoKeys := make(map[string]struct{})
+	ownedScripts := make([][]byte, 0)
+	for _, owner := range owners {
+		tapKey, _, err := tree.NewDefaultVtxoScript(owner, server, exitDelay).TapTree()
+		if err != nil {
+			return fmt.Errorf("failed to get vtxo taproot key: %s", err)
+		}
+		ownedVtxoKeys[hex.EncodeToString(schnorr.SerializePubKey(tapKey))] = struct{}{}
+
+		for _, key := range []*secp256k1.PublicKey{
+			owner, txscript.ComputeTaprootKeyNoScript(owner),
+		} {
+			script, err := common.P2TRScript(key)
+			if err != nil {
+				return fmt.Errorf("failed to get owner script: %s", err)
+			}
+			ownedScripts = append(ownedScripts, script)
+		}
+	}
+
+	for _, rcv := range receivers {
+		if !rcv.IsOnchain() {
+			if _, ok := ownedVtxoKeys[rcv.PubKey]; !ok {
+				return fmt.Errorf(
+					"receiver %s is not owned by the owner of the boarding inputs", rcv.PubKey,
+				)
+			}
+			continue
+		}
+
+		addr, err := btcutil.DecodeAddress(rcv.OnchainAddress, chainParams)
+		if err != nil {
+			return fmt.Errorf("invalid onchain address %s: %s", rcv.OnchainAddress, err)
+		}
+		script, err := txscript.PayToAddrScript(addr)
+		if err != nil {
+			return fmt.Errorf("invalid onchain address %s: %s", rcv.OnchainAddress, err)
+		}
+
+		owned := false
+		for _, ownedScript := range ownedScripts {
+			if bytes.Equal(script, ownedScript) {
+				owned = true
+				break
+			}
+		}
+		if !owned {
+			return fmt.Errorf(
+				"receiver %s is not owned by the owner of the boarding inputs", rcv.OnchainAddress,
+			)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package application
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoardingOwners(t *testing.T) {
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	owner := ownerKey.PubKey()
+	other := otherKey.PubKey()
+	server := serverKey.PubKey()
+	boardingExitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 1024}
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	chainParams := &chaincfg.RegressionNetParams
+
+	tapscripts, err := tree.NewDefaultVtxoScript(server, owner, boardingExitDelay).Encode()
+	require.NoError(t, err)
+	boardingInputs := []ports.BoardingInput{{
+		Input: ports.Input{
+			VtxoKey:    domain.VtxoKey{Txid: "aa"},
+			Tapscripts: tapscripts,
+		},
+		Amount: 1000,
+	}}
+
+	owners, err := getBoardingOwners(boardingInputs, server)
+	require.NoError(t, err)
+	require.Len(t, owners, 1)
+	// the keys are parsed from the x-only keys of the scripts
+	require.Equal(t, schnorr.SerializePubKey(owner), schnorr.SerializePubKey(owners[0]))
+
+	vtxoReceiver := func(key *secp256k1.PublicKey) domain.Receiver {
+		tapKey, _, err := tree.NewDefaultVtxoScript(key, server, exitDelay).TapTree()
+		require.NoError(t, err)
+		return domain.Receiver{
+			PubKey: hex.EncodeToString(schnorr.SerializePubKey(tapKey)),
+			Amount: 500,
+		}
+	}
+	onchainReceiver := func(key *secp256k1.PublicKey) domain.Receiver {
+		addr, err := btcutil.NewAddressTaproot(
+			schnorr.SerializePubKey(txscript.ComputeTaprootKeyNoScript(key)), chainParams,
+		)
+		require.NoError(t, err)
+		return domain.Receiver{OnchainAddress: addr.EncodeAddress(), Amount: 500}
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		receivers := []domain.Receiver{vtxoReceiver(owner), onchainReceiver(owner)}
+		err := validateBoardingOwners(owners, receivers, server, exitDelay, chainParams)
+		require.NoError(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		fixtures := [][]domain.Receiver{
+			{vtxoReceiver(owner), vtxoReceiver(other)},
+			{vtxoReceiver(owner), onchainReceiver(other)},
+		}
+		for _, receivers := range fixtures {
+			err := validateBoardingOwners(owners, receivers, server, exitDelay, chainParams)
+			require.ErrorContains(t, err, "is not owned by the owner of the boarding inputs")
+		}
+
+		err := validateBoardingOwners(nil, fixtures[0], server, exitDelay, chainParams)
+		require.Error(t, err)
+	})
+}
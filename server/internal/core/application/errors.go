@@ -2,6 +2,18 @@ package application
 
 import "fmt"
 
+// ErrRoundTimedOut is the reason of the failure of a round aborted because it
+// exceeded its deadline, whatever phase it was stuck in.
+var ErrRoundTimedOut = fmt.Errorf("round timed out")
+
+// ErrRoundAborted is the reason of the failure of a round aborted by the
+// server shutting down, its participants can register again for a next round.
+var ErrRoundAborted = fmt.Errorf("round aborted, please retry")
+
+// ErrShuttingDown is returned for the tx requests submitted while the server
+// is shutting down.
+var ErrShuttingDown = fmt.Errorf("server is shutting down")
+
 type errTxRequestNotFound struct {
 	id string
 }
@@ -9,3 +21,19 @@ type errTxRequestNotFound struct {
 func (e errTxRequestNotFound) Error() string {
 	return fmt.Sprintf("tx request %s not found", e.id)
 }
+
+type errTooManyReceivers struct {
+	count int
+	max   int64
+}
+
+func (e errTooManyReceivers) Error() string {
+	return fmt.Sprintf(
+		"too many receivers, tx request has %d, max %d per request", e.count, e.max,
+	)
+}
+
+// ErrMempoolLimitExceeded is returned when accepting a redeem tx would create
+// a chain of unconfirmed txs longer than the mempool ancestor limit, meaning
+// that the redeem tx couldn't be broadcasted in case of unilateral exit.
+var ErrMempoolLimitExceeded = fmt.Errorf("mempool ancestor limit exceeded")
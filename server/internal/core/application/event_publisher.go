@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// RoundEventType identifies the stage of the round lifecycle a RoundEvent is
+// emitted at.
+type RoundEventType string
+
+const (
+	RoundEventStarted           RoundEventType = "round_started"
+	RoundEventTxRequestsPopped  RoundEventType = "tx_requests_popped"
+	RoundEventTreeSigned        RoundEventType = "tree_signed"
+	RoundEventForfeitsCollected RoundEventType = "forfeits_collected"
+	RoundEventFinalized         RoundEventType = "round_finalized"
+	RoundEventSweepTriggered    RoundEventType = "sweep_triggered"
+)
+
+// RoundEvent is emitted by the service at every stage of a round, for metrics
+// and alerting. Only the fields relevant for the type of event are set.
+type RoundEvent struct {
+	Type      RoundEventType
+	RoundId   string
+	RoundTxid string
+	Timestamp time.Time
+	// NumOfTxRequests is the number of tx requests popped for the round.
+	NumOfTxRequests int
+	// NumOfForfeitTxs is the number of forfeit txs collected for the round.
+	NumOfForfeitTxs int
+	// RoundTxids are the rounds whose vtxo trees are being swept.
+	RoundTxids []string
+}
+
+// EventPublisher is notified of the round events. Publish is called from the
+// round engine, therefore it must not block.
+type EventPublisher interface {
+	Publish(ctx context.Context, event RoundEvent)
+}
+
+// NoopEventPublisher discards all events, it's the default publisher of the
+// service.
+type NoopEventPublisher struct{}
+
+func (NoopEventPublisher) Publish(context.Context, RoundEvent) {}
+
+// ChanEventPublisher forwards the events to a buffered channel. Events are
+// dropped if the channel is full.
+type ChanEventPublisher struct {
+	events chan RoundEvent
+}
+
+// NewChanEventPublisher returns a ChanEventPublisher with a channel of the
+// given size.
+func NewChanEventPublisher(size int) *ChanEventPublisher {
+	return &ChanEventPublisher{events: make(chan RoundEvent, size)}
+}
+
+func (p *ChanEventPublisher) Publish(_ context.Context, event RoundEvent) {
+	select {
+	case p.events <- event:
+	default:
+		log.Warnf("event publisher: channel full, dropped %s event", event.Type)
+	}
+}
+
+// Events returns the channel the events are forwarded to.
+func (p *ChanEventPublisher) Events() <-chan RoundEvent {
+	return p.events
+}
+
+func publishRoundEvent(publisher EventPublisher, event RoundEvent) {
+	if publisher == nil {
+		return
+	}
+	if event.Timestamp.IsZero() {
+		event.Timestamp = time.Now()
+	}
+	publisher.Publish(context.Background(), event)
+}
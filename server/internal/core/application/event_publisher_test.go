@@ -0,0 +1,32 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChanEventPublisher(t *testing.T) {
+	publisher := NewChanEventPublisher(2)
+
+	publishRoundEvent(publisher, RoundEvent{Type: RoundEventStarted, RoundId: "round"})
+	publishRoundEvent(publisher, RoundEvent{
+		Type: RoundEventTxRequestsPopped, RoundId: "round", NumOfTxRequests: 3,
+	})
+	// the channel is full, the event is dropped without blocking
+	publishRoundEvent(publisher, RoundEvent{Type: RoundEventTreeSigned, RoundId: "round"})
+
+	event := <-publisher.Events()
+	require.Equal(t, RoundEventStarted, event.Type)
+	require.Equal(t, "round", event.RoundId)
+	require.False(t, event.Timestamp.IsZero())
+
+	event = <-publisher.Events()
+	require.Equal(t, RoundEventTxRequestsPopped, event.Type)
+	require.Equal(t, 3, event.NumOfTxRequests)
+
+	require.Empty(t, publisher.Events())
+
+	// a nil publisher is a no-op
+	publishRoundEvent(nil, RoundEvent{Type: RoundEventStarted})
+}
@@ -0,0 +1,139 @@
+package application
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// ExitScriptType is a type of output script that collaborative exits are
+// allowed to send funds to.
+type ExitScriptType string
+
+const (
+	ExitScriptTypeP2PKH  ExitScriptType = "p2pkh"
+	ExitScriptTypeP2SH   ExitScriptType = "p2sh"
+	ExitScriptTypeP2WPKH ExitScriptType = "p2wpkh"
+	ExitScriptTypeP2WSH  ExitScriptType = "p2wsh"
+	ExitScriptTypeP2TR   ExitScriptType = "p2tr"
+)
+
+var ExitScriptTypes = []ExitScriptType{
+	ExitScriptTypeP2PKH,
+	ExitScriptTypeP2SH,
+	ExitScriptTypeP2WPKH,
+	ExitScriptTypeP2WSH,
+	ExitScriptTypeP2TR,
+}
+
+func (t ExitScriptType) IsValid() bool {
+	for _, scriptType := range ExitScriptTypes {
+		if t == scriptType {
+			return true
+		}
+	}
+	return false
+}
+
+var scriptClassToExitScriptType = map[txscript.ScriptClass]ExitScriptType{
+	txscript.PubKeyHashTy:          ExitScriptTypeP2PKH,
+	txscript.ScriptHashTy:          ExitScriptTypeP2SH,
+	txscript.WitnessV0PubKeyHashTy: ExitScriptTypeP2WPKH,
+	txscript.WitnessV0ScriptHashTy: ExitScriptTypeP2WSH,
+	txscript.WitnessV1TaprootTy:    ExitScriptTypeP2TR,
+}
+
+// exitPolicy restricts the destinations of collaborative exits to the given
+// script types and addresses. An empty list means no restriction, if both are
+// set a destination must satisfy both.
+type exitPolicy struct {
+	chainParams *chaincfg.Params
+	scriptTypes map[ExitScriptType]struct{}
+	// scripts are the hex encoded output scripts of the allowed addresses
+	scripts map[string]struct{}
+}
+
+func newExitPolicy(
+	scriptTypes []ExitScriptType, addresses []string, chainParams *chaincfg.Params,
+) (*exitPolicy, error) {
+	policy := &exitPolicy{
+		chainParams: chainParams,
+		scriptTypes: make(map[ExitScriptType]struct{}),
+		scripts:     make(map[string]struct{}),
+	}
+
+	for _, scriptType := range scriptTypes {
+		if !scriptType.IsValid() {
+			return nil, fmt.Errorf(
+				"invalid exit script type %s, must be one of %v", scriptType, ExitScriptTypes,
+			)
+		}
+		policy.scriptTypes[scriptType] = struct{}{}
+	}
+
+	for _, addr := range addresses {
+		script, err := policy.outputScript(addr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed exit address %s: %s", addr, err)
+		}
+		policy.scripts[hex.EncodeToString(script)] = struct{}{}
+	}
+	return policy, nil
+}
+
+// validate returns an error if the policy doesn't allow to exit to the given
+// onchain address.
+func (p *exitPolicy) validate(addr string) error {
+	if p == nil || (len(p.scriptTypes) <= 0 && len(p.scripts) <= 0) {
+		return nil
+	}
+
+	script, err := p.outputScript(addr)
+	if err != nil {
+		return fmt.Errorf("invalid onchain address %s: %s", addr, err)
+	}
+
+	if len(p.scriptTypes) > 0 {
+		scriptType, ok := scriptClassToExitScriptType[txscript.GetScriptClass(script)]
+		if !ok {
+			return fmt.Errorf("exit to %s not allowed, unknown script type", addr)
+		}
+		if _, ok := p.scriptTypes[scriptType]; !ok {
+			return fmt.Errorf(
+				"exit to %s not allowed, script type %s is not one of %v",
+				addr, scriptType, p.allowedScriptTypes(),
+			)
+		}
+	}
+
+	if len(p.scripts) > 0 {
+		if _, ok := p.scripts[hex.EncodeToString(script)]; !ok {
+			return fmt.Errorf("exit to %s not allowed, address is not in the allowlist", addr)
+		}
+	}
+	return nil
+}
+
+func (p *exitPolicy) outputScript(addr string) ([]byte, error) {
+	if p.chainParams == nil {
+		return nil, fmt.Errorf("unknown network params")
+	}
+	decodedAddr, err := btcutil.DecodeAddress(addr, p.chainParams)
+	if err != nil {
+		return nil, err
+	}
+	return txscript.PayToAddrScript(decodedAddr)
+}
+
+func (p *exitPolicy) allowedScriptTypes() []ExitScriptType {
+	scriptTypes := make([]ExitScriptType, 0, len(p.scriptTypes))
+	for _, scriptType := range ExitScriptTypes {
+		if _, ok := p.scriptTypes[scriptType]; ok {
+			scriptTypes = append(scriptTypes, scriptType)
+		}
+	}
+	return scriptTypes
+}
@@ -0,0 +1,81 @@
+package application
+
+import (
+	"testing"
+
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitPolicy(t *testing.T) {
+	chainParams := &chaincfg.RegressionNetParams
+
+	key, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	pubkeyHash := btcutil.Hash160(key.PubKey().SerializeCompressed())
+
+	p2pkh, err := btcutil.NewAddressPubKeyHash(pubkeyHash, chainParams)
+	require.NoError(t, err)
+	p2wpkh, err := btcutil.NewAddressWitnessPubKeyHash(pubkeyHash, chainParams)
+	require.NoError(t, err)
+	p2tr, err := btcutil.NewAddressTaproot(schnorr.SerializePubKey(key.PubKey()), chainParams)
+	require.NoError(t, err)
+
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherP2tr, err := btcutil.NewAddressTaproot(
+		schnorr.SerializePubKey(otherKey.PubKey()), chainParams,
+	)
+	require.NoError(t, err)
+
+	t.Run("no restrictions", func(t *testing.T) {
+		policy, err := newExitPolicy(nil, nil, chainParams)
+		require.NoError(t, err)
+		for _, addr := range []btcutil.Address{p2pkh, p2wpkh, p2tr} {
+			require.NoError(t, policy.validate(addr.EncodeAddress()))
+		}
+	})
+
+	t.Run("script types", func(t *testing.T) {
+		policy, err := newExitPolicy(
+			[]ExitScriptType{ExitScriptTypeP2WPKH, ExitScriptTypeP2TR}, nil, chainParams,
+		)
+		require.NoError(t, err)
+		require.NoError(t, policy.validate(p2wpkh.EncodeAddress()))
+		require.NoError(t, policy.validate(p2tr.EncodeAddress()))
+
+		err = policy.validate(p2pkh.EncodeAddress())
+		require.ErrorContains(t, err, "script type p2pkh is not one of [p2wpkh p2tr]")
+	})
+
+	t.Run("addresses", func(t *testing.T) {
+		policy, err := newExitPolicy(
+			[]ExitScriptType{ExitScriptTypeP2TR}, []string{p2tr.EncodeAddress()}, chainParams,
+		)
+		require.NoError(t, err)
+		require.NoError(t, policy.validate(p2tr.EncodeAddress()))
+
+		err = policy.validate(otherP2tr.EncodeAddress())
+		require.ErrorContains(t, err, "address is not in the allowlist")
+
+		// allowed addresses must satisfy the allowed script types too
+		err = policy.validate(p2wpkh.EncodeAddress())
+		require.ErrorContains(t, err, "script type p2wpkh")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := newExitPolicy([]ExitScriptType{"p2ms"}, nil, chainParams)
+		require.Error(t, err)
+
+		_, err = newExitPolicy(nil, []string{"not an address"}, chainParams)
+		require.Error(t, err)
+
+		policy, err := newExitPolicy([]ExitScriptType{ExitScriptTypeP2TR}, nil, chainParams)
+		require.NoError(t, err)
+		err = policy.validate("not an address")
+		require.ErrorContains(t, err, "invalid onchain address")
+	})
+}
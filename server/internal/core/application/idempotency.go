@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// redeemTxIdempotencyTTL is for how long the result of a redeem tx submitted
+// with an idempotency key is returned to the resubmissions with the same key.
+const redeemTxIdempotencyTTL = 10 * time.Minute
+
+// ErrIdempotencyKeyReused is returned when an idempotency key is reused for a
+// redeem tx different from the one it was first submitted with.
+var ErrIdempotencyKeyReused = fmt.Errorf("idempotency key already used for another tx")
+
+type redeemTxSubmission struct {
+	txid      string
+	done      chan struct{}
+	signedTx  string
+	err       error
+	expiresAt time.Time
+}
+
+// redeemTxSubmissions caches the results of the redeem txs submitted with an
+// idempotency key, so that a resubmission of the same tx with the same key
+// gets the original result rather than a double spend error. Failed
+// submissions are not cached and can be retried.
+type redeemTxSubmissions struct {
+	lock        *sync.Mutex
+	ttl         time.Duration
+	submissions map[string]*redeemTxSubmission
+}
+
+func newRedeemTxSubmissions(ttl time.Duration) *redeemTxSubmissions {
+	return &redeemTxSubmissions{
+		lock:        &sync.Mutex{},
+		ttl:         ttl,
+		submissions: make(map[string]*redeemTxSubmission),
+	}
+}
+
+// do calls submit only once for the given key and txid, concurrent and later
+// calls with the same key wait for its outcome and get the same result.
+func (m *redeemTxSubmissions) do(
+	ctx context.Context, key, txid string, submit func() (string, error),
+) (string, string, error) {
+	submission, ok := m.getOrAdd(key, txid)
+	if ok {
+		if submission.txid != txid {
+			return "", "", ErrIdempotencyKeyReused
+		}
+
+		select {
+		case <-submission.done:
+		case <-ctx.Done():
+			return "", "", ctx.Err()
+		}
+		if submission.err != nil {
+			return "", "", submission.err
+		}
+		return submission.signedTx, submission.txid, nil
+	}
+
+	signedTx, err := submit()
+
+	m.lock.Lock()
+	submission.signedTx, submission.err = signedTx, err
+	submission.expiresAt = time.Now().Add(m.ttl)
+	if err != nil {
+		delete(m.submissions, key)
+	}
+	m.lock.Unlock()
+	close(submission.done)
+
+	if err != nil {
+		return "", "", err
+	}
+	return signedTx, txid, nil
+}
+
+// getOrAdd returns the submission of the given key if any and not expired,
+// otherwise adds a new one for the given txid.
+func (m *redeemTxSubmissions) getOrAdd(key, txid string) (*redeemTxSubmission, bool) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	now := time.Now()
+	for k, submission := range m.submissions {
+		if submission.expired(now) {
+			delete(m.submissions, k)
+		}
+	}
+
+	if submission, ok := m.submissions[key]; ok {
+		return submission, true
+	}
+
+	submission := &redeemTxSubmission{txid: txid, done: make(chan struct{})}
+	m.submissions[key] = submission
+	return submission, false
+}
+
+// expired returns whether the submission is completed and older than the ttl,
+// the pending ones never expire.
+func (s *redeemTxSubmission) expired(now time.Time) bool {
+	return !s.expiresAt.IsZero() && now.After(s.expiresAt)
+}
@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedeemTxSubmissions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("resubmission", func(t *testing.T) {
+		submissions := newRedeemTxSubmissions(time.Minute)
+		calls := int32(0)
+		submit := func() (string, error) {
+			if atomic.AddInt32(&calls, 1) > 1 {
+				return "", fmt.Errorf("vtxo already spent")
+			}
+			return "signedtx", nil
+		}
+
+		signedTx, txid, err := submissions.do(ctx, "key", "txid", submit)
+		require.NoError(t, err)
+		require.Equal(t, "signedtx", signedTx)
+		require.Equal(t, "txid", txid)
+
+		// the same tx with the same key gets the original result
+		signedTx, txid, err = submissions.do(ctx, "key", "txid", submit)
+		require.NoError(t, err)
+		require.Equal(t, "signedtx", signedTx)
+		require.Equal(t, "txid", txid)
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		// another tx can't reuse the key
+		_, _, err = submissions.do(ctx, "key", "othertxid", submit)
+		require.ErrorIs(t, err, ErrIdempotencyKeyReused)
+
+		// without the key, the resubmission is processed again
+		_, _, err = submissions.do(ctx, "otherkey", "txid", submit)
+		require.EqualError(t, err, "vtxo already spent")
+	})
+
+	t.Run("concurrent submissions", func(t *testing.T) {
+		submissions := newRedeemTxSubmissions(time.Minute)
+		calls := int32(0)
+		submit := func() (string, error) {
+			atomic.AddInt32(&calls, 1)
+			time.Sleep(50 * time.Millisecond)
+			return "signedtx", nil
+		}
+
+		signedTxs := make([]string, 5)
+		errs := make([]error, 5)
+		wg := &sync.WaitGroup{}
+		for i := range signedTxs {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				signedTxs[i], _, errs[i] = submissions.do(ctx, "key", "txid", submit)
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range signedTxs {
+			require.NoError(t, errs[i])
+			require.Equal(t, "signedtx", signedTxs[i])
+		}
+		require.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("failed submission", func(t *testing.T) {
+		submissions := newRedeemTxSubmissions(time.Minute)
+
+		_, _, err := submissions.do(ctx, "key", "txid", func() (string, error) {
+			return "", fmt.Errorf("invalid tx")
+		})
+		require.EqualError(t, err, "invalid tx")
+
+		// failures are not cached, the submission can be retried
+		signedTx, _, err := submissions.do(ctx, "key", "txid", func() (string, error) {
+			return "signedtx", nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "signedtx", signedTx)
+	})
+
+	t.Run("expiration", func(t *testing.T) {
+		submissions := newRedeemTxSubmissions(10 * time.Millisecond)
+
+		_, _, err := submissions.do(ctx, "key", "txid", func() (string, error) {
+			return "signedtx", nil
+		})
+		require.NoError(t, err)
+
+		time.Sleep(20 * time.Millisecond)
+
+		// once expired, the key can be used for another tx
+		_, _, err = submissions.do(ctx, "key", "othertxid", func() (string, error) {
+			return "othersignedtx", nil
+		})
+		require.NoError(t, err)
+		require.Len(t, submissions.submissions, 1)
+	})
+}
@@ -0,0 +1,185 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/server/internal/core/ports"
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// LiquidityStatus is the snapshot of the funds of the server wallet that can
+// be used to fund new rounds.
+type LiquidityStatus struct {
+	// AvailableBalance is the spendable onchain balance of the wallet.
+	AvailableBalance uint64
+	// ReservedLiquidity is the amount committed by the rounds in flight.
+	ReservedLiquidity uint64
+	Threshold         uint64
+}
+
+// FreeLiquidity returns the balance not yet committed to any round.
+func (s LiquidityStatus) FreeLiquidity() uint64 {
+	if s.AvailableBalance <= s.ReservedLiquidity {
+		return 0
+	}
+	return s.AvailableBalance - s.ReservedLiquidity
+}
+
+// IsLow returns whether the free liquidity fell below the threshold. A zero
+// threshold disables the check.
+func (s LiquidityStatus) IsLow() bool {
+	return s.Threshold > 0 && s.FreeLiquidity() < s.Threshold
+}
+
+// liquidityMonitor periodically checks the free liquidity of the server and
+// warns the operator when it falls below the threshold, so that the wallet
+// can be topped up before rounds start failing for lack of funds.
+type liquidityMonitor struct {
+	lock      *sync.Mutex
+	wallet    ports.WalletService
+	rounds    *roundInstances
+	threshold uint64
+	interval  time.Duration
+
+	status *LiquidityStatus
+	quit   chan struct{}
+
+	alertsCounter metric.Int64Counter
+}
+
+func newLiquidityMonitor(
+	wallet ports.WalletService, rounds *roundInstances,
+	threshold uint64, interval time.Duration,
+) *liquidityMonitor {
+	m := &liquidityMonitor{
+		lock:      &sync.Mutex{},
+		wallet:    wallet,
+		rounds:    rounds,
+		threshold: threshold,
+		interval:  interval,
+	}
+	m.initMetrics()
+	return m
+}
+
+// start runs the periodic check in background until stop is called.
+func (m *liquidityMonitor) start() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.quit != nil || m.interval <= 0 {
+		return
+	}
+	m.quit = make(chan struct{})
+
+	go func(quit chan struct{}) {
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := m.check(context.Background()); err != nil {
+				log.WithError(err).Warn("failed to check liquidity")
+			}
+
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(m.quit)
+}
+
+func (m *liquidityMonitor) stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.quit != nil {
+		close(m.quit)
+		m.quit = nil
+	}
+}
+
+// check refreshes the liquidity status and logs a warning if the free
+// liquidity is below the threshold. The warning is logged only when the
+// status changes from ok to low to not flood the logs at every check.
+func (m *liquidityMonitor) check(ctx context.Context) (*LiquidityStatus, error) {
+	availableBalance, _, err := m.wallet.MainAccountBalance(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &LiquidityStatus{
+		AvailableBalance:  availableBalance,
+		ReservedLiquidity: m.rounds.reservedLiquidity(),
+		Threshold:         m.threshold,
+	}
+
+	m.lock.Lock()
+	wasLow := m.status != nil && m.status.IsLow()
+	m.status = status
+	m.lock.Unlock()
+
+	if !status.IsLow() {
+		if wasLow {
+			log.Infof("server liquidity back to %d sats", status.FreeLiquidity())
+		}
+		return status, nil
+	}
+
+	if !wasLow {
+		log.Warnf(
+			"server is low on liquidity: %d sats free (balance %d, reserved %d), "+
+				"threshold %d sats, top up the wallet to keep rounds running",
+			status.FreeLiquidity(), status.AvailableBalance,
+			status.ReservedLiquidity, status.Threshold,
+		)
+		if m.alertsCounter != nil {
+			m.alertsCounter.Add(ctx, 1)
+		}
+	}
+	return status, nil
+}
+
+func (m *liquidityMonitor) initMetrics() {
+	meter := otel.Meter("ark.wallet")
+
+	counter, err := meter.Int64Counter(
+		"ark_wallet_low_liquidity_alerts",
+		metric.WithDescription("number of times the free liquidity fell below the threshold"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create low liquidity alerts counter")
+		return
+	}
+	m.alertsCounter = counter
+
+	gauge, err := meter.Int64ObservableGauge(
+		"ark_wallet_free_liquidity_sats",
+		metric.WithDescription("wallet balance not committed to any round in flight"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create free liquidity gauge")
+		return
+	}
+
+	if _, err := meter.RegisterCallback(
+		func(_ context.Context, obs metric.Observer) error {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+
+			if m.status == nil {
+				return nil
+			}
+			obs.ObserveInt64(gauge, int64(m.status.FreeLiquidity()))
+			return nil
+		},
+		gauge,
+	); err != nil {
+		log.WithError(err).Warn("failed to register free liquidity callback")
+	}
+}
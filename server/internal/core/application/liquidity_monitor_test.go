@@ -0,0 +1,60 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiquidityMonitor(t *testing.T) {
+	ctx := context.Background()
+	vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte("vtxo")).String()}}
+	round := newTestRoundInstance(t, "round", vtxo)
+
+	rounds := newRoundInstances()
+	rounds.add(round)
+	wallet := &mockedWallet{balance: 10000}
+
+	t.Run("ok", func(t *testing.T) {
+		monitor := newLiquidityMonitor(wallet, rounds, 5000, 0)
+
+		status, err := monitor.check(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10000), status.AvailableBalance)
+		require.Zero(t, status.ReservedLiquidity)
+		require.Equal(t, uint64(10000), status.FreeLiquidity())
+		require.False(t, status.IsLow())
+	})
+
+	t.Run("low", func(t *testing.T) {
+		monitor := newLiquidityMonitor(wallet, rounds, 5000, 0)
+
+		err := rounds.reserveLiquidity(round.round.Id, 6000, wallet.balance)
+		require.NoError(t, err)
+
+		// the liquidity reserved by the rounds in flight is not free
+		status, err := monitor.check(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(6000), status.ReservedLiquidity)
+		require.Equal(t, uint64(4000), status.FreeLiquidity())
+		require.True(t, status.IsLow())
+
+		// the liquidity is released once the round ends
+		rounds.remove(round.round.Id)
+		defer rounds.add(round)
+
+		status, err = monitor.check(ctx)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10000), status.FreeLiquidity())
+		require.False(t, status.IsLow())
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		status := LiquidityStatus{AvailableBalance: 1000, ReservedLiquidity: 2000}
+		require.Zero(t, status.FreeLiquidity())
+		require.False(t, status.IsLow())
+	})
+}
@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+)
+
+// mempoolAncestorsChecker makes sure that the redeem txs accepted by the server
+// can be broadcasted in case of unilateral exit. To exit a vtxo, its owner must
+// broadcast the branch of the vtxo tree it belongs to and all the redeem txs
+// leading to it: those txs are all unconfirmed ancestors of the last one and
+// can't be more than the mempool limit.
+// The round txs are expected to be confirmed and aren't counted.
+type mempoolAncestorsChecker struct {
+	vtxoRepo  domain.VtxoRepository
+	roundRepo domain.RoundRepository
+	// limit is the max number of unconfirmed ancestors of a redeem tx,
+	// including the tx itself, 0 means no limit
+	limit int64
+}
+
+// validate returns ErrMempoolLimitExceeded if a redeem tx spending the given
+// vtxos would have more unconfirmed ancestors than the limit.
+func (c *mempoolAncestorsChecker) validate(
+	ctx context.Context, spentVtxos []domain.Vtxo,
+) error {
+	if c == nil || c.limit <= 0 {
+		return nil
+	}
+
+	count, err := c.countAncestors(ctx, spentVtxos)
+	if err != nil {
+		return fmt.Errorf("failed to count unconfirmed ancestors: %s", err)
+	}
+	// the redeem tx counts as well
+	if count+1 > c.limit {
+		return fmt.Errorf(
+			"%w: the redeem tx would have more than %d unconfirmed ancestors, "+
+				"settle the vtxos before spending them offchain",
+			ErrMempoolLimitExceeded, c.limit-1,
+		)
+	}
+	return nil
+}
+
+// countAncestors returns the number of distinct txs, up to the limit, that
+// must be broadcasted to unilaterally exit the given vtxos.
+func (c *mempoolAncestorsChecker) countAncestors(
+	ctx context.Context, vtxos []domain.Vtxo,
+) (int64, error) {
+	ancestors := make(map[string]struct{})
+	visited := make(map[string]struct{})
+	vtxoTrees := make(map[string]*domain.Round)
+
+	queue := vtxos
+	for len(queue) > 0 && int64(len(ancestors)) < c.limit {
+		vtxo := queue[0]
+		queue = queue[1:]
+
+		if _, ok := visited[vtxo.Txid]; ok {
+			continue
+		}
+		visited[vtxo.Txid] = struct{}{}
+
+		if !vtxo.IsPending() {
+			round, ok := vtxoTrees[vtxo.RoundTxid]
+			if !ok {
+				var err error
+				round, err = c.roundRepo.GetRoundWithTxid(ctx, vtxo.RoundTxid)
+				if err != nil {
+					return 0, fmt.Errorf("failed to get round %s: %s", vtxo.RoundTxid, err)
+				}
+				vtxoTrees[vtxo.RoundTxid] = round
+			}
+
+			branch, err := round.VtxoTree.Branch(vtxo.Txid)
+			if err != nil {
+				return 0, fmt.Errorf("failed to get branch of vtxo %s: %s", vtxo.VtxoKey, err)
+			}
+			for _, node := range branch {
+				ancestors[node.Txid] = struct{}{}
+			}
+			continue
+		}
+
+		ancestors[vtxo.Txid] = struct{}{}
+
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(vtxo.RedeemTx), true)
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse redeem tx %s: %s", vtxo.Txid, err)
+		}
+		inputs := make([]domain.VtxoKey, 0, len(ptx.UnsignedTx.TxIn))
+		for _, in := range ptx.UnsignedTx.TxIn {
+			inputs = append(inputs, domain.VtxoKey{
+				Txid: in.PreviousOutPoint.Hash.String(),
+				VOut: in.PreviousOutPoint.Index,
+			})
+		}
+		spentVtxos, err := c.vtxoRepo.GetVtxos(ctx, inputs)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get inputs of redeem tx %s: %s", vtxo.Txid, err)
+		}
+		queue = append(queue, spentVtxos...)
+	}
+
+	return int64(len(ancestors)), nil
+}
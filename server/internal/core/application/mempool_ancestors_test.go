@@ -0,0 +1,91 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMempoolAncestorsChecker(t *testing.T) {
+	ctx := context.Background()
+	vtxoTree := makeTestVtxoTree(4)
+	leaves := vtxoTree.Leaves()
+	round := &domain.Round{Txid: "roundtx", VtxoTree: vtxoTree}
+
+	// leaf1 and leaf2 share the root of the tree, their branches are made of
+	// 3 txs, the root included
+	leaf1 := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: leaves[0].Txid}, RoundTxid: round.Txid}
+	leaf2 := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: leaves[2].Txid}, RoundTxid: round.Txid}
+	// chain of 2 redeem txs spending leaf1
+	redeem1 := makePendingVtxo(t, leaf1.VtxoKey, round.Txid)
+	redeem2 := makePendingVtxo(t, redeem1.VtxoKey, round.Txid)
+
+	vtxoRepo := &mockedVtxoRepo{
+		vtxos: map[string]domain.Vtxo{
+			leaf1.VtxoKey.String():   leaf1,
+			leaf2.VtxoKey.String():   leaf2,
+			redeem1.VtxoKey.String(): redeem1,
+			redeem2.VtxoKey.String(): redeem2,
+		},
+	}
+	roundRepo := &mockedRoundRepo{rounds: map[string]*domain.Round{round.Txid: round}}
+
+	testCases := []struct {
+		description string
+		spentVtxos  []domain.Vtxo
+		// expected number of unconfirmed ancestors of the redeem tx
+		expected int64
+	}{
+		{"leaf", []domain.Vtxo{leaf1}, 3},
+		{"leaves sharing the root", []domain.Vtxo{leaf1, leaf2}, 5},
+		{"chain of redeem txs", []domain.Vtxo{redeem2}, 5},
+		{"chain of redeem txs and leaf", []domain.Vtxo{redeem2, leaf2}, 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.description, func(t *testing.T) {
+			// the redeem tx itself counts as ancestor
+			checker := &mempoolAncestorsChecker{vtxoRepo, roundRepo, tc.expected + 1}
+			require.NoError(t, checker.validate(ctx, tc.spentVtxos))
+
+			checker.limit = tc.expected
+			err := checker.validate(ctx, tc.spentVtxos)
+			require.ErrorIs(t, err, ErrMempoolLimitExceeded)
+		})
+	}
+
+	t.Run("no limit", func(t *testing.T) {
+		checker := &mempoolAncestorsChecker{vtxoRepo, roundRepo, 0}
+		require.NoError(t, checker.validate(ctx, []domain.Vtxo{redeem2}))
+
+		var nilChecker *mempoolAncestorsChecker
+		require.NoError(t, nilChecker.validate(ctx, []domain.Vtxo{redeem2}))
+	})
+
+	t.Run("unknown round", func(t *testing.T) {
+		checker := &mempoolAncestorsChecker{vtxoRepo, roundRepo, 25}
+		unknown := leaf1
+		unknown.RoundTxid = "unknown"
+		err := checker.validate(ctx, []domain.Vtxo{unknown})
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrMempoolLimitExceeded)
+	})
+}
+
+// makePendingVtxo returns the first output of a redeem tx spending the given
+// vtxo.
+func makePendingVtxo(t *testing.T, spent domain.VtxoKey, roundTxid string) domain.Vtxo {
+	redeemTx := makeTx(t, spent)
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+	require.NoError(t, err)
+
+	return domain.Vtxo{
+		VtxoKey:   domain.VtxoKey{Txid: ptx.UnsignedTx.TxID()},
+		RoundTxid: roundTxid,
+		RedeemTx:  redeemTx,
+	}
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	log "github.com/sirupsen/logrus"
+)
+
+// how often the expired notes are removed from the db
+const noteReaperInterval = time.Hour
+
+// noteReaper periodically deletes the redeemed notes past their expiry. Once
+// expired a note can't be redeemed anymore, therefore there's no need to keep
+// it to prevent double spends.
+type noteReaper struct {
+	lock     *sync.Mutex
+	notes    domain.NoteRepository
+	interval time.Duration
+
+	quit chan struct{}
+}
+
+func newNoteReaper(
+	notes domain.NoteRepository, interval time.Duration,
+) *noteReaper {
+	return &noteReaper{
+		lock:     &sync.Mutex{},
+		notes:    notes,
+		interval: interval,
+	}
+}
+
+// start runs the periodic cleanup in background until stop is called.
+func (r *noteReaper) start() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.quit != nil || r.interval <= 0 {
+		return
+	}
+	r.quit = make(chan struct{})
+
+	go func(quit chan struct{}) {
+		ticker := time.NewTicker(r.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := r.reap(context.Background(), time.Now()); err != nil {
+				log.WithError(err).Warn("failed to delete expired notes")
+			}
+
+			select {
+			case <-quit:
+				return
+			case <-ticker.C:
+			}
+		}
+	}(r.quit)
+}
+
+func (r *noteReaper) stop() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.quit != nil {
+		close(r.quit)
+		r.quit = nil
+	}
+}
+
+// reap deletes the notes expired before the given time.
+func (r *noteReaper) reap(ctx context.Context, now time.Time) (int64, error) {
+	count, err := r.notes.DeleteExpired(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+	if count > 0 {
+		log.Debugf("deleted %d expired notes", count)
+	}
+	return count, nil
+}
@@ -0,0 +1,37 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoteReaper(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	notes := &mockedNoteRepo{
+		expiries: map[uint64]int64{
+			1: now.Add(-time.Hour).Unix(),
+			2: now.Add(time.Hour).Unix(),
+			// notes without expiry are never deleted
+			3: 0,
+		},
+	}
+	reaper := newNoteReaper(notes, 0)
+
+	count, err := reaper.reap(ctx, now)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	require.NotContains(t, notes.expiries, uint64(1))
+	require.Contains(t, notes.expiries, uint64(2))
+	require.Contains(t, notes.expiries, uint64(3))
+
+	count, err = reaper.reap(ctx, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), count)
+	require.Len(t, notes.expiries, 1)
+	require.Contains(t, notes.expiries, uint64(3))
+}
@@ -0,0 +1,185 @@
+package application
+
+import (
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/txscript"
+)
+
+// OfflineCosignerPolicy defines how the round engine reacts when some
+// cosigners submit their nonces but go offline before signing the vtxo tree.
+type OfflineCosignerPolicy string
+
+const (
+	// OfflineCosignerPolicyFail makes the round fail, reporting the tx requests
+	// and the leaves of the offline cosigners.
+	OfflineCosignerPolicyFail OfflineCosignerPolicy = "fail"
+	// OfflineCosignerPolicyDrop removes the tx requests of the offline cosigners
+	// from the round and restarts the signing of the rebuilt vtxo tree. The round
+	// fails like with OfflineCosignerPolicyFail if the requests can't be removed.
+	OfflineCosignerPolicyDrop OfflineCosignerPolicy = "drop"
+)
+
+var OfflineCosignerPolicies = []OfflineCosignerPolicy{
+	OfflineCosignerPolicyFail,
+	OfflineCosignerPolicyDrop,
+}
+
+func (p OfflineCosignerPolicy) IsValid() bool {
+	for _, policy := range OfflineCosignerPolicies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+// errOfflineCosigners is returned when the collection of the vtxo tree
+// signatures times out.
+type errOfflineCosigners struct {
+	cosigners []string
+	requests  []string
+	leaves    []string
+}
+
+func (e errOfflineCosigners) Error() string {
+	return fmt.Sprintf(
+		"musig2 signing session timed out (signatures collection), "+
+			"missing signatures of cosigners [%s] of tx requests [%s] for leaves [%s]",
+		strings.Join(e.cosigners, ", "), strings.Join(e.requests, ", "),
+		strings.Join(e.leaves, ", "),
+	)
+}
+
+// offlineCosigners returns the sorted list of cosigners that didn't submit
+// their signatures yet.
+func (s *musigSigningSession) offlineCosigners() []string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	signed := make(map[string]struct{})
+	for pubkey := range s.signatures {
+		signed[hex.EncodeToString(pubkey.SerializeCompressed())] = struct{}{}
+	}
+
+	offline := make([]string, 0)
+	for pubkey := range s.cosigners {
+		if _, ok := signed[pubkey]; !ok {
+			offline = append(offline, pubkey)
+		}
+	}
+	sort.Strings(offline)
+	return offline
+}
+
+// splitTxRequestsByCosigners returns the tx requests, along with their musig2
+// data, not involving any of the given cosigners, and those that do.
+func splitTxRequestsByCosigners(
+	requests []domain.TxRequest, musig2data []*tree.Musig2, cosigners []string,
+) ([]domain.TxRequest, []*tree.Musig2, []domain.TxRequest) {
+	cosignersSet := make(map[string]struct{})
+	for _, pubkey := range cosigners {
+		cosignersSet[pubkey] = struct{}{}
+	}
+
+	onlineRequests := make([]domain.TxRequest, 0, len(requests))
+	onlineMusig2data := make([]*tree.Musig2, 0, len(musig2data))
+	offlineRequests := make([]domain.TxRequest, 0)
+	for i, request := range requests {
+		var data *tree.Musig2
+		if i < len(musig2data) {
+			data = musig2data[i]
+		}
+
+		isOffline := false
+		if data != nil {
+			for _, pubkey := range data.CosignersPublicKeys {
+				if _, ok := cosignersSet[pubkey]; ok {
+					isOffline = true
+					break
+				}
+			}
+		}
+
+		if isOffline {
+			offlineRequests = append(offlineRequests, request)
+			continue
+		}
+		onlineRequests = append(onlineRequests, request)
+		onlineMusig2data = append(onlineMusig2data, data)
+	}
+	return onlineRequests, onlineMusig2data, offlineRequests
+}
+
+// canDropTxRequests returns an error if the given tx requests can't be removed
+// from the round. Only the requests spending nothing but vtxos can be dropped,
+// since their inputs are not part of the round tx and are simply released,
+// and at least one request must be left in the round.
+func canDropTxRequests(
+	requests []domain.TxRequest, inputAmounts map[string]uint64, numOfOnlineRequests int,
+) error {
+	if numOfOnlineRequests <= 0 {
+		return fmt.Errorf("no tx requests left in the round")
+	}
+	for _, request := range requests {
+		if inputAmount, ok := inputAmounts[request.Id]; !ok || inputAmount != request.TotalInputAmount() {
+			return fmt.Errorf(
+				"tx request %s spends boarding utxos, notes or recovered vtxos", request.Id,
+			)
+		}
+	}
+	return nil
+}
+
+// getLeavesOfTxRequests returns the txids of the vtxo tree leaves paying to
+// the receivers of the given tx requests.
+func getLeavesOfTxRequests(vtxoTree tree.TxTree, requests []domain.TxRequest) []string {
+	pubkeys := make(map[string]struct{})
+	for _, request := range requests {
+		for _, receiver := range request.Receivers {
+			if !receiver.IsOnchain() {
+				pubkeys[receiver.PubKey] = struct{}{}
+			}
+		}
+	}
+	if len(pubkeys) <= 0 || len(vtxoTree) <= 0 {
+		return nil
+	}
+
+	leaves := make([]string, 0)
+	for _, node := range vtxoTree.Leaves() {
+		tx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
+		if err != nil {
+			continue
+		}
+		for _, out := range tx.UnsignedTx.TxOut {
+			if txscript.GetScriptClass(out.PkScript) != txscript.WitnessV1TaprootTy {
+				continue
+			}
+			vtxoTapKey, err := schnorr.ParsePubKey(out.PkScript[2:])
+			if err != nil {
+				continue
+			}
+			if _, ok := pubkeys[hex.EncodeToString(schnorr.SerializePubKey(vtxoTapKey))]; ok {
+				leaves = append(leaves, node.Txid)
+				break
+			}
+		}
+	}
+	return leaves
+}
+
+func getTxRequestIds(requests []domain.TxRequest) []string {
+	ids := make([]string, 0, len(requests))
+	for _, request := range requests {
+		ids = append(ids, request.Id)
+	}
+	return ids
+}
@@ -0,0 +1,375 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineCosigners(t *testing.T) {
+	onlineKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	offlineKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	onlineCosigner := hex.EncodeToString(onlineKey.PubKey().SerializeCompressed())
+	offlineCosigner := hex.EncodeToString(offlineKey.PubKey().SerializeCompressed())
+	serverCosigner := hex.EncodeToString(serverKey.PubKey().SerializeCompressed())
+
+	onlineReceiver := hex.EncodeToString(schnorr.SerializePubKey(onlineKey.PubKey()))
+	offlineReceiver := hex.EncodeToString(schnorr.SerializePubKey(offlineKey.PubKey()))
+
+	onlineRequest := domain.TxRequest{
+		Id:        "online",
+		Inputs:    []domain.Vtxo{{VtxoKey: domain.VtxoKey{Txid: "aa"}, Amount: 1000}},
+		Receivers: []domain.Receiver{{PubKey: onlineReceiver, Amount: 1000}},
+	}
+	offlineRequest := domain.TxRequest{
+		Id:        "offline",
+		Inputs:    []domain.Vtxo{{VtxoKey: domain.VtxoKey{Txid: "bb"}, Amount: 2000}},
+		Receivers: []domain.Receiver{{PubKey: offlineReceiver, Amount: 2000}},
+	}
+	onchainRequest := domain.TxRequest{
+		Id:        "onchain",
+		Inputs:    []domain.Vtxo{{VtxoKey: domain.VtxoKey{Txid: "cc"}, Amount: 3000}},
+		Receivers: []domain.Receiver{{OnchainAddress: "address", Amount: 3000}},
+	}
+	requests := []domain.TxRequest{onlineRequest, offlineRequest, onchainRequest}
+	musig2data := []*tree.Musig2{
+		{CosignersPublicKeys: []string{onlineCosigner, serverCosigner}},
+		{CosignersPublicKeys: []string{offlineCosigner, serverCosigner}},
+		nil,
+	}
+
+	// both cosigners submit their nonces, but only one signs the tree
+	session := newMusigSigningSession(map[string]struct{}{
+		onlineCosigner:  {},
+		offlineCosigner: {},
+	})
+	session.nonces[onlineKey.PubKey()] = nil
+	session.nonces[offlineKey.PubKey()] = nil
+	session.signatures[onlineKey.PubKey()] = nil
+
+	offlineCosigners := session.offlineCosigners()
+	require.Equal(t, []string{offlineCosigner}, offlineCosigners)
+
+	onlineRequests, onlineMusig2data, offlineRequests := splitTxRequestsByCosigners(
+		requests, musig2data, offlineCosigners,
+	)
+	require.Equal(t, []domain.TxRequest{onlineRequest, onchainRequest}, onlineRequests)
+	require.Equal(t, []*tree.Musig2{musig2data[0], nil}, onlineMusig2data)
+	require.Equal(t, []domain.TxRequest{offlineRequest}, offlineRequests)
+
+	vtxoTree := tree.TxTree{{
+		makeLeaf(t, onlineKey.PubKey(), 1000),
+		makeLeaf(t, offlineKey.PubKey(), 2000),
+	}}
+	leaves := getLeavesOfTxRequests(vtxoTree, offlineRequests)
+	require.Equal(t, []string{vtxoTree[0][1].Txid}, leaves)
+
+	t.Run("drop", func(t *testing.T) {
+		inputAmounts := map[string]uint64{"online": 1000, "offline": 2000, "onchain": 3000}
+		err := canDropTxRequests(offlineRequests, inputAmounts, len(onlineRequests))
+		require.NoError(t, err)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		// the offline request spends also a boarding utxo or a note
+		inputAmounts := map[string]uint64{"online": 1000, "offline": 2500, "onchain": 3000}
+		err := canDropTxRequests(offlineRequests, inputAmounts, len(onlineRequests))
+		require.Error(t, err)
+
+		// no requests would be left in the round
+		inputAmounts = map[string]uint64{"offline": 2000}
+		err = canDropTxRequests(offlineRequests, inputAmounts, 0)
+		require.Error(t, err)
+
+		err = errOfflineCosigners{
+			cosigners: offlineCosigners,
+			requests:  getTxRequestIds(offlineRequests),
+			leaves:    leaves,
+		}
+		require.Contains(t, err.Error(), offlineCosigner)
+		require.Contains(t, err.Error(), offlineRequest.Id)
+		require.Contains(t, err.Error(), leaves[0])
+	})
+}
+
+func TestDropOfflineCosigners(t *testing.T) {
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	onlineKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	offlineKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	builder := &mockedRoundTxBuilder{t: t}
+	s := &covenantlessService{
+		pubkey:                serverKey.PubKey(),
+		vtxoTreeExpiry:        testVtxoTreeExpiry,
+		builder:               builder,
+		roundInputs:           newOutpointMap(),
+		roundMonitor:          newRoundMonitor(nil, time.Hour, ""),
+		treeSigningSessions:   make(map[string]*musigSigningSession),
+		eventsCh:              make(chan domain.RoundEvent),
+		serverSigningKey:      serverKey,
+		serverSigningPubKey:   serverKey.PubKey(),
+		offlineCosignerPolicy: OfflineCosignerPolicyDrop,
+	}
+
+	serverCosigner := hex.EncodeToString(serverKey.PubKey().SerializeCompressed())
+	requests := make([]domain.TxRequest, 0, 2)
+	musig2data := make([]*tree.Musig2, 0, 2)
+	inputAmounts := make(map[string]uint64)
+	vtxoKeys := make([]domain.VtxoKey, 0, 2)
+	for _, key := range []*secp256k1.PrivateKey{onlineKey, offlineKey} {
+		cosigner := hex.EncodeToString(key.PubKey().SerializeCompressed())
+		vtxo := domain.Vtxo{
+			VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte(cosigner)).String()},
+			Amount:  1000,
+		}
+		request := domain.TxRequest{
+			Id:     cosigner,
+			Inputs: []domain.Vtxo{vtxo},
+			Receivers: []domain.Receiver{{
+				PubKey: hex.EncodeToString(schnorr.SerializePubKey(key.PubKey())),
+				Amount: 1000,
+			}},
+		}
+		requests = append(requests, request)
+		musig2data = append(musig2data, &tree.Musig2{
+			CosignersPublicKeys: []string{cosigner, serverCosigner},
+			SigningType:         tree.SignAll,
+		})
+		inputAmounts[request.Id] = request.TotalInputAmount()
+		vtxoKeys = append(vtxoKeys, vtxo.VtxoKey)
+	}
+	s.roundInputs.add(vtxoKeys)
+
+	round := &domain.Round{Id: "round"}
+	instance := newRoundInstance(
+		round, newForfeitTxsMap(builder, &mockedForfeitTxsRepo{}), 0,
+	)
+	defer instance.cancel()
+
+	// both cosigners submit their nonces, but one goes offline before signing
+	go runTestCosigners(t, s, onlineKey, offlineKey)
+	defer close(s.eventsCh)
+
+	roundEndTime := time.Now().Add(3 * time.Second)
+	roundTx, vtxoTree, _, _, leftRequests, err := s.buildAndSignRoundTx(
+		instance, requests, nil, musig2data, inputAmounts, nil,
+		chainfee.FeePerKwFloor.FeePerKVByte(), 500*time.Millisecond, roundEndTime,
+	)
+	require.NoError(t, err)
+	require.NotEmpty(t, roundTx)
+
+	// the round tx is rebuilt without the tx request of the offline cosigner
+	require.Equal(t, 2, builder.builds)
+	require.Equal(t, requests[:1], leftRequests)
+	require.Len(t, vtxoTree.Leaves(), 1)
+	require.Equal(
+		t, []string{vtxoTree.Leaves()[0].Txid},
+		getLeavesOfTxRequests(vtxoTree, requests[:1]),
+	)
+	require.Empty(t, getLeavesOfTxRequests(vtxoTree, requests[1:]))
+
+	// the rebuilt tree is signed and the input of the dropped request released
+	rootTx, err := psbt.NewFromRawBytes(strings.NewReader(vtxoTree[0][0].Tx), true)
+	require.NoError(t, err)
+	require.NotEmpty(t, rootTx.Inputs[0].TaprootKeySpendSig)
+	require.True(t, s.roundInputs.includes(vtxoKeys[0]))
+	require.False(t, s.roundInputs.includes(vtxoKeys[1]))
+
+	t.Run("fail policy", func(t *testing.T) {
+		s.offlineCosignerPolicy = OfflineCosignerPolicyFail
+		defer func() { s.offlineCosignerPolicy = OfflineCosignerPolicyDrop }()
+		builder.builds = 0
+
+		round := &domain.Round{Id: "failing-round"}
+		instance := newRoundInstance(
+			round, newForfeitTxsMap(builder, &mockedForfeitTxsRepo{}), 0,
+		)
+		defer instance.cancel()
+
+		_, _, _, _, _, err := s.buildAndSignRoundTx(
+			instance, requests, nil, musig2data, inputAmounts, nil,
+			chainfee.FeePerKwFloor.FeePerKVByte(), 500*time.Millisecond,
+			time.Now().Add(3*time.Second),
+		)
+		require.Error(t, err)
+		offlineErr, ok := err.(errOfflineCosigners)
+		require.True(t, ok)
+		require.Equal(t, []string{requests[1].Id}, offlineErr.requests)
+		require.Equal(t, 1, builder.builds)
+	})
+}
+
+func makeLeaf(t *testing.T, pubkey *secp256k1.PublicKey, amount int64) tree.Node {
+	script, err := txscript.PayToTaprootScript(pubkey)
+	require.NoError(t, err)
+
+	ptx, err := psbt.New(
+		[]*wire.OutPoint{{Hash: chainhash.Hash{}, Index: 0}},
+		[]*wire.TxOut{{Value: amount, PkScript: script}},
+		2, 0, []uint32{wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+
+	b64, err := ptx.B64Encode()
+	require.NoError(t, err)
+
+	return tree.Node{
+		Txid: ptx.UnsignedTx.TxHash().String(),
+		Tx:   b64,
+		Leaf: true,
+	}
+}
+
+// runTestCosigners plays the cosigners of the vtxo trees being signed, the
+// offline one submits its nonces but never signs.
+func runTestCosigners(
+	t *testing.T, s *covenantlessService, onlineKey, offlineKey *secp256k1.PrivateKey,
+) {
+	ctx := context.Background()
+	sweepScript, err := (&tree.CSVMultisigClosure{
+		MultisigClosure: tree.MultisigClosure{PubKeys: []*secp256k1.PublicKey{s.pubkey}},
+		Locktime:        s.vtxoTreeExpiry,
+	}).Script()
+	require.NoError(t, err)
+	sweepRoot := txscript.NewBaseTapLeaf(sweepScript).TapHash()
+
+	sessions := make(map[*secp256k1.PrivateKey]tree.SignerSession)
+	for ev := range s.eventsCh {
+		switch e := ev.(type) {
+		case RoundSigningStarted:
+			roundTx, err := psbt.NewFromRawBytes(strings.NewReader(e.UnsignedRoundTx), true)
+			require.NoError(t, err)
+			sharedOutputAmount := roundTx.UnsignedTx.TxOut[0].Value
+
+			sessions = make(map[*secp256k1.PrivateKey]tree.SignerSession)
+			for _, key := range []*secp256k1.PrivateKey{onlineKey, offlineKey} {
+				cosigner := hex.EncodeToString(key.PubKey().SerializeCompressed())
+				if !slices.Contains(e.CosignersPubkeys, cosigner) {
+					continue
+				}
+				session := tree.NewTreeSignerSession(key)
+				require.NoError(t, session.Init(sweepRoot[:], sharedOutputAmount, e.UnsignedVtxoTree))
+				nonces, err := session.GetNonces()
+				require.NoError(t, err)
+				var buf bytes.Buffer
+				require.NoError(t, nonces.Encode(&buf))
+				require.NoError(t, s.RegisterCosignerNonces(
+					ctx, e.Id, key.PubKey(), hex.EncodeToString(buf.Bytes()),
+				))
+				sessions[key] = session
+			}
+		case RoundSigningNoncesGenerated:
+			session, ok := sessions[onlineKey]
+			if !ok {
+				continue
+			}
+			session.SetAggregatedNonces(e.Nonces)
+			sigs, err := session.Sign()
+			require.NoError(t, err)
+			var buf bytes.Buffer
+			require.NoError(t, sigs.Encode(&buf))
+			require.NoError(t, s.RegisterCosignerSignatures(
+				ctx, e.Id, onlineKey.PubKey(), hex.EncodeToString(buf.Bytes()),
+			))
+		}
+	}
+}
+
+// mockedRoundTxBuilder builds a round tx funding the vtxo tree of the given
+// tx requests.
+type mockedRoundTxBuilder struct {
+	mockedTxBuilder
+	t      *testing.T
+	builds int
+}
+
+func (m *mockedRoundTxBuilder) BuildRoundTx(
+	serverPubkey *secp256k1.PublicKey, requests []domain.TxRequest,
+	_ []ports.BoardingInput, _ []string, musig2data []*tree.Musig2,
+) (string, tree.TxTree, string, tree.TxTree, error) {
+	m.builds++
+
+	sweepScript, err := (&tree.CSVMultisigClosure{
+		MultisigClosure: tree.MultisigClosure{PubKeys: []*secp256k1.PublicKey{serverPubkey}},
+		Locktime:        testVtxoTreeExpiry,
+	}).Script()
+	if err != nil {
+		return "", nil, "", nil, err
+	}
+	sweepRoot := txscript.NewBaseTapLeaf(sweepScript).TapHash()
+
+	leaves := make([]tree.Leaf, 0, len(requests))
+	for i, request := range requests {
+		for _, receiver := range request.Receivers {
+			pubkeyBytes, err := hex.DecodeString(receiver.PubKey)
+			if err != nil {
+				return "", nil, "", nil, err
+			}
+			pubkey, err := schnorr.ParsePubKey(pubkeyBytes)
+			if err != nil {
+				return "", nil, "", nil, err
+			}
+			script, err := common.P2TRScript(pubkey)
+			if err != nil {
+				return "", nil, "", nil, err
+			}
+			leaves = append(leaves, tree.Leaf{
+				Script:     hex.EncodeToString(script),
+				Amount:     receiver.Amount,
+				Musig2Data: musig2data[i],
+			})
+		}
+	}
+
+	sharedOutputScript, sharedOutputAmount, err := tree.CraftSharedOutput(
+		leaves, 0, sweepRoot[:],
+	)
+	if err != nil {
+		return "", nil, "", nil, err
+	}
+	roundTx, err := psbt.New(
+		[]*wire.OutPoint{{Hash: chainhash.HashH([]byte("funding")), Index: 0}},
+		[]*wire.TxOut{{Value: sharedOutputAmount, PkScript: sharedOutputScript}},
+		2, 0, []uint32{wire.MaxTxInSequenceNum},
+	)
+	if err != nil {
+		return "", nil, "", nil, err
+	}
+	vtxoTree, err := tree.BuildVtxoTree(
+		&wire.OutPoint{Hash: roundTx.UnsignedTx.TxHash(), Index: 0}, leaves, 0,
+		sweepRoot[:], testVtxoTreeExpiry,
+	)
+	if err != nil {
+		return "", nil, "", nil, err
+	}
+	b64, err := roundTx.B64Encode()
+	if err != nil {
+		return "", nil, "", nil, err
+	}
+	return b64, vtxoTree, "", makeTestConnectorsTree(m.t, requests), nil
+}
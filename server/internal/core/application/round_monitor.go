@@ -0,0 +1,306 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// RoundPhase identifies the step of the round engine a round is going through.
+type RoundPhase string
+
+const (
+	RoundPhaseRegistration RoundPhase = "registration"
+	RoundPhaseTxBuilding   RoundPhase = "tx_building"
+	RoundPhaseTreeSigning  RoundPhase = "tree_signing"
+	RoundPhaseForfeits     RoundPhase = "forfeits"
+	RoundPhaseFinalization RoundPhase = "finalization"
+)
+
+const stuckRoundWebhookTimeout = 10 * time.Second
+
+var RoundPhases = []RoundPhase{
+	RoundPhaseRegistration,
+	RoundPhaseTxBuilding,
+	RoundPhaseTreeSigning,
+	RoundPhaseForfeits,
+	RoundPhaseFinalization,
+}
+
+func (p RoundPhase) IsValid() bool {
+	for _, phase := range RoundPhases {
+		if p == phase {
+			return true
+		}
+	}
+	return false
+}
+
+// StuckRoundAlert is the payload posted to the webhook when a round stays in
+// a phase for longer than the configured threshold.
+type StuckRoundAlert struct {
+	RoundId          string     `json:"round_id"`
+	Phase            RoundPhase `json:"phase"`
+	PhaseStartedAt   int64      `json:"phase_started_at"`
+	DurationSeconds  int64      `json:"duration_seconds"`
+	ThresholdSeconds int64      `json:"threshold_seconds"`
+}
+
+// roundMonitor keeps track of the time spent by every round in flight in each
+// phase and raises an alert if a phase lasts longer than its threshold. It
+// doesn't interfere with the rounds, the alert is meant to draw the attention
+// of the operator before a round eventually fails.
+type roundMonitor struct {
+	lock       *sync.Mutex
+	thresholds map[RoundPhase]time.Duration
+	webhookUrl string
+	httpClient *http.Client
+
+	rounds map[string]*monitoredRound
+
+	alertsCounter       metric.Int64Counter
+	phaseDurationsHisto metric.Float64Histogram
+}
+
+// monitoredRound is the phase a round in flight is going through.
+type monitoredRound struct {
+	phase          RoundPhase
+	phaseStartedAt time.Time
+	timer          *time.Timer
+}
+
+// newRoundMonitor returns a monitor for the given per-phase thresholds. Any
+// phase without a threshold uses the default one, a threshold <= 0 disables
+// the alert for the phase.
+func newRoundMonitor(
+	thresholds map[RoundPhase]time.Duration, defaultThreshold time.Duration,
+	webhookUrl string,
+) *roundMonitor {
+	t := make(map[RoundPhase]time.Duration)
+	for _, phase := range RoundPhases {
+		t[phase] = defaultThreshold
+		if threshold, ok := thresholds[phase]; ok {
+			t[phase] = threshold
+		}
+	}
+
+	m := &roundMonitor{
+		lock:       &sync.Mutex{},
+		thresholds: t,
+		webhookUrl: webhookUrl,
+		httpClient: &http.Client{Timeout: stuckRoundWebhookTimeout},
+		rounds:     make(map[string]*monitoredRound),
+	}
+	m.initMetrics()
+	return m
+}
+
+// enterPhase records that the given round moved to a new phase and restarts
+// the timer of its stuck round alert. The other rounds in flight are not
+// affected.
+func (m *roundMonitor) enterPhase(roundId string, phase RoundPhase) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	round, ok := m.rounds[roundId]
+	if ok {
+		m.exitPhase(roundId, round)
+	} else {
+		round = &monitoredRound{}
+		m.rounds[roundId] = round
+	}
+
+	round.phase = phase
+	round.phaseStartedAt = time.Now()
+
+	threshold := m.thresholds[phase]
+	if threshold <= 0 {
+		return
+	}
+
+	startedAt := round.phaseStartedAt
+	round.timer = time.AfterFunc(threshold, func() {
+		m.alert(roundId, phase, startedAt, threshold)
+	})
+}
+
+// endRound records that the given round left its current phase and stops
+// monitoring it, to be called once the round ends, whether it succeeded or
+// failed.
+func (m *roundMonitor) endRound(roundId string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	round, ok := m.rounds[roundId]
+	if !ok {
+		return
+	}
+	m.exitPhase(roundId, round)
+	delete(m.rounds, roundId)
+}
+
+// stop disables any pending alert, to be called when the round engine stops.
+func (m *roundMonitor) stop() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, round := range m.rounds {
+		if round.timer != nil {
+			round.timer.Stop()
+		}
+	}
+	m.rounds = make(map[string]*monitoredRound)
+}
+
+// exitPhase stops the alert of the current phase of the given round and
+// records the time spent in it. The caller must hold the lock.
+func (m *roundMonitor) exitPhase(roundId string, round *monitoredRound) {
+	if round.timer != nil {
+		round.timer.Stop()
+		round.timer = nil
+	}
+	if len(round.phase) <= 0 {
+		return
+	}
+
+	duration := time.Since(round.phaseStartedAt)
+	log.Debugf(
+		"round %s spent %s in %s phase",
+		roundId, duration.Round(time.Millisecond), round.phase,
+	)
+	if m.phaseDurationsHisto != nil {
+		m.phaseDurationsHisto.Record(
+			context.Background(), duration.Seconds(),
+			metric.WithAttributes(attribute.String("phase", string(round.phase))),
+		)
+	}
+}
+
+func (m *roundMonitor) alert(
+	roundId string, phase RoundPhase, startedAt time.Time, threshold time.Duration,
+) {
+	m.lock.Lock()
+	// the round moved on in the meantime
+	round, ok := m.rounds[roundId]
+	if !ok || round.phase != phase || !round.phaseStartedAt.Equal(startedAt) {
+		m.lock.Unlock()
+		return
+	}
+	m.lock.Unlock()
+
+	duration := time.Since(startedAt)
+	log.Warnf(
+		"round %s is stuck in %s phase since %s (threshold %s)",
+		roundId, phase, duration.Round(time.Second), threshold,
+	)
+
+	if m.alertsCounter != nil {
+		m.alertsCounter.Add(
+			context.Background(), 1,
+			metric.WithAttributes(attribute.String("phase", string(phase))),
+		)
+	}
+
+	if len(m.webhookUrl) <= 0 {
+		return
+	}
+
+	go func() {
+		if err := m.notify(StuckRoundAlert{
+			RoundId:          roundId,
+			Phase:            phase,
+			PhaseStartedAt:   startedAt.Unix(),
+			DurationSeconds:  int64(duration.Seconds()),
+			ThresholdSeconds: int64(threshold.Seconds()),
+		}); err != nil {
+			log.WithError(err).Warn("failed to notify stuck round to webhook")
+		}
+	}()
+}
+
+func (m *roundMonitor) notify(alert StuckRoundAlert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	resp, err := m.httpClient.Post(m.webhookUrl, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return nil
+}
+
+func (m *roundMonitor) initMetrics() {
+	meter := otel.Meter("ark.rounds")
+
+	counter, err := meter.Int64Counter(
+		"ark_round_stuck_alerts",
+		metric.WithDescription("number of rounds exceeding the time threshold of a phase"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create stuck round alerts counter")
+		return
+	}
+	m.alertsCounter = counter
+
+	histo, err := meter.Float64Histogram(
+		"ark_round_phase_seconds",
+		metric.WithDescription("time spent by the rounds in every phase"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create round phase durations histogram")
+	} else {
+		m.phaseDurationsHisto = histo
+	}
+
+	gauge, err := meter.Int64ObservableGauge(
+		"ark_round_phase_duration_seconds",
+		metric.WithDescription("time spent by the oldest round in flight in its current phase"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create round phase duration gauge")
+		return
+	}
+
+	if _, err := meter.RegisterCallback(
+		func(_ context.Context, obs metric.Observer) error {
+			m.lock.Lock()
+			defer m.lock.Unlock()
+
+			// report, per phase, the round stuck in it for the longest time
+			durations := make(map[RoundPhase]time.Duration)
+			for _, round := range m.rounds {
+				duration := time.Since(round.phaseStartedAt)
+				if duration > durations[round.phase] {
+					durations[round.phase] = duration
+				}
+			}
+			for phase, duration := range durations {
+				obs.ObserveInt64(
+					gauge, int64(duration.Seconds()),
+					metric.WithAttributes(attribute.String("phase", string(phase))),
+				)
+			}
+			return nil
+		},
+		gauge,
+	); err != nil {
+		log.WithError(err).Warn("failed to register round phase duration callback")
+	}
+}
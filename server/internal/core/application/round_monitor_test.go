@@ -0,0 +1,88 @@
+package application
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoundMonitor(t *testing.T) {
+	alerts := make(chan StuckRoundAlert, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var alert StuckRoundAlert
+		if err := json.NewDecoder(r.Body).Decode(&alert); err == nil {
+			alerts <- alert
+		}
+	}))
+	defer server.Close()
+
+	monitor := newRoundMonitor(map[RoundPhase]time.Duration{
+		RoundPhaseTreeSigning: 100 * time.Millisecond,
+		RoundPhaseForfeits:    0,
+	}, time.Hour, server.URL)
+	defer monitor.stop()
+
+	t.Run("alert when phase exceeds threshold", func(t *testing.T) {
+		monitor.enterPhase("round1", RoundPhaseTreeSigning)
+
+		select {
+		case alert := <-alerts:
+			require.Equal(t, "round1", alert.RoundId)
+			require.Equal(t, RoundPhaseTreeSigning, alert.Phase)
+		case <-time.After(2 * time.Second):
+			t.Fatal("expected stuck round alert")
+		}
+	})
+
+	t.Run("no alert if round moves on", func(t *testing.T) {
+		monitor.enterPhase("round2", RoundPhaseTreeSigning)
+		monitor.enterPhase("round2", RoundPhaseForfeits)
+
+		select {
+		case alert := <-alerts:
+			t.Fatalf("unexpected alert %+v", alert)
+		case <-time.After(300 * time.Millisecond):
+		}
+	})
+
+	t.Run("concurrent rounds are monitored independently", func(t *testing.T) {
+		monitor.enterPhase("round3", RoundPhaseTreeSigning)
+		monitor.enterPhase("round4", RoundPhaseRegistration)
+		monitor.enterPhase("round4", RoundPhaseTreeSigning)
+
+		// round4 moving on doesn't reset the alert of round3
+		received := make(map[string]RoundPhase)
+		for len(received) < 2 {
+			select {
+			case alert := <-alerts:
+				received[alert.RoundId] = alert.Phase
+			case <-time.After(2 * time.Second):
+				t.Fatalf("expected stuck round alerts, got %v", received)
+			}
+		}
+		require.Equal(t, RoundPhaseTreeSigning, received["round3"])
+		require.Equal(t, RoundPhaseTreeSigning, received["round4"])
+	})
+
+	t.Run("no alert once the round ends", func(t *testing.T) {
+		monitor.enterPhase("round5", RoundPhaseTreeSigning)
+		monitor.enterPhase("round6", RoundPhaseForfeits)
+		monitor.endRound("round5")
+		monitor.endRound("round6")
+
+		select {
+		case alert := <-alerts:
+			t.Fatalf("unexpected alert %+v", alert)
+		case <-time.After(300 * time.Millisecond):
+		}
+
+		monitor.lock.Lock()
+		defer monitor.lock.Unlock()
+		require.NotContains(t, monitor.rounds, "round5")
+		require.NotContains(t, monitor.rounds, "round6")
+	})
+}
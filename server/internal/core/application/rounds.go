@@ -0,0 +1,309 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	log "github.com/sirupsen/logrus"
+)
+
+// roundInstance holds the state of a round in flight, from the start of its
+// registration until it's finalized or fails.
+type roundInstance struct {
+	// lock guards the partial signatures combined into the round tx
+	lock  *sync.Mutex
+	round *domain.Round
+
+	forfeitTxs               *forfeitTxsMap
+	forfeitsBoardingSigsChan chan struct{}
+
+	numOfBoardingInputs    int
+	numOfBoardingInputsMtx *sync.RWMutex
+
+	// liquidity is the amount of funds of the server locked in the round tx
+	liquidity          uint64
+	connectorAddresses []string
+
+	// txRequestIds are the tx requests popped from the queue for the round,
+	// deleted from it once the round ends
+	txRequestIds []string
+
+	// ctx is done once the round exceeds its deadline, once it's aborted, or
+	// once it ends
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	abortErr error
+	abortMtx *sync.RWMutex
+}
+
+// newRoundInstance returns a round in flight that must end within the given
+// timeout, 0 means no deadline.
+func newRoundInstance(
+	round *domain.Round, forfeitTxs *forfeitTxsMap, timeout time.Duration,
+) *roundInstance {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	return &roundInstance{
+		lock:                     &sync.Mutex{},
+		round:                    round,
+		forfeitTxs:               forfeitTxs,
+		forfeitsBoardingSigsChan: make(chan struct{}, 1),
+		numOfBoardingInputsMtx:   &sync.RWMutex{},
+		abortMtx:                 &sync.RWMutex{},
+		ctx:                      ctx,
+		cancel:                   cancel,
+	}
+}
+
+// timedOut returns whether the round exceeded its deadline.
+func (r *roundInstance) timedOut() bool {
+	return errors.Is(r.ctx.Err(), context.DeadlineExceeded)
+}
+
+// abort makes the round fail with the given reason at its next step.
+func (r *roundInstance) abort(reason error) {
+	r.abortMtx.Lock()
+	if r.abortErr == nil {
+		r.abortErr = reason
+	}
+	r.abortMtx.Unlock()
+	r.cancel()
+}
+
+// abortReason returns why the round can't go on, either because it was
+// aborted or because it exceeded its deadline, nil otherwise.
+func (r *roundInstance) abortReason() error {
+	r.abortMtx.RLock()
+	defer r.abortMtx.RUnlock()
+
+	if r.abortErr != nil {
+		return r.abortErr
+	}
+	if r.timedOut() {
+		return ErrRoundTimedOut
+	}
+	return nil
+}
+
+// waitForfeitsBoardingSigs waits for all the forfeit txs and boarding inputs
+// signatures until the given timeout. It returns the abort reason if the round
+// is aborted or exceeds its deadline in the meantime.
+func (r *roundInstance) waitForfeitsBoardingSigs(timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-r.forfeitsBoardingSigsChan:
+		log.Debug("all forfeit txs and boarding inputs signatures have been sent")
+	case <-timer.C:
+		log.Debug("timeout waiting for forfeit txs and boarding inputs signatures")
+	case <-r.ctx.Done():
+		if err := r.abortReason(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *roundInstance) setNumOfBoardingInputs(num int) {
+	r.numOfBoardingInputsMtx.Lock()
+	defer r.numOfBoardingInputsMtx.Unlock()
+	r.numOfBoardingInputs = num
+}
+
+func (r *roundInstance) getNumOfBoardingInputs() int {
+	r.numOfBoardingInputsMtx.RLock()
+	defer r.numOfBoardingInputsMtx.RUnlock()
+	return r.numOfBoardingInputs
+}
+
+// roundInstances keeps track of the rounds in flight. The liquidity and the
+// connector addresses reserved by every round make sure that concurrent rounds
+// never spend more than the funds available or the same connector outputs.
+type roundInstances struct {
+	lock   *sync.RWMutex
+	rounds map[string]*roundInstance
+}
+
+func newRoundInstances() *roundInstances {
+	return &roundInstances{
+		lock:   &sync.RWMutex{},
+		rounds: make(map[string]*roundInstance),
+	}
+}
+
+func (r *roundInstances) len() int {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return len(r.rounds)
+}
+
+func (r *roundInstances) add(instance *roundInstance) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.rounds[instance.round.Id] = instance
+}
+
+func (r *roundInstances) get(roundId string) (*roundInstance, bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	instance, ok := r.rounds[roundId]
+	return instance, ok
+}
+
+func (r *roundInstances) remove(roundId string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.rounds, roundId)
+}
+
+// abortAll aborts all the rounds in flight with the given reason.
+func (r *roundInstances) abortAll(reason error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for _, instance := range r.rounds {
+		instance.abort(reason)
+	}
+}
+
+// reserveLiquidity reserves the given amount for the round, failing if the
+// available balance doesn't cover also the amounts reserved by the other
+// rounds in flight.
+func (r *roundInstances) reserveLiquidity(
+	roundId string, amount, availableBalance uint64,
+) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	instance, ok := r.rounds[roundId]
+	if !ok {
+		return fmt.Errorf("round %s not found", roundId)
+	}
+
+	reserved := uint64(0)
+	for id, round := range r.rounds {
+		if id != roundId {
+			reserved += round.liquidity
+		}
+	}
+	if availableBalance <= reserved+amount {
+		return fmt.Errorf("not enough liquidity")
+	}
+
+	instance.liquidity = amount
+	return nil
+}
+
+// reservedLiquidity returns the total amount reserved by the rounds in flight.
+func (r *roundInstances) reservedLiquidity() uint64 {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	reserved := uint64(0)
+	for _, round := range r.rounds {
+		reserved += round.liquidity
+	}
+	return reserved
+}
+
+// reserveConnectorAddresses reserves for the round the given addresses that
+// are not used by any other round in flight, and returns them.
+func (r *roundInstances) reserveConnectorAddresses(
+	roundId string, addresses []string,
+) []string {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	reserved := make(map[string]struct{})
+	for id, round := range r.rounds {
+		if id == roundId {
+			continue
+		}
+		for _, addr := range round.connectorAddresses {
+			reserved[addr] = struct{}{}
+		}
+	}
+
+	available := make([]string, 0, len(addresses))
+	for _, addr := range addresses {
+		if _, ok := reserved[addr]; !ok {
+			available = append(available, addr)
+		}
+	}
+
+	if instance, ok := r.rounds[roundId]; ok {
+		instance.connectorAddresses = available
+	}
+	return available
+}
+
+// getByForfeitTxs returns the round in flight expecting the given forfeit txs,
+// the one including the vtxos they spend.
+func (r *roundInstances) getByForfeitTxs(txs []string) (*roundInstance, error) {
+	vtxoKeys := make([]domain.VtxoKey, 0)
+	for _, tx := range txs {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse forfeit tx: %s", err)
+		}
+		for _, in := range ptx.UnsignedTx.TxIn {
+			vtxoKeys = append(vtxoKeys, domain.VtxoKey{
+				Txid: in.PreviousOutPoint.Hash.String(),
+				VOut: in.PreviousOutPoint.Index,
+			})
+		}
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for _, instance := range r.rounds {
+		if instance.forfeitTxs.includesAny(vtxoKeys) {
+			return instance, nil
+		}
+	}
+	return nil, fmt.Errorf("no round in flight found for forfeit txs")
+}
+
+// getByRoundTx returns the round in flight with the given (partially signed)
+// round tx.
+func (r *roundInstances) getByRoundTx(tx string) (*roundInstance, error) {
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse round tx: %s", err)
+	}
+	txid := ptx.UnsignedTx.TxHash().String()
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	for _, instance := range r.rounds {
+		instance.lock.Lock()
+		unsignedTx := instance.round.UnsignedTx
+		instance.lock.Unlock()
+		if len(unsignedTx) <= 0 {
+			continue
+		}
+
+		roundTx, err := psbt.NewFromRawBytes(strings.NewReader(unsignedTx), true)
+		if err != nil {
+			continue
+		}
+		if roundTx.UnsignedTx.TxHash().String() == txid {
+			return instance, nil
+		}
+	}
+	return nil, fmt.Errorf("no round in flight found for round tx %s", txid)
+}
@@ -0,0 +1,369 @@
+package application
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentRounds(t *testing.T) {
+	vtxo1 := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte("vtxo1")).String()}}
+	vtxo2 := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte("vtxo2")).String()}}
+
+	round1 := newTestRoundInstance(t, "round1", vtxo1)
+	round2 := newTestRoundInstance(t, "round2", vtxo2)
+
+	rounds := newRoundInstances()
+	rounds.add(round1)
+	rounds.add(round2)
+	require.Equal(t, 2, rounds.len())
+
+	t.Run("liquidity", func(t *testing.T) {
+		availableBalance := uint64(10000)
+
+		err := rounds.reserveLiquidity(round1.round.Id, 6000, availableBalance)
+		require.NoError(t, err)
+
+		// the balance doesn't cover the liquidity of both rounds
+		err = rounds.reserveLiquidity(round2.round.Id, 4000, availableBalance)
+		require.EqualError(t, err, "not enough liquidity")
+
+		err = rounds.reserveLiquidity(round2.round.Id, 3000, availableBalance)
+		require.NoError(t, err)
+
+		// the liquidity is released once the first round ends
+		rounds.remove(round1.round.Id)
+		defer rounds.add(round1)
+
+		err = rounds.reserveLiquidity(round2.round.Id, 9000, availableBalance)
+		require.NoError(t, err)
+	})
+
+	t.Run("connectors", func(t *testing.T) {
+		addresses := []string{"addr1", "addr2", "addr3"}
+
+		reserved := rounds.reserveConnectorAddresses(round1.round.Id, addresses[:2])
+		require.Equal(t, addresses[:2], reserved)
+
+		// the connector addresses of the first round are not available to the second
+		reserved = rounds.reserveConnectorAddresses(round2.round.Id, addresses)
+		require.Equal(t, addresses[2:], reserved)
+	})
+
+	t.Run("forfeit txs", func(t *testing.T) {
+		instance, err := rounds.getByForfeitTxs([]string{makeTx(t, vtxo2.VtxoKey)})
+		require.NoError(t, err)
+		require.Equal(t, round2, instance)
+
+		instance, err = rounds.getByForfeitTxs([]string{makeTx(t, vtxo1.VtxoKey)})
+		require.NoError(t, err)
+		require.Equal(t, round1, instance)
+
+		unknownVtxo := domain.VtxoKey{Txid: chainhash.HashH([]byte("vtxo3")).String()}
+		_, err = rounds.getByForfeitTxs([]string{makeTx(t, unknownVtxo)})
+		require.Error(t, err)
+	})
+
+	t.Run("round tx", func(t *testing.T) {
+		instance, err := rounds.getByRoundTx(round1.round.UnsignedTx)
+		require.NoError(t, err)
+		require.Equal(t, round1, instance)
+
+		instance, err = rounds.getByRoundTx(round2.round.UnsignedTx)
+		require.NoError(t, err)
+		require.Equal(t, round2, instance)
+	})
+}
+
+func TestRoundTimeout(t *testing.T) {
+	vtxo := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte("vtxo")).String()},
+		Amount:  1000,
+	}
+	s := &covenantlessService{
+		txRequests:   newTxRequestsQueue(time.Minute, 5*time.Minute, 0),
+		roundInputs:  newOutpointMap(),
+		rounds:       newRoundInstances(),
+		roundSlots:   make(chan struct{}, 1),
+		roundMonitor: newRoundMonitor(nil, time.Hour, ""),
+	}
+
+	// the round reserves a slot, its tx requests and their inputs
+	round := &domain.Round{Id: "round"}
+	forfeitTxs := &forfeitTxsMap{lock: &sync.RWMutex{}}
+	instance := newRoundInstance(round, forfeitTxs, 50*time.Millisecond)
+	s.roundSlots <- struct{}{}
+	s.rounds.add(instance)
+	s.roundMonitor.enterPhase(round.Id, RoundPhaseForfeits)
+
+	request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+	require.NoError(t, err)
+	require.NoError(t, s.txRequests.push(*request, nil, nil, nil))
+	s.roundInputs.add([]domain.VtxoKey{vtxo.VtxoKey})
+	instance.txRequestIds = []string{request.Id}
+
+	// the round gets stuck waiting for the forfeit txs way past its deadline
+	start := time.Now()
+	err = instance.waitForfeitsBoardingSigs(time.Minute)
+	require.ErrorIs(t, err, ErrRoundTimedOut)
+	require.Less(t, time.Since(start), time.Second)
+	require.True(t, instance.timedOut())
+
+	round.Fail(err)
+	require.True(t, round.IsFailed())
+
+	// the round is aborted like any failed one, releasing everything it reserved
+	s.roundInputs.remove([]domain.VtxoKey{vtxo.VtxoKey})
+	s.endRound(instance)
+
+	require.False(t, s.roundInputs.includes(vtxo.VtxoKey))
+	require.Empty(t, s.txRequests.requests)
+	require.Zero(t, s.rounds.len())
+	require.Empty(t, s.roundSlots)
+	require.Empty(t, s.roundMonitor.rounds)
+
+	// a round without deadline doesn't time out
+	instance = newRoundInstance(round, forfeitTxs, 0)
+	instance.forfeitsBoardingSigsChan <- struct{}{}
+	require.NoError(t, instance.waitForfeitsBoardingSigs(time.Minute))
+	require.False(t, instance.timedOut())
+}
+
+func TestShutdown(t *testing.T) {
+	vtxo := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte("vtxo")).String()},
+		Amount:  1000,
+	}
+	s := &covenantlessService{
+		txRequests:   newTxRequestsQueue(time.Minute, 5*time.Minute, 0),
+		roundInputs:  newOutpointMap(),
+		rounds:       newRoundInstances(),
+		roundSlots:   make(chan struct{}, 1),
+		roundMonitor: newRoundMonitor(nil, time.Hour, ""),
+	}
+
+	// the round in flight is stuck waiting for the forfeit txs
+	round := &domain.Round{Id: "round"}
+	forfeitTxs := &forfeitTxsMap{lock: &sync.RWMutex{}}
+	instance := newRoundInstance(round, forfeitTxs, 0)
+	s.roundSlots <- struct{}{}
+	s.rounds.add(instance)
+
+	request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+	require.NoError(t, err)
+	require.NoError(t, s.txRequests.push(*request, nil, nil, nil))
+	s.roundInputs.add([]domain.VtxoKey{vtxo.VtxoKey})
+	instance.txRequestIds = []string{request.Id}
+
+	roundErr := make(chan error, 1)
+	go func() {
+		err := instance.waitForfeitsBoardingSigs(time.Minute)
+		round.Fail(err)
+		s.roundInputs.remove([]domain.VtxoKey{vtxo.VtxoKey})
+		s.endRound(instance)
+		roundErr <- err
+	}()
+
+	// the round doesn't end before the deadline, it's aborted
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	require.NoError(t, s.Shutdown(ctx))
+	require.ErrorIs(t, <-roundErr, ErrRoundAborted)
+	require.ErrorIs(t, instance.abortReason(), ErrRoundAborted)
+
+	// the round released everything it reserved
+	require.False(t, s.roundInputs.includes(vtxo.VtxoKey))
+	require.Empty(t, s.txRequests.requests)
+	require.Zero(t, s.rounds.len())
+	require.Empty(t, s.roundSlots)
+
+	// no more tx requests are accepted
+	_, err = s.SpendVtxos(context.Background(), nil)
+	require.ErrorIs(t, err, ErrShuttingDown)
+
+	// with no rounds in flight, the shutdown returns right away
+	require.NoError(t, s.Shutdown(context.Background()))
+}
+
+func TestRoundMaxParticipantsCount(t *testing.T) {
+	s := &covenantlessService{
+		txRequests:                newTxRequestsQueue(time.Minute, 5*time.Minute, 0),
+		roundMaxParticipantsCount: 2,
+	}
+
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	for _, txid := range []string{"vtxo1", "vtxo2", "vtxo3"} {
+		vtxo := domain.Vtxo{
+			VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte(txid)).String()},
+			Amount:  1000,
+		}
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		require.NoError(t, err)
+		require.NoError(t, request.AddReceivers([]domain.Receiver{{Amount: 1000, PubKey: pubkey}}))
+		require.NoError(t, s.txRequests.push(*request, nil, nil, nil))
+	}
+
+	// every round registers at most 2 tx requests, the third one is left for
+	// the next round
+	numOfRounds := 0
+	poppedIds := make(map[string]struct{})
+	for num := s.numOfTxRequestsToPop(); num > 0; num = s.numOfTxRequestsToPop() {
+		require.LessOrEqual(t, num, s.roundMaxParticipantsCount)
+
+		requests, _, _, _, _, _ := s.txRequests.pop(num)
+		require.Len(t, requests, int(num))
+		for _, request := range requests {
+			poppedIds[request.Id] = struct{}{}
+		}
+		numOfRounds++
+	}
+	require.Equal(t, 2, numOfRounds)
+	require.Len(t, poppedIds, 3)
+}
+
+func TestRestoreRounds(t *testing.T) {
+	ctx := context.Background()
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	newRound := func(name string, outputAmount uint64) (*domain.Round, []domain.VtxoKey) {
+		vtxos := []domain.Vtxo{
+			{VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte(name + "1")).String()}, Amount: 1000},
+			{VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte(name + "2")).String()}, Amount: 1000},
+		}
+		request, err := domain.NewTxRequest(vtxos)
+		require.NoError(t, err)
+		require.NoError(t, request.AddReceivers([]domain.Receiver{
+			{Amount: outputAmount, PubKey: pubkey},
+		}))
+		requests := []domain.TxRequest{*request}
+
+		round := domain.NewRound(330)
+		_, err = round.StartRegistration()
+		require.NoError(t, err)
+		_, err = round.RegisterTxRequests(requests)
+		require.NoError(t, err)
+		roundTx := makeTx(t, domain.VtxoKey{Txid: chainhash.HashH([]byte(name)).String()})
+		_, err = round.StartFinalization(
+			"", makeTestConnectorsTree(t, requests), nil, roundTx, nil,
+		)
+		require.NoError(t, err)
+		return round, []domain.VtxoKey{vtxos[0].VtxoKey, vtxos[1].VtxoKey}
+	}
+
+	// the server shuts down while collecting the forfeit txs of a round, after
+	// receiving only one of them. The fee rate has changed since the round
+	// started
+	round, vtxoKeys := newRound("round", 2000)
+	forfeitTxsRepo := &mockedForfeitTxsRepo{}
+	roundFeeRate := 2 * (&mockedWallet{}).MinRelayFeeRate(ctx)
+	require.NoError(t, forfeitTxsRepo.Init(ctx, round.Id, vtxoKeys, int64(roundFeeRate)))
+	forfeitTx := makeTx(t, vtxoKeys[0])
+	require.NoError(t, forfeitTxsRepo.Sign(
+		ctx, round.Id, map[domain.VtxoKey]string{vtxoKeys[0]: forfeitTx},
+	))
+
+	// the round spending notes can't be restored, they are not persisted
+	roundWithNotes, vtxoKeysWithNotes := newRound("round with notes", 5000)
+	require.NoError(t, forfeitTxsRepo.Init(
+		ctx, roundWithNotes.Id, vtxoKeysWithNotes, int64(roundFeeRate),
+	))
+
+	// the round that didn't reach the finalization stage was never persisted
+	require.NoError(t, forfeitTxsRepo.Init(
+		ctx, "stale round", vtxoKeys[:1], int64(roundFeeRate),
+	))
+
+	s := &covenantlessService{
+		repoManager: &mockedRepoManager{
+			rounds:     &mockedRoundRepo{rounds: map[string]*domain.Round{}},
+			forfeitTxs: forfeitTxsRepo,
+			events: &mockedEventRepo{rounds: map[string]*domain.Round{
+				round.Id:          round,
+				roundWithNotes.Id: roundWithNotes,
+			}},
+		},
+		builder:     &mockedTxBuilder{},
+		wallet:      &mockedWallet{},
+		txRequests:  newTxRequestsQueue(time.Minute, 5*time.Minute, 0),
+		roundInputs: newOutpointMap(),
+		rounds:      newRoundInstances(),
+		roundSlots:  make(chan struct{}, 2),
+	}
+
+	// after the restart, the round is in flight again with the forfeit tx
+	// already collected
+	instances, err := s.restoreRounds(ctx)
+	require.NoError(t, err)
+	require.Len(t, instances, 1)
+	instance := instances[0]
+	require.Equal(t, round.Id, instance.round.Id)
+	require.Len(t, s.roundSlots, 1)
+
+	restored, ok := s.rounds.get(round.Id)
+	require.True(t, ok)
+	require.Equal(t, instance, restored)
+	for _, vtxoKey := range vtxoKeys {
+		require.True(t, s.roundInputs.includes(vtxoKey))
+	}
+
+	forfeitTxs, err := forfeitTxsRepo.GetForfeitTxs(ctx, round.Id)
+	require.NoError(t, err)
+	require.Equal(t, forfeitTx, forfeitTxs[vtxoKeys[0]])
+	require.False(t, instance.forfeitTxs.allSigned())
+	// the forfeit txs are still requested at the fee rate of the round
+	require.Equal(t, roundFeeRate, instance.forfeitTxs.getFeeRate())
+
+	// only the missing forfeit tx must be signed to complete the round
+	otherForfeitTx := makeTx(t, vtxoKeys[1])
+	require.NoError(t, instance.forfeitTxs.sign([]string{otherForfeitTx}))
+	require.True(t, instance.forfeitTxs.allSigned())
+	txs, err := instance.forfeitTxs.pop()
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{forfeitTx, otherForfeitTx}, txs)
+
+	// the other rounds are dropped, the one spending notes is failed
+	roundIds, err := forfeitTxsRepo.GetRoundIds(ctx)
+	require.NoError(t, err)
+	require.Empty(t, roundIds)
+	failedRound, err := s.repoManager.Events().Load(ctx, roundWithNotes.Id)
+	require.NoError(t, err)
+	require.True(t, failedRound.IsFailed())
+	for _, vtxoKey := range vtxoKeysWithNotes {
+		require.False(t, s.roundInputs.includes(vtxoKey))
+	}
+}
+
+func newTestRoundInstance(t *testing.T, id string, vtxo domain.Vtxo) *roundInstance {
+	round := &domain.Round{
+		Id:         id,
+		UnsignedTx: makeTx(t, domain.VtxoKey{Txid: chainhash.HashH([]byte(id)).String()}),
+	}
+	forfeitTxs := &forfeitTxsMap{
+		lock:    &sync.RWMutex{},
+		roundId: id,
+		vtxos:   []domain.Vtxo{vtxo},
+	}
+	return newRoundInstance(round, forfeitTxs, 0)
+}
+
+func makeTx(t *testing.T, input domain.VtxoKey) string {
+	hash, err := chainhash.NewHashFromStr(input.Txid)
+	require.NoError(t, err)
+
+	ptx, err := psbt.New(
+		[]*wire.OutPoint{{Hash: *hash, Index: input.VOut}},
+		[]*wire.TxOut{{Value: 1000, PkScript: []byte{0x51}}},
+		2, 0, []uint32{wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+
+	b64, err := ptx.B64Encode()
+	require.NoError(t, err)
+	return b64
+}
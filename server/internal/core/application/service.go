@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"runtime"
 	"runtime/debug"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -30,7 +31,16 @@ import (
 	log "github.com/sirupsen/logrus"
 )
 
-const marketHourDelta = 5 * time.Minute
+const (
+	marketHourDelta = 5 * time.Minute
+	// maxAddressesPerListVtxos bounds the size of the query of a batched
+	// ListVtxos.
+	maxAddressesPerListVtxos = 1000
+	// how often Shutdown checks whether the rounds in flight ended, and how
+	// long it waits for the aborted ones to release their state
+	roundsDrainPollInterval = 100 * time.Millisecond
+	roundsAbortGracePeriod  = 10 * time.Second
+)
 
 type covenantlessService struct {
 	network             common.Network
@@ -47,17 +57,27 @@ type covenantlessService struct {
 	sweeper     *sweeper
 
 	txRequests     *txRequestsQueue
-	forfeitTxs     *forfeitTxsMap
 	redeemTxInputs *outpointMap
 	roundInputs    *outpointMap
+	// redeemTxSubmissions are the results of the redeem txs submitted with an
+	// idempotency key
+	redeemTxSubmissions *redeemTxSubmissions
 
 	eventsCh            chan domain.RoundEvent
 	transactionEventsCh chan TransactionEvent
 
-	// cached data for the current round
-	currentRoundLock    sync.Mutex
-	currentRound        *domain.Round
-	treeSigningSessions map[string]*musigSigningSession
+	// cached data for the round in registration stage
+	currentRoundLock sync.Mutex
+	currentRound     *domain.Round
+
+	// rounds in flight, at most maxConcurrentRounds, every one holding a slot
+	// of the roundSlots semaphore
+	rounds              *roundInstances
+	roundSlots          chan struct{}
+	maxConcurrentRounds int64
+
+	treeSigningSessions    map[string]*musigSigningSession
+	treeSigningSessionsMtx sync.RWMutex
 
 	// TODO derive this from wallet
 	serverSigningKey    *secp256k1.PrivateKey
@@ -67,16 +87,62 @@ type covenantlessService struct {
 	// this should be removed after we migrate to transactions version 3
 	allowZeroFees bool
 
-	numOfBoardingInputs    int
-	numOfBoardingInputsMtx sync.RWMutex
+	// amount spent by every tx request of a round, by round id, used to report
+	// the fees to the participants once the round is finalized
+	roundInputAmounts    map[string]map[string]uint64
+	roundInputAmountsMtx sync.Mutex
+
+	roundMonitor *roundMonitor
+
+	// liquidityMonitor warns when the wallet is running out of funds for the
+	// next rounds
+	liquidityMonitor *liquidityMonitor
+
+	// noteReaper deletes the redeemed notes past their expiry
+	noteReaper *noteReaper
+
+	// autoRefresher registers the vtxos close to expiry for the next round on
+	// behalf of their owners, if authorized
+	autoRefresher *autoRefresher
+
+	offlineCosignerPolicy OfflineCosignerPolicy
+
+	// requireSameBoardingOwner rejects tx requests with boarding inputs whose
+	// receivers are not owned by the owner of the boarding inputs
+	requireSameBoardingOwner bool
+
+	// exitPolicy restricts the destinations of collaborative exits
+	exitPolicy *exitPolicy
 
-	forfeitsBoardingSigsChan chan struct{}
+	// mempoolAncestors rejects the redeem txs that couldn't be broadcasted
+	// because of the mempool ancestor limit
+	mempoolAncestors *mempoolAncestorsChecker
+
+	// roundTimeout is the max duration of a round, the outer bound of the
+	// timeouts of its phases
+	roundTimeout time.Duration
+
+	// singlePartyFastMode ends the registration stage of a round as soon as a
+	// tx request is ready, for servers used by a single participant. It's the
+	// only fixed wait of a round: the tree signing and the forfeit stages
+	// already move on as soon as all the participants have responded
+	singlePartyFastMode bool
+
+	// eventPublisher is notified at every stage of the rounds
+	eventPublisher EventPublisher
+
+	// shuttingDown is set by Shutdown, no new tx requests nor rounds are
+	// accepted from then on
+	shuttingDown    bool
+	shuttingDownMtx sync.RWMutex
 
 	roundMaxParticipantsCount int64
 	utxoMaxAmount             int64
 	utxoMinAmount             int64
 	vtxoMaxAmount             int64
 	vtxoMinAmount             int64
+	settleMaxAmount           int64
+	settleMinAmount           int64
 }
 
 func NewService(
@@ -95,6 +161,24 @@ func NewService(
 	utxoMinAmount int64,
 	vtxoMaxAmount int64,
 	vtxoMinAmount int64,
+	settleMaxAmount int64,
+	settleMinAmount int64,
+	maxInputsPerSweepTx int64,
+	maxConcurrentConfirmationChecks int64,
+	stuckRoundThresholds map[RoundPhase]time.Duration,
+	stuckRoundWebhookUrl string,
+	offlineCosignerPolicy OfflineCosignerPolicy,
+	maxConcurrentRounds int64,
+	requireSameBoardingOwner bool,
+	txRequestPingGap, txRequestDeleteGap time.Duration,
+	exitScriptTypes []ExitScriptType, exitAddresses []string,
+	roundTimeout time.Duration,
+	mempoolAncestorLimit int64,
+	singlePartyFastMode bool,
+	lowLiquidityThreshold uint64,
+	autoRefreshMargin time.Duration,
+	maxReceiversPerRequest int64,
+	eventPublisher EventPublisher,
 ) (Service, error) {
 	pubkey, err := walletSvc.GetPubkey(context.Background())
 	if err != nil {
@@ -119,6 +203,10 @@ func NewService(
 		return nil, fmt.Errorf("failed to generate ephemeral key: %s", err)
 	}
 
+	if maxConcurrentRounds < 1 {
+		maxConcurrentRounds = 1
+	}
+
 	dustAmount, err := walletSvc.GetDustAmount(context.Background())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get dust amount: %s", err)
@@ -130,35 +218,95 @@ func NewService(
 		utxoMinAmount = int64(dustAmount)
 	}
 
+	// by default, a round is considered stuck if any phase lasts longer than
+	// the whole round interval
+	roundMonitor := newRoundMonitor(
+		stuckRoundThresholds, time.Duration(roundInterval)*time.Second, stuckRoundWebhookUrl,
+	)
+
+	// the deadline of a round defaults to twice the round interval
+	if roundTimeout <= 0 {
+		roundTimeout = 2 * time.Duration(roundInterval) * time.Second
+	}
+
+	if eventPublisher == nil {
+		eventPublisher = NoopEventPublisher{}
+	}
+
+	// the free liquidity is checked once per round interval
+	rounds := newRoundInstances()
+	liquidityMonitor := newLiquidityMonitor(
+		walletSvc, rounds, lowLiquidityThreshold, time.Duration(roundInterval)*time.Second,
+	)
+
 	svc := &covenantlessService{
-		network:                   network,
-		pubkey:                    pubkey,
-		vtxoTreeExpiry:            vtxoTreeExpiry,
-		roundInterval:             roundInterval,
-		unilateralExitDelay:       unilateralExitDelay,
-		wallet:                    walletSvc,
-		repoManager:               repoManager,
-		builder:                   builder,
-		scanner:                   scanner,
-		sweeper:                   newSweeper(walletSvc, repoManager, builder, scheduler, noteUriPrefix),
-		txRequests:                newTxRequestsQueue(),
-		forfeitTxs:                newForfeitTxsMap(builder),
+		network:             network,
+		pubkey:              pubkey,
+		vtxoTreeExpiry:      vtxoTreeExpiry,
+		roundInterval:       roundInterval,
+		unilateralExitDelay: unilateralExitDelay,
+		wallet:              walletSvc,
+		repoManager:         repoManager,
+		builder:             builder,
+		scanner:             scanner,
+		sweeper: newSweeper(
+			walletSvc, repoManager, builder, scheduler, noteUriPrefix, maxInputsPerSweepTx,
+			maxConcurrentConfirmationChecks, eventPublisher,
+		),
+		txRequests: newTxRequestsQueue(
+			txRequestPingGap, txRequestDeleteGap, maxReceiversPerRequest,
+		),
 		redeemTxInputs:            newOutpointMap(),
+		redeemTxSubmissions:       newRedeemTxSubmissions(redeemTxIdempotencyTTL),
 		roundInputs:               newOutpointMap(),
 		eventsCh:                  make(chan domain.RoundEvent),
+		roundInputAmounts:         make(map[string]map[string]uint64),
 		transactionEventsCh:       make(chan TransactionEvent),
 		currentRoundLock:          sync.Mutex{},
 		treeSigningSessions:       make(map[string]*musigSigningSession),
+		rounds:                    rounds,
+		roundSlots:                make(chan struct{}, maxConcurrentRounds),
+		maxConcurrentRounds:       maxConcurrentRounds,
 		boardingExitDelay:         boardingExitDelay,
 		serverSigningKey:          serverSigningKey,
 		serverSigningPubKey:       serverSigningKey.PubKey(),
 		allowZeroFees:             allowZeroFees,
-		forfeitsBoardingSigsChan:  make(chan struct{}, 1),
+		roundMonitor:              roundMonitor,
+		liquidityMonitor:          liquidityMonitor,
 		roundMaxParticipantsCount: roundMaxParticipantsCount,
 		utxoMaxAmount:             utxoMaxAmount,
 		utxoMinAmount:             utxoMinAmount,
 		vtxoMaxAmount:             vtxoMaxAmount,
 		vtxoMinAmount:             vtxoMinAmount,
+		settleMaxAmount:           settleMaxAmount,
+		settleMinAmount:           settleMinAmount,
+		offlineCosignerPolicy:     offlineCosignerPolicy,
+		requireSameBoardingOwner:  requireSameBoardingOwner,
+		roundTimeout:              roundTimeout,
+		singlePartyFastMode:       singlePartyFastMode,
+		eventPublisher:            eventPublisher,
+		mempoolAncestors: &mempoolAncestorsChecker{
+			vtxoRepo:  repoManager.Vtxos(),
+			roundRepo: repoManager.Rounds(),
+			limit:     mempoolAncestorLimit,
+		},
+	}
+
+	// the refresh requests must be pinged before being considered offline
+	refreshInterval := time.Duration(roundInterval) * time.Second
+	if txRequestPingGap > 0 && txRequestPingGap/2 < refreshInterval {
+		refreshInterval = txRequestPingGap / 2
+	}
+	svc.autoRefresher = newAutoRefresher(
+		repoManager, scheduler, svc.txRequests, svc.roundInputs, svc.RegisterIntent,
+		autoRefreshMargin, refreshInterval,
+	)
+
+	svc.noteReaper = newNoteReaper(repoManager.Notes(), noteReaperInterval)
+
+	svc.exitPolicy, err = newExitPolicy(exitScriptTypes, exitAddresses, svc.chainParams())
+	if err != nil {
+		return nil, err
 	}
 
 	repoManager.RegisterEventsHandler(
@@ -200,13 +348,155 @@ func (s *covenantlessService) Start() error {
 		return err
 	}
 
+	// the rounds that were collecting the forfeit txs before the last shutdown
+	// are resumed, the clients don't have to sign again those already collected
+	instances, err := s.restoreRounds(context.Background())
+	if err != nil {
+		log.WithError(err).Warn("failed to restore rounds in flight")
+	}
+	for _, instance := range instances {
+		go s.resumeRound(instance)
+	}
+
 	log.Debug("starting app service")
 	go s.start()
+	s.liquidityMonitor.start()
+	s.autoRefresher.start()
+	s.noteReaper.start()
 	return nil
 }
 
+// restoreRounds restores the rounds that were in finalization stage before
+// the last shutdown, with the forfeit txs collected so far. The forfeit txs
+// of the rounds that didn't reach that stage are dropped, while the rounds
+// that can't be restored are failed.
+func (s *covenantlessService) restoreRounds(ctx context.Context) ([]*roundInstance, error) {
+	roundIds, err := s.repoManager.ForfeitTxs().GetRoundIds(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rounds with forfeit txs: %s", err)
+	}
+
+	instances := make([]*roundInstance, 0, len(roundIds))
+	for _, roundId := range roundIds {
+		round, err := s.repoManager.Events().Load(ctx, roundId)
+		if err != nil || round == nil || !round.IsStarted() ||
+			round.Stage.Code != domain.FinalizationStage {
+			if err := s.repoManager.ForfeitTxs().Delete(ctx, roundId); err != nil {
+				log.WithError(err).Warnf("failed to drop forfeit txs of round %s", roundId)
+			}
+			continue
+		}
+
+		instance, err := s.restoreRound(ctx, round)
+		if err != nil {
+			log.WithError(err).Warnf("failed to restore round %s", roundId)
+			changes := round.Fail(fmt.Errorf("failed to restore round: %s", err))
+			if err := s.saveEvents(ctx, round.Id, changes); err != nil {
+				log.WithError(err).Warn("failed to store new round events")
+			}
+			if err := s.repoManager.ForfeitTxs().Delete(ctx, roundId); err != nil {
+				log.WithError(err).Warnf("failed to drop forfeit txs of round %s", roundId)
+			}
+			continue
+		}
+
+		log.Infof("restored round %s in finalization stage", roundId)
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// restoreRound makes the given round in finalization stage a round in flight
+// again, waiting for the forfeit txs not collected yet.
+func (s *covenantlessService) restoreRound(
+	ctx context.Context, round *domain.Round,
+) (*roundInstance, error) {
+	roundTx, err := psbt.NewFromRawBytes(strings.NewReader(round.UnsignedTx), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse round tx: %s", err)
+	}
+
+	requests := make([]domain.TxRequest, 0, len(round.TxRequests))
+	vtxoKeys := make([]domain.VtxoKey, 0)
+	inputAmount, outputAmount := uint64(0), uint64(0)
+	for _, request := range round.TxRequests {
+		requests = append(requests, request)
+		for _, in := range request.Inputs {
+			vtxoKeys = append(vtxoKeys, in.VtxoKey)
+			inputAmount += in.Amount
+		}
+		outputAmount += request.TotalOutputAmount()
+	}
+	numOfBoardingInputs := 0
+	for _, in := range roundTx.Inputs {
+		if len(in.TaprootLeafScript) > 0 {
+			numOfBoardingInputs++
+			inputAmount += uint64(in.WitnessUtxo.Value)
+		}
+	}
+	// the notes and the recovered vtxos spent in the round are not persisted
+	// until it's finalized, they would be left unspent
+	if outputAmount > inputAmount {
+		return nil, fmt.Errorf("round spends notes or recovered vtxos")
+	}
+
+	// the forfeit txs must pay the fee rate fixed at the start of the round,
+	// the current one is used only if the round has no vtxos to forfeit
+	feeRate := s.wallet.MinRelayFeeRate(ctx)
+	persistedFeeRate, err := s.repoManager.ForfeitTxs().GetFeeRate(ctx, round.Id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forfeit fee rate: %s", err)
+	}
+	if persistedFeeRate > 0 {
+		feeRate = chainfee.SatPerKVByte(persistedFeeRate)
+	}
+
+	forfeitTxs := newForfeitTxsMap(s.builder, s.repoManager.ForfeitTxs())
+	if err := forfeitTxs.init(
+		round.Id, round.Connectors, requests, feeRate,
+	); err != nil {
+		return nil, fmt.Errorf("failed to initialize forfeit txs: %s", err)
+	}
+
+	select {
+	case s.roundSlots <- struct{}{}:
+	default:
+		return nil, fmt.Errorf("max number of concurrent rounds reached")
+	}
+
+	instance := newRoundInstance(round, forfeitTxs, s.roundTimeout)
+	instance.txRequestIds = getTxRequestIds(requests)
+	instance.setNumOfBoardingInputs(numOfBoardingInputs)
+	instance.liquidity = outputAmount
+	s.rounds.add(instance)
+	s.roundInputs.add(vtxoKeys)
+	return instance, nil
+}
+
+// resumeRound notifies the clients of the given restored round that its
+// finalization started and finalizes it once the missing forfeit txs are
+// collected, in the last third of the round interval.
+func (s *covenantlessService) resumeRound(instance *roundInstance) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("recovered from panic in resumeRound: %v", r)
+		}
+	}()
+
+	go s.propagateEvents(instance.round)
+
+	go s.checkForfeitsAndBoardingSigsSent(instance)
+
+	roundEndTime := time.Now().Add(time.Duration(s.roundInterval) * time.Second / 3)
+	s.finalizeRound(instance, nil, nil, roundEndTime)
+}
+
 func (s *covenantlessService) Stop() {
 	s.sweeper.stop()
+	s.roundMonitor.stop()
+	s.liquidityMonitor.stop()
+	s.autoRefresher.stop()
+	s.noteReaper.stop()
 	// nolint
 	vtxos, _ := s.repoManager.Vtxos().GetAllSweepableVtxos(context.Background())
 	if len(vtxos) > 0 {
@@ -220,19 +510,206 @@ func (s *covenantlessService) Stop() {
 	close(s.eventsCh)
 }
 
+// Shutdown stops accepting new tx requests and rounds, and waits for the rounds
+// in flight to end. Once the given context is done, the rounds still in flight
+// are aborted with ErrRoundAborted, so that their participants are notified
+// that they can retry, and it waits at most roundsAbortGracePeriod for them to
+// release their state.
+func (s *covenantlessService) Shutdown(ctx context.Context) error {
+	s.shuttingDownMtx.Lock()
+	s.shuttingDown = true
+	s.shuttingDownMtx.Unlock()
+
+	log.Infof("draining %d rounds in flight", s.rounds.len())
+	if err := s.waitForRounds(ctx); err == nil {
+		return nil
+	}
+
+	log.Warnf("aborting %d rounds in flight", s.rounds.len())
+	s.rounds.abortAll(ErrRoundAborted)
+
+	graceCtx, cancel := context.WithTimeout(
+		context.Background(), roundsAbortGracePeriod,
+	)
+	defer cancel()
+	if err := s.waitForRounds(graceCtx); err != nil {
+		return fmt.Errorf(
+			"failed to drain rounds in flight, %d still running", s.rounds.len(),
+		)
+	}
+	return nil
+}
+
+// waitForRounds waits for all the rounds in flight to end, or for the given
+// context to be done.
+func (s *covenantlessService) waitForRounds(ctx context.Context) error {
+	ticker := time.NewTicker(roundsDrainPollInterval)
+	defer ticker.Stop()
+
+	for s.rounds.len() > 0 {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+	return nil
+}
+
+func (s *covenantlessService) isShuttingDown() bool {
+	s.shuttingDownMtx.RLock()
+	defer s.shuttingDownMtx.RUnlock()
+	return s.shuttingDown
+}
+
 func (s *covenantlessService) SubmitRedeemTx(
+	ctx context.Context, redeemTx, idempotencyKey string,
+) (string, string, error) {
+	if len(idempotencyKey) <= 0 {
+		return s.submitRedeemTx(ctx, redeemTx)
+	}
+
+	ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to parse redeem tx: %s", err)
+	}
+
+	return s.redeemTxSubmissions.do(
+		ctx, idempotencyKey, ptx.UnsignedTx.TxID(), func() (string, error) {
+			signedRedeemTx, _, err := s.submitRedeemTx(ctx, redeemTx)
+			return signedRedeemTx, err
+		},
+	)
+}
+
+func (s *covenantlessService) submitRedeemTx(
 	ctx context.Context, redeemTx string,
 ) (string, string, error) {
-	vtxoRepo := s.repoManager.Vtxos()
+	ptx, spentVtxoKeys, spentVtxos, err := s.getRedeemTxInputs(ctx, redeemTx)
+	if err != nil {
+		return "", "", err
+	}
 
-	expiration := int64(0)
-	roundTxid := ""
+	// lock the vtxos until the redeem tx is persisted so that concurrent
+	// submissions can't spend any of them twice
+	if exists, vtxo := s.redeemTxInputs.addIfNotIncluded(spentVtxoKeys); exists {
+		return "", "", fmt.Errorf("vtxo %s is currently being spent", vtxo)
+	}
+	releaseInputs := true
+	defer func() {
+		if releaseInputs {
+			s.redeemTxInputs.remove(spentVtxoKeys)
+		}
+	}()
 
-	ins := make([]common.VtxoInput, 0)
+	if exists, vtxo := s.roundInputs.includesAny(spentVtxoKeys); exists {
+		return "", "", fmt.Errorf("vtxo %s is already registered for next round", vtxo)
+	}
+
+	expiration, roundTxid, err := s.validateRedeemTx(ctx, redeemTx, ptx, spentVtxos)
+	if err != nil {
+		return "", "", err
+	}
+	outputs := ptx.UnsignedTx.TxOut
+	redeemTxid := ptx.UnsignedTx.TxID()
+
+	// sign the redeem tx
+
+	signedRedeemTx, err := s.wallet.SignTransactionTapscript(ctx, redeemTx, nil)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sign redeem tx: %s", err)
+	}
+
+	releaseInputs = false
+	go func(ptx *psbt.Packet, signedRedeemTx, redeemTxid string) {
+		defer s.redeemTxInputs.remove(spentVtxoKeys)
+
+		ctx := context.Background()
+		// Create new vtxos, update spent vtxos state
+		newVtxos := make([]domain.Vtxo, 0, len(ptx.UnsignedTx.TxOut))
+		for outIndex, out := range outputs {
+			// the anchor is only meant to bump the fees of the tx
+			if tree.IsAnchor(out) {
+				continue
+			}
+			//notlint:all
+			vtxoPubkey := hex.EncodeToString(out.PkScript[2:])
+
+			newVtxos = append(newVtxos, domain.Vtxo{
+				VtxoKey: domain.VtxoKey{
+					Txid: redeemTxid,
+					VOut: uint32(outIndex),
+				},
+				PubKey:    vtxoPubkey,
+				Amount:    uint64(out.Value),
+				ExpireAt:  expiration,
+				RoundTxid: roundTxid,
+				RedeemTx:  signedRedeemTx,
+				CreatedAt: time.Now().Unix(),
+			})
+		}
+
+		if err := s.repoManager.Vtxos().ApplyVtxoStateChange(
+			ctx, spentVtxoKeys, redeemTxid, newVtxos,
+		); err != nil {
+			log.WithError(err).Warn("failed to spend and add vtxos")
+			return
+		}
+		log.Debugf("spent %d vtxos and added %d vtxos", len(spentVtxos), len(newVtxos))
+
+		if err := s.startWatchingVtxos(newVtxos); err != nil {
+			log.WithError(err).Warn("failed to start watching vtxos")
+		} else {
+			log.Debugf("started watching %d vtxos", len(newVtxos))
+		}
+
+		for i := range spentVtxos {
+			spentVtxos[i].Spent = true
+			spentVtxos[i].SpentBy = redeemTxid
+		}
+
+		s.transactionEventsCh <- RedeemTransactionEvent{
+			RedeemTxid:     redeemTxid,
+			SpentVtxos:     spentVtxos,
+			SpendableVtxos: newVtxos,
+			TxHex:          signedRedeemTx,
+		}
+	}(ptx, signedRedeemTx, redeemTxid)
+
+	return signedRedeemTx, redeemTxid, nil
+}
 
+// ValidateRedeemTx runs the same checks of SubmitRedeemTx without signing
+// the redeem tx, nor locking or spending its inputs.
+func (s *covenantlessService) ValidateRedeemTx(
+	ctx context.Context, redeemTx string,
+) (string, error) {
+	ptx, spentVtxoKeys, spentVtxos, err := s.getRedeemTxInputs(ctx, redeemTx)
+	if err != nil {
+		return "", err
+	}
+
+	if exists, vtxo := s.redeemTxInputs.includesAny(spentVtxoKeys); exists {
+		return "", fmt.Errorf("vtxo %s is currently being spent", vtxo)
+	}
+	if exists, vtxo := s.roundInputs.includesAny(spentVtxoKeys); exists {
+		return "", fmt.Errorf("vtxo %s is already registered for next round", vtxo)
+	}
+
+	if _, _, err := s.validateRedeemTx(ctx, redeemTx, ptx, spentVtxos); err != nil {
+		return "", err
+	}
+	return ptx.UnsignedTx.TxID(), nil
+}
+
+// getRedeemTxInputs parses the given redeem tx and returns it along with the
+// vtxos it spends.
+func (s *covenantlessService) getRedeemTxInputs(
+	ctx context.Context, redeemTx string,
+) (*psbt.Packet, []domain.VtxoKey, []domain.Vtxo, error) {
 	ptx, err := psbt.NewFromRawBytes(strings.NewReader(redeemTx), true)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to parse redeem tx: %s", err)
+		return nil, nil, nil, fmt.Errorf("failed to parse redeem tx: %s", err)
 	}
 
 	spentVtxoKeys := make([]domain.VtxoKey, 0, len(ptx.Inputs))
@@ -243,27 +720,38 @@ func (s *covenantlessService) SubmitRedeemTx(
 		})
 	}
 
-	spentVtxos, err := vtxoRepo.GetVtxos(ctx, spentVtxoKeys)
+	spentVtxos, err := s.repoManager.Vtxos().GetVtxos(ctx, spentVtxoKeys)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to get vtxos: %s", err)
+		return nil, nil, nil, fmt.Errorf("failed to get vtxos: %s", err)
 	}
 
 	if len(spentVtxos) != len(spentVtxoKeys) {
-		return "", "", fmt.Errorf("some vtxos not found")
+		return nil, nil, nil, fmt.Errorf("some vtxos not found")
 	}
+	return ptx, spentVtxoKeys, spentVtxos, nil
+}
 
-	if exists, vtxo := s.roundInputs.includesAny(spentVtxoKeys); exists {
-		return "", "", fmt.Errorf("vtxo %s is already registered for next round", vtxo)
+// validateRedeemTx verifies the inputs, the outputs, the fees and the
+// signatures of the given redeem tx, and returns the expiration and the round
+// txid of the vtxos it creates.
+func (s *covenantlessService) validateRedeemTx(
+	ctx context.Context, redeemTx string, ptx *psbt.Packet, spentVtxos []domain.Vtxo,
+) (int64, string, error) {
+	// an anchor is only meant to bump the fees of the tx, not to carry funds
+	if err := tree.ValidateAnchors(ptx.UnsignedTx); err != nil {
+		return 0, "", err
 	}
 
-	s.redeemTxInputs.add(spentVtxoKeys)
-	defer s.redeemTxInputs.remove(spentVtxoKeys)
+	expiration := int64(0)
+	roundTxid := ""
+
+	ins := make([]common.VtxoInput, 0)
 
 	vtxoMap := make(map[wire.OutPoint]domain.Vtxo)
 	for _, vtxo := range spentVtxos {
 		hash, err := chainhash.NewHashFromStr(vtxo.Txid)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to parse vtxo txid: %s", err)
+			return 0, "", fmt.Errorf("failed to parse vtxo txid: %s", err)
 		}
 		vtxoMap[wire.OutPoint{Hash: *hash, Index: vtxo.VOut}] = vtxo
 	}
@@ -271,87 +759,87 @@ func (s *covenantlessService) SubmitRedeemTx(
 	sumOfInputs := int64(0)
 	for inputIndex, input := range ptx.Inputs {
 		if input.WitnessUtxo == nil {
-			return "", "", fmt.Errorf("missing witness utxo")
+			return 0, "", fmt.Errorf("missing witness utxo")
 		}
 
 		if len(input.TaprootLeafScript) == 0 {
-			return "", "", fmt.Errorf("missing tapscript leaf")
+			return 0, "", fmt.Errorf("missing tapscript leaf")
 		}
 
 		tapscripts, err := tree.GetTaprootTree(input)
 		if err != nil {
-			return "", "", fmt.Errorf("missing tapscripts: %s", err)
+			return 0, "", fmt.Errorf("missing tapscripts: %s", err)
 		}
 
 		if len(input.TaprootScriptSpendSig) == 0 {
-			return "", "", fmt.Errorf("missing tapscript spend sig")
+			return 0, "", fmt.Errorf("missing tapscript spend sig")
 		}
 
 		if len(input.TaprootLeafScript) != 1 {
-			return "", "", fmt.Errorf("expected exactly one taproot leaf script")
+			return 0, "", fmt.Errorf("expected exactly one taproot leaf script")
 		}
 
 		signedTapscript := input.TaprootLeafScript[0]
 
 		if signedTapscript == nil {
-			return "", "", fmt.Errorf("no matching tapscript found")
+			return 0, "", fmt.Errorf("no matching tapscript found")
 		}
 
 		outpoint := ptx.UnsignedTx.TxIn[inputIndex].PreviousOutPoint
 
 		vtxo, exists := vtxoMap[outpoint]
 		if !exists {
-			return "", "", fmt.Errorf("vtxo not found")
+			return 0, "", fmt.Errorf("vtxo not found")
 		}
 
 		// make sure we don't use the same vtxo twice
 		delete(vtxoMap, outpoint)
 
 		if vtxo.Spent {
-			return "", "", fmt.Errorf("vtxo already spent")
+			return 0, "", fmt.Errorf("vtxo already spent")
 		}
 
 		if vtxo.Redeemed {
-			return "", "", fmt.Errorf("vtxo already redeemed")
+			return 0, "", fmt.Errorf("vtxo already redeemed")
 		}
 
 		if vtxo.Swept {
-			return "", "", fmt.Errorf("vtxo already swept")
+			return 0, "", fmt.Errorf("vtxo already swept")
 		}
 
 		vtxoScript, err := tree.ParseVtxoScript(tapscripts)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to parse vtxo script: %s", err)
+			return 0, "", fmt.Errorf("failed to parse vtxo script: %s", err)
 		}
 
 		// validate the vtxo script
 		if err := vtxoScript.Validate(s.pubkey, s.unilateralExitDelay); err != nil {
-			return "", "", fmt.Errorf("invalid vtxo script: %s", err)
+			return 0, "", fmt.Errorf("invalid vtxo script: %s", err)
 		}
 
 		// verify the witnessUtxo script
 		if input.WitnessUtxo == nil {
-			return "", "", fmt.Errorf("missing witness utxo")
+			return 0, "", fmt.Errorf("missing witness utxo")
 		}
 
 		witnessUtxoScript := input.WitnessUtxo.PkScript
 
 		tapKeyFromTapscripts, _, err := vtxoScript.TapTree()
 		if err != nil {
-			return "", "", fmt.Errorf("failed to get taproot key from vtxo script: %s", err)
+			return 0, "", fmt.Errorf("failed to get taproot key from vtxo script: %s", err)
 		}
 
 		if vtxo.PubKey != hex.EncodeToString(schnorr.SerializePubKey(tapKeyFromTapscripts)) {
-			return "", "", fmt.Errorf("vtxo pubkey mismatch")
+			return 0, "", fmt.Errorf("vtxo pubkey mismatch")
 		}
 
 		pkScriptFromTapscripts, err := common.P2TRScript(tapKeyFromTapscripts)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to get pkscript from taproot key: %s", err)
+			return 0, "", fmt.Errorf("failed to get pkscript from taproot key: %s", err)
 		}
 
 		if !bytes.Equal(witnessUtxoScript, pkScriptFromTapscripts) {
-			return "", "", fmt.Errorf("witness utxo script mismatch")
+			return 0, "", fmt.Errorf("witness utxo script mismatch")
 		}
 
 		sumOfInputs += input.WitnessUtxo.Value
@@ -370,7 +858,7 @@ func (s *covenantlessService) SubmitRedeemTx(
 			if !bytes.Equal(sig.XOnlyPubKey, serverXOnlyPubkey) {
 				parsed, err := schnorr.ParsePubKey(sig.XOnlyPubKey)
 				if err != nil {
-					return "", "", fmt.Errorf("failed to parse pubkey: %s", err)
+					return 0, "", fmt.Errorf("failed to parse pubkey: %s", err)
 				}
 				userPubkey = parsed
 				break
@@ -378,37 +866,37 @@ func (s *covenantlessService) SubmitRedeemTx(
 		}
 
 		if userPubkey == nil {
-			return "", "", fmt.Errorf("redeem transaction is not signed")
+			return 0, "", fmt.Errorf("redeem transaction is not signed")
 		}
 
 		vtxoPubkeyBuf, err := hex.DecodeString(vtxo.PubKey)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to decode vtxo pubkey: %s", err)
+			return 0, "", fmt.Errorf("failed to decode vtxo pubkey: %s", err)
 		}
 
 		vtxoPubkey, err := schnorr.ParsePubKey(vtxoPubkeyBuf)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to parse vtxo pubkey: %s", err)
+			return 0, "", fmt.Errorf("failed to parse vtxo pubkey: %s", err)
 		}
 
 		// verify witness utxo
 		pkscript, err := common.P2TRScript(vtxoPubkey)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to get pkscript: %s", err)
+			return 0, "", fmt.Errorf("failed to get pkscript: %s", err)
 		}
 
 		if !bytes.Equal(input.WitnessUtxo.PkScript, pkscript) {
-			return "", "", fmt.Errorf("witness utxo script mismatch")
+			return 0, "", fmt.Errorf("witness utxo script mismatch")
 		}
 
 		if input.WitnessUtxo.Value != int64(vtxo.Amount) {
-			return "", "", fmt.Errorf("witness utxo value mismatch")
+			return 0, "", fmt.Errorf("witness utxo value mismatch")
 		}
 
 		// verify forfeit closure script
 		closure, err := tree.DecodeClosure(signedTapscript.Script)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to decode forfeit closure: %s", err)
+			return 0, "", fmt.Errorf("failed to decode forfeit closure: %s", err)
 		}
 
 		var locktime *common.AbsoluteLocktime
@@ -416,30 +904,28 @@ func (s *covenantlessService) SubmitRedeemTx(
 		switch c := closure.(type) {
 		case *tree.CLTVMultisigClosure:
 			locktime = &c.Locktime
-		case *tree.MultisigClosure, *tree.ConditionMultisigClosure:
+		case *tree.MultisigClosure, *tree.ConditionMultisigClosure,
+			*tree.HashLockMultisigClosure:
 		default:
-			return "", "", fmt.Errorf("invalid forfeit closure script %x, cannot verify redeem tx", signedTapscript.Script)
+			return 0, "", fmt.Errorf("invalid forfeit closure script %x, cannot verify redeem tx", signedTapscript.Script)
 		}
 
 		if locktime != nil {
 			blocktimestamp, err := s.wallet.GetCurrentBlockTime(ctx)
 			if err != nil {
-				return "", "", fmt.Errorf("failed to get current block time: %s", err)
+				return 0, "", fmt.Errorf("failed to get current block time: %s", err)
 			}
-			if !locktime.IsSeconds() {
-				if *locktime > common.AbsoluteLocktime(blocktimestamp.Height) {
-					return "", "", fmt.Errorf("forfeit closure is CLTV locked, %d > %d (block time)", *locktime, blocktimestamp.Time)
-				}
-			} else {
-				if *locktime > common.AbsoluteLocktime(blocktimestamp.Time) {
-					return "", "", fmt.Errorf("forfeit closure is CLTV locked, %d > %d (block time)", *locktime, blocktimestamp.Time)
-				}
+			if locktime.IsLocked(blocktimestamp.Height, blocktimestamp.Time) {
+				return 0, "", fmt.Errorf(
+					"forfeit closure is CLTV locked until %d (block height %d, block time %d)",
+					*locktime, blocktimestamp.Height, blocktimestamp.Time,
+				)
 			}
 		}
 
 		ctrlBlock, err := txscript.ParseControlBlock(signedTapscript.ControlBlock)
 		if err != nil {
-			return "", "", fmt.Errorf("failed to parse control block: %s", err)
+			return 0, "", fmt.Errorf("failed to parse control block: %s", err)
 		}
 
 		ins = append(ins, common.VtxoInput{
@@ -456,23 +942,26 @@ func (s *covenantlessService) SubmitRedeemTx(
 
 	sumOfOutputs := int64(0)
 	for _, out := range outputs {
+		if tree.IsAnchor(out) {
+			continue
+		}
 		sumOfOutputs += out.Value
 
 		if s.vtxoMaxAmount >= 0 {
 			if out.Value > s.vtxoMaxAmount {
-				return "", "", fmt.Errorf("output amount is higher than max vtxo amount:%d", s.vtxoMaxAmount)
+				return 0, "", fmt.Errorf("output amount is higher than max vtxo amount:%d", s.vtxoMaxAmount)
 			}
 		}
 		if s.vtxoMinAmount >= 0 {
 			if out.Value < s.vtxoMinAmount {
-				return "", "", fmt.Errorf("output amount is lower than min utxo amount:%d", s.vtxoMinAmount)
+				return 0, "", fmt.Errorf("output amount is lower than min utxo amount:%d", s.vtxoMinAmount)
 			}
 		}
 	}
 
 	fees := sumOfInputs - sumOfOutputs
 	if fees < 0 {
-		return "", "", fmt.Errorf("invalid fees, inputs are less than outputs")
+		return 0, "", fmt.Errorf("invalid fees, inputs are less than outputs")
 	}
 
 	if !s.allowZeroFees {
@@ -480,105 +969,73 @@ func (s *covenantlessService) SubmitRedeemTx(
 
 		minFees, err := common.ComputeRedeemTxFee(chainfee.SatPerKVByte(minFeeRate), ins, len(outputs))
 		if err != nil {
-			return "", "", fmt.Errorf("failed to compute min fees: %s", err)
+			return 0, "", fmt.Errorf("failed to compute min fees: %s", err)
 		}
 
 		if fees < minFees {
-			return "", "", fmt.Errorf("min relay fee not met, %d < %d", fees, minFees)
+			return 0, "", fmt.Errorf("min relay fee not met, %d < %d", fees, minFees)
 		}
 	}
 
 	// recompute redeem tx
 	rebuiltRedeemTx, err := tree.BuildRedeemTx(ins, outputs)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to rebuild redeem tx: %s", err)
+		return 0, "", fmt.Errorf("failed to rebuild redeem tx: %s", err)
 	}
 
 	rebuiltPtx, err := psbt.NewFromRawBytes(strings.NewReader(rebuiltRedeemTx), true)
 	if err != nil {
-		return "", "", fmt.Errorf("failed to parse rebuilt redeem tx: %s", err)
+		return 0, "", fmt.Errorf("failed to parse rebuilt redeem tx: %s", err)
 	}
 
-	rebuiltTxid := rebuiltPtx.UnsignedTx.TxID()
-	redeemTxid := ptx.UnsignedTx.TxID()
-	if rebuiltTxid != redeemTxid {
-		return "", "", fmt.Errorf("invalid redeem tx")
+	if rebuiltPtx.UnsignedTx.TxID() != ptx.UnsignedTx.TxID() {
+		return 0, "", fmt.Errorf("invalid redeem tx")
 	}
 
 	// verify the tapscript signatures
 	if valid, _, err := s.builder.VerifyTapscriptPartialSigs(redeemTx); err != nil || !valid {
-		return "", "", fmt.Errorf("invalid tx signature: %s", err)
+		return 0, "", fmt.Errorf("invalid tx signature: %s", err)
 	}
 
 	if expiration == 0 {
-		return "", "", fmt.Errorf("no valid vtxo found")
+		return 0, "", fmt.Errorf("no valid vtxo found")
 	}
 
 	if roundTxid == "" {
-		return "", "", fmt.Errorf("no valid vtxo found")
+		return 0, "", fmt.Errorf("no valid vtxo found")
 	}
 
-	// sign the redeem tx
-
-	signedRedeemTx, err := s.wallet.SignTransactionTapscript(ctx, redeemTx, nil)
-	if err != nil {
-		return "", "", fmt.Errorf("failed to sign redeem tx: %s", err)
+	if err := s.mempoolAncestors.validate(ctx, spentVtxos); err != nil {
+		return 0, "", err
 	}
 
-	go func(ptx *psbt.Packet, signedRedeemTx, redeemTxid string) {
-		ctx := context.Background()
-		// Create new vtxos, update spent vtxos state
-		newVtxos := make([]domain.Vtxo, 0, len(ptx.UnsignedTx.TxOut))
-		for outIndex, out := range outputs {
-			//notlint:all
-			vtxoPubkey := hex.EncodeToString(out.PkScript[2:])
-
-			newVtxos = append(newVtxos, domain.Vtxo{
-				VtxoKey: domain.VtxoKey{
-					Txid: redeemTxid,
-					VOut: uint32(outIndex),
-				},
-				PubKey:    vtxoPubkey,
-				Amount:    uint64(out.Value),
-				ExpireAt:  expiration,
-				RoundTxid: roundTxid,
-				RedeemTx:  signedRedeemTx,
-				CreatedAt: time.Now().Unix(),
-			})
-		}
-
-		if err := s.repoManager.Vtxos().AddVtxos(ctx, newVtxos); err != nil {
-			log.WithError(err).Warn("failed to add vtxos")
-			return
-		}
-		log.Debugf("added %d vtxos", len(newVtxos))
-
-		if err := s.repoManager.Vtxos().SpendVtxos(ctx, spentVtxoKeys, redeemTxid); err != nil {
-			log.WithError(err).Warn("failed to spend vtxos")
-			return
-		}
-		log.Debugf("spent %d vtxos", len(spentVtxos))
-
-		if err := s.startWatchingVtxos(newVtxos); err != nil {
-			log.WithError(err).Warn("failed to start watching vtxos")
-		} else {
-			log.Debugf("started watching %d vtxos", len(newVtxos))
-		}
-
-		for i := range spentVtxos {
-			spentVtxos[i].Spent = true
-			spentVtxos[i].SpentBy = redeemTxid
-		}
+	return expiration, roundTxid, nil
+}
 
-		s.transactionEventsCh <- RedeemTransactionEvent{
-			RedeemTxid:     redeemTxid,
-			SpentVtxos:     spentVtxos,
-			SpendableVtxos: newVtxos,
-			TxHex:          signedRedeemTx,
-		}
-	}(ptx, signedRedeemTx, redeemTxid)
+// SubmitRedeemTxs processes the given redeem txs concurrently and returns the
+// result of each of them in the same order. Txs spending the same vtxos are
+// accepted only once, the others are rejected.
+func (s *covenantlessService) SubmitRedeemTxs(
+	ctx context.Context, redeemTxs []string,
+) []RedeemTxResult {
+	results := make([]RedeemTxResult, len(redeemTxs))
+
+	wg := &sync.WaitGroup{}
+	wg.Add(len(redeemTxs))
+	for i, redeemTx := range redeemTxs {
+		go func(i int, redeemTx string) {
+			defer wg.Done()
+			signedRedeemTx, redeemTxid, err := s.SubmitRedeemTx(ctx, redeemTx, "")
+			results[i] = RedeemTxResult{
+				SignedRedeemTx: signedRedeemTx,
+				Txid:           redeemTxid,
+				Err:            err,
+			}
+		}(i, redeemTx)
+	}
+	wg.Wait()
 
-	return signedRedeemTx, redeemTxid, nil
+	return results
 }
 
 func (s *covenantlessService) GetBoardingAddress(
@@ -609,45 +1066,63 @@ func (s *covenantlessService) GetBoardingAddress(
 }
 
 func (s *covenantlessService) SpendNotes(ctx context.Context, notes []note.Note) (string, error) {
-	notesRepo := s.repoManager.Notes()
+	if s.isShuttingDown() {
+		return "", ErrShuttingDown
+	}
 
 	for _, note := range notes {
-		// verify the note signature
-		hash := note.Hash()
-
-		valid, err := s.wallet.VerifyMessageSignature(ctx, hash, note.Signature)
-		if err != nil {
-			return "", fmt.Errorf("failed to verify note signature: %s", err)
+		if err := s.validateNote(ctx, note); err != nil {
+			return "", err
 		}
+	}
 
-		if !valid {
-			return "", fmt.Errorf("invalid note signature %s", note)
-		}
+	request, err := domain.NewTxRequest(make([]domain.Vtxo, 0))
+	if err != nil {
+		return "", fmt.Errorf("failed to create tx request: %s", err)
+	}
 
-		// verify that the note is spendable
-		spent, err := notesRepo.Contains(ctx, note.ID)
-		if err != nil {
-			return "", fmt.Errorf("failed to check if note is spent: %s", err)
-		}
+	if err := s.txRequests.pushWithNotes(*request, notes); err != nil {
+		return "", fmt.Errorf("failed to push tx requests: %s", err)
+	}
+
+	return request.Id, nil
+}
+
+func (s *covenantlessService) validateNote(ctx context.Context, note note.Note) error {
+	// verify the note signature
+	hash := note.Hash()
+
+	valid, err := s.wallet.VerifyMessageSignature(ctx, hash, note.Signature)
+	if err != nil {
+		return fmt.Errorf("failed to verify note signature: %s", err)
+	}
 
-		if spent {
-			return "", fmt.Errorf("note already spent: %s", note)
-		}
+	if !valid {
+		return fmt.Errorf("invalid note signature %s", note)
 	}
 
-	request, err := domain.NewTxRequest(make([]domain.Vtxo, 0))
+	// verify that the note is spendable
+	spent, err := s.repoManager.Notes().Contains(ctx, note.ID)
 	if err != nil {
-		return "", fmt.Errorf("failed to create tx request: %s", err)
+		return fmt.Errorf("failed to check if note is spent: %s", err)
 	}
 
-	if err := s.txRequests.pushWithNotes(*request, notes); err != nil {
-		return "", fmt.Errorf("failed to push tx requests: %s", err)
+	if spent {
+		return fmt.Errorf("note already spent: %s", note)
 	}
 
-	return request.Id, nil
+	if err := note.Verify(time.Now()); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (s *covenantlessService) RegisterIntent(ctx context.Context, bip322signature bip322.Signature, message tree.IntentMessage) (string, error) {
+	if s.isShuttingDown() {
+		return "", ErrShuttingDown
+	}
+
 	vtxoKeys := make([]domain.VtxoKey, 0)
 	// the vtxo to swap for new ones
 	vtxosInputs := make([]domain.Vtxo, 0)
@@ -902,6 +1377,9 @@ func (s *covenantlessService) RegisterIntent(ctx context.Context, bip322signatur
 				}
 
 				rcv.OnchainAddress = addrs[0].EncodeAddress()
+				if err := s.exitPolicy.validate(rcv.OnchainAddress); err != nil {
+					return "", err
+				}
 			} else {
 				if s.vtxoMaxAmount >= 0 {
 					if amount > uint64(s.vtxoMaxAmount) {
@@ -935,6 +1413,10 @@ func (s *covenantlessService) RegisterIntent(ctx context.Context, bip322signatur
 			}
 		}
 
+		if err := s.validateBoardingOwners(boardingInputs, receivers); err != nil {
+			return "", err
+		}
+
 		if err := request.AddReceivers(receivers); err != nil {
 			return "", err
 		}
@@ -950,6 +1432,10 @@ func (s *covenantlessService) RegisterIntent(ctx context.Context, bip322signatur
 }
 
 func (s *covenantlessService) SpendVtxos(ctx context.Context, inputs []ports.Input) (string, error) {
+	if s.isShuttingDown() {
+		return "", ErrShuttingDown
+	}
+
 	vtxosInputs := make([]domain.Vtxo, 0)
 	vtxoKeys := make([]domain.VtxoKey, 0)
 	boardingInputs := make([]ports.BoardingInput, 0)
@@ -959,135 +1445,153 @@ func (s *covenantlessService) SpendVtxos(ctx context.Context, inputs []ports.Inp
 	boardingTxs := make(map[string]wire.MsgTx, 0) // txid -> txhex
 
 	for _, input := range inputs {
-		if s.redeemTxInputs.includes(input.VtxoKey) {
-			return "", fmt.Errorf("vtxo %s is currently being spent", input.String())
+		vtxo, boardingInput, err := s.validateInput(ctx, input, boardingTxs, now)
+		if err != nil {
+			return "", err
 		}
 
-		vtxosResult, err := s.repoManager.Vtxos().GetVtxos(ctx, []domain.VtxoKey{input.VtxoKey})
-		if err != nil || len(vtxosResult) == 0 {
-			// vtxo not found in db, check if it exists on-chain
-			if _, ok := boardingTxs[input.Txid]; !ok {
-				// check if the tx exists and is confirmed
-				txhex, err := s.wallet.GetTransaction(ctx, input.Txid)
-				if err != nil {
-					return "", fmt.Errorf("failed to get tx %s: %s", input.Txid, err)
-				}
+		if boardingInput != nil {
+			boardingInputs = append(boardingInputs, *boardingInput)
+			continue
+		}
 
-				var tx wire.MsgTx
-				if err := tx.Deserialize(hex.NewDecoder(strings.NewReader(txhex))); err != nil {
-					return "", fmt.Errorf("failed to deserialize tx %s: %s", input.Txid, err)
-				}
+		vtxosInputs = append(vtxosInputs, *vtxo)
+		vtxoKeys = append(vtxoKeys, vtxo.VtxoKey)
+	}
 
-				confirmed, _, blocktime, err := s.wallet.IsTransactionConfirmed(ctx, input.Txid)
-				if err != nil {
-					return "", fmt.Errorf("failed to check tx %s: %s", input.Txid, err)
-				}
+	request, err := domain.NewTxRequest(vtxosInputs)
+	if err != nil {
+		return "", err
+	}
 
-				if !confirmed {
-					return "", fmt.Errorf("tx %s not confirmed", input.Txid)
-				}
+	if err := s.txRequests.push(*request, boardingInputs, nil, nil); err != nil {
+		return "", err
+	}
 
-				vtxoScript, err := tree.ParseVtxoScript(input.Tapscripts)
-				if err != nil {
-					return "", fmt.Errorf("failed to parse boarding descriptor: %s", err)
-				}
+	s.roundInputs.add(vtxoKeys)
 
-				// validate the vtxo script
-				// TODO: fix in PR #501
-				if err := vtxoScript.Validate(s.pubkey, common.RelativeLocktime{
-					Type:  s.unilateralExitDelay.Type,
-					Value: s.unilateralExitDelay.Value * 2,
-				}); err != nil {
-					return "", fmt.Errorf("invalid vtxo script: %s", err)
-				}
+	return request.Id, nil
+}
 
-				exitDelay, err := vtxoScript.SmallestExitDelay()
-				if err != nil {
-					return "", fmt.Errorf("failed to get exit delay: %s", err)
-				}
+// validateInput runs the checks required to register the given input in a tx
+// request, and returns either the vtxo or the boarding input it refers to.
+// The boardingTxs cache is meant to be shared among the inputs of a request.
+func (s *covenantlessService) validateInput(
+	ctx context.Context, input ports.Input, boardingTxs map[string]wire.MsgTx, now int64,
+) (*domain.Vtxo, *ports.BoardingInput, error) {
+	if s.redeemTxInputs.includes(input.VtxoKey) {
+		return nil, nil, fmt.Errorf("vtxo %s is currently being spent", input.String())
+	}
+
+	vtxosResult, err := s.repoManager.Vtxos().GetVtxos(ctx, []domain.VtxoKey{input.VtxoKey})
+	if err != nil || len(vtxosResult) == 0 {
+		// vtxo not found in db, check if it exists on-chain
+		if _, ok := boardingTxs[input.Txid]; !ok {
+			// check if the tx exists and is confirmed
+			txhex, err := s.wallet.GetTransaction(ctx, input.Txid)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get tx %s: %s", input.Txid, err)
+			}
 
-				// if the exit path is available, forbid registering the boarding utxo
-				if blocktime+exitDelay.Seconds() < now {
-					return "", fmt.Errorf("tx %s expired", input.Txid)
-				}
+			var tx wire.MsgTx
+			if err := tx.Deserialize(hex.NewDecoder(strings.NewReader(txhex))); err != nil {
+				return nil, nil, fmt.Errorf("failed to deserialize tx %s: %s", input.Txid, err)
+			}
 
-				if s.utxoMaxAmount >= 0 {
-					if tx.TxOut[input.VOut].Value > s.utxoMaxAmount {
-						return "", fmt.Errorf("boarding input amount is higher than max utxo amount:%d", s.utxoMaxAmount)
-					}
-				}
-				if s.utxoMinAmount >= 0 {
-					if tx.TxOut[input.VOut].Value < s.utxoMinAmount {
-						return "", fmt.Errorf("boarding input amount is lower than min utxo amount:%d", s.utxoMinAmount)
-					}
-				}
+			confirmed, _, blocktime, err := s.wallet.IsTransactionConfirmed(ctx, input.Txid)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to check tx %s: %s", input.Txid, err)
+			}
 
-				boardingTxs[input.Txid] = tx
+			if !confirmed {
+				return nil, nil, fmt.Errorf("tx %s not confirmed", input.Txid)
 			}
 
-			tx := boardingTxs[input.Txid]
-			boardingInput, err := s.newBoardingInput(tx, input)
+			vtxoScript, err := tree.ParseVtxoScript(input.Tapscripts)
 			if err != nil {
-				return "", err
+				return nil, nil, fmt.Errorf("failed to parse boarding descriptor: %s", err)
 			}
 
-			boardingInputs = append(boardingInputs, *boardingInput)
-			continue
-		}
+			// validate the vtxo script
+			// TODO: fix in PR #501
+			if err := vtxoScript.Validate(s.pubkey, common.RelativeLocktime{
+				Type:  s.unilateralExitDelay.Type,
+				Value: s.unilateralExitDelay.Value * 2,
+			}); err != nil {
+				return nil, nil, fmt.Errorf("invalid vtxo script: %s", err)
+			}
 
-		vtxo := vtxosResult[0]
-		if vtxo.Spent {
-			return "", fmt.Errorf("input %s:%d already spent", vtxo.Txid, vtxo.VOut)
-		}
+			exitDelay, err := vtxoScript.SmallestExitDelay()
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to get exit delay: %s", err)
+			}
 
-		if vtxo.Redeemed {
-			return "", fmt.Errorf("input %s:%d already redeemed", vtxo.Txid, vtxo.VOut)
-		}
+			// if the exit path is available, forbid registering the boarding utxo
+			if blocktime+exitDelay.Seconds() < now {
+				return nil, nil, fmt.Errorf("tx %s expired", input.Txid)
+			}
 
-		if vtxo.Swept {
-			return "", fmt.Errorf("input %s:%d already swept", vtxo.Txid, vtxo.VOut)
+			if s.utxoMaxAmount >= 0 {
+				if tx.TxOut[input.VOut].Value > s.utxoMaxAmount {
+					return nil, nil, fmt.Errorf("boarding input amount is higher than max utxo amount:%d", s.utxoMaxAmount)
+				}
+			}
+			if s.utxoMinAmount >= 0 {
+				if tx.TxOut[input.VOut].Value < s.utxoMinAmount {
+					return nil, nil, fmt.Errorf("boarding input amount is lower than min utxo amount:%d", s.utxoMinAmount)
+				}
+			}
+
+			boardingTxs[input.Txid] = tx
 		}
 
-		vtxoScript, err := tree.ParseVtxoScript(input.Tapscripts)
+		tx := boardingTxs[input.Txid]
+		boardingInput, err := s.newBoardingInput(tx, input)
 		if err != nil {
-			return "", fmt.Errorf("failed to parse boarding descriptor: %s", err)
+			return nil, nil, err
 		}
 
-		// validate the vtxo script
-		if err := vtxoScript.Validate(s.pubkey, s.unilateralExitDelay); err != nil {
-			return "", fmt.Errorf("invalid vtxo script: %s", err)
-		}
+		return nil, boardingInput, nil
+	}
 
-		tapKey, _, err := vtxoScript.TapTree()
-		if err != nil {
-			return "", fmt.Errorf("failed to get taproot key: %s", err)
-		}
+	vtxo := vtxosResult[0]
+	if vtxo.Spent {
+		return nil, nil, fmt.Errorf("input %s:%d already spent", vtxo.Txid, vtxo.VOut)
+	}
 
-		expectedTapKey, err := vtxo.TapKey()
-		if err != nil {
-			return "", fmt.Errorf("failed to get taproot key: %s", err)
-		}
+	if vtxo.Redeemed {
+		return nil, nil, fmt.Errorf("input %s:%d already redeemed", vtxo.Txid, vtxo.VOut)
+	}
 
-		if !bytes.Equal(schnorr.SerializePubKey(tapKey), schnorr.SerializePubKey(expectedTapKey)) {
-			return "", fmt.Errorf("descriptor does not match vtxo pubkey")
-		}
+	if vtxo.Swept {
+		return nil, nil, fmt.Errorf("input %s:%d already swept", vtxo.Txid, vtxo.VOut)
+	}
 
-		vtxosInputs = append(vtxosInputs, vtxo)
-		vtxoKeys = append(vtxoKeys, vtxo.VtxoKey)
+	vtxoScript, err := tree.ParseVtxoScript(input.Tapscripts)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse boarding descriptor: %s", err)
 	}
 
-	request, err := domain.NewTxRequest(vtxosInputs)
+	// validate the vtxo script
+	if err := vtxoScript.Validate(s.pubkey, s.unilateralExitDelay); err != nil {
+		return nil, nil, fmt.Errorf("invalid vtxo script: %s", err)
+	}
+
+	tapKey, _, err := vtxoScript.TapTree()
 	if err != nil {
-		return "", err
+		return nil, nil, fmt.Errorf("failed to get taproot key: %s", err)
 	}
 
-	if err := s.txRequests.push(*request, boardingInputs, nil, nil); err != nil {
-		return "", err
+	expectedTapKey, err := vtxo.TapKey()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get taproot key: %s", err)
 	}
 
-	s.roundInputs.add(vtxoKeys)
+	if !bytes.Equal(schnorr.SerializePubKey(tapKey), schnorr.SerializePubKey(expectedTapKey)) {
+		return nil, nil, fmt.Errorf("descriptor does not match vtxo pubkey")
+	}
 
-	return request.Id, nil
+	return &vtxo, nil, nil
 }
 
 func (s *covenantlessService) newBoardingInput(tx wire.MsgTx, input ports.Input) (*ports.BoardingInput, error) {
@@ -1133,48 +1637,279 @@ func (s *covenantlessService) ClaimVtxos(ctx context.Context, creds string, rece
 		return fmt.Errorf("invalid credentials")
 	}
 
+	data, err := s.validateReceivers(creds, receivers, musig2Data)
+	if err != nil {
+		return err
+	}
+
+	if err := request.AddReceivers(receivers); err != nil {
+		return err
+	}
+
+	return s.txRequests.update(*request, data)
+}
+
+func (s *covenantlessService) AmendTxRequest(
+	ctx context.Context, requestId string, receivers []domain.Receiver, musig2Data *tree.Musig2,
+) error {
+	requests, err := s.txRequests.viewAll([]string{requestId})
+	if err != nil {
+		return err
+	}
+	if len(requests) <= 0 {
+		return errTxRequestNotFound{requestId}
+	}
+	request := requests[0]
+	if len(request.Receivers) <= 0 {
+		return fmt.Errorf("tx request %s has no registered outputs to amend", requestId)
+	}
+
+	// keep the registered cosigners if the new ones are not provided
+	if musig2Data == nil {
+		musig2Data = request.musig2Data
+	}
+
+	data, err := s.validateReceivers(requestId, receivers, musig2Data)
+	if err != nil {
+		return err
+	}
+
+	amended := request.TxRequest
+	amended.Receivers = nil
+	if err := amended.AddReceivers(receivers); err != nil {
+		return err
+	}
+
+	return s.txRequests.update(amended, data)
+}
+
+// validateReceivers checks the receivers registered for the given tx request
+// and returns the musig2 data to store with them, if any offchain receiver
+// requires it.
+func (s *covenantlessService) validateReceivers(
+	requestId string, receivers []domain.Receiver, musig2Data *tree.Musig2,
+) (*tree.Musig2, error) {
 	hasOffChainReceiver := false
+	sumOfOutputs := uint64(0)
 
 	for _, rcv := range receivers {
-		if s.vtxoMaxAmount >= 0 {
-			if rcv.Amount > uint64(s.vtxoMaxAmount) {
-				return fmt.Errorf("receiver amount is higher than max vtxo amount:%d", s.vtxoMaxAmount)
-			}
-		}
-		if s.vtxoMinAmount >= 0 {
-			if rcv.Amount < uint64(s.vtxoMinAmount) {
-				return fmt.Errorf("receiver amount is lower than min vtxo amount:%d", s.vtxoMinAmount)
-			}
+		if err := s.validateReceiverAmount(rcv); err != nil {
+			return nil, err
 		}
 
 		if !rcv.IsOnchain() {
 			hasOffChainReceiver = true
+		} else if err := s.exitPolicy.validate(rcv.OnchainAddress); err != nil {
+			return nil, err
 		}
+		sumOfOutputs += rcv.Amount
+	}
+
+	if err := s.validateSettleAmount(sumOfOutputs); err != nil {
+		return nil, err
 	}
 
 	var data *tree.Musig2
 
 	if hasOffChainReceiver {
 		if musig2Data == nil {
-			return fmt.Errorf("musig2 data is required for offchain receivers")
+			return nil, fmt.Errorf("musig2 data is required for offchain receivers")
 		}
 
 		// check if the server pubkey has been set as cosigner
 		serverPubKeyHex := hex.EncodeToString(s.serverSigningPubKey.SerializeCompressed())
 		for _, pubkey := range musig2Data.CosignersPublicKeys {
 			if pubkey == serverPubKeyHex {
-				return fmt.Errorf("server pubkey already in musig2 data")
+				return nil, fmt.Errorf("server pubkey already in musig2 data")
 			}
 		}
 
 		data = musig2Data
 	}
 
-	if err := request.AddReceivers(receivers); err != nil {
+	if s.requireSameBoardingOwner {
+		requests, err := s.txRequests.viewAll([]string{requestId})
+		if err != nil {
+			return nil, err
+		}
+		if len(requests) > 0 {
+			if err := s.validateBoardingOwners(requests[0].boardingInputs, receivers); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// validateBoardingOwners enforces, if required, that the receivers of a tx
+// request with boarding inputs are owned by the owner of those inputs.
+func (s *covenantlessService) validateBoardingOwners(
+	boardingInputs []ports.BoardingInput, receivers []domain.Receiver,
+) error {
+	if !s.requireSameBoardingOwner || len(boardingInputs) <= 0 {
+		return nil
+	}
+
+	owners, err := getBoardingOwners(boardingInputs, s.pubkey)
+	if err != nil {
 		return err
 	}
+	return validateBoardingOwners(
+		owners, receivers, s.pubkey, s.unilateralExitDelay, s.chainParams(),
+	)
+}
 
-	return s.txRequests.update(*request, data)
+func (s *covenantlessService) validateReceiverAmount(rcv domain.Receiver) error {
+	if s.vtxoMaxAmount >= 0 {
+		if rcv.Amount > uint64(s.vtxoMaxAmount) {
+			return fmt.Errorf("receiver amount is higher than max vtxo amount:%d", s.vtxoMaxAmount)
+		}
+	}
+	if s.vtxoMinAmount >= 0 {
+		if rcv.Amount < uint64(s.vtxoMinAmount) {
+			return fmt.Errorf("receiver amount is lower than min vtxo amount:%d", s.vtxoMinAmount)
+		}
+	}
+	return nil
+}
+
+// validateSettleAmount checks the total amount of the outputs of a tx request
+// against the bounds of the amount that can be settled in a round. A bound
+// <= 0 means no limit.
+func (s *covenantlessService) validateSettleAmount(amount uint64) error {
+	if s.settleMaxAmount > 0 {
+		if amount > uint64(s.settleMaxAmount) {
+			return fmt.Errorf("total amount is higher than max settle amount:%d", s.settleMaxAmount)
+		}
+	}
+	if s.settleMinAmount > 0 {
+		if amount < uint64(s.settleMinAmount) {
+			return fmt.Errorf("total amount is lower than min settle amount:%d", s.settleMinAmount)
+		}
+	}
+	return nil
+}
+
+func (s *covenantlessService) ValidateTxRequest(
+	ctx context.Context, inputs []ports.Input, notes []note.Note, receivers []domain.Receiver,
+) (*TxRequestValidation, error) {
+	result := &TxRequestValidation{
+		Inputs:    make([]ValidationResult, 0, len(inputs)),
+		Notes:     make([]ValidationResult, 0, len(notes)),
+		Receivers: make([]ValidationResult, 0, len(receivers)),
+		Errors:    make([]string, 0),
+	}
+
+	if len(inputs) <= 0 && len(notes) <= 0 {
+		result.Errors = append(result.Errors, "missing inputs")
+	}
+	if len(inputs) > 0 && len(notes) > 0 {
+		result.Errors = append(result.Errors, "cannot mix vtxos and notes")
+	}
+	if len(receivers) <= 0 {
+		result.Errors = append(result.Errors, "missing outputs")
+	}
+	if err := s.txRequests.validateReceiversCount(len(receivers)); err != nil {
+		result.Errors = append(result.Errors, err.Error())
+	}
+
+	now := time.Now().Unix()
+	boardingTxs := make(map[string]wire.MsgTx, 0)
+	seen := make(map[string]struct{})
+	sumOfInputs, sumOfOutputs := uint64(0), uint64(0)
+
+	for _, input := range inputs {
+		id := input.String()
+		res := ValidationResult{Id: id}
+
+		if _, ok := seen[id]; ok {
+			res.Error = "duplicated input"
+			result.Inputs = append(result.Inputs, res)
+			continue
+		}
+		seen[id] = struct{}{}
+
+		vtxo, boardingInput, err := s.validateInput(ctx, input, boardingTxs, now)
+		if err != nil {
+			res.Error = err.Error()
+			result.Inputs = append(result.Inputs, res)
+			continue
+		}
+
+		if requestId, ok := s.txRequests.findInput(input.VtxoKey); ok {
+			res.Error = fmt.Sprintf("input already used by tx request %s", requestId)
+		} else if s.roundInputs.includes(input.VtxoKey) {
+			res.Error = "input already registered for the ongoing round"
+		}
+		result.Inputs = append(result.Inputs, res)
+
+		if len(res.Error) > 0 {
+			continue
+		}
+		if boardingInput != nil {
+			sumOfInputs += boardingInput.Amount
+			continue
+		}
+		sumOfInputs += vtxo.Amount
+	}
+
+	for _, note := range notes {
+		res := ValidationResult{Id: fmt.Sprintf("%d", note.ID)}
+
+		if _, ok := seen[res.Id]; ok {
+			res.Error = "duplicated note"
+			result.Notes = append(result.Notes, res)
+			continue
+		}
+		seen[res.Id] = struct{}{}
+
+		if err := s.validateNote(ctx, note); err != nil {
+			res.Error = err.Error()
+		} else if requestId, ok := s.txRequests.findNote(note.ID); ok {
+			res.Error = fmt.Sprintf("note already used by tx request %s", requestId)
+		}
+		result.Notes = append(result.Notes, res)
+
+		if len(res.Error) <= 0 {
+			sumOfInputs += uint64(note.Value)
+		}
+	}
+
+	for _, rcv := range receivers {
+		res := ValidationResult{Id: rcv.OnchainAddress}
+		if !rcv.IsOnchain() {
+			res.Id = rcv.PubKey
+		}
+
+		if len(rcv.OnchainAddress) <= 0 && len(rcv.PubKey) <= 0 {
+			res.Error = "missing receiver destination"
+		} else if rcv.Amount == 0 {
+			res.Error = "missing receiver amount"
+		} else if err := s.validateReceiverAmount(rcv); err != nil {
+			res.Error = err.Error()
+		} else if rcv.IsOnchain() {
+			if err := s.exitPolicy.validate(rcv.OnchainAddress); err != nil {
+				res.Error = err.Error()
+			}
+		}
+		result.Receivers = append(result.Receivers, res)
+
+		sumOfOutputs += rcv.Amount
+	}
+
+	if !result.hasInvalidItems() && sumOfInputs != sumOfOutputs {
+		result.Errors = append(result.Errors, fmt.Sprintf(
+			"sum of inputs %d does not match sum of outputs %d", sumOfInputs, sumOfOutputs,
+		))
+	}
+	if len(receivers) > 0 {
+		if err := s.validateSettleAmount(sumOfOutputs); err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+
+	return result, nil
 }
 
 func (s *covenantlessService) UpdateTxRequestStatus(_ context.Context, id string) error {
@@ -1186,16 +1921,16 @@ func (s *covenantlessService) SignVtxos(ctx context.Context, forfeitTxs []string
 		return nil
 	}
 
-	if err := s.forfeitTxs.sign(forfeitTxs); err != nil {
+	instance, err := s.rounds.getByForfeitTxs(forfeitTxs)
+	if err != nil {
 		return err
 	}
 
-	go func() {
-		s.currentRoundLock.Lock()
-		round := s.currentRound
-		s.currentRoundLock.Unlock()
-		s.checkForfeitsAndBoardingSigsSent(round)
-	}()
+	if err := instance.forfeitTxs.sign(forfeitTxs); err != nil {
+		return err
+	}
+
+	go s.checkForfeitsAndBoardingSigsSent(instance)
 
 	return nil
 }
@@ -1209,29 +1944,32 @@ func (s *covenantlessService) SignRoundTx(ctx context.Context, signedRoundTx str
 		return nil
 	}
 
-	s.currentRoundLock.Lock()
-	defer s.currentRoundLock.Unlock()
-	currentRound := s.currentRound
+	instance, err := s.rounds.getByRoundTx(signedRoundTx)
+	if err != nil {
+		return err
+	}
+
+	instance.lock.Lock()
+	defer instance.lock.Unlock()
 
-	combined, err := s.builder.VerifyAndCombinePartialTx(currentRound.UnsignedTx, signedRoundTx)
+	combined, err := s.builder.VerifyAndCombinePartialTx(instance.round.UnsignedTx, signedRoundTx)
 	if err != nil {
 		return fmt.Errorf("failed to verify and combine partial tx: %s", err)
 	}
 
-	s.currentRound.UnsignedTx = combined
+	instance.round.UnsignedTx = combined
 
-	go func() {
-		s.currentRoundLock.Lock()
-		round := s.currentRound
-		s.currentRoundLock.Unlock()
-		s.checkForfeitsAndBoardingSigsSent(round)
-	}()
+	go s.checkForfeitsAndBoardingSigsSent(instance)
 
 	return nil
 }
 
-func (s *covenantlessService) checkForfeitsAndBoardingSigsSent(currentRound *domain.Round) {
-	roundTx, _ := psbt.NewFromRawBytes(strings.NewReader(currentRound.UnsignedTx), true)
+func (s *covenantlessService) checkForfeitsAndBoardingSigsSent(instance *roundInstance) {
+	instance.lock.Lock()
+	unsignedTx := instance.round.UnsignedTx
+	instance.lock.Unlock()
+
+	roundTx, _ := psbt.NewFromRawBytes(strings.NewReader(unsignedTx), true)
 	numOfInputsSigned := 0
 	for _, v := range roundTx.Inputs {
 		if len(v.TaprootScriptSpendSig) > 0 {
@@ -1244,12 +1982,10 @@ func (s *covenantlessService) checkForfeitsAndBoardingSigsSent(currentRound *dom
 	// Condition: all forfeit txs are signed and
 	// the number of signed boarding inputs matches
 	// numOfBoardingInputs we expect
-	s.numOfBoardingInputsMtx.RLock()
-	numOfBoardingInputs := s.numOfBoardingInputs
-	s.numOfBoardingInputsMtx.RUnlock()
-	if s.forfeitTxs.allSigned() && numOfBoardingInputs == numOfInputsSigned {
+	numOfBoardingInputs := instance.getNumOfBoardingInputs()
+	if instance.forfeitTxs.allSigned() && numOfBoardingInputs == numOfInputsSigned {
 		select {
-		case s.forfeitsBoardingSigsChan <- struct{}{}:
+		case instance.forfeitsBoardingSigsChan <- struct{}{}:
 		default:
 		}
 	}
@@ -1270,6 +2006,62 @@ func (s *covenantlessService) ListVtxos(ctx context.Context, address string) ([]
 	return s.repoManager.Vtxos().GetAllNonRedeemedVtxos(ctx, pubkey)
 }
 
+func (s *covenantlessService) ListVtxosForAddresses(
+	ctx context.Context, addresses []string,
+) ([]AddressVtxos, error) {
+	if len(addresses) > maxAddressesPerListVtxos {
+		return nil, fmt.Errorf(
+			"too many addresses, max %d per request", maxAddressesPerListVtxos,
+		)
+	}
+
+	pubkeys := make([]string, 0, len(addresses))
+	result := make([]AddressVtxos, 0, len(addresses))
+	// an address may be listed more than once
+	indexesByPubkey := make(map[string][]int)
+	for i, address := range addresses {
+		decodedAddress, err := common.DecodeAddress(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode address %s: %s", address, err)
+		}
+
+		if !bytes.Equal(schnorr.SerializePubKey(decodedAddress.Server), schnorr.SerializePubKey(s.pubkey)) {
+			return nil, fmt.Errorf("address %s does not match server pubkey", address)
+		}
+
+		pubkey := hex.EncodeToString(schnorr.SerializePubKey(decodedAddress.VtxoTapKey))
+		if _, ok := indexesByPubkey[pubkey]; !ok {
+			pubkeys = append(pubkeys, pubkey)
+		}
+		indexesByPubkey[pubkey] = append(indexesByPubkey[pubkey], i)
+		result = append(result, AddressVtxos{
+			Address:        address,
+			SpendableVtxos: make([]domain.Vtxo, 0),
+			SpentVtxos:     make([]domain.Vtxo, 0),
+		})
+	}
+
+	// a single query makes sure that all the addresses are listed from the
+	// same snapshot of the db.
+	spendableVtxos, spentVtxos, err := s.repoManager.Vtxos().GetAllNonRedeemedVtxosWithPubKeys(ctx, pubkeys)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, vtxo := range spendableVtxos {
+		for _, i := range indexesByPubkey[vtxo.PubKey] {
+			result[i].SpendableVtxos = append(result[i].SpendableVtxos, vtxo)
+		}
+	}
+	for _, vtxo := range spentVtxos {
+		for _, i := range indexesByPubkey[vtxo.PubKey] {
+			result[i].SpentVtxos = append(result[i].SpentVtxos, vtxo)
+		}
+	}
+
+	return result, nil
+}
+
 func (s *covenantlessService) GetEventsChannel(ctx context.Context) <-chan domain.RoundEvent {
 	return s.eventsCh
 }
@@ -1282,12 +2074,48 @@ func (s *covenantlessService) GetRoundByTxid(ctx context.Context, roundTxid stri
 	return s.repoManager.Rounds().GetRoundWithTxid(ctx, roundTxid)
 }
 
+func (s *covenantlessService) GetRoundTx(ctx context.Context, roundTxid string) (string, error) {
+	round, err := s.repoManager.Rounds().GetRoundWithTxid(ctx, roundTxid)
+	if err != nil {
+		return "", err
+	}
+	if !round.IsEnded() {
+		return "", fmt.Errorf("round %s is not finalized", round.Id)
+	}
+
+	// the signed round tx is not stored, it's fetched from the chain
+	tx, err := s.wallet.GetTransaction(ctx, round.Txid)
+	if err != nil {
+		return "", fmt.Errorf("failed to get round tx %s: %s", round.Txid, err)
+	}
+	return tx, nil
+}
+
 func (s *covenantlessService) GetRoundById(ctx context.Context, id string) (*domain.Round, error) {
 	return s.repoManager.Rounds().GetRoundWithId(ctx, id)
 }
 
+func (s *covenantlessService) ListRounds(
+	ctx context.Context, endedAfter int64, afterTxid string, limit int,
+) ([]domain.RoundSummary, error) {
+	return s.repoManager.Rounds().GetRoundSummaries(ctx, endedAfter, afterTxid, limit)
+}
+
+func (s *covenantlessService) GetRoundTreeBranch(
+	ctx context.Context, roundTxid string, vtxo domain.VtxoKey,
+) (tree.TxTree, error) {
+	round, err := s.repoManager.Rounds().GetRoundWithTxid(ctx, roundTxid)
+	if err != nil {
+		return nil, err
+	}
+	return vtxoTreeBranch(round.VtxoTree, vtxo)
+}
+
 func (s *covenantlessService) GetCurrentRound(ctx context.Context) (*domain.Round, error) {
-	return domain.NewRoundFromEvents(s.currentRound.Events()), nil
+	s.currentRoundLock.Lock()
+	round := s.currentRound
+	s.currentRoundLock.Unlock()
+	return domain.NewRoundFromEvents(round.Events()), nil
 }
 
 func (s *covenantlessService) GetInfo(ctx context.Context) (*ServiceInfo, error) {
@@ -1334,10 +2162,13 @@ func (s *covenantlessService) GetInfo(ctx context.Context) (*ServiceInfo, error)
 			Period:        marketHourConfig.Period,
 			RoundInterval: marketHourConfig.RoundInterval,
 		},
-		UtxoMinAmount: s.utxoMinAmount,
-		UtxoMaxAmount: s.utxoMaxAmount,
-		VtxoMinAmount: s.vtxoMinAmount,
-		VtxoMaxAmount: s.vtxoMaxAmount,
+		UtxoMinAmount:          s.utxoMinAmount,
+		UtxoMaxAmount:          s.utxoMaxAmount,
+		VtxoMinAmount:          s.vtxoMinAmount,
+		VtxoMaxAmount:          s.vtxoMaxAmount,
+		SettleMinAmount:        s.settleMinAmount,
+		SettleMaxAmount:        s.settleMaxAmount,
+		MaxReceiversPerRequest: s.txRequests.maxReceivers,
 	}, nil
 }
 
@@ -1432,6 +2263,41 @@ func (s *covenantlessService) DeleteTxRequests(
 	return s.txRequests.delete(requestIds)
 }
 
+func (s *covenantlessService) GetLiquidityStatus(
+	ctx context.Context,
+) (*LiquidityStatus, error) {
+	return s.liquidityMonitor.check(ctx)
+}
+
+func (s *covenantlessService) EstimateNextRound(
+	ctx context.Context,
+) (*RoundEstimation, error) {
+	requests, boardingInputs, musig2data := s.txRequests.peek(s.roundMaxParticipantsCount)
+	if len(requests) <= 0 {
+		return nil, fmt.Errorf("no tx requests in queue")
+	}
+
+	serverPubKeyHex := hex.EncodeToString(s.serverSigningPubKey.SerializeCompressed())
+	for _, data := range musig2data {
+		if data == nil {
+			continue
+		}
+		data.CosignersPublicKeys = append(data.CosignersPublicKeys, serverPubKeyHex)
+	}
+
+	estimation, err := s.builder.EstimateRoundTx(
+		s.pubkey, requests, boardingInputs, musig2data,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to estimate round tx: %s", err)
+	}
+
+	return &RoundEstimation{
+		RoundTxEstimation: *estimation,
+		NumOfTxRequests:   len(requests),
+	}, nil
+}
+
 func calcNextMarketHour(marketHourStartTime, marketHourEndTime time.Time, period, marketHourDelta time.Duration, now time.Time) (time.Time, time.Time, error) {
 	// Validate input parameters
 	if period <= 0 {
@@ -1481,7 +2347,9 @@ func calcNextMarketHour(marketHourStartTime, marketHourEndTime time.Time, period
 func (s *covenantlessService) RegisterCosignerNonces(
 	ctx context.Context, roundID string, pubkey *secp256k1.PublicKey, encodedNonces string,
 ) error {
+	s.treeSigningSessionsMtx.RLock()
 	session, ok := s.treeSigningSessions[roundID]
+	s.treeSigningSessionsMtx.RUnlock()
 	if !ok {
 		return fmt.Errorf(`signing session not found for round "%s"`, roundID)
 	}
@@ -1519,7 +2387,9 @@ func (s *covenantlessService) RegisterCosignerNonces(
 func (s *covenantlessService) RegisterCosignerSignatures(
 	ctx context.Context, roundID string, pubkey *secp256k1.PublicKey, encodedSignatures string,
 ) error {
+	s.treeSigningSessionsMtx.RLock()
 	session, ok := s.treeSigningSessions[roundID]
+	s.treeSigningSessionsMtx.RUnlock()
 	if !ok {
 		return fmt.Errorf(`signing session not found for round "%s"`, roundID)
 	}
@@ -1565,11 +2435,18 @@ func (s *covenantlessService) start() {
 }
 
 func (s *covenantlessService) startRound() {
-	// reset the forfeit txs map to avoid polluting the next batch of forfeits transactions
-	s.forfeitTxs.reset()
+	if s.isShuttingDown() {
+		log.Debug("service is shutting down, no new round started")
+		return
+	}
+
+	// wait for a round in flight to end if the max number of concurrent rounds
+	// is reached
+	s.roundSlots <- struct{}{}
 
 	dustAmount, err := s.wallet.GetDustAmount(context.Background())
 	if err != nil {
+		<-s.roundSlots
 		log.WithError(err).Warn("failed to get dust amount")
 		return
 	}
@@ -1577,26 +2454,67 @@ func (s *covenantlessService) startRound() {
 	round := domain.NewRound(dustAmount)
 	//nolint:all
 	round.StartRegistration()
+	instance := newRoundInstance(
+		round, newForfeitTxsMap(s.builder, s.repoManager.ForfeitTxs()), s.roundTimeout,
+	)
+	s.rounds.add(instance)
+	s.currentRoundLock.Lock()
 	s.currentRound = round
-	close(s.forfeitsBoardingSigsChan)
-	s.forfeitsBoardingSigsChan = make(chan struct{}, 1)
+	s.currentRoundLock.Unlock()
+	s.roundMonitor.enterPhase(round.Id, RoundPhaseRegistration)
 
 	defer func() {
-		roundEndTime := time.Now().Add(time.Duration(s.roundInterval) * time.Second)
 		sleepingTime := s.roundInterval / 6
 		if sleepingTime < 1 {
 			sleepingTime = 1
 		}
-		time.Sleep(time.Duration(sleepingTime) * time.Second)
-		s.startFinalization(roundEndTime)
+		registrationDuration := time.Duration(sleepingTime) * time.Second
+		s.txRequests.waitForRegistration(registrationDuration, s.singlePartyFastMode)
+		// the rest of the round keeps its whole duration even if the
+		// registration ended early
+		roundEndTime := time.Now().Add(
+			time.Duration(s.roundInterval)*time.Second - registrationDuration,
+		)
+		s.startFinalization(instance, roundEndTime)
 	}()
 
-	log.Debugf("started registration stage for new round: %s", round.Id)
+	log.Debugf(
+		"started registration stage for new round: %s (%d/%d rounds in flight)",
+		round.Id, s.rounds.len(), s.maxConcurrentRounds,
+	)
+	publishRoundEvent(s.eventPublisher, RoundEvent{
+		Type: RoundEventStarted, RoundId: round.Id,
+	})
+}
+
+// endRound releases the forfeit txs, the tx requests, the liquidity and the
+// slot of the given round in flight.
+func (s *covenantlessService) endRound(instance *roundInstance) {
+	instance.cancel()
+	instance.forfeitTxs.reset()
+	//nolint:all
+	s.txRequests.delete(instance.txRequestIds)
+	s.rounds.remove(instance.round.Id)
+	s.roundMonitor.endRound(instance.round.Id)
+	<-s.roundSlots
+}
+
+// numOfTxRequestsToPop returns how many tx requests are registered for the
+// round being finalized: all the pending ones, up to the max number of
+// participants of a round. The others are left for the next rounds.
+func (s *covenantlessService) numOfTxRequestsToPop() int64 {
+	num := s.txRequests.len()
+	if num > s.roundMaxParticipantsCount {
+		num = s.roundMaxParticipantsCount
+	}
+	return num
 }
-func (s *covenantlessService) startFinalization(roundEndTime time.Time) {
-	log.Debugf("started finalization stage for round: %s", s.currentRound.Id)
+
+func (s *covenantlessService) startFinalization(instance *roundInstance, roundEndTime time.Time) {
 	ctx := context.Background()
-	round := s.currentRound
+	round := instance.round
+	log.Debugf("started finalization stage for round: %s", round.Id)
+	s.roundMonitor.enterPhase(round.Id, RoundPhaseTxBuilding)
 
 	roundRemainingDuration := time.Duration((s.roundInterval/3)*2-1) * time.Second
 	thirdOfRemainingDuration := roundRemainingDuration / 3
@@ -1606,8 +2524,11 @@ func (s *covenantlessService) startFinalization(roundEndTime time.Time) {
 	var roundAborted bool
 	var vtxoKeys []domain.VtxoKey
 	defer func() {
+		s.treeSigningSessionsMtx.Lock()
 		delete(s.treeSigningSessions, round.Id)
+		s.treeSigningSessionsMtx.Unlock()
 		if roundAborted {
+			s.endRound(instance)
 			s.startRound()
 			return
 		}
@@ -1618,22 +2539,31 @@ func (s *covenantlessService) startFinalization(roundEndTime time.Time) {
 
 		if round.IsFailed() {
 			s.roundInputs.remove(vtxoKeys)
+			s.endRound(instance)
 			s.startRound()
 			return
 		}
 
-		s.finalizeRound(notes, recoveredVtxos, roundEndTime)
+		// the registration of the next round starts while this one is being
+		// finalized, it waits for a free slot if the max number of concurrent
+		// rounds is reached
+		go s.startRound()
+		s.finalizeRound(instance, notes, recoveredVtxos, roundEndTime)
 	}()
 
 	if round.IsFailed() {
 		return
 	}
+	if err := instance.abortReason(); err != nil {
+		round.Fail(err)
+		log.WithError(err).Warnf("round %s aborted", round.Id)
+		return
+	}
 
 	// nolint:all
 	availableBalance, _, _ := s.wallet.MainAccountBalance(ctx)
 
-	// TODO: understand how many tx requests must be popped from the queue and actually registered for the round
-	num := s.txRequests.len()
+	num := s.numOfTxRequestsToPop()
 	if num == 0 {
 		roundAborted = true
 		err := fmt.Errorf("no tx requests registered")
@@ -1641,254 +2571,371 @@ func (s *covenantlessService) startFinalization(roundEndTime time.Time) {
 		log.WithError(err).Debugf("round %s aborted", round.Id)
 		return
 	}
-	if num > s.roundMaxParticipantsCount {
-		num = s.roundMaxParticipantsCount
-	}
-	requests, boardingInputs, redeeemedNotes, musig2data, vtxosToRecover := s.txRequests.pop(num)
+	requests, boardingInputs, redeeemedNotes, musig2data, vtxosToRecover, inputAmounts := s.txRequests.pop(num)
+	instance.txRequestIds = getTxRequestIds(requests)
+	publishRoundEvent(s.eventPublisher, RoundEvent{
+		Type: RoundEventTxRequestsPopped, RoundId: round.Id, NumOfTxRequests: len(requests),
+	})
 	// save notes and recovered vtxos for finalize function
 	notes = redeeemedNotes
 	recoveredVtxos = vtxosToRecover
+	s.roundInputAmountsMtx.Lock()
+	s.roundInputAmounts[round.Id] = inputAmounts
+	s.roundInputAmountsMtx.Unlock()
 	for _, req := range requests {
 		for _, in := range req.Inputs {
 			vtxoKeys = append(vtxoKeys, in.VtxoKey)
 		}
 	}
-	s.numOfBoardingInputsMtx.Lock()
-	s.numOfBoardingInputs = len(boardingInputs)
-	s.numOfBoardingInputsMtx.Unlock()
+	instance.setNumOfBoardingInputs(len(boardingInputs))
 
 	totAmount := uint64(0)
 	for _, request := range requests {
 		totAmount += request.TotalOutputAmount()
 	}
-	if availableBalance <= totAmount {
-		err := fmt.Errorf("not enough liquidity")
+	// the balance must cover also the amounts locked by the other rounds in flight
+	if err := s.rounds.reserveLiquidity(round.Id, totAmount, availableBalance); err != nil {
 		round.Fail(err)
 		log.WithError(err).Debugf("round %s aborted, balance: %d", round.Id, availableBalance)
 		return
 	}
 
-	if _, err := round.RegisterTxRequests(requests); err != nil {
-		round.Fail(fmt.Errorf("failed to register tx requests: %s", err))
-		log.WithError(err).Warn("failed to register tx requests")
-		return
-	}
-
-	connectorAddresses, err := s.repoManager.Rounds().GetSweptRoundsConnectorAddress(ctx)
+	sweptConnectorAddresses, err := s.repoManager.Rounds().GetSweptRoundsConnectorAddress(ctx)
 	if err != nil {
 		round.Fail(fmt.Errorf("failed to retrieve swept rounds: %s", err))
 		log.WithError(err).Warn("failed to retrieve swept rounds")
 		return
 	}
+	// the connector outputs can be spent by only one of the rounds in flight
+	connectorAddresses := s.rounds.reserveConnectorAddresses(round.Id, sweptConnectorAddresses)
 
-	// add server pubkey in musig2data and count the number of unique keys
-	uniqueSignerPubkeys := make(map[string]struct{})
+	// add server pubkey in musig2data
 	serverPubKeyHex := hex.EncodeToString(s.serverSigningPubKey.SerializeCompressed())
 	for _, data := range musig2data {
 		if data == nil {
 			continue
 		}
-		for _, pubkey := range data.CosignersPublicKeys {
-			uniqueSignerPubkeys[pubkey] = struct{}{}
-		}
 		data.CosignersPublicKeys = append(data.CosignersPublicKeys, serverPubKeyHex)
 	}
-	log.Debugf("building tx for round %s", round.Id)
-	unsignedRoundTx, vtxoTree, connectorAddress, connectors, err := s.builder.BuildRoundTx(
-		s.pubkey, requests, boardingInputs, connectorAddresses, musig2data,
+
+	// the forfeit txs are requested at the fee rate of the start of the round,
+	// those paying less are rejected
+	forfeitFeeRate := s.wallet.MinRelayFeeRate(ctx)
+
+	unsignedRoundTx, vtxoTree, connectorAddress, connectors, requests, err := s.buildAndSignRoundTx(
+		instance, requests, boardingInputs, musig2data, inputAmounts, connectorAddresses,
+		forfeitFeeRate, thirdOfRemainingDuration, roundEndTime,
 	)
 	if err != nil {
-		round.Fail(fmt.Errorf("failed to create round tx: %s", err))
-		log.WithError(err).Warn("failed to create round tx")
+		round.Fail(err)
+		log.WithError(err).Warnf("round %s aborted", round.Id)
 		return
 	}
-	log.Debugf("round tx created for round %s", round.Id)
 
-	if err := s.forfeitTxs.init(connectors, requests); err != nil {
-		round.Fail(fmt.Errorf("failed to initialize forfeit txs: %s", err))
-		log.WithError(err).Warn("failed to initialize forfeit txs")
+	if err := instance.abortReason(); err != nil {
+		round.Fail(err)
+		log.WithError(err).Warnf("round %s aborted", round.Id)
 		return
 	}
 
-	if len(vtxoTree) > 0 {
-		sweepClosure := tree.CSVMultisigClosure{
-			MultisigClosure: tree.MultisigClosure{PubKeys: []*secp256k1.PublicKey{s.pubkey}},
-			Locktime:        s.vtxoTreeExpiry,
-		}
+	// the owners of the vtxos refreshed on their behalf are offline, their
+	// forfeit txs have been signed in advance
+	s.signRefreshForfeitTxs(instance, requests)
 
-		sweepScript, err := sweepClosure.Script()
-		if err != nil {
-			return
-		}
+	if _, err := round.RegisterTxRequests(requests); err != nil {
+		round.Fail(fmt.Errorf("failed to register tx requests: %s", err))
+		log.WithError(err).Warn("failed to register tx requests")
+		return
+	}
 
-		unsignedPsbt, err := psbt.NewFromRawBytes(strings.NewReader(unsignedRoundTx), true)
-		if err != nil {
-			round.Fail(fmt.Errorf("failed to parse round tx: %s", err))
-			log.WithError(err).Warn("failed to parse round tx")
-			return
-		}
+	instance.lock.Lock()
+	_, err = round.StartFinalization(
+		connectorAddress, connectors, vtxoTree, unsignedRoundTx, instance.forfeitTxs.connectorsIndex,
+	)
+	instance.lock.Unlock()
+	if err != nil {
+		round.Fail(fmt.Errorf("failed to start finalization: %s", err))
+		log.WithError(err).Warn("failed to start finalization")
+		return
+	}
 
-		sharedOutputAmount := unsignedPsbt.UnsignedTx.TxOut[0].Value
+	log.Debugf("started finalization stage for round: %s", round.Id)
+}
 
-		sweepLeaf := txscript.NewBaseTapLeaf(sweepScript)
-		sweepTapTree := txscript.AssembleTaprootScriptTree(sweepLeaf)
-		root := sweepTapTree.RootNode.TapHash()
+// buildAndSignRoundTx builds the round tx for the given tx requests and gets
+// its vtxo tree signed by the cosigners. With the drop policy, the tx requests
+// of the cosigners going offline before signing are removed from the round and
+// the tree is rebuilt and signed again. It returns the tx requests left in the
+// round along with the txs.
+func (s *covenantlessService) buildAndSignRoundTx(
+	instance *roundInstance, requests []domain.TxRequest,
+	boardingInputs []ports.BoardingInput, musig2data []*tree.Musig2,
+	inputAmounts map[string]uint64, connectorAddresses []string,
+	forfeitFeeRate chainfee.SatPerKVByte, signingTimeout time.Duration,
+	roundEndTime time.Time,
+) (string, tree.TxTree, string, tree.TxTree, []domain.TxRequest, error) {
+	round := instance.round
+	serverPubKeyHex := hex.EncodeToString(s.serverSigningPubKey.SerializeCompressed())
 
-		coordinator, err := tree.NewTreeCoordinatorSession(sharedOutputAmount, vtxoTree, root.CloneBytes())
+	for {
+		log.Debugf("building tx for round %s", round.Id)
+		unsignedRoundTx, vtxoTree, connectorAddress, connectors, err := s.builder.BuildRoundTx(
+			s.pubkey, requests, boardingInputs, connectorAddresses, musig2data,
+		)
 		if err != nil {
-			round.Fail(fmt.Errorf("failed to create tree coordinator: %s", err))
-			log.WithError(err).Warn("failed to create tree coordinator")
-			return
+			return "", nil, "", nil, nil, fmt.Errorf("failed to create round tx: %s", err)
 		}
+		log.Debugf("round tx created for round %s", round.Id)
 
-		serverSignerSession := tree.NewTreeSignerSession(s.serverSigningKey)
-		if err := serverSignerSession.Init(root.CloneBytes(), sharedOutputAmount, vtxoTree); err != nil {
-			round.Fail(fmt.Errorf("failed to create tree signer session: %s", err))
-			log.WithError(err).Warn("failed to create tree signer session")
-			return
+		if err := instance.forfeitTxs.init(
+			round.Id, connectors, requests, forfeitFeeRate,
+		); err != nil {
+			return "", nil, "", nil, nil, fmt.Errorf("failed to initialize forfeit txs: %s", err)
 		}
 
-		nonces, err := serverSignerSession.GetNonces()
-		if err != nil {
-			round.Fail(fmt.Errorf("failed to get nonces: %s", err))
-			log.WithError(err).Warn("failed to get nonces")
-			return
+		if len(vtxoTree) <= 0 {
+			return unsignedRoundTx, vtxoTree, connectorAddress, connectors, requests, nil
 		}
 
-		coordinator.AddNonce(s.serverSigningPubKey, nonces)
-
-		signingSession := newMusigSigningSession(uniqueSignerPubkeys)
-		s.treeSigningSessions[round.Id] = signingSession
+		// count the number of unique keys
+		uniqueSignerPubkeys := make(map[string]struct{})
+		for _, data := range musig2data {
+			if data == nil {
+				continue
+			}
+			for _, pubkey := range data.CosignersPublicKeys {
+				if pubkey != serverPubKeyHex {
+					uniqueSignerPubkeys[pubkey] = struct{}{}
+				}
+			}
+		}
 
-		log.Debugf("signing session created for round %s with %d signers", round.Id, len(uniqueSignerPubkeys))
+		signedTree, err := s.signVtxoTree(
+			instance, unsignedRoundTx, vtxoTree, uniqueSignerPubkeys, signingTimeout,
+		)
+		if err == nil {
+			return unsignedRoundTx, signedTree, connectorAddress, connectors, requests, nil
+		}
 
-		s.currentRound.UnsignedTx = unsignedRoundTx
-		// send back the unsigned tree & all cosigners pubkeys
-		listOfCosignersPubkeys := make([]string, 0, len(uniqueSignerPubkeys))
-		for pubkey := range uniqueSignerPubkeys {
-			listOfCosignersPubkeys = append(listOfCosignersPubkeys, pubkey)
+		offlineErr, ok := err.(errOfflineCosigners)
+		if !ok {
+			return "", nil, "", nil, nil, err
 		}
 
-		s.propagateRoundSigningStartedEvent(vtxoTree, listOfCosignersPubkeys)
+		onlineRequests, onlineMusig2data, offlineRequests := splitTxRequestsByCosigners(
+			requests, musig2data, offlineErr.cosigners,
+		)
+		offlineErr.requests = getTxRequestIds(offlineRequests)
+		offlineErr.leaves = getLeavesOfTxRequests(vtxoTree, offlineRequests)
 
-		noncesTimer := time.NewTimer(thirdOfRemainingDuration)
+		if s.offlineCosignerPolicy != OfflineCosignerPolicyDrop {
+			return "", nil, "", nil, nil, offlineErr
+		}
 
-		select {
-		case <-noncesTimer.C:
-			err := fmt.Errorf(
-				"musig2 signing session timed out (nonce collection), collected %d/%d nonces",
-				len(signingSession.nonces), len(uniqueSignerPubkeys),
-			)
-			round.Fail(err)
-			log.Warn(err)
-			return
-		case <-signingSession.nonceDoneC:
-			noncesTimer.Stop()
-			for pubkey, nonce := range signingSession.nonces {
-				coordinator.AddNonce(pubkey, nonce)
+		// the nonces and signatures of the rebuilt tree must be collected in the
+		// time left, keeping a third of it for the forfeit txs
+		signingTimeout = time.Until(roundEndTime) / 3
+		if err := canDropTxRequests(
+			offlineRequests, inputAmounts, len(onlineRequests),
+		); err != nil || signingTimeout <= 0 {
+			if err == nil {
+				err = fmt.Errorf("no time left to sign the vtxo tree again")
 			}
+			return "", nil, "", nil, nil, fmt.Errorf(
+				"%s, failed to drop offline tx requests: %s", offlineErr, err,
+			)
 		}
 
-		log.Debugf("nonces collected for round %s", round.Id)
+		log.WithError(offlineErr).Warnf(
+			"dropping %d offline tx requests from round %s", len(offlineRequests), round.Id,
+		)
 
-		aggregatedNonces, err := coordinator.AggregateNonces()
-		if err != nil {
-			round.Fail(fmt.Errorf("failed to aggregate nonces: %s", err))
-			log.WithError(err).Warn("failed to aggregate nonces")
-			return
+		droppedVtxoKeys := make([]domain.VtxoKey, 0)
+		for _, request := range offlineRequests {
+			for _, in := range request.Inputs {
+				droppedVtxoKeys = append(droppedVtxoKeys, in.VtxoKey)
+			}
 		}
+		s.roundInputs.remove(droppedVtxoKeys)
+		instance.forfeitTxs.reset()
+
+		requests = onlineRequests
+		musig2data = onlineMusig2data
+	}
+}
 
-		log.Debugf("nonces aggregated for round %s", round.Id)
+// signVtxoTree coordinates the musig2 session with the cosigners of the vtxo
+// tree. If some cosigners don't submit their signatures in time, it returns
+// an errOfflineCosigners.
+func (s *covenantlessService) signVtxoTree(
+	instance *roundInstance, unsignedRoundTx string, vtxoTree tree.TxTree,
+	uniqueSignerPubkeys map[string]struct{}, timeout time.Duration,
+) (tree.TxTree, error) {
+	round := instance.round
+	sweepClosure := tree.CSVMultisigClosure{
+		MultisigClosure: tree.MultisigClosure{PubKeys: []*secp256k1.PublicKey{s.pubkey}},
+		Locktime:        s.vtxoTreeExpiry,
+	}
 
-		serverSignerSession.SetAggregatedNonces(aggregatedNonces)
+	sweepScript, err := sweepClosure.Script()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create sweep script: %s", err)
+	}
 
-		// send the combined nonces to the clients
-		s.propagateRoundSigningNoncesGeneratedEvent(aggregatedNonces)
+	unsignedPsbt, err := psbt.NewFromRawBytes(strings.NewReader(unsignedRoundTx), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse round tx: %s", err)
+	}
 
-		// sign the tree as server
-		serverTreeSigs, err := serverSignerSession.Sign()
-		if err != nil {
-			round.Fail(fmt.Errorf("failed to sign tree: %s", err))
-			log.WithError(err).Warn("failed to sign tree")
-			return
-		}
-		coordinator.AddSignatures(s.serverSigningPubKey, serverTreeSigs)
+	sharedOutputAmount := unsignedPsbt.UnsignedTx.TxOut[0].Value
 
-		log.Debugf("tree signed by us for round %s", round.Id)
+	sweepLeaf := txscript.NewBaseTapLeaf(sweepScript)
+	sweepTapTree := txscript.AssembleTaprootScriptTree(sweepLeaf)
+	root := sweepTapTree.RootNode.TapHash()
 
-		signaturesTimer := time.NewTimer(thirdOfRemainingDuration)
+	coordinator, err := tree.NewTreeCoordinatorSession(sharedOutputAmount, vtxoTree, root.CloneBytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tree coordinator: %s", err)
+	}
 
-		log.Debugf("waiting for cosigners to sign the tree")
+	serverSignerSession := tree.NewTreeSignerSession(s.serverSigningKey)
+	if err := serverSignerSession.Init(root.CloneBytes(), sharedOutputAmount, vtxoTree); err != nil {
+		return nil, fmt.Errorf("failed to create tree signer session: %s", err)
+	}
 
-		select {
-		case <-signaturesTimer.C:
-			err := fmt.Errorf(
-				"musig2 signing session timed out (signatures collection), collected %d/%d signatures",
-				len(signingSession.signatures), len(uniqueSignerPubkeys),
-			)
-			round.Fail(err)
-			log.Warn(err)
-			return
-		case <-signingSession.sigDoneC:
-			signaturesTimer.Stop()
-			for pubkey, sig := range signingSession.signatures {
-				coordinator.AddSignatures(pubkey, sig)
-			}
-		}
+	nonces, err := serverSignerSession.GetNonces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonces: %s", err)
+	}
 
-		log.Debugf("signatures collected for round %s", round.Id)
+	coordinator.AddNonce(s.serverSigningPubKey, nonces)
 
-		signedTree, err := coordinator.SignTree()
-		if err != nil {
-			round.Fail(fmt.Errorf("failed to aggregate tree signatures: %s", err))
-			log.WithError(err).Warn("failed to aggregate tree signatures")
-			return
+	signingSession := newMusigSigningSession(uniqueSignerPubkeys)
+	s.treeSigningSessionsMtx.Lock()
+	s.treeSigningSessions[round.Id] = signingSession
+	s.treeSigningSessionsMtx.Unlock()
+
+	log.Debugf("signing session created for round %s with %d signers", round.Id, len(uniqueSignerPubkeys))
+
+	instance.lock.Lock()
+	round.UnsignedTx = unsignedRoundTx
+	instance.lock.Unlock()
+	// send back the unsigned tree & all cosigners pubkeys
+	listOfCosignersPubkeys := make([]string, 0, len(uniqueSignerPubkeys))
+	for pubkey := range uniqueSignerPubkeys {
+		listOfCosignersPubkeys = append(listOfCosignersPubkeys, pubkey)
+	}
+
+	s.roundMonitor.enterPhase(round.Id, RoundPhaseTreeSigning)
+	s.propagateRoundSigningStartedEvent(round, vtxoTree, listOfCosignersPubkeys)
+
+	noncesTimer := time.NewTimer(timeout)
+
+	select {
+	case <-noncesTimer.C:
+		return nil, fmt.Errorf(
+			"musig2 signing session timed out (nonce collection), collected %d/%d nonces",
+			len(signingSession.nonces), len(uniqueSignerPubkeys),
+		)
+	case <-instance.ctx.Done():
+		noncesTimer.Stop()
+		return nil, instance.abortReason()
+	case <-signingSession.nonceDoneC:
+		noncesTimer.Stop()
+		for pubkey, nonce := range signingSession.nonces {
+			coordinator.AddNonce(pubkey, nonce)
 		}
+	}
 
-		log.Debugf("vtxo tree signed for round %s", round.Id)
+	log.Debugf("nonces collected for round %s", round.Id)
 
-		vtxoTree = signedTree
+	aggregatedNonces, err := coordinator.AggregateNonces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate nonces: %s", err)
 	}
 
-	_, err = round.StartFinalization(
-		connectorAddress, connectors, vtxoTree, unsignedRoundTx, s.forfeitTxs.connectorsIndex,
-	)
+	log.Debugf("nonces aggregated for round %s", round.Id)
+
+	serverSignerSession.SetAggregatedNonces(aggregatedNonces)
+
+	// send the combined nonces to the clients
+	s.propagateRoundSigningNoncesGeneratedEvent(round.Id, aggregatedNonces)
+
+	// sign the tree as server
+	serverTreeSigs, err := serverSignerSession.Sign()
 	if err != nil {
-		round.Fail(fmt.Errorf("failed to start finalization: %s", err))
-		log.WithError(err).Warn("failed to start finalization")
-		return
+		return nil, fmt.Errorf("failed to sign tree: %s", err)
 	}
+	coordinator.AddSignatures(s.serverSigningPubKey, serverTreeSigs)
 
-	log.Debugf("started finalization stage for round: %s", round.Id)
+	log.Debugf("tree signed by us for round %s", round.Id)
+
+	signaturesTimer := time.NewTimer(timeout)
+
+	log.Debugf("waiting for cosigners to sign the tree")
+
+	select {
+	case <-signaturesTimer.C:
+		// the cosigners submitted their nonces but some of them went offline
+		// before signing
+		return nil, errOfflineCosigners{cosigners: signingSession.offlineCosigners()}
+	case <-instance.ctx.Done():
+		signaturesTimer.Stop()
+		return nil, instance.abortReason()
+	case <-signingSession.sigDoneC:
+		signaturesTimer.Stop()
+		for pubkey, sig := range signingSession.signatures {
+			coordinator.AddSignatures(pubkey, sig)
+		}
+	}
+
+	log.Debugf("signatures collected for round %s", round.Id)
+
+	signedTree, err := coordinator.SignTree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate tree signatures: %s", err)
+	}
+
+	log.Debugf("vtxo tree signed for round %s", round.Id)
+	publishRoundEvent(s.eventPublisher, RoundEvent{
+		Type: RoundEventTreeSigned, RoundId: round.Id,
+	})
+
+	return signedTree, nil
 }
 
-func (s *covenantlessService) propagateRoundSigningStartedEvent(unsignedVtxoTree tree.TxTree, cosignersPubkeys []string) {
+func (s *covenantlessService) propagateRoundSigningStartedEvent(
+	round *domain.Round, unsignedVtxoTree tree.TxTree, cosignersPubkeys []string,
+) {
 	ev := RoundSigningStarted{
-		Id:               s.currentRound.Id,
+		Id:               round.Id,
 		UnsignedVtxoTree: unsignedVtxoTree,
-		UnsignedRoundTx:  s.currentRound.UnsignedTx,
+		UnsignedRoundTx:  round.UnsignedTx,
 		CosignersPubkeys: cosignersPubkeys,
 	}
 
 	s.eventsCh <- ev
 }
 
-func (s *covenantlessService) propagateRoundSigningNoncesGeneratedEvent(combinedNonces tree.TreeNonces) {
+func (s *covenantlessService) propagateRoundSigningNoncesGeneratedEvent(
+	roundId string, combinedNonces tree.TreeNonces,
+) {
 	ev := RoundSigningNoncesGenerated{
-		Id:     s.currentRound.Id,
+		Id:     roundId,
 		Nonces: combinedNonces,
 	}
 
 	s.eventsCh <- ev
 }
 
-func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []domain.Vtxo, roundEndTime time.Time) {
-	defer s.startRound()
+func (s *covenantlessService) finalizeRound(
+	instance *roundInstance, notes []note.Note, recoveredVtxos []domain.Vtxo,
+	roundEndTime time.Time,
+) {
+	defer s.endRound(instance)
 
 	ctx := context.Background()
-	s.currentRoundLock.Lock()
-	round := s.currentRound
-	s.currentRoundLock.Unlock()
+	round := instance.round
 
 	defer func() {
 		vtxoKeys := make([]domain.VtxoKey, 0)
@@ -1904,6 +2951,8 @@ func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []
 		return
 	}
 
+	s.roundMonitor.enterPhase(round.Id, RoundPhaseFinalization)
+
 	var changes []domain.RoundEvent
 	defer func() {
 		if err := s.saveEvents(ctx, round.Id, changes); err != nil {
@@ -1935,29 +2984,28 @@ func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []
 	boardingInputs := make([]domain.VtxoKey, 0)
 	forfeitTxs := make([]domain.ForfeitTx, 0)
 
-	if len(s.forfeitTxs.forfeitTxs) > 0 || includesBoardingInputs {
-		remainingTime := time.Until(roundEndTime)
-		select {
-		case <-s.forfeitsBoardingSigsChan:
-			log.Debug("all forfeit txs and boarding inputs signatures have been sent")
-		case <-time.After(remainingTime):
-			log.Debug("timeout waiting for forfeit txs and boarding inputs signatures")
+	if instance.forfeitTxs.hasVtxos() || includesBoardingInputs {
+		s.roundMonitor.enterPhase(round.Id, RoundPhaseForfeits)
+		if err := instance.waitForfeitsBoardingSigs(time.Until(roundEndTime)); err != nil {
+			changes = round.Fail(err)
+			log.WithError(err).Warnf("round %s aborted", round.Id)
+			return
 		}
+		s.roundMonitor.enterPhase(round.Id, RoundPhaseFinalization)
 
-		s.currentRoundLock.Lock()
-		round := s.currentRound
-		s.currentRoundLock.Unlock()
+		instance.lock.Lock()
+		txToSign = round.UnsignedTx
+		instance.lock.Unlock()
 
-		roundTx, err := psbt.NewFromRawBytes(strings.NewReader(round.UnsignedTx), true)
+		roundTx, err := psbt.NewFromRawBytes(strings.NewReader(txToSign), true)
 		if err != nil {
-			log.Debugf("failed to parse round tx: %s", round.UnsignedTx)
+			log.Debugf("failed to parse round tx: %s", txToSign)
 			changes = round.Fail(fmt.Errorf("failed to parse round tx: %s", err))
 			log.WithError(err).Warn("failed to parse round tx")
 			return
 		}
-		txToSign = round.UnsignedTx
 
-		forfeitTxList, err := s.forfeitTxs.pop()
+		forfeitTxList, err := instance.forfeitTxs.pop()
 		if err != nil {
 			changes = round.Fail(fmt.Errorf("failed to finalize round: %s", err))
 			log.WithError(err).Warn("failed to finalize round")
@@ -1969,6 +3017,10 @@ func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []
 			log.WithError(err).Warn("failed to validate forfeit txs")
 			return
 		}
+		publishRoundEvent(s.eventPublisher, RoundEvent{
+			Type: RoundEventForfeitsCollected, RoundId: round.Id,
+			NumOfForfeitTxs: len(forfeitTxList),
+		})
 
 		boardingInputsIndexes := make([]int, 0)
 		for i, in := range roundTx.Inputs {
@@ -1989,7 +3041,12 @@ func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []
 		}
 
 		if len(boardingInputsIndexes) > 0 {
-			txToSign, err = s.wallet.SignTransactionTapscript(ctx, txToSign, boardingInputsIndexes)
+			txToSign, err = s.wallet.SignTransactionTapscript(
+				instance.ctx, txToSign, boardingInputsIndexes,
+			)
+			if abortErr := instance.abortReason(); abortErr != nil {
+				err = abortErr
+			}
 			if err != nil {
 				changes = round.Fail(fmt.Errorf("failed to sign round tx: %s", err))
 				log.WithError(err).Warn("failed to sign round tx")
@@ -2010,7 +3067,12 @@ func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []
 
 	log.Debugf("signing transaction %s\n", round.Id)
 
-	signedRoundTx, err := s.wallet.SignTransaction(ctx, txToSign, true)
+	signedRoundTx, err := s.wallet.SignTransaction(instance.ctx, txToSign, true)
+	// the round tx must not be broadcasted once the round is aborted or timed
+	// out
+	if abortErr := instance.abortReason(); abortErr != nil {
+		err = abortErr
+	}
 	if err != nil {
 		changes = round.Fail(fmt.Errorf("failed to sign round tx: %s", err))
 		log.WithError(err).Warn("failed to sign round tx")
@@ -2031,9 +3093,23 @@ func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []
 	}
 
 	// mark the notes as spent
-	for _, note := range notes {
-		if err := s.repoManager.Notes().Add(ctx, note.ID); err != nil {
-			log.WithError(err).Warn("failed to mark note as spent")
+	if len(notes) > 0 {
+		spentNotes := make([]domain.Note, 0, len(notes))
+		for _, note := range notes {
+			spentNotes = append(spentNotes, domain.Note{
+				ID:        note.ID,
+				Value:     uint64(note.Value),
+				ExpiresAt: note.ExpiresAt,
+			})
+		}
+		count, err := s.repoManager.Notes().AddMany(ctx, round.Txid, spentNotes)
+		if err != nil {
+			log.WithError(err).Warn("failed to mark notes as spent")
+		} else if count < len(spentNotes) {
+			log.Warnf(
+				"%d notes of round %s were already marked as spent",
+				len(spentNotes)-count, round.Txid,
+			)
 		}
 	}
 
@@ -2063,6 +3139,9 @@ func (s *covenantlessService) finalizeRound(notes []note.Note, recoveredVtxos []
 	}()
 
 	log.Debugf("finalized round %s with round tx %s", round.Id, round.Txid)
+	publishRoundEvent(s.eventPublisher, RoundEvent{
+		Type: RoundEventFinalized, RoundId: round.Id, RoundTxid: round.Txid,
+	})
 }
 
 func (s *covenantlessService) listenToScannerNotifications() {
@@ -2138,30 +3217,22 @@ func (s *covenantlessService) updateVtxoSet(round *domain.Round) {
 	ctx := context.Background()
 	repo := s.repoManager.Vtxos()
 	spentVtxos := getSpentVtxos(round.TxRequests)
-	if len(spentVtxos) > 0 {
+	newVtxos := s.getNewVtxos(round)
+	if len(spentVtxos) > 0 || len(newVtxos) > 0 {
 		for {
-			if err := repo.SpendVtxos(ctx, spentVtxos, round.Txid); err != nil {
-				log.WithError(err).Warn("failed to add new vtxos, retrying soon")
+			if err := repo.ApplyVtxoStateChange(
+				ctx, spentVtxos, round.Txid, newVtxos,
+			); err != nil {
+				log.WithError(err).Warn("failed to update vtxo set, retrying soon")
 				time.Sleep(100 * time.Millisecond)
 				continue
 			}
-			log.Debugf("spent %d vtxos", len(spentVtxos))
+			log.Debugf("spent %d vtxos and added %d new vtxos", len(spentVtxos), len(newVtxos))
 			break
 		}
 	}
 
-	newVtxos := s.getNewVtxos(round)
 	if len(newVtxos) > 0 {
-		for {
-			if err := repo.AddVtxos(ctx, newVtxos); err != nil {
-				log.WithError(err).Warn("failed to add new vtxos, retrying soon")
-				time.Sleep(100 * time.Millisecond)
-				continue
-			}
-			log.Debugf("added %d new vtxos", len(newVtxos))
-			break
-		}
-
 		go func() {
 			defer func() {
 				if r := recover(); r != nil {
@@ -2188,19 +3259,91 @@ func (s *covenantlessService) propagateEvents(round *domain.Round) {
 	lastEvent := round.Events()[len(round.Events())-1]
 	switch e := lastEvent.(type) {
 	case domain.RoundFinalizationStarted:
+		// the forfeit txs must be built at the fee rate fixed for the round
+		feeRate := s.wallet.MinRelayFeeRate(context.Background())
+		if instance, ok := s.rounds.get(e.Id); ok {
+			if forfeitFeeRate := instance.forfeitTxs.getFeeRate(); forfeitFeeRate > 0 {
+				feeRate = forfeitFeeRate
+			}
+		}
 		ev := domain.RoundFinalizationStarted{
 			Id:               e.Id,
 			VtxoTree:         e.VtxoTree,
 			Connectors:       e.Connectors,
 			RoundTx:          e.RoundTx,
-			MinRelayFeeRate:  int64(s.wallet.MinRelayFeeRate(context.Background())),
+			MinRelayFeeRate:  int64(feeRate),
 			ConnectorAddress: e.ConnectorAddress,
 			ConnectorsIndex:  e.ConnectorsIndex,
 		}
 		s.eventsCh <- ev
-	case domain.RoundFinalized, domain.RoundFailed:
-		s.eventsCh <- e
+	case domain.RoundFinalized:
+		s.eventsCh <- RoundFinalized{
+			RoundFinalized: e,
+			Results:        s.getRoundResults(round),
+		}
+	case domain.RoundFailed:
+		s.roundInputAmountsMtx.Lock()
+		delete(s.roundInputAmounts, round.Id)
+		s.roundInputAmountsMtx.Unlock()
+
+		s.eventsCh <- RoundFailed{
+			RoundFailed: e,
+			Retryable:   e.Err == ErrRoundAborted.Error(),
+		}
+	}
+}
+
+// getRoundResults returns the vtxos created and the amounts spent and received
+// by every tx request of the given finalized round.
+func (s *covenantlessService) getRoundResults(round *domain.Round) map[string]RoundResult {
+	s.roundInputAmountsMtx.Lock()
+	inputAmounts := s.roundInputAmounts[round.Id]
+	delete(s.roundInputAmounts, round.Id)
+	s.roundInputAmountsMtx.Unlock()
+
+	vtxosByPubkey := make(map[string][]domain.Vtxo)
+	for _, vtxo := range s.getNewVtxos(round) {
+		vtxosByPubkey[vtxo.PubKey] = append(vtxosByPubkey[vtxo.PubKey], vtxo)
+	}
+
+	// assign the vtxos to the requests in canonical order
+	ids := make([]string, 0, len(round.TxRequests))
+	for id := range round.TxRequests {
+		ids = append(ids, id)
 	}
+	sort.Strings(ids)
+
+	results := make(map[string]RoundResult)
+	for _, id := range ids {
+		request := round.TxRequests[id]
+		vtxos := make([]domain.Vtxo, 0)
+		for _, receiver := range request.Receivers {
+			if receiver.IsOnchain() {
+				continue
+			}
+			// several receivers may share the same pubkey, every vtxo is assigned
+			// only once
+			candidates := vtxosByPubkey[receiver.PubKey]
+			for i, vtxo := range candidates {
+				if vtxo.Amount == receiver.Amount {
+					vtxos = append(vtxos, vtxo)
+					vtxosByPubkey[receiver.PubKey] = append(candidates[:i:i], candidates[i+1:]...)
+					break
+				}
+			}
+		}
+
+		inputAmount, ok := inputAmounts[id]
+		if !ok {
+			inputAmount = request.TotalInputAmount()
+		}
+		results[id] = RoundResult{
+			Vtxos:        vtxos,
+			InputAmount:  inputAmount,
+			OutputAmount: request.TotalOutputAmount(),
+		}
+	}
+	return results
 }
 
 func (s *covenantlessService) scheduleSweepVtxosForRound(round *domain.Round) {
@@ -2234,6 +3377,9 @@ func (s *covenantlessService) getNewVtxos(round *domain.Round) []domain.Vtxo {
 			continue
 		}
 		for i, out := range tx.UnsignedTx.TxOut {
+			if tree.IsAnchor(out) {
+				continue
+			}
 			vtxoTapKey, err := schnorr.ParsePubKey(out.PkScript[2:])
 			if err != nil {
 				log.WithError(err).Warn("failed to parse vtxo tap key")
@@ -2257,6 +3403,7 @@ func (s *covenantlessService) getNewVtxos(round *domain.Round) []domain.Vtxo {
 func (s *covenantlessService) getSpentVtxos(requests map[string]domain.TxRequest) []domain.Vtxo {
 	outpoints := getSpentVtxos(requests)
 	vtxos, _ := s.repoManager.Vtxos().GetVtxos(context.Background(), outpoints)
+	sortVtxos(vtxos)
 	return vtxos
 }
 
@@ -2568,7 +3715,7 @@ type musigSigningSession struct {
 }
 
 func newMusigSigningSession(cosigners map[string]struct{}) *musigSigningSession {
-	return &musigSigningSession{
+	session := &musigSigningSession{
 		nonces:     make(map[*secp256k1.PublicKey]tree.TreeNonces),
 		nonceDoneC: make(chan struct{}),
 
@@ -2578,6 +3725,14 @@ func newMusigSigningSession(cosigners map[string]struct{}) *musigSigningSession
 		cosigners:   cosigners,
 		nbCosigners: len(cosigners) + 1, // the server
 	}
+
+	// the tree is signed by the server only, for example if the round includes
+	// only refreshes of pre-authorized vtxos
+	if len(cosigners) == 0 {
+		close(session.nonceDoneC)
+		close(session.sigDoneC)
+	}
+	return session
 }
 
 func (s *covenantlessService) GetMarketHourConfig(ctx context.Context) (*domain.MarketHour, error) {
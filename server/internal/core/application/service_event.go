@@ -10,6 +10,7 @@ import (
 	"encoding/hex"
 
 	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
 )
 
 // signer should react to this event by generating a musig2 nonce for each transaction in the tree
@@ -37,6 +38,36 @@ func (e RoundSigningNoncesGenerated) SerializeNonces() (string, error) {
 	return hex.EncodeToString(serialized.Bytes()), nil
 }
 
+// RoundFinalized extends the domain event with the outcome of the round for
+// every tx request, by id, so that each participant can be notified.
+type RoundFinalized struct {
+	domain.RoundFinalized
+	Results map[string]RoundResult
+}
+
+// RoundFailed is the failure of a round, Retryable reports whether it was
+// aborted by the server, in which case its participants can register again for
+// a next round.
+type RoundFailed struct {
+	domain.RoundFailed
+	Retryable bool
+}
+
+// RoundResult is the outcome of a finalized round for a tx request.
+type RoundResult struct {
+	Vtxos        []domain.Vtxo
+	InputAmount  uint64
+	OutputAmount uint64
+}
+
+func (r RoundResult) FeeAmount() uint64 {
+	if r.InputAmount <= r.OutputAmount {
+		return 0
+	}
+	return r.InputAmount - r.OutputAmount
+}
+
 // implement domain.RoundEvent interface
 func (r RoundSigningStarted) IsEvent()         {}
 func (r RoundSigningNoncesGenerated) IsEvent() {}
+func (r RoundFinalized) IsEvent()              {}
@@ -1,6 +1,19 @@
 package application
 
 import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/note"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
@@ -89,3 +102,341 @@ func parseTime(t *testing.T, value string) time.Time {
 	require.NoError(t, err)
 	return tm
 }
+
+func TestAmendTxRequest(t *testing.T) {
+	ctx := context.Background()
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	cosignerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	s := &covenantlessService{
+		txRequests:          newTxRequestsQueue(time.Minute, 5*time.Minute, 0),
+		serverSigningPubKey: serverKey.PubKey(),
+		vtxoMaxAmount:       -1,
+		vtxoMinAmount:       -1,
+	}
+
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	musig2Data := &tree.Musig2{
+		CosignersPublicKeys: []string{hex.EncodeToString(cosignerKey.PubKey().SerializeCompressed())},
+		SigningType:         tree.SignBranch,
+	}
+	vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: "aa", VOut: 0}, Amount: 1000}
+	request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+	require.NoError(t, err)
+	require.NoError(t, s.txRequests.push(*request, nil, nil, nil))
+
+	// outputs must be registered before being amended
+	err = s.AmendTxRequest(ctx, request.Id, []domain.Receiver{{Amount: 1000, PubKey: pubkey}}, nil)
+	require.ErrorContains(t, err, "no registered outputs")
+
+	err = s.ClaimVtxos(ctx, request.Id, []domain.Receiver{{Amount: 1000, PubKey: pubkey}}, musig2Data)
+	require.NoError(t, err)
+
+	t.Run("valid", func(t *testing.T) {
+		receivers := []domain.Receiver{{Amount: 600, PubKey: pubkey}, {Amount: 400, PubKey: pubkey}}
+		require.NoError(t, s.AmendTxRequest(ctx, request.Id, receivers, nil))
+
+		requests, err := s.txRequests.viewAll([]string{request.Id})
+		require.NoError(t, err)
+		require.Len(t, requests, 1)
+		require.Equal(t, receivers, requests[0].Receivers)
+		// the registered cosigners are kept
+		require.Equal(t, musig2Data, requests[0].musig2Data)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		err := s.AmendTxRequest(ctx, "unknown", []domain.Receiver{{Amount: 1000, PubKey: pubkey}}, nil)
+		require.Error(t, err)
+
+		err = s.AmendTxRequest(ctx, request.Id, []domain.Receiver{{Amount: 2000, PubKey: pubkey}}, nil)
+		require.ErrorContains(t, err, "does not match sum of outputs")
+
+		// the request is rejected once selected for a round
+		selected, _, _, _, _, _ := s.txRequests.pop(1)
+		require.Len(t, selected, 1)
+
+		err = s.AmendTxRequest(ctx, request.Id, []domain.Receiver{{Amount: 1000, PubKey: pubkey}}, nil)
+		require.ErrorContains(t, err, "already selected for a round")
+	})
+}
+
+func TestValidateRedeemTx(t *testing.T) {
+	ctx := context.Background()
+	vtxo := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte("vtxo")).String()}, Amount: 1000,
+	}
+	unknownVtxo := domain.VtxoKey{Txid: chainhash.HashH([]byte("unknown")).String()}
+
+	s := &covenantlessService{
+		repoManager: &mockedRepoManager{
+			vtxos: &mockedVtxoRepo{vtxos: map[string]domain.Vtxo{vtxo.String(): vtxo}},
+		},
+		redeemTxInputs: newOutpointMap(),
+		roundInputs:    newOutpointMap(),
+	}
+	redeemTx := makeTx(t, vtxo.VtxoKey)
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := s.ValidateRedeemTx(ctx, "invalid")
+		require.ErrorContains(t, err, "failed to parse redeem tx")
+
+		_, err = s.ValidateRedeemTx(ctx, makeTx(t, unknownVtxo))
+		require.ErrorContains(t, err, "not found")
+
+		_, err = s.ValidateRedeemTx(ctx, redeemTx)
+		require.EqualError(t, err, "missing witness utxo")
+
+		// the inputs are not locked by the validation
+		exists, _ := s.redeemTxInputs.includesAny([]domain.VtxoKey{vtxo.VtxoKey})
+		require.False(t, exists)
+	})
+
+	t.Run("invalid anchor", func(t *testing.T) {
+		hash, err := chainhash.NewHashFromStr(vtxo.Txid)
+		require.NoError(t, err)
+		ptx, err := psbt.New(
+			[]*wire.OutPoint{{Hash: *hash, Index: vtxo.VOut}},
+			[]*wire.TxOut{
+				{Value: 900, PkScript: []byte{0x51}},
+				{Value: 100, PkScript: tree.AnchorPkScript},
+			},
+			3, 0, []uint32{wire.MaxTxInSequenceNum},
+		)
+		require.NoError(t, err)
+		anchorTx, err := ptx.B64Encode()
+		require.NoError(t, err)
+
+		_, err = s.ValidateRedeemTx(ctx, anchorTx)
+		require.ErrorIs(t, err, tree.ErrInvalidAnchor)
+	})
+
+	t.Run("inputs already spent", func(t *testing.T) {
+		s.redeemTxInputs.addIfNotIncluded([]domain.VtxoKey{vtxo.VtxoKey})
+		_, err := s.ValidateRedeemTx(ctx, redeemTx)
+		require.ErrorContains(t, err, "is currently being spent")
+		s.redeemTxInputs.remove([]domain.VtxoKey{vtxo.VtxoKey})
+
+		s.roundInputs.addIfNotIncluded([]domain.VtxoKey{vtxo.VtxoKey})
+		_, err = s.ValidateRedeemTx(ctx, redeemTx)
+		require.ErrorContains(t, err, "is already registered for next round")
+		s.roundInputs.remove([]domain.VtxoKey{vtxo.VtxoKey})
+	})
+}
+
+func TestValidateTxRequest(t *testing.T) {
+	ctx := context.Background()
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	otherKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	tapscripts, err := tree.NewDefaultVtxoScript(ownerKey.PubKey(), serverKey.PubKey(), exitDelay).Encode()
+	require.NoError(t, err)
+	ownerTapKey, _, err := tree.NewDefaultVtxoScript(ownerKey.PubKey(), serverKey.PubKey(), exitDelay).TapTree()
+	require.NoError(t, err)
+	otherTapKey, _, err := tree.NewDefaultVtxoScript(otherKey.PubKey(), serverKey.PubKey(), exitDelay).TapTree()
+	require.NoError(t, err)
+
+	makeVtxo := func(name string, amount uint64, tapKey *secp256k1.PublicKey) domain.Vtxo {
+		return domain.Vtxo{
+			VtxoKey: domain.VtxoKey{Txid: chainhash.HashH([]byte(name)).String()},
+			Amount:  amount,
+			PubKey:  hex.EncodeToString(schnorr.SerializePubKey(tapKey)),
+		}
+	}
+	vtxo := makeVtxo("vtxo", 1000, ownerTapKey)
+	otherVtxo := makeVtxo("other", 500, ownerTapKey)
+	bigVtxo := makeVtxo("big", 3000, ownerTapKey)
+	spentVtxo := makeVtxo("spent", 1000, ownerTapKey)
+	spentVtxo.Spent = true
+	queuedVtxo := makeVtxo("queued", 1000, ownerTapKey)
+	registeredVtxo := makeVtxo("registered", 1000, ownerTapKey)
+	foreignVtxo := makeVtxo("foreign", 1000, otherTapKey)
+
+	vtxos := make(map[string]domain.Vtxo)
+	for _, v := range []domain.Vtxo{
+		vtxo, otherVtxo, bigVtxo, spentVtxo, queuedVtxo, registeredVtxo, foreignVtxo,
+	} {
+		vtxos[v.String()] = v
+	}
+	input := func(v domain.Vtxo) ports.Input {
+		return ports.Input{VtxoKey: v.VtxoKey, Tapscripts: tapscripts}
+	}
+
+	validNote := note.Note{Data: note.Data{ID: 1, Value: 1000}, Signature: []byte{1}}
+	unsignedNote := note.Note{Data: note.Data{ID: 2, Value: 1000}}
+	redeemedNote := note.Note{Data: note.Data{ID: 3, Value: 1000}, Signature: []byte{1}}
+	expiredNote := note.Note{
+		Data:      note.Data{ID: 4, Value: 1000, ExpiresAt: time.Now().Add(-time.Hour).Unix()},
+		Signature: []byte{1},
+	}
+
+	s := &covenantlessService{
+		pubkey:              serverKey.PubKey(),
+		unilateralExitDelay: exitDelay,
+		wallet:              &mockedWallet{},
+		repoManager: &mockedRepoManager{
+			vtxos: &mockedVtxoRepo{vtxos: vtxos},
+			notes: &mockedNoteRepo{expiries: map[uint64]int64{redeemedNote.ID: 0}},
+		},
+		txRequests:      newTxRequestsQueue(time.Minute, 5*time.Minute, 2),
+		redeemTxInputs:  newOutpointMap(),
+		roundInputs:     newOutpointMap(),
+		vtxoMaxAmount:   -1,
+		vtxoMinAmount:   100,
+		settleMaxAmount: 2000,
+	}
+
+	queuedRequest, err := domain.NewTxRequest([]domain.Vtxo{queuedVtxo})
+	require.NoError(t, err)
+	require.NoError(t, s.txRequests.push(*queuedRequest, nil, nil, nil))
+	s.roundInputs.addIfNotIncluded([]domain.VtxoKey{registeredVtxo.VtxoKey})
+
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	onchainAddress := "bc1qw508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4"
+
+	t.Run("valid", func(t *testing.T) {
+		t.Run("vtxos", func(t *testing.T) {
+			receivers := []domain.Receiver{
+				{Amount: 1200, PubKey: pubkey},
+				{Amount: 300, OnchainAddress: onchainAddress},
+			}
+			result, err := s.ValidateTxRequest(
+				ctx, []ports.Input{input(vtxo), input(otherVtxo)}, nil, receivers,
+			)
+			require.NoError(t, err)
+			require.True(t, result.IsValid())
+			require.Empty(t, result.Errors)
+			require.Equal(t, []ValidationResult{{Id: vtxo.String()}, {Id: otherVtxo.String()}}, result.Inputs)
+			require.Empty(t, result.Notes)
+			require.Equal(t, []ValidationResult{{Id: pubkey}, {Id: onchainAddress}}, result.Receivers)
+
+			// the validation doesn't register nor lock the inputs
+			_, queued := s.txRequests.findInput(vtxo.VtxoKey)
+			require.False(t, queued)
+			require.False(t, s.roundInputs.includes(vtxo.VtxoKey))
+		})
+
+		t.Run("notes", func(t *testing.T) {
+			result, err := s.ValidateTxRequest(
+				ctx, nil, []note.Note{validNote}, []domain.Receiver{{Amount: 1000, PubKey: pubkey}},
+			)
+			require.NoError(t, err)
+			require.True(t, result.IsValid())
+			require.Equal(t, []ValidationResult{{Id: "1"}}, result.Notes)
+		})
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		t.Run("missing inputs and outputs", func(t *testing.T) {
+			result, err := s.ValidateTxRequest(ctx, nil, nil, nil)
+			require.NoError(t, err)
+			require.False(t, result.IsValid())
+			require.Equal(t, []string{"missing inputs", "missing outputs"}, result.Errors)
+		})
+
+		t.Run("vtxos and notes", func(t *testing.T) {
+			result, err := s.ValidateTxRequest(
+				ctx, []ports.Input{input(vtxo)}, []note.Note{validNote},
+				[]domain.Receiver{{Amount: 2000, PubKey: pubkey}},
+			)
+			require.NoError(t, err)
+			require.False(t, result.IsValid())
+			require.Equal(t, []string{"cannot mix vtxos and notes"}, result.Errors)
+		})
+
+		t.Run("inputs", func(t *testing.T) {
+			inputs := []ports.Input{
+				input(vtxo), input(vtxo), input(spentVtxo),
+				input(queuedVtxo), input(registeredVtxo), input(foreignVtxo),
+			}
+			result, err := s.ValidateTxRequest(
+				ctx, inputs, nil, []domain.Receiver{{Amount: 1000, PubKey: pubkey}},
+			)
+			require.NoError(t, err)
+			require.False(t, result.IsValid())
+			// the sums are not compared if some items are invalid
+			require.Empty(t, result.Errors)
+
+			require.Len(t, result.Inputs, len(inputs))
+			expectedErrors := []string{
+				"",
+				"duplicated input",
+				"already spent",
+				fmt.Sprintf("input already used by tx request %s", queuedRequest.Id),
+				"input already registered for the ongoing round",
+				"descriptor does not match vtxo pubkey",
+			}
+			for i, res := range result.Inputs {
+				require.Equal(t, inputs[i].String(), res.Id)
+				if len(expectedErrors[i]) <= 0 {
+					require.True(t, res.IsValid())
+					continue
+				}
+				require.Contains(t, res.Error, expectedErrors[i])
+			}
+		})
+
+		t.Run("notes", func(t *testing.T) {
+			notes := []note.Note{validNote, validNote, unsignedNote, redeemedNote, expiredNote}
+			result, err := s.ValidateTxRequest(
+				ctx, nil, notes, []domain.Receiver{{Amount: 1000, PubKey: pubkey}},
+			)
+			require.NoError(t, err)
+			require.False(t, result.IsValid())
+
+			require.Len(t, result.Notes, len(notes))
+			expectedErrors := []string{
+				"", "duplicated note", "invalid note signature", "note already spent", "note expired",
+			}
+			for i, res := range result.Notes {
+				require.Equal(t, fmt.Sprintf("%d", notes[i].ID), res.Id)
+				if len(expectedErrors[i]) <= 0 {
+					require.True(t, res.IsValid())
+					continue
+				}
+				require.Contains(t, res.Error, expectedErrors[i])
+			}
+		})
+
+		t.Run("receivers", func(t *testing.T) {
+			receivers := []domain.Receiver{
+				{Amount: 1000},
+				{Amount: 0, PubKey: pubkey},
+				{Amount: 50, PubKey: pubkey},
+			}
+			result, err := s.ValidateTxRequest(ctx, []ports.Input{input(vtxo)}, nil, receivers)
+			require.NoError(t, err)
+			require.False(t, result.IsValid())
+			require.Equal(t, []string{
+				"too many receivers, tx request has 3, max 2 per request",
+			}, result.Errors)
+			require.Equal(t, []ValidationResult{
+				{Error: "missing receiver destination"},
+				{Id: pubkey, Error: "missing receiver amount"},
+				{Id: pubkey, Error: "receiver amount is lower than min vtxo amount:100"},
+			}, result.Receivers)
+		})
+
+		t.Run("amounts", func(t *testing.T) {
+			result, err := s.ValidateTxRequest(
+				ctx, []ports.Input{input(vtxo)}, nil, []domain.Receiver{{Amount: 900, PubKey: pubkey}},
+			)
+			require.NoError(t, err)
+			require.False(t, result.IsValid())
+			require.Equal(t, []string{"sum of inputs 1000 does not match sum of outputs 900"}, result.Errors)
+
+			result, err = s.ValidateTxRequest(
+				ctx, []ports.Input{input(bigVtxo)}, nil, []domain.Receiver{{Amount: 3000, PubKey: pubkey}},
+			)
+			require.NoError(t, err)
+			require.False(t, result.IsValid())
+			require.Equal(t, []string{"total amount is higher than max settle amount:2000"}, result.Errors)
+		})
+	})
+}
@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sync"
 	"time"
 
@@ -16,17 +17,38 @@ import (
 // it is responsible for sweeping onchain shared outputs that expired
 // it also handles delaying the sweep events in case some parts of the tree are broadcasted
 // when a round is finalized, the main application service schedules a sweep event on the newly created vtxo tree
+// vtxo trees expiring at the same time are swept together, the sweepable outputs are consolidated
+// into as few sweep txs as possible, each one spending at most maxInputsPerSweepTx inputs
 type sweeper struct {
 	wallet      ports.WalletService
 	repoManager ports.RepoManager
 	builder     ports.TxBuilder
 	scheduler   ports.SchedulerService
 
-	noteUriPrefix string
+	noteUriPrefix       string
+	maxInputsPerSweepTx int64
+	// maxConcurrentConfirmationChecks is the max number of txs of a vtxo tree
+	// whose onchain state is checked at the same time
+	maxConcurrentConfirmationChecks int64
+	eventPublisher                  EventPublisher
 
 	// cache of scheduled tasks, avoid scheduling the same sweep event multiple times
 	locker         sync.Locker
 	scheduledTasks map[string]struct{}
+	// vtxo trees to sweep grouped by expiration, only one task is scheduled per expiration
+	pendingSweeps map[int64][]sweepTarget
+}
+
+// sweepTarget is a vtxo tree (or a subtree) to be swept
+type sweepTarget struct {
+	roundTxid string
+	vtxoTree  tree.TxTree
+}
+
+// sweepItem is a sweepable shared output along with the vtxos it's related to
+type sweepItem struct {
+	input ports.SweepInput
+	vtxos []domain.VtxoKey
 }
 
 func newSweeper(
@@ -35,6 +57,9 @@ func newSweeper(
 	builder ports.TxBuilder,
 	scheduler ports.SchedulerService,
 	noteUriPrefix string,
+	maxInputsPerSweepTx int64,
+	maxConcurrentConfirmationChecks int64,
+	eventPublisher EventPublisher,
 ) *sweeper {
 	return &sweeper{
 		wallet,
@@ -42,8 +67,12 @@ func newSweeper(
 		builder,
 		scheduler,
 		noteUriPrefix,
+		maxInputsPerSweepTx,
+		maxConcurrentConfirmationChecks,
+		eventPublisher,
 		&sync.Mutex{},
 		make(map[string]struct{}),
+		make(map[int64][]sweepTarget),
 	}
 }
 
@@ -57,16 +86,22 @@ func (s *sweeper) start() error {
 		return err
 	}
 
+	targets := make([]sweepTarget, 0, len(expiredRounds))
 	for _, txid := range expiredRounds {
 		vtxoTree, err := s.repoManager.Rounds().GetVtxoTreeWithTxid(ctx, txid)
 		if err != nil {
 			return err
 		}
+		if len(vtxoTree) <= 0 {
+			continue
+		}
 
-		task := s.createTask(txid, vtxoTree)
-		task()
+		targets = append(targets, sweepTarget{txid, vtxoTree})
 	}
 
+	// sweep all the expired rounds at once to consolidate their outputs
+	s.sweep(targets)
+
 	return nil
 }
 
@@ -82,6 +117,7 @@ func (s *sweeper) removeTask(treeRootTxid string) {
 }
 
 // schedule set up a task to be executed once at the given timestamp
+// if a task is already scheduled at the same timestamp, the vtxo tree is added to it
 func (s *sweeper) schedule(
 	expirationTimestamp int64, roundTxid string, vtxoTree tree.TxTree,
 ) error {
@@ -95,11 +131,15 @@ func (s *sweeper) schedule(
 		return err
 	}
 
+	s.locker.Lock()
 	if _, scheduled := s.scheduledTasks[root.Txid]; scheduled {
+		s.locker.Unlock()
 		return nil
 	}
-
-	task := s.createTask(roundTxid, vtxoTree)
+	targets, taskExists := s.pendingSweeps[expirationTimestamp]
+	s.pendingSweeps[expirationTimestamp] = append(targets, sweepTarget{roundTxid, vtxoTree})
+	s.scheduledTasks[root.Txid] = struct{}{}
+	s.locker.Unlock()
 
 	var fancyTime string
 	if s.scheduler.Unit() == ports.UnixTime {
@@ -109,14 +149,17 @@ func (s *sweeper) schedule(
 	}
 	log.Debugf("scheduled sweep for round %s at %s", roundTxid, fancyTime)
 
-	if err := s.scheduler.ScheduleTaskOnce(expirationTimestamp, task); err != nil {
-		return err
+	if !taskExists {
+		task := s.createTask(expirationTimestamp)
+		if err := s.scheduler.ScheduleTaskOnce(expirationTimestamp, task); err != nil {
+			s.locker.Lock()
+			delete(s.pendingSweeps, expirationTimestamp)
+			delete(s.scheduledTasks, root.Txid)
+			s.locker.Unlock()
+			return err
+		}
 	}
 
-	s.locker.Lock()
-	s.scheduledTasks[root.Txid] = struct{}{}
-	s.locker.Unlock()
-
 	if err := s.updateVtxoExpirationTime(vtxoTree, expirationTimestamp); err != nil {
 		log.WithError(err).Error("error while updating vtxo expiration time")
 	}
@@ -125,188 +168,270 @@ func (s *sweeper) schedule(
 }
 
 // createTask returns a function passed as handler in the scheduler
-// it tries to craft a sweep tx containing the onchain outputs of the given vtxo tree
-// if some parts of the tree have been broadcasted in the meantine, it will schedule the next taskes for the remaining parts of the tree
-func (s *sweeper) createTask(
-	roundTxid string, vtxoTree tree.TxTree,
-) func() {
+// it sweeps all the vtxo trees scheduled for the given expiration
+func (s *sweeper) createTask(expirationTimestamp int64) func() {
 	return func() {
-		ctx := context.Background()
-		root, err := vtxoTree.Root()
-		if err != nil {
-			log.WithError(err).Error("error while getting root node")
-			return
+		s.locker.Lock()
+		targets := s.pendingSweeps[expirationTimestamp]
+		delete(s.pendingSweeps, expirationTimestamp)
+		s.locker.Unlock()
+
+		roundTxids := make([]string, 0, len(targets))
+		for _, target := range targets {
+			if !slices.Contains(roundTxids, target.roundTxid) {
+				roundTxids = append(roundTxids, target.roundTxid)
+			}
 		}
+		publishRoundEvent(s.eventPublisher, RoundEvent{
+			Type: RoundEventSweepTriggered, RoundTxids: roundTxids,
+		})
 
-		s.removeTask(root.Txid)
-		log.Debugf("sweeper: %s", root.Txid)
+		s.sweep(targets)
+	}
+}
 
-		sweepInputs := make([]ports.SweepInput, 0)
-		vtxoKeys := make([]domain.VtxoKey, 0) // vtxos associated to the sweep inputs
+// sweep tries to craft sweep txs containing the onchain outputs of the given vtxo trees
+// if some parts of the trees have been broadcasted in the meantine, it will schedule the next tasks for the remaining parts of the trees
+func (s *sweeper) sweep(targets []sweepTarget) {
+	ctx := context.Background()
 
-		// inspect the vtxo tree to find onchain shared outputs
-		sharedOutputs, err := findSweepableOutputs(ctx, s.wallet, s.builder, s.scheduler.Unit(), vtxoTree)
-		if err != nil {
-			log.WithError(err).Error("error while inspecting vtxo tree")
-			return
+	items := make([]sweepItem, 0)
+	for _, target := range targets {
+		items = append(items, s.findSweepItems(ctx, target)...)
+	}
+
+	for _, batch := range batchSweepItems(items, s.maxInputsPerSweepTx) {
+		if err := s.broadcastSweepTx(ctx, batch); err != nil {
+			log.WithError(err).Error("error while sweeping")
 		}
+	}
 
-		for expiredAt, inputs := range sharedOutputs {
-			// if the shared outputs are not expired, schedule a sweep task for it
-			if s.scheduler.AfterNow(expiredAt) {
-				subtrees, err := computeSubTrees(vtxoTree, inputs)
-				if err != nil {
-					log.WithError(err).Error("error while computing subtrees")
-					continue
-				}
+	rounds := make(map[string]struct{})
+	for _, target := range targets {
+		if _, ok := rounds[target.roundTxid]; ok {
+			continue
+		}
+		rounds[target.roundTxid] = struct{}{}
+		s.markRoundSweptIfNeeded(ctx, target.roundTxid)
+	}
+}
 
-				for _, subTree := range subtrees {
-					if err := s.schedule(expiredAt, roundTxid, subTree); err != nil {
-						log.WithError(err).Error("error while scheduling sweep task")
-						continue
-					}
-				}
+// batchSweepItems consolidates the sweepable outputs into batches of at most
+// maxInputs inputs, a non-positive maxInputs means no limit
+func batchSweepItems(items []sweepItem, maxInputs int64) [][]sweepItem {
+	if len(items) <= 0 {
+		return nil
+	}
+
+	batchSize := len(items)
+	if maxInputs > 0 && int64(batchSize) > maxInputs {
+		batchSize = int(maxInputs)
+	}
+
+	batches := make([][]sweepItem, 0, (len(items)+batchSize-1)/batchSize)
+	for start := 0; start < len(items); start += batchSize {
+		end := min(start+batchSize, len(items))
+		batches = append(batches, items[start:end])
+	}
+	return batches
+}
+
+// findSweepItems inspects the given vtxo tree and returns the expired shared outputs to sweep
+// the not expired ones are scheduled for later
+func (s *sweeper) findSweepItems(ctx context.Context, target sweepTarget) []sweepItem {
+	roundTxid, vtxoTree := target.roundTxid, target.vtxoTree
+
+	root, err := vtxoTree.Root()
+	if err != nil {
+		log.WithError(err).Error("error while getting root node")
+		return nil
+	}
+
+	s.removeTask(root.Txid)
+	log.Debugf("sweeper: %s", root.Txid)
+
+	items := make([]sweepItem, 0)
+
+	// inspect the vtxo tree to find onchain shared outputs
+	sharedOutputs, err := findSweepableOutputs(
+		ctx, s.wallet, s.builder, s.scheduler.Unit(), vtxoTree,
+		s.maxConcurrentConfirmationChecks,
+	)
+	if err != nil {
+		log.WithError(err).Error("error while inspecting vtxo tree")
+		return nil
+	}
+
+	for expiredAt, inputs := range sharedOutputs {
+		// if the shared outputs are not expired, schedule a sweep task for it
+		if s.scheduler.AfterNow(expiredAt) {
+			subtrees, err := computeSubTrees(vtxoTree, inputs)
+			if err != nil {
+				log.WithError(err).Error("error while computing subtrees")
 				continue
 			}
 
-			// iterate over the expired shared outputs
-			for _, input := range inputs {
-				// sweepableVtxos related to the sweep input
-				sweepableVtxos := make([]domain.VtxoKey, 0)
-
-				// check if input is the vtxo itself
-				vtxos, _ := s.repoManager.Vtxos().GetVtxos(
-					ctx,
-					[]domain.VtxoKey{
-						{
-							Txid: input.GetHash().String(),
-							VOut: input.GetIndex(),
-						},
+			for _, subTree := range subtrees {
+				if err := s.schedule(expiredAt, roundTxid, subTree); err != nil {
+					log.WithError(err).Error("error while scheduling sweep task")
+					continue
+				}
+			}
+			continue
+		}
+
+		// iterate over the expired shared outputs
+		for _, input := range inputs {
+			// sweepableVtxos related to the sweep input
+			sweepableVtxos := make([]domain.VtxoKey, 0)
+
+			// check if input is the vtxo itself
+			vtxos, _ := s.repoManager.Vtxos().GetVtxos(
+				ctx,
+				[]domain.VtxoKey{
+					{
+						Txid: input.GetHash().String(),
+						VOut: input.GetIndex(),
 					},
-				)
-				if len(vtxos) > 0 {
-					if !vtxos[0].Swept && !vtxos[0].Redeemed {
-						sweepableVtxos = append(sweepableVtxos, vtxos[0].VtxoKey)
-					}
-				} else {
-					// if it's not a vtxo, find all the vtxos leaves reachable from that input
-					vtxosLeaves, err := s.builder.FindLeaves(vtxoTree, input.GetHash().String(), input.GetIndex())
+				},
+			)
+			if len(vtxos) > 0 {
+				if !vtxos[0].Swept && !vtxos[0].Redeemed {
+					sweepableVtxos = append(sweepableVtxos, vtxos[0].VtxoKey)
+				}
+			} else {
+				// if it's not a vtxo, find all the vtxos leaves reachable from that input
+				vtxosLeaves, err := s.builder.FindLeaves(vtxoTree, input.GetHash().String(), input.GetIndex())
+				if err != nil {
+					log.WithError(err).Error("error while finding vtxos leaves")
+					continue
+				}
+
+				for _, leaf := range vtxosLeaves {
+					vtxo, err := extractVtxoOutpoint(leaf)
 					if err != nil {
-						log.WithError(err).Error("error while finding vtxos leaves")
+						log.Error(err)
 						continue
 					}
 
-					for _, leaf := range vtxosLeaves {
-						vtxo, err := extractVtxoOutpoint(leaf)
-						if err != nil {
-							log.Error(err)
-							continue
-						}
-
-						sweepableVtxos = append(sweepableVtxos, *vtxo)
-					}
-
-					if len(sweepableVtxos) <= 0 {
-						continue
-					}
+					sweepableVtxos = append(sweepableVtxos, *vtxo)
+				}
 
-					firstVtxo, err := s.repoManager.Vtxos().GetVtxos(ctx, sweepableVtxos[:1])
-					if err != nil {
-						log.Error(fmt.Errorf("error while getting vtxo: %w", err))
-						sweepInputs = append(sweepInputs, input) // add the input anyway in order to try to sweep it
-						continue
-					}
+				if len(sweepableVtxos) <= 0 {
+					continue
+				}
 
-					if firstVtxo[0].Swept || firstVtxo[0].Redeemed {
-						// we assume that if the first vtxo is swept or redeemed, the shared output has been spent
-						// skip, the output is already swept or spent by a unilateral redeem
-						continue
-					}
+				firstVtxo, err := s.repoManager.Vtxos().GetVtxos(ctx, sweepableVtxos[:1])
+				if err != nil {
+					log.Error(fmt.Errorf("error while getting vtxo: %w", err))
+					items = append(items, sweepItem{input, nil}) // add the input anyway in order to try to sweep it
+					continue
 				}
 
-				if len(sweepableVtxos) > 0 {
-					vtxoKeys = append(vtxoKeys, sweepableVtxos...)
-					sweepInputs = append(sweepInputs, input)
+				if firstVtxo[0].Swept || firstVtxo[0].Redeemed {
+					// we assume that if the first vtxo is swept or redeemed, the shared output has been spent
+					// skip, the output is already swept or spent by a unilateral redeem
+					continue
 				}
 			}
-		}
 
-		vtxosRepository := s.repoManager.Vtxos()
-		if len(sweepInputs) > 0 {
-			// build the sweep transaction with all the expired non-swept shared outputs
-			sweepTxId, sweepTx, err := s.builder.BuildSweepTx(sweepInputs)
-			if err != nil {
-				log.WithError(err).Error("error while building sweep tx")
-				return
+			if len(sweepableVtxos) > 0 {
+				items = append(items, sweepItem{input, sweepableVtxos})
 			}
+		}
+	}
 
-			// check if the transaction is already onchain
-			tx, _ := s.wallet.GetTransaction(ctx, sweepTxId)
+	return items
+}
 
-			txid := ""
+// broadcastSweepTx builds and broadcasts a sweep tx spending the given items
+// and marks the related vtxos as swept
+func (s *sweeper) broadcastSweepTx(ctx context.Context, items []sweepItem) error {
+	if len(items) <= 0 {
+		return nil
+	}
 
-			if len(tx) > 0 {
-				txid = sweepTxId
-			}
+	sweepInputs := make([]ports.SweepInput, 0, len(items))
+	vtxoKeys := make([]domain.VtxoKey, 0) // vtxos associated to the sweep inputs
+	for _, item := range items {
+		sweepInputs = append(sweepInputs, item.input)
+		vtxoKeys = append(vtxoKeys, item.vtxos...)
+	}
 
-			err = nil
-			// retry until the tx is broadcasted or the error is not BIP68 final
-			for len(txid) == 0 && (err == nil || err == ports.ErrNonFinalBIP68) {
-				if err != nil {
-					log.Debugln("sweep tx not BIP68 final, retrying in 5 seconds")
-					time.Sleep(5 * time.Second)
-				}
+	// build the sweep transaction with all the expired non-swept shared outputs
+	sweepTxId, sweepTx, err := s.builder.BuildSweepTx(sweepInputs)
+	if err != nil {
+		return fmt.Errorf("error while building sweep tx: %w", err)
+	}
 
-				txid, err = s.wallet.BroadcastTransaction(ctx, sweepTx)
-			}
-			if err != nil {
-				log.WithError(err).Error("error while broadcasting sweep tx")
-				return
-			}
+	// check if the transaction is already onchain
+	tx, _ := s.wallet.GetTransaction(ctx, sweepTxId)
 
-			if len(txid) > 0 {
-				log.Debugln("sweep tx broadcasted:", txid)
+	txid := ""
 
-				// mark the vtxos as swept
-				if err := vtxosRepository.SweepVtxos(ctx, vtxoKeys); err != nil {
-					log.Error(fmt.Errorf("error while deleting vtxos: %w", err))
-					return
-				}
+	if len(tx) > 0 {
+		txid = sweepTxId
+	}
 
-				log.Debugf("%d vtxos swept", len(vtxoKeys))
-			}
+	err = nil
+	// retry until the tx is broadcasted or the error is not BIP68 final
+	for len(txid) == 0 && (err == nil || err == ports.ErrNonFinalBIP68) {
+		if err != nil {
+			log.Debugln("sweep tx not BIP68 final, retrying in 5 seconds")
+			time.Sleep(5 * time.Second)
 		}
 
-		roundVtxos, err := vtxosRepository.GetVtxosForRound(ctx, roundTxid)
-		if err != nil {
-			log.WithError(err).Error("error while getting vtxos for round")
-			return
+		txid, err = s.wallet.BroadcastTransaction(ctx, sweepTx)
+	}
+	if err != nil {
+		return fmt.Errorf("error while broadcasting sweep tx: %w", err)
+	}
+
+	if len(txid) > 0 {
+		log.Debugf("sweep tx broadcasted: %s (%d inputs)", txid, len(sweepInputs))
+
+		// mark the vtxos as swept
+		if err := s.repoManager.Vtxos().SweepVtxos(ctx, vtxoKeys); err != nil {
+			return fmt.Errorf("error while deleting vtxos: %w", err)
 		}
 
-		allSwept := true
-		for _, vtxo := range roundVtxos {
-			allSwept = allSwept && (vtxo.Swept || vtxo.Redeemed)
-			if !allSwept {
-				break
-			}
+		log.Debugf("%d vtxos swept", len(vtxoKeys))
+	}
+
+	return nil
+}
+
+// markRoundSweptIfNeeded marks the round as swept if all its vtxos are swept or redeemed
+func (s *sweeper) markRoundSweptIfNeeded(ctx context.Context, roundTxid string) {
+	roundVtxos, err := s.repoManager.Vtxos().GetVtxosForRound(ctx, roundTxid)
+	if err != nil {
+		log.WithError(err).Error("error while getting vtxos for round")
+		return
+	}
+
+	allSwept := true
+	for _, vtxo := range roundVtxos {
+		allSwept = allSwept && (vtxo.Swept || vtxo.Redeemed)
+		if !allSwept {
+			break
 		}
+	}
 
-		if allSwept {
-			// update the round
-			roundRepo := s.repoManager.Rounds()
-			round, err := roundRepo.GetRoundWithTxid(ctx, roundTxid)
-			if err != nil {
-				log.WithError(err).Error("error while getting round")
-				return
-			}
+	if allSwept {
+		// update the round
+		roundRepo := s.repoManager.Rounds()
+		round, err := roundRepo.GetRoundWithTxid(ctx, roundTxid)
+		if err != nil {
+			log.WithError(err).Error("error while getting round")
+			return
+		}
 
-			log.Debugf("round %s fully swept", roundTxid)
-			round.Sweep()
+		log.Debugf("round %s fully swept", roundTxid)
+		round.Sweep()
 
-			if err := roundRepo.AddOrUpdateRound(ctx, *round); err != nil {
-				log.WithError(err).Error("error while marking round as swept")
-				return
-			}
+		if err := roundRepo.AddOrUpdateRound(ctx, *round); err != nil {
+			log.WithError(err).Error("error while marking round as swept")
+			return
 		}
 	}
 }
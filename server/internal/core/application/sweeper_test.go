@@ -0,0 +1,72 @@
+package application
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchSweepItems(t *testing.T) {
+	items := makeTestSweepItems(5)
+
+	testCases := []struct {
+		name          string
+		maxInputs     int64
+		expectedSizes []int
+	}{
+		{"no limit", 0, []int{5}},
+		{"negative limit", -1, []int{5}},
+		{"limit above the number of inputs", 10, []int{5}},
+		{"limit equal to the number of inputs", 5, []int{5}},
+		{"one input per sweep tx", 1, []int{1, 1, 1, 1, 1}},
+		{"limit not dividing the number of inputs", 2, []int{2, 2, 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			batches := batchSweepItems(items, tc.maxInputs)
+			require.Len(t, batches, len(tc.expectedSizes))
+
+			// every input is swept exactly once and in order
+			swept := make([]sweepItem, 0, len(items))
+			for i, batch := range batches {
+				require.Len(t, batch, tc.expectedSizes[i])
+				swept = append(swept, batch...)
+			}
+			require.Equal(t, items, swept)
+		})
+	}
+
+	t.Run("no inputs", func(t *testing.T) {
+		require.Empty(t, batchSweepItems(nil, 2))
+		require.Empty(t, batchSweepItems([]sweepItem{}, 0))
+	})
+
+	t.Run("inputs of different rounds", func(t *testing.T) {
+		// the outputs of the rounds expiring together are consolidated into the same sweep tx
+		round1, round2 := makeTestSweepItems(2), makeTestSweepItems(2)
+		for i := range round2 {
+			round2[i].vtxos[0].Txid = fmt.Sprintf("round2-%d", i)
+		}
+		items := append(round1, round2...)
+
+		batches := batchSweepItems(items, 3)
+		require.Len(t, batches, 2)
+		require.Equal(t, items[:3], batches[0])
+		require.Equal(t, items[3:], batches[1])
+	})
+}
+
+func makeTestSweepItems(count int) []sweepItem {
+	items := make([]sweepItem, 0, count)
+	for i := 0; i < count; i++ {
+		txid := fmt.Sprintf("%064x", i+1)
+		items = append(items, sweepItem{
+			input: mockedSweepInput{txid: txid},
+			vtxos: []domain.VtxoKey{{Txid: txid, VOut: 0}},
+		})
+	}
+	return items
+}
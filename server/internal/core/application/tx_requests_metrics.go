@@ -0,0 +1,103 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// TxRequestsQueueMetrics is a snapshot of the metrics of the tx requests
+// queue.
+type TxRequestsQueueMetrics struct {
+	// Length is the number of pending requests with registered receivers.
+	Length int64
+	// Dropped is the number of requests deleted for missing pings.
+	Dropped uint64
+	// Popped is the number of requests selected for a round.
+	Popped uint64
+	// TotalWait is the sum of the time the popped requests waited in the
+	// queue, from push to pop.
+	TotalWait time.Duration
+}
+
+// AvgWait returns the average time the popped requests waited in the queue.
+func (m TxRequestsQueueMetrics) AvgWait() time.Duration {
+	if m.Popped == 0 {
+		return 0
+	}
+	return m.TotalWait / time.Duration(m.Popped)
+}
+
+// Metrics returns a snapshot of the metrics of the queue.
+func (m *txRequestsQueue) Metrics() TxRequestsQueueMetrics {
+	length := m.len()
+
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return TxRequestsQueueMetrics{
+		Length:    length,
+		Dropped:   m.dropped,
+		Popped:    m.popped,
+		TotalWait: m.totalWait,
+	}
+}
+
+// observeWait records the wait time of a popped request, the caller must hold
+// the write lock.
+func (m *txRequestsQueue) observeWait(wait time.Duration) {
+	m.popped++
+	m.totalWait += wait
+	if m.waitHistogram != nil {
+		m.waitHistogram.Record(context.Background(), wait.Seconds())
+	}
+}
+
+// initMetrics registers the instruments of the queue with the global meter
+// provider, they're exported along with the other metrics of the server.
+func (m *txRequestsQueue) initMetrics() {
+	meter := otel.Meter("ark.tx_requests")
+
+	histogram, err := meter.Float64Histogram(
+		"ark_tx_requests_wait_seconds",
+		metric.WithDescription("time tx requests wait in the queue before being selected for a round"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create tx requests wait histogram")
+		return
+	}
+	m.waitHistogram = histogram
+
+	length, err := meter.Int64ObservableGauge(
+		"ark_tx_requests_queue_length",
+		metric.WithDescription("number of pending tx requests with registered receivers"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create tx requests queue length gauge")
+		return
+	}
+	dropped, err := meter.Int64ObservableCounter(
+		"ark_tx_requests_dropped",
+		metric.WithDescription("number of tx requests deleted for missing pings"),
+	)
+	if err != nil {
+		log.WithError(err).Warn("failed to create tx requests dropped counter")
+		return
+	}
+
+	if _, err := meter.RegisterCallback(
+		func(_ context.Context, obs metric.Observer) error {
+			metrics := m.Metrics()
+			obs.ObserveInt64(length, metrics.Length)
+			obs.ObserveInt64(dropped, int64(metrics.Dropped))
+			return nil
+		},
+		length, dropped,
+	); err != nil {
+		log.WithError(err).Warn("failed to register tx requests queue callback")
+	}
+}
@@ -15,22 +15,48 @@ import (
 type Service interface {
 	Start() error
 	Stop()
+	// Shutdown stops accepting tx requests and waits for the rounds in flight
+	// to end, aborting them once the given context is done.
+	Shutdown(ctx context.Context) error
 	SpendNotes(ctx context.Context, notes []note.Note) (string, error)
 	RegisterIntent(ctx context.Context, bip322signature bip322.Signature, message tree.IntentMessage) (string, error)
 	SpendVtxos(ctx context.Context, inputs []ports.Input) (string, error)
 	ClaimVtxos(ctx context.Context, creds string, receivers []domain.Receiver, musig2Data *tree.Musig2) error
+	// AmendTxRequest replaces the receivers of a tx request that is not yet
+	// selected for a round. If musig2Data is nil the registered one is kept.
+	AmendTxRequest(ctx context.Context, requestId string, receivers []domain.Receiver, musig2Data *tree.Musig2) error
 	SignVtxos(ctx context.Context, forfeitTxs []string) error
 	SignRoundTx(ctx context.Context, roundTx string) error
 	GetRoundByTxid(ctx context.Context, roundTxid string) (*domain.Round, error)
+	// GetRoundTx returns the hex encoded raw round tx of a finalized round.
+	GetRoundTx(ctx context.Context, roundTxid string) (string, error)
 	GetRoundById(ctx context.Context, id string) (*domain.Round, error)
+	// GetRoundTreeBranch returns the nodes of the vtxo tree of the given round
+	// from the root to the leaf of the given vtxo, one per level.
+	GetRoundTreeBranch(ctx context.Context, roundTxid string, vtxo domain.VtxoKey) (tree.TxTree, error)
+	// ListRounds returns at most limit finalized rounds, sorted by ending time
+	// and txid, that follow the round with the given ending time and txid.
+	ListRounds(
+		ctx context.Context, endedAfter int64, afterTxid string, limit int,
+	) ([]domain.RoundSummary, error)
 	GetCurrentRound(ctx context.Context) (*domain.Round, error)
 	GetEventsChannel(ctx context.Context) <-chan domain.RoundEvent
 	UpdateTxRequestStatus(ctx context.Context, requestID string) error
 	ListVtxos(
 		ctx context.Context, address string,
 	) (spendableVtxos, spentVtxos []domain.Vtxo, err error)
+	ListVtxosForAddresses(ctx context.Context, addresses []string) ([]AddressVtxos, error)
 	GetInfo(ctx context.Context) (*ServiceInfo, error)
-	SubmitRedeemTx(ctx context.Context, redeemTx string) (signedRedeemTx, redeemTxid string, err error)
+	// SubmitRedeemTx accepts the given redeem tx. With a non-empty idempotency
+	// key, resubmitting the same tx with the same key returns the original
+	// result instead of failing because the vtxos are already spent.
+	SubmitRedeemTx(
+		ctx context.Context, redeemTx, idempotencyKey string,
+	) (signedRedeemTx, redeemTxid string, err error)
+	SubmitRedeemTxs(ctx context.Context, redeemTxs []string) []RedeemTxResult
+	// ValidateRedeemTx runs the checks of SubmitRedeemTx without accepting the
+	// redeem tx, and returns its txid if valid.
+	ValidateRedeemTx(ctx context.Context, redeemTx string) (string, error)
 	GetBoardingAddress(
 		ctx context.Context, userPubkey *secp256k1.PublicKey,
 	) (address string, scripts []string, err error)
@@ -48,6 +74,24 @@ type Service interface {
 	UpdateMarketHourConfig(ctx context.Context, marketHourStartTime, marketHourEndTime time.Time, period, roundInterval time.Duration) error
 	GetTxRequestQueue(ctx context.Context, requestIds ...string) ([]TxRequestInfo, error)
 	DeleteTxRequests(ctx context.Context, requestIds ...string) error
+	EstimateNextRound(ctx context.Context) (*RoundEstimation, error)
+	// GetLiquidityStatus returns the balance of the server wallet that is not
+	// committed to any round in flight.
+	GetLiquidityStatus(ctx context.Context) (*LiquidityStatus, error)
+	ValidateTxRequest(
+		ctx context.Context, inputs []ports.Input, notes []note.Note, receivers []domain.Receiver,
+	) (*TxRequestValidation, error)
+	// AuthorizeRefresh lets the server register the given intent on behalf of
+	// the owner of the vtxo it spends once the vtxo gets close to expiry. The
+	// forfeit tx of the vtxo must be signed with SIGHASH_ALL|ANYONECANPAY.
+	AuthorizeRefresh(
+		ctx context.Context, bip322signature bip322.Signature,
+		message tree.IntentMessage, forfeitTx string,
+	) error
+	// RevokeRefreshAuthorization revokes the authorization to refresh the given
+	// vtxo by refreshing it right away, the signature is the BIP322 signature
+	// of common.RefreshRevocationMessage made by the owner of the vtxo.
+	RevokeRefreshAuthorization(ctx context.Context, vtxo domain.VtxoKey, signature string) error
 }
 
 type ServiceInfo struct {
@@ -64,6 +108,11 @@ type ServiceInfo struct {
 	UtxoMaxAmount       int64
 	VtxoMinAmount       int64
 	VtxoMaxAmount       int64
+	SettleMinAmount     int64
+	SettleMaxAmount     int64
+	// MaxReceiversPerRequest is the max number of receivers of a tx request,
+	// 0 means no limit.
+	MaxReceiversPerRequest int64
 }
 
 type NextMarketHour struct {
@@ -141,6 +190,62 @@ type TxRequestInfo struct {
 	LastPing       time.Time
 }
 
+// RoundEstimation is the projection of the round tx that would be built with
+// the tx requests currently in queue.
+type RoundEstimation struct {
+	ports.RoundTxEstimation
+	NumOfTxRequests int
+}
+
+// TxRequestValidation is the outcome of validating a tx request without
+// registering it. Items are reported in the same order they were given.
+type TxRequestValidation struct {
+	Inputs    []ValidationResult
+	Notes     []ValidationResult
+	Receivers []ValidationResult
+	// Errors that do not refer to a specific item.
+	Errors []string
+}
+
+func (v TxRequestValidation) IsValid() bool {
+	return len(v.Errors) <= 0 && !v.hasInvalidItems()
+}
+
+func (v TxRequestValidation) hasInvalidItems() bool {
+	for _, list := range [][]ValidationResult{v.Inputs, v.Notes, v.Receivers} {
+		for _, r := range list {
+			if !r.IsValid() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ValidationResult refers to an input, note or receiver of a tx request.
+type ValidationResult struct {
+	Id    string
+	Error string
+}
+
+func (r ValidationResult) IsValid() bool {
+	return len(r.Error) <= 0
+}
+
+// RedeemTxResult is the outcome of the submission of a redeem tx in a batch.
+type RedeemTxResult struct {
+	SignedRedeemTx string
+	Txid           string
+	Err            error
+}
+
+// AddressVtxos groups the vtxos of an address.
+type AddressVtxos struct {
+	Address        string
+	SpendableVtxos []domain.Vtxo
+	SpentVtxos     []domain.Vtxo
+}
+
 type VtxoChainResp struct {
 	Chain              []ChainWithExpiry
 	Page               PageResp
@@ -4,20 +4,30 @@ import (
 	"context"
 	"fmt"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
-	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/common/note"
 	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/server/internal/core/domain"
 	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	log "github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/metric"
 )
 
+// txRequestState is the stage of the lifecycle of a tx request in the queue.
+type txRequestState int
+
 const (
-	selectGapMinutes = float64(1)
-	deleteGapMinutes = float64(5)
+	// txRequestPending requests wait to be selected for the next round.
+	txRequestPending txRequestState = iota
+	// txRequestSigning requests have been selected for a round and their
+	// owners are signing it, they're deleted from the queue once it ends.
+	txRequestSigning
 )
 
 type timedTxRequest struct {
@@ -28,17 +38,69 @@ type timedTxRequest struct {
 	pingTimestamp  time.Time
 	musig2Data     *tree.Musig2
 	recoveredVtxos []domain.Vtxo
+	state          txRequestState
 }
 
+// totalInputAmount returns the sum of the vtxos, boarding utxos, notes and
+// recovered vtxos spent by the request.
+func (r timedTxRequest) totalInputAmount() uint64 {
+	tot := r.TotalInputAmount()
+	for _, boardingInput := range r.boardingInputs {
+		tot += boardingInput.Amount
+	}
+	for _, note := range r.notes {
+		tot += uint64(note.Value)
+	}
+	for _, vtxo := range r.recoveredVtxos {
+		tot += vtxo.Amount
+	}
+	return tot
+}
+
+// txRequestsQueue holds the tx requests registered for the next rounds.
+// Requests without a ping for longer than pingGap are not selected for a round
+// and, if pending, they're deleted after deleteGap. Requests being signed in a
+// round are never deleted for the lack of pings since their owners may be busy
+// signing rather than gone. Requests with more than maxReceivers receivers are
+// rejected to bound their contribution to the width of the vtxo tree.
 type txRequestsQueue struct {
-	lock     *sync.RWMutex
-	requests map[string]*timedTxRequest
+	lock         *sync.RWMutex
+	requests     map[string]*timedTxRequest
+	pingGap      time.Duration
+	deleteGap    time.Duration
+	maxReceivers int64
+	// ready is signaled when a request with receivers is added or updated
+	ready chan struct{}
+
+	// dropped, popped and totalWait are the counters of the queue metrics
+	dropped       uint64
+	popped        uint64
+	totalWait     time.Duration
+	waitHistogram metric.Float64Histogram
 }
 
-func newTxRequestsQueue() *txRequestsQueue {
-	requestsById := make(map[string]*timedTxRequest)
-	lock := &sync.RWMutex{}
-	return &txRequestsQueue{lock, requestsById}
+func newTxRequestsQueue(
+	pingGap, deleteGap time.Duration, maxReceivers int64,
+) *txRequestsQueue {
+	queue := &txRequestsQueue{
+		lock:         &sync.RWMutex{},
+		requests:     make(map[string]*timedTxRequest),
+		pingGap:      pingGap,
+		deleteGap:    deleteGap,
+		maxReceivers: maxReceivers,
+		ready:        make(chan struct{}, 1),
+	}
+	queue.initMetrics()
+	return queue
+}
+
+// validateReceiversCount returns an error if a tx request with the given
+// number of receivers exceeds the max allowed, 0 means no limit.
+func (m *txRequestsQueue) validateReceiversCount(count int) error {
+	if m.maxReceivers > 0 && int64(count) > m.maxReceivers {
+		return errTooManyReceivers{count, m.maxReceivers}
+	}
+	return nil
 }
 
 func (m *txRequestsQueue) len() int64 {
@@ -47,13 +109,15 @@ func (m *txRequestsQueue) len() int64 {
 
 	count := int64(0)
 	for _, p := range m.requests {
-		if len(p.Receivers) > 0 {
+		if p.state == txRequestPending && len(p.Receivers) > 0 {
 			count++
 		}
 	}
 	return count
 }
 
+// pushWithNotes adds a request spending only notes. Notes and vtxos can't be
+// mixed in the same request, therefore the request must have no inputs.
 func (m *txRequestsQueue) pushWithNotes(request domain.TxRequest, notes []note.Note) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -62,6 +126,16 @@ func (m *txRequestsQueue) pushWithNotes(request domain.TxRequest, notes []note.N
 		return fmt.Errorf("duplicated tx request %s", request.Id)
 	}
 
+	if len(notes) <= 0 {
+		return fmt.Errorf("missing notes for tx request %s", request.Id)
+	}
+	if len(request.Inputs) > 0 {
+		return fmt.Errorf("tx request %s can't spend both notes and vtxos", request.Id)
+	}
+	if err := m.validateReceiversCount(len(request.Receivers)); err != nil {
+		return err
+	}
+
 	for _, note := range notes {
 		for _, txRequest := range m.requests {
 			for _, rNote := range txRequest.notes {
@@ -72,7 +146,17 @@ func (m *txRequestsQueue) pushWithNotes(request domain.TxRequest, notes []note.N
 		}
 	}
 
-	m.requests[request.Id] = &timedTxRequest{request, make([]ports.BoardingInput, 0), notes, time.Now(), time.Time{}, nil, make([]domain.Vtxo, 0)}
+	m.requests[request.Id] = &timedTxRequest{
+		TxRequest:      request,
+		boardingInputs: make([]ports.BoardingInput, 0),
+		notes:          notes,
+		timestamp:      time.Now(),
+		recoveredVtxos: make([]domain.Vtxo, 0),
+		state:          txRequestPending,
+	}
+	if len(request.Receivers) > 0 {
+		m.notifyReady()
+	}
 	return nil
 }
 
@@ -88,6 +172,9 @@ func (m *txRequestsQueue) push(
 	if _, ok := m.requests[request.Id]; ok {
 		return fmt.Errorf("duplicated tx request %s", request.Id)
 	}
+	if err := m.validateReceiversCount(len(request.Receivers)); err != nil {
+		return err
+	}
 
 	for _, input := range request.Inputs {
 		for _, pay := range m.requests {
@@ -120,30 +207,108 @@ func (m *txRequestsQueue) push(
 	}
 
 	now := time.Now()
-	m.requests[request.Id] = &timedTxRequest{request, boardingInputs, make([]note.Note, 0), now, now, musig2Data, recoveredVtxos}
+	m.requests[request.Id] = &timedTxRequest{
+		TxRequest:      request,
+		boardingInputs: boardingInputs,
+		notes:          make([]note.Note, 0),
+		timestamp:      now,
+		pingTimestamp:  now,
+		musig2Data:     musig2Data,
+		recoveredVtxos: recoveredVtxos,
+		state:          txRequestPending,
+	}
+	if len(request.Receivers) > 0 {
+		m.notifyReady()
+	}
 	return nil
 }
 
-func (m *txRequestsQueue) pop(num int64) ([]domain.TxRequest, []ports.BoardingInput, []note.Note, []*tree.Musig2, []domain.Vtxo) {
+// pop selects at most num tx requests for a round and marks them as being
+// signed. They are kept in the queue, exempt from deletion for the lack of
+// pings, until deleted once the round ends.
+func (m *txRequestsQueue) pop(num int64) ([]domain.TxRequest, []ports.BoardingInput, []note.Note, []*tree.Musig2, []domain.Vtxo, map[string]uint64) {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	selectedRequests := m.selectRequests(num, true)
+
+	requests := make([]domain.TxRequest, 0, len(selectedRequests))
+	boardingInputs := make([]ports.BoardingInput, 0)
+	notes := make([]note.Note, 0)
+	musig2Data := make([]*tree.Musig2, 0)
+	recoveredVtxos := make([]domain.Vtxo, 0)
+	inputAmounts := make(map[string]uint64)
+	now := time.Now()
+	for _, p := range selectedRequests {
+		m.observeWait(now.Sub(p.timestamp))
+		inputAmounts[p.Id] = p.totalInputAmount()
+		boardingInputs = append(boardingInputs, p.boardingInputs...)
+		requests = append(requests, p.TxRequest)
+		musig2Data = append(musig2Data, p.musig2Data)
+		notes = append(notes, p.notes...)
+		recoveredVtxos = append(recoveredVtxos, p.recoveredVtxos...)
+		m.requests[p.Id].state = txRequestSigning
+	}
+	return requests, boardingInputs, notes, musig2Data, recoveredVtxos, inputAmounts
+}
+
+// peek returns the tx requests that would be popped from the queue, without
+// removing them. The returned musig2 data are copies and can be modified.
+func (m *txRequestsQueue) peek(num int64) ([]domain.TxRequest, []ports.BoardingInput, []*tree.Musig2) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	selectedRequests := m.selectRequests(num, false)
+
+	requests := make([]domain.TxRequest, 0, len(selectedRequests))
+	boardingInputs := make([]ports.BoardingInput, 0)
+	musig2Data := make([]*tree.Musig2, 0)
+	for _, p := range selectedRequests {
+		var data *tree.Musig2
+		if p.musig2Data != nil {
+			data = &tree.Musig2{
+				CosignersPublicKeys: append([]string{}, p.musig2Data.CosignersPublicKeys...),
+				SigningType:         p.musig2Data.SigningType,
+			}
+		}
+		boardingInputs = append(boardingInputs, p.boardingInputs...)
+		requests = append(requests, p.TxRequest)
+		musig2Data = append(musig2Data, data)
+	}
+	return requests, boardingInputs, musig2Data
+}
+
+// selectRequests returns at most num pending tx requests with registered
+// receivers and a recent ping, picking the oldest ones first. The selected
+// requests are returned sorted by id so that the composition of a round
+// doesn't depend on the order they were registered in. If deleteStale is true,
+// the pending requests without a ping for longer than the delete gap are
+// removed from the queue, in which case the caller must hold the write lock.
+func (m *txRequestsQueue) selectRequests(num int64, deleteStale bool) []timedTxRequest {
 	requestsByTime := make([]timedTxRequest, 0, len(m.requests))
 	for _, p := range m.requests {
+		// Skip tx requests already selected for a round, they're never deleted
+		// here because their owners may be busy signing rather than gone.
+		if p.state == txRequestSigning {
+			continue
+		}
+
 		// Skip tx requests without registered receivers.
 		if len(p.Receivers) <= 0 {
 			continue
 		}
 
-		sinceLastPing := time.Since(p.pingTimestamp).Minutes()
+		sinceLastPing := time.Since(p.pingTimestamp)
 
-		// Skip tx requests for which users didn't notify to be online in the last minute.
-		if sinceLastPing > selectGapMinutes {
-			// Cleanup the request from the map if greater than deleteGapMinutes
-			// TODO move to dedicated function
-			if sinceLastPing > deleteGapMinutes {
-				log.Debugf("delete tx request %s : we didn't receive a ping in the last %d minutes", p.Id, int(deleteGapMinutes))
+		// Skip tx requests for which users didn't notify to be online recently.
+		if sinceLastPing > m.pingGap {
+			if deleteStale && sinceLastPing > m.deleteGap {
+				log.Debugf(
+					"delete tx request %s: we didn't receive a ping in the last %s",
+					p.Id, m.deleteGap,
+				)
 				delete(m.requests, p.Id)
+				m.dropped++
 			}
 
 			continue
@@ -152,27 +317,20 @@ func (m *txRequestsQueue) pop(num int64) ([]domain.TxRequest, []ports.BoardingIn
 		requestsByTime = append(requestsByTime, *p)
 	}
 	sort.SliceStable(requestsByTime, func(i, j int) bool {
+		if requestsByTime[i].timestamp.Equal(requestsByTime[j].timestamp) {
+			return requestsByTime[i].Id < requestsByTime[j].Id
+		}
 		return requestsByTime[i].timestamp.Before(requestsByTime[j].timestamp)
 	})
 
 	if num < 0 || num > int64(len(requestsByTime)) {
 		num = int64(len(requestsByTime))
 	}
-
-	requests := make([]domain.TxRequest, 0, num)
-	boardingInputs := make([]ports.BoardingInput, 0)
-	notes := make([]note.Note, 0)
-	musig2Data := make([]*tree.Musig2, 0)
-	recoveredVtxos := make([]domain.Vtxo, 0)
-	for _, p := range requestsByTime[:num] {
-		boardingInputs = append(boardingInputs, p.boardingInputs...)
-		requests = append(requests, p.TxRequest)
-		musig2Data = append(musig2Data, p.musig2Data)
-		notes = append(notes, p.notes...)
-		recoveredVtxos = append(recoveredVtxos, p.recoveredVtxos...)
-		delete(m.requests, p.Id)
-	}
-	return requests, boardingInputs, notes, musig2Data, recoveredVtxos
+	selected := requestsByTime[:num]
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Id < selected[j].Id
+	})
+	return selected
 }
 
 func (m *txRequestsQueue) update(request domain.TxRequest, musig2Data *tree.Musig2) error {
@@ -183,10 +341,25 @@ func (m *txRequestsQueue) update(request domain.TxRequest, musig2Data *tree.Musi
 	if !ok {
 		return errTxRequestNotFound{request.Id}
 	}
+	if r.state == txRequestSigning {
+		return fmt.Errorf("tx request %s already selected for a round", request.Id)
+	}
+
+	// The inputs are validated when the request is pushed, the update can only
+	// change its receivers.
+	if len(r.notes) > 0 && len(request.Inputs) > 0 {
+		return fmt.Errorf("tx request %s can't spend both notes and vtxos", request.Id)
+	}
+	if !sameInputs(r.Inputs, request.Inputs) {
+		return fmt.Errorf("inputs of tx request %s can't be changed", request.Id)
+	}
+	if err := m.validateReceiversCount(len(request.Receivers)); err != nil {
+		return err
+	}
 
 	// sum inputs = vtxos + boarding utxos + notes + recovered vtxos
 	sumOfInputs := uint64(0)
-	for _, input := range request.Inputs {
+	for _, input := range r.Inputs {
 		sumOfInputs += input.Amount
 	}
 
@@ -217,9 +390,56 @@ func (m *txRequestsQueue) update(request domain.TxRequest, musig2Data *tree.Musi
 	if musig2Data != nil {
 		r.musig2Data = musig2Data
 	}
+	m.notifyReady()
 	return nil
 }
 
+func (m *txRequestsQueue) notifyReady() {
+	select {
+	case m.ready <- struct{}{}:
+	default:
+	}
+}
+
+// waitForRegistration blocks for the given duration of the registration stage
+// of a round. In single-party fast mode, the stage ends as soon as a tx request
+// is ready to be selected instead: on a server used by a single participant
+// there's nobody else to wait for. The rest of the round, from the tree and
+// connectors construction to the musig2 and forfeit exchanges, is the same
+// in both modes.
+func (m *txRequestsQueue) waitForRegistration(duration time.Duration, fastMode bool) {
+	if !fastMode {
+		time.Sleep(duration)
+		return
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	for {
+		// the signal may be stale, left by a request already selected
+		if m.len() > 0 {
+			return
+		}
+		select {
+		case <-timer.C:
+			return
+		case <-m.ready:
+		}
+	}
+}
+
+func sameInputs(a, b []domain.Vtxo) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].VtxoKey != b[i].VtxoKey || a[i].Amount != b[i].Amount {
+			return false
+		}
+	}
+	return true
+}
+
 func (m *txRequestsQueue) updatePingTimestamp(id string) error {
 	m.lock.Lock()
 	defer m.lock.Unlock()
@@ -271,6 +491,48 @@ func (m *txRequestsQueue) viewAll(ids []string) ([]timedTxRequest, error) {
 	return requests, nil
 }
 
+// findInput returns the id of the tx request that already uses the given
+// outpoint as vtxo, boarding or recovered input, if any.
+func (m *txRequestsQueue) findInput(outpoint domain.VtxoKey) (string, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, request := range m.requests {
+		for _, input := range request.Inputs {
+			if input.VtxoKey == outpoint {
+				return request.Id, true
+			}
+		}
+		for _, input := range request.boardingInputs {
+			if input.VtxoKey == outpoint {
+				return request.Id, true
+			}
+		}
+		for _, vtxo := range request.recoveredVtxos {
+			if vtxo.VtxoKey == outpoint {
+				return request.Id, true
+			}
+		}
+	}
+	return "", false
+}
+
+// findNote returns the id of the tx request that already uses the given note,
+// if any.
+func (m *txRequestsQueue) findNote(id uint64) (string, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, request := range m.requests {
+		for _, note := range request.notes {
+			if note.ID == id {
+				return request.Id, true
+			}
+		}
+	}
+	return "", false
+}
+
 func (m *txRequestsQueue) view(id string) (*domain.TxRequest, bool) {
 	m.lock.RLock()
 	defer m.lock.RUnlock()
@@ -287,81 +549,135 @@ func (m *txRequestsQueue) view(id string) (*domain.TxRequest, bool) {
 	}, true
 }
 
+// forfeitTxsMap collects the forfeit txs of a round in flight. The forfeits
+// are persisted through the repository as they arrive so that they can be
+// restored if the round is initialized again, for example after a restart.
 type forfeitTxsMap struct {
 	lock    *sync.RWMutex
 	builder ports.TxBuilder
+	repo    domain.ForfeitTxsRepository
 
-	forfeitTxs      map[domain.VtxoKey]string
+	roundId         string
 	connectors      tree.TxTree
 	connectorsIndex map[string]domain.Outpoint
 	vtxos           []domain.Vtxo
+	// feeRate is the fee rate the forfeit txs of the round must pay, fixed at
+	// the start of the round
+	feeRate chainfee.SatPerKVByte
 }
 
-func newForfeitTxsMap(txBuilder ports.TxBuilder) *forfeitTxsMap {
+func newForfeitTxsMap(
+	txBuilder ports.TxBuilder, repo domain.ForfeitTxsRepository,
+) *forfeitTxsMap {
 	return &forfeitTxsMap{
 		lock:            &sync.RWMutex{},
 		builder:         txBuilder,
-		forfeitTxs:      make(map[domain.VtxoKey]string),
+		repo:            repo,
 		connectors:      nil,
 		connectorsIndex: nil,
 		vtxos:           nil,
 	}
 }
 
-func (m *forfeitTxsMap) init(connectors tree.TxTree, requests []domain.TxRequest) error {
+// init registers the vtxos of the given requests to be forfeited at the given
+// fee rate. Forfeit txs restored from a previous attempt of the round that
+// don't pay the fee for this rate are dropped, so that they are requested
+// again.
+func (m *forfeitTxsMap) init(
+	roundId string, connectors tree.TxTree, requests []domain.TxRequest,
+	feeRate chainfee.SatPerKVByte,
+) error {
 	vtxosToSign := make([]domain.Vtxo, 0)
 	for _, request := range requests {
 		vtxosToSign = append(vtxosToSign, request.Inputs...)
 	}
+	sortVtxos(vtxosToSign)
 
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
+	m.roundId = roundId
 	m.vtxos = vtxosToSign
 	m.connectors = connectors
+	m.feeRate = feeRate
 
 	// init the forfeit txs map
+	vtxoKeys := make([]domain.VtxoKey, 0, len(vtxosToSign))
 	for _, vtxo := range vtxosToSign {
-		m.forfeitTxs[vtxo.VtxoKey] = ""
+		vtxoKeys = append(vtxoKeys, vtxo.VtxoKey)
+	}
+	ctx := context.Background()
+	if err := m.repo.Init(ctx, roundId, vtxoKeys, int64(feeRate)); err != nil {
+		return fmt.Errorf("failed to init forfeit txs: %s", err)
 	}
 
 	// create the connectors index
 	connectorsIndex := make(map[string]domain.Outpoint)
 
 	if len(vtxosToSign) > 0 {
-		connectorsOutpoints := make([]domain.Outpoint, 0)
+		vtxoOutpoints := make([]string, 0, len(vtxosToSign))
+		for _, vtxo := range vtxosToSign {
+			vtxoOutpoints = append(vtxoOutpoints, vtxo.String())
+		}
 
-		leaves := connectors.Leaves()
-		if len(leaves) == 0 {
-			return fmt.Errorf("no connectors found")
+		assignments, err := tree.AssignConnectors(vtxoOutpoints, connectors.Leaves())
+		if err != nil {
+			return err
 		}
 
-		for _, n := range leaves {
-			connectorsOutpoints = append(connectorsOutpoints, domain.Outpoint{
-				Txid: n.Txid,
-				VOut: 0,
-			})
+		for vtxo, connector := range assignments {
+			connectorsIndex[vtxo] = domain.Outpoint{
+				Txid: connector.Hash.String(),
+				VOut: connector.Index,
+			}
 		}
+	}
 
-		// sort lexicographically
-		sort.Slice(vtxosToSign, func(i, j int) bool {
-			return vtxosToSign[i].String() < vtxosToSign[j].String()
-		})
+	m.connectorsIndex = connectorsIndex
 
-		if len(vtxosToSign) > len(connectorsOutpoints) {
-			return fmt.Errorf("more vtxos to sign than outpoints, %d > %d", len(vtxosToSign), len(connectorsOutpoints))
+	forfeitTxs, err := m.repo.GetForfeitTxs(ctx, roundId)
+	if err != nil {
+		return fmt.Errorf("failed to get forfeit txs: %s", err)
+	}
+	restored := 0
+	outdated := make(map[domain.VtxoKey]string)
+	for vtxoKey, tx := range forfeitTxs {
+		if len(tx) <= 0 {
+			continue
 		}
-
-		for i, vtxo := range vtxosToSign {
-			connectorsIndex[vtxo.String()] = connectorsOutpoints[i]
+		if _, err := m.builder.VerifyForfeitTxs(
+			m.vtxos, m.connectors, []string{tx}, m.connectorsIndex, m.feeRate,
+		); err != nil {
+			log.WithError(err).Debugf("dropping restored forfeit tx of vtxo %s", vtxoKey)
+			outdated[vtxoKey] = ""
+			continue
 		}
+		restored++
+	}
+	if len(outdated) > 0 {
+		if err := m.repo.Sign(ctx, roundId, outdated); err != nil {
+			return fmt.Errorf("failed to drop outdated forfeit txs: %s", err)
+		}
+		log.Infof(
+			"dropped %d restored forfeit txs for round %s not valid at %d sats/kvB",
+			len(outdated), roundId, m.feeRate,
+		)
+	}
+	if restored > 0 {
+		log.Infof("restored %d forfeit txs for round %s", restored, roundId)
 	}
-
-	m.connectorsIndex = connectorsIndex
 
 	return nil
 }
 
+// getFeeRate returns the fee rate the forfeit txs of the round must pay.
+func (m *forfeitTxsMap) getFeeRate() chainfee.SatPerKVByte {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.feeRate
+}
+
 func (m *forfeitTxsMap) sign(txs []string) error {
 	if len(txs) == 0 {
 		return nil
@@ -375,16 +691,26 @@ func (m *forfeitTxsMap) sign(txs []string) error {
 	}
 
 	// verify the txs are valid
-	validTxs, err := m.builder.VerifyForfeitTxs(m.vtxos, m.connectors, txs, m.connectorsIndex)
+	validTxs, err := m.builder.VerifyForfeitTxs(
+		m.vtxos, m.connectors, txs, m.connectorsIndex, m.feeRate,
+	)
 	if err != nil {
 		return err
 	}
 
-	for vtxoKey, txs := range validTxs {
-		if _, ok := m.forfeitTxs[vtxoKey]; !ok {
+	ctx := context.Background()
+	forfeitTxs, err := m.repo.GetForfeitTxs(ctx, m.roundId)
+	if err != nil {
+		return fmt.Errorf("failed to get forfeit txs: %s", err)
+	}
+	for vtxoKey := range validTxs {
+		if _, ok := forfeitTxs[vtxoKey]; !ok {
 			return fmt.Errorf("unexpected forfeit tx, vtxo %s is not in the batch", vtxoKey)
 		}
-		m.forfeitTxs[vtxoKey] = txs
+	}
+
+	if err := m.repo.Sign(ctx, m.roundId, validTxs); err != nil {
+		return fmt.Errorf("failed to store forfeit txs: %s", err)
 	}
 
 	return nil
@@ -394,10 +720,16 @@ func (m *forfeitTxsMap) reset() {
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	m.forfeitTxs = make(map[domain.VtxoKey]string)
+	if len(m.roundId) > 0 {
+		if err := m.repo.Delete(context.Background(), m.roundId); err != nil {
+			log.WithError(err).Warn("failed to reset forfeit txs")
+		}
+	}
+	m.roundId = ""
 	m.connectors = nil
 	m.connectorsIndex = nil
 	m.vtxos = nil
+	m.feeRate = 0
 }
 
 func (m *forfeitTxsMap) pop() ([]string, error) {
@@ -407,8 +739,21 @@ func (m *forfeitTxsMap) pop() ([]string, error) {
 		m.reset()
 	}()
 
-	txs := make([]string, 0)
-	for vtxo, forfeit := range m.forfeitTxs {
+	forfeitTxs, err := m.repo.GetForfeitTxs(context.Background(), m.roundId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get forfeit txs: %s", err)
+	}
+
+	// return the forfeit txs in the canonical order of the vtxos
+	vtxos := make([]domain.VtxoKey, 0, len(forfeitTxs))
+	for vtxo := range forfeitTxs {
+		vtxos = append(vtxos, vtxo)
+	}
+	sortVtxoKeys(vtxos)
+
+	txs := make([]string, 0, len(vtxos))
+	for _, vtxo := range vtxos {
+		forfeit := forfeitTxs[vtxo]
 		if len(forfeit) == 0 {
 			return nil, fmt.Errorf("missing forfeit tx for vtxo %s", vtxo)
 		}
@@ -419,7 +764,16 @@ func (m *forfeitTxsMap) pop() ([]string, error) {
 }
 
 func (m *forfeitTxsMap) allSigned() bool {
-	for _, txs := range m.forfeitTxs {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	forfeitTxs, err := m.repo.GetForfeitTxs(context.Background(), m.roundId)
+	if err != nil {
+		log.WithError(err).Warn("failed to get forfeit txs")
+		return false
+	}
+
+	for _, txs := range forfeitTxs {
 		if len(txs) == 0 {
 			return false
 		}
@@ -428,6 +782,57 @@ func (m *forfeitTxsMap) allSigned() bool {
 	return true
 }
 
+// connectorOf returns the outpoint and the prevout of the connector assigned
+// to the given vtxo.
+func (m *forfeitTxsMap) connectorOf(vtxo domain.VtxoKey) (*wire.OutPoint, *wire.TxOut, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	connector, ok := m.connectorsIndex[vtxo.String()]
+	if !ok {
+		return nil, nil, fmt.Errorf("missing connector for vtxo %s", vtxo)
+	}
+
+	for _, leaf := range m.connectors.Leaves() {
+		if leaf.Txid != connector.Txid {
+			continue
+		}
+		connectorTx, err := psbt.NewFromRawBytes(strings.NewReader(leaf.Tx), true)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(connectorTx.UnsignedTx.TxOut) <= int(connector.VOut) {
+			return nil, nil, fmt.Errorf("invalid connector tx %s", connector.Txid)
+		}
+		outpoint := &wire.OutPoint{
+			Hash: connectorTx.UnsignedTx.TxHash(), Index: connector.VOut,
+		}
+		return outpoint, connectorTx.UnsignedTx.TxOut[connector.VOut], nil
+	}
+	return nil, nil, fmt.Errorf("connector tx %s not found", connector.Txid)
+}
+
+func (m *forfeitTxsMap) hasVtxos() bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return len(m.vtxos) > 0
+}
+
+func (m *forfeitTxsMap) includesAny(vtxoKeys []domain.VtxoKey) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	for _, vtxo := range m.vtxos {
+		for _, key := range vtxoKeys {
+			if vtxo.VtxoKey == key {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 type outpointMap struct {
 	lock      *sync.RWMutex
 	outpoints map[string]struct{}
@@ -456,6 +861,23 @@ func (r *outpointMap) remove(outpoints []domain.VtxoKey) {
 	}
 }
 
+// addIfNotIncluded atomically adds the given outpoints only if none of them is
+// already included, otherwise it returns the first one found.
+func (r *outpointMap) addIfNotIncluded(outpoints []domain.VtxoKey) (bool, string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, out := range outpoints {
+		if _, exists := r.outpoints[out.String()]; exists {
+			return true, out.String()
+		}
+	}
+	for _, out := range outpoints {
+		r.outpoints[out.String()] = struct{}{}
+	}
+	return false, ""
+}
+
 func (r *outpointMap) includes(outpoint domain.VtxoKey) bool {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
@@ -476,65 +898,102 @@ func (r *outpointMap) includesAny(outpoints []domain.VtxoKey) (bool, string) {
 	return false, ""
 }
 
+// defaultMaxConcurrentConfirmationChecks is the default max number of txs of
+// a vtxo tree whose onchain state is checked at the same time by
+// findSweepableOutputs.
+const defaultMaxConcurrentConfirmationChecks = 16
+
 // findSweepableOutputs iterates over all the nodes' outputs in the vtxo tree and checks their onchain state
 // returns the sweepable outputs as ports.SweepInput mapped by their expiration time
+// At most maxConcurrentChecks txs are checked at the same time, the default
+// limit is used if not positive.
 func findSweepableOutputs(
 	ctx context.Context,
 	walletSvc ports.WalletService,
 	txbuilder ports.TxBuilder,
 	schedulerUnit ports.TimeUnit,
 	vtxoTree tree.TxTree,
+	maxConcurrentChecks int64,
 ) (map[int64][]ports.SweepInput, error) {
 	sweepableOutputs := make(map[int64][]ports.SweepInput)
 	blocktimeCache := make(map[string]int64) // txid -> blocktime / blockheight
 	nodesToCheck := vtxoTree[0]              // init with the root
 
+	// index the children of every node once instead of scanning the whole tree
+	// for every confirmed node
+	childrenByParent := make(map[string][]tree.Node)
+	for _, level := range vtxoTree {
+		for _, node := range level {
+			childrenByParent[node.ParentTxid] = append(childrenByParent[node.ParentTxid], node)
+		}
+	}
+
+	getBlocktime := func(confirmation txConfirmation) int64 {
+		if schedulerUnit == ports.BlockHeight {
+			return confirmation.height
+		}
+		return confirmation.blocktime
+	}
+
 	for len(nodesToCheck) > 0 {
 		newNodesToCheck := make([]tree.Node, 0)
 
+		// check the onchain state of all the nodes of the level at once
+		txids := make([]string, 0, len(nodesToCheck))
+		for _, node := range nodesToCheck {
+			txids = append(txids, node.Txid)
+		}
+		confirmations := getTxConfirmations(ctx, walletSvc, txids, maxConcurrentChecks)
+
+		// and of the parents of the unconfirmed ones, if not cached yet
+		parentTxids := make([]string, 0)
+		for _, node := range nodesToCheck {
+			confirmation := confirmations[node.Txid]
+			if confirmation.err != nil || confirmation.confirmed {
+				continue
+			}
+			if _, ok := blocktimeCache[node.ParentTxid]; !ok {
+				parentTxids = append(parentTxids, node.ParentTxid)
+			}
+		}
+		parentConfirmations := getTxConfirmations(
+			ctx, walletSvc, parentTxids, maxConcurrentChecks,
+		)
+
 		for _, node := range nodesToCheck {
-			isConfirmed, height, blocktime, err := walletSvc.IsTransactionConfirmed(ctx, node.Txid)
-			if err != nil {
-				return nil, err
+			confirmation := confirmations[node.Txid]
+			if confirmation.err != nil {
+				return nil, confirmation.err
 			}
 
 			var expirationTime int64
 			var sweepInput ports.SweepInput
 
-			if !isConfirmed {
+			if !confirmation.confirmed {
 				if _, ok := blocktimeCache[node.ParentTxid]; !ok {
-					isConfirmed, height, blocktime, err := walletSvc.IsTransactionConfirmed(ctx, node.ParentTxid)
-					if !isConfirmed || err != nil {
+					parentConfirmation := parentConfirmations[node.ParentTxid]
+					if !parentConfirmation.confirmed || parentConfirmation.err != nil {
 						return nil, fmt.Errorf("tx %s not found", node.ParentTxid)
 					}
 
-					if schedulerUnit == ports.BlockHeight {
-						blocktimeCache[node.ParentTxid] = height
-					} else {
-						blocktimeCache[node.ParentTxid] = blocktime
-					}
+					blocktimeCache[node.ParentTxid] = getBlocktime(parentConfirmation)
 				}
 
-				var vtxoTreeExpiry *common.RelativeLocktime
-				vtxoTreeExpiry, sweepInput, err = txbuilder.GetSweepInput(node)
+				vtxoTreeExpiry, input, err := txbuilder.GetSweepInput(node)
 				if err != nil {
 					return nil, err
 				}
+				sweepInput = input
 				expirationTime = blocktimeCache[node.ParentTxid] + int64(vtxoTreeExpiry.Value)
 			} else {
 				// cache the blocktime for future use
-				if schedulerUnit == ports.BlockHeight {
-					blocktimeCache[node.Txid] = height
-				} else {
-					blocktimeCache[node.Txid] = blocktime
-				}
+				blocktimeCache[node.Txid] = getBlocktime(confirmation)
 
 				// if the tx is onchain, it means that the input is spent
 				// add the children to the nodes in order to check them during the next iteration
 				// We will return the error below, but are we going to schedule the tasks for the "children roots"?
 				if !node.Leaf {
-					children := vtxoTree.Children(node.Txid)
-					newNodesToCheck = append(newNodesToCheck, children...)
+					newNodesToCheck = append(newNodesToCheck, childrenByParent[node.Txid]...)
 				}
 				continue
 			}
@@ -551,6 +1010,73 @@ func findSweepableOutputs(
 	return sweepableOutputs, nil
 }
 
+// vtxoTreeBranch returns the branch of the given vtxo tree leading to the leaf
+// of the given vtxo, as a tree with one node per level.
+func vtxoTreeBranch(vtxoTree tree.TxTree, vtxo domain.VtxoKey) (tree.TxTree, error) {
+	nodes, err := vtxoTree.Branch(vtxo.Txid)
+	if err != nil {
+		return nil, fmt.Errorf("vtxo %s not found in vtxo tree: %s", vtxo, err)
+	}
+
+	branch := make(tree.TxTree, 0, len(nodes))
+	for _, node := range nodes {
+		branch = append(branch, []tree.Node{node})
+	}
+	return branch, nil
+}
+
+type txConfirmation struct {
+	confirmed bool
+	height    int64
+	blocktime int64
+	err       error
+}
+
+// getTxConfirmations checks the onchain state of the given txs with at most
+// maxConcurrentChecks concurrent queries. Every tx is queried only once, the
+// error of a query is returned within its result.
+func getTxConfirmations(
+	ctx context.Context, walletSvc ports.WalletService, txids []string,
+	maxConcurrentChecks int64,
+) map[string]txConfirmation {
+	if maxConcurrentChecks <= 0 {
+		maxConcurrentChecks = defaultMaxConcurrentConfirmationChecks
+	}
+
+	confirmations := make(map[string]txConfirmation, len(txids))
+	lock := &sync.Mutex{}
+	wg := &sync.WaitGroup{}
+	sem := make(chan struct{}, maxConcurrentChecks)
+
+	seen := make(map[string]struct{}, len(txids))
+	for _, txid := range txids {
+		if _, ok := seen[txid]; ok {
+			continue
+		}
+		seen[txid] = struct{}{}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(txid string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+
+			confirmed, height, blocktime, err := walletSvc.IsTransactionConfirmed(ctx, txid)
+
+			lock.Lock()
+			defer lock.Unlock()
+			confirmations[txid] = txConfirmation{confirmed, height, blocktime, err}
+		}(txid)
+	}
+	wg.Wait()
+
+	return confirmations
+}
+
+// getSpentVtxos returns the vtxos spent by the given requests, in canonical
+// order.
 func getSpentVtxos(requests map[string]domain.TxRequest) []domain.VtxoKey {
 	vtxos := make([]domain.VtxoKey, 0)
 	for _, request := range requests {
@@ -558,5 +1084,20 @@ func getSpentVtxos(requests map[string]domain.TxRequest) []domain.VtxoKey {
 			vtxos = append(vtxos, vtxo.VtxoKey)
 		}
 	}
+	sortVtxoKeys(vtxos)
 	return vtxos
 }
+
+// sortVtxos sorts the given vtxos by outpoint, in the same lexicographic
+// order used to assign them the connectors of a round.
+func sortVtxos(vtxos []domain.Vtxo) {
+	sort.Slice(vtxos, func(i, j int) bool {
+		return vtxos[i].String() < vtxos[j].String()
+	})
+}
+
+func sortVtxoKeys(vtxos []domain.VtxoKey) {
+	sort.Slice(vtxos, func(i, j int) bool {
+		return vtxos[i].String() < vtxos[j].String()
+	})
+}
@@ -0,0 +1,993 @@
+package application
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ark-network/ark/common"
+	"github.com/ark-network/ark/common/note"
+	"github.com/ark-network/ark/common/tree"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxRequestsQueueFind(t *testing.T) {
+	queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+
+	vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: "aa", VOut: 0}, Amount: 1000}
+	boarding := ports.BoardingInput{
+		Input:  ports.Input{VtxoKey: domain.VtxoKey{Txid: "bb", VOut: 1}},
+		Amount: 2000,
+	}
+
+	request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+	require.NoError(t, err)
+	err = queue.push(*request, []ports.BoardingInput{boarding}, nil, nil)
+	require.NoError(t, err)
+
+	noteRequest, err := domain.NewTxRequest(nil)
+	require.NoError(t, err)
+	err = queue.pushWithNotes(*noteRequest, []note.Note{{Data: note.Data{ID: 42, Value: 500}}})
+	require.NoError(t, err)
+
+	id, found := queue.findInput(vtxo.VtxoKey)
+	require.True(t, found)
+	require.Equal(t, request.Id, id)
+
+	id, found = queue.findInput(boarding.VtxoKey)
+	require.True(t, found)
+	require.Equal(t, request.Id, id)
+
+	_, found = queue.findInput(domain.VtxoKey{Txid: "aa", VOut: 1})
+	require.False(t, found)
+
+	id, found = queue.findNote(42)
+	require.True(t, found)
+	require.Equal(t, noteRequest.Id, id)
+
+	_, found = queue.findNote(43)
+	require.False(t, found)
+
+	// lookups must not alter the queue
+	require.Len(t, queue.requests, 2)
+}
+
+func TestTxRequestsQueuePopInputAmounts(t *testing.T) {
+	queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+
+	vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: "aa", VOut: 0}, Amount: 1000}
+	boarding := ports.BoardingInput{
+		Input:  ports.Input{VtxoKey: domain.VtxoKey{Txid: "bb", VOut: 1}},
+		Amount: 2000,
+	}
+
+	request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+	require.NoError(t, err)
+	err = queue.push(*request, []ports.BoardingInput{boarding}, nil, nil)
+	require.NoError(t, err)
+
+	noteRequest, err := domain.NewTxRequest(nil)
+	require.NoError(t, err)
+	err = queue.pushWithNotes(*noteRequest, []note.Note{{Data: note.Data{ID: 42, Value: 500}}})
+	require.NoError(t, err)
+
+	// only requests with registered outputs and recently pinged are popped
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	require.NoError(t, request.AddReceivers([]domain.Receiver{{Amount: 3000, PubKey: pubkey}}))
+	require.NoError(t, queue.update(*request, nil))
+	require.NoError(t, queue.updatePingTimestamp(request.Id))
+	require.NoError(t, noteRequest.AddReceivers([]domain.Receiver{{Amount: 500, PubKey: pubkey}}))
+	require.NoError(t, queue.update(*noteRequest, nil))
+	require.NoError(t, queue.updatePingTimestamp(noteRequest.Id))
+
+	requests, _, _, _, _, inputAmounts := queue.pop(-1)
+	require.Len(t, requests, 2)
+	require.Equal(t, map[string]uint64{
+		request.Id:     3000,
+		noteRequest.Id: 500,
+	}, inputAmounts)
+	require.Zero(t, queue.len())
+}
+
+func TestTxRequestsQueueSigningExemption(t *testing.T) {
+	pingGap, deleteGap := 10*time.Millisecond, 20*time.Millisecond
+	queue := newTxRequestsQueue(pingGap, deleteGap, 0)
+
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	requests := make([]*domain.TxRequest, 0, 2)
+	for _, txid := range []string{"aa", "bb"} {
+		vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: txid}, Amount: 1000}
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		require.NoError(t, err)
+		require.NoError(t, queue.push(*request, nil, nil, nil))
+		require.NoError(t, request.AddReceivers([]domain.Receiver{{Amount: 1000, PubKey: pubkey}}))
+		require.NoError(t, queue.update(*request, nil))
+		requests = append(requests, request)
+	}
+	signingRequest, pendingRequest := requests[0], requests[1]
+
+	// the first request is selected for a round and is being signed
+	selected, _, _, _, _, _ := queue.pop(1)
+	require.Len(t, selected, 1)
+	require.Equal(t, signingRequest.Id, selected[0].Id)
+	require.Equal(t, int64(1), queue.len())
+
+	// the request being signed can't be updated nor selected again
+	err := queue.update(*signingRequest, nil)
+	require.Error(t, err)
+
+	// no pings are received for longer than the delete gap
+	time.Sleep(2 * deleteGap)
+
+	// the pending request is deleted, the one being signed is not
+	selected, _, _, _, _, _ = queue.pop(-1)
+	require.Empty(t, selected)
+	_, ok := queue.view(pendingRequest.Id)
+	require.False(t, ok)
+	_, ok = queue.view(signingRequest.Id)
+	require.True(t, ok)
+	require.Zero(t, queue.len())
+
+	// the request is deleted once the round ends
+	require.NoError(t, queue.delete([]string{signingRequest.Id}))
+	_, ok = queue.view(signingRequest.Id)
+	require.False(t, ok)
+}
+
+func TestTxRequestsQueueMetrics(t *testing.T) {
+	pingGap, deleteGap := 10*time.Millisecond, 20*time.Millisecond
+	queue := newTxRequestsQueue(pingGap, deleteGap, 0)
+
+	pushReadyTxRequest(t, queue, "aa")
+	pushReadyTxRequest(t, queue, "bb")
+
+	metrics := queue.Metrics()
+	require.Equal(t, int64(2), metrics.Length)
+	require.Zero(t, metrics.Dropped)
+	require.Zero(t, metrics.Popped)
+	require.Zero(t, metrics.AvgWait())
+
+	time.Sleep(pingGap / 2)
+	selected, _, _, _, _, _ := queue.pop(1)
+	require.Len(t, selected, 1)
+
+	metrics = queue.Metrics()
+	require.Equal(t, int64(1), metrics.Length)
+	require.Equal(t, uint64(1), metrics.Popped)
+	require.GreaterOrEqual(t, metrics.TotalWait, pingGap/2)
+	require.Equal(t, metrics.TotalWait, metrics.AvgWait())
+
+	// the pending request is dropped for missing pings
+	time.Sleep(2 * deleteGap)
+	selected, _, _, _, _, _ = queue.pop(-1)
+	require.Empty(t, selected)
+
+	metrics = queue.Metrics()
+	require.Zero(t, metrics.Length)
+	require.Equal(t, uint64(1), metrics.Dropped)
+	require.Equal(t, uint64(1), metrics.Popped)
+}
+
+func TestTxRequestsQueueMixedNotesAndInputs(t *testing.T) {
+	queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: "aa", VOut: 0}, Amount: 1000}
+	notes := []note.Note{{Data: note.Data{ID: 42, Value: 500}}}
+
+	t.Run("push", func(t *testing.T) {
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		require.NoError(t, err)
+
+		err = queue.pushWithNotes(*request, notes)
+		require.EqualError(
+			t, err, fmt.Sprintf("tx request %s can't spend both notes and vtxos", request.Id),
+		)
+
+		noteRequest, err := domain.NewTxRequest(nil)
+		require.NoError(t, err)
+		err = queue.pushWithNotes(*noteRequest, nil)
+		require.EqualError(t, err, fmt.Sprintf("missing notes for tx request %s", noteRequest.Id))
+
+		require.Zero(t, queue.len())
+		require.Empty(t, queue.requests)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		noteRequest, err := domain.NewTxRequest(nil)
+		require.NoError(t, err)
+		require.NoError(t, queue.pushWithNotes(*noteRequest, notes))
+
+		// the inputs can't be added to a request spending notes
+		mixedRequest := *noteRequest
+		mixedRequest.Inputs = []domain.Vtxo{vtxo}
+		err = mixedRequest.AddReceivers([]domain.Receiver{{Amount: 1500, PubKey: pubkey}})
+		require.NoError(t, err)
+		err = queue.update(mixedRequest, nil)
+		require.EqualError(
+			t, err, fmt.Sprintf("tx request %s can't spend both notes and vtxos", noteRequest.Id),
+		)
+
+		// the value of the notes is included in the sum of the inputs
+		require.NoError(t, noteRequest.AddReceivers([]domain.Receiver{{Amount: 500, PubKey: pubkey}}))
+		require.NoError(t, queue.update(*noteRequest, nil))
+
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		require.NoError(t, err)
+		require.NoError(t, queue.push(*request, nil, nil, nil))
+
+		// the inputs can't be changed to match a greater sum of outputs
+		changedRequest := *request
+		changedRequest.Inputs = []domain.Vtxo{{VtxoKey: vtxo.VtxoKey, Amount: 2000}}
+		err = changedRequest.AddReceivers([]domain.Receiver{{Amount: 2000, PubKey: pubkey}})
+		require.NoError(t, err)
+		err = queue.update(changedRequest, nil)
+		require.EqualError(t, err, fmt.Sprintf("inputs of tx request %s can't be changed", request.Id))
+
+		require.NoError(t, request.AddReceivers([]domain.Receiver{{Amount: 1000, PubKey: pubkey}}))
+		require.NoError(t, queue.update(*request, nil))
+	})
+}
+
+func TestTxRequestsQueueMaxReceivers(t *testing.T) {
+	queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 2)
+
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: "aa", VOut: 0}, Amount: 900}
+	receivers := []domain.Receiver{
+		{Amount: 300, PubKey: pubkey},
+		{Amount: 300, PubKey: pubkey},
+		{Amount: 300, PubKey: pubkey},
+	}
+
+	t.Run("push", func(t *testing.T) {
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		require.NoError(t, err)
+		require.NoError(t, request.AddReceivers(receivers))
+
+		err = queue.push(*request, nil, nil, nil)
+		require.EqualError(t, err, "too many receivers, tx request has 3, max 2 per request")
+		require.Empty(t, queue.requests)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		require.NoError(t, err)
+		require.NoError(t, queue.push(*request, nil, nil, nil))
+
+		oversized := *request
+		require.NoError(t, oversized.AddReceivers(receivers))
+		err = queue.update(oversized, nil)
+		require.EqualError(t, err, "too many receivers, tx request has 3, max 2 per request")
+
+		require.NoError(t, request.AddReceivers([]domain.Receiver{
+			{Amount: 450, PubKey: pubkey}, {Amount: 450, PubKey: pubkey},
+		}))
+		require.NoError(t, queue.update(*request, nil))
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+		request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+		require.NoError(t, err)
+		require.NoError(t, request.AddReceivers(receivers))
+		require.NoError(t, queue.push(*request, nil, nil, nil))
+	})
+}
+
+func TestTxRequestsQueueWaitForRegistration(t *testing.T) {
+	duration := 200 * time.Millisecond
+
+	t.Run("single-party fast mode", func(t *testing.T) {
+		queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			pushReadyTxRequest(t, queue, "aa")
+		}()
+
+		start := time.Now()
+		queue.waitForRegistration(duration, true)
+		require.Less(t, time.Since(start), duration)
+		require.Equal(t, int64(1), queue.len())
+	})
+
+	t.Run("stale signal", func(t *testing.T) {
+		queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+		// the request is selected for a previous round
+		pushReadyTxRequest(t, queue, "aa")
+		selected, _, _, _, _, _ := queue.pop(1)
+		require.Len(t, selected, 1)
+
+		start := time.Now()
+		queue.waitForRegistration(duration, true)
+		require.GreaterOrEqual(t, time.Since(start), duration)
+	})
+
+	t.Run("normal mode", func(t *testing.T) {
+		queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+		pushReadyTxRequest(t, queue, "aa")
+
+		start := time.Now()
+		queue.waitForRegistration(duration, false)
+		require.GreaterOrEqual(t, time.Since(start), duration)
+	})
+}
+
+func TestOutpointMapAddIfNotIncluded(t *testing.T) {
+	vtxos := []domain.VtxoKey{{Txid: "aa", VOut: 0}, {Txid: "bb", VOut: 1}, {Txid: "cc", VOut: 2}}
+
+	// concurrent txs spending the same vtxo are locked only once
+	outpoints := newOutpointMap()
+	locked := make(chan bool, 10)
+	wg := &sync.WaitGroup{}
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		go func() {
+			defer wg.Done()
+			exists, _ := outpoints.addIfNotIncluded(vtxos[:2])
+			locked <- !exists
+		}()
+	}
+	wg.Wait()
+	close(locked)
+
+	count := 0
+	for ok := range locked {
+		if ok {
+			count++
+		}
+	}
+	require.Equal(t, 1, count)
+
+	exists, vtxo := outpoints.addIfNotIncluded(vtxos[1:])
+	require.True(t, exists)
+	require.Equal(t, vtxos[1].String(), vtxo)
+	require.False(t, outpoints.includes(vtxos[2]))
+
+	outpoints.remove(vtxos[:2])
+	exists, _ = outpoints.addIfNotIncluded(vtxos[1:])
+	require.False(t, exists)
+	require.True(t, outpoints.includes(vtxos[2]))
+}
+
+func TestFindSweepableOutputs(t *testing.T) {
+	ctx := context.Background()
+	vtxoTree := makeTestVtxoTree(4)
+	root := vtxoTree[0][0]
+	left, right := vtxoTree[1][0], vtxoTree[1][1]
+	vtxoTreeExpiry := int64(testVtxoTreeExpiry.Value)
+
+	t.Run("unconfirmed root", func(t *testing.T) {
+		wallet := &mockedWallet{confirmed: map[string]int64{root.ParentTxid: 100}}
+
+		outputs, err := findSweepableOutputs(
+			ctx, wallet, &mockedTxBuilder{}, ports.BlockHeight, vtxoTree, 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[int64][]ports.SweepInput{
+			100 + vtxoTreeExpiry: {mockedSweepInput{txid: root.Txid}},
+		}, outputs)
+	})
+
+	t.Run("partially unrolled tree", func(t *testing.T) {
+		wallet := &mockedWallet{confirmed: map[string]int64{
+			root.ParentTxid: 100,
+			root.Txid:       101,
+			left.Txid:       102,
+		}}
+
+		outputs, err := findSweepableOutputs(
+			ctx, wallet, &mockedTxBuilder{}, ports.BlockHeight, vtxoTree, 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[int64][]ports.SweepInput{
+			101 + vtxoTreeExpiry: {mockedSweepInput{txid: right.Txid}},
+			102 + vtxoTreeExpiry: {
+				mockedSweepInput{txid: vtxoTree[2][0].Txid},
+				mockedSweepInput{txid: vtxoTree[2][1].Txid},
+			},
+		}, outputs)
+
+		// the expiration is based on the blocktime with a time based scheduler
+		outputs, err = findSweepableOutputs(
+			ctx, wallet, &mockedTxBuilder{}, ports.UnixTime, vtxoTree, 0,
+		)
+		require.NoError(t, err)
+		require.Contains(t, outputs, 101*600+vtxoTreeExpiry)
+		require.Contains(t, outputs, 102*600+vtxoTreeExpiry)
+	})
+
+	t.Run("unconfirmed round tx", func(t *testing.T) {
+		wallet := &mockedWallet{confirmed: map[string]int64{}}
+
+		_, err := findSweepableOutputs(
+			ctx, wallet, &mockedTxBuilder{}, ports.BlockHeight, vtxoTree, 0,
+		)
+		require.EqualError(t, err, fmt.Sprintf("tx %s not found", root.ParentTxid))
+	})
+
+	t.Run("concurrent checks keep the tree order", func(t *testing.T) {
+		vtxoTree := makeTestVtxoTree(128)
+		confirmed := map[string]int64{vtxoTree[0][0].ParentTxid: 100}
+		for _, level := range vtxoTree[:len(vtxoTree)-1] {
+			for _, node := range level {
+				confirmed[node.Txid] = 101
+			}
+		}
+		wallet := &mockedWallet{confirmed: confirmed, latency: time.Millisecond}
+
+		leaves := vtxoTree[len(vtxoTree)-1]
+		expected := make([]ports.SweepInput, 0, len(leaves))
+		for _, leaf := range leaves {
+			expected = append(expected, mockedSweepInput{txid: leaf.Txid})
+		}
+
+		for i := 0; i < 3; i++ {
+			outputs, err := findSweepableOutputs(
+				ctx, wallet, &mockedTxBuilder{}, ports.BlockHeight, vtxoTree, 0,
+			)
+			require.NoError(t, err)
+			require.Equal(t, map[int64][]ports.SweepInput{
+				101 + vtxoTreeExpiry: expected,
+			}, outputs)
+		}
+		require.Equal(t, int64(defaultMaxConcurrentConfirmationChecks), wallet.maxInFlight)
+
+		// the given limit is respected
+		wallet = &mockedWallet{confirmed: confirmed, latency: time.Millisecond}
+		outputs, err := findSweepableOutputs(
+			ctx, wallet, &mockedTxBuilder{}, ports.BlockHeight, vtxoTree, 4,
+		)
+		require.NoError(t, err)
+		require.Equal(t, map[int64][]ports.SweepInput{
+			101 + vtxoTreeExpiry: expected,
+		}, outputs)
+		require.Equal(t, int64(4), wallet.maxInFlight)
+	})
+}
+
+func TestVtxoTreeBranch(t *testing.T) {
+	vtxoTree := makeTestVtxoTree(4)
+	leaf := vtxoTree[2][3]
+
+	branch, err := vtxoTreeBranch(vtxoTree, domain.VtxoKey{Txid: leaf.Txid})
+	require.NoError(t, err)
+	require.Equal(t, tree.TxTree{
+		{vtxoTree[0][0]}, {vtxoTree[1][1]}, {leaf},
+	}, branch)
+
+	// the branch is a valid tree with the vtxo as only leaf
+	root, err := branch.Root()
+	require.NoError(t, err)
+	require.Equal(t, vtxoTree[0][0], root)
+	require.Equal(t, []tree.Node{leaf}, branch.Leaves())
+
+	_, err = vtxoTreeBranch(vtxoTree, domain.VtxoKey{Txid: vtxoTree[1][0].Txid})
+	require.Error(t, err)
+}
+
+func TestDeterministicRoundComposition(t *testing.T) {
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	requests := make([]domain.TxRequest, 0, 4)
+	for i := 0; i < 4; i++ {
+		request, err := domain.NewTxRequest([]domain.Vtxo{
+			{VtxoKey: domain.VtxoKey{Txid: fmt.Sprintf("%064x", 10-i), VOut: 1}, Amount: 1000},
+			{VtxoKey: domain.VtxoKey{Txid: fmt.Sprintf("%064x", 10-i), VOut: 0}, Amount: 1000},
+		})
+		require.NoError(t, err)
+		require.NoError(t, request.AddReceivers([]domain.Receiver{{Amount: 2000, PubKey: pubkey}}))
+		requests = append(requests, *request)
+	}
+
+	// the same requests registered in different orders, at the same time, must
+	// result in the same round composition
+	registeredAt := time.Now()
+	compose := func(order []int) ([]domain.TxRequest, tree.TxTree, *forfeitTxsMap) {
+		queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+		for _, i := range order {
+			require.NoError(t, queue.push(requests[i], nil, nil, nil))
+			queue.requests[requests[i].Id].timestamp = registeredAt
+		}
+		popped, _, _, _, _, _ := queue.pop(-1)
+
+		connectors := makeTestConnectorsTree(t, popped)
+		forfeitTxs := newForfeitTxsMap(nil, &mockedForfeitTxsRepo{})
+		require.NoError(t, forfeitTxs.init("round", connectors, popped, 0))
+		return popped, connectors, forfeitTxs
+	}
+
+	popped, connectors, forfeitTxs := compose([]int{0, 1, 2, 3})
+	otherPopped, otherConnectors, otherForfeitTxs := compose([]int{3, 1, 0, 2})
+	require.Equal(t, popped, otherPopped)
+	require.Len(t, otherConnectors, len(connectors))
+	for i, level := range connectors {
+		require.Len(t, otherConnectors[i], len(level))
+		for j, node := range level {
+			require.Equal(t, node.Tx, otherConnectors[i][j].Tx)
+		}
+	}
+	require.Equal(t, forfeitTxs.vtxos, otherForfeitTxs.vtxos)
+	require.Equal(t, forfeitTxs.connectorsIndex, otherForfeitTxs.connectorsIndex)
+
+	// the vtxos are forfeited in the same order used to assign the connectors
+	for i, vtxo := range forfeitTxs.vtxos {
+		connector := forfeitTxs.connectorsIndex[vtxo.String()]
+		require.Equal(t, connectors.Leaves()[i].Txid, connector.Txid)
+	}
+
+	requestsById := make(map[string]domain.TxRequest)
+	for _, request := range requests {
+		requestsById[request.Id] = request
+	}
+	spentVtxos := getSpentVtxos(requestsById)
+	require.Len(t, spentVtxos, 8)
+	for i, vtxo := range forfeitTxs.vtxos {
+		require.Equal(t, vtxo.VtxoKey, spentVtxos[i])
+	}
+}
+
+// BenchmarkFindSweepableOutputs measures the worst case of a 128-leaf vtxo
+// tree fully unrolled onchain except for the leaves, with a 1ms latency for
+// every confirmation check.
+func BenchmarkFindSweepableOutputs(b *testing.B) {
+	ctx := context.Background()
+	vtxoTree := makeTestVtxoTree(128)
+
+	confirmed := map[string]int64{vtxoTree[0][0].ParentTxid: 100}
+	for _, level := range vtxoTree[:len(vtxoTree)-1] {
+		for _, node := range level {
+			confirmed[node.Txid] = 101
+		}
+	}
+	wallet := &mockedWallet{confirmed: confirmed, latency: time.Millisecond}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		outputs, err := findSweepableOutputs(
+			ctx, wallet, &mockedTxBuilder{}, ports.BlockHeight, vtxoTree, 0,
+		)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(outputs[101+int64(testVtxoTreeExpiry.Value)]) != 128 {
+			b.Fatal("unexpected number of sweepable outputs")
+		}
+	}
+}
+
+// BenchmarkWaitForRegistration measures the latency of the registration stage
+// of a round with a single participant settling, with the default 5s stage of
+// a 30s round interval. This is the only stage affected by the single-party
+// fast mode, the rest of the settlement takes the same time in both modes.
+func BenchmarkWaitForRegistration(b *testing.B) {
+	duration := 5 * time.Second
+
+	for _, fastMode := range []bool{false, true} {
+		name := "normal"
+		if fastMode {
+			name = "single-party fast"
+		}
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				queue := newTxRequestsQueue(time.Minute, 5*time.Minute, 0)
+				go pushReadyTxRequest(b, queue, "aa")
+				queue.waitForRegistration(duration, fastMode)
+			}
+		})
+	}
+}
+
+func pushReadyTxRequest(t testing.TB, queue *txRequestsQueue, txid string) {
+	pubkey := "25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967"
+	vtxo := domain.Vtxo{VtxoKey: domain.VtxoKey{Txid: txid}, Amount: 1000}
+	request, err := domain.NewTxRequest([]domain.Vtxo{vtxo})
+	require.NoError(t, err)
+	require.NoError(t, queue.push(*request, nil, nil, nil))
+	require.NoError(t, request.AddReceivers([]domain.Receiver{{Amount: 1000, PubKey: pubkey}}))
+	require.NoError(t, queue.update(*request, nil))
+}
+
+var testVtxoTreeExpiry = common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+
+// makeTestVtxoTree returns a binary vtxo tree with the given number of leaves,
+// which must be a power of 2. Only the txids of the nodes are set.
+func makeTestVtxoTree(numOfLeaves int) tree.TxTree {
+	count := 0
+	newNode := func(parentTxid string, leaf bool) tree.Node {
+		count++
+		return tree.Node{
+			Txid:       fmt.Sprintf("%064x", count),
+			ParentTxid: parentTxid,
+			Leaf:       leaf,
+		}
+	}
+
+	vtxoTree := tree.TxTree{{newNode("roundtx", numOfLeaves == 1)}}
+	for len(vtxoTree[len(vtxoTree)-1]) < numOfLeaves {
+		parents := vtxoTree[len(vtxoTree)-1]
+		isLeaf := len(parents)*2 == numOfLeaves
+		level := make([]tree.Node, 0, len(parents)*2)
+		for _, parent := range parents {
+			level = append(level, newNode(parent.Txid, isLeaf), newNode(parent.Txid, isLeaf))
+		}
+		vtxoTree = append(vtxoTree, level)
+	}
+	return vtxoTree
+}
+
+// makeTestConnectorsTree builds the connectors tree of a round with the given
+// requests. The tree spends an outpoint committing to the inputs of the
+// requests in the given order, like the round tx it would be the output of.
+func makeTestConnectorsTree(t *testing.T, requests []domain.TxRequest) tree.TxTree {
+	buf, err := hex.DecodeString("25a43cecfa0e1b1a4f72d64ad15f4cfa7a84d0723e8511c969aa543638ea9967")
+	require.NoError(t, err)
+	key, err := schnorr.ParsePubKey(buf)
+	require.NoError(t, err)
+	pkScript, err := common.P2TRScript(key)
+	require.NoError(t, err)
+
+	inputs := make([]byte, 0)
+	leaves := make([]tree.Leaf, 0)
+	for _, request := range requests {
+		for _, vtxo := range request.Inputs {
+			inputs = append(inputs, []byte(vtxo.String())...)
+			leaves = append(leaves, tree.Leaf{
+				Amount: 330,
+				Script: hex.EncodeToString(pkScript),
+				Musig2Data: &tree.Musig2{
+					CosignersPublicKeys: []string{hex.EncodeToString(key.SerializeCompressed())},
+					SigningType:         tree.SignBranch,
+				},
+			})
+		}
+	}
+
+	connectors, err := tree.BuildConnectorsTree(
+		&wire.OutPoint{Hash: chainhash.HashH(inputs), Index: 1}, leaves, 100, 2,
+	)
+	require.NoError(t, err)
+	return connectors
+}
+
+type mockedWallet struct {
+	ports.WalletService
+	// confirmed maps the txids of the confirmed txs to their block height
+	confirmed map[string]int64
+	latency   time.Duration
+	balance   uint64
+
+	// inFlight and maxInFlight track the concurrent confirmation checks
+	lock        sync.Mutex
+	inFlight    int64
+	maxInFlight int64
+}
+
+func (m *mockedWallet) MainAccountBalance(context.Context) (uint64, uint64, error) {
+	return m.balance, 0, nil
+}
+
+func (m *mockedWallet) MinRelayFeeRate(context.Context) chainfee.SatPerKVByte {
+	return chainfee.FeePerKwFloor.FeePerKVByte()
+}
+
+func (m *mockedWallet) IsTransactionConfirmed(
+	_ context.Context, txid string,
+) (bool, int64, int64, error) {
+	m.lock.Lock()
+	m.inFlight++
+	m.maxInFlight = max(m.maxInFlight, m.inFlight)
+	m.lock.Unlock()
+	defer func() {
+		m.lock.Lock()
+		m.inFlight--
+		m.lock.Unlock()
+	}()
+
+	time.Sleep(m.latency)
+	height, ok := m.confirmed[txid]
+	if !ok {
+		return false, 0, 0, nil
+	}
+	return true, height, height * 600, nil
+}
+
+// VerifyMessageSignature accepts any non-empty signature.
+func (m *mockedWallet) VerifyMessageSignature(
+	_ context.Context, _, signature []byte,
+) (bool, error) {
+	return len(signature) > 0, nil
+}
+
+type mockedTxBuilder struct {
+	ports.TxBuilder
+}
+
+func (m *mockedTxBuilder) GetSweepInput(
+	node tree.Node,
+) (*common.RelativeLocktime, ports.SweepInput, error) {
+	return &testVtxoTreeExpiry, mockedSweepInput{txid: node.Txid}, nil
+}
+
+// VerifyForfeitTxs considers valid any forfeit tx, returned by the vtxo spent
+// by its first input.
+func (m *mockedTxBuilder) VerifyForfeitTxs(
+	_ []domain.Vtxo, _ tree.TxTree, txs []string, _ map[string]domain.Outpoint,
+	_ chainfee.SatPerKVByte,
+) (map[domain.VtxoKey]string, error) {
+	valid := make(map[domain.VtxoKey]string)
+	for _, tx := range txs {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(tx), true)
+		if err != nil {
+			return nil, err
+		}
+		prevout := ptx.UnsignedTx.TxIn[0].PreviousOutPoint
+		valid[domain.VtxoKey{Txid: prevout.Hash.String(), VOut: prevout.Index}] = tx
+	}
+	return valid, nil
+}
+
+type mockedSweepInput struct {
+	ports.SweepInput
+	txid string
+}
+
+func (m mockedSweepInput) GetHash() chainhash.Hash {
+	hash, _ := chainhash.NewHashFromStr(m.txid)
+	return *hash
+}
+
+func (m mockedSweepInput) GetIndex() uint32 {
+	return 0
+}
+
+func (m mockedSweepInput) GetAmount() uint64 {
+	return 1000
+}
+
+type mockedVtxoRepo struct {
+	domain.VtxoRepository
+	vtxos map[string]domain.Vtxo
+}
+
+func (m *mockedVtxoRepo) GetAll(context.Context) ([]domain.Vtxo, error) {
+	vtxos := make([]domain.Vtxo, 0, len(m.vtxos))
+	for _, vtxo := range m.vtxos {
+		vtxos = append(vtxos, vtxo)
+	}
+	return vtxos, nil
+}
+
+func (m *mockedVtxoRepo) GetVtxos(
+	_ context.Context, keys []domain.VtxoKey,
+) ([]domain.Vtxo, error) {
+	vtxos := make([]domain.Vtxo, 0, len(keys))
+	for _, key := range keys {
+		vtxo, ok := m.vtxos[key.String()]
+		if !ok {
+			return nil, fmt.Errorf("vtxo %s not found", key)
+		}
+		vtxos = append(vtxos, vtxo)
+	}
+	return vtxos, nil
+}
+
+type mockedForfeitTxsRepo struct {
+	domain.ForfeitTxsRepository
+	// rounds maps the ids of the rounds to their forfeit txs
+	rounds   map[string]map[domain.VtxoKey]string
+	feeRates map[string]int64
+}
+
+func (m *mockedForfeitTxsRepo) Init(
+	_ context.Context, roundId string, vtxos []domain.VtxoKey, feeRate int64,
+) error {
+	if m.rounds == nil {
+		m.rounds = make(map[string]map[domain.VtxoKey]string)
+		m.feeRates = make(map[string]int64)
+	}
+	if _, ok := m.rounds[roundId]; !ok {
+		m.rounds[roundId] = make(map[domain.VtxoKey]string)
+		m.feeRates[roundId] = feeRate
+	}
+	for _, vtxo := range vtxos {
+		if _, ok := m.rounds[roundId][vtxo]; !ok {
+			m.rounds[roundId][vtxo] = ""
+		}
+	}
+	return nil
+}
+
+func (m *mockedForfeitTxsRepo) Sign(
+	_ context.Context, roundId string, forfeitTxs map[domain.VtxoKey]string,
+) error {
+	for vtxo, tx := range forfeitTxs {
+		if _, ok := m.rounds[roundId][vtxo]; !ok {
+			return fmt.Errorf("vtxo %s not registered for round %s", vtxo, roundId)
+		}
+		m.rounds[roundId][vtxo] = tx
+	}
+	return nil
+}
+
+func (m *mockedForfeitTxsRepo) GetForfeitTxs(
+	_ context.Context, roundId string,
+) (map[domain.VtxoKey]string, error) {
+	forfeitTxs := make(map[domain.VtxoKey]string)
+	for vtxo, tx := range m.rounds[roundId] {
+		forfeitTxs[vtxo] = tx
+	}
+	return forfeitTxs, nil
+}
+
+func (m *mockedForfeitTxsRepo) GetFeeRate(
+	_ context.Context, roundId string,
+) (int64, error) {
+	return m.feeRates[roundId], nil
+}
+
+func (m *mockedForfeitTxsRepo) GetRoundIds(context.Context) ([]string, error) {
+	roundIds := make([]string, 0, len(m.rounds))
+	for roundId := range m.rounds {
+		roundIds = append(roundIds, roundId)
+	}
+	sort.Strings(roundIds)
+	return roundIds, nil
+}
+
+func (m *mockedForfeitTxsRepo) Delete(_ context.Context, roundId string) error {
+	delete(m.rounds, roundId)
+	delete(m.feeRates, roundId)
+	return nil
+}
+
+type mockedEventRepo struct {
+	domain.RoundEventRepository
+	rounds map[string]*domain.Round
+}
+
+func (m *mockedEventRepo) Load(_ context.Context, id string) (*domain.Round, error) {
+	round, ok := m.rounds[id]
+	if !ok {
+		return nil, fmt.Errorf("round %s not found", id)
+	}
+	return domain.NewRoundFromEvents(round.Events()), nil
+}
+
+func (m *mockedEventRepo) Save(
+	_ context.Context, id string, events ...domain.RoundEvent,
+) (*domain.Round, error) {
+	if round, ok := m.rounds[id]; ok {
+		events = append(round.Events(), events...)
+	}
+	m.rounds[id] = domain.NewRoundFromEvents(events)
+	return m.rounds[id], nil
+}
+
+type mockedRoundRepo struct {
+	domain.RoundRepository
+	rounds map[string]*domain.Round
+}
+
+func (m *mockedRoundRepo) GetRoundWithTxid(
+	_ context.Context, txid string,
+) (*domain.Round, error) {
+	round, ok := m.rounds[txid]
+	if !ok {
+		return nil, fmt.Errorf("round %s not found", txid)
+	}
+	return round, nil
+}
+
+func (m *mockedRoundRepo) GetExpiredRoundsTxid(context.Context) ([]string, error) {
+	txids := make([]string, 0)
+	for txid, round := range m.rounds {
+		if !round.Swept {
+			txids = append(txids, txid)
+		}
+	}
+	sort.Strings(txids)
+	return txids, nil
+}
+
+func (m *mockedRoundRepo) GetRoundWithId(
+	_ context.Context, id string,
+) (*domain.Round, error) {
+	for _, round := range m.rounds {
+		if round.Id == id {
+			return round, nil
+		}
+	}
+	return nil, fmt.Errorf("round %s not found", id)
+}
+
+func (m *mockedRoundRepo) AddOrUpdateRound(_ context.Context, round domain.Round) error {
+	m.rounds[round.Txid] = &round
+	return nil
+}
+
+func (m *mockedRoundRepo) GetRoundsIds(
+	_ context.Context, startedAfter, startedBefore int64,
+) ([]string, error) {
+	ids := make([]string, 0)
+	for _, round := range m.rounds {
+		if round.StartingTimestamp >= startedAfter && round.StartingTimestamp <= startedBefore {
+			ids = append(ids, round.Id)
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+type mockedNoteRepo struct {
+	domain.NoteRepository
+	// amounts maps the txids of the rounds to the value of the notes redeemed
+	amounts map[string]uint64
+	// expiries maps the ids of the redeemed notes to their expiry
+	expiries map[uint64]int64
+}
+
+func (m *mockedNoteRepo) GetAmountForRound(_ context.Context, roundTxid string) (uint64, error) {
+	return m.amounts[roundTxid], nil
+}
+
+func (m *mockedNoteRepo) Contains(_ context.Context, id uint64) (bool, error) {
+	_, ok := m.expiries[id]
+	return ok, nil
+}
+
+func (m *mockedNoteRepo) DeleteExpired(_ context.Context, before time.Time) (int64, error) {
+	count := int64(0)
+	for id, expiresAt := range m.expiries {
+		if expiresAt > 0 && expiresAt < before.Unix() {
+			delete(m.expiries, id)
+			count++
+		}
+	}
+	return count, nil
+}
+
+type mockedRepoManager struct {
+	ports.RepoManager
+	vtxos                 *mockedVtxoRepo
+	rounds                *mockedRoundRepo
+	notes                 *mockedNoteRepo
+	refreshAuthorizations *mockedRefreshAuthorizationRepo
+	forfeitTxs            *mockedForfeitTxsRepo
+	events                *mockedEventRepo
+}
+
+func (m *mockedRepoManager) Vtxos() domain.VtxoRepository {
+	return m.vtxos
+}
+
+func (m *mockedRepoManager) Rounds() domain.RoundRepository {
+	return m.rounds
+}
+
+func (m *mockedRepoManager) Notes() domain.NoteRepository {
+	return m.notes
+}
+
+func (m *mockedRepoManager) RefreshAuthorizations() domain.RefreshAuthorizationRepository {
+	return m.refreshAuthorizations
+}
+
+func (m *mockedRepoManager) ForfeitTxs() domain.ForfeitTxsRepository {
+	return m.forfeitTxs
+}
+
+func (m *mockedRepoManager) Events() domain.RoundEventRepository {
+	return m.events
+}
@@ -1,9 +1,38 @@
 package domain
 
-import "context"
+import (
+	"context"
+	"time"
+)
+
+// Note is a note redeemed in a round.
+type Note struct {
+	ID    uint64
+	Value uint64
+	// ExpiresAt is the unix timestamp after which the note can't be redeemed,
+	// 0 if the note never expires.
+	ExpiresAt int64
+}
 
 type NoteRepository interface {
 	Contains(ctx context.Context, id uint64) (bool, error)
-	Add(ctx context.Context, id uint64) error
+	// Add marks the note with the given id and value as redeemed in the round
+	// with the given txid. The expiry is the unix timestamp after which the
+	// note can't be redeemed, 0 if the note never expires.
+	Add(
+		ctx context.Context, id uint64, value uint64, roundTxid string,
+		expiresAt int64,
+	) error
+	// AddMany atomically marks the given notes as redeemed in the round with
+	// the given txid. Unlike Add, the notes already redeemed are skipped
+	// rather than failing the whole batch, the returned value is the number of
+	// notes newly inserted.
+	AddMany(ctx context.Context, roundTxid string, notes []Note) (int, error)
+	// GetAmountForRound returns the total value of the notes redeemed in the
+	// round with the given txid.
+	GetAmountForRound(ctx context.Context, roundTxid string) (uint64, error)
+	// DeleteExpired removes the notes that expired before the given time and
+	// returns how many were deleted. Notes without expiry are never removed.
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
 	Close()
 }
@@ -0,0 +1,34 @@
+package domain
+
+import "context"
+
+// RefreshAuthorization is the permission granted by the owner of a vtxo to
+// the server to include it in a round on its behalf when close to expiry, so
+// that it's not swept while the owner is offline.
+type RefreshAuthorization struct {
+	VtxoKey
+	// Intent is the BIP322 signature of the tx request registered for the
+	// refresh, and Message is the signed intent message.
+	Intent  string
+	Message string
+	// ForfeitTx is the forfeit tx of the vtxo signed by the owner with
+	// SIGHASH_ALL|ANYONECANPAY, so that it stays valid once the connector
+	// input of the round is set.
+	ForfeitTx string
+	CreatedAt int64
+	// RevokedAt is set when the owner revokes the authorization. The forfeit
+	// tx isn't bound to any round, so it's valid as long as the vtxo is
+	// unspent: a revoked authorization is used to refresh the vtxo right away
+	// and is dropped once the vtxo is spent.
+	RevokedAt int64
+}
+
+type RefreshAuthorizationRepository interface {
+	// Add stores the given authorization, replacing any other one for the same
+	// vtxo.
+	Add(ctx context.Context, authorization RefreshAuthorization) error
+	Get(ctx context.Context, vtxo VtxoKey) (*RefreshAuthorization, error)
+	GetAll(ctx context.Context) ([]RefreshAuthorization, error)
+	Delete(ctx context.Context, vtxos []VtxoKey) error
+	Close()
+}
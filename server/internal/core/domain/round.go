@@ -257,6 +257,55 @@ func (r *Round) TotalOutputAmount() uint64 {
 	return tot
 }
 
+// NumOfParticipants returns the number of distinct participants of the round,
+// ie. the size of its anonymity set.
+// Tx requests spending vtxos owned by the same key are counted as a single
+// participant, while requests without vtxo inputs (ie. boarding or notes) are
+// counted one each.
+func (r *Round) NumOfParticipants() int {
+	parents := make(map[string]string)
+	var find func(id string) string
+	find = func(id string) string {
+		if parents[id] == id {
+			return id
+		}
+		root := find(parents[id])
+		parents[id] = root
+		return root
+	}
+
+	owners := make(map[string]string)
+	for id, request := range r.TxRequests {
+		parents[id] = id
+		for _, vtxo := range request.Inputs {
+			other, ok := owners[vtxo.PubKey]
+			if !ok {
+				owners[vtxo.PubKey] = id
+				continue
+			}
+			parents[find(id)] = find(other)
+		}
+	}
+
+	count := 0
+	for id := range parents {
+		if find(id) == id {
+			count++
+		}
+	}
+	return count
+}
+
+// NumOfOutputs returns the number of vtxos and onchain outputs created by the
+// round.
+func (r *Round) NumOfOutputs() int {
+	count := 0
+	for _, request := range r.TxRequests {
+		count += len(request.Receivers)
+	}
+	return count
+}
+
 func (r *Round) Sweep() {
 	r.Swept = true
 }
@@ -23,6 +23,12 @@ type RoundRepository interface {
 	GetVtxoTreeWithTxid(ctx context.Context, txid string) (tree.TxTree, error)
 	GetExpiredRoundsTxid(ctx context.Context) ([]string, error)
 	GetRoundsIds(ctx context.Context, startedAfter int64, startedBefore int64) ([]string, error)
+	// GetRoundSummaries returns at most limit finalized rounds, sorted by
+	// ending time and txid, that follow the round with the given ending time
+	// and txid. An empty txid selects the rounds ended since endedAfter.
+	GetRoundSummaries(
+		ctx context.Context, endedAfter int64, afterTxid string, limit int,
+	) ([]RoundSummary, error)
 	GetSweptRoundsConnectorAddress(ctx context.Context) ([]string, error)
 	GetTxsWithTxids(ctx context.Context, txids []string) ([]string, error)
 	GetExistingRounds(ctx context.Context, txids []string) (map[string]any, error)
@@ -32,11 +38,15 @@ type RoundRepository interface {
 type VtxoRepository interface {
 	AddVtxos(ctx context.Context, vtxos []Vtxo) error
 	SpendVtxos(ctx context.Context, vtxos []VtxoKey, txid string) error
+	// ApplyVtxoStateChange marks the spent vtxos as spent by the given txid and
+	// adds the created ones atomically, either both or none are persisted.
+	ApplyVtxoStateChange(ctx context.Context, spent []VtxoKey, spentBy string, created []Vtxo) error
 	RedeemVtxos(ctx context.Context, vtxos []VtxoKey) error
 	GetVtxos(ctx context.Context, vtxos []VtxoKey) ([]Vtxo, error)
 	GetVtxosForRound(ctx context.Context, txid string) ([]Vtxo, error)
 	SweepVtxos(ctx context.Context, vtxos []VtxoKey) error
 	GetAllNonRedeemedVtxos(ctx context.Context, pubkey string) ([]Vtxo, []Vtxo, error)
+	GetAllNonRedeemedVtxosWithPubKeys(ctx context.Context, pubkeys []string) ([]Vtxo, []Vtxo, error)
 	GetAllSweepableVtxos(ctx context.Context) ([]Vtxo, error)
 	GetSpendableVtxosWithPubKey(ctx context.Context, pubkey string) ([]Vtxo, error)
 	GetAll(ctx context.Context) ([]Vtxo, error)
@@ -47,6 +57,31 @@ type VtxoRepository interface {
 	Close()
 }
 
+// ForfeitTxsRepository persists the forfeit txs collected during the ongoing
+// round, keyed by round and vtxo, so that they can be restored after a restart
+// instead of asking the clients to sign them again.
+type ForfeitTxsRepository interface {
+	// Init registers the vtxos of the given round that must be forfeited at
+	// the given fee rate, in sats/kvbyte. The forfeit txs already collected
+	// for the same round are preserved, along with their fee rate, as well as
+	// those of any other round in flight.
+	Init(ctx context.Context, roundId string, vtxos []VtxoKey, feeRate int64) error
+	Sign(ctx context.Context, roundId string, forfeitTxs map[VtxoKey]string) error
+	// GetForfeitTxs returns the forfeit tx of every registered vtxo of the
+	// round, empty if not signed yet.
+	GetForfeitTxs(ctx context.Context, roundId string) (map[VtxoKey]string, error)
+	// GetFeeRate returns the fee rate the vtxos of the round were registered
+	// with, 0 if there are none.
+	GetFeeRate(ctx context.Context, roundId string) (int64, error)
+	// GetRoundIds returns the ids of the rounds with registered vtxos.
+	GetRoundIds(ctx context.Context) ([]string, error)
+	// Delete drops the forfeit txs of the given round.
+	Delete(ctx context.Context, roundId string) error
+	// Reset drops the forfeit txs of all rounds.
+	Reset(ctx context.Context) error
+	Close()
+}
+
 type MarketHourRepo interface {
 	Get(ctx context.Context) (*MarketHour, error)
 	Upsert(ctx context.Context, marketHour MarketHour) error
@@ -63,3 +98,14 @@ type RoundStats struct {
 	Started            int64
 	Ended              int64
 }
+
+// RoundSummary is the digest of a finalized round.
+type RoundSummary struct {
+	Txid             string
+	StartedAt        int64
+	EndedAt          int64
+	TotalInputVtxos  int
+	TotalOutputVtxos int
+	// TotalAmount is the amount of the vtxos created by the round.
+	TotalAmount uint64
+}
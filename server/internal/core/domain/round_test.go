@@ -149,6 +149,8 @@ func TestRound(t *testing.T) {
 
 	testRegisterTxRequests(t)
 
+	testNumOfParticipants(t)
+
 	testStartFinalization(t)
 
 	testEndFinalization(t)
@@ -245,6 +247,14 @@ func testRegisterTxRequests(t *testing.T) {
 				return true
 			})
 
+			numOfOutputs := 0
+			for _, request := range requests {
+				numOfOutputs += len(request.Receivers)
+			}
+			// All the registered requests spend vtxos owned by the same key.
+			require.Equal(t, 1, round.NumOfParticipants())
+			require.Equal(t, numOfOutputs, round.NumOfOutputs())
+
 			event, ok := events[0].(domain.TxRequestsRegistered)
 			require.True(t, ok)
 			require.Equal(t, round.Id, event.Id)
@@ -307,6 +317,80 @@ func testRegisterTxRequests(t *testing.T) {
 	})
 }
 
+func testNumOfParticipants(t *testing.T) {
+	t.Run("num_of_participants", func(t *testing.T) {
+		request := func(id string, owners ...string) domain.TxRequest {
+			inputs := make([]domain.Vtxo, 0, len(owners))
+			for i, owner := range owners {
+				inputs = append(inputs, domain.Vtxo{
+					VtxoKey: domain.VtxoKey{Txid: txid, VOut: uint32(i)},
+					PubKey:  owner,
+					Amount:  1000,
+				})
+			}
+			return domain.TxRequest{
+				Id:        id,
+				Inputs:    inputs,
+				Receivers: []domain.Receiver{{PubKey: pubkey, Amount: 1000}},
+			}
+		}
+
+		fixtures := []struct {
+			name                      string
+			requests                  []domain.TxRequest
+			expectedNumOfParticipants int
+		}{
+			{
+				name:                      "no requests",
+				expectedNumOfParticipants: 0,
+			},
+			{
+				name: "distinct owners",
+				requests: []domain.TxRequest{
+					request("0", "a"), request("1", "b"), request("2", "c"),
+				},
+				expectedNumOfParticipants: 3,
+			},
+			{
+				name: "shared owner",
+				requests: []domain.TxRequest{
+					request("0", "a"), request("1", "a"), request("2", "b"),
+				},
+				expectedNumOfParticipants: 2,
+			},
+			{
+				name: "owners linked by a request",
+				requests: []domain.TxRequest{
+					request("0", "a"), request("1", "b"), request("2", "a", "b"),
+					request("3", "c"),
+				},
+				expectedNumOfParticipants: 2,
+			},
+			{
+				name: "requests without vtxo inputs",
+				requests: []domain.TxRequest{
+					request("0"), request("1"), request("2", "a"),
+				},
+				expectedNumOfParticipants: 3,
+			},
+		}
+
+		for _, f := range fixtures {
+			t.Run(f.name, func(t *testing.T) {
+				round := domain.NewRound(dustAmount)
+				_, err := round.StartRegistration()
+				require.NoError(t, err)
+
+				if len(f.requests) > 0 {
+					_, err = round.RegisterTxRequests(f.requests)
+					require.NoError(t, err)
+				}
+				require.Equal(t, f.expectedNumOfParticipants, round.NumOfParticipants())
+			})
+		}
+	})
+}
+
 func testStartFinalization(t *testing.T) {
 	t.Run("start_finalization", func(t *testing.T) {
 		t.Run("valid", func(t *testing.T) {
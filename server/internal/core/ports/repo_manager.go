@@ -8,6 +8,8 @@ type RepoManager interface {
 	Vtxos() domain.VtxoRepository
 	Notes() domain.NoteRepository
 	MarketHourRepo() domain.MarketHourRepo
+	ForfeitTxs() domain.ForfeitTxsRepository
+	RefreshAuthorizations() domain.RefreshAuthorizationRepository
 	RegisterEventsHandler(func(*domain.Round))
 	Close()
 }
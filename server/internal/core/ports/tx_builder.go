@@ -6,6 +6,7 @@ import (
 	"github.com/ark-network/ark/server/internal/core/domain"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
 type SweepInput interface {
@@ -27,6 +28,35 @@ type BoardingInput struct {
 	Amount uint64
 }
 
+// RoundTxEstimation is the projected onchain footprint of a round.
+type RoundTxEstimation struct {
+	RoundTxVSize uint64
+	RoundTxFee   uint64
+	// ConnectorTxsVSize and ConnectorTxsFee refer to all the txs of the
+	// connectors tree, whose fees are locked in the connector output.
+	ConnectorTxsVSize uint64
+	ConnectorTxsFee   uint64
+	// VtxoTreeFee is the amount locked in the shared output to pay for the
+	// fees of the vtxo tree txs.
+	VtxoTreeFee uint64
+	// TotalOutputAmount is the sum of the outputs of the round tx.
+	TotalOutputAmount uint64
+}
+
+// TotalFee returns the amount of fees to be funded for the round.
+func (e RoundTxEstimation) TotalFee() uint64 {
+	return e.RoundTxFee + e.ConnectorTxsFee + e.VtxoTreeFee
+}
+
+// ConnectorTreeParams is the shape of the connectors tree of a round.
+type ConnectorTreeParams struct {
+	// NumOfLeaves is the number of connector outputs of the tree, at least one
+	// per vtxo to forfeit.
+	NumOfLeaves int
+	// Radix is the max number of outputs of every connector tx.
+	Radix int
+}
+
 type TxBuilder interface {
 	// BuildRoundTx builds a round tx for the given offchain and boarding tx
 	// requests. It expects an optional list of connector addresses of expired
@@ -44,11 +74,25 @@ type TxBuilder interface {
 		connectors tree.TxTree,
 		err error,
 	)
+	// EstimateRoundTx returns the projected sizes and fees of the round tx and
+	// of the connectors tree that BuildRoundTx would create for the given tx
+	// requests. Unlike BuildRoundTx, it doesn't derive any address nor select
+	// and lock any utxo of the wallet.
+	EstimateRoundTx(
+		serverPubkey *secp256k1.PublicKey, txRequests []domain.TxRequest,
+		boardingInputs []BoardingInput, musig2Data []*tree.Musig2,
+	) (*RoundTxEstimation, error)
+	// ConnectorTreeParams returns the shape of the connectors tree for a round
+	// forfeiting the given number of vtxos. The radix is chosen to minimize the
+	// overall size of the connector txs.
+	ConnectorTreeParams(numOfVtxos int) ConnectorTreeParams
 	// VerifyForfeitTxs verifies a list of forfeit txs against a set of VTXOs and
-	// connectors.
+	// connectors. The forfeit txs must pay the fee for the given fee rate, or
+	// for the min relay one if higher.
 	VerifyForfeitTxs(
 		vtxos []domain.Vtxo, connectors tree.TxTree, txs []string,
 		connectorIndex map[string]domain.Outpoint,
+		forfeitFeeRate chainfee.SatPerKVByte,
 	) (valid map[domain.VtxoKey]string, err error)
 	BuildSweepTx(inputs []SweepInput) (txid string, signedSweepTx string, err error)
 	GetSweepInput(node tree.Node) (vtxoTreeExpiry *common.RelativeLocktime, sweepInput SweepInput, err error)
@@ -0,0 +1,161 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const forfeitTxsStoreDir = "forfeit_txs"
+
+type forfeitTxsRepository struct {
+	store *badgerhold.Store
+}
+
+type pendingForfeitTx struct {
+	RoundId  string
+	VtxoTxid string
+	VtxoVout uint32
+	Tx       string
+	FeeRate  int64
+}
+
+func NewForfeitTxsRepository(config ...interface{}) (domain.ForfeitTxsRepository, error) {
+	if len(config) != 2 {
+		return nil, fmt.Errorf("invalid config")
+	}
+	baseDir, ok := config[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid base directory")
+	}
+	var logger badger.Logger
+	if config[1] != nil {
+		logger, ok = config[1].(badger.Logger)
+		if !ok {
+			return nil, fmt.Errorf("invalid logger")
+		}
+	}
+
+	var dir string
+	if len(baseDir) > 0 {
+		dir = filepath.Join(baseDir, forfeitTxsStoreDir)
+	}
+	store, err := createDB(dir, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open forfeit txs store: %s", err)
+	}
+
+	return &forfeitTxsRepository{store}, nil
+}
+
+func (r *forfeitTxsRepository) Init(
+	ctx context.Context, roundId string, vtxos []domain.VtxoKey, feeRate int64,
+) error {
+	for _, vtxo := range vtxos {
+		forfeit := pendingForfeitTx{
+			RoundId:  roundId,
+			VtxoTxid: vtxo.Txid,
+			VtxoVout: vtxo.VOut,
+			FeeRate:  feeRate,
+		}
+		if err := r.store.Insert(forfeitTxKey(roundId, vtxo), forfeit); err != nil {
+			if errors.Is(err, badgerhold.ErrKeyExists) {
+				continue
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *forfeitTxsRepository) Sign(
+	ctx context.Context, roundId string, forfeitTxs map[domain.VtxoKey]string,
+) error {
+	for vtxo, tx := range forfeitTxs {
+		key := forfeitTxKey(roundId, vtxo)
+		var forfeit pendingForfeitTx
+		if err := r.store.Get(key, &forfeit); err != nil {
+			if errors.Is(err, badgerhold.ErrNotFound) {
+				return fmt.Errorf("vtxo %s not registered for round %s", vtxo, roundId)
+			}
+			return err
+		}
+		forfeit.Tx = tx
+		if err := r.store.Update(key, forfeit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *forfeitTxsRepository) GetForfeitTxs(
+	ctx context.Context, roundId string,
+) (map[domain.VtxoKey]string, error) {
+	var forfeits []pendingForfeitTx
+	query := badgerhold.Where("RoundId").Eq(roundId)
+	if err := r.store.Find(&forfeits, query); err != nil {
+		return nil, err
+	}
+
+	forfeitTxs := make(map[domain.VtxoKey]string, len(forfeits))
+	for _, forfeit := range forfeits {
+		vtxo := domain.VtxoKey{Txid: forfeit.VtxoTxid, VOut: forfeit.VtxoVout}
+		forfeitTxs[vtxo] = forfeit.Tx
+	}
+	return forfeitTxs, nil
+}
+
+func (r *forfeitTxsRepository) GetFeeRate(
+	ctx context.Context, roundId string,
+) (int64, error) {
+	var forfeits []pendingForfeitTx
+	query := badgerhold.Where("RoundId").Eq(roundId).Limit(1)
+	if err := r.store.Find(&forfeits, query); err != nil {
+		return 0, err
+	}
+	if len(forfeits) <= 0 {
+		return 0, nil
+	}
+	return forfeits[0].FeeRate, nil
+}
+
+func (r *forfeitTxsRepository) GetRoundIds(ctx context.Context) ([]string, error) {
+	var forfeits []pendingForfeitTx
+	if err := r.store.Find(&forfeits, nil); err != nil {
+		return nil, err
+	}
+
+	roundIds := make([]string, 0)
+	seen := make(map[string]struct{})
+	for _, forfeit := range forfeits {
+		if _, ok := seen[forfeit.RoundId]; ok {
+			continue
+		}
+		seen[forfeit.RoundId] = struct{}{}
+		roundIds = append(roundIds, forfeit.RoundId)
+	}
+	return roundIds, nil
+}
+
+func (r *forfeitTxsRepository) Delete(ctx context.Context, roundId string) error {
+	query := badgerhold.Where("RoundId").Eq(roundId)
+	return r.store.DeleteMatching(&pendingForfeitTx{}, query)
+}
+
+func (r *forfeitTxsRepository) Reset(ctx context.Context) error {
+	return r.store.DeleteMatching(&pendingForfeitTx{}, nil)
+}
+
+func (r *forfeitTxsRepository) Close() {
+	// nolint:all
+	r.store.Close()
+}
+
+func forfeitTxKey(roundId string, vtxo domain.VtxoKey) string {
+	return fmt.Sprintf("%s:%s", roundId, vtxo)
+}
@@ -21,7 +21,10 @@ type noteRepository struct {
 }
 
 type note struct {
-	ID uint64
+	ID        uint64
+	Value     uint64
+	RoundTxid string
+	ExpiresAt int64
 }
 
 func NewNoteRepository(config ...interface{}) (domain.NoteRepository, error) {
@@ -59,16 +62,22 @@ func (n *noteRepository) Close() {
 	n.store.Close()
 }
 
-func (n *noteRepository) Add(ctx context.Context, id uint64) error {
+func (n *noteRepository) Add(
+	ctx context.Context, id uint64, value uint64, roundTxid string,
+	expiresAt int64,
+) error {
 	n.lock.Lock()
 	defer n.lock.Unlock()
 
-	if err := n.store.Insert(id, note{ID: id}); err != nil {
+	data := note{
+		ID: id, Value: value, RoundTxid: roundTxid, ExpiresAt: expiresAt,
+	}
+	if err := n.store.Insert(id, data); err != nil {
 		if errors.Is(err, badger.ErrConflict) {
 			attempts := 1
 			for errors.Is(err, badger.ErrConflict) && attempts <= maxRetries {
 				time.Sleep(100 * time.Millisecond)
-				err = n.store.Insert(id, note{ID: id})
+				err = n.store.Insert(id, data)
 				attempts++
 			}
 		}
@@ -77,6 +86,53 @@ func (n *noteRepository) Add(ctx context.Context, id uint64) error {
 	return nil
 }
 
+// AddMany inserts all the notes in a single badger transaction, the ones
+// already present are ignored.
+func (n *noteRepository) AddMany(
+	ctx context.Context, roundTxid string, notes []domain.Note,
+) (int, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	var count int
+	addNotes := func(tx *badger.Txn) error {
+		// reset in case the tx is retried
+		count = 0
+		for _, nt := range notes {
+			var v note
+			err := n.store.TxGet(tx, nt.ID, &v)
+			if err == nil {
+				continue
+			}
+			if !errors.Is(err, badgerhold.ErrNotFound) {
+				return err
+			}
+
+			data := note{
+				ID: nt.ID, Value: nt.Value, RoundTxid: roundTxid,
+				ExpiresAt: nt.ExpiresAt,
+			}
+			if err := n.store.TxInsert(tx, nt.ID, data); err != nil {
+				return err
+			}
+			count++
+		}
+		return nil
+	}
+
+	err := n.store.Badger().Update(addNotes)
+	attempts := 1
+	for errors.Is(err, badger.ErrConflict) && attempts <= maxRetries {
+		time.Sleep(100 * time.Millisecond)
+		err = n.store.Badger().Update(addNotes)
+		attempts++
+	}
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (n *noteRepository) Contains(ctx context.Context, id uint64) (bool, error) {
 	n.lock.Lock()
 	defer n.lock.Unlock()
@@ -91,3 +147,43 @@ func (n *noteRepository) Contains(ctx context.Context, id uint64) (bool, error)
 	}
 	return true, nil
 }
+
+func (n *noteRepository) GetAmountForRound(
+	ctx context.Context, roundTxid string,
+) (uint64, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	var notes []note
+	query := badgerhold.Where("RoundTxid").Eq(roundTxid)
+	if err := n.store.Find(&notes, query); err != nil {
+		return 0, err
+	}
+
+	amount := uint64(0)
+	for _, note := range notes {
+		amount += note.Value
+	}
+	return amount, nil
+}
+
+func (n *noteRepository) DeleteExpired(
+	ctx context.Context, before time.Time,
+) (int64, error) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	query := badgerhold.Where("ExpiresAt").Gt(int64(0)).
+		And("ExpiresAt").Lt(before.Unix())
+	count, err := n.store.Count(&note{}, query)
+	if err != nil {
+		return 0, err
+	}
+	if count == 0 {
+		return 0, nil
+	}
+	if err := n.store.DeleteMatching(&note{}, query); err != nil {
+		return 0, err
+	}
+	return int64(count), nil
+}
@@ -0,0 +1,95 @@
+package badgerdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/dgraph-io/badger/v4"
+	"github.com/timshannon/badgerhold/v4"
+)
+
+const refreshAuthorizationStoreDir = "refresh_authorizations"
+
+type refreshAuthorizationRepository struct {
+	store *badgerhold.Store
+}
+
+func NewRefreshAuthorizationRepository(
+	config ...interface{},
+) (domain.RefreshAuthorizationRepository, error) {
+	if len(config) != 2 {
+		return nil, fmt.Errorf("invalid config")
+	}
+	baseDir, ok := config[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("invalid base directory")
+	}
+	var logger badger.Logger
+	if config[1] != nil {
+		logger, ok = config[1].(badger.Logger)
+		if !ok {
+			return nil, fmt.Errorf("invalid logger")
+		}
+	}
+
+	var dir string
+	if len(baseDir) > 0 {
+		dir = filepath.Join(baseDir, refreshAuthorizationStoreDir)
+	}
+	store, err := createDB(dir, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open refresh authorization store: %s", err)
+	}
+
+	return &refreshAuthorizationRepository{store}, nil
+}
+
+func (r *refreshAuthorizationRepository) Add(
+	ctx context.Context, authorization domain.RefreshAuthorization,
+) error {
+	return r.store.Upsert(authorization.VtxoKey.String(), authorization)
+}
+
+func (r *refreshAuthorizationRepository) Get(
+	ctx context.Context, vtxo domain.VtxoKey,
+) (*domain.RefreshAuthorization, error) {
+	var authorization domain.RefreshAuthorization
+	if err := r.store.Get(vtxo.String(), &authorization); err != nil {
+		if errors.Is(err, badgerhold.ErrNotFound) {
+			return nil, fmt.Errorf("refresh authorization for vtxo %s not found", vtxo)
+		}
+		return nil, err
+	}
+	return &authorization, nil
+}
+
+func (r *refreshAuthorizationRepository) GetAll(
+	ctx context.Context,
+) ([]domain.RefreshAuthorization, error) {
+	var authorizations []domain.RefreshAuthorization
+	if err := r.store.Find(&authorizations, nil); err != nil {
+		return nil, err
+	}
+	return authorizations, nil
+}
+
+func (r *refreshAuthorizationRepository) Delete(
+	ctx context.Context, vtxos []domain.VtxoKey,
+) error {
+	for _, vtxo := range vtxos {
+		if err := r.store.Delete(
+			vtxo.String(), domain.RefreshAuthorization{},
+		); err != nil && !errors.Is(err, badgerhold.ErrNotFound) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *refreshAuthorizationRepository) Close() {
+	// nolint:all
+	r.store.Close()
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/ark-network/ark/common/tree"
@@ -160,6 +161,54 @@ func (r *roundRepository) GetRoundsIds(ctx context.Context, startedAfter int64,
 	return ids, nil
 }
 
+func (r *roundRepository) GetRoundSummaries(
+	ctx context.Context, endedAfter int64, afterTxid string, limit int,
+) ([]domain.RoundSummary, error) {
+	query := badgerhold.Where("Stage.Code").Eq(domain.FinalizationStage).
+		And("Stage.Ended").Eq(true).And("Stage.Failed").Eq(false).
+		And("EndingTimestamp").Ge(endedAfter)
+	rounds, err := r.findRound(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]domain.RoundSummary, 0, len(rounds))
+	for _, round := range rounds {
+		if round.EndingTimestamp == endedAfter && round.Txid <= afterTxid {
+			continue
+		}
+
+		summary := domain.RoundSummary{
+			Txid:      round.Txid,
+			StartedAt: round.StartingTimestamp,
+			EndedAt:   round.EndingTimestamp,
+		}
+		if len(round.VtxoTree) > 0 {
+			summary.TotalOutputVtxos = len(round.VtxoTree.Leaves())
+		}
+		for _, request := range round.TxRequests {
+			summary.TotalInputVtxos += len(request.Inputs)
+			for _, receiver := range request.Receivers {
+				if !receiver.IsOnchain() {
+					summary.TotalAmount += receiver.Amount
+				}
+			}
+		}
+		summaries = append(summaries, summary)
+	}
+
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].EndedAt != summaries[j].EndedAt {
+			return summaries[i].EndedAt < summaries[j].EndedAt
+		}
+		return summaries[i].Txid < summaries[j].Txid
+	})
+	if len(summaries) > limit {
+		summaries = summaries[:limit]
+	}
+	return summaries, nil
+}
+
 func (r *roundRepository) GetVtxoTreeWithTxid(
 	ctx context.Context, txid string,
 ) (tree.TxTree, error) {
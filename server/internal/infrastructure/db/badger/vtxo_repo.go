@@ -70,6 +70,31 @@ func (r *vtxoRepository) SpendVtxos(
 	return nil
 }
 
+func (r *vtxoRepository) ApplyVtxoStateChange(
+	ctx context.Context, spent []domain.VtxoKey, spentBy string, created []domain.Vtxo,
+) error {
+	return r.withTx(ctx, func(ctx context.Context) error {
+		for _, vtxoKey := range spent {
+			if err := r.spendVtxo(ctx, vtxoKey, spentBy); err != nil {
+				return err
+			}
+		}
+		for _, vtxo := range created {
+			existing, err := r.getVtxo(ctx, vtxo.VtxoKey)
+			if err != nil {
+				if strings.Contains(err.Error(), "not found") {
+					continue
+				}
+				return err
+			}
+			if existing.Spent {
+				return fmt.Errorf("vtxo %s:%d already spent", vtxo.Txid, vtxo.VOut)
+			}
+		}
+		return r.addVtxos(ctx, created)
+	})
+}
+
 func (r *vtxoRepository) RedeemVtxos(
 	ctx context.Context, vtxoKeys []domain.VtxoKey,
 ) error {
@@ -134,6 +159,37 @@ func (r *vtxoRepository) GetAllNonRedeemedVtxos(
 	return unspentVtxos, spentVtxos, nil
 }
 
+// GetAllNonRedeemedVtxosWithPubKeys fetches the vtxos of all the given pubkeys
+// within a single read so that the result is a consistent snapshot of the db.
+func (r *vtxoRepository) GetAllNonRedeemedVtxosWithPubKeys(
+	ctx context.Context, pubkeys []string,
+) ([]domain.Vtxo, []domain.Vtxo, error) {
+	if len(pubkeys) <= 0 {
+		return []domain.Vtxo{}, []domain.Vtxo{}, nil
+	}
+
+	var keys []interface{}
+	for _, pubkey := range pubkeys {
+		keys = append(keys, pubkey)
+	}
+	query := badgerhold.Where("Redeemed").Eq(false).And("PubKey").In(keys...)
+	vtxos, err := r.findVtxos(ctx, query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	spentVtxos := make([]domain.Vtxo, 0, len(vtxos))
+	unspentVtxos := make([]domain.Vtxo, 0, len(vtxos))
+	for _, vtxo := range vtxos {
+		if vtxo.Spent || vtxo.Swept {
+			spentVtxos = append(spentVtxos, vtxo)
+		} else {
+			unspentVtxos = append(unspentVtxos, vtxo)
+		}
+	}
+	return unspentVtxos, spentVtxos, nil
+}
+
 func (r *vtxoRepository) GetAllSweepableVtxos(ctx context.Context) ([]domain.Vtxo, error) {
 	query := badgerhold.Where("Redeemed").Eq(false).And("Swept").Eq(false)
 	return r.findVtxos(ctx, query)
@@ -256,6 +312,39 @@ func (r *vtxoRepository) Close() {
 	r.store.Close()
 }
 
+// withTx runs txBody with a context carrying a new read-write transaction
+// that is committed only if txBody succeeds, otherwise every change is
+// discarded.
+func (r *vtxoRepository) withTx(
+	ctx context.Context, txBody func(ctx context.Context) error,
+) error {
+	var err error
+
+	for range maxRetries {
+		err = func() error {
+			tx := r.store.Badger().NewTransaction(true)
+			defer tx.Discard()
+
+			// nolint:all
+			if err := txBody(context.WithValue(ctx, "tx", tx)); err != nil {
+				return err
+			}
+			return tx.Commit()
+		}()
+		if err == nil {
+			return nil
+		}
+
+		if errors.Is(err, badger.ErrConflict) {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		return err
+	}
+
+	return err
+}
+
 func (r *vtxoRepository) addVtxos(
 	ctx context.Context, vtxos []domain.Vtxo,
 ) error {
@@ -0,0 +1,58 @@
+package badgerdb
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyVtxoStateChange(t *testing.T) {
+	repo, err := NewVtxoRepository("", nil)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ctx := context.Background()
+	spent := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: strings.Repeat("aa", 32), VOut: 0},
+		Amount:  1000,
+	}
+	created := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: strings.Repeat("bb", 32), VOut: 0},
+		Amount:  1000,
+	}
+	require.NoError(t, repo.AddVtxos(ctx, []domain.Vtxo{spent}))
+
+	t.Run("rollback", func(t *testing.T) {
+		// the vtxo is spent but re-creating it fails before committing
+		err := repo.ApplyVtxoStateChange(
+			ctx, []domain.VtxoKey{spent.VtxoKey}, "txid", []domain.Vtxo{created, spent},
+		)
+		require.EqualError(t, err, fmt.Sprintf("vtxo %s:0 already spent", spent.Txid))
+
+		vtxos, err := repo.GetVtxos(ctx, []domain.VtxoKey{spent.VtxoKey})
+		require.NoError(t, err)
+		require.Len(t, vtxos, 1)
+		require.False(t, vtxos[0].Spent)
+		require.Empty(t, vtxos[0].SpentBy)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		err := repo.ApplyVtxoStateChange(
+			ctx, []domain.VtxoKey{spent.VtxoKey}, "txid", []domain.Vtxo{created},
+		)
+		require.NoError(t, err)
+
+		vtxos, err := repo.GetVtxos(
+			ctx, []domain.VtxoKey{spent.VtxoKey, created.VtxoKey},
+		)
+		require.NoError(t, err)
+		require.Len(t, vtxos, 2)
+		require.True(t, vtxos[0].Spent)
+		require.Equal(t, "txid", vtxos[0].SpentBy)
+		require.Equal(t, created, vtxos[1])
+	})
+}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_note_round_txid;
+
+DROP TABLE IF EXISTS note;
@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS note (
+    id BIGINT PRIMARY KEY,
+    value BIGINT NOT NULL DEFAULT 0,
+    round_txid TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_note_round_txid ON note(round_txid);
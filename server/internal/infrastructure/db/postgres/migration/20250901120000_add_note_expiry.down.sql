@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_note_expires_at;
+
+ALTER TABLE note DROP COLUMN IF EXISTS expires_at;
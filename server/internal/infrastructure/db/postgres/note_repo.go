@@ -0,0 +1,117 @@
+package postgresdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/infrastructure/db/postgres/sqlc/queries"
+)
+
+// ErrNoteAlreadyExists is returned when adding a note that is already
+// redeemed.
+var ErrNoteAlreadyExists = errors.New("note already exists")
+
+// noteRepository stores the redeemed notes in a postgres db, so that several
+// servers can share them.
+type noteRepository struct {
+	db      *sql.DB
+	querier *queries.Queries
+}
+
+// NewNoteRepository returns a note repository for the given *sql.DB, migrated
+// with the scripts in the migration folder.
+func NewNoteRepository(config ...interface{}) (domain.NoteRepository, error) {
+	if len(config) != 1 {
+		return nil, fmt.Errorf("invalid config")
+	}
+	db, ok := config[0].(*sql.DB)
+	if !ok {
+		return nil, fmt.Errorf("cannot open note repository: invalid config, expected db at 0")
+	}
+
+	return &noteRepository{
+		db:      db,
+		querier: queries.New(db),
+	}, nil
+}
+
+func (n *noteRepository) Close() {
+	_ = n.db.Close()
+}
+
+// Add inserts the note only if not already present. The insert returns no
+// row for a duplicate, so that concurrent servers adding the same note can't
+// both succeed.
+func (n *noteRepository) Add(
+	ctx context.Context, id uint64, value uint64, roundTxid string,
+	expiresAt int64,
+) error {
+	if _, err := n.querier.InsertNote(ctx, queries.InsertNoteParams{
+		ID:        int64(id),
+		Value:     int64(value),
+		RoundTxid: roundTxid,
+		ExpiresAt: expiresAt,
+	}); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return fmt.Errorf("%w: %d", ErrNoteAlreadyExists, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// AddMany inserts all the notes in a single transaction, the ones already
+// present are ignored.
+func (n *noteRepository) AddMany(
+	ctx context.Context, roundTxid string, notes []domain.Note,
+) (int, error) {
+	var count int
+	txBody := func(querierWithTx *queries.Queries) error {
+		// reset in case the tx is retried
+		count = 0
+		for _, note := range notes {
+			inserted, err := querierWithTx.InsertNoteIfNotExists(
+				ctx, queries.InsertNoteIfNotExistsParams{
+					ID:        int64(note.ID),
+					Value:     int64(note.Value),
+					RoundTxid: roundTxid,
+					ExpiresAt: note.ExpiresAt,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			count += int(inserted)
+		}
+		return nil
+	}
+
+	if err := execTx(ctx, n.db, txBody); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (n *noteRepository) Contains(ctx context.Context, id uint64) (bool, error) {
+	return n.querier.ContainsNote(ctx, int64(id))
+}
+
+func (n *noteRepository) GetAmountForRound(
+	ctx context.Context, roundTxid string,
+) (uint64, error) {
+	amount, err := n.querier.SelectNotesAmountByRoundTxid(ctx, roundTxid)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(amount), nil
+}
+
+func (n *noteRepository) DeleteExpired(
+	ctx context.Context, before time.Time,
+) (int64, error) {
+	return n.querier.DeleteExpiredNotes(ctx, before.Unix())
+}
@@ -0,0 +1,17 @@
+-- name: InsertNote :one
+INSERT INTO note (id, value, round_txid, expires_at) VALUES ($1, $2, $3, $4)
+ON CONFLICT (id) DO NOTHING
+RETURNING id;
+
+-- name: InsertNoteIfNotExists :execrows
+INSERT INTO note (id, value, round_txid, expires_at) VALUES ($1, $2, $3, $4)
+ON CONFLICT (id) DO NOTHING;
+
+-- name: ContainsNote :one
+SELECT EXISTS(SELECT 1 FROM note WHERE id = $1);
+
+-- name: SelectNotesAmountByRoundTxid :one
+SELECT CAST(COALESCE(SUM(value), 0) AS BIGINT) AS amount FROM note WHERE round_txid = $1;
+
+-- name: DeleteExpiredNotes :execrows
+DELETE FROM note WHERE expires_at > 0 AND expires_at < $1;
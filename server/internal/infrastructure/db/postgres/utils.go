@@ -0,0 +1,83 @@
+package postgresdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ark-network/ark/server/internal/infrastructure/db/postgres/sqlc/queries"
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+const (
+	driverName = "pgx"
+	maxRetries = 5
+)
+
+// OpenDb connects to the postgres db with the given connection string.
+func OpenDb(dsn string) (*sql.DB, error) {
+	db, err := sql.Open(driverName, dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open db: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to connect to db: %w", err)
+	}
+
+	return db, nil
+}
+
+// isConflictError returns whether the given error is due to a concurrent
+// transaction, in which case the query can be retried.
+func isConflictError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	errMsg := strings.ToLower(err.Error())
+	return strings.Contains(errMsg, "could not serialize access") ||
+		strings.Contains(errMsg, "deadlock detected")
+}
+
+// execTx runs the given body in a transaction, retried if aborted by a
+// concurrent one.
+func execTx(
+	ctx context.Context, db *sql.DB, txBody func(*queries.Queries) error,
+) error {
+	var lastErr error
+	for range maxRetries {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to begin transaction: %w", err)
+		}
+		qtx := queries.New(db).WithTx(tx)
+
+		if err := txBody(qtx); err != nil {
+			//nolint:all
+			tx.Rollback()
+
+			if isConflictError(err) {
+				lastErr = err
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return err
+		}
+
+		if err := tx.Commit(); err != nil {
+			if isConflictError(err) {
+				lastErr = err
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			return fmt.Errorf("failed to commit transaction: %w", err)
+		}
+		return nil
+	}
+
+	return lastErr
+}
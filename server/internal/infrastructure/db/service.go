@@ -9,8 +9,11 @@ import (
 	"github.com/ark-network/ark/server/internal/core/domain"
 	"github.com/ark-network/ark/server/internal/core/ports"
 	badgerdb "github.com/ark-network/ark/server/internal/infrastructure/db/badger"
+	postgresdb "github.com/ark-network/ark/server/internal/infrastructure/db/postgres"
 	sqlitedb "github.com/ark-network/ark/server/internal/infrastructure/db/sqlite"
 	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database"
+	pgxmigrate "github.com/golang-migrate/migrate/v4/database/pgx/v5"
 	sqlitemigrate "github.com/golang-migrate/migrate/v4/database/sqlite"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
 	"github.com/golang-migrate/migrate/v4/source/iofs"
@@ -19,6 +22,9 @@ import (
 //go:embed sqlite/migration/*
 var migrations embed.FS
 
+//go:embed postgres/migration/*
+var postgresMigrations embed.FS
+
 var (
 	eventStoreTypes = map[string]func(...interface{}) (domain.RoundEventRepository, error){
 		"badger": badgerdb.NewRoundEventRepository,
@@ -32,13 +38,22 @@ var (
 		"sqlite": sqlitedb.NewVtxoRepository,
 	}
 	noteStoreTypes = map[string]func(...interface{}) (domain.NoteRepository, error){
-		"badger": badgerdb.NewNoteRepository,
-		"sqlite": sqlitedb.NewNoteRepository,
+		"badger":   badgerdb.NewNoteRepository,
+		"sqlite":   sqlitedb.NewNoteRepository,
+		"postgres": postgresdb.NewNoteRepository,
 	}
 	marketHourStoreTypes = map[string]func(...interface{}) (domain.MarketHourRepo, error){
 		"badger": badgerdb.NewMarketHourRepository,
 		"sqlite": sqlitedb.NewMarketHourRepository,
 	}
+	forfeitTxsStoreTypes = map[string]func(...interface{}) (domain.ForfeitTxsRepository, error){
+		"badger": badgerdb.NewForfeitTxsRepository,
+		"sqlite": sqlitedb.NewForfeitTxsRepository,
+	}
+	refreshAuthorizationStoreTypes = map[string]func(...interface{}) (domain.RefreshAuthorizationRepository, error){
+		"badger": badgerdb.NewRefreshAuthorizationRepository,
+		"sqlite": sqlitedb.NewRefreshAuthorizationRepository,
+	}
 )
 
 const (
@@ -48,17 +63,24 @@ const (
 type ServiceConfig struct {
 	EventStoreType string
 	DataStoreType  string
+	// NoteStoreType is the type of the db storing the redeemed notes, which can
+	// be a postgres db shared by several servers. Empty means the notes are in
+	// the data store.
+	NoteStoreType string
 
 	EventStoreConfig []interface{}
 	DataStoreConfig  []interface{}
+	NoteStoreConfig  []interface{}
 }
 
 type service struct {
-	eventStore     domain.RoundEventRepository
-	roundStore     domain.RoundRepository
-	vtxoStore      domain.VtxoRepository
-	noteStore      domain.NoteRepository
-	marketHourRepo domain.MarketHourRepo
+	eventStore      domain.RoundEventRepository
+	roundStore      domain.RoundRepository
+	vtxoStore       domain.VtxoRepository
+	noteStore       domain.NoteRepository
+	marketHourRepo  domain.MarketHourRepo
+	forfeitTxsRepo  domain.ForfeitTxsRepository
+	refreshAuthRepo domain.RefreshAuthorizationRepository
 }
 
 func NewService(config ServiceConfig) (ports.RepoManager, error) {
@@ -74,7 +96,14 @@ func NewService(config ServiceConfig) (ports.RepoManager, error) {
 	if !ok {
 		return nil, fmt.Errorf("vtxo store type not supported")
 	}
-	noteStoreFactory, ok := noteStoreTypes[config.DataStoreType]
+	noteStoreType := config.DataStoreType
+	if len(config.NoteStoreType) > 0 {
+		noteStoreType = config.NoteStoreType
+	}
+	if noteStoreType != config.DataStoreType && noteStoreType != "postgres" {
+		return nil, fmt.Errorf("note store type not supported")
+	}
+	noteStoreFactory, ok := noteStoreTypes[noteStoreType]
 	if !ok {
 		return nil, fmt.Errorf("note store type not supported")
 	}
@@ -82,12 +111,22 @@ func NewService(config ServiceConfig) (ports.RepoManager, error) {
 	if !ok {
 		return nil, fmt.Errorf("invalid data store type: %s", config.DataStoreType)
 	}
+	forfeitTxsStoreFactory, ok := forfeitTxsStoreTypes[config.DataStoreType]
+	if !ok {
+		return nil, fmt.Errorf("forfeit txs store type not supported")
+	}
+	refreshAuthStoreFactory, ok := refreshAuthorizationStoreTypes[config.DataStoreType]
+	if !ok {
+		return nil, fmt.Errorf("refresh authorization store type not supported")
+	}
 
 	var eventStore domain.RoundEventRepository
 	var roundStore domain.RoundRepository
 	var vtxoStore domain.VtxoRepository
 	var noteStore domain.NoteRepository
 	var marketHourRepo domain.MarketHourRepo
+	var forfeitTxsRepo domain.ForfeitTxsRepository
+	var refreshAuthRepo domain.RefreshAuthorizationRepository
 	var err error
 
 	switch config.EventStoreType {
@@ -110,14 +149,24 @@ func NewService(config ServiceConfig) (ports.RepoManager, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to open vtxo store: %s", err)
 		}
-		noteStore, err = noteStoreFactory(config.DataStoreConfig...)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open note store: %s", err)
+		if noteStoreType == config.DataStoreType {
+			noteStore, err = noteStoreFactory(config.DataStoreConfig...)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open note store: %s", err)
+			}
 		}
 		marketHourRepo, err = marketHourStoreFactory(config.DataStoreConfig...)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create market hour store: %w", err)
 		}
+		forfeitTxsRepo, err = forfeitTxsStoreFactory(config.DataStoreConfig...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open forfeit txs store: %s", err)
+		}
+		refreshAuthRepo, err = refreshAuthStoreFactory(config.DataStoreConfig...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open refresh authorization store: %s", err)
+		}
 	case "sqlite":
 		if len(config.DataStoreConfig) != 1 {
 			return nil, fmt.Errorf("invalid data store config")
@@ -139,18 +188,8 @@ func NewService(config ServiceConfig) (ports.RepoManager, error) {
 			return nil, fmt.Errorf("failed to init driver: %s", err)
 		}
 
-		source, err := iofs.New(migrations, "sqlite/migration")
-		if err != nil {
-			return nil, fmt.Errorf("failed to embed migrations: %s", err)
-		}
-
-		m, err := migrate.NewWithInstance("iofs", source, "arkdb", driver)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create migration instance: %s", err)
-		}
-
-		if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
-			return nil, fmt.Errorf("failed to run migrations: %s", err)
+		if err := runMigrations(migrations, "sqlite/migration", driver); err != nil {
+			return nil, err
 		}
 
 		roundStore, err = roundStoreFactory(db)
@@ -161,23 +200,64 @@ func NewService(config ServiceConfig) (ports.RepoManager, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to open vtxo store: %s", err)
 		}
-		noteStore, err = noteStoreFactory(db)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open note store: %s", err)
+		if noteStoreType == config.DataStoreType {
+			noteStore, err = noteStoreFactory(db)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open note store: %s", err)
+			}
 		}
 
 		marketHourRepo, err = marketHourStoreFactory(db)
 		if err != nil {
 			return nil, fmt.Errorf("failed to create market hour store: %w", err)
 		}
+		forfeitTxsRepo, err = forfeitTxsStoreFactory(db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open forfeit txs store: %s", err)
+		}
+		refreshAuthRepo, err = refreshAuthStoreFactory(db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open refresh authorization store: %s", err)
+		}
+	}
+
+	if noteStoreType == "postgres" {
+		if len(config.NoteStoreConfig) != 1 {
+			return nil, fmt.Errorf("invalid note store config")
+		}
+		dsn, ok := config.NoteStoreConfig[0].(string)
+		if !ok {
+			return nil, fmt.Errorf("invalid note store connection string")
+		}
+
+		db, err := postgresdb.OpenDb(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open note db: %s", err)
+		}
+
+		driver, err := pgxmigrate.WithInstance(db, &pgxmigrate.Config{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to init note db driver: %s", err)
+		}
+
+		if err := runMigrations(postgresMigrations, "postgres/migration", driver); err != nil {
+			return nil, err
+		}
+
+		noteStore, err = noteStoreFactory(db)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open note store: %s", err)
+		}
 	}
 
 	return &service{
-		eventStore:     eventStore,
-		roundStore:     roundStore,
-		vtxoStore:      vtxoStore,
-		noteStore:      noteStore,
-		marketHourRepo: marketHourRepo,
+		eventStore:      eventStore,
+		roundStore:      roundStore,
+		vtxoStore:       vtxoStore,
+		noteStore:       noteStore,
+		marketHourRepo:  marketHourRepo,
+		forfeitTxsRepo:  forfeitTxsRepo,
+		refreshAuthRepo: refreshAuthRepo,
 	}, nil
 }
 
@@ -205,10 +285,39 @@ func (s *service) MarketHourRepo() domain.MarketHourRepo {
 	return s.marketHourRepo
 }
 
+func (s *service) ForfeitTxs() domain.ForfeitTxsRepository {
+	return s.forfeitTxsRepo
+}
+
+func (s *service) RefreshAuthorizations() domain.RefreshAuthorizationRepository {
+	return s.refreshAuthRepo
+}
+
 func (s *service) Close() {
 	s.eventStore.Close()
 	s.roundStore.Close()
 	s.vtxoStore.Close()
 	s.noteStore.Close()
 	s.marketHourRepo.Close()
+	s.forfeitTxsRepo.Close()
+	s.refreshAuthRepo.Close()
+}
+
+// runMigrations applies to the db of the given driver the migrations not
+// applied yet from the given folder.
+func runMigrations(fs embed.FS, path string, driver database.Driver) error {
+	source, err := iofs.New(fs, path)
+	if err != nil {
+		return fmt.Errorf("failed to embed migrations: %s", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", source, "arkdb", driver)
+	if err != nil {
+		return fmt.Errorf("failed to create migration instance: %s", err)
+	}
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("failed to run migrations: %s", err)
+	}
+	return nil
 }
@@ -14,6 +14,7 @@ import (
 	"github.com/ark-network/ark/server/internal/core/domain"
 	"github.com/ark-network/ark/server/internal/core/ports"
 	"github.com/ark-network/ark/server/internal/infrastructure/db"
+	postgresdb "github.com/ark-network/ark/server/internal/infrastructure/db/postgres"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -155,6 +156,31 @@ func TestService(t *testing.T) {
 		},
 	}
 
+	// the postgres stores are tested only against the db at the given url, the
+	// tables used by the tests are emptied
+	if pgUrl := os.Getenv("TEST_POSTGRES_URL"); len(pgUrl) > 0 {
+		pgDb, err := postgresdb.OpenDb(pgUrl)
+		require.NoError(t, err)
+		defer pgDb.Close()
+		_, err = pgDb.Exec("DROP TABLE IF EXISTS note, schema_migrations")
+		require.NoError(t, err)
+
+		tests = append(tests, struct {
+			name   string
+			config db.ServiceConfig
+		}{
+			name: "repo_manager_with_postgres_note_store",
+			config: db.ServiceConfig{
+				EventStoreType:   "badger",
+				DataStoreType:    "sqlite",
+				NoteStoreType:    "postgres",
+				EventStoreConfig: []interface{}{"", nil},
+				DataStoreConfig:  []interface{}{t.TempDir()},
+				NoteStoreConfig:  []interface{}{pgUrl},
+			},
+		})
+	}
+
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			svc, err := db.NewService(tt.config)
@@ -165,11 +191,51 @@ func TestService(t *testing.T) {
 			testRoundRepository(t, svc)
 			testVtxoRepository(t, svc)
 			testNoteRepository(t, svc)
+			testForfeitTxsRepository(t, svc)
+			testRefreshAuthorizationRepository(t, svc)
 			testMarketHourRepository(t, svc)
 		})
 	}
 }
 
+func TestServiceNoteStoreConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		config db.ServiceConfig
+		err    string
+	}{
+		{
+			name: "note store type different from data store type",
+			config: db.ServiceConfig{
+				EventStoreType:   "badger",
+				DataStoreType:    "sqlite",
+				NoteStoreType:    "badger",
+				EventStoreConfig: []interface{}{"", nil},
+				DataStoreConfig:  []interface{}{t.TempDir()},
+			},
+			err: "note store type not supported",
+		},
+		{
+			name: "missing postgres note store config",
+			config: db.ServiceConfig{
+				EventStoreType:   "badger",
+				DataStoreType:    "sqlite",
+				NoteStoreType:    "postgres",
+				EventStoreConfig: []interface{}{"", nil},
+				DataStoreConfig:  []interface{}{t.TempDir()},
+			},
+			err: "invalid note store config",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := db.NewService(tt.config)
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
 func testRoundEventRepository(t *testing.T, svc ports.RepoManager) {
 	t.Run("test_event_repository", func(t *testing.T) {
 		fixtures := []struct {
@@ -392,6 +458,25 @@ func testRoundRepository(t *testing.T, svc ports.RepoManager) {
 		require.NotNil(t, roundByTxid)
 		require.Condition(t, roundsMatch(*finalizedRound, *roundByTxid))
 
+		summaries, err := svc.Rounds().GetRoundSummaries(ctx, 0, "", 100)
+		require.NoError(t, err)
+		var summary *domain.RoundSummary
+		for i := range summaries {
+			if summaries[i].Txid == txid {
+				summary = &summaries[i]
+			}
+		}
+		require.NotNil(t, summary)
+		require.Equal(t, finalizedRound.EndingTimestamp, summary.EndedAt)
+		require.Equal(t, 2, summary.TotalInputVtxos)
+		require.Equal(t, uint64(900), summary.TotalAmount)
+
+		summaries, err = svc.Rounds().GetRoundSummaries(ctx, summary.EndedAt, txid, 100)
+		require.NoError(t, err)
+		for _, s := range summaries {
+			require.NotEqual(t, txid, s.Txid)
+		}
+
 		txs, err := svc.Rounds().GetTxsWithTxids(ctx, []string{txida, txidb})
 		require.NoError(t, err)
 		require.NotNil(t, txs)
@@ -476,6 +561,18 @@ func testVtxoRepository(t *testing.T, svc ports.RepoManager) {
 		require.NoError(t, err)
 		require.Len(t, append(spendableVtxos, spentVtxos...), numberOfVtxos+len(newVtxos))
 
+		spendableVtxos, spentVtxos, err = svc.Vtxos().GetAllNonRedeemedVtxosWithPubKeys(
+			ctx, []string{pubkey, pubkey2},
+		)
+		require.NoError(t, err)
+		require.Len(t, spendableVtxos, len(newVtxos))
+		require.Empty(t, spentVtxos)
+
+		spendableVtxos, spentVtxos, err = svc.Vtxos().GetAllNonRedeemedVtxosWithPubKeys(ctx, nil)
+		require.NoError(t, err)
+		require.Empty(t, spendableVtxos)
+		require.Empty(t, spentVtxos)
+
 		err = svc.Vtxos().SpendVtxos(ctx, vtxoKeys[:1], randomString(32))
 		require.NoError(t, err)
 
@@ -497,10 +594,15 @@ func testNoteRepository(t *testing.T, svc ports.RepoManager) {
 	t.Run("test_note_repository", func(t *testing.T) {
 		ctx := context.Background()
 
-		err := svc.Notes().Add(ctx, 1)
+		roundTxid := randomString(32)
+
+		err := svc.Notes().Add(ctx, 1, 1000, roundTxid, 0)
+		require.NoError(t, err)
+
+		err = svc.Notes().Add(ctx, 1099200322, 2000, roundTxid, 0)
 		require.NoError(t, err)
 
-		err = svc.Notes().Add(ctx, 1099200322)
+		err = svc.Notes().Add(ctx, 789, 5000, randomString(32), 0)
 		require.NoError(t, err)
 
 		contains, err := svc.Notes().Contains(ctx, 1)
@@ -515,8 +617,215 @@ func testNoteRepository(t *testing.T, svc ports.RepoManager) {
 		require.NoError(t, err)
 		require.False(t, contains)
 
-		err = svc.Notes().Add(ctx, 1)
+		err = svc.Notes().Add(ctx, 1, 1000, roundTxid, 0)
+		require.Error(t, err)
+
+		amount, err := svc.Notes().GetAmountForRound(ctx, roundTxid)
+		require.NoError(t, err)
+		require.Equal(t, uint64(3000), amount)
+
+		amount, err = svc.Notes().GetAmountForRound(ctx, randomString(32))
+		require.NoError(t, err)
+		require.Zero(t, amount)
+
+		// the notes already present are skipped
+		count, err := svc.Notes().AddMany(ctx, roundTxid, []domain.Note{
+			{ID: 1, Value: 1000},
+			{ID: 2001, Value: 3000},
+			{ID: 2002, Value: 4000},
+		})
+		require.NoError(t, err)
+		require.Equal(t, 2, count)
+
+		amount, err = svc.Notes().GetAmountForRound(ctx, roundTxid)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10000), amount)
+
+		contains, err = svc.Notes().Contains(ctx, 2002)
+		require.NoError(t, err)
+		require.True(t, contains)
+
+		now := time.Now()
+		err = svc.Notes().Add(ctx, 1001, 1000, roundTxid, now.Add(-time.Hour).Unix())
+		require.NoError(t, err)
+
+		err = svc.Notes().Add(ctx, 1002, 1000, roundTxid, now.Add(time.Hour).Unix())
+		require.NoError(t, err)
+
+		deleted, err := svc.Notes().DeleteExpired(ctx, now)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), deleted)
+
+		contains, err = svc.Notes().Contains(ctx, 1001)
+		require.NoError(t, err)
+		require.False(t, contains)
+
+		contains, err = svc.Notes().Contains(ctx, 1002)
+		require.NoError(t, err)
+		require.True(t, contains)
+
+		// the notes without expiry are never deleted
+		contains, err = svc.Notes().Contains(ctx, 1)
+		require.NoError(t, err)
+		require.True(t, contains)
+	})
+}
+
+func testForfeitTxsRepository(t *testing.T, svc ports.RepoManager) {
+	t.Run("test_forfeit_txs_repository", func(t *testing.T) {
+		ctx := context.Background()
+		repo := svc.ForfeitTxs()
+
+		vtxos := []domain.VtxoKey{
+			{Txid: randomString(32), VOut: 0},
+			{Txid: randomString(32), VOut: 1},
+		}
+		roundId := uuid.New().String()
+
+		forfeitTxs, err := repo.GetForfeitTxs(ctx, roundId)
+		require.NoError(t, err)
+		require.Empty(t, forfeitTxs)
+
+		feeRate, err := repo.GetFeeRate(ctx, roundId)
+		require.NoError(t, err)
+		require.Zero(t, feeRate)
+
+		err = repo.Init(ctx, roundId, vtxos, 1000)
+		require.NoError(t, err)
+
+		forfeitTxs, err = repo.GetForfeitTxs(ctx, roundId)
+		require.NoError(t, err)
+		require.Len(t, forfeitTxs, len(vtxos))
+		for _, vtxo := range vtxos {
+			require.Empty(t, forfeitTxs[vtxo])
+		}
+
+		err = repo.Sign(ctx, roundId, map[domain.VtxoKey]string{vtxos[0]: "forfeit"})
+		require.NoError(t, err)
+
+		// initializing again the same round must preserve the signed forfeits
+		// and the fee rate of the round
+		err = repo.Init(ctx, roundId, vtxos, 2000)
+		require.NoError(t, err)
+
+		forfeitTxs, err = repo.GetForfeitTxs(ctx, roundId)
+		require.NoError(t, err)
+		require.Len(t, forfeitTxs, len(vtxos))
+		require.Equal(t, "forfeit", forfeitTxs[vtxos[0]])
+		require.Empty(t, forfeitTxs[vtxos[1]])
+
+		feeRate, err = repo.GetFeeRate(ctx, roundId)
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), feeRate)
+
+		// initializing a new round preserves the forfeits of the one in flight
+		newRoundId := uuid.New().String()
+		err = repo.Init(ctx, newRoundId, vtxos[:1], 2000)
+		require.NoError(t, err)
+
+		forfeitTxs, err = repo.GetForfeitTxs(ctx, roundId)
+		require.NoError(t, err)
+		require.Len(t, forfeitTxs, len(vtxos))
+
+		forfeitTxs, err = repo.GetForfeitTxs(ctx, newRoundId)
+		require.NoError(t, err)
+		require.Len(t, forfeitTxs, 1)
+
+		roundIds, err := repo.GetRoundIds(ctx)
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{roundId, newRoundId}, roundIds)
+
+		err = repo.Delete(ctx, roundId)
+		require.NoError(t, err)
+
+		forfeitTxs, err = repo.GetForfeitTxs(ctx, roundId)
+		require.NoError(t, err)
+		require.Empty(t, forfeitTxs)
+
+		forfeitTxs, err = repo.GetForfeitTxs(ctx, newRoundId)
+		require.NoError(t, err)
+		require.Len(t, forfeitTxs, 1)
+
+		err = repo.Reset(ctx)
+		require.NoError(t, err)
+
+		forfeitTxs, err = repo.GetForfeitTxs(ctx, newRoundId)
+		require.NoError(t, err)
+		require.Empty(t, forfeitTxs)
+
+		roundIds, err = repo.GetRoundIds(ctx)
+		require.NoError(t, err)
+		require.Empty(t, roundIds)
+	})
+}
+
+func testRefreshAuthorizationRepository(t *testing.T, svc ports.RepoManager) {
+	t.Run("test_refresh_authorization_repository", func(t *testing.T) {
+		ctx := context.Background()
+		repo := svc.RefreshAuthorizations()
+
+		authorization := domain.RefreshAuthorization{
+			VtxoKey:   domain.VtxoKey{Txid: randomString(32), VOut: 1},
+			Intent:    "intent",
+			Message:   "message",
+			ForfeitTx: f1,
+			CreatedAt: time.Now().Unix(),
+		}
+		otherAuthorization := domain.RefreshAuthorization{
+			VtxoKey:   domain.VtxoKey{Txid: randomString(32), VOut: 0},
+			Intent:    "other intent",
+			Message:   "other message",
+			ForfeitTx: f2,
+			CreatedAt: time.Now().Unix(),
+		}
+
+		_, err := repo.Get(ctx, authorization.VtxoKey)
 		require.Error(t, err)
+
+		authorizations, err := repo.GetAll(ctx)
+		require.NoError(t, err)
+		require.Empty(t, authorizations)
+
+		err = repo.Add(ctx, authorization)
+		require.NoError(t, err)
+		err = repo.Add(ctx, otherAuthorization)
+		require.NoError(t, err)
+
+		got, err := repo.Get(ctx, authorization.VtxoKey)
+		require.NoError(t, err)
+		require.Equal(t, authorization, *got)
+
+		// adding an authorization for the same vtxo replaces the previous one
+		authorization.Intent = "new intent"
+		err = repo.Add(ctx, authorization)
+		require.NoError(t, err)
+
+		got, err = repo.Get(ctx, authorization.VtxoKey)
+		require.NoError(t, err)
+		require.Equal(t, authorization, *got)
+
+		// the revocation is stored along with the authorization
+		authorization.RevokedAt = time.Now().Unix()
+		err = repo.Add(ctx, authorization)
+		require.NoError(t, err)
+
+		got, err = repo.Get(ctx, authorization.VtxoKey)
+		require.NoError(t, err)
+		require.Equal(t, authorization, *got)
+
+		authorizations, err = repo.GetAll(ctx)
+		require.NoError(t, err)
+		require.Len(t, authorizations, 2)
+
+		err = repo.Delete(ctx, []domain.VtxoKey{authorization.VtxoKey})
+		require.NoError(t, err)
+
+		_, err = repo.Get(ctx, authorization.VtxoKey)
+		require.Error(t, err)
+
+		authorizations, err = repo.GetAll(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []domain.RefreshAuthorization{otherAuthorization}, authorizations)
 	})
 }
 
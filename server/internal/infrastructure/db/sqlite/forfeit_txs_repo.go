@@ -0,0 +1,118 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/infrastructure/db/sqlite/sqlc/queries"
+)
+
+type forfeitTxsRepository struct {
+	db      *sql.DB
+	querier *queries.Queries
+}
+
+func NewForfeitTxsRepository(config ...interface{}) (domain.ForfeitTxsRepository, error) {
+	if len(config) != 1 {
+		return nil, fmt.Errorf("invalid config")
+	}
+	db, ok := config[0].(*sql.DB)
+	if !ok {
+		return nil, fmt.Errorf("cannot open forfeit txs repository: invalid config, expected db at 0")
+	}
+
+	return &forfeitTxsRepository{
+		db:      db,
+		querier: queries.New(db),
+	}, nil
+}
+
+func (r *forfeitTxsRepository) Init(
+	ctx context.Context, roundId string, vtxos []domain.VtxoKey, feeRate int64,
+) error {
+	txBody := func(querierWithTx *queries.Queries) error {
+		for _, vtxo := range vtxos {
+			if err := querierWithTx.InsertPendingForfeitTx(
+				ctx, queries.InsertPendingForfeitTxParams{
+					RoundID:  roundId,
+					VtxoTxid: vtxo.Txid,
+					VtxoVout: int64(vtxo.VOut),
+					FeeRate:  feeRate,
+				},
+			); err != nil {
+				return fmt.Errorf("failed to insert forfeit tx: %w", err)
+			}
+		}
+		return nil
+	}
+	return execTx(ctx, r.db, txBody)
+}
+
+func (r *forfeitTxsRepository) Sign(
+	ctx context.Context, roundId string, forfeitTxs map[domain.VtxoKey]string,
+) error {
+	txBody := func(querierWithTx *queries.Queries) error {
+		for vtxo, tx := range forfeitTxs {
+			if err := querierWithTx.UpdatePendingForfeitTx(
+				ctx, queries.UpdatePendingForfeitTxParams{
+					Tx:       tx,
+					RoundID:  roundId,
+					VtxoTxid: vtxo.Txid,
+					VtxoVout: int64(vtxo.VOut),
+				},
+			); err != nil {
+				return fmt.Errorf("failed to update forfeit tx: %w", err)
+			}
+		}
+		return nil
+	}
+	return execTx(ctx, r.db, txBody)
+}
+
+func (r *forfeitTxsRepository) GetForfeitTxs(
+	ctx context.Context, roundId string,
+) (map[domain.VtxoKey]string, error) {
+	rows, err := r.querier.SelectPendingForfeitTxs(ctx, roundId)
+	if err != nil {
+		return nil, err
+	}
+
+	forfeitTxs := make(map[domain.VtxoKey]string, len(rows))
+	for _, row := range rows {
+		vtxo := domain.VtxoKey{Txid: row.VtxoTxid, VOut: uint32(row.VtxoVout)}
+		forfeitTxs[vtxo] = row.Tx
+	}
+	return forfeitTxs, nil
+}
+
+func (r *forfeitTxsRepository) GetFeeRate(
+	ctx context.Context, roundId string,
+) (int64, error) {
+	feeRate, err := r.querier.SelectPendingForfeitFeeRate(ctx, roundId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return feeRate, nil
+}
+
+func (r *forfeitTxsRepository) GetRoundIds(ctx context.Context) ([]string, error) {
+	return r.querier.SelectPendingForfeitRoundIds(ctx)
+}
+
+func (r *forfeitTxsRepository) Delete(ctx context.Context, roundId string) error {
+	return r.querier.DeleteRoundPendingForfeitTxs(ctx, roundId)
+}
+
+func (r *forfeitTxsRepository) Reset(ctx context.Context) error {
+	return r.querier.DeletePendingForfeitTxs(ctx)
+}
+
+func (r *forfeitTxsRepository) Close() {
+	_ = r.db.Close()
+}
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS pending_forfeit_tx;
\ No newline at end of file
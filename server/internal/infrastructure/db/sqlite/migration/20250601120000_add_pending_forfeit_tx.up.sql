@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS pending_forfeit_tx (
+    round_id TEXT NOT NULL,
+    vtxo_txid TEXT NOT NULL,
+    vtxo_vout INTEGER NOT NULL,
+    tx TEXT NOT NULL DEFAULT '',
+    PRIMARY KEY (round_id, vtxo_txid, vtxo_vout)
+);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_note_round_txid;
+
+ALTER TABLE note DROP COLUMN round_txid;
+ALTER TABLE note DROP COLUMN value;
@@ -0,0 +1,4 @@
+ALTER TABLE note ADD COLUMN value INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE note ADD COLUMN round_txid TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_note_round_txid ON note(round_txid);
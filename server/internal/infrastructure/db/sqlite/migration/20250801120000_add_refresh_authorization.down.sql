@@ -0,0 +1 @@
+DROP TABLE IF EXISTS refresh_authorization;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS refresh_authorization (
+    vtxo_txid TEXT NOT NULL,
+    vtxo_vout INTEGER NOT NULL,
+    intent TEXT NOT NULL,
+    message TEXT NOT NULL,
+    forfeit_tx TEXT NOT NULL,
+    created_at INTEGER NOT NULL,
+    PRIMARY KEY (vtxo_txid, vtxo_vout)
+);
@@ -0,0 +1,3 @@
+ALTER TABLE note ADD COLUMN expires_at INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_note_expires_at ON note(expires_at);
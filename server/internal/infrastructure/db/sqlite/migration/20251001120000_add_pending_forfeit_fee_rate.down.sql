@@ -0,0 +1 @@
+ALTER TABLE pending_forfeit_tx DROP COLUMN fee_rate;
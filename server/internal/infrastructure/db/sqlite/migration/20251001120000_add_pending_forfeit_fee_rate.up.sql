@@ -0,0 +1 @@
+ALTER TABLE pending_forfeit_tx ADD COLUMN fee_rate INTEGER NOT NULL DEFAULT 0;
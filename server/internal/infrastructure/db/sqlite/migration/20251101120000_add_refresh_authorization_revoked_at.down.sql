@@ -0,0 +1 @@
+ALTER TABLE refresh_authorization DROP COLUMN revoked_at;
@@ -0,0 +1 @@
+ALTER TABLE refresh_authorization ADD COLUMN revoked_at INTEGER NOT NULL DEFAULT 0;
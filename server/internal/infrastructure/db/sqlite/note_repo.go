@@ -34,13 +34,22 @@ func (n *noteRepository) Close() {
 	_ = n.db.Close()
 }
 
-func (n *noteRepository) Add(ctx context.Context, id uint64) error {
-	if err := n.querier.InsertNote(ctx, int64(id)); err != nil {
+func (n *noteRepository) Add(
+	ctx context.Context, id uint64, value uint64, roundTxid string,
+	expiresAt int64,
+) error {
+	params := queries.InsertNoteParams{
+		ID:        int64(id),
+		Value:     int64(value),
+		RoundTxid: roundTxid,
+		ExpiresAt: expiresAt,
+	}
+	if err := n.querier.InsertNote(ctx, params); err != nil {
 		if isConflictError(err) {
 			attempts := 1
 			for isConflictError(err) && attempts <= maxRetries {
 				time.Sleep(100 * time.Millisecond)
-				err = n.querier.InsertNote(ctx, int64(id))
+				err = n.querier.InsertNote(ctx, params)
 				attempts++
 			}
 		}
@@ -49,6 +58,38 @@ func (n *noteRepository) Add(ctx context.Context, id uint64) error {
 	return nil
 }
 
+// AddMany inserts all the notes in a single transaction, the ones already
+// present are ignored.
+func (n *noteRepository) AddMany(
+	ctx context.Context, roundTxid string, notes []domain.Note,
+) (int, error) {
+	var count int
+	txBody := func(querierWithTx *queries.Queries) error {
+		// reset in case the tx is retried
+		count = 0
+		for _, note := range notes {
+			inserted, err := querierWithTx.InsertNoteIfNotExists(
+				ctx, queries.InsertNoteIfNotExistsParams{
+					ID:        int64(note.ID),
+					Value:     int64(note.Value),
+					RoundTxid: roundTxid,
+					ExpiresAt: note.ExpiresAt,
+				},
+			)
+			if err != nil {
+				return err
+			}
+			count += int(inserted)
+		}
+		return nil
+	}
+
+	if err := execTx(ctx, n.db, txBody); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (n *noteRepository) Contains(ctx context.Context, id uint64) (bool, error) {
 	contains, err := n.querier.ContainsNote(ctx, int64(id))
 	if err != nil {
@@ -56,3 +97,19 @@ func (n *noteRepository) Contains(ctx context.Context, id uint64) (bool, error)
 	}
 	return contains == 1, nil
 }
+
+func (n *noteRepository) GetAmountForRound(
+	ctx context.Context, roundTxid string,
+) (uint64, error) {
+	amount, err := n.querier.SelectNotesAmountByRoundTxid(ctx, roundTxid)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(amount), nil
+}
+
+func (n *noteRepository) DeleteExpired(
+	ctx context.Context, before time.Time,
+) (int64, error) {
+	return n.querier.DeleteExpiredNotes(ctx, before.Unix())
+}
@@ -0,0 +1,123 @@
+package sqlitedb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/infrastructure/db/sqlite/sqlc/queries"
+)
+
+type refreshAuthorizationRepository struct {
+	db      *sql.DB
+	querier *queries.Queries
+}
+
+func NewRefreshAuthorizationRepository(
+	config ...interface{},
+) (domain.RefreshAuthorizationRepository, error) {
+	if len(config) != 1 {
+		return nil, fmt.Errorf("invalid config")
+	}
+	db, ok := config[0].(*sql.DB)
+	if !ok {
+		return nil, fmt.Errorf(
+			"cannot open refresh authorization repository: invalid config, expected db at 0",
+		)
+	}
+
+	return &refreshAuthorizationRepository{
+		db:      db,
+		querier: queries.New(db),
+	}, nil
+}
+
+func (r *refreshAuthorizationRepository) Add(
+	ctx context.Context, authorization domain.RefreshAuthorization,
+) error {
+	return r.querier.UpsertRefreshAuthorization(
+		ctx, queries.UpsertRefreshAuthorizationParams{
+			VtxoTxid:  authorization.Txid,
+			VtxoVout:  int64(authorization.VOut),
+			Intent:    authorization.Intent,
+			Message:   authorization.Message,
+			ForfeitTx: authorization.ForfeitTx,
+			CreatedAt: authorization.CreatedAt,
+			RevokedAt: authorization.RevokedAt,
+		},
+	)
+}
+
+func (r *refreshAuthorizationRepository) Get(
+	ctx context.Context, vtxo domain.VtxoKey,
+) (*domain.RefreshAuthorization, error) {
+	row, err := r.querier.SelectRefreshAuthorization(
+		ctx, queries.SelectRefreshAuthorizationParams{
+			VtxoTxid: vtxo.Txid,
+			VtxoVout: int64(vtxo.VOut),
+		},
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("refresh authorization for vtxo %s not found", vtxo)
+		}
+		return nil, err
+	}
+
+	authorization := toRefreshAuthorization(row)
+	return &authorization, nil
+}
+
+func (r *refreshAuthorizationRepository) GetAll(
+	ctx context.Context,
+) ([]domain.RefreshAuthorization, error) {
+	rows, err := r.querier.SelectAllRefreshAuthorizations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizations := make([]domain.RefreshAuthorization, 0, len(rows))
+	for _, row := range rows {
+		authorizations = append(authorizations, toRefreshAuthorization(row))
+	}
+	return authorizations, nil
+}
+
+func (r *refreshAuthorizationRepository) Delete(
+	ctx context.Context, vtxos []domain.VtxoKey,
+) error {
+	txBody := func(querierWithTx *queries.Queries) error {
+		for _, vtxo := range vtxos {
+			if err := querierWithTx.DeleteRefreshAuthorization(
+				ctx, queries.DeleteRefreshAuthorizationParams{
+					VtxoTxid: vtxo.Txid,
+					VtxoVout: int64(vtxo.VOut),
+				},
+			); err != nil {
+				return fmt.Errorf("failed to delete refresh authorization: %w", err)
+			}
+		}
+		return nil
+	}
+	return execTx(ctx, r.db, txBody)
+}
+
+func (r *refreshAuthorizationRepository) Close() {
+	_ = r.db.Close()
+}
+
+func toRefreshAuthorization(row queries.RefreshAuthorization) domain.RefreshAuthorization {
+	return domain.RefreshAuthorization{
+		VtxoKey: domain.VtxoKey{
+			Txid: row.VtxoTxid,
+			VOut: uint32(row.VtxoVout),
+		},
+		Intent:    row.Intent,
+		Message:   row.Message,
+		ForfeitTx: row.ForfeitTx,
+		CreatedAt: row.CreatedAt,
+		RevokedAt: row.RevokedAt,
+	}
+}
@@ -297,6 +297,43 @@ func (r *roundRepository) GetRoundStats(ctx context.Context, id string) (*domain
 	}, nil
 }
 
+func (r *roundRepository) GetRoundSummaries(
+	ctx context.Context, endedAfter int64, afterTxid string, limit int,
+) ([]domain.RoundSummary, error) {
+	rows, err := r.querier.SelectRoundSummaries(
+		ctx, queries.SelectRoundSummariesParams{
+			EndingTimestamp:   endedAfter,
+			EndingTimestamp_2: endedAfter,
+			Txid:              afterTxid,
+			Limit:             int64(limit),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries := make([]domain.RoundSummary, 0, len(rows))
+	for _, row := range rows {
+		var totalAmount uint64
+		switch v := row.TotalAmount.(type) {
+		case int64:
+			totalAmount = uint64(v)
+		case int:
+			totalAmount = uint64(v)
+		}
+
+		summaries = append(summaries, domain.RoundSummary{
+			Txid:             row.Txid,
+			StartedAt:        row.StartingTimestamp,
+			EndedAt:          row.EndingTimestamp,
+			TotalInputVtxos:  int(row.TotalInputVtxos),
+			TotalOutputVtxos: int(row.TotalOutputVtxos),
+			TotalAmount:      totalAmount,
+		})
+	}
+	return summaries, nil
+}
+
 func (r *roundRepository) GetRoundForfeitTxs(ctx context.Context, roundTxid string) ([]domain.ForfeitTx, error) {
 	rows, err := r.querier.GetRoundForfeitTxs(ctx, roundTxid)
 	if err != nil {
@@ -18,7 +18,18 @@ type MarketHour struct {
 }
 
 type Note struct {
-	ID int64
+	ID        int64
+	Value     int64
+	RoundTxid string
+	ExpiresAt int64
+}
+
+type PendingForfeitTx struct {
+	RoundID  string
+	VtxoTxid string
+	VtxoVout int64
+	Tx       string
+	FeeRate  int64
 }
 
 type Receiver struct {
@@ -28,6 +39,16 @@ type Receiver struct {
 	Amount         int64
 }
 
+type RefreshAuthorization struct {
+	VtxoTxid  string
+	VtxoVout  int64
+	Intent    string
+	Message   string
+	ForfeitTx string
+	CreatedAt int64
+	RevokedAt int64
+}
+
 type RequestReceiverVw struct {
 	RequestID      sql.NullString
 	Pubkey         sql.NullString
@@ -22,6 +22,50 @@ func (q *Queries) ContainsNote(ctx context.Context, id int64) (int64, error) {
 	return column_1, err
 }
 
+const deleteExpiredNotes = `-- name: DeleteExpiredNotes :execrows
+DELETE FROM note WHERE expires_at > 0 AND expires_at < ?
+`
+
+func (q *Queries) DeleteExpiredNotes(ctx context.Context, expiresAt int64) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteExpiredNotes, expiresAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const deletePendingForfeitTxs = `-- name: DeletePendingForfeitTxs :exec
+DELETE FROM pending_forfeit_tx
+`
+
+func (q *Queries) DeletePendingForfeitTxs(ctx context.Context) error {
+	_, err := q.db.ExecContext(ctx, deletePendingForfeitTxs)
+	return err
+}
+
+const deleteRefreshAuthorization = `-- name: DeleteRefreshAuthorization :exec
+DELETE FROM refresh_authorization WHERE vtxo_txid = ? AND vtxo_vout = ?
+`
+
+type DeleteRefreshAuthorizationParams struct {
+	VtxoTxid string
+	VtxoVout int64
+}
+
+func (q *Queries) DeleteRefreshAuthorization(ctx context.Context, arg DeleteRefreshAuthorizationParams) error {
+	_, err := q.db.ExecContext(ctx, deleteRefreshAuthorization, arg.VtxoTxid, arg.VtxoVout)
+	return err
+}
+
+const deleteRoundPendingForfeitTxs = `-- name: DeleteRoundPendingForfeitTxs :exec
+DELETE FROM pending_forfeit_tx WHERE round_id = ?
+`
+
+func (q *Queries) DeleteRoundPendingForfeitTxs(ctx context.Context, roundID string) error {
+	_, err := q.db.ExecContext(ctx, deleteRoundPendingForfeitTxs, roundID)
+	return err
+}
+
 const getExistingRounds = `-- name: GetExistingRounds :many
 SELECT txid FROM round WHERE txid IN (/*SLICE:txids*/?)
 `
@@ -395,11 +439,69 @@ func (q *Queries) InsertMarketHour(ctx context.Context, arg InsertMarketHourPara
 }
 
 const insertNote = `-- name: InsertNote :exec
-INSERT INTO note (id) VALUES (?)
+INSERT INTO note (id, value, round_txid, expires_at) VALUES (?, ?, ?, ?)
 `
 
-func (q *Queries) InsertNote(ctx context.Context, id int64) error {
-	_, err := q.db.ExecContext(ctx, insertNote, id)
+type InsertNoteParams struct {
+	ID        int64
+	Value     int64
+	RoundTxid string
+	ExpiresAt int64
+}
+
+func (q *Queries) InsertNote(ctx context.Context, arg InsertNoteParams) error {
+	_, err := q.db.ExecContext(ctx, insertNote,
+		arg.ID,
+		arg.Value,
+		arg.RoundTxid,
+		arg.ExpiresAt,
+	)
+	return err
+}
+
+const insertNoteIfNotExists = `-- name: InsertNoteIfNotExists :execrows
+INSERT OR IGNORE INTO note (id, value, round_txid, expires_at) VALUES (?, ?, ?, ?)
+`
+
+type InsertNoteIfNotExistsParams struct {
+	ID        int64
+	Value     int64
+	RoundTxid string
+	ExpiresAt int64
+}
+
+func (q *Queries) InsertNoteIfNotExists(ctx context.Context, arg InsertNoteIfNotExistsParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, insertNoteIfNotExists,
+		arg.ID,
+		arg.Value,
+		arg.RoundTxid,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const insertPendingForfeitTx = `-- name: InsertPendingForfeitTx :exec
+INSERT INTO pending_forfeit_tx (round_id, vtxo_txid, vtxo_vout, fee_rate)
+VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING
+`
+
+type InsertPendingForfeitTxParams struct {
+	RoundID  string
+	VtxoTxid string
+	VtxoVout int64
+	FeeRate  int64
+}
+
+func (q *Queries) InsertPendingForfeitTx(ctx context.Context, arg InsertPendingForfeitTxParams) error {
+	_, err := q.db.ExecContext(ctx, insertPendingForfeitTx,
+		arg.RoundID,
+		arg.VtxoTxid,
+		arg.VtxoVout,
+		arg.FeeRate,
+	)
 	return err
 }
 
@@ -446,6 +548,41 @@ func (q *Queries) MarkVtxoAsSwept(ctx context.Context, arg MarkVtxoAsSweptParams
 	return err
 }
 
+const selectAllRefreshAuthorizations = `-- name: SelectAllRefreshAuthorizations :many
+SELECT vtxo_txid, vtxo_vout, intent, message, forfeit_tx, created_at, revoked_at FROM refresh_authorization
+`
+
+func (q *Queries) SelectAllRefreshAuthorizations(ctx context.Context) ([]RefreshAuthorization, error) {
+	rows, err := q.db.QueryContext(ctx, selectAllRefreshAuthorizations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RefreshAuthorization
+	for rows.Next() {
+		var i RefreshAuthorization
+		if err := rows.Scan(
+			&i.VtxoTxid,
+			&i.VtxoVout,
+			&i.Intent,
+			&i.Message,
+			&i.ForfeitTx,
+			&i.CreatedAt,
+			&i.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const selectAllVtxos = `-- name: SelectAllVtxos :many
 SELECT vtxo.txid, vtxo.vout, vtxo.pubkey, vtxo.amount, vtxo.round_tx, vtxo.spent_by, vtxo.spent, vtxo.redeemed, vtxo.swept, vtxo.expire_at, vtxo.created_at, vtxo.request_id, vtxo.redeem_tx FROM vtxo
 `
@@ -657,6 +794,168 @@ func (q *Queries) SelectNotRedeemedVtxosWithPubkey(ctx context.Context, pubkey s
 	return items, nil
 }
 
+const selectNotRedeemedVtxosWithPubkeys = `-- name: SelectNotRedeemedVtxosWithPubkeys :many
+SELECT vtxo.txid, vtxo.vout, vtxo.pubkey, vtxo.amount, vtxo.round_tx, vtxo.spent_by, vtxo.spent, vtxo.redeemed, vtxo.swept, vtxo.expire_at, vtxo.created_at, vtxo.request_id, vtxo.redeem_tx FROM vtxo
+WHERE redeemed = false AND pubkey IN (/*SLICE:pubkeys*/?)
+`
+
+type SelectNotRedeemedVtxosWithPubkeysRow struct {
+	Vtxo Vtxo
+}
+
+func (q *Queries) SelectNotRedeemedVtxosWithPubkeys(ctx context.Context, pubkeys []string) ([]SelectNotRedeemedVtxosWithPubkeysRow, error) {
+	query := selectNotRedeemedVtxosWithPubkeys
+	var queryParams []interface{}
+	if len(pubkeys) > 0 {
+		for _, v := range pubkeys {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:pubkeys*/?", strings.Repeat(",?", len(pubkeys))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:pubkeys*/?", "NULL", 1)
+	}
+	rows, err := q.db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectNotRedeemedVtxosWithPubkeysRow
+	for rows.Next() {
+		var i SelectNotRedeemedVtxosWithPubkeysRow
+		if err := rows.Scan(
+			&i.Vtxo.Txid,
+			&i.Vtxo.Vout,
+			&i.Vtxo.Pubkey,
+			&i.Vtxo.Amount,
+			&i.Vtxo.RoundTx,
+			&i.Vtxo.SpentBy,
+			&i.Vtxo.Spent,
+			&i.Vtxo.Redeemed,
+			&i.Vtxo.Swept,
+			&i.Vtxo.ExpireAt,
+			&i.Vtxo.CreatedAt,
+			&i.Vtxo.RequestID,
+			&i.Vtxo.RedeemTx,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectNotesAmountByRoundTxid = `-- name: SelectNotesAmountByRoundTxid :one
+SELECT CAST(COALESCE(SUM(value), 0) AS INTEGER) AS amount FROM note WHERE round_txid = ?
+`
+
+func (q *Queries) SelectNotesAmountByRoundTxid(ctx context.Context, roundTxid string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectNotesAmountByRoundTxid, roundTxid)
+	var amount int64
+	err := row.Scan(&amount)
+	return amount, err
+}
+
+const selectPendingForfeitFeeRate = `-- name: SelectPendingForfeitFeeRate :one
+SELECT fee_rate FROM pending_forfeit_tx WHERE round_id = ? LIMIT 1
+`
+
+func (q *Queries) SelectPendingForfeitFeeRate(ctx context.Context, roundID string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, selectPendingForfeitFeeRate, roundID)
+	var fee_rate int64
+	err := row.Scan(&fee_rate)
+	return fee_rate, err
+}
+
+const selectPendingForfeitRoundIds = `-- name: SelectPendingForfeitRoundIds :many
+SELECT DISTINCT round_id FROM pending_forfeit_tx
+`
+
+func (q *Queries) SelectPendingForfeitRoundIds(ctx context.Context) ([]string, error) {
+	rows, err := q.db.QueryContext(ctx, selectPendingForfeitRoundIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var round_id string
+		if err := rows.Scan(&round_id); err != nil {
+			return nil, err
+		}
+		items = append(items, round_id)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectPendingForfeitTxs = `-- name: SelectPendingForfeitTxs :many
+SELECT round_id, vtxo_txid, vtxo_vout, tx, fee_rate FROM pending_forfeit_tx WHERE round_id = ?
+`
+
+func (q *Queries) SelectPendingForfeitTxs(ctx context.Context, roundID string) ([]PendingForfeitTx, error) {
+	rows, err := q.db.QueryContext(ctx, selectPendingForfeitTxs, roundID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PendingForfeitTx
+	for rows.Next() {
+		var i PendingForfeitTx
+		if err := rows.Scan(
+			&i.RoundID,
+			&i.VtxoTxid,
+			&i.VtxoVout,
+			&i.Tx,
+			&i.FeeRate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const selectRefreshAuthorization = `-- name: SelectRefreshAuthorization :one
+SELECT vtxo_txid, vtxo_vout, intent, message, forfeit_tx, created_at, revoked_at FROM refresh_authorization WHERE vtxo_txid = ? AND vtxo_vout = ?
+`
+
+type SelectRefreshAuthorizationParams struct {
+	VtxoTxid string
+	VtxoVout int64
+}
+
+func (q *Queries) SelectRefreshAuthorization(ctx context.Context, arg SelectRefreshAuthorizationParams) (RefreshAuthorization, error) {
+	row := q.db.QueryRowContext(ctx, selectRefreshAuthorization, arg.VtxoTxid, arg.VtxoVout)
+	var i RefreshAuthorization
+	err := row.Scan(
+		&i.VtxoTxid,
+		&i.VtxoVout,
+		&i.Intent,
+		&i.Message,
+		&i.ForfeitTx,
+		&i.CreatedAt,
+		&i.RevokedAt,
+	)
+	return i, err
+}
+
 const selectRoundIds = `-- name: SelectRoundIds :many
 SELECT id FROM round
 `
@@ -716,6 +1015,92 @@ func (q *Queries) SelectRoundIdsInRange(ctx context.Context, arg SelectRoundIdsI
 	return items, nil
 }
 
+const selectRoundSummaries = `-- name: SelectRoundSummaries :many
+SELECT
+    r.txid,
+    r.starting_timestamp,
+    r.ending_timestamp,
+    (
+        SELECT COUNT(v.txid)
+        FROM vtxo v
+                 JOIN tx_request req ON req.id = v.request_id
+        WHERE req.round_id = r.id
+    ) AS total_input_vtxos,
+    (
+        SELECT COUNT(*)
+        FROM tx t
+        WHERE t.round_id = r.id
+          AND t.type = 'tree'
+          AND t.is_leaf = 1
+    ) AS total_output_vtxos,
+    (
+        SELECT COALESCE(SUM(amount), 0)
+        FROM (
+            SELECT DISTINCT rr.*
+            FROM receiver rr
+                JOIN tx_request req2 ON req2.id = rr.request_id
+            WHERE req2.round_id = r.id
+            AND (rr.onchain_address = '' OR rr.onchain_address IS NULL)
+        ) AS tx_req_outputs_amount
+    ) AS total_amount
+FROM round r
+WHERE r.ended = true AND r.failed = false
+    AND (r.ending_timestamp > ? OR (r.ending_timestamp = ? AND r.txid > ?))
+ORDER BY r.ending_timestamp, r.txid
+LIMIT ?
+`
+
+type SelectRoundSummariesParams struct {
+	EndingTimestamp   int64
+	EndingTimestamp_2 int64
+	Txid              string
+	Limit             int64
+}
+
+type SelectRoundSummariesRow struct {
+	Txid              string
+	StartingTimestamp int64
+	EndingTimestamp   int64
+	TotalInputVtxos   int64
+	TotalOutputVtxos  int64
+	TotalAmount       interface{}
+}
+
+func (q *Queries) SelectRoundSummaries(ctx context.Context, arg SelectRoundSummariesParams) ([]SelectRoundSummariesRow, error) {
+	rows, err := q.db.QueryContext(ctx, selectRoundSummaries,
+		arg.EndingTimestamp,
+		arg.EndingTimestamp_2,
+		arg.Txid,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelectRoundSummariesRow
+	for rows.Next() {
+		var i SelectRoundSummariesRow
+		if err := rows.Scan(
+			&i.Txid,
+			&i.StartingTimestamp,
+			&i.EndingTimestamp,
+			&i.TotalInputVtxos,
+			&i.TotalOutputVtxos,
+			&i.TotalAmount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const selectRoundWithRoundId = `-- name: SelectRoundWithRoundId :many
 SELECT round.id, round.starting_timestamp, round.ending_timestamp, round.ended, round.failed, round.stage_code, round.txid, round.unsigned_tx, round.connector_address, round.dust_amount, round.version, round.swept,
        round_request_vw.id, round_request_vw.round_id,
@@ -1176,6 +1561,28 @@ func (q *Queries) UpdateMarketHour(ctx context.Context, arg UpdateMarketHourPara
 	return i, err
 }
 
+const updatePendingForfeitTx = `-- name: UpdatePendingForfeitTx :exec
+UPDATE pending_forfeit_tx SET tx = ?
+WHERE round_id = ? AND vtxo_txid = ? AND vtxo_vout = ?
+`
+
+type UpdatePendingForfeitTxParams struct {
+	Tx       string
+	RoundID  string
+	VtxoTxid string
+	VtxoVout int64
+}
+
+func (q *Queries) UpdatePendingForfeitTx(ctx context.Context, arg UpdatePendingForfeitTxParams) error {
+	_, err := q.db.ExecContext(ctx, updatePendingForfeitTx,
+		arg.Tx,
+		arg.RoundID,
+		arg.VtxoTxid,
+		arg.VtxoVout,
+	)
+	return err
+}
+
 const updateVtxoExpireAt = `-- name: UpdateVtxoExpireAt :exec
 UPDATE vtxo SET expire_at = ? WHERE txid = ? AND vout = ?
 `
@@ -1231,6 +1638,41 @@ func (q *Queries) UpsertReceiver(ctx context.Context, arg UpsertReceiverParams)
 	return err
 }
 
+const upsertRefreshAuthorization = `-- name: UpsertRefreshAuthorization :exec
+INSERT INTO refresh_authorization (
+    vtxo_txid, vtxo_vout, intent, message, forfeit_tx, created_at, revoked_at
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(vtxo_txid, vtxo_vout) DO UPDATE SET
+    intent = EXCLUDED.intent,
+    message = EXCLUDED.message,
+    forfeit_tx = EXCLUDED.forfeit_tx,
+    created_at = EXCLUDED.created_at,
+    revoked_at = EXCLUDED.revoked_at
+`
+
+type UpsertRefreshAuthorizationParams struct {
+	VtxoTxid  string
+	VtxoVout  int64
+	Intent    string
+	Message   string
+	ForfeitTx string
+	CreatedAt int64
+	RevokedAt int64
+}
+
+func (q *Queries) UpsertRefreshAuthorization(ctx context.Context, arg UpsertRefreshAuthorizationParams) error {
+	_, err := q.db.ExecContext(ctx, upsertRefreshAuthorization,
+		arg.VtxoTxid,
+		arg.VtxoVout,
+		arg.Intent,
+		arg.Message,
+		arg.ForfeitTx,
+		arg.CreatedAt,
+		arg.RevokedAt,
+	)
+	return err
+}
+
 const upsertRound = `-- name: UpsertRound :exec
 INSERT INTO round (
     id,
@@ -141,6 +141,40 @@ SELECT
 FROM round r
 WHERE r.txid = ?;
 
+-- name: SelectRoundSummaries :many
+SELECT
+    r.txid,
+    r.starting_timestamp,
+    r.ending_timestamp,
+    (
+        SELECT COUNT(v.txid)
+        FROM vtxo v
+                 JOIN tx_request req ON req.id = v.request_id
+        WHERE req.round_id = r.id
+    ) AS total_input_vtxos,
+    (
+        SELECT COUNT(*)
+        FROM tx t
+        WHERE t.round_id = r.id
+          AND t.type = 'tree'
+          AND t.is_leaf = 1
+    ) AS total_output_vtxos,
+    (
+        SELECT COALESCE(SUM(amount), 0)
+        FROM (
+            SELECT DISTINCT rr.*
+            FROM receiver rr
+                JOIN tx_request req2 ON req2.id = rr.request_id
+            WHERE req2.round_id = r.id
+            AND (rr.onchain_address = '' OR rr.onchain_address IS NULL)
+        ) AS tx_req_outputs_amount
+    ) AS total_amount
+FROM round r
+WHERE r.ended = true AND r.failed = false
+    AND (r.ending_timestamp > ? OR (r.ending_timestamp = ? AND r.txid > ?))
+ORDER BY r.ending_timestamp, r.txid
+LIMIT ?;
+
 -- name: GetRoundForfeitTxs :many
 SELECT tx.* FROM round
 LEFT OUTER JOIN tx ON round.id=tx.round_id
@@ -191,6 +225,10 @@ WHERE redeemed = false;
 SELECT sqlc.embed(vtxo) FROM vtxo
 WHERE redeemed = false AND pubkey = ?;
 
+-- name: SelectNotRedeemedVtxosWithPubkeys :many
+SELECT sqlc.embed(vtxo) FROM vtxo
+WHERE redeemed = false AND pubkey IN (sqlc.slice('pubkeys'));
+
 -- name: SelectVtxoByOutpoint :one
 SELECT sqlc.embed(vtxo) FROM vtxo
 WHERE txid = ? AND vout = ?;
@@ -215,11 +253,20 @@ UPDATE vtxo SET spent = true, spent_by = ? WHERE txid = ? AND vout = ?;
 UPDATE vtxo SET expire_at = ? WHERE txid = ? AND vout = ?;
 
 -- name: InsertNote :exec
-INSERT INTO note (id) VALUES (?);
+INSERT INTO note (id, value, round_txid, expires_at) VALUES (?, ?, ?, ?);
+
+-- name: InsertNoteIfNotExists :execrows
+INSERT OR IGNORE INTO note (id, value, round_txid, expires_at) VALUES (?, ?, ?, ?);
 
 -- name: ContainsNote :one
 SELECT EXISTS(SELECT 1 FROM note WHERE id = ?);
 
+-- name: SelectNotesAmountByRoundTxid :one
+SELECT CAST(COALESCE(SUM(value), 0) AS INTEGER) AS amount FROM note WHERE round_txid = ?;
+
+-- name: DeleteExpiredNotes :execrows
+DELETE FROM note WHERE expires_at > 0 AND expires_at < ?;
+
 -- name: InsertMarketHour :one
 INSERT INTO market_hour (
     start_time,
@@ -256,4 +303,47 @@ SELECT txid FROM round WHERE txid IN (sqlc.slice('txids'));
 
 -- name: SelectLeafVtxosByRoundTxid :many
 SELECT sqlc.embed(vtxo) FROM vtxo
-WHERE round_tx = ? AND (redeem_tx IS NULL or redeem_tx = '');
\ No newline at end of file
+WHERE round_tx = ? AND (redeem_tx IS NULL or redeem_tx = '');
+
+-- name: InsertPendingForfeitTx :exec
+INSERT INTO pending_forfeit_tx (round_id, vtxo_txid, vtxo_vout, fee_rate)
+VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING;
+
+-- name: UpdatePendingForfeitTx :exec
+UPDATE pending_forfeit_tx SET tx = ?
+WHERE round_id = ? AND vtxo_txid = ? AND vtxo_vout = ?;
+
+-- name: SelectPendingForfeitTxs :many
+SELECT * FROM pending_forfeit_tx WHERE round_id = ?;
+
+-- name: SelectPendingForfeitFeeRate :one
+SELECT fee_rate FROM pending_forfeit_tx WHERE round_id = ? LIMIT 1;
+
+-- name: SelectPendingForfeitRoundIds :many
+SELECT DISTINCT round_id FROM pending_forfeit_tx;
+
+-- name: DeleteRoundPendingForfeitTxs :exec
+DELETE FROM pending_forfeit_tx WHERE round_id = ?;
+
+-- name: DeletePendingForfeitTxs :exec
+DELETE FROM pending_forfeit_tx;
+
+-- name: UpsertRefreshAuthorization :exec
+INSERT INTO refresh_authorization (
+    vtxo_txid, vtxo_vout, intent, message, forfeit_tx, created_at, revoked_at
+) VALUES (?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT(vtxo_txid, vtxo_vout) DO UPDATE SET
+    intent = EXCLUDED.intent,
+    message = EXCLUDED.message,
+    forfeit_tx = EXCLUDED.forfeit_tx,
+    created_at = EXCLUDED.created_at,
+    revoked_at = EXCLUDED.revoked_at;
+
+-- name: SelectRefreshAuthorization :one
+SELECT * FROM refresh_authorization WHERE vtxo_txid = ? AND vtxo_vout = ?;
+
+-- name: SelectAllRefreshAuthorizations :many
+SELECT * FROM refresh_authorization;
+
+-- name: DeleteRefreshAuthorization :exec
+DELETE FROM refresh_authorization WHERE vtxo_txid = ? AND vtxo_vout = ?;
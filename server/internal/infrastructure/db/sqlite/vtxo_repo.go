@@ -3,6 +3,7 @@ package sqlitedb
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"sort"
 
@@ -36,30 +37,7 @@ func (v *vtxoRepository) Close() {
 
 func (v *vtxoRepository) AddVtxos(ctx context.Context, vtxos []domain.Vtxo) error {
 	txBody := func(querierWithTx *queries.Queries) error {
-		for i := range vtxos {
-			vtxo := vtxos[i]
-
-			if err := querierWithTx.UpsertVtxo(
-				ctx, queries.UpsertVtxoParams{
-					Txid:      vtxo.Txid,
-					Vout:      int64(vtxo.VOut),
-					Pubkey:    vtxo.PubKey,
-					Amount:    int64(vtxo.Amount),
-					RoundTx:   vtxo.RoundTxid,
-					SpentBy:   vtxo.SpentBy,
-					Spent:     vtxo.Spent,
-					Redeemed:  vtxo.Redeemed,
-					Swept:     vtxo.Swept,
-					ExpireAt:  vtxo.ExpireAt,
-					CreatedAt: vtxo.CreatedAt,
-					RedeemTx:  sql.NullString{String: vtxo.RedeemTx, Valid: true},
-				},
-			); err != nil {
-				return err
-			}
-		}
-
-		return nil
+		return upsertVtxos(ctx, querierWithTx, vtxos)
 	}
 
 	return execTx(ctx, v.db, txBody)
@@ -121,6 +99,43 @@ func (v *vtxoRepository) GetAllNonRedeemedVtxos(ctx context.Context, pubkey stri
 	return unspentVtxos, spentVtxos, nil
 }
 
+// GetAllNonRedeemedVtxosWithPubKeys fetches the vtxos of all the given pubkeys
+// with a single query so that the result is a consistent snapshot of the db.
+func (v *vtxoRepository) GetAllNonRedeemedVtxosWithPubKeys(
+	ctx context.Context, pubkeys []string,
+) ([]domain.Vtxo, []domain.Vtxo, error) {
+	if len(pubkeys) <= 0 {
+		return []domain.Vtxo{}, []domain.Vtxo{}, nil
+	}
+
+	res, err := v.querier.SelectNotRedeemedVtxosWithPubkeys(ctx, pubkeys)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows := make([]queries.Vtxo, 0, len(res))
+	for _, row := range res {
+		rows = append(rows, row.Vtxo)
+	}
+
+	vtxos, err := readRows(rows)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	unspentVtxos := make([]domain.Vtxo, 0)
+	spentVtxos := make([]domain.Vtxo, 0)
+
+	for _, vtxo := range vtxos {
+		if vtxo.Spent || vtxo.Swept {
+			spentVtxos = append(spentVtxos, vtxo)
+		} else {
+			unspentVtxos = append(unspentVtxos, vtxo)
+		}
+	}
+
+	return unspentVtxos, spentVtxos, nil
+}
+
 func (v *vtxoRepository) GetVtxos(ctx context.Context, outpoints []domain.VtxoKey) ([]domain.Vtxo, error) {
 	vtxos := make([]domain.Vtxo, 0, len(outpoints))
 	for _, o := range outpoints {
@@ -240,20 +255,39 @@ func (v *vtxoRepository) RedeemVtxos(ctx context.Context, vtxos []domain.VtxoKey
 
 func (v *vtxoRepository) SpendVtxos(ctx context.Context, vtxos []domain.VtxoKey, txid string) error {
 	txBody := func(querierWithTx *queries.Queries) error {
-		for _, vtxo := range vtxos {
-			if err := querierWithTx.MarkVtxoAsSpent(
+		return spendVtxos(ctx, querierWithTx, vtxos, txid)
+	}
+
+	return execTx(ctx, v.db, txBody)
+}
+
+func (v *vtxoRepository) ApplyVtxoStateChange(
+	ctx context.Context, spent []domain.VtxoKey, spentBy string, created []domain.Vtxo,
+) error {
+	txBody := func(querierWithTx *queries.Queries) error {
+		if err := spendVtxos(ctx, querierWithTx, spent, spentBy); err != nil {
+			return err
+		}
+		// Upserting a vtxo that is already spent would revert its state.
+		for _, vtxo := range created {
+			res, err := querierWithTx.SelectVtxoByOutpoint(
 				ctx,
-				queries.MarkVtxoAsSpentParams{
-					SpentBy: txid,
-					Txid:    vtxo.Txid,
-					Vout:    int64(vtxo.VOut),
+				queries.SelectVtxoByOutpointParams{
+					Txid: vtxo.Txid,
+					Vout: int64(vtxo.VOut),
 				},
-			); err != nil {
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					continue
+				}
 				return err
 			}
+			if res.Vtxo.Spent {
+				return fmt.Errorf("vtxo %s:%d already spent", vtxo.Txid, vtxo.VOut)
+			}
 		}
-
-		return nil
+		return upsertVtxos(ctx, querierWithTx, created)
 	}
 
 	return execTx(ctx, v.db, txBody)
@@ -383,6 +417,50 @@ func (v *vtxoRepository) GetAllVtxosWithPubKeys(
 	return allVtxos, nil
 }
 
+func upsertVtxos(ctx context.Context, querier *queries.Queries, vtxos []domain.Vtxo) error {
+	for i := range vtxos {
+		vtxo := vtxos[i]
+
+		if err := querier.UpsertVtxo(
+			ctx, queries.UpsertVtxoParams{
+				Txid:      vtxo.Txid,
+				Vout:      int64(vtxo.VOut),
+				Pubkey:    vtxo.PubKey,
+				Amount:    int64(vtxo.Amount),
+				RoundTx:   vtxo.RoundTxid,
+				SpentBy:   vtxo.SpentBy,
+				Spent:     vtxo.Spent,
+				Redeemed:  vtxo.Redeemed,
+				Swept:     vtxo.Swept,
+				ExpireAt:  vtxo.ExpireAt,
+				CreatedAt: vtxo.CreatedAt,
+				RedeemTx:  sql.NullString{String: vtxo.RedeemTx, Valid: true},
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func spendVtxos(
+	ctx context.Context, querier *queries.Queries, vtxos []domain.VtxoKey, txid string,
+) error {
+	for _, vtxo := range vtxos {
+		if err := querier.MarkVtxoAsSpent(
+			ctx,
+			queries.MarkVtxoAsSpentParams{
+				SpentBy: txid,
+				Txid:    vtxo.Txid,
+				Vout:    int64(vtxo.VOut),
+			},
+		); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func rowToVtxo(row queries.Vtxo) domain.Vtxo {
 	return domain.Vtxo{
 		VtxoKey: domain.VtxoKey{
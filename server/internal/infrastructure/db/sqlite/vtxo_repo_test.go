@@ -0,0 +1,74 @@
+package sqlitedb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/golang-migrate/migrate/v4"
+	sqlitemigrate "github.com/golang-migrate/migrate/v4/database/sqlite"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyVtxoStateChange(t *testing.T) {
+	db, err := OpenDb(filepath.Join(t.TempDir(), "sqlite.db"))
+	require.NoError(t, err)
+
+	driver, err := sqlitemigrate.WithInstance(db, &sqlitemigrate.Config{})
+	require.NoError(t, err)
+	source, err := iofs.New(os.DirFS("."), "migration")
+	require.NoError(t, err)
+	m, err := migrate.NewWithInstance("iofs", source, "arkdb", driver)
+	require.NoError(t, err)
+	require.NoError(t, m.Up())
+
+	repo, err := NewVtxoRepository(db)
+	require.NoError(t, err)
+	defer repo.Close()
+
+	ctx := context.Background()
+	spent := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: "spent", VOut: 0},
+		Amount:  1000,
+	}
+	created := domain.Vtxo{
+		VtxoKey: domain.VtxoKey{Txid: "created", VOut: 0},
+		Amount:  1000,
+	}
+	require.NoError(t, repo.AddVtxos(ctx, []domain.Vtxo{spent}))
+
+	t.Run("rollback", func(t *testing.T) {
+		// the vtxo is spent but re-creating it fails before committing
+		err := repo.ApplyVtxoStateChange(
+			ctx, []domain.VtxoKey{spent.VtxoKey}, "txid", []domain.Vtxo{created, spent},
+		)
+		require.EqualError(t, err, fmt.Sprintf("vtxo %s:0 already spent", spent.Txid))
+
+		vtxos, err := repo.GetVtxos(ctx, []domain.VtxoKey{spent.VtxoKey})
+		require.NoError(t, err)
+		require.Len(t, vtxos, 1)
+		require.False(t, vtxos[0].Spent)
+		require.Empty(t, vtxos[0].SpentBy)
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		err := repo.ApplyVtxoStateChange(
+			ctx, []domain.VtxoKey{spent.VtxoKey}, "txid", []domain.Vtxo{created},
+		)
+		require.NoError(t, err)
+
+		vtxos, err := repo.GetVtxos(
+			ctx, []domain.VtxoKey{spent.VtxoKey, created.VtxoKey},
+		)
+		require.NoError(t, err)
+		require.Len(t, vtxos, 2)
+		require.True(t, vtxos[0].Spent)
+		require.Equal(t, "txid", vtxos[0].SpentBy)
+		require.Equal(t, created.VtxoKey, vtxos[1].VtxoKey)
+		require.False(t, vtxos[1].Spent)
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 )
 
 type txBuilder struct {
@@ -111,6 +112,21 @@ func (b *txBuilder) verifyTapscriptPartialSigs(ptx *psbt.Packet) (bool, string,
 				return false, txid, fmt.Errorf("condition not met for input %d", index)
 			}
 
+			for _, key := range c.PubKeys {
+				keys[hex.EncodeToString(schnorr.SerializePubKey(key))] = false
+			}
+		case *tree.HashLockMultisigClosure:
+			witness, err := tree.GetConditionWitness(input)
+			if err != nil {
+				return false, txid, err
+			}
+			if len(witness) != 1 {
+				return false, txid, fmt.Errorf("missing preimage for input %d", index)
+			}
+			if err := c.VerifyPreimage(witness[0]); err != nil {
+				return false, txid, fmt.Errorf("invalid preimage for input %d: %s", index, err)
+			}
+
 			for _, key := range c.PubKeys {
 				keys[hex.EncodeToString(schnorr.SerializePubKey(key))] = false
 			}
@@ -141,12 +157,17 @@ func (b *txBuilder) verifyTapscriptPartialSigs(ptx *psbt.Packet) (bool, string,
 			return false, txid, fmt.Errorf("invalid control block for input %d", index)
 		}
 
-		preimage, err := b.getTaprootPreimage(ptx, index, tapLeaf.Script)
-		if err != nil {
-			return false, txid, err
-		}
-
 		for _, tapScriptSig := range input.TaprootScriptSpendSig {
+			// the signatures may commit to different parts of the tx, for example
+			// a forfeit tx pre-signed with SIGHASH_ALL|ANYONECANPAY before its
+			// connector input is known
+			preimage, err := b.getTaprootPreimage(
+				ptx, index, tapLeaf.Script, tapScriptSig.SigHash,
+			)
+			if err != nil {
+				return false, txid, err
+			}
+
 			sig, err := schnorr.ParseSignature(tapScriptSig.Signature)
 			if err != nil {
 				return false, txid, err
@@ -208,7 +229,12 @@ func (b *txBuilder) FinalizeAndExtract(tx string) (string, error) {
 			}
 
 			for _, sig := range in.TaprootScriptSpendSig {
-				args[hex.EncodeToString(sig.XOnlyPubKey)] = sig.Signature
+				signature := sig.Signature
+				// a non-default sighash type is appended to the signature
+				if sig.SigHash != txscript.SigHashDefault {
+					signature = append(append([]byte{}, sig.Signature...), byte(sig.SigHash))
+				}
+				args[hex.EncodeToString(sig.XOnlyPubKey)] = signature
 			}
 
 			witness, err := closure.Witness(in.TaprootLeafScript[0].ControlBlock, args)
@@ -293,6 +319,7 @@ func (b *txBuilder) BuildSweepTx(inputs []ports.SweepInput) (txid, signedSweepTx
 func (b *txBuilder) VerifyForfeitTxs(
 	vtxos []domain.Vtxo, connectors tree.TxTree,
 	forfeitTxs []string, connectorIndex map[string]domain.Outpoint,
+	forfeitFeeRate chainfee.SatPerKVByte,
 ) (map[domain.VtxoKey]string, error) {
 	connectorsLeaves := connectors.Leaves()
 	if len(connectorsLeaves) == 0 {
@@ -309,7 +336,10 @@ func (b *txBuilder) VerifyForfeitTxs(
 		return nil, err
 	}
 
-	minRate := b.wallet.MinRelayFeeRate(context.Background())
+	feeRate := b.wallet.MinRelayFeeRate(context.Background())
+	if forfeitFeeRate > feeRate {
+		feeRate = forfeitFeeRate
+	}
 
 	blocktimestamp, err := b.wallet.GetCurrentBlockTime(context.Background())
 	if err != nil {
@@ -321,6 +351,11 @@ func (b *txBuilder) VerifyForfeitTxs(
 		return nil, err
 	}
 
+	serverPubkey, err := b.wallet.GetPubkey(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
 	validForfeitTxs := make(map[domain.VtxoKey]string)
 
 	for _, forfeitTx := range forfeitTxs {
@@ -419,21 +454,23 @@ func (b *txBuilder) VerifyForfeitTxs(
 		switch c := closure.(type) {
 		case *tree.CLTVMultisigClosure:
 			locktime = c.Locktime
-		case *tree.MultisigClosure, *tree.ConditionMultisigClosure:
+		case *tree.MultisigClosure, *tree.ConditionMultisigClosure,
+			*tree.HashLockMultisigClosure:
 		default:
 			return nil, fmt.Errorf("invalid forfeit closure script")
 		}
 
-		if locktime != 0 {
-			if !locktime.IsSeconds() {
-				if locktime > common.AbsoluteLocktime(blocktimestamp.Height) {
-					return nil, fmt.Errorf("forfeit closure is CLTV locked, %d > %d (block height)", locktime, blocktimestamp.Height)
-				}
-			} else {
-				if locktime > common.AbsoluteLocktime(blocktimestamp.Time) {
-					return nil, fmt.Errorf("forfeit closure is CLTV locked, %d > %d (block time)", locktime, blocktimestamp.Time)
-				}
-			}
+		// the forfeit closure may require other keys than the owner's one (eg. a
+		// watchtower), but it must include the server key
+		if err := tree.ValidateForfeitClosure(closure, serverPubkey); err != nil {
+			return nil, fmt.Errorf("invalid forfeit tx for vtxo %s: %s", vtxoKey, err)
+		}
+
+		if locktime.IsLocked(blocktimestamp.Height, blocktimestamp.Time) {
+			return nil, fmt.Errorf(
+				"forfeit closure is CLTV locked until %d (block height %d, block time %d)",
+				locktime, blocktimestamp.Height, blocktimestamp.Time,
+			)
 		}
 
 		ctrlBlock, err := txscript.ParseControlBlock(vtxoTapscript.ControlBlock)
@@ -442,7 +479,7 @@ func (b *txBuilder) VerifyForfeitTxs(
 		}
 
 		minFee, err := common.ComputeForfeitTxFee(
-			minRate,
+			feeRate,
 			&waddrmgr.Tapscript{
 				RevealedScript: vtxoTapscript.Script,
 				ControlBlock:   ctrlBlock,
@@ -459,13 +496,16 @@ func (b *txBuilder) VerifyForfeitTxs(
 		}
 
 		if feeAmount < uint64(minFee) {
-			return nil, fmt.Errorf("forfeit tx fee is lower than the min relay fee, %d < %d", feeAmount, minFee)
+			return nil, fmt.Errorf(
+				"forfeit tx fee is lower than the required fee at %d sats/kvB, %d < %d",
+				feeRate, feeAmount, minFee,
+			)
 		}
 
 		feeThreshold := uint64(math.Ceil(float64(minFee) * 1.05))
 
 		if feeAmount > feeThreshold {
-			return nil, fmt.Errorf("forfeit tx fee is higher than 5%% of the min relay fee, %d > %d", feeAmount, feeThreshold)
+			return nil, fmt.Errorf("forfeit tx fee is higher than 5%% of the required fee, %d > %d", feeAmount, feeThreshold)
 		}
 
 		vtxoTapKey, err := vtxo.TapKey()
@@ -503,6 +543,20 @@ func (b *txBuilder) VerifyForfeitTxs(
 			return nil, fmt.Errorf("invalid forfeit tx")
 		}
 
+		// make sure the forfeit leaf is part of the vtxo script and that only the
+		// server signature is missing, otherwise the server can't complete the
+		// forfeit tx
+		for i := range tx.Inputs {
+			tx.Inputs[i].WitnessUtxo = rebuilt.Inputs[i].WitnessUtxo
+		}
+		valid, _, err := b.verifyTapscriptPartialSigs(tx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forfeit tx for vtxo %s: %s", vtxoKey, err)
+		}
+		if !valid {
+			return nil, fmt.Errorf("invalid forfeit tx for vtxo %s: invalid signature", vtxoKey)
+		}
+
 		validForfeitTxs[vtxoKey] = forfeitTx
 	}
 
@@ -550,14 +604,15 @@ func (b *txBuilder) BuildRoundTx(
 		}
 	}
 
-	nbOfConnectors := countSpentVtxos(requests)
+	connectorsTreeParams := b.ConnectorTreeParams(int(countSpentVtxos(requests)))
+	nbOfConnectors := connectorsTreeParams.NumOfLeaves
 
 	dustAmount, err := b.wallet.GetDustAmount(context.Background())
 	if err != nil {
 		return "", nil, "", nil, err
 	}
 
-	minRelayFeeConnectorTx, err := b.minRelayFeeConnectorTx()
+	minRelayFeeConnectorTx, err := b.minRelayFeeConnectorTx(connectorsTreeParams.Radix)
 	if err != nil {
 		return "", nil, "", nil, err
 	}
@@ -597,7 +652,7 @@ func (b *txBuilder) BuildRoundTx(
 
 		cosigners := []string{hex.EncodeToString(taprootKey.SerializeCompressed())}
 
-		for i := uint64(0); i < nbOfConnectors; i++ {
+		for i := 0; i < nbOfConnectors; i++ {
 			connectorsTreeLeaves = append(connectorsTreeLeaves, tree.Leaf{
 				Amount: uint64(dustAmount),
 				Script: hex.EncodeToString(connectorPkScript),
@@ -611,6 +666,7 @@ func (b *txBuilder) BuildRoundTx(
 		connectorsTreePkScript, connectorsTreeAmount, err = tree.CraftConnectorsOutput(
 			connectorsTreeLeaves,
 			minRelayFeeConnectorTx,
+			connectorsTreeParams.Radix,
 		)
 		if err != nil {
 			return "", nil, "", nil, err
@@ -666,6 +722,7 @@ func (b *txBuilder) BuildRoundTx(
 		rootConnectorsOutpoint,
 		connectorsTreeLeaves,
 		minRelayFeeConnectorTx,
+		connectorsTreeParams.Radix,
 	)
 	if err != nil {
 		return "", nil, "", nil, err
@@ -674,6 +731,203 @@ func (b *txBuilder) BuildRoundTx(
 	return roundTx, vtxoTree, nextConnectorAddress, connectors, nil
 }
 
+// ConnectorTreeParams returns a connectors tree with one leaf per vtxo and the
+// radix that minimizes the total vsize of its txs. In case of tie, the smallest
+// radix is preferred to keep every connector tx small.
+func (b *txBuilder) ConnectorTreeParams(numOfVtxos int) ports.ConnectorTreeParams {
+	params := ports.ConnectorTreeParams{
+		NumOfLeaves: numOfVtxos,
+		Radix:       minConnectorsTreeRadix,
+	}
+	if numOfVtxos <= 1 {
+		return params
+	}
+
+	minVSize := connectorsTreeVSize(numOfVtxos, minConnectorsTreeRadix)
+	for radix := minConnectorsTreeRadix + 1; radix <= maxConnectorsTreeRadix; radix++ {
+		if vsize := connectorsTreeVSize(numOfVtxos, radix); vsize < minVSize {
+			minVSize = vsize
+			params.Radix = radix
+		}
+	}
+	return params
+}
+
+func (b *txBuilder) EstimateRoundTx(
+	serverPubkey *secp256k1.PublicKey,
+	requests []domain.TxRequest,
+	boardingInputs []ports.BoardingInput,
+	musig2Data []*tree.Musig2,
+) (*ports.RoundTxEstimation, error) {
+	ctx := context.Background()
+	estimation := &ports.RoundTxEstimation{}
+	outputs := make([]*wire.TxOut, 0)
+
+	if !isOnchainOnly(requests) {
+		receivers, err := getOutputVtxosLeaves(requests, musig2Data)
+		if err != nil {
+			return nil, err
+		}
+
+		feeAmount, err := b.minRelayFeeTreeTx()
+		if err != nil {
+			return nil, err
+		}
+
+		sweepScript, err := (&tree.CSVMultisigClosure{
+			MultisigClosure: tree.MultisigClosure{
+				PubKeys: []*secp256k1.PublicKey{serverPubkey},
+			},
+			Locktime: b.vtxoTreeExpiry,
+		}).Script()
+		if err != nil {
+			return nil, err
+		}
+
+		sweepTapscriptRoot := txscript.NewBaseTapLeaf(sweepScript).TapHash()
+
+		sharedOutputScript, sharedOutputAmount, err := tree.CraftSharedOutput(
+			receivers, feeAmount, sweepTapscriptRoot[:],
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, &wire.TxOut{
+			Value:    sharedOutputAmount,
+			PkScript: sharedOutputScript,
+		})
+
+		estimation.VtxoTreeFee = uint64(sharedOutputAmount)
+		for _, receiver := range receivers {
+			estimation.VtxoTreeFee -= receiver.Amount
+		}
+	}
+
+	connectorsTreeParams := b.ConnectorTreeParams(int(countSpentVtxos(requests)))
+	if nbOfConnectors := connectorsTreeParams.NumOfLeaves; nbOfConnectors > 0 {
+		dustAmount, err := b.wallet.GetDustAmount(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		minRelayFeeConnectorTx, err := b.minRelayFeeConnectorTx(connectorsTreeParams.Radix)
+		if err != nil {
+			return nil, err
+		}
+
+		// no connector address is derived, any taproot script results in the
+		// same sizes and amounts
+		connectorPkScript, err := taprootOutputScript(serverPubkey)
+		if err != nil {
+			return nil, err
+		}
+		cosigners := []string{hex.EncodeToString(serverPubkey.SerializeCompressed())}
+
+		connectorsTreeLeaves := make([]tree.Leaf, 0, nbOfConnectors)
+		for i := 0; i < nbOfConnectors; i++ {
+			connectorsTreeLeaves = append(connectorsTreeLeaves, tree.Leaf{
+				Amount: dustAmount,
+				Script: hex.EncodeToString(connectorPkScript),
+				Musig2Data: &tree.Musig2{
+					CosignersPublicKeys: cosigners,
+					SigningType:         tree.SignBranch,
+				},
+			})
+		}
+
+		connectorsTreePkScript, connectorsTreeAmount, err := tree.CraftConnectorsOutput(
+			connectorsTreeLeaves, minRelayFeeConnectorTx, connectorsTreeParams.Radix,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		outputs = append(outputs, &wire.TxOut{
+			Value:    connectorsTreeAmount,
+			PkScript: connectorsTreePkScript,
+		})
+
+		connectors, err := tree.BuildConnectorsTree(
+			&wire.OutPoint{}, connectorsTreeLeaves, minRelayFeeConnectorTx,
+			connectorsTreeParams.Radix,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		numOfConnectorTxs := uint64(0)
+		for _, level := range connectors {
+			numOfConnectorTxs += uint64(len(level))
+		}
+		estimation.ConnectorTxsVSize = numOfConnectorTxs *
+			uint64(common.ConnectorTxVSize(connectorsTreeParams.Radix))
+		estimation.ConnectorTxsFee = uint64(connectorsTreeAmount) - uint64(nbOfConnectors)*dustAmount
+	}
+
+	onchainOutputs, err := getOnchainOutputs(requests, b.onchainNetwork())
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, onchainOutputs...)
+
+	for _, output := range outputs {
+		estimation.TotalOutputAmount += uint64(output.Value)
+	}
+
+	// the round tx is funded with a single wallet input and has a change output
+	walletScript, err := txscript.NewScriptBuilder().
+		AddOp(txscript.OP_0).AddData(make([]byte, 20)).Script()
+	if err != nil {
+		return nil, err
+	}
+	outputs = append(outputs, &wire.TxOut{PkScript: walletScript})
+
+	ins := []*wire.OutPoint{{}}
+	nSequences := []uint32{wire.MaxTxInSequenceNum}
+	prevouts := []*wire.TxOut{{PkScript: walletScript}}
+	tapLeaves := []*psbt.TaprootTapLeafScript{nil}
+	for _, boardingInput := range boardingInputs {
+		prevout, tapLeaf, err := getBoardingPrevout(boardingInput)
+		if err != nil {
+			return nil, err
+		}
+
+		ins = append(ins, &wire.OutPoint{})
+		nSequences = append(nSequences, wire.MaxTxInSequenceNum)
+		prevouts = append(prevouts, prevout)
+		tapLeaves = append(tapLeaves, tapLeaf)
+	}
+
+	ptx, err := psbt.New(ins, outputs, 2, 0, nSequences)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ptx.Inputs {
+		ptx.Inputs[i].WitnessUtxo = prevouts[i]
+		if tapLeaves[i] != nil {
+			ptx.Inputs[i].TaprootLeafScript = []*psbt.TaprootTapLeafScript{tapLeaves[i]}
+		}
+	}
+
+	b64, err := ptx.B64Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	estimation.RoundTxFee, err = b.wallet.EstimateFees(ctx, b64)
+	if err != nil {
+		return nil, err
+	}
+
+	estimation.RoundTxVSize, err = estimateVSize(ptx)
+	if err != nil {
+		return nil, err
+	}
+
+	return estimation, nil
+}
+
 func (b *txBuilder) GetSweepInput(node tree.Node) (vtxoTreeExpiry *common.RelativeLocktime, sweepInput ports.SweepInput, err error) {
 	partialTx, err := psbt.NewFromRawBytes(strings.NewReader(node.Tx), true)
 	if err != nil {
@@ -885,35 +1139,13 @@ func (b *txBuilder) createRoundTx(
 		})
 		nSequences = append(nSequences, wire.MaxTxInSequenceNum)
 
-		boardingVtxoScript, err := tree.ParseVtxoScript(boardingInput.Tapscripts)
-		if err != nil {
-			return nil, err
-		}
-
-		boardingTapKey, boardingTapTree, err := boardingVtxoScript.TapTree()
-		if err != nil {
-			return nil, err
-		}
-
-		boardingOutputScript, err := common.P2TRScript(boardingTapKey)
+		prevout, tapLeaf, err := getBoardingPrevout(boardingInput)
 		if err != nil {
 			return nil, err
 		}
 
-		witnessUtxos[nextIndex] = &wire.TxOut{
-			Value:    int64(boardingInput.Amount),
-			PkScript: boardingOutputScript,
-		}
-
-		biggestProof, err := common.BiggestLeafMerkleProof(boardingTapTree)
-		if err != nil {
-			return nil, err
-		}
-
-		tapLeaves[nextIndex] = &psbt.TaprootTapLeafScript{
-			Script:       biggestProof.Script,
-			ControlBlock: biggestProof.ControlBlock,
-		}
+		witnessUtxos[nextIndex] = prevout
+		tapLeaves[nextIndex] = tapLeaf
 
 		nextIndex++
 	}
@@ -1044,8 +1276,10 @@ func (b *txBuilder) createRoundTx(
 	return ptx, nil
 }
 
-func (b *txBuilder) minRelayFeeConnectorTx() (uint64, error) {
-	return b.wallet.MinRelayFee(context.Background(), uint64(common.ConnectorTxSize))
+// minRelayFeeConnectorTx returns the fee paid by every tx of a connectors tree
+// with the given radix, enough for the biggest one.
+func (b *txBuilder) minRelayFeeConnectorTx(radix int) (uint64, error) {
+	return b.wallet.MinRelayFee(context.Background(), uint64(common.ConnectorTxVSize(radix)))
 }
 
 func (b *txBuilder) CountSignedTaprootInputs(tx string) (int, error) {
@@ -1089,7 +1323,9 @@ func (b *txBuilder) VerifyAndCombinePartialTx(dest string, src string) (string,
 			}
 
 			partialSig := sourceInput.TaprootScriptSpendSig[0]
-			preimage, err := b.getTaprootPreimage(sourceTx, i, sourceInput.TaprootLeafScript[0].Script)
+			preimage, err := b.getTaprootPreimage(
+				sourceTx, i, sourceInput.TaprootLeafScript[0].Script, partialSig.SigHash,
+			)
 			if err != nil {
 				return "", err
 			}
@@ -1167,7 +1403,9 @@ func (b *txBuilder) selectUtxos(
 	return append(selectedConnectorsUtxos, utxos...), change, nil
 }
 
-func (b *txBuilder) getTaprootPreimage(partial *psbt.Packet, inputIndex int, leafScript []byte) ([]byte, error) {
+func (b *txBuilder) getTaprootPreimage(
+	partial *psbt.Packet, inputIndex int, leafScript []byte, sigHashType txscript.SigHashType,
+) ([]byte, error) {
 	prevouts := make(map[wire.OutPoint]*wire.TxOut)
 
 	for i, input := range partial.Inputs {
@@ -1183,7 +1421,7 @@ func (b *txBuilder) getTaprootPreimage(partial *psbt.Packet, inputIndex int, lea
 
 	return txscript.CalcTapscriptSignaturehash(
 		txscript.NewTxSigHashes(partial.UnsignedTx, prevoutFetcher),
-		txscript.SigHashDefault,
+		sigHashType,
 		partial.UnsignedTx,
 		inputIndex,
 		prevoutFetcher,
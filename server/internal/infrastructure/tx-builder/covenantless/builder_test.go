@@ -1,10 +1,12 @@
 package txbuilder_test
 
 import (
+	"bytes"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/ark-network/ark/common"
@@ -12,7 +14,16 @@ import (
 	"github.com/ark-network/ark/server/internal/core/domain"
 	"github.com/ark-network/ark/server/internal/core/ports"
 	txbuilder "github.com/ark-network/ark/server/internal/infrastructure/tx-builder/covenantless"
+	"github.com/btcsuite/btcd/btcec/v2/schnorr"
+	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
@@ -126,6 +137,352 @@ func TestBuildRoundTx(t *testing.T) {
 	}
 }
 
+func TestEstimateRoundTx(t *testing.T) {
+	// the estimation must not derive addresses nor select utxos, any call to
+	// the methods not mocked here makes the test fail
+	estimationWallet := &mockedWallet{}
+	estimationWallet.On("EstimateFees", mock.Anything, mock.Anything).
+		Return(uint64(100), nil)
+	estimationWallet.On("MinRelayFee", mock.Anything, mock.Anything).
+		Return(uint64(30), nil)
+	estimationWallet.On("GetDustAmount", mock.Anything).
+		Return(uint64(1000), nil)
+
+	estimationBuilder := txbuilder.NewTxBuilder(
+		estimationWallet, common.Bitcoin, vtxoTreeExpiry, boardingExitDelay,
+	)
+	builder := txbuilder.NewTxBuilder(
+		wallet, common.Bitcoin, vtxoTreeExpiry, boardingExitDelay,
+	)
+
+	fixtures, err := parseRoundTxFixtures()
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+
+	for _, f := range fixtures.Valid {
+		musig2Data := make([]*tree.Musig2, 0)
+		for range f.Requests {
+			randKey, err := secp256k1.GeneratePrivateKey()
+			require.NoError(t, err)
+
+			musig2Data = append(musig2Data, &tree.Musig2{
+				CosignersPublicKeys: []string{
+					hex.EncodeToString(randKey.PubKey().SerializeCompressed()),
+				},
+				SigningType: 0,
+			})
+		}
+
+		estimation, err := estimationBuilder.EstimateRoundTx(
+			pubkey, f.Requests, []ports.BoardingInput{}, musig2Data,
+		)
+		require.NoError(t, err)
+		require.NotNil(t, estimation)
+		require.Equal(t, uint64(100), estimation.RoundTxFee)
+		require.NotZero(t, estimation.RoundTxVSize)
+		require.NotZero(t, estimation.ConnectorTxsVSize)
+		require.NotZero(t, estimation.VtxoTreeFee)
+		require.Equal(
+			t,
+			estimation.RoundTxFee+estimation.ConnectorTxsFee+estimation.VtxoTreeFee,
+			estimation.TotalFee(),
+		)
+
+		// the projected outputs match those of the actual round tx, change excluded
+		roundTx, _, _, _, err := builder.BuildRoundTx(
+			pubkey, f.Requests, []ports.BoardingInput{}, []string{}, musig2Data,
+		)
+		require.NoError(t, err)
+
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(roundTx), true)
+		require.NoError(t, err)
+
+		changeAddr, err := btcutil.DecodeAddress(changeAddress, &chaincfg.RegressionNetParams)
+		require.NoError(t, err)
+		changeScript, err := txscript.PayToAddrScript(changeAddr)
+		require.NoError(t, err)
+
+		totalOutputAmount := uint64(0)
+		for _, out := range ptx.UnsignedTx.TxOut {
+			if bytes.Equal(out.PkScript, changeScript) {
+				continue
+			}
+			totalOutputAmount += uint64(out.Value)
+		}
+		require.Equal(t, totalOutputAmount, estimation.TotalOutputAmount)
+	}
+}
+
+func TestConnectorTreeParams(t *testing.T) {
+	builder := txbuilder.NewTxBuilder(
+		wallet, common.Bitcoin, vtxoTreeExpiry, boardingExitDelay,
+	)
+
+	connectorScript, err := txscript.PayToTaprootScript(pubkey)
+	require.NoError(t, err)
+	musig2Data := &tree.Musig2{
+		CosignersPublicKeys: []string{hex.EncodeToString(pubkey.SerializeCompressed())},
+		SigningType:         tree.SignBranch,
+	}
+
+	for numOfVtxos := 1; numOfVtxos <= 512; numOfVtxos++ {
+		params := builder.ConnectorTreeParams(numOfVtxos)
+		require.GreaterOrEqual(t, params.NumOfLeaves, numOfVtxos)
+		require.GreaterOrEqual(t, params.Radix, 2)
+
+		leaves := make([]tree.Leaf, 0, params.NumOfLeaves)
+		for i := 0; i < params.NumOfLeaves; i++ {
+			leaves = append(leaves, tree.Leaf{
+				Amount:     1000,
+				Script:     hex.EncodeToString(connectorScript),
+				Musig2Data: musig2Data,
+			})
+		}
+
+		connectors, err := tree.BuildConnectorsTree(
+			&wire.OutPoint{}, leaves, 30, params.Radix,
+		)
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, len(connectors.Leaves()), numOfVtxos)
+	}
+}
+
+func TestVerifyForfeitTxsWithWatchtower(t *testing.T) {
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	ownerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	watchtowerKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	forfeitWallet := &mockedWallet{}
+	forfeitWallet.On("GetForfeitAddress", mock.Anything).
+		Return(forfeitAddress, nil)
+	forfeitWallet.On("MinRelayFeeRate", mock.Anything).
+		Return(chainfee.SatPerKVByte(1000))
+	forfeitWallet.On("GetCurrentBlockTime", mock.Anything).
+		Return(&ports.BlockTimestamp{Height: 100, Time: 1700000000}, nil)
+	forfeitWallet.On("GetDustAmount", mock.Anything).
+		Return(uint64(330), nil)
+	forfeitWallet.On("GetPubkey", mock.Anything).
+		Return(serverKey.PubKey(), nil)
+
+	builder := txbuilder.NewTxBuilder(
+		forfeitWallet, common.Bitcoin, vtxoTreeExpiry, boardingExitDelay,
+	)
+
+	exitDelay := common.RelativeLocktime{Type: common.LocktimeTypeBlock, Value: 144}
+	watchtowerVtxoScript := tree.NewVtxoScriptWithForfeitCosigners(
+		ownerKey.PubKey(), serverKey.PubKey(), exitDelay, watchtowerKey.PubKey(),
+	)
+	noServerVtxoScript := &tree.TapscriptsVtxoScript{
+		Closures: []tree.Closure{
+			watchtowerVtxoScript.Closures[0],
+			&tree.MultisigClosure{
+				PubKeys: []*secp256k1.PublicKey{ownerKey.PubKey(), watchtowerKey.PubKey()},
+			},
+		},
+	}
+
+	connectorScript, err := txscript.PayToTaprootScript(serverKey.PubKey())
+	require.NoError(t, err)
+	connectorTx, err := psbt.New(
+		[]*wire.OutPoint{{Index: 0}},
+		[]*wire.TxOut{{Value: 1000, PkScript: connectorScript}},
+		2, 0, []uint32{wire.MaxTxInSequenceNum},
+	)
+	require.NoError(t, err)
+	connectorB64, err := connectorTx.B64Encode()
+	require.NoError(t, err)
+	connectorTxid := connectorTx.UnsignedTx.TxHash()
+	connectors := tree.TxTree{{{
+		Txid: connectorTxid.String(), Tx: connectorB64, Leaf: true,
+	}}}
+
+	makeForfeitTx := func(
+		t *testing.T, vtxoScript *tree.TapscriptsVtxoScript, connector wire.OutPoint,
+		sigHashType txscript.SigHashType, signers ...*secp256k1.PrivateKey,
+	) (domain.Vtxo, string, map[string]domain.Outpoint) {
+		vtxoTapKey, vtxoTapTree, err := vtxoScript.TapTree()
+		require.NoError(t, err)
+		vtxoPkScript, err := common.P2TRScript(vtxoTapKey)
+		require.NoError(t, err)
+
+		vtxoTxid := make([]byte, 32)
+		_, err = rand.Read(vtxoTxid)
+		require.NoError(t, err)
+		vtxoHash, err := chainhash.NewHash(vtxoTxid)
+		require.NoError(t, err)
+
+		vtxo := domain.Vtxo{
+			VtxoKey: domain.VtxoKey{Txid: vtxoHash.String(), VOut: 0},
+			Amount:  10000,
+			PubKey:  hex.EncodeToString(schnorr.SerializePubKey(vtxoTapKey)),
+		}
+
+		forfeitClosure := vtxoScript.ForfeitClosures()[0]
+		forfeitScript, err := forfeitClosure.Script()
+		require.NoError(t, err)
+		forfeitLeaf := txscript.NewBaseTapLeaf(forfeitScript)
+		leafProof, err := vtxoTapTree.GetTaprootMerkleProof(forfeitLeaf.TapHash())
+		require.NoError(t, err)
+		ctrlBlock, err := txscript.ParseControlBlock(leafProof.ControlBlock)
+		require.NoError(t, err)
+
+		forfeitAddr, err := btcutil.DecodeAddress(forfeitAddress, nil)
+		require.NoError(t, err)
+		forfeitPkScript, err := txscript.PayToAddrScript(forfeitAddr)
+		require.NoError(t, err)
+
+		feeAmount, err := common.ComputeForfeitTxFee(
+			chainfee.SatPerKVByte(1000),
+			&waddrmgr.Tapscript{RevealedScript: leafProof.Script, ControlBlock: ctrlBlock},
+			forfeitClosure.WitnessSize(),
+			txscript.GetScriptClass(forfeitPkScript),
+		)
+		require.NoError(t, err)
+
+		forfeitTx, err := tree.BuildForfeitTx(
+			&connector,
+			&wire.OutPoint{Hash: *vtxoHash, Index: 0},
+			vtxo.Amount, 1000, feeAmount,
+			vtxoPkScript, connectorScript, forfeitPkScript, 0,
+		)
+		require.NoError(t, err)
+		forfeitTx.Inputs[1].TaprootLeafScript = []*psbt.TaprootTapLeafScript{{
+			ControlBlock: leafProof.ControlBlock,
+			Script:       leafProof.Script,
+			LeafVersion:  txscript.BaseLeafVersion,
+		}}
+
+		prevoutFetcher := txscript.NewMultiPrevOutFetcher(map[wire.OutPoint]*wire.TxOut{
+			forfeitTx.UnsignedTx.TxIn[0].PreviousOutPoint: forfeitTx.Inputs[0].WitnessUtxo,
+			forfeitTx.UnsignedTx.TxIn[1].PreviousOutPoint: forfeitTx.Inputs[1].WitnessUtxo,
+		})
+		sighash, err := txscript.CalcTapscriptSignaturehash(
+			txscript.NewTxSigHashes(forfeitTx.UnsignedTx, prevoutFetcher),
+			sigHashType, forfeitTx.UnsignedTx, 1, prevoutFetcher, forfeitLeaf,
+		)
+		require.NoError(t, err)
+
+		leafHash := forfeitLeaf.TapHash()
+		for _, signer := range signers {
+			sig, err := schnorr.Sign(signer, sighash)
+			require.NoError(t, err)
+			forfeitTx.Inputs[1].TaprootScriptSpendSig = append(
+				forfeitTx.Inputs[1].TaprootScriptSpendSig, &psbt.TaprootScriptSpendSig{
+					XOnlyPubKey: schnorr.SerializePubKey(signer.PubKey()),
+					LeafHash:    leafHash[:],
+					Signature:   sig.Serialize(),
+					SigHash:     sigHashType,
+				},
+			)
+		}
+
+		b64, err := forfeitTx.B64Encode()
+		require.NoError(t, err)
+
+		connectorIndex := map[string]domain.Outpoint{
+			vtxo.VtxoKey.String(): {Txid: connectorTxid.String(), VOut: 0},
+		}
+		return vtxo, b64, connectorIndex
+	}
+	connector := wire.OutPoint{Hash: connectorTxid, Index: 0}
+
+	// setConnector replaces the connector input of the given forfeit tx
+	setConnector := func(
+		t *testing.T, forfeitTx string, connector wire.OutPoint,
+	) string {
+		ptx, err := psbt.NewFromRawBytes(strings.NewReader(forfeitTx), true)
+		require.NoError(t, err)
+		ptx.UnsignedTx.TxIn[0].PreviousOutPoint = connector
+		b64, err := ptx.B64Encode()
+		require.NoError(t, err)
+		return b64
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		vtxo, forfeitTx, connectorIndex := makeForfeitTx(
+			t, watchtowerVtxoScript, connector, txscript.SigHashDefault,
+			ownerKey, watchtowerKey,
+		)
+
+		validTxs, err := builder.VerifyForfeitTxs(
+			[]domain.Vtxo{vtxo}, connectors, []string{forfeitTx}, connectorIndex, 0,
+		)
+		require.NoError(t, err)
+		require.Equal(t, forfeitTx, validTxs[vtxo.VtxoKey])
+
+		// pre-signed with SIGHASH_ALL|ANYONECANPAY before the connector is known
+		placeholder := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+		vtxo, forfeitTx, connectorIndex = makeForfeitTx(
+			t, watchtowerVtxoScript, placeholder,
+			txscript.SigHashAll|txscript.SigHashAnyOneCanPay, ownerKey, watchtowerKey,
+		)
+		forfeitTx = setConnector(t, forfeitTx, connector)
+
+		validTxs, err = builder.VerifyForfeitTxs(
+			[]domain.Vtxo{vtxo}, connectors, []string{forfeitTx}, connectorIndex, 0,
+		)
+		require.NoError(t, err)
+		require.Contains(t, validTxs, vtxo.VtxoKey)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		// the watchtower signature is missing
+		vtxo, forfeitTx, connectorIndex := makeForfeitTx(
+			t, watchtowerVtxoScript, connector, txscript.SigHashDefault, ownerKey,
+		)
+		_, err := builder.VerifyForfeitTxs(
+			[]domain.Vtxo{vtxo}, connectors, []string{forfeitTx}, connectorIndex, 0,
+		)
+		require.ErrorContains(t, err, "missing 1 signatures")
+
+		// the server can't satisfy the forfeit closure
+		vtxo, forfeitTx, connectorIndex = makeForfeitTx(
+			t, noServerVtxoScript, connector, txscript.SigHashDefault,
+			ownerKey, watchtowerKey,
+		)
+		_, err = builder.VerifyForfeitTxs(
+			[]domain.Vtxo{vtxo}, connectors, []string{forfeitTx}, connectorIndex, 0,
+		)
+		require.ErrorContains(t, err, "server pubkey not found")
+
+		// the signatures commit to the connector input
+		placeholder := wire.OutPoint{Hash: chainhash.Hash{1}, Index: 0}
+		vtxo, forfeitTx, connectorIndex = makeForfeitTx(
+			t, watchtowerVtxoScript, placeholder, txscript.SigHashDefault,
+			ownerKey, watchtowerKey,
+		)
+		forfeitTx = setConnector(t, forfeitTx, connector)
+		_, err = builder.VerifyForfeitTxs(
+			[]domain.Vtxo{vtxo}, connectors, []string{forfeitTx}, connectorIndex, 0,
+		)
+		require.ErrorContains(t, err, "invalid signature")
+	})
+
+	t.Run("fee rate", func(t *testing.T) {
+		// the forfeit tx pays the fee for the min relay fee rate
+		vtxo, forfeitTx, connectorIndex := makeForfeitTx(
+			t, watchtowerVtxoScript, connector, txscript.SigHashDefault,
+			ownerKey, watchtowerKey,
+		)
+
+		// a lower fee rate than the min relay one is ignored
+		validTxs, err := builder.VerifyForfeitTxs(
+			[]domain.Vtxo{vtxo}, connectors, []string{forfeitTx}, connectorIndex, 500,
+		)
+		require.NoError(t, err)
+		require.Contains(t, validTxs, vtxo.VtxoKey)
+
+		// the fee rate went up since the forfeit tx was signed
+		_, err = builder.VerifyForfeitTxs(
+			[]domain.Vtxo{vtxo}, connectors, []string{forfeitTx}, connectorIndex, 2000,
+		)
+		require.ErrorContains(t, err, "forfeit tx fee is lower than the required fee")
+	})
+}
+
 func randomInput() []ports.TxInput {
 	txid := randomHex(32)
 	input := &mockedInput{}
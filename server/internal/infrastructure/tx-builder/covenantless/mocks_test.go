@@ -285,7 +285,13 @@ func (m *mockedWallet) MainAccountBalance(ctx context.Context) (uint64, uint64,
 }
 
 func (m *mockedWallet) GetCurrentBlockTime(ctx context.Context) (*ports.BlockTimestamp, error) {
-	panic("not implemented")
+	args := m.Called(ctx)
+
+	var res *ports.BlockTimestamp
+	if a := args.Get(0); a != nil {
+		res = a.(*ports.BlockTimestamp)
+	}
+	return res, args.Error(1)
 }
 
 func (m *mockedWallet) Withdraw(ctx context.Context, address string, amount uint64) (string, error) {
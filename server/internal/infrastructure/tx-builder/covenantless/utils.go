@@ -7,12 +7,23 @@ import (
 	"github.com/ark-network/ark/common"
 	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
 	"github.com/btcsuite/btcd/btcec/v2/schnorr"
 	"github.com/btcsuite/btcd/btcutil"
+	"github.com/btcsuite/btcd/btcutil/psbt"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lightningnetwork/lnd/input"
+)
+
+const (
+	// bounds of the radix of the connectors tree, the upper one keeps every
+	// connector tx well below the standard size limits
+	minConnectorsTreeRadix = 2
+	maxConnectorsTreeRadix = 16
 )
 
 func getOnchainOutputs(
@@ -80,6 +91,94 @@ func getOutputVtxosLeaves(
 	return leaves, nil
 }
 
+// getBoardingPrevout returns the prevout of the given boarding input along
+// with the biggest leaf of its taptree, used to estimate the fees of the tx
+// spending it.
+func getBoardingPrevout(
+	boardingInput ports.BoardingInput,
+) (*wire.TxOut, *psbt.TaprootTapLeafScript, error) {
+	boardingVtxoScript, err := tree.ParseVtxoScript(boardingInput.Tapscripts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boardingTapKey, boardingTapTree, err := boardingVtxoScript.TapTree()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	boardingOutputScript, err := common.P2TRScript(boardingTapKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	biggestProof, err := common.BiggestLeafMerkleProof(boardingTapTree)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	prevout := &wire.TxOut{
+		Value:    int64(boardingInput.Amount),
+		PkScript: boardingOutputScript,
+	}
+	tapLeaf := &psbt.TaprootTapLeafScript{
+		Script:       biggestProof.Script,
+		ControlBlock: biggestProof.ControlBlock,
+	}
+	return prevout, tapLeaf, nil
+}
+
+// estimateVSize returns the virtual size of the given tx once signed. Every
+// input must have a witness utxo, either P2WPKH or taproot, spent via the
+// given tapscript leaf, if any.
+func estimateVSize(ptx *psbt.Packet) (uint64, error) {
+	weightEstimator := &input.TxWeightEstimator{}
+
+	for _, in := range ptx.Inputs {
+		if in.WitnessUtxo == nil {
+			return 0, fmt.Errorf("missing witness utxo for input")
+		}
+
+		switch txscript.GetScriptClass(in.WitnessUtxo.PkScript) {
+		case txscript.WitnessV0PubKeyHashTy:
+			weightEstimator.AddP2WKHInput()
+		case txscript.WitnessV1TaprootTy:
+			if len(in.TaprootLeafScript) <= 0 {
+				weightEstimator.AddTaprootKeySpendInput(txscript.SigHashDefault)
+				continue
+			}
+
+			leaf := in.TaprootLeafScript[0]
+			ctrlBlock, err := txscript.ParseControlBlock(leaf.ControlBlock)
+			if err != nil {
+				return 0, err
+			}
+			weightEstimator.AddTapscriptInput(64*2, &waddrmgr.Tapscript{
+				RevealedScript: leaf.Script,
+				ControlBlock:   ctrlBlock,
+			})
+		default:
+			return 0, fmt.Errorf("unsupported input script type")
+		}
+	}
+
+	for _, out := range ptx.UnsignedTx.TxOut {
+		weightEstimator.AddOutput(out.PkScript)
+	}
+
+	return uint64(weightEstimator.VSize()), nil
+}
+
+// connectorsTreeVSize returns the sum of the vsizes of the txs of a connectors
+// tree with the given number of leaves and radix.
+func connectorsTreeVSize(numOfLeaves, radix int) int {
+	vsize := numOfLeaves * common.ConnectorTxVSize(1)
+	for _, numOfOutputs := range tree.TxTreeBranches(numOfLeaves, radix) {
+		vsize += common.ConnectorTxVSize(numOfOutputs)
+	}
+	return vsize
+}
+
 func countSpentVtxos(requests []domain.TxRequest) uint64 {
 	var sum uint64
 	for _, request := range requests {
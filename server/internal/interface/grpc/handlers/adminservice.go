@@ -2,12 +2,15 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common/note"
 	"github.com/ark-network/ark/server/internal/core/application"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -114,8 +117,20 @@ func (a *adminHandler) CreateNote(ctx context.Context, req *arkv1.CreateNoteRequ
 		return nil, status.Error(codes.InvalidArgument, "amount must be greater than 0")
 	}
 
-	notes, err := a.adminService.CreateNotes(ctx, amount, int(quantity))
+	var notBefore time.Time
+	if req.GetNotBefore() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "not before must be a valid unix timestamp")
+	}
+	if req.GetNotBefore() > 0 {
+		notBefore = time.Unix(req.GetNotBefore(), 0)
+	}
+
+	notes, err := a.adminService.CreateNotes(ctx, amount, int(quantity), notBefore)
 	if err != nil {
+		var invalidDenomination note.ErrInvalidDenomination
+		if errors.As(err, &invalidDenomination) {
+			return nil, status.Error(codes.InvalidArgument, invalidDenomination.Error())
+		}
 		return nil, err
 	}
 
@@ -179,6 +194,26 @@ func (a *adminHandler) GetTxRequestQueue(
 	return &arkv1.GetTxRequestQueueResponse{Requests: txReqsInfo(requests).toProto()}, nil
 }
 
+func (a *adminHandler) EstimateNextRound(
+	ctx context.Context, _ *arkv1.EstimateNextRoundRequest,
+) (*arkv1.EstimateNextRoundResponse, error) {
+	estimation, err := a.arkService.EstimateNextRound(ctx)
+	if err != nil {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+
+	return &arkv1.EstimateNextRoundResponse{
+		NumOfRequests:     int64(estimation.NumOfTxRequests),
+		RoundTxVsize:      estimation.RoundTxVSize,
+		RoundTxFee:        estimation.RoundTxFee,
+		ConnectorTxsVsize: estimation.ConnectorTxsVSize,
+		ConnectorTxsFee:   estimation.ConnectorTxsFee,
+		VtxoTreeFee:       estimation.VtxoTreeFee,
+		TotalFee:          estimation.TotalFee(),
+		TotalOutputAmount: estimation.TotalOutputAmount,
+	}, nil
+}
+
 func (a *adminHandler) Withdraw(ctx context.Context, req *arkv1.WithdrawRequest) (*arkv1.WithdrawResponse, error) {
 	if req.GetAmount() <= 0 {
 		return nil, status.Error(codes.InvalidArgument, "amount must be greater than 0")
@@ -195,6 +230,83 @@ func (a *adminHandler) Withdraw(ctx context.Context, req *arkv1.WithdrawRequest)
 	return &arkv1.WithdrawResponse{Txid: txid}, nil
 }
 
+func (a *adminHandler) GetAccountingReport(
+	ctx context.Context, req *arkv1.GetAccountingReportRequest,
+) (*arkv1.GetAccountingReportResponse, error) {
+	after, before := req.GetAfter(), req.GetBefore()
+	if after < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid after (must be >= 0)")
+	}
+	if before < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid before (must be >= 0)")
+	}
+	if before > 0 && after >= before {
+		return nil, status.Error(codes.InvalidArgument, "invalid range")
+	}
+
+	report, err := a.adminService.GetAccountingReport(ctx, after, before)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetAccountingReportResponse{
+		After:                   report.After,
+		Before:                  report.Before,
+		OpeningBalance:          report.OpeningBalance,
+		ClosingBalance:          report.ClosingBalance,
+		CreatedAmount:           report.CreatedAmount,
+		SpentAmount:             report.SpentAmount,
+		SweptAmount:             report.SweptAmount,
+		UnilateralExitAmount:    report.UnilateralExitAmount,
+		BoardingAmount:          report.BoardingAmount,
+		NotesAmount:             report.NotesAmount,
+		CollaborativeExitAmount: report.CollaborativeExitAmount,
+		FeesAmount:              report.FeesAmount,
+		Rounds:                  roundAccountingList(report.Rounds).toProto(),
+	}, nil
+}
+
+func (a *adminHandler) GetLiquidityStatus(
+	ctx context.Context, _ *arkv1.GetLiquidityStatusRequest,
+) (*arkv1.GetLiquidityStatusResponse, error) {
+	liquidity, err := a.arkService.GetLiquidityStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetLiquidityStatusResponse{
+		AvailableBalance:  liquidity.AvailableBalance,
+		ReservedLiquidity: liquidity.ReservedLiquidity,
+		FreeLiquidity:     liquidity.FreeLiquidity(),
+		Threshold:         liquidity.Threshold,
+		Low:               liquidity.IsLow(),
+	}, nil
+}
+
+func (a *adminHandler) ListPendingSweeps(
+	ctx context.Context, _ *arkv1.ListPendingSweepsRequest,
+) (*arkv1.ListPendingSweepsResponse, error) {
+	pendingSweeps, err := a.adminService.ListPendingSweeps(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sweeps := make([]*arkv1.PendingSweep, 0, len(pendingSweeps))
+	for _, sweep := range pendingSweeps {
+		sweeps = append(sweeps, &arkv1.PendingSweep{
+			RoundId:          sweep.RoundId,
+			RoundTxid:        sweep.RoundTxid,
+			Txid:             sweep.Txid,
+			Vout:             sweep.Vout,
+			Amount:           sweep.Amount,
+			ExpirationTime:   sweep.ExpirationTime,
+			ExpirationHeight: sweep.ExpirationHeight,
+		})
+	}
+
+	return &arkv1.ListPendingSweepsResponse{Sweeps: sweeps}, nil
+}
+
 func (a *adminHandler) DeleteTxRequests(
 	ctx context.Context, req *arkv1.DeleteTxRequestsRequest,
 ) (*arkv1.DeleteTxRequestsResponse, error) {
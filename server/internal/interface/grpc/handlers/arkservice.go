@@ -10,9 +10,11 @@ import (
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
 	"github.com/ark-network/ark/common/bip322"
 	"github.com/ark-network/ark/common/descriptor"
+	"github.com/ark-network/ark/common/note"
 	"github.com/ark-network/ark/common/tree"
 	"github.com/ark-network/ark/server/internal/core/application"
 	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/ark-network/ark/server/internal/core/ports"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
@@ -20,6 +22,20 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+const (
+	// maxRedeemTxsPerBatch is the max number of redeem txs that can be
+	// submitted with a single SubmitRedeemTxs request.
+	maxRedeemTxsPerBatch = 100
+	// defaultVtxosPageSize and maxVtxosPageSize bound the number of vtxos
+	// returned by a ListVtxosPaged request.
+	defaultVtxosPageSize = 100
+	maxVtxosPageSize     = 1000
+	// defaultRoundsPageSize and maxRoundsPageSize bound the number of rounds
+	// returned by a ListRounds request.
+	defaultRoundsPageSize = 50
+	maxRoundsPageSize     = 500
+)
+
 type service interface {
 	arkv1.ArkServiceServer
 	arkv1.ExplorerServiceServer
@@ -33,6 +49,7 @@ type handler struct {
 	eventsListenerHandler       *listenerHanlder[*arkv1.GetEventStreamResponse]
 	transactionsListenerHandler *listenerHanlder[*arkv1.GetTransactionsStreamResponse]
 	addressSubsHandler          *listenerHanlder[*arkv1.SubscribeForAddressResponse]
+	addressSubsLimiter          *subscriptionsLimiter
 
 	stopCh                  <-chan struct{}
 	stopRoundEventsCh       chan struct{}
@@ -40,13 +57,17 @@ type handler struct {
 	stopAddressEventsCh     chan struct{}
 }
 
-func NewHandler(version string, service application.Service, stopCh <-chan struct{}) service {
+func NewHandler(
+	version string, service application.Service, stopCh <-chan struct{},
+	maxSubscriptionsPerClient, maxSubscriptions int64,
+) service {
 	h := &handler{
 		version:                     version,
 		svc:                         service,
 		eventsListenerHandler:       newListenerHandler[*arkv1.GetEventStreamResponse](),
 		transactionsListenerHandler: newListenerHandler[*arkv1.GetTransactionsStreamResponse](),
 		addressSubsHandler:          newListenerHandler[*arkv1.SubscribeForAddressResponse](),
+		addressSubsLimiter:          newSubscriptionsLimiter(maxSubscriptionsPerClient, maxSubscriptions),
 		stopCh:                      stopCh,
 		stopRoundEventsCh:           make(chan struct{}, 1),
 		stopTransactionEventsCh:     make(chan struct{}, 1),
@@ -94,11 +115,14 @@ func (h *handler) GetInfo(
 			Period:        int64(info.NextMarketHour.Period.Seconds()),
 			RoundInterval: int64(info.NextMarketHour.RoundInterval.Seconds()),
 		},
-		Version:       h.version,
-		UtxoMinAmount: info.UtxoMinAmount,
-		UtxoMaxAmount: info.UtxoMaxAmount,
-		VtxoMinAmount: info.VtxoMinAmount,
-		VtxoMaxAmount: info.VtxoMaxAmount,
+		Version:                h.version,
+		UtxoMinAmount:          info.UtxoMinAmount,
+		UtxoMaxAmount:          info.UtxoMaxAmount,
+		VtxoMinAmount:          info.VtxoMinAmount,
+		VtxoMaxAmount:          info.VtxoMaxAmount,
+		SettleMinAmount:        info.SettleMinAmount,
+		SettleMaxAmount:        info.SettleMaxAmount,
+		MaxReceiversPerRequest: info.MaxReceiversPerRequest,
 	}, nil
 }
 
@@ -239,24 +263,87 @@ func (h *handler) RegisterOutputsForNextRound(
 		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
 
-	musig2Data := req.GetMusig2()
-	var musig2 *tree.Musig2
-	if musig2Data != nil {
-		signingType := tree.SignBranch
-		if musig2Data.SigningAll {
-			signingType = tree.SignAll
+	musig2 := parseMusig2(req.GetMusig2())
+
+	if err := h.svc.ClaimVtxos(ctx, req.GetRequestId(), receivers, musig2); err != nil {
+		return nil, err
+	}
+
+	return &arkv1.RegisterOutputsForNextRoundResponse{}, nil
+}
+
+func (h *handler) UpdateTxRequest(
+	ctx context.Context, req *arkv1.UpdateTxRequestRequest,
+) (*arkv1.UpdateTxRequestResponse, error) {
+	if len(req.GetRequestId()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing request id")
+	}
+	if len(req.GetOutputs()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing outputs")
+	}
+
+	receivers, err := parseReceivers(req.GetOutputs())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	musig2 := parseMusig2(req.GetMusig2())
+
+	if err := h.svc.AmendTxRequest(ctx, req.GetRequestId(), receivers, musig2); err != nil {
+		return nil, err
+	}
+
+	return &arkv1.UpdateTxRequestResponse{}, nil
+}
+
+func (h *handler) ValidateTxRequest(
+	ctx context.Context, req *arkv1.ValidateTxRequestRequest,
+) (*arkv1.ValidateTxRequestResponse, error) {
+	var inputs []ports.Input
+	if len(req.GetInputs()) > 0 {
+		var err error
+		inputs, err = parseInputs(req.GetInputs())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
-		musig2 = &tree.Musig2{
-			CosignersPublicKeys: musig2Data.GetCosignersPublicKeys(),
-			SigningType:         signingType,
+	}
+
+	var notes []note.Note
+	if len(req.GetNotes()) > 0 {
+		var err error
+		notes, err = parseNotes(req.GetNotes())
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
 		}
 	}
 
-	if err := h.svc.ClaimVtxos(ctx, req.GetRequestId(), receivers, musig2); err != nil {
+	receivers := make([]domain.Receiver, 0, len(req.GetOutputs()))
+	for _, out := range req.GetOutputs() {
+		rcv, err := parseReceiver(out)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		receivers = append(receivers, rcv)
+	}
+
+	result, err := h.svc.ValidateTxRequest(ctx, inputs, notes, receivers)
+	if err != nil {
 		return nil, err
 	}
 
-	return &arkv1.RegisterOutputsForNextRoundResponse{}, nil
+	outputs := validationResultList(result.Receivers).toProto()
+	// report the receivers with the address given by the client
+	for i, out := range req.GetOutputs() {
+		outputs[i].Id = out.GetAddress()
+	}
+
+	return &arkv1.ValidateTxRequestResponse{
+		Valid:   result.IsValid(),
+		Inputs:  validationResultList(result.Inputs).toProto(),
+		Notes:   validationResultList(result.Notes).toProto(),
+		Outputs: outputs,
+		Errors:  result.Errors,
+	}, nil
 }
 
 func (h *handler) SubmitTreeNonces(
@@ -357,11 +444,12 @@ func (h *handler) SubmitSignedForfeitTxs(
 }
 
 func (h *handler) GetEventStream(
-	_ *arkv1.GetEventStreamRequest, stream arkv1.ArkService_GetEventStreamServer,
+	req *arkv1.GetEventStreamRequest, stream arkv1.ArkService_GetEventStreamServer,
 ) error {
 	listener := &listener[*arkv1.GetEventStreamResponse]{
-		id: uuid.NewString(),
-		ch: make(chan *arkv1.GetEventStreamResponse),
+		id:        uuid.NewString(),
+		ch:        make(chan *arkv1.GetEventStreamResponse),
+		requestId: req.GetRequestId(),
 	}
 
 	h.eventsListenerHandler.pushListener(listener)
@@ -404,7 +492,7 @@ func (h *handler) SubmitRedeemTx(
 	}
 
 	signedRedeemTx, redeemTxid, err := h.svc.SubmitRedeemTx(
-		ctx, req.GetRedeemTx(),
+		ctx, req.GetRedeemTx(), req.GetIdempotencyKey(),
 	)
 	if err != nil {
 		return nil, err
@@ -416,10 +504,113 @@ func (h *handler) SubmitRedeemTx(
 	}, nil
 }
 
+func (h *handler) ValidateRedeemTx(
+	ctx context.Context, req *arkv1.ValidateRedeemTxRequest,
+) (*arkv1.ValidateRedeemTxResponse, error) {
+	if req.GetRedeemTx() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing redeem tx")
+	}
+
+	redeemTxid, err := h.svc.ValidateRedeemTx(ctx, req.GetRedeemTx())
+	if err != nil {
+		return &arkv1.ValidateRedeemTxResponse{Error: err.Error()}, nil
+	}
+
+	return &arkv1.ValidateRedeemTxResponse{
+		Valid: true,
+		Txid:  redeemTxid,
+	}, nil
+}
+
+func (h *handler) AuthorizeRefresh(
+	ctx context.Context, req *arkv1.AuthorizeRefreshRequest,
+) (*arkv1.AuthorizeRefreshResponse, error) {
+	bip322Signature := req.GetBip322Signature()
+	if bip322Signature == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing BIP0322 signature")
+	}
+	if req.GetForfeitTx() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing forfeit tx")
+	}
+
+	signature, err := bip322.DecodeSignature(bip322Signature.Signature)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid BIP0322 signature")
+	}
+
+	if len(bip322Signature.Message) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing message")
+	}
+
+	var message tree.IntentMessage
+	if err := message.Decode(bip322Signature.Message); err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid BIP0322 message")
+	}
+
+	if err := h.svc.AuthorizeRefresh(
+		ctx, *signature, message, req.GetForfeitTx(),
+	); err != nil {
+		return nil, err
+	}
+
+	return &arkv1.AuthorizeRefreshResponse{}, nil
+}
+
+func (h *handler) RevokeRefreshAuthorization(
+	ctx context.Context, req *arkv1.RevokeRefreshAuthorizationRequest,
+) (*arkv1.RevokeRefreshAuthorizationResponse, error) {
+	vtxo := req.GetVtxo()
+	if vtxo == nil || vtxo.GetTxid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing vtxo")
+	}
+	if req.GetSignature() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing signature")
+	}
+
+	if err := h.svc.RevokeRefreshAuthorization(
+		ctx, domain.VtxoKey{Txid: vtxo.GetTxid(), VOut: vtxo.GetVout()}, req.GetSignature(),
+	); err != nil {
+		return nil, err
+	}
+
+	return &arkv1.RevokeRefreshAuthorizationResponse{}, nil
+}
+
+func (h *handler) SubmitRedeemTxs(
+	ctx context.Context, req *arkv1.SubmitRedeemTxsRequest,
+) (*arkv1.SubmitRedeemTxsResponse, error) {
+	redeemTxs := req.GetRedeemTxs()
+	if len(redeemTxs) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing redeem txs")
+	}
+	if len(redeemTxs) > maxRedeemTxsPerBatch {
+		return nil, status.Errorf(
+			codes.InvalidArgument, "too many redeem txs, max %d", maxRedeemTxsPerBatch,
+		)
+	}
+	for _, redeemTx := range redeemTxs {
+		if redeemTx == "" {
+			return nil, status.Error(codes.InvalidArgument, "missing redeem tx")
+		}
+	}
+
+	results := h.svc.SubmitRedeemTxs(ctx, redeemTxs)
+
+	return &arkv1.SubmitRedeemTxsResponse{
+		Results: redeemTxResults(results).toProto(),
+	}, nil
+}
+
 func (h *handler) GetRound(
 	ctx context.Context, req *arkv1.GetRoundRequest,
 ) (*arkv1.GetRoundResponse, error) {
 	if len(req.GetTxid()) <= 0 {
+		if req.GetIncludeRawTx() {
+			return nil, status.Error(
+				codes.InvalidArgument, "missing txid, raw tx available only for finalized rounds",
+			)
+		}
+
 		round, err := h.svc.GetCurrentRound(ctx)
 		if err != nil {
 			return nil, err
@@ -427,14 +618,17 @@ func (h *handler) GetRound(
 
 		return &arkv1.GetRoundResponse{
 			Round: &arkv1.Round{
-				Id:         round.Id,
-				Start:      round.StartingTimestamp,
-				End:        round.EndingTimestamp,
-				RoundTx:    round.UnsignedTx,
-				VtxoTree:   vtxoTree(round.VtxoTree).toProto(),
-				ForfeitTxs: forfeitTxs(round.ForfeitTxs).toProto(),
-				Connectors: vtxoTree(round.Connectors).toProto(),
-				Stage:      stage(round.Stage).toProto(),
+				Id:                round.Id,
+				Start:             round.StartingTimestamp,
+				End:               round.EndingTimestamp,
+				RoundTx:           round.UnsignedTx,
+				VtxoTree:          vtxoTree(round.VtxoTree).toProto(),
+				ForfeitTxs:        forfeitTxs(round.ForfeitTxs).toProto(),
+				Connectors:        vtxoTree(round.Connectors).toProto(),
+				Stage:             stage(round.Stage).toProto(),
+				NumOfParticipants: int32(round.NumOfParticipants()),
+				NumOfOutputs:      int32(round.NumOfOutputs()),
+				TotalOutputAmount: round.TotalOutputAmount(),
 			},
 		}, nil
 	}
@@ -444,16 +638,28 @@ func (h *handler) GetRound(
 		return nil, err
 	}
 
+	var rawRoundTx string
+	if req.GetIncludeRawTx() {
+		rawRoundTx, err = h.svc.GetRoundTx(ctx, req.GetTxid())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &arkv1.GetRoundResponse{
+		RawRoundTx: rawRoundTx,
 		Round: &arkv1.Round{
-			Id:         round.Id,
-			Start:      round.StartingTimestamp,
-			End:        round.EndingTimestamp,
-			RoundTx:    round.UnsignedTx,
-			VtxoTree:   vtxoTree(round.VtxoTree).toProto(),
-			ForfeitTxs: forfeitTxs(round.ForfeitTxs).toProto(),
-			Connectors: vtxoTree(round.Connectors).toProto(),
-			Stage:      stage(round.Stage).toProto(),
+			Id:                round.Id,
+			Start:             round.StartingTimestamp,
+			End:               round.EndingTimestamp,
+			RoundTx:           round.UnsignedTx,
+			VtxoTree:          vtxoTree(round.VtxoTree).toProto(),
+			ForfeitTxs:        forfeitTxs(round.ForfeitTxs).toProto(),
+			Connectors:        vtxoTree(round.Connectors).toProto(),
+			Stage:             stage(round.Stage).toProto(),
+			NumOfParticipants: int32(round.NumOfParticipants()),
+			NumOfOutputs:      int32(round.NumOfOutputs()),
+			TotalOutputAmount: round.TotalOutputAmount(),
 		},
 	}, nil
 }
@@ -473,18 +679,88 @@ func (h *handler) GetRoundById(
 
 	return &arkv1.GetRoundByIdResponse{
 		Round: &arkv1.Round{
-			Id:         round.Id,
-			Start:      round.StartingTimestamp,
-			End:        round.EndingTimestamp,
-			RoundTx:    round.UnsignedTx,
-			VtxoTree:   vtxoTree(round.VtxoTree).toProto(),
-			ForfeitTxs: forfeitTxs(round.ForfeitTxs).toProto(),
-			Connectors: vtxoTree(round.Connectors).toProto(),
-			Stage:      stage(round.Stage).toProto(),
+			Id:                round.Id,
+			Start:             round.StartingTimestamp,
+			End:               round.EndingTimestamp,
+			RoundTx:           round.UnsignedTx,
+			VtxoTree:          vtxoTree(round.VtxoTree).toProto(),
+			ForfeitTxs:        forfeitTxs(round.ForfeitTxs).toProto(),
+			Connectors:        vtxoTree(round.Connectors).toProto(),
+			Stage:             stage(round.Stage).toProto(),
+			NumOfParticipants: int32(round.NumOfParticipants()),
+			NumOfOutputs:      int32(round.NumOfOutputs()),
+			TotalOutputAmount: round.TotalOutputAmount(),
 		},
 	}, nil
 }
 
+func (h *handler) GetRoundTreeBranch(
+	ctx context.Context, req *arkv1.GetRoundTreeBranchRequest,
+) (*arkv1.GetRoundTreeBranchResponse, error) {
+	if len(req.GetRoundTxid()) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing round txid")
+	}
+	vtxo := req.GetVtxo()
+	if vtxo == nil || vtxo.GetTxid() == "" {
+		return nil, status.Error(codes.InvalidArgument, "missing vtxo")
+	}
+
+	branch, err := h.svc.GetRoundTreeBranch(
+		ctx, req.GetRoundTxid(), domain.VtxoKey{Txid: vtxo.GetTxid(), VOut: vtxo.GetVout()},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.GetRoundTreeBranchResponse{
+		Branch: vtxoTree(branch).toProto(),
+	}, nil
+}
+
+func (h *handler) ListRounds(
+	ctx context.Context, req *arkv1.ListRoundsRequest,
+) (*arkv1.ListRoundsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit > maxRoundsPageSize {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("limit must be at most %d", maxRoundsPageSize),
+		)
+	}
+	if limit <= 0 {
+		limit = defaultRoundsPageSize
+	}
+	if req.GetAfter() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "invalid after timestamp")
+	}
+
+	endedAfter, afterTxid := req.GetAfter(), ""
+	if token := req.GetPageToken(); token != "" {
+		var err error
+		endedAfter, afterTxid, err = parseRoundsPageToken(token)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	// one more round is fetched to know whether there's a next page
+	rounds, err := h.svc.ListRounds(ctx, endedAfter, afterTxid, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var next string
+	if len(rounds) > limit {
+		rounds = rounds[:limit]
+		next = encodeRoundsPageToken(rounds[len(rounds)-1])
+	}
+
+	return &arkv1.ListRoundsResponse{
+		Rounds:        roundSummaryList(rounds).toProto(),
+		NextPageToken: next,
+	}, nil
+}
+
 func (h *handler) ListVtxos(
 	ctx context.Context, req *arkv1.ListVtxosRequest,
 ) (*arkv1.ListVtxosResponse, error) {
@@ -504,6 +780,75 @@ func (h *handler) ListVtxos(
 	}, nil
 }
 
+func (h *handler) ListVtxosForAddresses(
+	ctx context.Context, req *arkv1.ListVtxosForAddressesRequest,
+) (*arkv1.ListVtxosForAddressesResponse, error) {
+	addresses := req.GetAddresses()
+	if len(addresses) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing addresses")
+	}
+	for _, addr := range addresses {
+		if _, err := parseAddress(addr); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	vtxos, err := h.svc.ListVtxosForAddresses(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	return &arkv1.ListVtxosForAddressesResponse{
+		Vtxos: addressVtxosList(vtxos).toProto(),
+	}, nil
+}
+
+func (h *handler) ListVtxosPaged(
+	ctx context.Context, req *arkv1.ListVtxosPagedRequest,
+) (*arkv1.ListVtxosPagedResponse, error) {
+	addresses := req.GetAddresses()
+	if len(addresses) <= 0 {
+		return nil, status.Error(codes.InvalidArgument, "missing addresses")
+	}
+	for _, addr := range addresses {
+		if _, err := parseAddress(addr); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	pageSize := int(req.GetPageSize())
+	if pageSize > maxVtxosPageSize {
+		return nil, status.Error(
+			codes.InvalidArgument,
+			fmt.Sprintf("page size must be at most %d", maxVtxosPageSize),
+		)
+	}
+	if pageSize <= 0 {
+		pageSize = defaultVtxosPageSize
+	}
+	after, err := parsePageToken(req.GetPageToken())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	vtxos, err := h.svc.ListVtxosForAddresses(ctx, addresses)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := vtxosFilter{
+		spendable: req.GetSpendable(),
+		spent:     req.GetSpent(),
+		minAmount: req.GetMinAmount(),
+		roundTxid: req.GetRoundTxid(),
+	}
+	page, next := filter.page(vtxos, after, pageSize)
+
+	return &arkv1.ListVtxosPagedResponse{
+		Vtxos:         vtxoList(page).toProto(),
+		NextPageToken: next,
+	}, nil
+}
+
 func (h *handler) GetTransactionsStream(
 	_ *arkv1.GetTransactionsStreamRequest,
 	stream arkv1.ArkService_GetTransactionsStreamServer,
@@ -542,6 +887,12 @@ func (h *handler) SubscribeForAddress(
 		return status.Error(codes.InvalidArgument, err.Error())
 	}
 
+	clientId := clientIdFromContext(stream.Context())
+	if err := h.addressSubsLimiter.acquire(clientId); err != nil {
+		return status.Error(codes.ResourceExhausted, err.Error())
+	}
+	defer h.addressSubsLimiter.release(clientId)
+
 	listener := &listener[*arkv1.SubscribeForAddressResponse]{
 		id: fmt.Sprintf("%s:%s", uuid.NewString(), vtxoScript),
 		ch: make(chan *arkv1.SubscribeForAddressResponse),
@@ -596,21 +947,26 @@ func (h *handler) listenToEvents() {
 					},
 				},
 			}
-		case domain.RoundFinalized:
-			ev = &arkv1.GetEventStreamResponse{
-				Event: &arkv1.GetEventStreamResponse_RoundFinalized{
-					RoundFinalized: &arkv1.RoundFinalizedEvent{
-						Id:        e.Id,
-						RoundTxid: e.Txid,
+		case application.RoundFinalized:
+			// the outcome of the round is private, every listener is notified only
+			// about the one of the request it subscribed for, if any
+			for _, l := range h.eventsListenerHandler.listeners {
+				ev := &arkv1.GetEventStreamResponse{
+					Event: &arkv1.GetEventStreamResponse_RoundFinalized{
+						RoundFinalized: roundFinalizedEvent(e).toProto(l.requestId),
 					},
-				},
+				}
+				go func(l *listener[*arkv1.GetEventStreamResponse]) {
+					l.ch <- ev
+				}(l)
 			}
-		case domain.RoundFailed:
+		case application.RoundFailed:
 			ev = &arkv1.GetEventStreamResponse{
 				Event: &arkv1.GetEventStreamResponse_RoundFailed{
 					RoundFailed: &arkv1.RoundFailed{
-						Id:     e.Id,
-						Reason: e.Err,
+						Id:        e.Id,
+						Reason:    e.Err,
+						Retryable: e.Retryable,
 					},
 				},
 			}
@@ -719,6 +1075,8 @@ func (h *handler) listenToTxEvents() {
 type listener[T any] struct {
 	id string
 	ch chan T
+	// optional, the id of the tx request the listener is interested in
+	requestId string
 }
 
 type listenerHanlder[T any] struct {
@@ -1,8 +1,12 @@
 package handlers
 
 import (
+	"encoding/base64"
 	"encoding/hex"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
 	"github.com/ark-network/ark/common"
@@ -108,6 +112,21 @@ func parseReceivers(outs []*arkv1.Output) ([]domain.Receiver, error) {
 	return receivers, nil
 }
 
+func parseMusig2(musig2Data *arkv1.Musig2) *tree.Musig2 {
+	if musig2Data == nil {
+		return nil
+	}
+
+	signingType := tree.SignBranch
+	if musig2Data.GetSigningAll() {
+		signingType = tree.SignAll
+	}
+	return &tree.Musig2{
+		CosignersPublicKeys: musig2Data.GetCosignersPublicKeys(),
+		SigningType:         signingType,
+	}
+}
+
 // From app type to interface type
 
 type vtxoList []domain.Vtxo
@@ -136,6 +155,170 @@ func (v vtxoList) toProto() []*arkv1.Vtxo {
 	return list
 }
 
+// vtxosFilter selects the vtxos returned by ListVtxosPaged.
+type vtxosFilter struct {
+	// if neither spendable nor spent is set, all vtxos are selected
+	spendable bool
+	spent     bool
+	minAmount uint64
+	roundTxid string
+}
+
+func (f vtxosFilter) match(vtxo domain.Vtxo) bool {
+	if f.spendable != f.spent && vtxo.Spent != f.spent {
+		return false
+	}
+	if vtxo.Amount < f.minAmount {
+		return false
+	}
+	if f.roundTxid != "" && vtxo.RoundTxid != f.roundTxid {
+		return false
+	}
+	return true
+}
+
+// page returns, sorted by outpoint, the first pageSize vtxos matching the
+// filter that follow the given one, and the token of the next page, empty if
+// there's none.
+func (f vtxosFilter) page(
+	addrVtxos []application.AddressVtxos, after *domain.VtxoKey, pageSize int,
+) ([]domain.Vtxo, string) {
+	// an address may be listed more than once
+	selected := make(map[domain.VtxoKey]domain.Vtxo)
+	for _, v := range addrVtxos {
+		for _, list := range [][]domain.Vtxo{v.SpendableVtxos, v.SpentVtxos} {
+			for _, vtxo := range list {
+				if f.match(vtxo) {
+					selected[vtxo.VtxoKey] = vtxo
+				}
+			}
+		}
+	}
+
+	vtxos := make([]domain.Vtxo, 0, len(selected))
+	for _, vtxo := range selected {
+		if after != nil && !lessVtxoKey(*after, vtxo.VtxoKey) {
+			continue
+		}
+		vtxos = append(vtxos, vtxo)
+	}
+	sort.Slice(vtxos, func(i, j int) bool {
+		return lessVtxoKey(vtxos[i].VtxoKey, vtxos[j].VtxoKey)
+	})
+
+	if len(vtxos) <= pageSize {
+		return vtxos, ""
+	}
+	vtxos = vtxos[:pageSize]
+	return vtxos, encodePageToken(vtxos[len(vtxos)-1].VtxoKey)
+}
+
+func lessVtxoKey(a, b domain.VtxoKey) bool {
+	if a.Txid != b.Txid {
+		return a.Txid < b.Txid
+	}
+	return a.VOut < b.VOut
+}
+
+// the page token is the opaque encoding of the last vtxo of the previous page.
+func encodePageToken(key domain.VtxoKey) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key.String()))
+}
+
+func parsePageToken(token string) (*domain.VtxoKey, error) {
+	if token == "" {
+		return nil, nil
+	}
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	txid, vout, ok := strings.Cut(string(buf), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	if b, err := hex.DecodeString(txid); err != nil || len(b) != 32 {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	index, err := strconv.ParseUint(vout, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid page token")
+	}
+	return &domain.VtxoKey{Txid: txid, VOut: uint32(index)}, nil
+}
+
+// the page token of ListRounds is the opaque encoding of the ending time and
+// txid of the last round of the previous page.
+func encodeRoundsPageToken(round domain.RoundSummary) string {
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(fmt.Sprintf("%d:%s", round.EndedAt, round.Txid)),
+	)
+}
+
+func parseRoundsPageToken(token string) (int64, string, error) {
+	buf, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid page token")
+	}
+	endedAt, txid, ok := strings.Cut(string(buf), ":")
+	if !ok || txid == "" {
+		return 0, "", fmt.Errorf("invalid page token")
+	}
+	timestamp, err := strconv.ParseInt(endedAt, 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid page token")
+	}
+	return timestamp, txid, nil
+}
+
+type roundSummaryList []domain.RoundSummary
+
+func (l roundSummaryList) toProto() []*arkv1.RoundSummary {
+	list := make([]*arkv1.RoundSummary, 0, len(l))
+	for _, round := range l {
+		list = append(list, &arkv1.RoundSummary{
+			Txid:         round.Txid,
+			Start:        round.StartedAt,
+			End:          round.EndedAt,
+			InputsCount:  uint32(round.TotalInputVtxos),
+			OutputsCount: uint32(round.TotalOutputVtxos),
+			TotalAmount:  round.TotalAmount,
+		})
+	}
+	return list
+}
+
+type addressVtxosList []application.AddressVtxos
+
+func (l addressVtxosList) toProto() []*arkv1.AddressVtxos {
+	list := make([]*arkv1.AddressVtxos, 0, len(l))
+	for _, v := range l {
+		list = append(list, &arkv1.AddressVtxos{
+			Address:        v.Address,
+			SpendableVtxos: vtxoList(v.SpendableVtxos).toProto(),
+			SpentVtxos:     vtxoList(v.SpentVtxos).toProto(),
+		})
+	}
+	return list
+}
+
+type redeemTxResults []application.RedeemTxResult
+
+func (r redeemTxResults) toProto() []*arkv1.RedeemTxResult {
+	list := make([]*arkv1.RedeemTxResult, 0, len(r))
+	for _, result := range r {
+		if result.Err != nil {
+			list = append(list, &arkv1.RedeemTxResult{Error: result.Err.Error()})
+			continue
+		}
+		list = append(list, &arkv1.RedeemTxResult{
+			SignedRedeemTx: result.SignedRedeemTx,
+			Txid:           result.Txid,
+		})
+	}
+	return list
+}
+
 type vtxoKeyList []domain.VtxoKey
 
 func (v vtxoKeyList) toProto() []*arkv1.Outpoint {
@@ -229,6 +412,30 @@ func (e redeemTxEvent) toProto() *arkv1.RedeemTransaction {
 	}
 }
 
+type roundFinalizedEvent application.RoundFinalized
+
+func (e roundFinalizedEvent) toProto(requestId string) *arkv1.RoundFinalizedEvent {
+	ev := &arkv1.RoundFinalizedEvent{
+		Id:        e.Id,
+		RoundTxid: e.Txid,
+	}
+	if len(requestId) <= 0 {
+		return ev
+	}
+
+	result, ok := e.Results[requestId]
+	if !ok {
+		return ev
+	}
+	ev.Vtxos = vtxoList(result.Vtxos).toProto()
+	ev.FeeReport = &arkv1.RoundFeeReport{
+		InputAmount:  result.InputAmount,
+		OutputAmount: result.OutputAmount,
+		FeeAmount:    result.FeeAmount(),
+	}
+	return ev
+}
+
 type forfeitTxs []domain.ForfeitTx
 
 func (f forfeitTxs) toProto() []string {
@@ -295,3 +502,37 @@ func convertSatsToBTCStr(sats uint64) string {
 	btc := float64(sats) * 1e-8
 	return fmt.Sprintf("%.8f", btc)
 }
+
+type validationResultList []application.ValidationResult
+
+func (l validationResultList) toProto() []*arkv1.ValidationResult {
+	list := make([]*arkv1.ValidationResult, 0, len(l))
+	for _, r := range l {
+		list = append(list, &arkv1.ValidationResult{
+			Id:    r.Id,
+			Valid: r.IsValid(),
+			Error: r.Error,
+		})
+	}
+	return list
+}
+
+type roundAccountingList []application.RoundAccounting
+
+func (l roundAccountingList) toProto() []*arkv1.RoundAccounting {
+	list := make([]*arkv1.RoundAccounting, 0, len(l))
+	for _, round := range l {
+		list = append(list, &arkv1.RoundAccounting{
+			RoundId:                 round.RoundId,
+			Txid:                    round.Txid,
+			Timestamp:               round.Timestamp,
+			BoardingAmount:          round.BoardingAmount,
+			NotesAmount:             round.NotesAmount,
+			ForfeitedAmount:         round.ForfeitedAmount,
+			VtxosAmount:             round.VtxosAmount,
+			CollaborativeExitAmount: round.CollaborativeExitAmount,
+			FeesAmount:              round.FeesAmount,
+		})
+	}
+	return list
+}
@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/ark-network/ark/server/internal/core/application"
+	"github.com/ark-network/ark/server/internal/core/domain"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPageToken(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		keys := []domain.VtxoKey{
+			{Txid: testTxid(1), VOut: 0},
+			{Txid: testTxid(2), VOut: 7},
+			{Txid: testTxid(3), VOut: ^uint32(0)},
+		}
+		for _, key := range keys {
+			parsed, err := parsePageToken(encodePageToken(key))
+			require.NoError(t, err)
+			require.Equal(t, key, *parsed)
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		parsed, err := parsePageToken("")
+		require.NoError(t, err)
+		require.Nil(t, parsed)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		encode := func(s string) string {
+			return base64.RawURLEncoding.EncodeToString([]byte(s))
+		}
+		tokens := map[string]string{
+			"not base64":        "not a token!",
+			"missing separator": encode(testTxid(1)),
+			"missing vout":      encode(testTxid(1) + ":"),
+			"negative vout":     encode(testTxid(1) + ":-1"),
+			"vout overflow":     encode(fmt.Sprintf("%s:%d", testTxid(1), uint64(1)<<32)),
+			"invalid txid":      encode("txid:0"),
+			"short txid":        encode("aa:0"),
+		}
+		for name, token := range tokens {
+			t.Run(name, func(t *testing.T) {
+				parsed, err := parsePageToken(token)
+				require.EqualError(t, err, "invalid page token")
+				require.Nil(t, parsed)
+			})
+		}
+	})
+}
+
+func TestVtxosFilterPage(t *testing.T) {
+	vtxos := make([]domain.Vtxo, 0, 5)
+	for i := 0; i < 5; i++ {
+		vtxos = append(vtxos, domain.Vtxo{
+			VtxoKey:   domain.VtxoKey{Txid: testTxid(i / 2), VOut: uint32(i % 2)},
+			Amount:    uint64(1000 * (i + 1)),
+			RoundTxid: testTxid(100 + i%2),
+			Spent:     i == 4,
+		})
+	}
+	// the vtxos are not sorted and the same address is listed twice
+	addrVtxos := []application.AddressVtxos{
+		{Address: "addr1", SpendableVtxos: []domain.Vtxo{vtxos[3], vtxos[0]}, SpentVtxos: []domain.Vtxo{vtxos[4]}},
+		{Address: "addr2", SpendableVtxos: []domain.Vtxo{vtxos[2], vtxos[1]}},
+		{Address: "addr1", SpendableVtxos: []domain.Vtxo{vtxos[3], vtxos[0]}, SpentVtxos: []domain.Vtxo{vtxos[4]}},
+	}
+
+	t.Run("pages", func(t *testing.T) {
+		filter := vtxosFilter{}
+
+		page, next := filter.page(addrVtxos, nil, 2)
+		require.Equal(t, vtxos[:2], page)
+		require.Equal(t, encodePageToken(vtxos[1].VtxoKey), next)
+
+		after, err := parsePageToken(next)
+		require.NoError(t, err)
+		page, next = filter.page(addrVtxos, after, 2)
+		require.Equal(t, vtxos[2:4], page)
+		require.NotEmpty(t, next)
+
+		after, err = parsePageToken(next)
+		require.NoError(t, err)
+		page, next = filter.page(addrVtxos, after, 2)
+		require.Equal(t, vtxos[4:], page)
+		require.Empty(t, next)
+	})
+
+	t.Run("page boundaries", func(t *testing.T) {
+		filter := vtxosFilter{}
+
+		// a page as big as the remaining vtxos is the last one
+		page, next := filter.page(addrVtxos, nil, len(vtxos))
+		require.Equal(t, vtxos, page)
+		require.Empty(t, next)
+
+		page, next = filter.page(addrVtxos, nil, len(vtxos)-1)
+		require.Equal(t, vtxos[:len(vtxos)-1], page)
+		require.Equal(t, encodePageToken(vtxos[len(vtxos)-2].VtxoKey), next)
+
+		// nothing follows the last vtxo
+		page, next = filter.page(addrVtxos, &vtxos[len(vtxos)-1].VtxoKey, 2)
+		require.Empty(t, page)
+		require.Empty(t, next)
+
+		// the page starts right after the token, even if it doesn't refer to a listed vtxo
+		after := domain.VtxoKey{Txid: vtxos[0].Txid, VOut: 5}
+		page, _ = filter.page(addrVtxos, &after, 1)
+		require.Equal(t, vtxos[2:3], page)
+
+		page, next = filter.page(nil, nil, 2)
+		require.Empty(t, page)
+		require.Empty(t, next)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			filter   vtxosFilter
+			expected []domain.Vtxo
+		}{
+			{"all", vtxosFilter{spendable: true, spent: true}, vtxos},
+			{"spendable", vtxosFilter{spendable: true}, vtxos[:4]},
+			{"spent", vtxosFilter{spent: true}, vtxos[4:]},
+			{"min amount", vtxosFilter{minAmount: 3000}, vtxos[2:]},
+			{"round", vtxosFilter{roundTxid: testTxid(101)}, []domain.Vtxo{vtxos[1], vtxos[3]}},
+			{
+				"combined",
+				vtxosFilter{spendable: true, minAmount: 2000, roundTxid: testTxid(100)},
+				vtxos[2:3],
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				page, next := tc.filter.page(addrVtxos, nil, 10)
+				require.Equal(t, tc.expected, page)
+				require.Empty(t, next)
+			})
+		}
+	})
+}
+
+func testTxid(i int) string {
+	return fmt.Sprintf("%064x", i)
+}
@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// subscriptionsLimiter keeps track of the open address subscriptions and
+// enforces both a per-client and a global limit. A limit <= 0 means no limit.
+type subscriptionsLimiter struct {
+	lock         *sync.Mutex
+	maxPerClient int64
+	maxTotal     int64
+	total        int64
+	perClient    map[string]int64
+}
+
+func newSubscriptionsLimiter(maxPerClient, maxTotal int64) *subscriptionsLimiter {
+	return &subscriptionsLimiter{
+		lock:         &sync.Mutex{},
+		maxPerClient: maxPerClient,
+		maxTotal:     maxTotal,
+		perClient:    make(map[string]int64),
+	}
+}
+
+func (l *subscriptionsLimiter) acquire(clientId string) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.maxTotal > 0 && l.total >= l.maxTotal {
+		return fmt.Errorf(
+			"server reached the max number of subscriptions (%d)", l.maxTotal,
+		)
+	}
+	if l.maxPerClient > 0 && l.perClient[clientId] >= l.maxPerClient {
+		return fmt.Errorf(
+			"client reached the max number of subscriptions (%d)", l.maxPerClient,
+		)
+	}
+
+	l.total++
+	l.perClient[clientId]++
+	return nil
+}
+
+func (l *subscriptionsLimiter) release(clientId string) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if _, ok := l.perClient[clientId]; !ok {
+		return
+	}
+
+	l.total--
+	l.perClient[clientId]--
+	if l.perClient[clientId] <= 0 {
+		delete(l.perClient, clientId)
+	}
+}
+
+// clientIdFromContext identifies the client by its IP address. Requests
+// proxied by the local grpc gateway carry the original address in the
+// x-forwarded-for header, which is trusted only for loopback peers.
+func clientIdFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get("x-forwarded-for"); len(values) > 0 {
+				return strings.TrimSpace(strings.Split(values[0], ",")[0])
+			}
+		}
+	}
+	return host
+}
@@ -0,0 +1,198 @@
+package handlers
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	arkv1 "github.com/ark-network/ark/api-spec/protobuf/gen/ark/v1"
+	"github.com/ark-network/ark/common"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func TestSubscriptionsLimiter(t *testing.T) {
+	t.Run("per client limit", func(t *testing.T) {
+		limiter := newSubscriptionsLimiter(2, 0)
+
+		require.NoError(t, limiter.acquire("client"))
+		require.NoError(t, limiter.acquire("client"))
+		require.EqualError(
+			t, limiter.acquire("client"), "client reached the max number of subscriptions (2)",
+		)
+		// the limit doesn't affect other clients
+		require.NoError(t, limiter.acquire("other"))
+
+		limiter.release("client")
+		require.NoError(t, limiter.acquire("client"))
+	})
+
+	t.Run("global limit", func(t *testing.T) {
+		limiter := newSubscriptionsLimiter(0, 2)
+
+		require.NoError(t, limiter.acquire("client"))
+		require.NoError(t, limiter.acquire("other"))
+		require.EqualError(
+			t, limiter.acquire("another"), "server reached the max number of subscriptions (2)",
+		)
+
+		limiter.release("other")
+		require.NoError(t, limiter.acquire("another"))
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		limiter := newSubscriptionsLimiter(0, 0)
+		for i := 0; i < 100; i++ {
+			require.NoError(t, limiter.acquire("client"))
+		}
+		require.Equal(t, int64(100), limiter.total)
+	})
+
+	t.Run("release", func(t *testing.T) {
+		limiter := newSubscriptionsLimiter(1, 1)
+		require.NoError(t, limiter.acquire("client"))
+
+		// releasing the slots of an unknown client is a no-op
+		limiter.release("unknown")
+		require.Equal(t, int64(1), limiter.total)
+
+		limiter.release("client")
+		require.Zero(t, limiter.total)
+		require.Empty(t, limiter.perClient)
+
+		// releasing twice doesn't free more slots than acquired
+		limiter.release("client")
+		require.Zero(t, limiter.total)
+	})
+}
+
+func TestSubscribeForAddressLimits(t *testing.T) {
+	h := &handler{
+		addressSubsHandler:  newListenerHandler[*arkv1.SubscribeForAddressResponse](),
+		addressSubsLimiter:  newSubscriptionsLimiter(1, 2),
+		stopAddressEventsCh: make(chan struct{}, 1),
+	}
+	req := &arkv1.SubscribeForAddressRequest{Address: makeTestArkAddress(t)}
+
+	subscribe := func(ip string) (context.CancelFunc, <-chan error) {
+		stream, cancel := newMockedAddressStream(ip)
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- h.SubscribeForAddress(req, stream)
+		}()
+		return cancel, errCh
+	}
+	openSubscriptions := func() int64 {
+		h.addressSubsLimiter.lock.Lock()
+		defer h.addressSubsLimiter.lock.Unlock()
+		return h.addressSubsLimiter.total
+	}
+	requireRejected := func(t *testing.T, errCh <-chan error, msg string) {
+		select {
+		case err := <-errCh:
+			require.Equal(t, codes.ResourceExhausted, status.Code(err))
+			require.Contains(t, err.Error(), msg)
+		case <-time.After(time.Second):
+			t.Fatal("subscription over the limit was not rejected")
+		}
+	}
+
+	cancel1, errCh1 := subscribe("1.1.1.1")
+	defer cancel1()
+	require.Eventually(t, func() bool { return openSubscriptions() == 1 }, time.Second, 10*time.Millisecond)
+
+	// a client can't open more subscriptions than its limit
+	cancel, errCh := subscribe("1.1.1.1")
+	defer cancel()
+	requireRejected(t, errCh, "client reached the max number of subscriptions")
+
+	cancel2, errCh2 := subscribe("2.2.2.2")
+	defer cancel2()
+	require.Eventually(t, func() bool { return openSubscriptions() == 2 }, time.Second, 10*time.Millisecond)
+
+	// the server can't open more subscriptions than the global limit
+	cancel, errCh = subscribe("3.3.3.3")
+	defer cancel()
+	requireRejected(t, errCh, "server reached the max number of subscriptions")
+
+	// the slot is released once the client disconnects
+	cancel1()
+	select {
+	case err := <-errCh1:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not closed on disconnect")
+	}
+	require.Equal(t, int64(1), openSubscriptions())
+
+	cancel3, errCh3 := subscribe("3.3.3.3")
+	defer cancel3()
+	require.Eventually(t, func() bool { return openSubscriptions() == 2 }, time.Second, 10*time.Millisecond)
+
+	cancel2()
+	cancel3()
+	require.NoError(t, <-errCh2)
+	require.NoError(t, <-errCh3)
+	require.Zero(t, openSubscriptions())
+	require.Empty(t, h.addressSubsLimiter.perClient)
+}
+
+func TestClientIdFromContext(t *testing.T) {
+	withPeer := func(ip string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 7070},
+		})
+	}
+	forwarded := metadata.Pairs("x-forwarded-for", "5.5.5.5, 127.0.0.1")
+
+	require.Empty(t, clientIdFromContext(context.Background()))
+	require.Equal(t, "1.1.1.1", clientIdFromContext(withPeer("1.1.1.1")))
+
+	// the forwarded address is trusted only if the peer is the local gateway
+	ctx := metadata.NewIncomingContext(withPeer("127.0.0.1"), forwarded)
+	require.Equal(t, "5.5.5.5", clientIdFromContext(ctx))
+	ctx = metadata.NewIncomingContext(withPeer("1.1.1.1"), forwarded)
+	require.Equal(t, "1.1.1.1", clientIdFromContext(ctx))
+}
+
+type mockedAddressStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func newMockedAddressStream(ip string) (*mockedAddressStream, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 7070},
+	}))
+	return &mockedAddressStream{ctx: ctx}, cancel
+}
+
+func (s *mockedAddressStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockedAddressStream) Send(*arkv1.SubscribeForAddressResponse) error {
+	return nil
+}
+
+func makeTestArkAddress(t *testing.T) string {
+	serverKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+	vtxoKey, err := secp256k1.GeneratePrivateKey()
+	require.NoError(t, err)
+
+	addr := &common.Address{
+		HRP:        common.Bitcoin.Addr,
+		Server:     serverKey.PubKey(),
+		VtxoTapKey: vtxoKey.PubKey(),
+	}
+	encoded, err := addr.Encode()
+	require.NoError(t, err)
+	return encoded
+}
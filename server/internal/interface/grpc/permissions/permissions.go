@@ -122,6 +122,14 @@ func Whitelist() map[string][]bakery.Op {
 			Entity: EntityArk,
 			Action: "write",
 		}},
+		fmt.Sprintf("/%s/UpdateTxRequest", arkv1.ArkService_ServiceDesc.ServiceName): {{
+			Entity: EntityArk,
+			Action: "write",
+		}},
+		fmt.Sprintf("/%s/ValidateTxRequest", arkv1.ArkService_ServiceDesc.ServiceName): {{
+			Entity: EntityArk,
+			Action: "read",
+		}},
 		fmt.Sprintf("/%s/SubmitSignedForfeitTxs", arkv1.ArkService_ServiceDesc.ServiceName): {{
 			Entity: EntityArk,
 			Action: "write",
@@ -142,6 +150,22 @@ func Whitelist() map[string][]bakery.Op {
 			Entity: EntityArk,
 			Action: "write",
 		}},
+		fmt.Sprintf("/%s/SubmitRedeemTxs", arkv1.ArkService_ServiceDesc.ServiceName): {{
+			Entity: EntityArk,
+			Action: "write",
+		}},
+		fmt.Sprintf("/%s/ValidateRedeemTx", arkv1.ArkService_ServiceDesc.ServiceName): {{
+			Entity: EntityArk,
+			Action: "read",
+		}},
+		fmt.Sprintf("/%s/AuthorizeRefresh", arkv1.ArkService_ServiceDesc.ServiceName): {{
+			Entity: EntityArk,
+			Action: "write",
+		}},
+		fmt.Sprintf("/%s/RevokeRefreshAuthorization", arkv1.ArkService_ServiceDesc.ServiceName): {{
+			Entity: EntityArk,
+			Action: "write",
+		}},
 		fmt.Sprintf("/%s/Check", grpchealth.Health_ServiceDesc.ServiceName): {{
 			Entity: EntityHealth,
 			Action: "read",
@@ -170,10 +194,26 @@ func Whitelist() map[string][]bakery.Op {
 			Entity: EntityExplorer,
 			Action: "read",
 		}},
+		fmt.Sprintf("/%s/GetRoundTreeBranch", arkv1.ExplorerService_ServiceDesc.ServiceName): {{
+			Entity: EntityExplorer,
+			Action: "read",
+		}},
 		fmt.Sprintf("/%s/ListVtxos", arkv1.ExplorerService_ServiceDesc.ServiceName): {{
 			Entity: EntityExplorer,
 			Action: "read",
 		}},
+		fmt.Sprintf("/%s/ListVtxosForAddresses", arkv1.ExplorerService_ServiceDesc.ServiceName): {{
+			Entity: EntityExplorer,
+			Action: "read",
+		}},
+		fmt.Sprintf("/%s/ListRounds", arkv1.ExplorerService_ServiceDesc.ServiceName): {{
+			Entity: EntityExplorer,
+			Action: "read",
+		}},
+		fmt.Sprintf("/%s/ListVtxosPaged", arkv1.ExplorerService_ServiceDesc.ServiceName): {{
+			Entity: EntityExplorer,
+			Action: "read",
+		}},
 		fmt.Sprintf("/%s/SubscribeForAddress", arkv1.ExplorerService_ServiceDesc.ServiceName): {{
 			Entity: EntityExplorer,
 			Action: "read",
@@ -229,9 +269,25 @@ func AllPermissionsByMethod() map[string][]bakery.Op {
 			Entity: EntityManager,
 			Action: "read",
 		}},
+		fmt.Sprintf("/%s/EstimateNextRound", arkv1.AdminService_ServiceDesc.ServiceName): {{
+			Entity: EntityManager,
+			Action: "read",
+		}},
 		fmt.Sprintf("/%s/Withdraw", arkv1.AdminService_ServiceDesc.ServiceName): {{
 			Entity: EntityManager,
 			Action: "write",
 		}},
+		fmt.Sprintf("/%s/GetAccountingReport", arkv1.AdminService_ServiceDesc.ServiceName): {{
+			Entity: EntityManager,
+			Action: "read",
+		}},
+		fmt.Sprintf("/%s/GetLiquidityStatus", arkv1.AdminService_ServiceDesc.ServiceName): {{
+			Entity: EntityManager,
+			Action: "read",
+		}},
+		fmt.Sprintf("/%s/ListPendingSweeps", arkv1.AdminService_ServiceDesc.ServiceName): {{
+			Entity: EntityManager,
+			Action: "read",
+		}},
 	}
 }
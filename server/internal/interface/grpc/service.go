@@ -156,6 +156,13 @@ func (s *service) stop(withAppSvc bool) {
 		s.stopCh <- struct{}{}
 		appSvc, _ := s.appConfig.AppService()
 		if appSvc != nil {
+			ctx, cancel := context.WithTimeout(
+				context.Background(), s.appConfig.ShutdownTimeout,
+			)
+			if err := appSvc.Shutdown(ctx); err != nil {
+				log.WithError(err).Warn("failed to drain rounds in flight")
+			}
+			cancel()
 			appSvc.Stop()
 			log.Info("stopped app service")
 		}
@@ -200,7 +207,10 @@ func (s *service) newServer(tlsConfig *tls.Config, withAppSvc bool) error {
 			return err
 		}
 		appSvc = svc
-		appHandler := handlers.NewHandler(s.version, appSvc, s.stopCh)
+		appHandler := handlers.NewHandler(
+			s.version, appSvc, s.stopCh,
+			s.appConfig.MaxSubscriptionsPerClient, s.appConfig.MaxSubscriptions,
+		)
 		indexerSvc, err := s.appConfig.IndexerService()
 		if err != nil {
 			return err
@@ -34,12 +34,15 @@ import (
 	"github.com/btcsuite/btcd/wire"
 	"github.com/btcsuite/btcwallet/waddrmgr"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/lightningnetwork/lnd/lnwallet/chainfee"
 	"github.com/stretchr/testify/require"
 )
 
 const (
 	composePath   = "../../../docker-compose.regtest.yml"
 	redeemAddress = "bcrt1q2wrgf2hrkfegt0t97cnv4g5yvfjua9k6vua54d"
+	// min relay fee rate of the regtest server
+	redeemTxFeeRate = chainfee.SatPerKVByte(1000)
 )
 
 func TestMain(m *testing.M) {
@@ -554,7 +557,8 @@ func TestReactToRedemptionOfVtxosSpentAsync(t *testing.T) {
 		currentHeight, err := utils.GetBlockHeight()
 		require.NoError(t, err)
 
-		cltvLocktime := common.AbsoluteLocktime(currentHeight + cltvBlocks)
+		cltvLocktime, err := common.AbsoluteLocktimeAfterBlocks(currentHeight, cltvBlocks)
+		require.NoError(t, err)
 		vtxoScript := tree.TapscriptsVtxoScript{
 			Closures: []tree.Closure{
 				&tree.CLTVMultisigClosure{
@@ -647,7 +651,7 @@ func TestReactToRedemptionOfVtxosSpentAsync(t *testing.T) {
 			tapscripts = append(tapscripts, hex.EncodeToString(script))
 		}
 
-		ptx, err := tree.BuildRedeemTx(
+		ptx, err := tree.BuildRedeemTxWithChange(
 			[]common.VtxoInput{
 				{
 					Outpoint: &wire.OutPoint{
@@ -660,12 +664,7 @@ func TestReactToRedemptionOfVtxosSpentAsync(t *testing.T) {
 					RevealedTapscripts: tapscripts,
 				},
 			},
-			[]*wire.TxOut{
-				{
-					Value:    bobOutput.Value - 500,
-					PkScript: alicePkScript,
-				},
-			},
+			nil, alicePkScript, redeemTxFeeRate,
 		)
 		require.NoError(t, err)
 
@@ -1047,10 +1046,13 @@ func TestSendToCLTVMultisigClosure(t *testing.T) {
 	currentHeight, err := utils.GetBlockHeight()
 	require.NoError(t, err)
 
+	cltvLocktime, err := common.AbsoluteLocktimeAfterBlocks(currentHeight, cltvBlocks)
+	require.NoError(t, err)
+
 	vtxoScript := tree.TapscriptsVtxoScript{
 		Closures: []tree.Closure{
 			&tree.CLTVMultisigClosure{
-				Locktime: common.AbsoluteLocktime(currentHeight + cltvBlocks),
+				Locktime: cltvLocktime,
 				MultisigClosure: tree.MultisigClosure{
 					PubKeys: []*secp256k1.PublicKey{bobPubKey, aliceAddr.Server},
 				},
@@ -1137,7 +1139,7 @@ func TestSendToCLTVMultisigClosure(t *testing.T) {
 		tapscripts = append(tapscripts, hex.EncodeToString(script))
 	}
 
-	ptx, err := tree.BuildRedeemTx(
+	ptx, err := tree.BuildRedeemTxWithChange(
 		[]common.VtxoInput{
 			{
 				Outpoint: &wire.OutPoint{
@@ -1150,12 +1152,7 @@ func TestSendToCLTVMultisigClosure(t *testing.T) {
 				RevealedTapscripts: tapscripts,
 			},
 		},
-		[]*wire.TxOut{
-			{
-				Value:    bobOutput.Value - 500,
-				PkScript: alicePkScript,
-			},
-		},
+		nil, alicePkScript, redeemTxFeeRate,
 	)
 	require.NoError(t, err)
 
@@ -1176,8 +1173,18 @@ func TestSendToCLTVMultisigClosure(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	_, _, err = grpcAlice.SubmitRedeemTx(ctx, signedTx)
+	// the submission is safe to retry with an idempotency key
+	idempotencyKey := "cltv-multisig-redeem"
+	_, redeemTxid, err := grpcAlice.SubmitRedeemTx(
+		ctx, signedTx, client.WithIdempotencyKey(idempotencyKey),
+	)
 	require.NoError(t, err)
+
+	_, retriedTxid, err := grpcAlice.SubmitRedeemTx(
+		ctx, signedTx, client.WithIdempotencyKey(idempotencyKey),
+	)
+	require.NoError(t, err)
+	require.Equal(t, redeemTxid, retriedTxid)
 }
 
 func TestSendToConditionMultisigClosure(t *testing.T) {
@@ -1342,7 +1349,7 @@ func TestSendToConditionMultisigClosure(t *testing.T) {
 		tapscripts = append(tapscripts, hex.EncodeToString(script))
 	}
 
-	ptx, err := tree.BuildRedeemTx(
+	ptx, err := tree.BuildRedeemTxWithChange(
 		[]common.VtxoInput{
 			{
 				Outpoint: &wire.OutPoint{
@@ -1355,12 +1362,7 @@ func TestSendToConditionMultisigClosure(t *testing.T) {
 				RevealedTapscripts: tapscripts,
 			},
 		},
-		[]*wire.TxOut{
-			{
-				Value:    bobOutput.Value - 500,
-				PkScript: alicePkScript,
-			},
-		},
+		nil, alicePkScript, redeemTxFeeRate,
 	)
 	require.NoError(t, err)
 
@@ -1413,6 +1415,21 @@ func TestSweep(t *testing.T) {
 	require.NoError(t, json.Unmarshal([]byte(balanceStr), &balance))
 	require.Zero(t, balance.Offchain.Total) // all funds should be swept
 
+	// the swept funds are listed as recoverable
+	var recoverable struct {
+		Vtxos []struct {
+			Amount    uint64 `json:"amount"`
+			RoundTxid string `json:"round_txid"`
+		} `json:"vtxos"`
+		TotalAmount uint64 `json:"total_amount"`
+	}
+	recoverableStr, err := runArkCommand("recover", "--list")
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(recoverableStr), &recoverable))
+	require.NotEmpty(t, recoverable.Vtxos)
+	require.NotZero(t, recoverable.TotalAmount)
+	require.NotEmpty(t, recoverable.Vtxos[0].RoundTxid)
+
 	// redeem the note
 	_, err = runArkCommand("recover", "--password", utils.Password)
 	require.NoError(t, err)